@@ -0,0 +1,63 @@
+package app
+
+import (
+	"flag"
+	"fmt"
+	"log"
+
+	"github.com/nimaeskandary/go-realworld/pkg/util"
+)
+
+const (
+	ActionExportUserData string = "export-user-data"
+	ActionRestoreUser    string = "restore-user"
+	ActionRestoreArticle string = "restore-article"
+	ActionAuditLog       string = "audit-log"
+)
+
+type Args struct {
+	ConfigPath string `validate:"required"`
+	Action     string `validate:"required,oneof=export-user-data restore-user restore-article audit-log"`
+	Username   string `validate:"required_if=Action export-user-data"`
+	OutputPath string `validate:"required_if=Action export-user-data"`
+	// Id is required by the restore actions, a deleted user is looked up by id as their username is released on delete
+	Id         string `validate:"omitempty,uuid"`
+	Actor      string
+	EntityType string `validate:"omitempty,oneof=user article follow block mute"`
+	EntityId   string
+	From       string
+	To         string
+	Limit      int `validate:"min=1,max=1000"`
+	Offset     int `validate:"min=0"`
+}
+
+func ParseArgs() Args {
+	args := Args{}
+
+	flag.StringVar(&args.ConfigPath, "config-path", "", "path to the config file")
+	flag.StringVar(&args.Action, "action", "", "admin action to perform: export-user-data, restore-user, restore-article, audit-log")
+	flag.StringVar(&args.Username, "username", "", "username of the user to act on")
+	flag.StringVar(&args.OutputPath, "output-path", "", "file to write output to, e.g. the export zip archive")
+	flag.StringVar(&args.Id, "id", "", "id of the user or article to act on")
+	flag.StringVar(&args.Actor, "actor", "", "audit-log: only changes made by this user, a username or a user id")
+	flag.StringVar(&args.EntityType, "entity-type", "", "audit-log: only changes to this kind of entity: user, article, follow, block, mute")
+	flag.StringVar(&args.EntityId, "entity-id", "", "audit-log: only changes to the entity with this id, the id of a follow, block or mute is the id of the user followed, blocked or muted")
+	flag.StringVar(&args.From, "from", "", "audit-log: only changes made at or after this time, a date or an RFC3339 time")
+	flag.StringVar(&args.To, "to", "", "audit-log: only changes made before this time, a date or an RFC3339 time")
+	flag.IntVar(&args.Limit, "limit", 100, "audit-log: max number of entries to list")
+	flag.IntVar(&args.Offset, "offset", 0, "audit-log: number of entries to skip")
+
+	flag.Parse()
+
+	validator := util.NewValidator()
+	err := validator.Struct(args)
+	if err == nil && (args.Action == ActionRestoreUser || args.Action == ActionRestoreArticle) && args.Id == "" {
+		err = fmt.Errorf("-id is required for %v", args.Action)
+	}
+	if err != nil {
+		flag.Usage()
+		log.Fatalf("invalid arguments: %v", err)
+	}
+
+	return args
+}
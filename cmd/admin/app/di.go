@@ -0,0 +1,46 @@
+package app
+
+import (
+	"github.com/nimaeskandary/go-realworld/pkg/article"
+	"github.com/nimaeskandary/go-realworld/pkg/audit"
+	"github.com/nimaeskandary/go-realworld/pkg/config"
+	config_types "github.com/nimaeskandary/go-realworld/pkg/config/types"
+	"github.com/nimaeskandary/go-realworld/pkg/data_export"
+	"github.com/nimaeskandary/go-realworld/pkg/database"
+	db_types "github.com/nimaeskandary/go-realworld/pkg/database/types"
+	obs "github.com/nimaeskandary/go-realworld/pkg/observability"
+	obs_types "github.com/nimaeskandary/go-realworld/pkg/observability/types"
+	soft_delete_types "github.com/nimaeskandary/go-realworld/pkg/soft_delete/types"
+	"github.com/nimaeskandary/go-realworld/pkg/user"
+	user_types "github.com/nimaeskandary/go-realworld/pkg/user/types"
+
+	"go.uber.org/fx"
+)
+
+func ModuleList(configData []byte) []fx.Option {
+	return []fx.Option{
+		config.NewIdentitySecretParserModule(),
+		config.NewYamlConfigLoaderModule[Config](configData),
+		fx.Provide(
+			func(cfg config_types.ConfigLoader[Config]) obs_types.SlogLoggerConfig {
+				return cfg.GetConfig().Slog
+			},
+			func(cfg config_types.ConfigLoader[Config]) db_types.RealWorldAppDbConfig {
+				return cfg.GetConfig().RealWorldAppDb
+			},
+			func(cfg config_types.ConfigLoader[Config]) soft_delete_types.SoftDeleteConfig {
+				return cfg.GetConfig().SoftDelete
+			},
+			func(cfg config_types.ConfigLoader[Config]) user_types.UserConfig {
+				return cfg.GetConfig().User
+			},
+		),
+		database.NewRealworldAppDbModule[db_types.RealWorldAppDb](),
+		database.NewRealworldAppTxManagerModule(),
+		user.NewUserModule(),
+		article.NewArticleModule(),
+		audit.NewAuditModule(),
+		data_export.NewDataExportModule(),
+		obs.NewSlogLoggerModule(),
+	}
+}
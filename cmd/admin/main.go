@@ -0,0 +1,228 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/nimaeskandary/go-realworld/cmd/admin/app"
+	article_types "github.com/nimaeskandary/go-realworld/pkg/article/types"
+	audit_types "github.com/nimaeskandary/go-realworld/pkg/audit/types"
+	data_export_types "github.com/nimaeskandary/go-realworld/pkg/data_export/types"
+	user_types "github.com/nimaeskandary/go-realworld/pkg/user/types"
+	"github.com/nimaeskandary/go-realworld/pkg/util"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/samber/mo"
+)
+
+func main() {
+	ctx := context.Background()
+	cleanupManager := util.NewCleanupManager(ctx, true)
+	defer cleanupManager.Cleanup()
+
+	args := app.ParseArgs()
+
+	// setup deps
+
+	configData, err := os.ReadFile(args.ConfigPath)
+	if err != nil {
+		log.Fatalf("failed to read config file at %v: %v", args.ConfigPath, err)
+	}
+
+	var userService user_types.UserService
+	var articleService article_types.ArticleService
+	var dataExportService data_export_types.DataExportService
+	var auditLog audit_types.AuditLog
+	fxApp := util.CreateFxAppAndExtract(app.ModuleList(configData), &userService, &articleService, &dataExportService, &auditLog)
+
+	if err := fxApp.Start(ctx); err != nil {
+		log.Fatalf("dependency injection system failed to start: %v", err)
+	}
+
+	cleanupManager.RegisterCleanupFunc(func() {
+		if err := fxApp.Stop(ctx); err != nil {
+			log.Printf("dependency injection system failed to stop gracefully: %v", err)
+		}
+	})
+
+	// run action
+
+	switch args.Action {
+
+	case app.ActionExportUserData:
+		log.Printf("running %v for user %v...", args.Action, args.Username)
+		err = exportUserData(ctx, userService, dataExportService, args.Username, args.OutputPath)
+		if err == nil {
+			log.Printf("wrote user data export to %v", args.OutputPath)
+		}
+
+	case app.ActionRestoreUser:
+		log.Printf("running %v for user %v...", args.Action, args.Id)
+		err = restoreUser(ctx, userService, args.Id)
+
+	case app.ActionRestoreArticle:
+		log.Printf("running %v for article %v...", args.Action, args.Id)
+		err = restoreArticle(ctx, articleService, args.Id)
+
+	case app.ActionAuditLog:
+		log.Printf("running %v...", args.Action)
+		var count int
+		count, err = listAuditLog(ctx, userService, auditLog, args)
+		if err == nil {
+			log.Printf("listed %v audit log entries", count)
+		}
+
+	default:
+		err = fmt.Errorf("unknown admin action: %v", args.Action)
+	}
+
+	if err != nil {
+		log.Printf("failed to run %v: %v", args.Action, err)
+		cleanupManager.Cleanup()
+		os.Exit(1)
+	}
+}
+
+func exportUserData(
+	ctx context.Context,
+	userService user_types.UserService,
+	dataExportService data_export_types.DataExportService,
+	username string,
+	outputPath string,
+) error {
+	user, err := userService.GetUserByUsername(ctx, username)
+	if err != nil {
+		return fmt.Errorf("error getting user: %w", err)
+	}
+
+	f, createErr := os.Create(outputPath)
+	if createErr != nil {
+		return fmt.Errorf("error creating output file: %w", createErr)
+	}
+
+	if err := dataExportService.ExportUserData(ctx, user, f); err != nil {
+		_ = f.Close()
+		_ = os.Remove(outputPath)
+		return fmt.Errorf("error exporting user data: %w", err)
+	}
+
+	if closeErr := f.Close(); closeErr != nil {
+		return fmt.Errorf("error closing output file: %w", closeErr)
+	}
+
+	return nil
+}
+
+func restoreUser(ctx context.Context, userService user_types.UserService, id string) error {
+	user, err := userService.RestoreUser(ctx, uuid.MustParse(id))
+	if err != nil {
+		return fmt.Errorf("error restoring user: %w", err)
+	}
+
+	log.Printf("restored user %v", user.Username)
+	return nil
+}
+
+func restoreArticle(ctx context.Context, articleService article_types.ArticleService, id string) error {
+	article, err := articleService.RestoreArticle(ctx, uuid.MustParse(id))
+	if err != nil {
+		return fmt.Errorf("error restoring article: %w", err)
+	}
+
+	log.Printf("restored article %q", article.Title)
+	return nil
+}
+
+// auditLogEntry is an audit_types.AuditEntry as it is written to stdout, one json object per line
+type auditLogEntry struct {
+	Id          string          `json:"id"`
+	ActorUserId *string         `json:"actor_user_id"`
+	Action      string          `json:"action"`
+	EntityType  string          `json:"entity_type"`
+	EntityId    string          `json:"entity_id"`
+	Before      json.RawMessage `json:"before"`
+	After       json.RawMessage `json:"after"`
+	CreatedAt   string          `json:"created_at"`
+}
+
+func listAuditLog(ctx context.Context, userService user_types.UserService, auditLog audit_types.AuditLog, args app.Args) (int, error) {
+	query := audit_types.AuditQuery{Limit: args.Limit, Offset: args.Offset}
+
+	if args.Actor != "" {
+		actorId, parseErr := uuid.Parse(args.Actor)
+		if parseErr != nil {
+			// not an id, so a username, deleted users can only be given by id
+			user, err := userService.ResolveUsername(ctx, args.Actor)
+			if err != nil {
+				return 0, fmt.Errorf("error getting actor: %w", err)
+			}
+			actorId = user.Id
+		}
+		query.ActorUserId = mo.Some(actorId)
+	}
+	if args.EntityType != "" {
+		query.EntityType = mo.Some(audit_types.AuditEntityType(args.EntityType))
+	}
+	if args.EntityId != "" {
+		query.EntityId = mo.Some(args.EntityId)
+	}
+	if args.From != "" {
+		from, err := parseAuditTime(args.From)
+		if err != nil {
+			return 0, err
+		}
+		query.From = mo.Some(from)
+	}
+	if args.To != "" {
+		to, err := parseAuditTime(args.To)
+		if err != nil {
+			return 0, err
+		}
+		query.To = mo.Some(to)
+	}
+
+	entries, err := auditLog.ListEntries(ctx, query)
+	if err != nil {
+		return 0, fmt.Errorf("error listing audit log entries: %w", err)
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	for _, entry := range entries {
+		var actorUserId *string
+		if actorId, ok := entry.ActorUserId.Get(); ok {
+			actorUserId = lo.ToPtr(actorId.String())
+		}
+		err := encoder.Encode(auditLogEntry{
+			Id:          entry.Id.String(),
+			ActorUserId: actorUserId,
+			Action:      string(entry.Action),
+			EntityType:  string(entry.EntityType),
+			EntityId:    entry.EntityId,
+			Before:      entry.Before,
+			After:       entry.After,
+			CreatedAt:   time.UnixMilli(entry.CreatedAtMillis).UTC().Format(time.RFC3339Nano),
+		})
+		if err != nil {
+			return 0, fmt.Errorf("error writing audit log entry: %w", err)
+		}
+	}
+
+	return len(entries), nil
+}
+
+// parseAuditTime parses a date, as the start of that day in UTC, or an RFC3339 time
+func parseAuditTime(value string) (time.Time, error) {
+	if t, err := time.Parse(time.DateOnly, value); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time %v, expected a date or an RFC3339 time", value)
+	}
+	return t, nil
+}
@@ -2,8 +2,13 @@ package app
 
 import (
 	auth_types "github.com/nimaeskandary/go-realworld/pkg/auth/types"
+	blob_store_types "github.com/nimaeskandary/go-realworld/pkg/blob_store/types"
 	db_types "github.com/nimaeskandary/go-realworld/pkg/database/types"
+	media_types "github.com/nimaeskandary/go-realworld/pkg/media/types"
 	obs_types "github.com/nimaeskandary/go-realworld/pkg/observability/types"
+	outbox_types "github.com/nimaeskandary/go-realworld/pkg/outbox/types"
+	soft_delete_types "github.com/nimaeskandary/go-realworld/pkg/soft_delete/types"
+	user_types "github.com/nimaeskandary/go-realworld/pkg/user/types"
 )
 
 type HttpServerConfig struct {
@@ -13,8 +18,15 @@ type HttpServerConfig struct {
 }
 
 type Config struct {
-	HttpServer     HttpServerConfig                `json:"http_server" validate:"required"`
-	JwtAuthService auth_types.JwtAuthServiceConfig `json:"jwt_auth_service" validate:"required"`
-	Slog           obs_types.SlogLoggerConfig      `json:"slog" validate:"required"`
-	RealWorldAppDb db_types.RealWorldAppDbConfig   `json:"realworld_app_db" validate:"required"`
+	HttpServer     HttpServerConfig                      `json:"http_server" validate:"required"`
+	JwtAuthService auth_types.JwtAuthServiceConfig       `json:"jwt_auth_service" validate:"required"`
+	Slog           obs_types.SlogLoggerConfig            `json:"slog" validate:"required"`
+	RealWorldAppDb db_types.RealWorldAppDbConfig         `json:"realworld_app_db" validate:"required"`
+	SoftDelete     soft_delete_types.SoftDeleteConfig    `json:"soft_delete" validate:"required"`
+	Outbox         outbox_types.OutboxConfig             `json:"outbox" validate:"required"`
+	User           user_types.UserConfig                 `json:"user" validate:"required"`
+	LocalBlobStore blob_store_types.LocalBlobStoreConfig `json:"local_blob_store" validate:"required"`
+	Media          media_types.MediaConfig               `json:"media" validate:"required"`
+	// StartupMigrations is off unless set, leaving migrations to cmd/migrations
+	StartupMigrations db_types.StartupMigrationsConfig `json:"startup_migrations"`
 }
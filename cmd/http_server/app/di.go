@@ -3,15 +3,27 @@ package app
 import (
 	"github.com/nimaeskandary/go-realworld/cmd/http_server/app/http_handler"
 	"github.com/nimaeskandary/go-realworld/pkg/article"
+	"github.com/nimaeskandary/go-realworld/pkg/audit"
 	"github.com/nimaeskandary/go-realworld/pkg/auth"
 	auth_types "github.com/nimaeskandary/go-realworld/pkg/auth/types"
+	"github.com/nimaeskandary/go-realworld/pkg/blob_store"
+	blob_store_types "github.com/nimaeskandary/go-realworld/pkg/blob_store/types"
 	"github.com/nimaeskandary/go-realworld/pkg/config"
 	config_types "github.com/nimaeskandary/go-realworld/pkg/config/types"
+	"github.com/nimaeskandary/go-realworld/pkg/data_export"
 	"github.com/nimaeskandary/go-realworld/pkg/database"
+	"github.com/nimaeskandary/go-realworld/pkg/database/migrations/realworld_app"
 	db_types "github.com/nimaeskandary/go-realworld/pkg/database/types"
+	"github.com/nimaeskandary/go-realworld/pkg/media"
+	media_types "github.com/nimaeskandary/go-realworld/pkg/media/types"
 	obs "github.com/nimaeskandary/go-realworld/pkg/observability"
 	obs_types "github.com/nimaeskandary/go-realworld/pkg/observability/types"
+	"github.com/nimaeskandary/go-realworld/pkg/outbox"
+	outbox_types "github.com/nimaeskandary/go-realworld/pkg/outbox/types"
+	"github.com/nimaeskandary/go-realworld/pkg/soft_delete"
+	soft_delete_types "github.com/nimaeskandary/go-realworld/pkg/soft_delete/types"
 	"github.com/nimaeskandary/go-realworld/pkg/user"
+	user_types "github.com/nimaeskandary/go-realworld/pkg/user/types"
 
 	"go.uber.org/fx"
 )
@@ -33,12 +45,41 @@ func ModuleList(configData []byte) []fx.Option {
 			func(cfg config_types.ConfigLoader[Config]) db_types.RealWorldAppDbConfig {
 				return cfg.GetConfig().RealWorldAppDb
 			},
+			func(cfg config_types.ConfigLoader[Config]) db_types.StartupMigrationsConfig {
+				return cfg.GetConfig().StartupMigrations
+			},
+			func(cfg config_types.ConfigLoader[Config]) soft_delete_types.SoftDeleteConfig {
+				return cfg.GetConfig().SoftDelete
+			},
+			func(cfg config_types.ConfigLoader[Config]) outbox_types.OutboxConfig {
+				return cfg.GetConfig().Outbox
+			},
+			func(cfg config_types.ConfigLoader[Config]) user_types.UserConfig {
+				return cfg.GetConfig().User
+			},
+			func(cfg config_types.ConfigLoader[Config]) blob_store_types.LocalBlobStoreConfig {
+				return cfg.GetConfig().LocalBlobStore
+			},
+			func(cfg config_types.ConfigLoader[Config]) media_types.MediaConfig {
+				return cfg.GetConfig().Media
+			},
 		),
-		database.NewPostgresRealworldAppDbModule[db_types.PostgresRealWorldAppDb](),
+		database.NewRealworldAppDbModule[db_types.RealWorldAppDb](),
+		database.NewRealworldAppStartupMigrationsModule(),
+		realworld_app.NewMigrationProviderModule(),
+		database.NewRealworldAppTxManagerModule(),
 		http_handler.NewHttpHandlerModule(),
 		auth.NewAuthModule(),
 		user.NewUserModule(),
 		article.NewArticleModule(),
+		audit.NewAuditModule(),
+		data_export.NewDataExportModule(),
+		blob_store.NewLocalBlobStoreModule(),
+		media.NewMediaModule(),
 		obs.NewSlogLoggerModule(),
+		soft_delete.NewSoftDeletePurgerModule(),
+		outbox.NewOutboxModule(),
+		outbox.NewOutboxRelayModule(),
+		fx.Invoke(registerEventHandlers),
 	}
 }
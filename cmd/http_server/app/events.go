@@ -0,0 +1,24 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	article_types "github.com/nimaeskandary/go-realworld/pkg/article/types"
+	obs_types "github.com/nimaeskandary/go-realworld/pkg/observability/types"
+	outbox_types "github.com/nimaeskandary/go-realworld/pkg/outbox/types"
+)
+
+// registerEventHandlers registers the handlers of the domain events relayed by the server. article.saved is only
+// logged for now, it is where e.g. search indexing or notifying followers would hook in
+func registerEventHandlers(relay outbox_types.OutboxRelay, logger obs_types.Logger) {
+	relay.RegisterHandler(article_types.ArticleSavedEventType, func(ctx context.Context, event outbox_types.OutboxEvent) error {
+		var saved article_types.ArticleSavedEvent
+		if err := json.Unmarshal(event.Payload, &saved); err != nil {
+			return fmt.Errorf("error decoding %v event: %w", event.Type, err)
+		}
+		logger.Info(ctx, "article saved", "article_id", saved.ArticleId, "version", saved.Version, "created", saved.Created)
+		return nil
+	})
+}
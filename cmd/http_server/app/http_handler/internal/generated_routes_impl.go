@@ -9,15 +9,21 @@ import (
 
 // generatedRoutesImpl implements the autogenerated api_gen.StrictServerInterface. It is just a thin wrapper that delegates to the actual handler implementations
 type generatedRoutesImpl struct {
+	articleRoutes *routes.ArticleRoutes
+	mediaRoutes   *routes.MediaRoutes
 	profileRoutes *routes.ProfileRoutes
 	userRoutes    *routes.UserRoutes
 }
 
 func NewGeneratedRoutesImpl(
+	articleRoutes *routes.ArticleRoutes,
+	mediaRoutes *routes.MediaRoutes,
 	profileRoutes *routes.ProfileRoutes,
 	userRoutes *routes.UserRoutes,
 ) api_gen.StrictServerInterface {
 	return &generatedRoutesImpl{
+		articleRoutes: articleRoutes,
+		mediaRoutes:   mediaRoutes,
 		profileRoutes: profileRoutes,
 		userRoutes:    userRoutes,
 	}
@@ -35,6 +41,10 @@ func (r *generatedRoutesImpl) GetArticlesFeed(ctx context.Context, request api_g
 	return nil, nil
 }
 
+func (r *generatedRoutesImpl) SearchArticles(ctx context.Context, request api_gen.SearchArticlesRequestObject) (api_gen.SearchArticlesResponseObject, error) {
+	return r.articleRoutes.SearchArticles(ctx, request)
+}
+
 func (r *generatedRoutesImpl) DeleteArticle(ctx context.Context, request api_gen.DeleteArticleRequestObject) (api_gen.DeleteArticleResponseObject, error) {
 	return nil, nil
 }
@@ -44,7 +54,7 @@ func (r *generatedRoutesImpl) GetArticle(ctx context.Context, request api_gen.Ge
 }
 
 func (r *generatedRoutesImpl) UpdateArticle(ctx context.Context, request api_gen.UpdateArticleRequestObject) (api_gen.UpdateArticleResponseObject, error) {
-	return nil, nil
+	return r.articleRoutes.UpdateArticle(ctx, request)
 }
 
 func (r *generatedRoutesImpl) GetArticleComments(ctx context.Context, request api_gen.GetArticleCommentsRequestObject) (api_gen.GetArticleCommentsResponseObject, error) {
@@ -67,6 +77,14 @@ func (r *generatedRoutesImpl) CreateArticleFavorite(ctx context.Context, request
 	return nil, nil
 }
 
+func (r *generatedRoutesImpl) UploadArticleImage(ctx context.Context, request api_gen.UploadArticleImageRequestObject) (api_gen.UploadArticleImageResponseObject, error) {
+	return r.mediaRoutes.UploadArticleImage(ctx, request)
+}
+
+func (r *generatedRoutesImpl) GetMedia(ctx context.Context, request api_gen.GetMediaRequestObject) (api_gen.GetMediaResponseObject, error) {
+	return r.mediaRoutes.GetMedia(ctx, request)
+}
+
 func (r *generatedRoutesImpl) GetProfileByUsername(ctx context.Context, request api_gen.GetProfileByUsernameRequestObject) (api_gen.GetProfileByUsernameResponseObject, error) {
 	return r.profileRoutes.GetProfileByUsername(ctx, request)
 }
@@ -79,6 +97,22 @@ func (r *generatedRoutesImpl) FollowUserByUsername(ctx context.Context, request
 	return r.profileRoutes.FollowUserByUsername(ctx, request)
 }
 
+func (r *generatedRoutesImpl) UnblockUserByUsername(ctx context.Context, request api_gen.UnblockUserByUsernameRequestObject) (api_gen.UnblockUserByUsernameResponseObject, error) {
+	return r.profileRoutes.UnblockUserByUsername(ctx, request)
+}
+
+func (r *generatedRoutesImpl) BlockUserByUsername(ctx context.Context, request api_gen.BlockUserByUsernameRequestObject) (api_gen.BlockUserByUsernameResponseObject, error) {
+	return r.profileRoutes.BlockUserByUsername(ctx, request)
+}
+
+func (r *generatedRoutesImpl) UnmuteUserByUsername(ctx context.Context, request api_gen.UnmuteUserByUsernameRequestObject) (api_gen.UnmuteUserByUsernameResponseObject, error) {
+	return r.profileRoutes.UnmuteUserByUsername(ctx, request)
+}
+
+func (r *generatedRoutesImpl) MuteUserByUsername(ctx context.Context, request api_gen.MuteUserByUsernameRequestObject) (api_gen.MuteUserByUsernameResponseObject, error) {
+	return r.profileRoutes.MuteUserByUsername(ctx, request)
+}
+
 func (r *generatedRoutesImpl) GetTags(ctx context.Context, request api_gen.GetTagsRequestObject) (api_gen.GetTagsResponseObject, error) {
 	return nil, nil
 }
@@ -87,6 +121,14 @@ func (r *generatedRoutesImpl) GetCurrentUser(ctx context.Context, request api_ge
 	return r.userRoutes.GetCurrentUser(ctx, request)
 }
 
+func (r *generatedRoutesImpl) ExportCurrentUserData(ctx context.Context, request api_gen.ExportCurrentUserDataRequestObject) (api_gen.ExportCurrentUserDataResponseObject, error) {
+	return r.userRoutes.ExportCurrentUserData(ctx, request)
+}
+
+func (r *generatedRoutesImpl) UploadCurrentUserAvatar(ctx context.Context, request api_gen.UploadCurrentUserAvatarRequestObject) (api_gen.UploadCurrentUserAvatarResponseObject, error) {
+	return r.mediaRoutes.UploadCurrentUserAvatar(ctx, request)
+}
+
 func (r *generatedRoutesImpl) UpdateCurrentUser(ctx context.Context, request api_gen.UpdateCurrentUserRequestObject) (api_gen.UpdateCurrentUserResponseObject, error) {
 	return r.userRoutes.UpdateCurrentUser(ctx, request)
 }
@@ -8,6 +8,7 @@ import (
 	"github.com/nimaeskandary/go-realworld/pkg/api_gen"
 	auth_types "github.com/nimaeskandary/go-realworld/pkg/auth/types"
 	obstypes "github.com/nimaeskandary/go-realworld/pkg/observability/types"
+	user_types "github.com/nimaeskandary/go-realworld/pkg/user/types"
 )
 
 type httpHandlerImpl struct {
@@ -17,12 +18,15 @@ type httpHandlerImpl struct {
 func NewHttpHandlerImpl(
 	generateRoutesImpl api_gen.StrictServerInterface,
 	authService auth_types.AuthService,
+	userService user_types.UserService,
 	logger obstypes.Logger,
 ) http_handler_types.HttpHandler {
 	// logically these are run in reverse order due to wrapping, the first element here is the outermost middleware
 	middlewares := []api_gen.StrictMiddlewareFunc{
+		middleware.CreateUserLoader(userService),
 		middleware.AuthenticateRoute(logger),
 		middleware.CreateAuthContext(logger, authService),
+		middleware.CreateReadYourWritesScope(),
 		middleware.HandleError(logger),
 	}
 
@@ -10,7 +10,9 @@ func RoutesModule() fx.Option {
 	return fx.Provide(
 		fx.Private,
 		NewGeneratedRoutesImpl,
+		routes.NewArticleRoutes,
 		routes.NewUserRoutes,
 		routes.NewProfileRoutes,
+		routes.NewMediaRoutes,
 	)
 }
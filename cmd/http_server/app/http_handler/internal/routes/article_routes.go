@@ -0,0 +1,164 @@
+package routes
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/nimaeskandary/go-realworld/cmd/http_server/app/http_handler/internal/transformers"
+	"github.com/nimaeskandary/go-realworld/pkg/api_gen"
+	"github.com/nimaeskandary/go-realworld/pkg/article/types"
+	"github.com/nimaeskandary/go-realworld/pkg/auth/context"
+	db_types "github.com/nimaeskandary/go-realworld/pkg/database/types"
+	"github.com/nimaeskandary/go-realworld/pkg/user/loader"
+	"github.com/nimaeskandary/go-realworld/pkg/user/types"
+
+	"github.com/google/uuid"
+	"github.com/samber/mo"
+)
+
+// defaultSearchLimit is the limit of a search that does not set one, as documented for limitParam
+const defaultSearchLimit = 20
+
+type ArticleRoutes struct {
+	articleService article_types.ArticleService
+	userService    user_types.UserService
+}
+
+func NewArticleRoutes(
+	articleService article_types.ArticleService,
+	userService user_types.UserService,
+) *ArticleRoutes {
+	return &ArticleRoutes{
+		articleService: articleService,
+		userService:    userService,
+	}
+}
+
+func (r *ArticleRoutes) UpdateArticle(ctx context.Context, request api_gen.UpdateArticleRequestObject) (api_gen.UpdateArticleResponseObject, error) {
+	authUser := auth_context.UserFromCtx(ctx)
+	if authUser.IsNone() {
+		return api_gen.UnauthorizedResponse{}, nil
+	}
+	expectedVersion, err := transformers.FromIfMatch(request.Params.IfMatch)
+	if err != nil {
+		return api_gen.UpdateArticle412JSONResponse{
+			PreconditionFailedJSONResponse: api_gen.PreconditionFailedJSONResponse(transformers.ToApiError(err)),
+		}, nil
+	}
+
+	// articles have no slugs yet, the slug is the article id
+	id, err := uuid.Parse(request.Slug)
+	if err != nil {
+		return api_gen.UpdateArticle422JSONResponse{
+			GenericErrorJSONResponse: transformers.ToApiError(article_types.NotFoundError{Identifier: request.Slug}),
+		}, nil
+	}
+
+	// the article is read to be written back, a stale replica read would turn into a spurious version conflict
+	ctx = db_types.CtxWithPrimaryReads(ctx)
+	existing, err := r.articleService.GetArticle(ctx, id)
+	if err != nil {
+		switch article_types.DomainError(article_types.AsDomainError(err)).(type) {
+		case article_types.NotFoundError:
+			return api_gen.UpdateArticle422JSONResponse{
+				GenericErrorJSONResponse: transformers.ToApiError(err),
+			}, nil
+		default:
+			return nil, fmt.Errorf("error getting article: %w", err)
+		}
+	}
+	if existing.AuthorUserId != authUser.MustGet().Id {
+		return api_gen.UpdateArticle403JSONResponse{
+			ForbiddenJSONResponse: api_gen.ForbiddenJSONResponse(transformers.ToApiError(fmt.Errorf("only the author can update article %v", request.Slug))),
+		}, nil
+	}
+
+	updated := transformers.FromApiUpdateArticle(request.Body.Article, existing)
+	// without If-Match the update applies to the version just read, so a concurrent update is still not overwritten
+	updated.Version = expectedVersion.OrElse(existing.Version)
+	updated.UpdatedAtMillis = time.Now().UnixMilli()
+
+	saved, err := r.articleService.UpsertArticle(ctx, updated)
+	if err != nil {
+		switch article_types.DomainError(article_types.AsDomainError(err)).(type) {
+		case article_types.VersionConflictError:
+			return api_gen.UpdateArticle412JSONResponse{
+				PreconditionFailedJSONResponse: api_gen.PreconditionFailedJSONResponse(transformers.ToApiError(err)),
+			}, nil
+		default:
+			return nil, fmt.Errorf("error updating article: %w", err)
+		}
+	}
+
+	// only the author can update, and users can not follow themselves
+	return api_gen.UpdateArticle200JSONResponse{
+		VersionedSingleArticleResponseJSONResponse: transformers.ToApiVersionedSingleArticleResponse(saved, authUser.MustGet(), false),
+	}, nil
+}
+
+func (r *ArticleRoutes) SearchArticles(ctx context.Context, request api_gen.SearchArticlesRequestObject) (api_gen.SearchArticlesResponseObject, error) {
+	// auth is optional for this route, when logged in the authors hidden from the user are left out
+	authUser := auth_context.UserFromCtx(ctx)
+
+	query := article_types.ArticleSearchQuery{
+		Text:   request.Params.Q,
+		Tag:    mo.PointerToOption(request.Params.Tag),
+		Limit:  defaultSearchLimit,
+		Offset: 0,
+	}
+	if request.Params.Limit != nil {
+		query.Limit = *request.Params.Limit
+	}
+	if request.Params.Offset != nil {
+		query.Offset = *request.Params.Offset
+	}
+
+	if request.Params.Author != nil {
+		author, err := r.userService.ResolveUsername(ctx, *request.Params.Author)
+		if err != nil {
+			switch err.(type) {
+			case user_types.NotFoundError:
+				// no articles are by an author that does not exist
+				return api_gen.SearchArticles200JSONResponse{
+					ArticleSearchResponseJSONResponse: transformers.ToApiArticleSearchResponse(nil, nil, nil),
+				}, nil
+			default:
+				return nil, err
+			}
+		}
+		query.AuthorUserId = mo.Some(author.Id)
+	}
+
+	results, err := r.articleService.SearchArticles(ctx, authUser, query)
+	if err != nil {
+		switch err.(type) {
+		case article_types.InvalidSearchQueryError:
+			return api_gen.SearchArticles422JSONResponse{
+				GenericErrorJSONResponse: transformers.ToApiError(err),
+			}, nil
+		default:
+			return nil, err
+		}
+	}
+
+	authorIds := make([]uuid.UUID, len(results))
+	for i, result := range results {
+		authorIds[i] = result.Article.AuthorUserId
+	}
+
+	loader := user_loader.LoaderFromCtx(ctx).OrElse(user_loader.NewUserLoader(r.userService, authUser))
+	loader.Prime(authorIds...)
+	authors, userErr := loader.GetUsers(ctx, authorIds)
+	if userErr != nil {
+		return nil, userErr
+	}
+	following, userErr := loader.AreFollowing(ctx, authorIds)
+	if userErr != nil {
+		return nil, userErr
+	}
+
+	return api_gen.SearchArticles200JSONResponse{
+		ArticleSearchResponseJSONResponse: transformers.ToApiArticleSearchResponse(results, authors, following),
+	}, nil
+}
@@ -0,0 +1,273 @@
+package routes_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/nimaeskandary/go-realworld/cmd/http_server/app/http_handler/internal/transformers"
+	"github.com/nimaeskandary/go-realworld/pkg/api_gen"
+	"github.com/nimaeskandary/go-realworld/pkg/article/types"
+	"github.com/nimaeskandary/go-realworld/pkg/test_utils/fixtures"
+	"github.com/nimaeskandary/go-realworld/pkg/test_utils/helpers"
+	"github.com/nimaeskandary/go-realworld/pkg/user/types"
+
+	"github.com/google/uuid"
+	"github.com/samber/mo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ArticleRoutes(t *testing.T) {
+	t.Parallel()
+
+	f := fixtures.SetupStandardFixture(t)
+
+	createArticle := func(t *testing.T, author user_types.User) article_types.Article {
+		article, err := f.ArticleService.UpsertArticle(t.Context(), helpers.GenArticle(author.Id))
+		require.NoError(t, err)
+		return article
+	}
+
+	t.Run("UpdateArticle", func(t *testing.T) {
+		t.Parallel()
+
+		t.Run("should return a 401 if there is no auth header", func(t *testing.T) {
+			t.Parallel()
+			user := helpers.CreateUsers(t, f.UserService, 1)[0]
+			article := createArticle(t, user)
+
+			updatedTitle := "Updated Title"
+			req := helpers.UpdateArticleRequest(t, f.AuthService, user, article.Id.String(), api_gen.UpdateArticle{Title: &updatedTitle})
+			req.Header.Del("Authorization")
+
+			rec := httptest.NewRecorder()
+			f.HttpHandler.GetHandler().ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		})
+
+		t.Run("should return a 422 if the article does not exist", func(t *testing.T) {
+			t.Parallel()
+			user := helpers.CreateUsers(t, f.UserService, 1)[0]
+
+			updatedTitle := "Updated Title"
+			for _, slug := range []string{uuid.NewString(), "not-an-id"} {
+				req := helpers.UpdateArticleRequest(t, f.AuthService, user, slug, api_gen.UpdateArticle{Title: &updatedTitle})
+
+				rec := httptest.NewRecorder()
+				f.HttpHandler.GetHandler().ServeHTTP(rec, req)
+
+				assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+				assert.Contains(t, rec.Body.String(), "NotFoundError")
+			}
+		})
+
+		t.Run("should return a 403 if the user is not the author", func(t *testing.T) {
+			t.Parallel()
+			users := helpers.CreateUsers(t, f.UserService, 2)
+			article := createArticle(t, users[0])
+
+			updatedTitle := "Updated Title"
+			req := helpers.UpdateArticleRequest(t, f.AuthService, users[1], article.Id.String(), api_gen.UpdateArticle{Title: &updatedTitle})
+
+			rec := httptest.NewRecorder()
+			f.HttpHandler.GetHandler().ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusForbidden, rec.Code)
+
+			unchanged, err := f.ArticleService.GetArticle(t.Context(), article.Id)
+			require.NoError(t, err)
+			assert.Equal(t, article, unchanged)
+		})
+
+		t.Run("should return a 412 if If-Match does not match the current version", func(t *testing.T) {
+			t.Parallel()
+			user := helpers.CreateUsers(t, f.UserService, 1)[0]
+			article := createArticle(t, user)
+
+			updatedTitle := "Stale Title"
+			req := helpers.UpdateArticleRequest(t, f.AuthService, user, article.Id.String(), api_gen.UpdateArticle{Title: &updatedTitle})
+			req.Header.Set("If-Match", transformers.ToETag(article.Version+1))
+
+			rec := httptest.NewRecorder()
+			f.HttpHandler.GetHandler().ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusPreconditionFailed, rec.Code)
+			assert.Contains(t, rec.Body.String(), "VersionConflictError")
+
+			unchanged, err := f.ArticleService.GetArticle(t.Context(), article.Id)
+			require.NoError(t, err)
+			assert.Equal(t, article, unchanged)
+		})
+
+		t.Run("should return a 412 if If-Match is malformed", func(t *testing.T) {
+			t.Parallel()
+			user := helpers.CreateUsers(t, f.UserService, 1)[0]
+			article := createArticle(t, user)
+
+			updatedTitle := "Malformed Title"
+			req := helpers.UpdateArticleRequest(t, f.AuthService, user, article.Id.String(), api_gen.UpdateArticle{Title: &updatedTitle})
+			req.Header.Set("If-Match", "W/\"1\"")
+
+			rec := httptest.NewRecorder()
+			f.HttpHandler.GetHandler().ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusPreconditionFailed, rec.Code)
+		})
+
+		t.Run("should update and return the new ETag if If-Match matches the current version", func(t *testing.T) {
+			t.Parallel()
+			user := helpers.CreateUsers(t, f.UserService, 1)[0]
+			article := createArticle(t, user)
+
+			expectedUpdatedArticle := article
+			expectedUpdatedArticle.Title = "If-Match Title"
+			req := helpers.UpdateArticleRequest(t, f.AuthService, user, article.Id.String(), api_gen.UpdateArticle{Title: &expectedUpdatedArticle.Title})
+			req.Header.Set("If-Match", transformers.ToETag(article.Version))
+
+			rec := httptest.NewRecorder()
+			f.HttpHandler.GetHandler().ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Equal(t, transformers.ToETag(article.Version+1), rec.Header().Get("ETag"))
+			validateArticleResponse(t, rec.Body.Bytes(), expectedUpdatedArticle, user)
+		})
+
+		t.Run("should successfully update all fields without If-Match", func(t *testing.T) {
+			t.Parallel()
+			user := helpers.CreateUsers(t, f.UserService, 1)[0]
+			article := createArticle(t, user)
+
+			expectedUpdatedArticle := article
+			expectedUpdatedArticle.Title = "Other Title"
+			expectedUpdatedArticle.Description = "Other Description"
+			expectedUpdatedArticle.Body = "Other Body"
+			req := helpers.UpdateArticleRequest(t, f.AuthService, user, article.Id.String(), api_gen.UpdateArticle{
+				Title:       &expectedUpdatedArticle.Title,
+				Description: &expectedUpdatedArticle.Description,
+				Body:        &expectedUpdatedArticle.Body,
+			})
+
+			rec := httptest.NewRecorder()
+			f.HttpHandler.GetHandler().ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Equal(t, transformers.ToETag(article.Version+1), rec.Header().Get("ETag"))
+			validateArticleResponse(t, rec.Body.Bytes(), expectedUpdatedArticle, user)
+		})
+	})
+
+	t.Run("SearchArticles", func(t *testing.T) {
+		t.Parallel()
+
+		// createSearchableArticle saves an article with word in its title by author
+		createSearchableArticle := func(t *testing.T, author user_types.User, word string) {
+			article := helpers.GenArticle(author.Id)
+			article.Title = "Notes on " + word
+			_, err := f.ArticleRepo.UpsertArticle(t.Context(), article)
+			require.NoError(t, err)
+		}
+		search := func(t *testing.T, authUser mo.Option[user_types.User], params api_gen.SearchArticlesParams) (int, api_gen.ArticleSearchResponseJSONResponse) {
+			req := helpers.SearchArticlesRequest(t, f.AuthService, authUser, params)
+			rec := httptest.NewRecorder()
+			f.HttpHandler.GetHandler().ServeHTTP(rec, req)
+
+			var body api_gen.ArticleSearchResponseJSONResponse
+			if rec.Code == http.StatusOK {
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+			}
+			return rec.Code, body
+		}
+
+		t.Run("should return 422 if the search text is blank", func(t *testing.T) {
+			t.Parallel()
+
+			code, _ := search(t, mo.None[user_types.User](), api_gen.SearchArticlesParams{Q: "   "})
+			assert.Equal(t, http.StatusUnprocessableEntity, code)
+		})
+
+		t.Run("should return 422 if the limit is too large", func(t *testing.T) {
+			t.Parallel()
+
+			limit := 1000
+			code, _ := search(t, mo.None[user_types.User](), api_gen.SearchArticlesParams{Q: "anything", Limit: &limit})
+			assert.Equal(t, http.StatusUnprocessableEntity, code)
+		})
+
+		t.Run("should return matches with their author and highlight", func(t *testing.T) {
+			t.Parallel()
+			users := helpers.CreateUsers(t, f.UserService, 2)
+			_, err := f.UserService.FollowProfile(t.Context(), users[0], users[1].Username)
+			require.NoError(t, err)
+			word := helpers.GenSearchWord()
+			createSearchableArticle(t, users[1], word)
+
+			code, body := search(t, mo.Some(users[0]), api_gen.SearchArticlesParams{Q: word})
+			assert.Equal(t, http.StatusOK, code)
+			require.Len(t, body.Results, 1)
+			assert.Equal(t, "Notes on "+word, body.Results[0].Title)
+			assert.Equal(t, []string{}, body.Results[0].TagList)
+			assert.Equal(t, api_gen.Profile{
+				Username:  users[1].Username,
+				Bio:       users[1].Bio.OrElse(""),
+				Image:     users[1].Image.OrElse(""),
+				Following: true,
+			}, body.Results[0].Author)
+			assert.Contains(t, body.Results[0].Highlight, "<mark>"+word+"</mark>")
+		})
+
+		t.Run("should filter by author", func(t *testing.T) {
+			t.Parallel()
+			users := helpers.CreateUsers(t, f.UserService, 2)
+			word := helpers.GenSearchWord()
+			createSearchableArticle(t, users[0], word)
+			createSearchableArticle(t, users[1], word)
+
+			code, body := search(t, mo.None[user_types.User](), api_gen.SearchArticlesParams{Q: word, Author: &users[1].Username})
+			assert.Equal(t, http.StatusOK, code)
+			require.Len(t, body.Results, 1)
+			assert.Equal(t, users[1].Username, body.Results[0].Author.Username)
+
+			unknown := "no-existo"
+			code, body = search(t, mo.None[user_types.User](), api_gen.SearchArticlesParams{Q: word, Author: &unknown})
+			assert.Equal(t, http.StatusOK, code)
+			assert.Empty(t, body.Results)
+		})
+
+		t.Run("should leave out authors the user has blocked or muted", func(t *testing.T) {
+			t.Parallel()
+			users := helpers.CreateUsers(t, f.UserService, 4)
+			word := helpers.GenSearchWord()
+			for _, author := range users[1:] {
+				createSearchableArticle(t, author, word)
+			}
+			_, err := f.UserService.BlockProfile(t.Context(), users[0], users[1].Username)
+			require.NoError(t, err)
+			_, err = f.UserService.MuteProfile(t.Context(), users[0], users[2].Username)
+			require.NoError(t, err)
+
+			code, body := search(t, mo.Some(users[0]), api_gen.SearchArticlesParams{Q: word})
+			assert.Equal(t, http.StatusOK, code)
+			require.Len(t, body.Results, 1)
+			assert.Equal(t, users[3].Username, body.Results[0].Author.Username)
+
+			code, body = search(t, mo.None[user_types.User](), api_gen.SearchArticlesParams{Q: word})
+			assert.Equal(t, http.StatusOK, code)
+			assert.Len(t, body.Results, 3)
+		})
+	})
+}
+
+func validateArticleResponse(t *testing.T, responseBody []byte, expectedArticle article_types.Article, expectedAuthor user_types.User) {
+	actual := new(api_gen.VersionedSingleArticleResponse)
+	err := json.Unmarshal(responseBody, actual)
+	require.NoError(t, err)
+
+	assert.Equal(t, expectedArticle.Id.String(), actual.Article.Slug)
+	assert.Equal(t, expectedArticle.Title, actual.Article.Title)
+	assert.Equal(t, expectedArticle.Description, actual.Article.Description)
+	assert.Equal(t, expectedArticle.Body, actual.Article.Body)
+	assert.Equal(t, expectedAuthor.Username, actual.Article.Author.Username)
+}
@@ -0,0 +1,205 @@
+package routes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+
+	"github.com/nimaeskandary/go-realworld/cmd/http_server/app/http_handler/internal/transformers"
+	"github.com/nimaeskandary/go-realworld/pkg/api_gen"
+	"github.com/nimaeskandary/go-realworld/pkg/auth/context"
+	auth_types "github.com/nimaeskandary/go-realworld/pkg/auth/types"
+	media_types "github.com/nimaeskandary/go-realworld/pkg/media/types"
+	obs_types "github.com/nimaeskandary/go-realworld/pkg/observability/types"
+	user_types "github.com/nimaeskandary/go-realworld/pkg/user/types"
+
+	"github.com/samber/mo"
+)
+
+// stored images are never overwritten, each upload gets a new name
+const mediaCacheControl = "public, max-age=31536000, immutable"
+
+// imageFormField is the multipart form field holding the uploaded image
+const imageFormField = "image"
+
+var errMissingImage = fmt.Errorf("multipart form field %q is required", imageFormField)
+
+type MediaRoutes struct {
+	logger       obs_types.Logger
+	authService  auth_types.AuthService
+	userService  user_types.UserService
+	mediaService media_types.MediaService
+}
+
+func NewMediaRoutes(
+	logger obs_types.Logger,
+	authService auth_types.AuthService,
+	userService user_types.UserService,
+	mediaService media_types.MediaService,
+) *MediaRoutes {
+	return &MediaRoutes{
+		logger:       logger,
+		authService:  authService,
+		userService:  userService,
+		mediaService: mediaService,
+	}
+}
+
+func (r *MediaRoutes) UploadCurrentUserAvatar(ctx context.Context, request api_gen.UploadCurrentUserAvatarRequestObject) (api_gen.UploadCurrentUserAvatarResponseObject, error) {
+	authUser := auth_context.UserFromCtx(ctx)
+	if authUser.IsNone() {
+		return api_gen.UnauthorizedResponse{}, nil
+	}
+
+	var err error
+	stored, err := r.uploadImage(ctx, media_types.ImageKindAvatar, request.Body)
+	if err != nil {
+		switch media_types.DomainError(media_types.AsDomainError(err)).(type) {
+		case media_types.TooLargeError:
+			return api_gen.UploadCurrentUserAvatar413JSONResponse{
+				PayloadTooLargeJSONResponse: api_gen.PayloadTooLargeJSONResponse(transformers.ToApiError(err)),
+			}, nil
+		case media_types.UnsupportedMediaTypeError:
+			return api_gen.UploadCurrentUserAvatar415JSONResponse{
+				UnsupportedMediaTypeJSONResponse: api_gen.UnsupportedMediaTypeJSONResponse(transformers.ToApiError(err)),
+			}, nil
+		case media_types.BadParamsError:
+			return api_gen.UploadCurrentUserAvatar422JSONResponse{
+				GenericErrorJSONResponse: transformers.ToApiError(err),
+			}, nil
+		default:
+			return nil, fmt.Errorf("error uploading avatar: %w", err)
+		}
+	}
+
+	// the other fields are kept as they were when the request was authenticated, so the update must apply to that
+	// version, a concurrent update in between is a version conflict rather than being overwritten
+	current := authUser.MustGet()
+	updatedUser, err := r.userService.UpdateUser(ctx, current.Id, user_types.UpsertUserParams{
+		Username: current.Username,
+		Email:    current.Email,
+		Bio:      current.Bio,
+		Image:    mo.Some(stored.Url),
+	}, mo.Some(current.Version))
+	if err != nil {
+		// the user keeps their previous image, so the one just stored would never be served
+		if deleteErr := r.mediaService.DeleteImage(ctx, stored.Kind, stored.Name); deleteErr != nil {
+			return nil, fmt.Errorf("error deleting avatar after failing to set it: %w", errors.Join(err, deleteErr))
+		}
+
+		switch user_types.DomainError(user_types.AsDomainError(err)).(type) {
+		case user_types.VersionConflictError:
+			return api_gen.UploadCurrentUserAvatar412JSONResponse{
+				PreconditionFailedJSONResponse: api_gen.PreconditionFailedJSONResponse(transformers.ToApiError(err)),
+			}, nil
+		case
+			user_types.ConflictError,
+			user_types.NotFoundError,
+			user_types.BadParamsError:
+			return api_gen.UploadCurrentUserAvatar422JSONResponse{
+				GenericErrorJSONResponse: transformers.ToApiError(err),
+			}, nil
+		default:
+			return nil, fmt.Errorf("error setting avatar: %w", err)
+		}
+	}
+
+	// the replaced avatar is no longer referenced, failing to delete it only leaves an orphaned blob behind
+	if previous, ok := current.Image.Get(); ok {
+		if deleteErr := r.mediaService.DeleteImageByUrl(ctx, previous); deleteErr != nil {
+			r.logger.Error(ctx, "error deleting replaced avatar", deleteErr, "url", previous)
+		}
+	}
+
+	token, err := r.authService.GenerateToken(ctx, updatedUser.Username)
+	if err != nil {
+		return nil, fmt.Errorf("error generating auth token: %w", err)
+	}
+
+	return api_gen.UploadCurrentUserAvatar200JSONResponse{
+		VersionedUserResponseJSONResponse: transformers.ToApiVersionedUserResponse(updatedUser, token.GetTokenString()),
+	}, nil
+}
+
+func (r *MediaRoutes) UploadArticleImage(ctx context.Context, request api_gen.UploadArticleImageRequestObject) (api_gen.UploadArticleImageResponseObject, error) {
+	authUser := auth_context.UserFromCtx(ctx)
+	if authUser.IsNone() {
+		return api_gen.UnauthorizedResponse{}, nil
+	}
+
+	var err error
+	stored, err := r.uploadImage(ctx, media_types.ImageKindArticle, request.Body)
+	if err != nil {
+		switch media_types.DomainError(media_types.AsDomainError(err)).(type) {
+		case media_types.TooLargeError:
+			return api_gen.UploadArticleImage413JSONResponse{
+				PayloadTooLargeJSONResponse: api_gen.PayloadTooLargeJSONResponse(transformers.ToApiError(err)),
+			}, nil
+		case media_types.UnsupportedMediaTypeError:
+			return api_gen.UploadArticleImage415JSONResponse{
+				UnsupportedMediaTypeJSONResponse: api_gen.UnsupportedMediaTypeJSONResponse(transformers.ToApiError(err)),
+			}, nil
+		case media_types.BadParamsError:
+			return api_gen.UploadArticleImage422JSONResponse{
+				GenericErrorJSONResponse: transformers.ToApiError(err),
+			}, nil
+		default:
+			return nil, fmt.Errorf("error uploading article image: %w", err)
+		}
+	}
+
+	return api_gen.UploadArticleImage201JSONResponse{
+		StoredImageResponseJSONResponse: api_gen.StoredImageResponseJSONResponse{
+			Image: transformers.ToApiStoredImage(stored),
+		},
+	}, nil
+}
+
+func (r *MediaRoutes) GetMedia(ctx context.Context, request api_gen.GetMediaRequestObject) (api_gen.GetMediaResponseObject, error) {
+	var err error
+	blob, err := r.mediaService.GetImage(ctx, media_types.ImageKind(request.Kind), request.Name)
+	if err != nil {
+		switch media_types.DomainError(media_types.AsDomainError(err)).(type) {
+		case media_types.NotFoundError:
+			return api_gen.GetMedia404JSONResponse{
+				GenericErrorJSONResponse: transformers.ToApiError(err),
+			}, nil
+		default:
+			return nil, fmt.Errorf("error getting media: %w", err)
+		}
+	}
+
+	// the generated response closes the body once it has been copied
+	return api_gen.GetMedia200ImageResponse{
+		Body:          blob.Body,
+		ContentType:   blob.ContentType,
+		ContentLength: blob.Size,
+		Headers:       api_gen.GetMedia200ResponseHeaders{CacheControl: mediaCacheControl},
+	}, nil
+}
+
+// uploadImage streams the image form field of the multipart body into the media service, the rest of the body is ignored
+func (r *MediaRoutes) uploadImage(ctx context.Context, kind media_types.ImageKind, body *multipart.Reader) (media_types.StoredImage, error) {
+	for {
+		part, err := body.NextPart()
+		if errors.Is(err, io.EOF) {
+			return media_types.StoredImage{}, media_types.BadParamsError{Err: errMissingImage}
+		}
+		if err != nil {
+			return media_types.StoredImage{}, media_types.BadParamsError{Err: fmt.Errorf("error reading multipart body: %w", err)}
+		}
+		if part.FormName() != imageFormField {
+			_ = part.Close()
+			continue
+		}
+		defer func() { _ = part.Close() }()
+
+		stored, domainErr := r.mediaService.UploadImage(ctx, kind, part)
+		if domainErr != nil {
+			return media_types.StoredImage{}, domainErr
+		}
+		return stored, nil
+	}
+}
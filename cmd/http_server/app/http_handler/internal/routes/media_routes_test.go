@@ -0,0 +1,233 @@
+package routes_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"image"
+	_ "image/jpeg"
+	_ "image/png"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/nimaeskandary/go-realworld/cmd/http_server/app/http_handler/internal/routes"
+	"github.com/nimaeskandary/go-realworld/pkg/api_gen"
+	"github.com/nimaeskandary/go-realworld/pkg/auth/context"
+	auth_types_mocks "github.com/nimaeskandary/go-realworld/pkg/auth/types/mocks"
+	media_types "github.com/nimaeskandary/go-realworld/pkg/media/types"
+	media_types_mocks "github.com/nimaeskandary/go-realworld/pkg/media/types/mocks"
+	obs_types_mocks "github.com/nimaeskandary/go-realworld/pkg/observability/types/mocks"
+	"github.com/nimaeskandary/go-realworld/pkg/test_utils/config"
+	"github.com/nimaeskandary/go-realworld/pkg/test_utils/fixtures"
+	"github.com/nimaeskandary/go-realworld/pkg/test_utils/helpers"
+	user_types "github.com/nimaeskandary/go-realworld/pkg/user/types"
+	user_types_mocks "github.com/nimaeskandary/go-realworld/pkg/user/types/mocks"
+
+	"github.com/samber/mo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_MediaRoutes(t *testing.T) {
+	t.Parallel()
+
+	f := fixtures.SetupStandardFixture(t)
+	cfg := config.NewTestConfig().Media
+
+	// getStored fetches an image through the media route using the path of its public url
+	getStored := func(t *testing.T, imageUrl string) *httptest.ResponseRecorder {
+		parsed, err := url.Parse(imageUrl)
+		require.NoError(t, err)
+
+		rec := httptest.NewRecorder()
+		f.HttpHandler.GetHandler().ServeHTTP(rec, helpers.GetMediaRequest(parsed.Path))
+		return rec
+	}
+
+	t.Run("UploadCurrentUserAvatar", func(t *testing.T) {
+		t.Parallel()
+
+		t.Run("should return a 401 if there is no auth header", func(t *testing.T) {
+			t.Parallel()
+			user := helpers.CreateUsers(t, f.UserService, 1)[0]
+
+			req := helpers.UploadCurrentUserAvatarRequest(t, f.AuthService, user, helpers.GenImage(t, "png", 10, 10))
+			req.Header.Del("Authorization")
+			rec := httptest.NewRecorder()
+			f.HttpHandler.GetHandler().ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		})
+
+		t.Run("should return a 415 if the upload is not an image", func(t *testing.T) {
+			t.Parallel()
+			user := helpers.CreateUsers(t, f.UserService, 1)[0]
+
+			req := helpers.UploadCurrentUserAvatarRequest(t, f.AuthService, user, []byte("just some text"))
+			rec := httptest.NewRecorder()
+			f.HttpHandler.GetHandler().ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusUnsupportedMediaType, rec.Code)
+		})
+
+		t.Run("should return a 413 if the upload is too large", func(t *testing.T) {
+			t.Parallel()
+			user := helpers.CreateUsers(t, f.UserService, 1)[0]
+
+			req := helpers.UploadCurrentUserAvatarRequest(t, f.AuthService, user, make([]byte, cfg.MaxUploadBytes+1))
+			rec := httptest.NewRecorder()
+			f.HttpHandler.GetHandler().ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+		})
+
+		t.Run("should set the resized avatar as the user's image", func(t *testing.T) {
+			t.Parallel()
+			user := helpers.CreateUsers(t, f.UserService, 1)[0]
+
+			req := helpers.UploadCurrentUserAvatarRequest(t, f.AuthService, user, helpers.GenImage(t, "png", 128, 128))
+			rec := httptest.NewRecorder()
+			f.HttpHandler.GetHandler().ServeHTTP(rec, req)
+			require.Equal(t, http.StatusOK, rec.Code)
+
+			var resp api_gen.VersionedUserResponseJSONResponse
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp.Body))
+
+			updated, err := f.UserService.GetUserByUsername(t.Context(), user.Username)
+			require.NoError(t, err)
+			assert.Equal(t, updated.Image.OrEmpty(), resp.Body.User.Image)
+
+			stored := getStored(t, resp.Body.User.Image)
+			require.Equal(t, http.StatusOK, stored.Code)
+			assert.Equal(t, "image/png", stored.Header().Get("Content-Type"))
+
+			decoded, _, decodeErr := image.Decode(bytes.NewReader(stored.Body.Bytes()))
+			require.NoError(t, decodeErr)
+			assert.Equal(t, image.Rect(0, 0, cfg.AvatarMaxDimension, cfg.AvatarMaxDimension), decoded.Bounds())
+		})
+
+		t.Run("should delete the replaced avatar", func(t *testing.T) {
+			t.Parallel()
+			user := helpers.CreateUsers(t, f.UserService, 1)[0]
+
+			uploadAvatar := func() string {
+				rec := httptest.NewRecorder()
+				f.HttpHandler.GetHandler().ServeHTTP(rec, helpers.UploadCurrentUserAvatarRequest(t, f.AuthService, user, helpers.GenImage(t, "png", 10, 10)))
+				require.Equal(t, http.StatusOK, rec.Code)
+
+				var resp api_gen.VersionedUserResponseJSONResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp.Body))
+				return resp.Body.User.Image
+			}
+			replaced := uploadAvatar()
+			current := uploadAvatar()
+
+			assert.Equal(t, http.StatusNotFound, getStored(t, replaced).Code)
+			assert.Equal(t, http.StatusOK, getStored(t, current).Code)
+		})
+
+		t.Run("should delete the stored avatar and map the error if the user cannot be updated", func(t *testing.T) {
+			t.Parallel()
+
+			cases := []struct {
+				err      user_types.DomainError
+				expected api_gen.UploadCurrentUserAvatarResponseObject
+			}{
+				{
+					err:      user_types.VersionConflictError{Identifier: "jake"},
+					expected: api_gen.UploadCurrentUserAvatar412JSONResponse{},
+				},
+				{
+					err:      user_types.ConflictError{Msg: "username is taken"},
+					expected: api_gen.UploadCurrentUserAvatar422JSONResponse{},
+				},
+			}
+			for _, c := range cases {
+				user := helpers.GenUser()
+				stored := media_types.StoredImage{Kind: media_types.ImageKindAvatar, Name: "stored.png", Url: "http://localhost/media/avatars/stored.png"}
+				userServiceMock := user_types_mocks.NewMockUserService(t)
+				mediaServiceMock := media_types_mocks.NewMockMediaService(t)
+				mediaServiceMock.EXPECT().UploadImage(mock.Anything, media_types.ImageKindAvatar, mock.Anything).Return(stored, nil)
+				userServiceMock.EXPECT().UpdateUser(mock.Anything, user.Id, mock.Anything, mo.Some(user.Version)).Return(user_types.User{}, c.err)
+				mediaServiceMock.EXPECT().DeleteImage(mock.Anything, stored.Kind, stored.Name).Return(nil)
+				underTest := routes.NewMediaRoutes(obs_types_mocks.NewMockLogger(t), auth_types_mocks.NewMockAuthService(t), userServiceMock, mediaServiceMock)
+
+				resp, err := underTest.UploadCurrentUserAvatar(auth_context.CtxWithUser(t.Context(), user), api_gen.UploadCurrentUserAvatarRequestObject{
+					Body: imageMultipartReader(t, helpers.GenImage(t, "png", 10, 10)),
+				})
+				require.NoError(t, err)
+				assert.IsType(t, c.expected, resp, "error %T", c.err)
+			}
+		})
+	})
+
+	t.Run("UploadArticleImage", func(t *testing.T) {
+		t.Parallel()
+
+		t.Run("should return a 422 if the image field is missing", func(t *testing.T) {
+			t.Parallel()
+			user := helpers.CreateUsers(t, f.UserService, 1)[0]
+
+			body := new(bytes.Buffer)
+			writer := multipart.NewWriter(body)
+			require.NoError(t, writer.WriteField("caption", "not an image"))
+			require.NoError(t, writer.Close())
+			req := helpers.WithAuthHeader(t, f.AuthService, user, httptest.NewRequest(http.MethodPost, "/media/articles", body))
+			req.Header.Set("Content-Type", writer.FormDataContentType())
+
+			rec := httptest.NewRecorder()
+			f.HttpHandler.GetHandler().ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		})
+
+		t.Run("should return a 201 with the url of the stored image", func(t *testing.T) {
+			t.Parallel()
+			user := helpers.CreateUsers(t, f.UserService, 1)[0]
+
+			req := helpers.UploadArticleImageRequest(t, f.AuthService, user, helpers.GenImage(t, "jpeg", 40, 20))
+			rec := httptest.NewRecorder()
+			f.HttpHandler.GetHandler().ServeHTTP(rec, req)
+			require.Equal(t, http.StatusCreated, rec.Code)
+
+			var resp api_gen.StoredImageResponseJSONResponse
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+			assert.Equal(t, 40, resp.Image.Width)
+			assert.Equal(t, 20, resp.Image.Height)
+
+			stored := getStored(t, resp.Image.Url)
+			require.Equal(t, http.StatusOK, stored.Code)
+			assert.Equal(t, "image/jpeg", stored.Header().Get("Content-Type"))
+			assert.Contains(t, stored.Header().Get("Cache-Control"), "immutable")
+		})
+	})
+
+	t.Run("GetMedia", func(t *testing.T) {
+		t.Parallel()
+
+		t.Run("should return a 404 if the image does not exist", func(t *testing.T) {
+			t.Parallel()
+
+			rec := httptest.NewRecorder()
+			f.HttpHandler.GetHandler().ServeHTTP(rec, helpers.GetMediaRequest("/media/avatars/missing.png"))
+
+			assert.Equal(t, http.StatusNotFound, rec.Code)
+		})
+	})
+}
+
+// imageMultipartReader is a multipart body with the image in its image field, as the generated handler passes it to
+// the route
+func imageMultipartReader(t *testing.T, image []byte) *multipart.Reader {
+	body := new(bytes.Buffer)
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile("image", "upload")
+	require.NoError(t, err)
+	_, err = part.Write(image)
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+	return multipart.NewReader(body, writer.Boundary())
+}
@@ -32,7 +32,7 @@ func (r *ProfileRoutes) GetProfileByUsername(ctx context.Context, request api_ge
 	eg, egCtx := errgroup.WithContext(ctx)
 	eg.Go(func() error {
 		var err error
-		user, err = r.userService.GetUserByUsername(egCtx, request.Username)
+		user, err = r.userService.ResolveUsername(egCtx, request.Username)
 		return err
 	})
 	eg.Go(func() error {
@@ -56,7 +56,7 @@ func (r *ProfileRoutes) GetProfileByUsername(ctx context.Context, request api_ge
 		}
 	}
 
-	return api_gen.GetProfileByUsername200JSONResponse{ProfileResponseJSONResponse: api_gen.ProfileResponseJSONResponse{Profile: transformers.ToApiProfile(user, isFollowing)}}, nil
+	return api_gen.GetProfileByUsername200JSONResponse{ProfileResponseJSONResponse: transformers.ToApiProfileResponse(user, isFollowing)}, nil
 }
 
 func (r *ProfileRoutes) UnfollowUserByUsername(ctx context.Context, request api_gen.UnfollowUserByUsernameRequestObject) (api_gen.UnfollowUserByUsernameResponseObject, error) {
@@ -77,7 +77,7 @@ func (r *ProfileRoutes) UnfollowUserByUsername(ctx context.Context, request api_
 		}
 	}
 
-	return api_gen.UnfollowUserByUsername200JSONResponse{ProfileResponseJSONResponse: api_gen.ProfileResponseJSONResponse{Profile: transformers.ToApiProfile(user, false)}}, nil
+	return api_gen.UnfollowUserByUsername200JSONResponse{ProfileResponseJSONResponse: transformers.ToApiProfileResponse(user, false)}, nil
 }
 
 func (r *ProfileRoutes) FollowUserByUsername(ctx context.Context, request api_gen.FollowUserByUsernameRequestObject) (api_gen.FollowUserByUsernameResponseObject, error) {
@@ -90,7 +90,8 @@ func (r *ProfileRoutes) FollowUserByUsername(ctx context.Context, request api_ge
 	if err != nil {
 		switch user_types.DomainError(err).(type) {
 		case user_types.NotFoundError,
-			user_types.CannotFollowYourselfError:
+			user_types.CannotFollowYourselfError,
+			user_types.CannotFollowBlockedUserError:
 			return api_gen.FollowUserByUsername422JSONResponse{
 				GenericErrorJSONResponse: transformers.ToApiError(err),
 			}, nil
@@ -99,5 +100,104 @@ func (r *ProfileRoutes) FollowUserByUsername(ctx context.Context, request api_ge
 		}
 	}
 
-	return api_gen.FollowUserByUsername200JSONResponse{ProfileResponseJSONResponse: api_gen.ProfileResponseJSONResponse{Profile: transformers.ToApiProfile(user, true)}}, nil
+	return api_gen.FollowUserByUsername200JSONResponse{ProfileResponseJSONResponse: transformers.ToApiProfileResponse(user, true)}, nil
+}
+
+func (r *ProfileRoutes) BlockUserByUsername(ctx context.Context, request api_gen.BlockUserByUsernameRequestObject) (api_gen.BlockUserByUsernameResponseObject, error) {
+	authUser := auth_context.UserFromCtx(ctx)
+	if authUser.IsNone() {
+		return api_gen.UnauthorizedResponse{}, nil
+	}
+
+	user, err := r.userService.BlockProfile(ctx, authUser.MustGet(), request.Username)
+	if err != nil {
+		switch user_types.DomainError(err).(type) {
+		case user_types.NotFoundError,
+			user_types.CannotBlockOrMuteYourselfError:
+			return api_gen.BlockUserByUsername422JSONResponse{
+				GenericErrorJSONResponse: transformers.ToApiError(err),
+			}, nil
+		default:
+			return nil, err
+		}
+	}
+
+	// blocking removes follows in both directions
+	return api_gen.BlockUserByUsername200JSONResponse{ProfileResponseJSONResponse: transformers.ToApiProfileResponse(user, false)}, nil
+}
+
+func (r *ProfileRoutes) UnblockUserByUsername(ctx context.Context, request api_gen.UnblockUserByUsernameRequestObject) (api_gen.UnblockUserByUsernameResponseObject, error) {
+	authUser := auth_context.UserFromCtx(ctx)
+	if authUser.IsNone() {
+		return api_gen.UnauthorizedResponse{}, nil
+	}
+
+	user, err := r.userService.UnblockProfile(ctx, authUser.MustGet(), request.Username)
+	if err != nil {
+		switch user_types.DomainError(err).(type) {
+		case user_types.NotFoundError:
+			return api_gen.UnblockUserByUsername422JSONResponse{
+				GenericErrorJSONResponse: transformers.ToApiError(err),
+			}, nil
+		default:
+			return nil, err
+		}
+	}
+
+	// unblocking does not restore follows
+	return api_gen.UnblockUserByUsername200JSONResponse{ProfileResponseJSONResponse: transformers.ToApiProfileResponse(user, false)}, nil
+}
+
+func (r *ProfileRoutes) MuteUserByUsername(ctx context.Context, request api_gen.MuteUserByUsernameRequestObject) (api_gen.MuteUserByUsernameResponseObject, error) {
+	authUser := auth_context.UserFromCtx(ctx)
+	if authUser.IsNone() {
+		return api_gen.UnauthorizedResponse{}, nil
+	}
+
+	user, err := r.userService.MuteProfile(ctx, authUser.MustGet(), request.Username)
+	if err != nil {
+		switch user_types.DomainError(err).(type) {
+		case user_types.NotFoundError,
+			user_types.CannotBlockOrMuteYourselfError:
+			return api_gen.MuteUserByUsername422JSONResponse{
+				GenericErrorJSONResponse: transformers.ToApiError(err),
+			}, nil
+		default:
+			return nil, err
+		}
+	}
+
+	// muting does not affect follows
+	isFollowing, err := r.userService.IsFollowing(ctx, authUser.MustGet(), user.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	return api_gen.MuteUserByUsername200JSONResponse{ProfileResponseJSONResponse: transformers.ToApiProfileResponse(user, isFollowing)}, nil
+}
+
+func (r *ProfileRoutes) UnmuteUserByUsername(ctx context.Context, request api_gen.UnmuteUserByUsernameRequestObject) (api_gen.UnmuteUserByUsernameResponseObject, error) {
+	authUser := auth_context.UserFromCtx(ctx)
+	if authUser.IsNone() {
+		return api_gen.UnauthorizedResponse{}, nil
+	}
+
+	user, err := r.userService.UnmuteProfile(ctx, authUser.MustGet(), request.Username)
+	if err != nil {
+		switch user_types.DomainError(err).(type) {
+		case user_types.NotFoundError:
+			return api_gen.UnmuteUserByUsername422JSONResponse{
+				GenericErrorJSONResponse: transformers.ToApiError(err),
+			}, nil
+		default:
+			return nil, err
+		}
+	}
+
+	isFollowing, err := r.userService.IsFollowing(ctx, authUser.MustGet(), user.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	return api_gen.UnmuteUserByUsername200JSONResponse{ProfileResponseJSONResponse: transformers.ToApiProfileResponse(user, isFollowing)}, nil
 }
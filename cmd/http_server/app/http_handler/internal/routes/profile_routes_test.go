@@ -7,6 +7,7 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"github.com/nimaeskandary/go-realworld/cmd/http_server/app/http_handler/internal/transformers"
 	"github.com/nimaeskandary/go-realworld/pkg/api_gen"
 	"github.com/nimaeskandary/go-realworld/pkg/test_utils/fixtures"
 	"github.com/nimaeskandary/go-realworld/pkg/test_utils/helpers"
@@ -35,6 +36,31 @@ func Test_ProfileRoutes(t *testing.T) {
 			assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
 		})
 
+		t.Run("should resolve a previous username to the renamed user", func(t *testing.T) {
+			t.Parallel()
+			user := helpers.CreateUsers(t, f.UserService, 1)[0]
+			previousUsername := user.Username
+
+			renamed, err := f.UserService.UpdateUser(t.Context(), user.Id, user_types.UpsertUserParams{
+				Username: "renamed-" + user.Id.String(),
+				Email:    user.Email,
+				Bio:      user.Bio,
+				Image:    user.Image,
+			}, mo.None[int64]())
+			require.NoError(t, err)
+
+			req := helpers.GetProfileByUserNameRequest(t, f.AuthService, mo.None[user_types.User](), previousUsername)
+			rec := httptest.NewRecorder()
+			f.HttpHandler.GetHandler().ServeHTTP(rec, req)
+
+			expected, marshalErr := json.Marshal(profileResponseBody(transformers.ToApiProfile(renamed, false)))
+			require.NoError(t, marshalErr)
+
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Equal(t, "/profiles/"+renamed.Username, rec.Header().Get("Content-Location"))
+			assert.Equal(t, expected, bytes.TrimSpace(rec.Body.Bytes()))
+		})
+
 		t.Run("should return profile", func(t *testing.T) {
 			t.Parallel()
 
@@ -94,13 +120,7 @@ func Test_ProfileRoutes(t *testing.T) {
 					rec := httptest.NewRecorder()
 					f.HttpHandler.GetHandler().ServeHTTP(rec, req)
 
-					expected, err := json.Marshal(
-						api_gen.GetProfileByUsername200JSONResponse{
-							ProfileResponseJSONResponse: api_gen.ProfileResponseJSONResponse{
-								Profile: tc.expectedProfile,
-							},
-						},
-					)
+					expected, err := json.Marshal(profileResponseBody(tc.expectedProfile))
 					require.NoError(t, err)
 
 					assert.Equal(t, http.StatusOK, rec.Code)
@@ -147,18 +167,12 @@ func Test_ProfileRoutes(t *testing.T) {
 			rec := httptest.NewRecorder()
 			f.HttpHandler.GetHandler().ServeHTTP(rec, req)
 
-			expected, err := json.Marshal(
-				api_gen.UnfollowUserByUsername200JSONResponse{
-					ProfileResponseJSONResponse: api_gen.ProfileResponseJSONResponse{
-						Profile: api_gen.Profile{
-							Username:  users[1].Username,
-							Bio:       users[1].Bio.OrElse(""),
-							Image:     users[1].Image.OrElse(""),
-							Following: false,
-						},
-					},
-				},
-			)
+			expected, err := json.Marshal(profileResponseBody(api_gen.Profile{
+				Username:  users[1].Username,
+				Bio:       users[1].Bio.OrElse(""),
+				Image:     users[1].Image.OrElse(""),
+				Following: false,
+			}))
 			require.NoError(t, err)
 
 			assert.Equal(t, http.StatusOK, rec.Code)
@@ -173,18 +187,12 @@ func Test_ProfileRoutes(t *testing.T) {
 			rec := httptest.NewRecorder()
 			f.HttpHandler.GetHandler().ServeHTTP(rec, req)
 
-			expected, err := json.Marshal(
-				api_gen.UnfollowUserByUsername200JSONResponse{
-					ProfileResponseJSONResponse: api_gen.ProfileResponseJSONResponse{
-						Profile: api_gen.Profile{
-							Username:  users[1].Username,
-							Bio:       users[1].Bio.OrElse(""),
-							Image:     users[1].Image.OrElse(""),
-							Following: false,
-						},
-					},
-				},
-			)
+			expected, err := json.Marshal(profileResponseBody(api_gen.Profile{
+				Username:  users[1].Username,
+				Bio:       users[1].Bio.OrElse(""),
+				Image:     users[1].Image.OrElse(""),
+				Following: false,
+			}))
 			require.NoError(t, err)
 
 			assert.Equal(t, http.StatusOK, rec.Code)
@@ -237,24 +245,41 @@ func Test_ProfileRoutes(t *testing.T) {
 			rec := httptest.NewRecorder()
 			f.HttpHandler.GetHandler().ServeHTTP(rec, req)
 
-			expected, err := json.Marshal(
-				api_gen.FollowUserByUsername200JSONResponse{
-					ProfileResponseJSONResponse: api_gen.ProfileResponseJSONResponse{
-						Profile: api_gen.Profile{
-							Username:  users[1].Username,
-							Bio:       users[1].Bio.OrElse(""),
-							Image:     users[1].Image.OrElse(""),
-							Following: true,
-						},
-					},
-				},
-			)
+			expected, err := json.Marshal(profileResponseBody(api_gen.Profile{
+				Username:  users[1].Username,
+				Bio:       users[1].Bio.OrElse(""),
+				Image:     users[1].Image.OrElse(""),
+				Following: true,
+			}))
 			require.NoError(t, err)
 
 			assert.Equal(t, http.StatusOK, rec.Code)
 			assert.Equal(t, expected, bytes.TrimSpace(rec.Body.Bytes()))
 		})
 
+		t.Run("should follow the renamed user by a previous username", func(t *testing.T) {
+			t.Parallel()
+			users := helpers.CreateUsers(t, f.UserService, 2)
+			previousUsername := users[1].Username
+
+			renamed, err := f.UserService.UpdateUser(t.Context(), users[1].Id, user_types.UpsertUserParams{
+				Username: "renamed-" + users[1].Id.String(),
+				Email:    users[1].Email,
+			}, mo.None[int64]())
+			require.NoError(t, err)
+
+			req := helpers.FollowUserByUsernameRequest(t, f.AuthService, users[0], previousUsername)
+			rec := httptest.NewRecorder()
+			f.HttpHandler.GetHandler().ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Equal(t, "/profiles/"+renamed.Username, rec.Header().Get("Content-Location"))
+
+			isFollowing, err := f.UserService.IsFollowing(t.Context(), users[0], renamed.Username)
+			require.NoError(t, err)
+			assert.True(t, isFollowing)
+		})
+
 		t.Run("should be idempotent if already following", func(t *testing.T) {
 			t.Parallel()
 			users := helpers.CreateUsers(t, f.UserService, 2)
@@ -266,22 +291,140 @@ func Test_ProfileRoutes(t *testing.T) {
 			rec := httptest.NewRecorder()
 			f.HttpHandler.GetHandler().ServeHTTP(rec, req)
 
-			expected, err := json.Marshal(
-				api_gen.FollowUserByUsername200JSONResponse{
-					ProfileResponseJSONResponse: api_gen.ProfileResponseJSONResponse{
-						Profile: api_gen.Profile{
-							Username:  users[1].Username,
-							Bio:       users[1].Bio.OrElse(""),
-							Image:     users[1].Image.OrElse(""),
-							Following: true,
-						},
-					},
-				},
-			)
+			expected, err := json.Marshal(profileResponseBody(api_gen.Profile{
+				Username:  users[1].Username,
+				Bio:       users[1].Bio.OrElse(""),
+				Image:     users[1].Image.OrElse(""),
+				Following: true,
+			}))
+			require.NoError(t, err)
+
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Equal(t, expected, bytes.TrimSpace(rec.Body.Bytes()))
+		})
+	})
+
+	t.Run("BlockUserByUsername", func(t *testing.T) {
+		t.Parallel()
+
+		t.Run("should return 401 if no auth header", func(t *testing.T) {
+			t.Parallel()
+			users := helpers.CreateUsers(t, f.UserService, 1)
+
+			req := helpers.BlockUserByUsernameRequest(t, f.AuthService, users[0], "someusername")
+			req.Header.Del("Authorization")
+			rec := httptest.NewRecorder()
+			f.HttpHandler.GetHandler().ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		})
+
+		t.Run("should return 422 if trying to block yourself", func(t *testing.T) {
+			t.Parallel()
+			users := helpers.CreateUsers(t, f.UserService, 1)
+
+			req := helpers.BlockUserByUsernameRequest(t, f.AuthService, users[0], users[0].Username)
+			rec := httptest.NewRecorder()
+			f.HttpHandler.GetHandler().ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		})
+
+		t.Run("should block the user and prevent following", func(t *testing.T) {
+			t.Parallel()
+			users := helpers.CreateUsers(t, f.UserService, 2)
+			_, followErr := f.UserService.FollowProfile(t.Context(), users[0], users[1].Username)
+			require.NoError(t, followErr)
+
+			req := helpers.BlockUserByUsernameRequest(t, f.AuthService, users[0], users[1].Username)
+			rec := httptest.NewRecorder()
+			f.HttpHandler.GetHandler().ServeHTTP(rec, req)
+
+			expected, err := json.Marshal(profileResponseBody(api_gen.Profile{
+				Username:  users[1].Username,
+				Bio:       users[1].Bio.OrElse(""),
+				Image:     users[1].Image.OrElse(""),
+				Following: false,
+			}))
+			require.NoError(t, err)
+
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Equal(t, expected, bytes.TrimSpace(rec.Body.Bytes()))
+
+			// the blocked user cannot follow back either
+			req = helpers.FollowUserByUsernameRequest(t, f.AuthService, users[1], users[0].Username)
+			rec = httptest.NewRecorder()
+			f.HttpHandler.GetHandler().ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		})
+	})
+
+	t.Run("UnblockUserByUsername", func(t *testing.T) {
+		t.Parallel()
+
+		t.Run("should unblock the user and allow following", func(t *testing.T) {
+			t.Parallel()
+			users := helpers.CreateUsers(t, f.UserService, 2)
+			_, err := f.UserService.BlockProfile(t.Context(), users[0], users[1].Username)
+			require.NoError(t, err)
+
+			req := helpers.UnblockUserByUsernameRequest(t, f.AuthService, users[0], users[1].Username)
+			rec := httptest.NewRecorder()
+			f.HttpHandler.GetHandler().ServeHTTP(rec, req)
+			assert.Equal(t, http.StatusOK, rec.Code)
+
+			req = helpers.FollowUserByUsernameRequest(t, f.AuthService, users[0], users[1].Username)
+			rec = httptest.NewRecorder()
+			f.HttpHandler.GetHandler().ServeHTTP(rec, req)
+			assert.Equal(t, http.StatusOK, rec.Code)
+		})
+	})
+
+	t.Run("MuteUserByUsername", func(t *testing.T) {
+		t.Parallel()
+
+		t.Run("should mute the user and keep following", func(t *testing.T) {
+			t.Parallel()
+			users := helpers.CreateUsers(t, f.UserService, 2)
+			_, followErr := f.UserService.FollowProfile(t.Context(), users[0], users[1].Username)
+			require.NoError(t, followErr)
+
+			req := helpers.MuteUserByUsernameRequest(t, f.AuthService, users[0], users[1].Username)
+			rec := httptest.NewRecorder()
+			f.HttpHandler.GetHandler().ServeHTTP(rec, req)
+
+			expected, err := json.Marshal(profileResponseBody(api_gen.Profile{
+				Username:  users[1].Username,
+				Bio:       users[1].Bio.OrElse(""),
+				Image:     users[1].Image.OrElse(""),
+				Following: true,
+			}))
 			require.NoError(t, err)
 
 			assert.Equal(t, http.StatusOK, rec.Code)
 			assert.Equal(t, expected, bytes.TrimSpace(rec.Body.Bytes()))
 		})
 	})
+
+	t.Run("UnmuteUserByUsername", func(t *testing.T) {
+		t.Parallel()
+
+		t.Run("should return 422 if profile does not exist", func(t *testing.T) {
+			t.Parallel()
+			users := helpers.CreateUsers(t, f.UserService, 1)
+
+			req := helpers.UnmuteUserByUsernameRequest(t, f.AuthService, users[0], "no-existo")
+			rec := httptest.NewRecorder()
+			f.HttpHandler.GetHandler().ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		})
+	})
+}
+
+func profileResponseBody(profile api_gen.Profile) any {
+	resp := api_gen.ProfileResponseJSONResponse{}
+	resp.Body.Profile = profile
+	return resp.Body
 }
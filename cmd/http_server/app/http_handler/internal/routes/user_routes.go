@@ -3,26 +3,31 @@ package routes
 import (
 	"context"
 	"fmt"
+	"io"
 
 	"github.com/nimaeskandary/go-realworld/cmd/http_server/app/http_handler/internal/transformers"
 	"github.com/nimaeskandary/go-realworld/pkg/api_gen"
 	"github.com/nimaeskandary/go-realworld/pkg/auth/context"
 	auth_types "github.com/nimaeskandary/go-realworld/pkg/auth/types"
+	data_export_types "github.com/nimaeskandary/go-realworld/pkg/data_export/types"
 	user_types "github.com/nimaeskandary/go-realworld/pkg/user/types"
 )
 
 type UserRoutes struct {
-	authService auth_types.AuthService
-	userService user_types.UserService
+	authService       auth_types.AuthService
+	userService       user_types.UserService
+	dataExportService data_export_types.DataExportService
 }
 
 func NewUserRoutes(
 	authService auth_types.AuthService,
 	userService user_types.UserService,
+	dataExportService data_export_types.DataExportService,
 ) *UserRoutes {
 	return &UserRoutes{
-		authService: authService,
-		userService: userService,
+		authService:       authService,
+		userService:       userService,
+		dataExportService: dataExportService,
 	}
 }
 
@@ -38,9 +43,7 @@ func (r *UserRoutes) GetCurrentUser(ctx context.Context, request api_gen.GetCurr
 	}
 
 	return api_gen.GetCurrentUser200JSONResponse{
-		UserResponseJSONResponse: api_gen.UserResponseJSONResponse{
-			User: transformers.ToApiUser(authUser.MustGet(), token.GetTokenString()),
-		},
+		VersionedUserResponseJSONResponse: transformers.ToApiVersionedUserResponse(authUser.MustGet(), token.GetTokenString()),
 	}, nil
 }
 
@@ -50,10 +53,20 @@ func (r *UserRoutes) UpdateCurrentUser(ctx context.Context, request api_gen.Upda
 		return api_gen.UnauthorizedResponse{}, nil
 	}
 	var err error
-	updatedUser, err := r.userService.UpdateUser(ctx, authUser.MustGet().Id, transformers.FromApiUpdateUser(request.Body.User, authUser.MustGet()))
+	expectedVersion, err := transformers.FromIfMatch(request.Params.IfMatch)
+	if err != nil {
+		return api_gen.UpdateCurrentUser412JSONResponse{
+			PreconditionFailedJSONResponse: api_gen.PreconditionFailedJSONResponse(transformers.ToApiError(err)),
+		}, nil
+	}
+	updatedUser, err := r.userService.UpdateUser(ctx, authUser.MustGet().Id, transformers.FromApiUpdateUser(request.Body.User, authUser.MustGet()), expectedVersion)
 
 	if err != nil {
 		switch user_types.DomainError(user_types.AsDomainError(err)).(type) {
+		case user_types.VersionConflictError:
+			return api_gen.UpdateCurrentUser412JSONResponse{
+				PreconditionFailedJSONResponse: api_gen.PreconditionFailedJSONResponse(transformers.ToApiError(err)),
+			}, nil
 		case
 			user_types.ConflictError,
 			user_types.NotFoundError,
@@ -72,9 +85,7 @@ func (r *UserRoutes) UpdateCurrentUser(ctx context.Context, request api_gen.Upda
 	}
 
 	return api_gen.UpdateCurrentUser200JSONResponse{
-		UserResponseJSONResponse: api_gen.UserResponseJSONResponse{
-			User: transformers.ToApiUser(updatedUser, token.GetTokenString()),
-		},
+		VersionedUserResponseJSONResponse: transformers.ToApiVersionedUserResponse(updatedUser, token.GetTokenString()),
 	}, nil
 }
 
@@ -134,3 +145,24 @@ func (r *UserRoutes) Login(ctx context.Context, request api_gen.LoginRequestObje
 		},
 	}, nil
 }
+
+func (r *UserRoutes) ExportCurrentUserData(ctx context.Context, request api_gen.ExportCurrentUserDataRequestObject) (api_gen.ExportCurrentUserDataResponseObject, error) {
+	authUser := auth_context.UserFromCtx(ctx)
+	if authUser.IsNone() {
+		return api_gen.UnauthorizedResponse{}, nil
+	}
+
+	// the archive is written into the pipe as the response body is read, so it is never fully buffered. Once streaming
+	// has started the status can no longer change, a failure part way through closes the body with an error instead
+	reader, writer := io.Pipe()
+	go func() {
+		err := r.dataExportService.ExportUserData(ctx, authUser.MustGet(), writer)
+		if err != nil {
+			_ = writer.CloseWithError(fmt.Errorf("error exporting user data: %w", err))
+			return
+		}
+		_ = writer.Close()
+	}()
+
+	return api_gen.ExportCurrentUserData200ApplicationzipResponse{Body: reader}, nil
+}
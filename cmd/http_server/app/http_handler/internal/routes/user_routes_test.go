@@ -1,10 +1,12 @@
 package routes_test
 
 import (
+	"archive/zip"
 	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -51,6 +53,7 @@ func Test_UserRoutes(t *testing.T) {
 			f.HttpHandler.GetHandler().ServeHTTP(rec, req)
 
 			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Equal(t, transformers.ToETag(user.Version), rec.Header().Get("ETag"))
 			validateUserResponse(t, f.AuthService, rec.Body.Bytes(), user)
 		})
 	})
@@ -99,6 +102,56 @@ func Test_UserRoutes(t *testing.T) {
 			assert.Contains(t, string(rec.Body.String()), "Email")
 		})
 
+		t.Run("should return a 412 if If-Match does not match the current version", func(t *testing.T) {
+			t.Parallel()
+			user := helpers.CreateUsers(t, f.UserService, 1)[0]
+
+			updatedUsername := "staleupdatedusername"
+			req := helpers.UpdateCurrentUserRequest(t, f.AuthService, user, api_gen.UpdateUser{Username: &updatedUsername})
+			req.Header.Set("If-Match", transformers.ToETag(user.Version+1))
+
+			rec := httptest.NewRecorder()
+			f.HttpHandler.GetHandler().ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusPreconditionFailed, rec.Code)
+			assert.Contains(t, rec.Body.String(), "VersionConflictError")
+
+			unchanged, err := f.UserService.GetUserByUsername(t.Context(), user.Username)
+			require.NoError(t, err)
+			assert.Equal(t, user.Version, unchanged.Version)
+		})
+
+		t.Run("should return a 412 if If-Match is malformed", func(t *testing.T) {
+			t.Parallel()
+			user := helpers.CreateUsers(t, f.UserService, 1)[0]
+
+			updatedUsername := "malformedupdatedusername"
+			req := helpers.UpdateCurrentUserRequest(t, f.AuthService, user, api_gen.UpdateUser{Username: &updatedUsername})
+			req.Header.Set("If-Match", "W/\"1\"")
+
+			rec := httptest.NewRecorder()
+			f.HttpHandler.GetHandler().ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusPreconditionFailed, rec.Code)
+		})
+
+		t.Run("should update and return the new ETag if If-Match matches the current version", func(t *testing.T) {
+			t.Parallel()
+			user := helpers.CreateUsers(t, f.UserService, 1)[0]
+
+			expectedUpdatedUser := user
+			expectedUpdatedUser.Username = "ifmatchupdatedusername"
+			req := helpers.UpdateCurrentUserRequest(t, f.AuthService, user, api_gen.UpdateUser{Username: &expectedUpdatedUser.Username})
+			req.Header.Set("If-Match", transformers.ToETag(user.Version))
+
+			rec := httptest.NewRecorder()
+			f.HttpHandler.GetHandler().ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Equal(t, transformers.ToETag(user.Version+1), rec.Header().Get("ETag"))
+			validateUserResponse(t, f.AuthService, rec.Body.Bytes(), expectedUpdatedUser)
+		})
+
 		t.Run("should successfully update partial fields", func(t *testing.T) {
 			t.Parallel()
 			user := helpers.CreateUsers(t, f.UserService, 1)[0]
@@ -141,6 +194,67 @@ func Test_UserRoutes(t *testing.T) {
 		})
 	})
 
+	t.Run("ExportCurrentUserData", func(t *testing.T) {
+		t.Parallel()
+
+		t.Run("should return a 401 if there is no auth header", func(t *testing.T) {
+			t.Parallel()
+			user := helpers.CreateUsers(t, f.UserService, 1)[0]
+
+			req := helpers.ExportCurrentUserDataRequest(t, f.AuthService, user)
+			req.Header.Del("Authorization")
+			rec := httptest.NewRecorder()
+			f.HttpHandler.GetHandler().ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		})
+
+		t.Run("should return a zip archive of the user's data", func(t *testing.T) {
+			t.Parallel()
+			users := helpers.CreateUsers(t, f.UserService, 3)
+			_, err := f.ArticleRepo.UpsertArticle(t.Context(), helpers.GenArticle(users[0].Id))
+			require.NoError(t, err)
+			_, followErr := f.UserService.FollowProfile(t.Context(), users[0], users[1].Username)
+			require.NoError(t, followErr)
+			_, followErr = f.UserService.FollowProfile(t.Context(), users[2], users[0].Username)
+			require.NoError(t, followErr)
+
+			req := helpers.ExportCurrentUserDataRequest(t, f.AuthService, users[0])
+			rec := httptest.NewRecorder()
+			f.HttpHandler.GetHandler().ServeHTTP(rec, req)
+
+			require.Equal(t, http.StatusOK, rec.Code)
+			assert.Equal(t, "application/zip", rec.Header().Get("Content-Type"))
+
+			archive, err := zip.NewReader(bytes.NewReader(rec.Body.Bytes()), int64(rec.Body.Len()))
+			require.NoError(t, err)
+
+			files := map[string][]byte{}
+			for _, file := range archive.File {
+				rc, err := file.Open()
+				require.NoError(t, err)
+				files[file.Name], err = io.ReadAll(rc)
+				require.NoError(t, err)
+				_ = rc.Close()
+			}
+
+			var articles []map[string]any
+			require.NoError(t, json.Unmarshal(files["articles.json"], &articles))
+			assert.Len(t, articles, 1)
+
+			var follows []map[string]any
+			require.NoError(t, json.Unmarshal(files["follows.json"], &follows))
+			require.Len(t, follows, 2)
+			assert.ElementsMatch(t, []map[string]any{
+				{"direction": "following", "username": users[1].Username},
+				{"direction": "follower", "username": users[2].Username},
+			}, []map[string]any{
+				{"direction": follows[0]["direction"], "username": follows[0]["username"]},
+				{"direction": follows[1]["direction"], "username": follows[1]["username"]},
+			})
+		})
+	})
+
 	t.Run("CreateUser", func(t *testing.T) {
 		t.Parallel()
 
@@ -0,0 +1,74 @@
+package transformers
+
+import (
+	"time"
+
+	"github.com/nimaeskandary/go-realworld/pkg/api_gen"
+	"github.com/nimaeskandary/go-realworld/pkg/article/types"
+	"github.com/nimaeskandary/go-realworld/pkg/user/types"
+
+	"github.com/google/uuid"
+)
+
+// ToApiArticle uses the article id as its slug. Tags and favorites are not modelled yet, so they are always empty
+func ToApiArticle(article article_types.Article, author user_types.User, isFollowing bool) api_gen.Article {
+	return api_gen.Article{
+		Slug:        article.Id.String(),
+		Title:       article.Title,
+		Description: article.Description,
+		Body:        article.Body,
+		TagList:     []string{},
+		CreatedAt:   time.UnixMilli(article.CreatedAtMillis).UTC(),
+		UpdatedAt:   time.UnixMilli(article.UpdatedAtMillis).UTC(),
+		Author:      ToApiProfile(author, isFollowing),
+	}
+}
+
+func ToApiVersionedSingleArticleResponse(article article_types.Article, author user_types.User, isFollowing bool) api_gen.VersionedSingleArticleResponseJSONResponse {
+	resp := api_gen.VersionedSingleArticleResponseJSONResponse{
+		Headers: api_gen.VersionedSingleArticleResponseResponseHeaders{ETag: ToETag(article.Version)},
+	}
+	resp.Body.Article = ToApiArticle(article, author, isFollowing)
+	return resp
+}
+
+func FromApiUpdateArticle(fromApi api_gen.UpdateArticle, currentArticle article_types.Article) article_types.Article {
+	updated := currentArticle
+	if fromApi.Title != nil {
+		updated.Title = *fromApi.Title
+	}
+	if fromApi.Description != nil {
+		updated.Description = *fromApi.Description
+	}
+	if fromApi.Body != nil {
+		updated.Body = *fromApi.Body
+	}
+	return updated
+}
+
+func ToApiArticleSearchResult(result article_types.ArticleSearchResult, author user_types.User, isFollowing bool) api_gen.ArticleSearchResult {
+	return api_gen.ArticleSearchResult{
+		Id:          result.Article.Id.String(),
+		Title:       result.Article.Title,
+		Description: result.Article.Description,
+		TagList:     result.Tags,
+		CreatedAt:   time.UnixMilli(result.Article.CreatedAtMillis).UTC(),
+		UpdatedAt:   time.UnixMilli(result.Article.UpdatedAtMillis).UTC(),
+		Author:      ToApiProfile(author, isFollowing),
+		Rank:        result.Rank,
+		Highlight:   result.Highlight,
+	}
+}
+
+// ToApiArticleSearchResponse leaves out results whose author is missing from authors, e.g. deleted since the search
+func ToApiArticleSearchResponse(results []article_types.ArticleSearchResult, authors map[uuid.UUID]user_types.User, following map[uuid.UUID]bool) api_gen.ArticleSearchResponseJSONResponse {
+	resp := api_gen.ArticleSearchResponseJSONResponse{Results: []api_gen.ArticleSearchResult{}}
+	for _, result := range results {
+		author, ok := authors[result.Article.AuthorUserId]
+		if !ok {
+			continue
+		}
+		resp.Results = append(resp.Results, ToApiArticleSearchResult(result, author, following[author.Id]))
+	}
+	return resp
+}
@@ -0,0 +1,38 @@
+package transformers
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/samber/mo"
+)
+
+func ToETag(version int64) string {
+	return fmt.Sprintf(`"%d"`, version)
+}
+
+// FromIfMatch returns the version an If-Match header requires, or none if the header is absent or `*`. Only a single
+// strong ETag is accepted, weak ETags can never match for a conditional update
+func FromIfMatch(ifMatch *string) (mo.Option[int64], error) {
+	if ifMatch == nil {
+		return mo.None[int64](), nil
+	}
+	value := strings.TrimSpace(*ifMatch)
+	if value == "*" {
+		return mo.None[int64](), nil
+	}
+
+	unquoted, ok := strings.CutPrefix(value, `"`)
+	if ok {
+		unquoted, ok = strings.CutSuffix(unquoted, `"`)
+	}
+	if !ok {
+		return mo.None[int64](), fmt.Errorf("If-Match must be a single strong ETag or *, got: %v", value)
+	}
+	version, err := strconv.ParseInt(unquoted, 10, 64)
+	if err != nil {
+		return mo.None[int64](), fmt.Errorf("If-Match does not match any version, got: %v", value)
+	}
+	return mo.Some(version), nil
+}
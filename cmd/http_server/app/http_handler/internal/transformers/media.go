@@ -0,0 +1,14 @@
+package transformers
+
+import (
+	"github.com/nimaeskandary/go-realworld/pkg/api_gen"
+	media_types "github.com/nimaeskandary/go-realworld/pkg/media/types"
+)
+
+func ToApiStoredImage(image media_types.StoredImage) api_gen.StoredImage {
+	return api_gen.StoredImage{
+		Url:    image.Url,
+		Width:  image.Width,
+		Height: image.Height,
+	}
+}
@@ -1,6 +1,8 @@
 package transformers
 
 import (
+	"net/url"
+
 	"github.com/nimaeskandary/go-realworld/pkg/api_gen"
 	"github.com/nimaeskandary/go-realworld/pkg/user/types"
 )
@@ -13,3 +15,11 @@ func ToApiProfile(user user_types.User, isFollowing bool) api_gen.Profile {
 		Following: isFollowing,
 	}
 }
+
+func ToApiProfileResponse(user user_types.User, isFollowing bool) api_gen.ProfileResponseJSONResponse {
+	resp := api_gen.ProfileResponseJSONResponse{
+		Headers: api_gen.ProfileResponseResponseHeaders{ContentLocation: "/profiles/" + url.PathEscape(user.Username)},
+	}
+	resp.Body.Profile = ToApiProfile(user, isFollowing)
+	return resp
+}
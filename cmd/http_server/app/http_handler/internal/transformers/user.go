@@ -17,6 +17,14 @@ func ToApiUser(user user_types.User, token string) api_gen.User {
 	}
 }
 
+func ToApiVersionedUserResponse(user user_types.User, token string) api_gen.VersionedUserResponseJSONResponse {
+	resp := api_gen.VersionedUserResponseJSONResponse{
+		Headers: api_gen.VersionedUserResponseResponseHeaders{ETag: ToETag(user.Version)},
+	}
+	resp.Body.User = ToApiUser(user, token)
+	return resp
+}
+
 func FromApiUpdateUser(fromApi api_gen.UpdateUser, currentUser user_types.User) user_types.UpsertUserParams {
 	return user_types.UpsertUserParams{
 		Username: func() string {
@@ -16,6 +16,8 @@ var nonAuthenticatedOperations = []operationId{
 	LoginOpId,
 	CreateUserOpId,
 	GetProfileByUsernameOpId,
+	GetMediaOpId,
+	SearchArticlesOpId,
 }
 
 // CreateAuthContext is a middleware that gets the auth header if it exists, and adds the auth user to the context.
@@ -19,6 +19,8 @@ const (
 	CreateUserOpId           operationId = "CreateUser"
 	GetCurrentUserOpId       operationId = "GetCurrentUser"
 	GetProfileByUsernameOpId operationId = "GetProfileByUsername"
+	GetMediaOpId             operationId = "GetMedia"
+	SearchArticlesOpId       operationId = "SearchArticles"
 )
 
 var errUnexpected = fmt.Errorf("unexpected error occured")
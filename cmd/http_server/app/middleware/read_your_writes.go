@@ -0,0 +1,19 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/nimaeskandary/go-realworld/pkg/api_gen"
+	db_types "github.com/nimaeskandary/go-realworld/pkg/database/types"
+)
+
+// CreateReadYourWritesScope is a middleware that scopes database read routing to the request, once a route has written,
+// its later reads go to the primary instead of a replica that may not have caught up yet
+func CreateReadYourWritesScope() api_gen.StrictMiddlewareFunc {
+	return func(next api_gen.StrictHandlerFunc, opId string) api_gen.StrictHandlerFunc {
+		return func(ctx context.Context, w http.ResponseWriter, r *http.Request, request any) (any, error) {
+			return next(db_types.CtxWithReadYourWrites(ctx), w, r, request)
+		}
+	}
+}
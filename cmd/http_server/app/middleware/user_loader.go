@@ -0,0 +1,23 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/nimaeskandary/go-realworld/pkg/api_gen"
+	"github.com/nimaeskandary/go-realworld/pkg/auth/context"
+	"github.com/nimaeskandary/go-realworld/pkg/user/loader"
+	user_types "github.com/nimaeskandary/go-realworld/pkg/user/types"
+)
+
+// CreateUserLoader is a middleware that adds a request scoped user loader to the context, so handlers building lists
+// can batch author and following lookups. It must be called after CreateAuthContext in the middleware chain, the auth
+// user becomes the loader's viewer
+func CreateUserLoader(userService user_types.UserService) api_gen.StrictMiddlewareFunc {
+	return func(next api_gen.StrictHandlerFunc, opId string) api_gen.StrictHandlerFunc {
+		return func(ctx context.Context, w http.ResponseWriter, r *http.Request, request any) (any, error) {
+			loader := user_loader.NewUserLoader(userService, auth_context.UserFromCtx(ctx))
+			return next(user_loader.CtxWithLoader(ctx, loader), w, r, request)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package middleware_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/nimaeskandary/go-realworld/cmd/http_server/app/middleware"
+	"github.com/nimaeskandary/go-realworld/pkg/auth/context"
+	"github.com/nimaeskandary/go-realworld/pkg/test_utils/helpers"
+	"github.com/nimaeskandary/go-realworld/pkg/user/loader"
+	user_types_mocks "github.com/nimaeskandary/go-realworld/pkg/user/types/mocks"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_CreateUserLoader(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should add a loader for the auth user to the context", func(t *testing.T) {
+		t.Parallel()
+		userService := user_types_mocks.NewMockUserService(t)
+		authUser := helpers.GenUser()
+		targetId := uuid.New()
+		userService.EXPECT().AreFollowing(mock.Anything, authUser, []uuid.UUID{targetId}).Return(map[uuid.UUID]bool{targetId: true}, nil)
+
+		nextCalled := false
+		nextFn := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request any) (response any, err error) {
+			nextCalled = true
+			loader := user_loader.LoaderFromCtx(ctx)
+			assert.True(t, loader.IsPresent())
+
+			// following lookups are made as the auth user
+			isFollowing, followingErr := loader.MustGet().IsFollowing(ctx, targetId)
+			assert.NoError(t, followingErr)
+			assert.True(t, isFollowing)
+			return "success", nil
+		}
+
+		handler := middleware.CreateUserLoader(userService)(nextFn, string(middleware.GetCurrentUserOpId))
+		ctx := auth_context.CtxWithUser(t.Context(), authUser)
+		resp, err := handler(ctx, httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/user", nil), nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "success", resp)
+		assert.True(t, nextCalled)
+	})
+
+	t.Run("should add a loader without a viewer for anonymous requests", func(t *testing.T) {
+		t.Parallel()
+		userService := user_types_mocks.NewMockUserService(t)
+
+		nextFn := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request any) (response any, err error) {
+			isFollowing, followingErr := user_loader.LoaderFromCtx(ctx).MustGet().IsFollowing(ctx, uuid.New())
+			assert.NoError(t, followingErr)
+			assert.False(t, isFollowing)
+			return "success", nil
+		}
+
+		handler := middleware.CreateUserLoader(userService)(nextFn, string(middleware.GetProfileByUsernameOpId))
+		_, err := handler(t.Context(), httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/profiles/someone", nil), nil)
+		assert.NoError(t, err)
+	})
+}
@@ -2,7 +2,11 @@ package app
 
 import (
 	"flag"
+	"fmt"
 	"log"
+	"os"
+	"slices"
+	"strings"
 
 	"github.com/nimaeskandary/go-realworld/pkg/util"
 )
@@ -13,13 +17,41 @@ const (
 	ActionRollback   string = "rollback"
 	ActionRollbackTo string = "rollback-to"
 	ActionStatus     string = "status"
+	ActionVerify     string = "verify"
+	ActionCreate     string = "create"
+	ActionValidate   string = "validate"
+	ActionUpByOne    string = "up-by-one"
+	ActionRedo       string = "redo"
+	ActionReset      string = "reset"
+	ActionList       string = "list"
+	ActionSnapshot   string = "snapshot"
+	ActionDiff       string = "diff"
+)
+
+// allTargetsActions are the actions that can run against every target database at once
+var allTargetsActions = []string{ActionApplyAll, ActionStatus, ActionVerify, ActionValidate, ActionSnapshot, ActionDiff}
+
+const (
+	OutputText string = "text"
+	OutputJson string = "json"
 )
 
 type Args struct {
-	ConfigPath     string `validate:"required"`
-	TargetDatabase string `validate:"required,oneof=realworld_app"`
-	Action         string `validate:"required,oneof=apply apply-all rollback rollback-to status"`
-	Version        *int64 `validate:"required_if=Action apply,required_if=Action rollback,required_if=Action rollback-to"`
+	// ConfigPath is not needed by create, validate and list, which do not connect to a database
+	ConfigPath string `validate:"required_if=Action apply,required_if=Action apply-all,required_if=Action rollback,required_if=Action rollback-to,required_if=Action status,required_if=Action verify,required_if=Action up-by-one,required_if=Action redo,required_if=Action reset,required_if=Action snapshot,required_if=Action diff"`
+	// TargetDatabase is the name of a registered target database, every action but list needs it or All
+	TargetDatabase string `validate:"excluded_with=All"`
+	// All runs the action against every registered target database, in name order
+	All     bool   `validate:"excluded_if=Action list"`
+	Action  string `validate:"required,oneof=apply apply-all rollback rollback-to status verify create validate up-by-one redo reset list snapshot diff"`
+	Version *int64 `validate:"required_if=Action apply,required_if=Action rollback,required_if=Action rollback-to"`
+	// DryRun prints the migrations an apply or rollback action would run, along with their sql, instead of running them
+	DryRun bool `validate:"excluded_if=Action status,excluded_if=Action verify,excluded_if=Action create,excluded_if=Action validate,excluded_if=Action list,excluded_if=Action snapshot,excluded_if=Action diff"`
+	// Name and MigrationType are the migration to create
+	Name          string `validate:"required_if=Action create,excluded_unless=Action create"`
+	MigrationType string `validate:"required_if=Action create,excluded_unless=Action create,omitempty,oneof=sql go"`
+	// Output is the format of the status, verify, validate, list, snapshot and diff reports, the other actions only log
+	Output string `validate:"omitempty,oneof=text json"`
 }
 
 func ParseArgs() Args {
@@ -27,9 +59,14 @@ func ParseArgs() Args {
 	var version_ int64
 
 	flag.StringVar(&args.ConfigPath, "config-path", "", "path to the config file")
-	flag.StringVar(&args.TargetDatabase, "target-database", "", "target database for migrations")
-	flag.StringVar(&args.Action, "action", "", "migration action to perform: apply, apply-all, rollback, rollback-to, status")
+	flag.StringVar(&args.TargetDatabase, "target-database", "", "target database for migrations, see the list action")
+	flag.BoolVar(&args.All, "all", false, "run the action against every target database: "+strings.Join(allTargetsActions, ", "))
+	flag.StringVar(&args.Action, "action", "", "migration action to perform: apply, apply-all, rollback, rollback-to, up-by-one, redo, reset, status, verify, create, validate, list, snapshot, diff")
 	flag.Int64Var(&version_, "version", 0, "target version for up-to and down-to actions")
+	flag.StringVar(&args.Name, "name", "", "snake case name of the migration to create, e.g. add_user_bio")
+	flag.StringVar(&args.MigrationType, "type", "", "type of the migration to create: sql, or go for a code migration")
+	flag.StringVar(&args.Output, "output", OutputText, "format of the status, verify, validate, list, snapshot and diff reports: text or json")
+	flag.BoolVar(&args.DryRun, "dry-run", false, "print the sql an apply or rollback action would run, without running it")
 
 	flag.Parse()
 
@@ -41,9 +78,17 @@ func ParseArgs() Args {
 
 	validator := util.NewValidator()
 	err := validator.Struct(args)
+	switch {
+	case err != nil:
+	case args.Action != ActionList && !args.All && args.TargetDatabase == "":
+		err = fmt.Errorf("target-database or all is required")
+	case args.All && !slices.Contains(allTargetsActions, args.Action):
+		err = fmt.Errorf("%v cannot run against all target databases", args.Action)
+	}
 	if err != nil {
 		flag.Usage()
-		log.Fatalf("invalid arguments: %v", err)
+		log.Printf("invalid arguments: %v", err)
+		os.Exit(ExitInvalidArgs)
 	}
 
 	return args
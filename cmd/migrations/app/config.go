@@ -1,9 +1,58 @@
 package app
 
 import (
+	"fmt"
+
+	"github.com/nimaeskandary/go-realworld/pkg/config"
+	config_types "github.com/nimaeskandary/go-realworld/pkg/config/types"
 	db_types "github.com/nimaeskandary/go-realworld/pkg/database/types"
+	obs_types "github.com/nimaeskandary/go-realworld/pkg/observability/types"
+
+	"github.com/goccy/go-yaml"
 )
 
+// Config is the part of the config file shared by all target databases, each database's config is read from its own
+// key, see targetDbConfig
 type Config struct {
-	RealWorldAppDb db_types.RealWorldAppDbConfig `json:"realworld_app_db" validate:"required"`
+	Slog obs_types.SlogLoggerConfig `json:"slog" validate:"required"`
+}
+
+// targetDbConfig reads the SqlDbConfig of a target database from its key in the config file, resolving secrets and
+// validating it like the rest of the config
+func targetDbConfig(secretParser config_types.SecretParser, key string, configData []byte) (db_types.SqlDbConfig, error) {
+	var sections map[string]any
+	if err := yaml.Unmarshal(configData, &sections); err != nil {
+		return db_types.SqlDbConfig{}, fmt.Errorf("failed to parse YAML config: %w", err)
+	}
+	section, ok := sections[key]
+	if !ok {
+		return db_types.SqlDbConfig{}, fmt.Errorf("config has no %v database config", key)
+	}
+
+	sectionData, err := yaml.Marshal(section)
+	if err != nil {
+		return db_types.SqlDbConfig{}, fmt.Errorf("failed to read %v database config: %w", key, err)
+	}
+	loader, err := config.NewYamlConfigLoader[db_types.SqlDbConfig](secretParser, sectionData)
+	if err != nil {
+		return db_types.SqlDbConfig{}, fmt.Errorf("invalid %v database config: %w", key, err)
+	}
+	return loader.GetConfig(), nil
+}
+
+// scratchDbConfigKey is the config of the database server the expected schema is built on, see ScratchDbConfig
+const scratchDbConfigKey = "scratch_db"
+
+// ScratchDbConfig reads the config scratch databases are created with, to build the expected schema of the target
+// database on. For postgres, the db_name is suffixed with the target's name, and names the template database kept
+// migrated on that server, each scratch database is a clone of it. For sqlite each one is a new temp file
+func ScratchDbConfig(target db_types.MigrationTarget, configData []byte) (db_types.SqlDbConfig, error) {
+	cfg, err := targetDbConfig(config.NewIdentitySecretParser(), scratchDbConfigKey, configData)
+	if err != nil {
+		return db_types.SqlDbConfig{}, err
+	}
+	if cfg.Driver != db_types.DialectSqlite {
+		cfg.DBName = fmt.Sprintf("%v_%v", cfg.DBName, target.Name)
+	}
+	return cfg, nil
 }
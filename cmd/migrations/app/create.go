@@ -0,0 +1,123 @@
+package app
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"github.com/nimaeskandary/go-realworld/pkg/database"
+	db_types "github.com/nimaeskandary/go-realworld/pkg/database/types"
+)
+
+//go:embed templates/*.tmpl
+var templates embed.FS
+
+var migrationTemplates = template.Must(template.ParseFS(templates, "templates/*.tmpl"))
+
+var migrationNamePattern = regexp.MustCompile(`^[a-z][a-z0-9]*(_[a-z0-9]+)*$`)
+
+// ValidateMigrations checks the versions of the target database's migrations, see database.CheckMigrationVersions,
+// returning the latest version
+func ValidateMigrations(target db_types.MigrationTarget) (int64, []db_types.MigrationVersionIssue, error) {
+	return database.CheckMigrationVersions(target.MigrationsProvider)
+}
+
+// CreateMigration writes a migration following the latest version of the target database, from the templates. A sql
+// migration gets a file for each dialect, a code migration one file registered for every dialect. It must be run
+// from the project root, and it returns the paths of the files it wrote
+func CreateMigration(target db_types.MigrationTarget, name string, migrationType string) ([]string, error) {
+	if !migrationNamePattern.MatchString(name) {
+		return nil, fmt.Errorf("migration name must be snake case, e.g. add_user_bio: %v", name)
+	}
+	if _, err := os.Stat(target.SourceDir); err != nil {
+		return nil, fmt.Errorf("migrations directory %v not found, run from the project root: %w", target.SourceDir, err)
+	}
+
+	latest, issues, err := database.CheckMigrationVersions(target.MigrationsProvider)
+	if err != nil {
+		return nil, err
+	}
+	if len(issues) > 0 {
+		return nil, fmt.Errorf("%v migration version issues, fix them before creating a migration, see the validate action",
+			len(issues))
+	}
+	version := latest + 1
+	fileName := fmt.Sprintf("%05d_%v", version, name)
+
+	switch migrationType {
+	case "sql":
+		var content bytes.Buffer
+		if err := migrationTemplates.ExecuteTemplate(&content, "migration.sql.tmpl", nil); err != nil {
+			return nil, fmt.Errorf("failed to render sql migration: %w", err)
+		}
+		var paths []string
+		for _, dialect := range db_types.Dialects {
+			sqlDir, ok := target.SqlDirs[dialect]
+			if !ok {
+				return nil, fmt.Errorf("no %v sql migrations directory for %v", dialect, target.Name)
+			}
+			paths = append(paths, filepath.Join(target.SourceDir, sqlDir, fileName+".sql"))
+		}
+		return paths, writeNewFiles(paths, content.Bytes())
+
+	case "go":
+		var content bytes.Buffer
+		err := migrationTemplates.ExecuteTemplate(&content, "migration.go.tmpl", map[string]any{
+			"Package": filepath.Base(target.SourceDir),
+			"Type":    migrationTypeName(name),
+			"Version": version,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to render code migration: %w", err)
+		}
+		formatted, err := format.Source(content.Bytes())
+		if err != nil {
+			return nil, fmt.Errorf("failed to format code migration: %w", err)
+		}
+		paths := []string{filepath.Join(target.SourceDir, fileName+".go")}
+		return paths, writeNewFiles(paths, formatted)
+
+	default:
+		return nil, fmt.Errorf("unknown migration type: %v", migrationType)
+	}
+}
+
+// migrationTypeName is the unexported type of a code migration, e.g. addUserBioMigration for add_user_bio
+func migrationTypeName(name string) string {
+	words := strings.Split(name, "_")
+	for i := 1; i < len(words); i++ {
+		words[i] = strings.ToUpper(words[i][:1]) + words[i][1:]
+	}
+	return strings.Join(words, "") + "Migration"
+}
+
+// writeNewFiles writes content to each path, failing rather than overwriting an existing file. Files written before a
+// failure are removed, so a failed create leaves nothing behind
+func writeNewFiles(paths []string, content []byte) error {
+	var written []string
+	for _, path := range paths {
+		err := func() error {
+			f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+			if err != nil {
+				return err
+			}
+			_, err = f.Write(content)
+			return errors.Join(err, f.Close())
+		}()
+		if err != nil {
+			for _, path := range written {
+				_ = os.Remove(path)
+			}
+			return fmt.Errorf("failed to write migration %v: %w", path, err)
+		}
+		written = append(written, path)
+	}
+	return nil
+}
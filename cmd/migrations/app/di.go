@@ -1,37 +1,32 @@
 package app
 
 import (
-	"fmt"
-
 	"github.com/nimaeskandary/go-realworld/pkg/config"
 	config_types "github.com/nimaeskandary/go-realworld/pkg/config/types"
 	"github.com/nimaeskandary/go-realworld/pkg/database"
-	"github.com/nimaeskandary/go-realworld/pkg/database/migrations/realworld_app"
 	db_types "github.com/nimaeskandary/go-realworld/pkg/database/types"
+	obs "github.com/nimaeskandary/go-realworld/pkg/observability"
+	obs_types "github.com/nimaeskandary/go-realworld/pkg/observability/types"
 
 	"go.uber.org/fx"
 )
 
-func realworldAppDbModules(configData []byte) []fx.Option {
+// ModuleList provides a migration runner for the target database, see database.RegisterMigrationTarget
+func ModuleList(target db_types.MigrationTarget, configData []byte) []fx.Option {
 	return []fx.Option{
 		config.NewIdentitySecretParserModule(),
 		config.NewYamlConfigLoaderModule[Config](configData),
 		fx.Provide(
-			func(cfg config_types.ConfigLoader[Config]) db_types.RealWorldAppDbConfig {
-				return cfg.GetConfig().RealWorldAppDb
+			func(cfg config_types.ConfigLoader[Config]) obs_types.SlogLoggerConfig {
+				return cfg.GetConfig().Slog
+			},
+			func(secretParser config_types.SecretParser) (db_types.SqlDbConfig, error) {
+				return targetDbConfig(secretParser, target.ConfigKey, configData)
 			},
 		),
-		database.NewPostgresRealworldAppDbModule[db_types.SQLDatabase](),
-		realworld_app.NewMigrationProviderModule(),
+		fx.Supply(fx.Annotate(target.MigrationsProvider, fx.As(new(db_types.MigrationsProvider)))),
+		target.DbModule,
 		database.NewGooseMigrationRunnerModule(),
-	}
-}
-
-func ModuleList(targetDatabase string, configData []byte) ([]fx.Option, error) {
-	switch targetDatabase {
-	case "realworld_app":
-		return realworldAppDbModules(configData), nil
-	default:
-		return nil, fmt.Errorf("unknown target database: %v", targetDatabase)
+		obs.NewSlogLoggerModule(),
 	}
 }
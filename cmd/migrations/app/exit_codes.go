@@ -0,0 +1,32 @@
+package app
+
+// exit codes of the migrations command, so a deploy pipeline can tell a failure from a finding
+const (
+	ExitOk = 0
+	// ExitFailed is an action that failed, or could not start
+	ExitFailed = 1
+	// ExitInvalidArgs matches the flag package's code for arguments it cannot parse
+	ExitInvalidArgs = 2
+	// ExitPendingMigrations is a status with migrations not applied yet
+	ExitPendingMigrations = 3
+	// ExitMigrationIssues is a verify that found drift, a validate that found version issues, or a diff that found
+	// schema differences
+	ExitMigrationIssues = 4
+)
+
+// exitCodeSeverity orders the exit codes, when an action runs against several databases the command exits with the
+// most severe
+var exitCodeSeverity = map[int]int{
+	ExitOk:                0,
+	ExitPendingMigrations: 1,
+	ExitMigrationIssues:   2,
+	ExitInvalidArgs:       3,
+	ExitFailed:            4,
+}
+
+func MostSevereExitCode(a int, b int) int {
+	if exitCodeSeverity[b] > exitCodeSeverity[a] {
+		return b
+	}
+	return a
+}
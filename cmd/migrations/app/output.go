@@ -0,0 +1,313 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	db_types "github.com/nimaeskandary/go-realworld/pkg/database/types"
+)
+
+// WritePlan writes the migrations of a dry run as a sql script, with each migration's version and direction in a
+// comment above its statements
+func WritePlan(w io.Writer, plan []db_types.PlannedMigration) error {
+	var b strings.Builder
+	if len(plan) == 0 {
+		b.WriteString("-- no migrations to run\n")
+	}
+	for _, migration := range plan {
+		direction := "up"
+		if !migration.Up {
+			direction = "down"
+		}
+		fmt.Fprintf(&b, "-- version %v %v: %v\n", migration.Version, direction, migration.Source)
+		if len(migration.Statements) == 0 {
+			b.WriteString("-- code migration, its statements are only known when it runs\n")
+		}
+		for _, statement := range migration.Statements {
+			b.WriteString(statement)
+			b.WriteString("\n")
+		}
+		b.WriteString("\n")
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+type statusReport struct {
+	TargetDatabase string                `json:"target_database"`
+	CurrentVersion int64                 `json:"current_version"`
+	LatestVersion  int64                 `json:"latest_version"`
+	Pending        int                   `json:"pending"`
+	Migrations     []migrationStatusJson `json:"migrations"`
+}
+
+type migrationStatusJson struct {
+	Version    int64                        `json:"version"`
+	State      db_types.MigrationState      `json:"state"`
+	AppliedAt  *time.Time                   `json:"applied_at,omitempty"`
+	SourceType db_types.MigrationSourceType `json:"source_type"`
+	Source     string                       `json:"source"`
+}
+
+// WriteStatus writes the state of each migration, as a table or json
+func WriteStatus(w io.Writer, output string, targetDatabase string, currentVersion int64, latestVersion int64, statuses []db_types.MigrationStatus) error {
+	report := statusReport{TargetDatabase: targetDatabase, CurrentVersion: currentVersion, LatestVersion: latestVersion, Migrations: []migrationStatusJson{}}
+	for _, status := range statuses {
+		if status.State == db_types.MigrationPending {
+			report.Pending++
+		}
+		report.Migrations = append(report.Migrations, migrationStatusJson{
+			Version:    status.Version,
+			State:      status.State,
+			AppliedAt:  status.AppliedAt.ToPointer(),
+			SourceType: status.SourceType,
+			Source:     status.Source,
+		})
+	}
+	if output == OutputJson {
+		return writeJson(w, report)
+	}
+
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "VERSION\tSTATE\tAPPLIED AT\tTYPE\tSOURCE")
+	for _, migration := range report.Migrations {
+		appliedAt := "-"
+		if migration.AppliedAt != nil {
+			appliedAt = migration.AppliedAt.UTC().Format(time.DateTime)
+		}
+		fmt.Fprintf(table, "%v\t%v\t%v\t%v\t%v\n",
+			migration.Version, migration.State, appliedAt, migration.SourceType, migration.Source)
+	}
+	fmt.Fprintf(table, "\ncurrent version %v, latest %v, %v pending\n", currentVersion, latestVersion, report.Pending)
+	return table.Flush()
+}
+
+type driftReport struct {
+	TargetDatabase string               `json:"target_database"`
+	Drift          []migrationDriftJson `json:"drift"`
+}
+
+type migrationDriftJson struct {
+	Version int64                       `json:"version"`
+	Kind    db_types.MigrationDriftKind `json:"kind"`
+	Source  string                      `json:"source"`
+}
+
+// WriteDrift writes one line per applied migration that does not match its source, or the drift as json
+func WriteDrift(w io.Writer, output string, targetDatabase string, drift []db_types.MigrationDrift) error {
+	if output == OutputJson {
+		report := driftReport{TargetDatabase: targetDatabase, Drift: []migrationDriftJson{}}
+		for _, d := range drift {
+			report.Drift = append(report.Drift, migrationDriftJson{Version: d.Version, Kind: d.Kind, Source: d.Source})
+		}
+		return writeJson(w, report)
+	}
+
+	var b strings.Builder
+	for _, d := range drift {
+		source := d.Source
+		if source == "" {
+			source = "no source"
+		}
+		fmt.Fprintf(&b, "%-10v version %v (%v)\n", d.Kind, d.Version, source)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+type versionIssuesReport struct {
+	TargetDatabase string                      `json:"target_database"`
+	LatestVersion  int64                       `json:"latest_version"`
+	Issues         []migrationVersionIssueJson `json:"issues"`
+}
+
+type migrationVersionIssueJson struct {
+	Version int64                              `json:"version"`
+	Kind    db_types.MigrationVersionIssueKind `json:"kind"`
+	Dialect string                             `json:"dialect,omitempty"`
+	Sources []string                           `json:"sources"`
+}
+
+// WriteVersionIssues writes one line per migration version issue, or the issues as json
+func WriteVersionIssues(w io.Writer, output string, targetDatabase string, latestVersion int64, issues []db_types.MigrationVersionIssue) error {
+	if output == OutputJson {
+		report := versionIssuesReport{TargetDatabase: targetDatabase, LatestVersion: latestVersion, Issues: []migrationVersionIssueJson{}}
+		for _, issue := range issues {
+			sources := issue.Sources
+			if sources == nil {
+				sources = []string{}
+			}
+			report.Issues = append(report.Issues, migrationVersionIssueJson{
+				Version: issue.Version, Kind: issue.Kind, Dialect: issue.Dialect, Sources: sources,
+			})
+		}
+		return writeJson(w, report)
+	}
+
+	var b strings.Builder
+	for _, issue := range issues {
+		scope := issue.Dialect
+		if scope == "" {
+			scope = "all dialects"
+		}
+		fmt.Fprintf(&b, "%-12v version %v, %v", issue.Kind, issue.Version, scope)
+		if len(issue.Sources) > 0 {
+			fmt.Fprintf(&b, ": %v", strings.Join(issue.Sources, ", "))
+		}
+		b.WriteString("\n")
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+type schemaReport struct {
+	TargetDatabase string      `json:"target_database"`
+	Dialect        string      `json:"dialect"`
+	Tables         []tableJson `json:"tables"`
+}
+
+type tableJson struct {
+	Name        string           `json:"name"`
+	Columns     []columnJson     `json:"columns"`
+	Indexes     []indexJson      `json:"indexes"`
+	Constraints []constraintJson `json:"constraints"`
+}
+
+type columnJson struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Nullable bool   `json:"nullable"`
+	Default  string `json:"default,omitempty"`
+}
+
+type indexJson struct {
+	Name       string `json:"name"`
+	Definition string `json:"definition"`
+}
+
+type constraintJson struct {
+	Name       string `json:"name"`
+	Type       string `json:"type"`
+	Definition string `json:"definition"`
+}
+
+// WriteSchema writes a schema snapshot as json, or as text with a line per table followed by an indented line per
+// column, index and constraint, which is stable enough to diff with other tools
+func WriteSchema(w io.Writer, output string, targetDatabase string, snapshot db_types.SchemaSnapshot) error {
+	if output == OutputJson {
+		report := schemaReport{TargetDatabase: targetDatabase, Dialect: snapshot.Dialect, Tables: []tableJson{}}
+		for _, table := range snapshot.Tables {
+			t := tableJson{Name: table.Name, Columns: []columnJson{}, Indexes: []indexJson{}, Constraints: []constraintJson{}}
+			for _, c := range table.Columns {
+				t.Columns = append(t.Columns, columnJson{Name: c.Name, Type: c.Type, Nullable: c.Nullable, Default: c.Default})
+			}
+			for _, i := range table.Indexes {
+				t.Indexes = append(t.Indexes, indexJson{Name: i.Name, Definition: i.Definition})
+			}
+			for _, c := range table.Constraints {
+				t.Constraints = append(t.Constraints, constraintJson{Name: c.Name, Type: c.Type, Definition: c.Definition})
+			}
+			report.Tables = append(report.Tables, t)
+		}
+		return writeJson(w, report)
+	}
+
+	var b strings.Builder
+	for _, table := range snapshot.Tables {
+		fmt.Fprintf(&b, "table %v\n", table.Name)
+		for _, c := range table.Columns {
+			// an untyped sqlite column without constraints has no definition
+			fmt.Fprintf(&b, "  %v\n", strings.TrimSpace(fmt.Sprintf("column %v %v", c.Name, c.Definition())))
+		}
+		for _, i := range table.Indexes {
+			fmt.Fprintf(&b, "  index %v %v\n", i.Name, i.Definition)
+		}
+		for _, c := range table.Constraints {
+			fmt.Fprintf(&b, "  constraint %v %v\n", c.Name, c.Definition)
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+type schemaDiffReport struct {
+	TargetDatabase string                 `json:"target_database"`
+	Differences    []schemaDifferenceJson `json:"differences"`
+}
+
+type schemaDifferenceJson struct {
+	Kind     db_types.SchemaDifferenceKind `json:"kind"`
+	Object   string                        `json:"object"`
+	Expected string                        `json:"expected,omitempty"`
+	Actual   string                        `json:"actual,omitempty"`
+}
+
+// WriteSchemaDifferences writes one line per difference between the expected and actual schema, with the expected
+// and actual definitions on indented lines below it, or the differences as json
+func WriteSchemaDifferences(w io.Writer, output string, targetDatabase string, differences []db_types.SchemaDifference) error {
+	if output == OutputJson {
+		report := schemaDiffReport{TargetDatabase: targetDatabase, Differences: []schemaDifferenceJson{}}
+		for _, d := range differences {
+			report.Differences = append(report.Differences, schemaDifferenceJson{
+				Kind: d.Kind, Object: d.Object, Expected: d.Expected, Actual: d.Actual,
+			})
+		}
+		return writeJson(w, report)
+	}
+
+	var b strings.Builder
+	for _, d := range differences {
+		fmt.Fprintf(&b, "%-10v %v\n", d.Kind, d.Object)
+		if d.Expected != "" {
+			fmt.Fprintf(&b, "  expected: %v\n", d.Expected)
+		}
+		if d.Actual != "" {
+			fmt.Fprintf(&b, "  actual:   %v\n", d.Actual)
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+type targetsReport struct {
+	Targets []targetJson `json:"targets"`
+}
+
+type targetJson struct {
+	Name      string `json:"name"`
+	ConfigKey string `json:"config_key"`
+	SourceDir string `json:"source_dir"`
+}
+
+// WriteTargets writes the registered target databases, as a table or json
+func WriteTargets(w io.Writer, output string, targets []db_types.MigrationTarget) error {
+	report := targetsReport{Targets: []targetJson{}}
+	for _, target := range targets {
+		report.Targets = append(report.Targets, targetJson{Name: target.Name, ConfigKey: target.ConfigKey, SourceDir: target.SourceDir})
+	}
+	if output == OutputJson {
+		return writeJson(w, report)
+	}
+
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "NAME\tCONFIG KEY\tSOURCE DIR")
+	for _, target := range report.Targets {
+		fmt.Fprintf(table, "%v\t%v\t%v\n", target.Name, target.ConfigKey, target.SourceDir)
+	}
+	return table.Flush()
+}
+
+func writeJson(w io.Writer, report any) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(report)
+}
@@ -0,0 +1,36 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nimaeskandary/go-realworld/pkg/database"
+	db_types "github.com/nimaeskandary/go-realworld/pkg/database/types"
+	db_config_provider "github.com/nimaeskandary/go-realworld/pkg/test_utils/db_config_provider"
+)
+
+// ExpectedSchema applies every migration of the target database to a scratch database and snapshots the schema they
+// build. The scratch database is dropped afterwards
+func ExpectedSchema(ctx context.Context, target db_types.MigrationTarget, scratchCfg db_types.SqlDbConfig) (db_types.SchemaSnapshot, error) {
+	provider := db_config_provider.NewSqlDbConfigProvider(scratchCfg, target.MigrationsProvider)
+	cfg, err := provider.GetFreshDbConfig(ctx)
+	if err != nil {
+		return db_types.SchemaSnapshot{}, fmt.Errorf("failed to create a migrated scratch database: %w", err)
+	}
+	defer func() { _ = provider.Cleanup(ctx, cfg) }()
+
+	db, err := database.NewSQLDatabase(cfg, nil)
+	if err != nil {
+		return db_types.SchemaSnapshot{}, fmt.Errorf("failed to connect to the scratch database: %w", err)
+	}
+	if err := db.Start(ctx); err != nil {
+		return db_types.SchemaSnapshot{}, fmt.Errorf("failed to start the scratch database: %w", err)
+	}
+	defer func() { _ = db.Stop(ctx) }()
+
+	snapshot, err := database.SnapshotSchema(ctx, db)
+	if err != nil {
+		return db_types.SchemaSnapshot{}, fmt.Errorf("failed to snapshot the scratch database schema: %w", err)
+	}
+	return snapshot, nil
+}
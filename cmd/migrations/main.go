@@ -2,10 +2,14 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"os"
 
 	"github.com/nimaeskandary/go-realworld/cmd/migrations/app"
+	"github.com/nimaeskandary/go-realworld/pkg/database"
+	// registers the migrations of every database
+	_ "github.com/nimaeskandary/go-realworld/pkg/database/migrations"
 	db_types "github.com/nimaeskandary/go-realworld/pkg/database/types"
 	"github.com/nimaeskandary/go-realworld/pkg/util"
 )
@@ -13,27 +17,94 @@ import (
 func main() {
 	ctx := context.Background()
 	cleanupManager := util.NewCleanupManager(ctx, true)
-	defer cleanupManager.Cleanup()
 
-	args := app.ParseArgs()
+	code := run(ctx, cleanupManager, app.ParseArgs())
+	cleanupManager.Cleanup()
+	os.Exit(code)
+}
+
+// run performs the action against each target database, returning the exit code. Reports go to stdout, logs to
+// stderr. With all target databases, a failure on one does not stop the others
+func run(ctx context.Context, cleanupManager util.CleanupManager, args app.Args) int {
+	if args.Action == app.ActionList {
+		if err := app.WriteTargets(os.Stdout, args.Output, database.MigrationTargets()); err != nil {
+			log.Printf("failed to write target databases: %v", err)
+			return app.ExitFailed
+		}
+		return app.ExitOk
+	}
+
+	var targets []db_types.MigrationTarget
+	if args.All {
+		targets = database.MigrationTargets()
+	} else {
+		target, err := database.GetMigrationTarget(args.TargetDatabase)
+		if err != nil {
+			log.Printf("%v, see the list action", err)
+			return app.ExitInvalidArgs
+		}
+		targets = append(targets, target)
+	}
+
+	code := app.ExitOk
+	for _, target := range targets {
+		code = app.MostSevereExitCode(code, runTarget(ctx, cleanupManager, args, target))
+	}
+	return code
+}
+
+func runTarget(ctx context.Context, cleanupManager util.CleanupManager, args app.Args, target db_types.MigrationTarget) int {
+	// create and validate work on the migration sources, without a database
+	switch args.Action {
+	case app.ActionCreate:
+		paths, err := app.CreateMigration(target, args.Name, args.MigrationType)
+		if err != nil {
+			log.Printf("failed to create migration: %v", err)
+			return app.ExitFailed
+		}
+		for _, path := range paths {
+			log.Printf("created %v", path)
+		}
+		return app.ExitOk
+
+	case app.ActionValidate:
+		latest, issues, err := app.ValidateMigrations(target)
+		if err != nil {
+			log.Printf("failed to validate %v migrations: %v", target.Name, err)
+			return app.ExitFailed
+		}
+		if err := app.WriteVersionIssues(os.Stdout, args.Output, target.Name, latest, issues); err != nil {
+			log.Printf("failed to write migration version issues: %v", err)
+			return app.ExitFailed
+		}
+		if len(issues) > 0 {
+			log.Printf("%v migration version issues on database %v", len(issues), target.Name)
+			return app.ExitMigrationIssues
+		}
+		log.Printf("migration versions 1 to %v of database %v are valid", latest, target.Name)
+		return app.ExitOk
+	}
 
 	// setup deps
 
 	configData, err := os.ReadFile(args.ConfigPath)
 	if err != nil {
-		log.Fatalf("failed to read config file at %v: %v", args.ConfigPath, err)
+		log.Printf("failed to read config file at %v: %v", args.ConfigPath, err)
+		return app.ExitFailed
 	}
 
-	moduleList, err := app.ModuleList(args.TargetDatabase, configData)
-	if err != nil {
-		log.Fatalf("failed to create module list: %v", err)
+	// snapshot builds the expected schema on a scratch database, the target database is not connected to
+	if args.Action == app.ActionSnapshot {
+		return snapshotSchema(ctx, args, target, configData)
 	}
 
 	var migrationRunner db_types.SqlMigrationRunner
-	fxApp := util.CreateFxAppAndExtract(moduleList, &migrationRunner)
+	var db db_types.SQLDatabase
+	fxApp := util.CreateFxAppAndExtract(app.ModuleList(target, configData), &migrationRunner, &db)
 
 	if err := fxApp.Start(ctx); err != nil {
-		log.Fatalf("dependency injection system failed to start: %v", err)
+		log.Printf("dependency injection system failed to start for database %v: %v", target.Name, err)
+		return app.ExitFailed
 	}
 
 	cleanupManager.RegisterCleanupFunc(func() {
@@ -42,55 +113,222 @@ func main() {
 		}
 	})
 
+	if args.Action == app.ActionDiff {
+		return diffSchema(ctx, db, args, target, configData)
+	}
+
 	// run migrations
 
-	err = nil
+	if args.DryRun {
+		if err := dryRun(ctx, migrationRunner, args); err != nil {
+			log.Printf("failed to plan %v: %v", args.Action, err)
+			return app.ExitFailed
+		}
+		return app.ExitOk
+	}
+
+	code := runMigrations(ctx, migrationRunner, args, target)
+
+	version, err := migrationRunner.CurrentVersion(ctx)
+	if err != nil {
+		log.Printf("failed to get current migration version: %v", err)
+		return app.ExitFailed
+	}
+	log.Printf("current migration version of database %v: %v", target.Name, version)
+
+	return code
+}
+
+func runMigrations(ctx context.Context, migrationRunner db_types.SqlMigrationRunner, args app.Args, target db_types.MigrationTarget) int {
 	switch args.Action {
 
 	case app.ActionApplyAll:
-		log.Printf("running %v on database %v...", args.Action, args.TargetDatabase)
+		log.Printf("running %v on database %v...", args.Action, target.Name)
 		if err := migrationRunner.ApplyAll(ctx); err != nil {
 			log.Printf("failed to apply migrations: %v", err)
+			return app.ExitFailed
 		}
 
 	case app.ActionApply:
-		log.Printf("running %v version %v on database %v...", args.Action, *args.Version, args.TargetDatabase)
+		log.Printf("running %v version %v on database %v...", args.Action, *args.Version, target.Name)
 		if err := migrationRunner.Apply(ctx, *args.Version); err != nil {
 			log.Printf("failed to apply migration version %v: %v", *args.Version, err)
+			return app.ExitFailed
+		}
+
+	case app.ActionUpByOne:
+		log.Printf("running %v on database %v...", args.Action, target.Name)
+		version, err := migrationRunner.UpByOne(ctx)
+		if err != nil {
+			log.Printf("failed to apply the next migration: %v", err)
+			return app.ExitFailed
+		}
+		if version == 0 {
+			log.Printf("no pending migrations")
+		} else {
+			log.Printf("applied migration version %v", version)
 		}
 
 	case app.ActionRollback:
-		log.Printf("running %v version %v on database %v...", args.Action, *args.Version, args.TargetDatabase)
+		log.Printf("running %v version %v on database %v...", args.Action, *args.Version, target.Name)
 		if err := migrationRunner.Rollback(ctx, *args.Version); err != nil {
 			log.Printf("failed to rollback migration version %v: %v", *args.Version, err)
+			return app.ExitFailed
 		}
 
 	case app.ActionRollbackTo:
-		log.Printf("running %v to version %v on database %v...", args.Action, *args.Version, args.TargetDatabase)
+		log.Printf("running %v to version %v on database %v...", args.Action, *args.Version, target.Name)
 		if err := migrationRunner.RollbackTo(ctx, *args.Version); err != nil {
 			log.Printf("failed to rollback migrations down to version %v: %v", *args.Version, err)
+			return app.ExitFailed
+		}
+
+	case app.ActionRedo:
+		log.Printf("running %v on database %v...", args.Action, target.Name)
+		version, err := migrationRunner.Redo(ctx)
+		if err != nil {
+			log.Printf("failed to redo the latest migration: %v", err)
+			return app.ExitFailed
+		}
+		if version == 0 {
+			log.Printf("no applied migrations to redo")
+		} else {
+			log.Printf("redid migration version %v", version)
+		}
+
+	case app.ActionReset:
+		log.Printf("running %v on database %v...", args.Action, target.Name)
+		if err := migrationRunner.RollbackTo(ctx, 0); err != nil {
+			log.Printf("failed to rollback all migrations: %v", err)
+			return app.ExitFailed
 		}
 
 	case app.ActionStatus:
-		status, err := migrationRunner.Status(ctx)
+		statuses, err := migrationRunner.Status(ctx)
 		if err != nil {
 			log.Printf("failed to get migration status: %v", err)
+			return app.ExitFailed
+		}
+		version, err := migrationRunner.CurrentVersion(ctx)
+		if err != nil {
+			log.Printf("failed to get current migration version: %v", err)
+			return app.ExitFailed
+		}
+		if err := app.WriteStatus(os.Stdout, args.Output, target.Name, version, migrationRunner.LatestVersion(), statuses); err != nil {
+			log.Printf("failed to write migration status: %v", err)
+			return app.ExitFailed
+		}
+		for _, status := range statuses {
+			if status.State == db_types.MigrationPending {
+				return app.ExitPendingMigrations
+			}
 		}
-		log.Printf("%s", status)
+
+	case app.ActionVerify:
+		drift, err := migrationRunner.Verify(ctx)
+		if err != nil {
+			log.Printf("failed to verify migrations: %v", err)
+			return app.ExitFailed
+		}
+		if err := app.WriteDrift(os.Stdout, args.Output, target.Name, drift); err != nil {
+			log.Printf("failed to write migration drift: %v", err)
+			return app.ExitFailed
+		}
+		if len(drift) > 0 {
+			log.Printf("%v migrations of database %v do not match their sources", len(drift), target.Name)
+			return app.ExitMigrationIssues
+		}
+		log.Printf("applied migrations of database %v match their sources", target.Name)
 
 	default:
 		log.Printf("unknown migration action: %v", args.Action)
+		return app.ExitInvalidArgs
 	}
-	passed := err == nil
 
-	version, err := migrationRunner.CurrentVersion(ctx)
+	return app.ExitOk
+}
+
+func snapshotSchema(ctx context.Context, args app.Args, target db_types.MigrationTarget, configData []byte) int {
+	scratchCfg, err := app.ScratchDbConfig(target, configData)
 	if err != nil {
-		log.Printf("failed to get current migration version: %v", err)
+		log.Printf("failed to read scratch database config: %v", err)
+		return app.ExitFailed
+	}
+	expected, err := app.ExpectedSchema(ctx, target, scratchCfg)
+	if err != nil {
+		log.Printf("failed to build the expected schema of database %v: %v", target.Name, err)
+		return app.ExitFailed
+	}
+	if err := app.WriteSchema(os.Stdout, args.Output, target.Name, expected); err != nil {
+		log.Printf("failed to write schema: %v", err)
+		return app.ExitFailed
+	}
+	return app.ExitOk
+}
+
+// diffSchema compares the schema of the target database with the one its migrations build on a scratch database,
+// which finds changes made outside of migrations, or migrations edited after they were applied
+func diffSchema(ctx context.Context, db db_types.SQLDatabase, args app.Args, target db_types.MigrationTarget, configData []byte) int {
+	scratchCfg, err := app.ScratchDbConfig(target, configData)
+	if err != nil {
+		log.Printf("failed to read scratch database config: %v", err)
+		return app.ExitFailed
+	}
+	actual, err := database.SnapshotSchema(ctx, db)
+	if err != nil {
+		log.Printf("failed to snapshot the schema of database %v: %v", target.Name, err)
+		return app.ExitFailed
+	}
+	expected, err := app.ExpectedSchema(ctx, target, scratchCfg)
+	if err != nil {
+		log.Printf("failed to build the expected schema of database %v: %v", target.Name, err)
+		return app.ExitFailed
+	}
+	if expected.Dialect != actual.Dialect {
+		log.Printf("the scratch database is %v but database %v is %v, schemas of different dialects cannot be compared",
+			expected.Dialect, target.Name, actual.Dialect)
+		return app.ExitFailed
 	}
-	log.Printf("current migration version: %v", version)
 
-	if !passed {
-		cleanupManager.Cleanup()
-		os.Exit(1)
+	differences := database.DiffSchemas(expected, actual)
+	if err := app.WriteSchemaDifferences(os.Stdout, args.Output, target.Name, differences); err != nil {
+		log.Printf("failed to write schema differences: %v", err)
+		return app.ExitFailed
+	}
+	if len(differences) > 0 {
+		log.Printf("%v schema differences on database %v", len(differences), target.Name)
+		return app.ExitMigrationIssues
 	}
+	log.Printf("schema of database %v matches its migrations", target.Name)
+	return app.ExitOk
+}
+
+// dryRun prints the sql the action would run, to stdout so it can be saved or piped to another tool
+func dryRun(ctx context.Context, migrationRunner db_types.SqlMigrationRunner, args app.Args) error {
+	var plan []db_types.PlannedMigration
+	var err error
+	switch args.Action {
+	case app.ActionApplyAll:
+		plan, err = migrationRunner.PlanApplyAll(ctx)
+	case app.ActionApply:
+		plan, err = migrationRunner.PlanApply(ctx, *args.Version)
+	case app.ActionUpByOne:
+		plan, err = migrationRunner.PlanApplyAll(ctx)
+		plan = plan[:min(len(plan), 1)]
+	case app.ActionRollback:
+		plan, err = migrationRunner.PlanRollback(ctx, *args.Version)
+	case app.ActionRollbackTo:
+		plan, err = migrationRunner.PlanRollbackTo(ctx, *args.Version)
+	case app.ActionRedo:
+		plan, err = migrationRunner.PlanRedo(ctx)
+	case app.ActionReset:
+		plan, err = migrationRunner.PlanRollbackTo(ctx, 0)
+	default:
+		return fmt.Errorf("%v has no dry run", args.Action)
+	}
+	if err != nil {
+		return err
+	}
+
+	return app.WritePlan(os.Stdout, plan)
 }
@@ -0,0 +1,49 @@
+package app
+
+import (
+	"flag"
+	"log"
+
+	"github.com/nimaeskandary/go-realworld/pkg/util"
+)
+
+const (
+	ModeService string = "service"
+	ModeBulk    string = "bulk"
+)
+
+type Args struct {
+	ConfigPath string `validate:"required"`
+	// Mode is how the data is written, see SeedThroughServices and SeedBulk
+	Mode  string `validate:"required,oneof=service bulk"`
+	Users int    `validate:"gte=1"`
+	// Seed picks the dataset, the same seed and number of users always generate the same data
+	Seed int64
+	// Until is the date the timestamps lead up to, as yyyy-mm-dd, it is fixed rather than now so a seed always gives
+	// the same data
+	Until string `validate:"required,datetime=2006-01-02"`
+	// BatchSize is how many rows bulk mode writes at a time
+	BatchSize int `validate:"gte=1"`
+}
+
+func ParseArgs() Args {
+	args := Args{}
+
+	flag.StringVar(&args.ConfigPath, "config-path", "", "path to the config file")
+	flag.StringVar(&args.Mode, "mode", ModeService, "how to write the data: service, through the services so validations apply, or bulk, straight to the tables for large datasets")
+	flag.IntVar(&args.Users, "users", 100, "number of users to generate, follows, articles and favorites scale with it")
+	flag.Int64Var(&args.Seed, "seed", 1, "seed of the generated data, the same seed and number of users generate the same data")
+	flag.StringVar(&args.Until, "until", "2026-01-01", "date as yyyy-mm-dd, the generated users and articles are created in the year before it")
+	flag.IntVar(&args.BatchSize, "batch-size", 10000, "rows written at a time in bulk mode")
+
+	flag.Parse()
+
+	validator := util.NewValidator()
+	err := validator.Struct(args)
+	if err != nil {
+		flag.Usage()
+		log.Fatalf("invalid arguments: %v", err)
+	}
+
+	return args
+}
@@ -0,0 +1,15 @@
+package app
+
+import (
+	db_types "github.com/nimaeskandary/go-realworld/pkg/database/types"
+	obs_types "github.com/nimaeskandary/go-realworld/pkg/observability/types"
+	soft_delete_types "github.com/nimaeskandary/go-realworld/pkg/soft_delete/types"
+	user_types "github.com/nimaeskandary/go-realworld/pkg/user/types"
+)
+
+type Config struct {
+	Slog           obs_types.SlogLoggerConfig         `json:"slog" validate:"required"`
+	RealWorldAppDb db_types.RealWorldAppDbConfig      `json:"realworld_app_db" validate:"required"`
+	SoftDelete     soft_delete_types.SoftDeleteConfig `json:"soft_delete" validate:"required"`
+	User           user_types.UserConfig              `json:"user" validate:"required"`
+}
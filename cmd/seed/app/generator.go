@@ -0,0 +1,313 @@
+package app
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/samber/mo"
+)
+
+// the streams of random numbers, each entity draws from the stream of its kind at its own index
+const (
+	streamSetup = iota
+	streamUser
+	streamFollows
+	streamArticleCount
+	streamArticle
+	streamFavorites
+)
+
+const (
+	maxFollowsPerUser   = 2000
+	maxArticlesPerUser  = 100
+	maxFavoritesPerUser = 1000
+	maxTagsPerArticle   = 4
+	// userAgeDays is how far back users were created, their articles come after them
+	userAgeDays = 365
+)
+
+type SeedUser struct {
+	Id        uuid.UUID
+	Username  string
+	Email     string
+	Bio       mo.Option[string]
+	CreatedAt time.Time
+}
+
+// SeedRelation is a follow of the user, or a favorite of the article, at Index
+type SeedRelation struct {
+	Index     int
+	CreatedAt time.Time
+}
+
+type SeedArticle struct {
+	Id          uuid.UUID
+	AuthorIndex int
+	Title       string
+	Description string
+	Body        string
+	Tags        []string
+	CreatedAt   time.Time
+}
+
+// Generator produces the seed dataset. Each user, follow list, article and favorite list is derived from the seed and
+// its index alone, so a seed and size give the same data whatever order it is written in, and a dataset too big for
+// memory can be streamed. Follows, articles and favorites are spread the way they are on social sites: most users
+// follow and write a little, while a few popular users and articles get most of the follows and favorites
+type Generator struct {
+	seed  uint64
+	users int
+	now   time.Time
+	// articleOffsets holds the global index of each user's first article, followed by the total number of articles
+	articleOffsets []int
+	userRanking    ranking
+	articleRanking ranking
+}
+
+// NewGenerator sizes the dataset for the number of users. Timestamps are in the year before now, which is fixed by
+// the caller so the timestamps are as deterministic as the rest
+func NewGenerator(seed int64, users int, now time.Time) *Generator {
+	g := &Generator{seed: uint64(seed), users: users, now: now, articleOffsets: make([]int, users+1)}
+	for i := range users {
+		g.articleOffsets[i+1] = g.articleOffsets[i] + g.articleCount(i)
+	}
+
+	r := g.rng(streamSetup, 0)
+	g.userRanking = newRanking(users, r)
+	g.articleRanking = newRanking(g.Articles(), r)
+	return g
+}
+
+func (g *Generator) Users() int {
+	return g.users
+}
+
+func (g *Generator) Articles() int {
+	return g.articleOffsets[g.users]
+}
+
+func (g *Generator) User(i int) SeedUser {
+	r := g.rng(streamUser, i)
+	// the id and creation time are drawn first, see UserId and userCreatedAt
+	id := randomUuid(r)
+	createdAt := g.drawUserCreatedAt(r)
+	first := pick(r, firstNames)
+	last := pick(r, lastNames)
+	user := SeedUser{
+		Id:        id,
+		Username:  fmt.Sprintf("%v_%v_%v", first, last, i),
+		Email:     fmt.Sprintf("%v.%v.%v@example.com", first, last, i),
+		CreatedAt: createdAt,
+	}
+	// most users write a bio
+	if r.IntN(10) < 7 {
+		user.Bio = mo.Some(sentence(r, 6, 14))
+	}
+	return user
+}
+
+// UserId is the id of User(i), without generating the rest of the user
+func (g *Generator) UserId(i int) uuid.UUID {
+	return randomUuid(g.rng(streamUser, i))
+}
+
+func (g *Generator) userCreatedAt(i int) time.Time {
+	r := g.rng(streamUser, i)
+	randomUuid(r)
+	return g.drawUserCreatedAt(r)
+}
+
+func (g *Generator) drawUserCreatedAt(r *rand.Rand) time.Time {
+	return g.now.Add(-time.Duration(r.Int64N(userAgeDays * 24 * int64(time.Hour)))).Truncate(time.Millisecond)
+}
+
+// Follows returns the users that user i follows, popular users are more likely to be followed
+func (g *Generator) Follows(i int) []SeedRelation {
+	if g.users < 2 {
+		return nil
+	}
+	r := g.rng(streamFollows, i)
+	count := int(rand.NewZipf(r, 1.7, 4, uint64(min(g.users-1, maxFollowsPerUser))).Uint64())
+	popularity := rand.NewZipf(r, 1.1, 1, uint64(g.users-1))
+	followed := pickDistinct(count, func() int {
+		return g.userRanking.index(int(popularity.Uint64()))
+	}, func(index int) bool { return index == i })
+
+	createdAt := g.userCreatedAt(i)
+	return lo.Map(followed, func(index int, _ int) SeedRelation {
+		return SeedRelation{Index: index, CreatedAt: g.between(r, later(createdAt, g.userCreatedAt(index)))}
+	})
+}
+
+// ArticleCount returns the number of articles user i wrote
+func (g *Generator) ArticleCount(i int) int {
+	return g.articleOffsets[i+1] - g.articleOffsets[i]
+}
+
+func (g *Generator) articleCount(i int) int {
+	r := g.rng(streamArticleCount, i)
+	return int(rand.NewZipf(r, 1.8, 1, maxArticlesPerUser).Uint64())
+}
+
+// Article returns article j of user i
+func (g *Generator) Article(i int, j int) SeedArticle {
+	r := g.rng(streamArticle, g.articleOffsets[i]+j)
+	// the id and creation time are drawn first, see ArticleId and articleCreatedAt
+	id := randomUuid(r)
+	createdAt := g.between(r, g.userCreatedAt(i))
+
+	var body []string
+	for range 2 + r.IntN(4) {
+		var paragraph []string
+		for range 3 + r.IntN(4) {
+			paragraph = append(paragraph, sentence(r, 8, 20))
+		}
+		body = append(body, strings.Join(paragraph, " "))
+	}
+
+	tagPopularity := rand.NewZipf(r, 1.2, 1, uint64(len(tags)-1))
+	articleTags := pickDistinct(r.IntN(maxTagsPerArticle+1), func() int {
+		return int(tagPopularity.Uint64())
+	}, func(int) bool { return false })
+
+	return SeedArticle{
+		Id:          id,
+		AuthorIndex: i,
+		Title:       title(r),
+		Description: sentence(r, 8, 16),
+		Body:        strings.Join(body, "\n\n"),
+		Tags:        lo.Map(articleTags, func(index int, _ int) string { return tags[index] }),
+		CreatedAt:   createdAt,
+	}
+}
+
+// ArticleId is the id of the article at a global index, without generating the rest of the article
+func (g *Generator) ArticleId(index int) uuid.UUID {
+	return randomUuid(g.rng(streamArticle, index))
+}
+
+func (g *Generator) articleCreatedAt(index int) time.Time {
+	// the author is the first user whose articles end after index
+	author := sort.Search(g.users, func(i int) bool { return g.articleOffsets[i+1] > index })
+	r := g.rng(streamArticle, index)
+	randomUuid(r)
+	return g.between(r, g.userCreatedAt(author))
+}
+
+// Favorites returns the articles user i favorited, by global index, popular articles are more likely to be favorited.
+// A user can favorite their own articles
+func (g *Generator) Favorites(i int) []SeedRelation {
+	total := g.Articles()
+	if total == 0 {
+		return nil
+	}
+	r := g.rng(streamFavorites, i)
+	count := int(rand.NewZipf(r, 1.8, 2, uint64(min(total, maxFavoritesPerUser))).Uint64())
+	popularity := rand.NewZipf(r, 1.1, 1, uint64(total-1))
+	favorited := pickDistinct(count, func() int {
+		return g.articleRanking.index(int(popularity.Uint64()))
+	}, func(int) bool { return false })
+
+	createdAt := g.userCreatedAt(i)
+	return lo.Map(favorited, func(index int, _ int) SeedRelation {
+		return SeedRelation{Index: index, CreatedAt: g.between(r, later(createdAt, g.articleCreatedAt(index)))}
+	})
+}
+
+// between draws a time from after to now
+func (g *Generator) between(r *rand.Rand, after time.Time) time.Time {
+	return after.Add(time.Duration(r.Int64N(int64(g.now.Sub(after)) + 1))).Truncate(time.Millisecond)
+}
+
+func later(a time.Time, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+func (g *Generator) rng(stream int, index int) *rand.Rand {
+	return rand.New(rand.NewPCG(g.seed, uint64(stream)<<48|uint64(index)))
+}
+
+// pickDistinct draws up to count distinct values that are not excluded. Draws from a skewed distribution repeat, so
+// it gives up after a few times count draws rather than looking for the rare remaining values
+func pickDistinct(count int, draw func() int, excluded func(int) bool) []int {
+	var picked []int
+	for attempt := 0; len(picked) < count && attempt < count*4; attempt++ {
+		value := draw()
+		if !excluded(value) && !slices.Contains(picked, value) {
+			picked = append(picked, value)
+		}
+	}
+	return picked
+}
+
+// ranking spreads popularity ranks over indices, so the most popular users and articles are not simply the first
+// ones created. It maps rank to index with a stride coprime with n, which visits every index once
+type ranking struct {
+	n      int
+	stride int
+	shift  int
+}
+
+func newRanking(n int, r *rand.Rand) ranking {
+	if n == 0 {
+		return ranking{}
+	}
+	stride := 1 + r.IntN(n)
+	for gcd(stride, n) != 1 {
+		stride++
+	}
+	return ranking{n: n, stride: stride, shift: r.IntN(n)}
+}
+
+func (r ranking) index(rank int) int {
+	return (rank*r.stride + r.shift) % r.n
+}
+
+func gcd(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+func randomUuid(r *rand.Rand) uuid.UUID {
+	var id uuid.UUID
+	for i := range id {
+		id[i] = byte(r.UintN(256))
+	}
+	// version 4 and the RFC 4122 variant, as uuid.New would set
+	id[6] = (id[6] & 0x0f) | 0x40
+	id[8] = (id[8] & 0x3f) | 0x80
+	return id
+}
+
+func pick(r *rand.Rand, values []string) string {
+	return values[r.IntN(len(values))]
+}
+
+func sentence(r *rand.Rand, minWords int, maxWords int) string {
+	words := make([]string, minWords+r.IntN(maxWords-minWords+1))
+	for i := range words {
+		words[i] = pick(r, loremWords)
+	}
+	words[0] = strings.ToUpper(words[0][:1]) + words[0][1:]
+	return strings.Join(words, " ") + "."
+}
+
+func title(r *rand.Rand) string {
+	words := make([]string, 3+r.IntN(5))
+	for i := range words {
+		word := pick(r, loremWords)
+		words[i] = strings.ToUpper(word[:1]) + word[1:]
+	}
+	return strings.Join(words, " ")
+}
@@ -0,0 +1,114 @@
+package app_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nimaeskandary/go-realworld/cmd/seed/app"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Generator(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("should generate the same data for the same seed", func(t *testing.T) {
+		t.Parallel()
+		a := app.NewGenerator(42, 200, now)
+		b := app.NewGenerator(42, 200, now)
+
+		assert.Equal(t, a.Articles(), b.Articles())
+		for i := range a.Users() {
+			assert.Equal(t, a.User(i), b.User(i))
+			assert.Equal(t, a.Follows(i), b.Follows(i))
+			assert.Equal(t, a.Favorites(i), b.Favorites(i))
+			for j := range a.ArticleCount(i) {
+				assert.Equal(t, a.Article(i, j), b.Article(i, j))
+			}
+		}
+	})
+
+	t.Run("should generate different data for a different seed", func(t *testing.T) {
+		t.Parallel()
+		a := app.NewGenerator(1, 50, now)
+		b := app.NewGenerator(2, 50, now)
+
+		assert.NotEqual(t, a.User(0), b.User(0))
+	})
+
+	t.Run("should give the ids of the generated users and articles, and favorite articles after they are written", func(t *testing.T) {
+		t.Parallel()
+		g := app.NewGenerator(7, 100, now)
+
+		var articles []app.SeedArticle
+		for i := range g.Users() {
+			assert.Equal(t, g.User(i).Id, g.UserId(i))
+			for j := range g.ArticleCount(i) {
+				article := g.Article(i, j)
+				assert.Equal(t, article.Id, g.ArticleId(len(articles)))
+				assert.Equal(t, i, article.AuthorIndex)
+				articles = append(articles, article)
+			}
+		}
+		assert.Equal(t, g.Articles(), len(articles))
+
+		for i := range g.Users() {
+			for _, favorite := range g.Favorites(i) {
+				assert.False(t, favorite.CreatedAt.Before(articles[favorite.Index].CreatedAt))
+			}
+		}
+	})
+
+	t.Run("should generate unique users and valid relations", func(t *testing.T) {
+		t.Parallel()
+		g := app.NewGenerator(3, 500, now)
+
+		usernames := map[string]bool{}
+		emails := map[string]bool{}
+		for i := range g.Users() {
+			user := g.User(i)
+			assert.False(t, usernames[user.Username])
+			assert.False(t, emails[user.Email])
+			usernames[user.Username] = true
+			emails[user.Email] = true
+			assert.True(t, user.CreatedAt.Before(now))
+
+			follows := g.Follows(i)
+			followed := lo.Map(follows, func(follow app.SeedRelation, _ int) int { return follow.Index })
+			assert.NotContains(t, followed, i)
+			assert.Len(t, followed, len(lo.Uniq(followed)))
+			for _, follow := range follows {
+				assert.True(t, follow.Index >= 0 && follow.Index < g.Users())
+				assert.False(t, follow.CreatedAt.Before(user.CreatedAt))
+				assert.False(t, follow.CreatedAt.Before(g.User(follow.Index).CreatedAt))
+				assert.False(t, follow.CreatedAt.After(now))
+			}
+
+			favorites := g.Favorites(i)
+			favorited := lo.Map(favorites, func(favorite app.SeedRelation, _ int) int { return favorite.Index })
+			assert.Len(t, favorited, len(lo.Uniq(favorited)))
+			for _, favorite := range favorites {
+				assert.True(t, favorite.Index >= 0 && favorite.Index < g.Articles())
+				assert.False(t, favorite.CreatedAt.Before(user.CreatedAt))
+				assert.False(t, favorite.CreatedAt.After(now))
+			}
+
+			for j := range g.ArticleCount(i) {
+				article := g.Article(i, j)
+				assert.Len(t, article.Tags, len(lo.Uniq(article.Tags)))
+				assert.False(t, article.CreatedAt.Before(user.CreatedAt))
+				assert.False(t, article.CreatedAt.After(now))
+			}
+		}
+	})
+
+	t.Run("should not follow anyone when there is only one user", func(t *testing.T) {
+		t.Parallel()
+		g := app.NewGenerator(1, 1, now)
+
+		assert.Empty(t, g.Follows(0))
+	})
+}
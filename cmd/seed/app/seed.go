@@ -0,0 +1,219 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/nimaeskandary/go-realworld/pkg/article"
+	article_types "github.com/nimaeskandary/go-realworld/pkg/article/types"
+	"github.com/nimaeskandary/go-realworld/pkg/database"
+	db_types "github.com/nimaeskandary/go-realworld/pkg/database/types"
+	user_types "github.com/nimaeskandary/go-realworld/pkg/user/types"
+)
+
+// the tables bulk mode writes to
+const (
+	usersTableName            = "users"
+	followersTableName        = "user_followers"
+	articlesTableName         = "articles"
+	articleTagsTableName      = "article_tags"
+	articleFavoritesTableName = "article_favorites"
+)
+
+var (
+	usersColumns            = []string{"id", "username", "email", "bio", "created_at", "updated_at"}
+	followersColumns        = []string{"followed_by_user_id", "following_user_id", "created_at"}
+	articlesColumns         = []string{"id", "author_user_id", "data", "created_at", "updated_at"}
+	articleTagsColumns      = []string{"article_id", "tag", "created_at"}
+	articleFavoritesColumns = []string{"article_id", "user_id", "created_at"}
+)
+
+// SeedThroughServices writes the dataset through the user and article services, so it is validated and audited as a
+// request's data would be. What the services assign themselves is not reproducible: user ids, and the creation times
+// of users, follows, tags and favorites. Everything else is, usernames, emails, bios, who follows whom, articles with their
+// ids and timestamps, tags and favorites. Comments are not seeded, there is no comments feature to write them through
+func SeedThroughServices(
+	ctx context.Context,
+	g *Generator,
+	userService user_types.UserService,
+	articleService article_types.ArticleService,
+) error {
+	users := make([]user_types.User, g.Users())
+	for i := range g.Users() {
+		seedUser := g.User(i)
+		created, err := userService.CreateUser(ctx, user_types.UpsertUserParams{
+			Username: seedUser.Username,
+			Email:    seedUser.Email,
+			Bio:      seedUser.Bio,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create user %v: %w", seedUser.Username, err)
+		}
+		users[i] = created
+		logProgress("created users", i+1, g.Users())
+	}
+
+	for i, user := range users {
+		for _, followed := range g.Follows(i) {
+			if _, err := userService.FollowProfile(ctx, user, users[followed.Index].Username); err != nil {
+				return fmt.Errorf("failed to follow %v as %v: %w", users[followed.Index].Username, user.Username, err)
+			}
+		}
+		logProgress("followed for users", i+1, len(users))
+	}
+
+	for i, user := range users {
+		for j := range g.ArticleCount(i) {
+			seedArticle := g.Article(i, j)
+			_, err := articleService.UpsertArticle(ctx, article_types.Article{
+				Id:              seedArticle.Id,
+				AuthorUserId:    user.Id,
+				Title:           seedArticle.Title,
+				Description:     seedArticle.Description,
+				Body:            seedArticle.Body,
+				CreatedAtMillis: seedArticle.CreatedAt.UnixMilli(),
+				UpdatedAtMillis: seedArticle.CreatedAt.UnixMilli(),
+			})
+			if err != nil {
+				return fmt.Errorf("failed to create article %v by %v: %w", seedArticle.Id, user.Username, err)
+			}
+			if err := articleService.AddArticleTags(ctx, seedArticle.Id, seedArticle.Tags); err != nil {
+				return fmt.Errorf("failed to tag article %v: %w", seedArticle.Id, err)
+			}
+		}
+		logProgress("created articles for users", i+1, len(users))
+	}
+
+	for i, user := range users {
+		for _, favorite := range g.Favorites(i) {
+			articleId := g.ArticleId(favorite.Index)
+			if err := articleService.FavoriteArticle(ctx, user, articleId); err != nil {
+				return fmt.Errorf("failed to favorite article %v as %v: %w", articleId, user.Username, err)
+			}
+		}
+		logProgress("favorited for users", i+1, len(users))
+	}
+	return nil
+}
+
+// SeedBulk writes the dataset straight to the tables with database.BulkInsert, batchSize rows at a time. It skips the
+// services and their validations, which makes it fast enough for the millions of rows of a performance test dataset
+func SeedBulk(ctx context.Context, g *Generator, db db_types.SQLDatabase, batchSize int) error {
+	users := newBatchWriter(db, usersTableName, usersColumns, batchSize, nil)
+	for i := range g.Users() {
+		user := g.User(i)
+		createdAt := user.CreatedAt.UTC()
+		if err := users.add(ctx, user.Id.String(), user.Username, user.Email, user.Bio.ToPointer(), createdAt, createdAt); err != nil {
+			return err
+		}
+	}
+	if err := users.flush(ctx); err != nil {
+		return err
+	}
+
+	follows := newBatchWriter(db, followersTableName, followersColumns, batchSize, nil)
+	for i := range g.Users() {
+		follower := g.UserId(i).String()
+		for _, followed := range g.Follows(i) {
+			if err := follows.add(ctx, follower, g.UserId(followed.Index).String(), followed.CreatedAt.UTC()); err != nil {
+				return err
+			}
+		}
+	}
+	if err := follows.flush(ctx); err != nil {
+		return err
+	}
+
+	articles := newBatchWriter(db, articlesTableName, articlesColumns, batchSize, nil)
+	tags := newBatchWriter(db, articleTagsTableName, articleTagsColumns, batchSize, articles)
+	for i := range g.Users() {
+		authorId := g.UserId(i).String()
+		for j := range g.ArticleCount(i) {
+			seedArticle := g.Article(i, j)
+			data, err := article.EncodeArticleData(article_types.Article{
+				Title:       seedArticle.Title,
+				Description: seedArticle.Description,
+				Body:        seedArticle.Body,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to encode article %v: %w", seedArticle.Id, err)
+			}
+			createdAt := seedArticle.CreatedAt.UTC()
+			if err := articles.add(ctx, seedArticle.Id.String(), authorId, string(data), createdAt, createdAt); err != nil {
+				return err
+			}
+			for _, tag := range seedArticle.Tags {
+				if err := tags.add(ctx, seedArticle.Id.String(), tag, createdAt); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	if err := tags.flush(ctx); err != nil {
+		return err
+	}
+
+	favorites := newBatchWriter(db, articleFavoritesTableName, articleFavoritesColumns, batchSize, nil)
+	for i := range g.Users() {
+		userId := g.UserId(i).String()
+		for _, favorite := range g.Favorites(i) {
+			err := favorites.add(ctx, g.ArticleId(favorite.Index).String(), userId, favorite.CreatedAt.UTC())
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return favorites.flush(ctx)
+}
+
+// batchWriter collects the rows of a table, writing them with database.BulkInsert once there are size of them
+type batchWriter struct {
+	db      db_types.SQLDatabase
+	table   string
+	columns []string
+	size    int
+	// references is the writer of the rows these rows reference, it is flushed first
+	references *batchWriter
+	rows       [][]any
+	written    int
+}
+
+func newBatchWriter(db db_types.SQLDatabase, table string, columns []string, size int, references *batchWriter) *batchWriter {
+	return &batchWriter{db: db, table: table, columns: columns, size: size, references: references}
+}
+
+func (w *batchWriter) add(ctx context.Context, row ...any) error {
+	w.rows = append(w.rows, row)
+	if len(w.rows) < w.size {
+		return nil
+	}
+	return w.flush(ctx)
+}
+
+// flush writes the rows collected so far
+func (w *batchWriter) flush(ctx context.Context) error {
+	if w.references != nil {
+		if err := w.references.flush(ctx); err != nil {
+			return err
+		}
+	}
+	if len(w.rows) == 0 {
+		return nil
+	}
+
+	if err := database.BulkInsert(ctx, w.db, w.table, w.columns, w.rows); err != nil {
+		return fmt.Errorf("failed to write %v rows to %v: %w", len(w.rows), w.table, err)
+	}
+	w.written += len(w.rows)
+	w.rows = w.rows[:0]
+	log.Printf("wrote %v rows to %v", w.written, w.table)
+	return nil
+}
+
+// logProgress logs every tenth of the way through a phase that goes through the services one row at a time
+func logProgress(what string, done int, total int) {
+	if done%max(1, total/10) == 0 || done == total {
+		log.Printf("%v: %v/%v", what, done, total)
+	}
+}
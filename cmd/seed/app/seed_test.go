@@ -0,0 +1,78 @@
+package app_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/nimaeskandary/go-realworld/cmd/seed/app"
+	data_export_types "github.com/nimaeskandary/go-realworld/pkg/data_export/types"
+	"github.com/nimaeskandary/go-realworld/pkg/test_utils/fixtures"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_SeedThroughServices(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should write the generated data, apart from what the services assign", func(t *testing.T) {
+		t.Parallel()
+		f := fixtures.SetupInMemoryFixture(t)
+		g := app.NewGenerator(11, 30, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+
+		require.NoError(t, app.SeedThroughServices(t.Context(), g, f.UserService, f.ArticleService))
+
+		for i := range g.Users() {
+			expected := g.User(i)
+			user, userErr := f.UserService.GetUserByUsername(t.Context(), expected.Username)
+			require.NoError(t, userErr)
+			assert.Equal(t, expected.Email, user.Email)
+			assert.Equal(t, expected.Bio, user.Bio)
+
+			var following []string
+			err := f.DataExportRepo.StreamFollows(t.Context(), user.Id, func(row data_export_types.ExportedFollow) error {
+				if row.Direction == data_export_types.FollowDirectionFollowing {
+					following = append(following, row.Username)
+				}
+				return nil
+			})
+			require.NoError(t, err)
+			assert.ElementsMatch(t, lo.Map(g.Follows(i), func(follow app.SeedRelation, _ int) string {
+				return g.User(follow.Index).Username
+			}), following)
+
+			articles := map[uuid.UUID]data_export_types.ExportedArticle{}
+			err = f.DataExportRepo.StreamArticles(t.Context(), user.Id, func(row data_export_types.ExportedArticle) error {
+				articles[row.Id] = row
+				return nil
+			})
+			require.NoError(t, err)
+			require.Len(t, articles, g.ArticleCount(i))
+			for j := range g.ArticleCount(i) {
+				expectedArticle := g.Article(i, j)
+				article, ok := articles[expectedArticle.Id]
+				require.True(t, ok)
+				assert.Equal(t, expectedArticle.CreatedAt.UnixMilli(), article.CreatedAt.UnixMilli())
+				assert.ElementsMatch(t, expectedArticle.Tags, article.Tags)
+
+				var data map[string]any
+				require.NoError(t, json.Unmarshal(article.Data, &data))
+				assert.Equal(t, expectedArticle.Title, data["title"])
+				assert.Equal(t, expectedArticle.Body, data["body"])
+			}
+
+			var favorited []uuid.UUID
+			err = f.DataExportRepo.StreamFavorites(t.Context(), user.Id, func(row data_export_types.ExportedFavorite) error {
+				favorited = append(favorited, row.ArticleId)
+				return nil
+			})
+			require.NoError(t, err)
+			assert.ElementsMatch(t, lo.Map(g.Favorites(i), func(favorite app.SeedRelation, _ int) uuid.UUID {
+				return g.ArticleId(favorite.Index)
+			}), favorited)
+		}
+	})
+}
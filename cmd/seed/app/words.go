@@ -0,0 +1,29 @@
+package app
+
+// the vocabulary of generated users and articles
+
+var firstNames = []string{
+	"amelia", "ben", "chloe", "daniel", "elena", "farah", "george", "hana", "ivan", "julia", "kofi", "lena", "mateo",
+	"nadia", "oliver", "priya", "quinn", "rosa", "samir", "tara", "umar", "vera", "wei", "ximena", "yusuf", "zoe",
+}
+
+var lastNames = []string{
+	"adams", "brown", "chen", "diaz", "evans", "fischer", "garcia", "haddad", "ito", "jensen", "kim", "lopez",
+	"muller", "nguyen", "okafor", "patel", "rossi", "silva", "tanaka", "ueda", "volkov", "walker", "yilmaz", "zhang",
+}
+
+// tags are ordered most popular first, they are picked with a skew towards the start
+var tags = []string{
+	"programming", "go", "javascript", "webdev", "tutorial", "devops", "databases", "postgres", "career", "testing",
+	"security", "design", "architecture", "performance", "opensource", "rust", "python", "kubernetes", "cloud",
+	"productivity", "frontend", "backend", "api", "beginners", "ai", "linux", "networking", "sql", "css", "react",
+}
+
+var loremWords = []string{
+	"lorem", "ipsum", "dolor", "sit", "amet", "consectetur", "adipiscing", "elit", "sed", "do", "eiusmod", "tempor",
+	"incididunt", "ut", "labore", "et", "dolore", "magna", "aliqua", "enim", "ad", "minim", "veniam", "quis",
+	"nostrud", "exercitation", "ullamco", "laboris", "nisi", "aliquip", "ex", "ea", "commodo", "consequat", "duis",
+	"aute", "irure", "in", "reprehenderit", "voluptate", "velit", "esse", "cillum", "fugiat", "nulla", "pariatur",
+	"excepteur", "sint", "occaecat", "cupidatat", "non", "proident", "sunt", "culpa", "qui", "officia", "deserunt",
+	"mollit", "anim", "id", "est", "laborum",
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/nimaeskandary/go-realworld/cmd/seed/app"
+	article_types "github.com/nimaeskandary/go-realworld/pkg/article/types"
+	db_types "github.com/nimaeskandary/go-realworld/pkg/database/types"
+	user_types "github.com/nimaeskandary/go-realworld/pkg/user/types"
+	"github.com/nimaeskandary/go-realworld/pkg/util"
+)
+
+func main() {
+	ctx := context.Background()
+	cleanupManager := util.NewCleanupManager(ctx, true)
+	defer cleanupManager.Cleanup()
+
+	args := app.ParseArgs()
+
+	// setup deps
+
+	configData, err := os.ReadFile(args.ConfigPath)
+	if err != nil {
+		log.Fatalf("failed to read config file at %v: %v", args.ConfigPath, err)
+	}
+
+	var userService user_types.UserService
+	var articleService article_types.ArticleService
+	var db db_types.RealWorldAppDb
+	fxApp := util.CreateFxAppAndExtract(app.ModuleList(configData), &userService, &articleService, &db)
+
+	if err := fxApp.Start(ctx); err != nil {
+		log.Fatalf("dependency injection system failed to start: %v", err)
+	}
+
+	cleanupManager.RegisterCleanupFunc(func() {
+		if err := fxApp.Stop(ctx); err != nil {
+			log.Printf("dependency injection system failed to stop gracefully: %v", err)
+		}
+	})
+
+	// seed
+
+	until, err := time.Parse(time.DateOnly, args.Until)
+	if err != nil {
+		log.Fatalf("invalid until date %v: %v", args.Until, err)
+	}
+
+	started := time.Now()
+	generator := app.NewGenerator(args.Seed, args.Users, until)
+	log.Printf("seeding %v users and %v articles with seed %v, in %v mode...",
+		generator.Users(), generator.Articles(), args.Seed, args.Mode)
+
+	switch args.Mode {
+	case app.ModeService:
+		err = app.SeedThroughServices(ctx, generator, userService, articleService)
+	case app.ModeBulk:
+		err = app.SeedBulk(ctx, generator, db, args.BatchSize)
+	default:
+		err = fmt.Errorf("unknown seed mode: %v", args.Mode)
+	}
+
+	if err != nil {
+		log.Printf("failed to seed: %v", err)
+		cleanupManager.Cleanup()
+		os.Exit(1)
+	}
+	log.Printf("seeded in %v", time.Since(started).Round(time.Millisecond))
+}
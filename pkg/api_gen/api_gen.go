@@ -12,6 +12,8 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"io"
+	"mime/multipart"
 	"net/http"
 	"net/url"
 	"path"
@@ -21,12 +23,31 @@ import (
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/oapi-codegen/runtime"
 	strictnethttp "github.com/oapi-codegen/runtime/strictmiddleware/nethttp"
+	openapi_types "github.com/oapi-codegen/runtime/types"
 )
 
 const (
 	TokenScopes = "Token.Scopes"
 )
 
+// Defines values for GetMediaParamsKind.
+const (
+	Articles GetMediaParamsKind = "articles"
+	Avatars  GetMediaParamsKind = "avatars"
+)
+
+// Valid indicates whether the value is a known member of the GetMediaParamsKind enum.
+func (e GetMediaParamsKind) Valid() bool {
+	switch e {
+	case Articles:
+		return true
+	case Avatars:
+		return true
+	default:
+		return false
+	}
+}
+
 // Article defines model for Article.
 type Article struct {
 	Author         Profile   `json:"author"`
@@ -41,6 +62,23 @@ type Article struct {
 	UpdatedAt      time.Time `json:"updatedAt"`
 }
 
+// ArticleSearchResult defines model for ArticleSearchResult.
+type ArticleSearchResult struct {
+	Author      Profile   `json:"author"`
+	CreatedAt   time.Time `json:"createdAt"`
+	Description string    `json:"description"`
+
+	// Highlight An excerpt around the matched words as html, matches are wrapped in mark elements
+	Highlight string `json:"highlight"`
+	Id        string `json:"id"`
+
+	// Rank How well the article matched, higher is better. Only comparable within one search
+	Rank      float64   `json:"rank"`
+	TagList   []string  `json:"tagList"`
+	Title     string    `json:"title"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
 // Comment defines model for Comment.
 type Comment struct {
 	Author    Profile   `json:"author"`
@@ -91,6 +129,13 @@ type Profile struct {
 	Username  string `json:"username"`
 }
 
+// StoredImage defines model for StoredImage.
+type StoredImage struct {
+	Height int    `json:"height"`
+	Url    string `json:"url"`
+	Width  int    `json:"width"`
+}
+
 // UpdateArticle defines model for UpdateArticle.
 type UpdateArticle struct {
 	Body        *string `json:"body,omitempty"`
@@ -116,12 +161,23 @@ type User struct {
 	Username string `json:"username"`
 }
 
+// IfMatchParam defines model for ifMatchParam.
+type IfMatchParam = string
+
 // LimitParam defines model for limitParam.
 type LimitParam = int
 
 // OffsetParam defines model for offsetParam.
 type OffsetParam = int
 
+// ArticleSearchResponse defines model for ArticleSearchResponse.
+type ArticleSearchResponse struct {
+	Results []ArticleSearchResult `json:"results"`
+}
+
+// Forbidden defines model for Forbidden.
+type Forbidden = GenericErrorModel
+
 // GenericError defines model for GenericError.
 type GenericError = GenericErrorModel
 
@@ -146,6 +202,12 @@ type MultipleCommentsResponse struct {
 	Comments []Comment `json:"comments"`
 }
 
+// PayloadTooLarge defines model for PayloadTooLarge.
+type PayloadTooLarge = GenericErrorModel
+
+// PreconditionFailed defines model for PreconditionFailed.
+type PreconditionFailed = GenericErrorModel
+
 // ProfileResponse defines model for ProfileResponse.
 type ProfileResponse struct {
 	Profile Profile `json:"profile"`
@@ -161,16 +223,34 @@ type SingleCommentResponse struct {
 	Comment Comment `json:"comment"`
 }
 
+// StoredImageResponse defines model for StoredImageResponse.
+type StoredImageResponse struct {
+	Image StoredImage `json:"image"`
+}
+
 // TagsResponse defines model for TagsResponse.
 type TagsResponse struct {
 	Tags []string `json:"tags"`
 }
 
+// UnsupportedMediaType defines model for UnsupportedMediaType.
+type UnsupportedMediaType = GenericErrorModel
+
 // UserResponse defines model for UserResponse.
 type UserResponse struct {
 	User User `json:"user"`
 }
 
+// VersionedSingleArticleResponse defines model for VersionedSingleArticleResponse.
+type VersionedSingleArticleResponse struct {
+	Article Article `json:"article"`
+}
+
+// VersionedUserResponse defines model for VersionedUserResponse.
+type VersionedUserResponse struct {
+	User User `json:"user"`
+}
+
 // LoginUserRequest defines model for LoginUserRequest.
 type LoginUserRequest struct {
 	User LoginUser `json:"user"`
@@ -233,21 +313,64 @@ type GetArticlesFeedParams struct {
 	Limit *LimitParam `form:"limit,omitempty" json:"limit,omitempty"`
 }
 
+// SearchArticlesParams defines parameters for SearchArticles.
+type SearchArticlesParams struct {
+	// Q The search text
+	Q string `form:"q" json:"q"`
+
+	// Tag Filter by tag
+	Tag *string `form:"tag,omitempty" json:"tag,omitempty"`
+
+	// Author Filter by author (username)
+	Author *string `form:"author,omitempty" json:"author,omitempty"`
+
+	// Offset The number of items to skip before starting to collect the result set.
+	Offset *OffsetParam `form:"offset,omitempty" json:"offset,omitempty"`
+
+	// Limit The numbers of items to return.
+	Limit *LimitParam `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
 // UpdateArticleJSONBody defines parameters for UpdateArticle.
 type UpdateArticleJSONBody struct {
 	Article UpdateArticle `json:"article"`
 }
 
+// UpdateArticleParams defines parameters for UpdateArticle.
+type UpdateArticleParams struct {
+	// IfMatch ETag of the version being updated, the update is rejected with a 412 if the resource has changed since.
+	IfMatch *IfMatchParam `json:"If-Match,omitempty"`
+}
+
 // CreateArticleCommentJSONBody defines parameters for CreateArticleComment.
 type CreateArticleCommentJSONBody struct {
 	Comment NewComment `json:"comment"`
 }
 
+// UploadArticleImageMultipartBody defines parameters for UploadArticleImage.
+type UploadArticleImageMultipartBody struct {
+	Image openapi_types.File `json:"image"`
+}
+
+// GetMediaParamsKind defines parameters for GetMedia.
+type GetMediaParamsKind string
+
 // UpdateCurrentUserJSONBody defines parameters for UpdateCurrentUser.
 type UpdateCurrentUserJSONBody struct {
 	User UpdateUser `json:"user"`
 }
 
+// UpdateCurrentUserParams defines parameters for UpdateCurrentUser.
+type UpdateCurrentUserParams struct {
+	// IfMatch ETag of the version being updated, the update is rejected with a 412 if the resource has changed since.
+	IfMatch *IfMatchParam `json:"If-Match,omitempty"`
+}
+
+// UploadCurrentUserAvatarMultipartBody defines parameters for UploadCurrentUserAvatar.
+type UploadCurrentUserAvatarMultipartBody struct {
+	Image openapi_types.File `json:"image"`
+}
+
 // CreateUserJSONBody defines parameters for CreateUser.
 type CreateUserJSONBody struct {
 	User NewUser `json:"user"`
@@ -267,9 +390,15 @@ type UpdateArticleJSONRequestBody UpdateArticleJSONBody
 // CreateArticleCommentJSONRequestBody defines body for CreateArticleComment for application/json ContentType.
 type CreateArticleCommentJSONRequestBody CreateArticleCommentJSONBody
 
+// UploadArticleImageMultipartRequestBody defines body for UploadArticleImage for multipart/form-data ContentType.
+type UploadArticleImageMultipartRequestBody UploadArticleImageMultipartBody
+
 // UpdateCurrentUserJSONRequestBody defines body for UpdateCurrentUser for application/json ContentType.
 type UpdateCurrentUserJSONRequestBody UpdateCurrentUserJSONBody
 
+// UploadCurrentUserAvatarMultipartRequestBody defines body for UploadCurrentUserAvatar for multipart/form-data ContentType.
+type UploadCurrentUserAvatarMultipartRequestBody UploadCurrentUserAvatarMultipartBody
+
 // CreateUserJSONRequestBody defines body for CreateUser for application/json ContentType.
 type CreateUserJSONRequestBody CreateUserJSONBody
 
@@ -287,6 +416,9 @@ type ServerInterface interface {
 	// Get recent articles from users you follow
 	// (GET /articles/feed)
 	GetArticlesFeed(w http.ResponseWriter, r *http.Request, params GetArticlesFeedParams)
+	// Search articles
+	// (GET /articles/search)
+	SearchArticles(w http.ResponseWriter, r *http.Request, params SearchArticlesParams)
 	// Delete an article
 	// (DELETE /articles/{slug})
 	DeleteArticle(w http.ResponseWriter, r *http.Request, slug string)
@@ -295,7 +427,7 @@ type ServerInterface interface {
 	GetArticle(w http.ResponseWriter, r *http.Request, slug string)
 	// Update an article
 	// (PUT /articles/{slug})
-	UpdateArticle(w http.ResponseWriter, r *http.Request, slug string)
+	UpdateArticle(w http.ResponseWriter, r *http.Request, slug string, params UpdateArticleParams)
 	// Get comments for an article
 	// (GET /articles/{slug}/comments)
 	GetArticleComments(w http.ResponseWriter, r *http.Request, slug string)
@@ -311,15 +443,33 @@ type ServerInterface interface {
 	// Favorite an article
 	// (POST /articles/{slug}/favorite)
 	CreateArticleFavorite(w http.ResponseWriter, r *http.Request, slug string)
+	// Upload an article image
+	// (POST /media/articles)
+	UploadArticleImage(w http.ResponseWriter, r *http.Request)
+	// Get a stored image
+	// (GET /media/{kind}/{name})
+	GetMedia(w http.ResponseWriter, r *http.Request, kind GetMediaParamsKind, name string)
 	// Get a profile
 	// (GET /profiles/{username})
 	GetProfileByUsername(w http.ResponseWriter, r *http.Request, username string)
+	// Unblock a user
+	// (DELETE /profiles/{username}/block)
+	UnblockUserByUsername(w http.ResponseWriter, r *http.Request, username string)
+	// Block a user
+	// (POST /profiles/{username}/block)
+	BlockUserByUsername(w http.ResponseWriter, r *http.Request, username string)
 	// Unfollow a user
 	// (DELETE /profiles/{username}/follow)
 	UnfollowUserByUsername(w http.ResponseWriter, r *http.Request, username string)
 	// Follow a user
 	// (POST /profiles/{username}/follow)
 	FollowUserByUsername(w http.ResponseWriter, r *http.Request, username string)
+	// Unmute a user
+	// (DELETE /profiles/{username}/mute)
+	UnmuteUserByUsername(w http.ResponseWriter, r *http.Request, username string)
+	// Mute a user
+	// (POST /profiles/{username}/mute)
+	MuteUserByUsername(w http.ResponseWriter, r *http.Request, username string)
 	// Get tags
 	// (GET /tags)
 	GetTags(w http.ResponseWriter, r *http.Request)
@@ -328,7 +478,13 @@ type ServerInterface interface {
 	GetCurrentUser(w http.ResponseWriter, r *http.Request)
 	// Update current user
 	// (PUT /user)
-	UpdateCurrentUser(w http.ResponseWriter, r *http.Request)
+	UpdateCurrentUser(w http.ResponseWriter, r *http.Request, params UpdateCurrentUserParams)
+	// Upload avatar for current user
+	// (POST /user/avatar)
+	UploadCurrentUserAvatar(w http.ResponseWriter, r *http.Request)
+	// Export current user data
+	// (GET /user/export)
+	ExportCurrentUserData(w http.ResponseWriter, r *http.Request)
 
 	// (POST /users)
 	CreateUser(w http.ResponseWriter, r *http.Request)
@@ -466,6 +622,72 @@ func (siw *ServerInterfaceWrapper) GetArticlesFeed(w http.ResponseWriter, r *htt
 	handler.ServeHTTP(w, r)
 }
 
+// SearchArticles operation middleware
+func (siw *ServerInterfaceWrapper) SearchArticles(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params SearchArticlesParams
+
+	// ------------- Required query parameter "q" -------------
+
+	if paramValue := r.URL.Query().Get("q"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "q"})
+		return
+	}
+
+	err = runtime.BindQueryParameterWithOptions("form", true, true, "q", r.URL.Query(), &params.Q, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "q", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "tag" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "tag", r.URL.Query(), &params.Tag, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tag", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "author" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "author", r.URL.Query(), &params.Author, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "author", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "offset", r.URL.Query(), &params.Offset, runtime.BindQueryParameterOptions{Type: "integer", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "limit", r.URL.Query(), &params.Limit, runtime.BindQueryParameterOptions{Type: "integer", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SearchArticles(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 // DeleteArticle operation middleware
 func (siw *ServerInterfaceWrapper) DeleteArticle(w http.ResponseWriter, r *http.Request) {
 
@@ -542,8 +764,32 @@ func (siw *ServerInterfaceWrapper) UpdateArticle(w http.ResponseWriter, r *http.
 
 	r = r.WithContext(ctx)
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params UpdateArticleParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatchParam
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false, Type: "string", Format: ""})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-Match", Err: err})
+			return
+		}
+
+		params.IfMatch = &IfMatch
+
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.UpdateArticle(w, r, slug)
+		siw.Handler.UpdateArticle(w, r, slug, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -711,6 +957,60 @@ func (siw *ServerInterfaceWrapper) CreateArticleFavorite(w http.ResponseWriter,
 	handler.ServeHTTP(w, r)
 }
 
+// UploadArticleImage operation middleware
+func (siw *ServerInterfaceWrapper) UploadArticleImage(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, TokenScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UploadArticleImage(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetMedia operation middleware
+func (siw *ServerInterfaceWrapper) GetMedia(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "kind" -------------
+	var kind GetMediaParamsKind
+
+	err = runtime.BindStyledParameterWithOptions("simple", "kind", r.PathValue("kind"), &kind, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "kind", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", r.PathValue("name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetMedia(w, r, kind, name)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 // GetProfileByUsername operation middleware
 func (siw *ServerInterfaceWrapper) GetProfileByUsername(w http.ResponseWriter, r *http.Request) {
 
@@ -736,8 +1036,8 @@ func (siw *ServerInterfaceWrapper) GetProfileByUsername(w http.ResponseWriter, r
 	handler.ServeHTTP(w, r)
 }
 
-// UnfollowUserByUsername operation middleware
-func (siw *ServerInterfaceWrapper) UnfollowUserByUsername(w http.ResponseWriter, r *http.Request) {
+// UnblockUserByUsername operation middleware
+func (siw *ServerInterfaceWrapper) UnblockUserByUsername(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -757,7 +1057,7 @@ func (siw *ServerInterfaceWrapper) UnfollowUserByUsername(w http.ResponseWriter,
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.UnfollowUserByUsername(w, r, username)
+		siw.Handler.UnblockUserByUsername(w, r, username)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -767,8 +1067,8 @@ func (siw *ServerInterfaceWrapper) UnfollowUserByUsername(w http.ResponseWriter,
 	handler.ServeHTTP(w, r)
 }
 
-// FollowUserByUsername operation middleware
-func (siw *ServerInterfaceWrapper) FollowUserByUsername(w http.ResponseWriter, r *http.Request) {
+// BlockUserByUsername operation middleware
+func (siw *ServerInterfaceWrapper) BlockUserByUsername(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -788,7 +1088,7 @@ func (siw *ServerInterfaceWrapper) FollowUserByUsername(w http.ResponseWriter, r
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.FollowUserByUsername(w, r, username)
+		siw.Handler.BlockUserByUsername(w, r, username)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -798,11 +1098,28 @@ func (siw *ServerInterfaceWrapper) FollowUserByUsername(w http.ResponseWriter, r
 	handler.ServeHTTP(w, r)
 }
 
-// GetTags operation middleware
-func (siw *ServerInterfaceWrapper) GetTags(w http.ResponseWriter, r *http.Request) {
+// UnfollowUserByUsername operation middleware
+func (siw *ServerInterfaceWrapper) UnfollowUserByUsername(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "username" -------------
+	var username string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "username", r.PathValue("username"), &username, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "username", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, TokenScopes, []string{})
+
+	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetTags(w, r)
+		siw.Handler.UnfollowUserByUsername(w, r, username)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -812,8 +1129,19 @@ func (siw *ServerInterfaceWrapper) GetTags(w http.ResponseWriter, r *http.Reques
 	handler.ServeHTTP(w, r)
 }
 
-// GetCurrentUser operation middleware
-func (siw *ServerInterfaceWrapper) GetCurrentUser(w http.ResponseWriter, r *http.Request) {
+// FollowUserByUsername operation middleware
+func (siw *ServerInterfaceWrapper) FollowUserByUsername(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "username" -------------
+	var username string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "username", r.PathValue("username"), &username, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "username", Err: err})
+		return
+	}
 
 	ctx := r.Context()
 
@@ -822,7 +1150,7 @@ func (siw *ServerInterfaceWrapper) GetCurrentUser(w http.ResponseWriter, r *http
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetCurrentUser(w, r)
+		siw.Handler.FollowUserByUsername(w, r, username)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -832,8 +1160,19 @@ func (siw *ServerInterfaceWrapper) GetCurrentUser(w http.ResponseWriter, r *http
 	handler.ServeHTTP(w, r)
 }
 
-// UpdateCurrentUser operation middleware
-func (siw *ServerInterfaceWrapper) UpdateCurrentUser(w http.ResponseWriter, r *http.Request) {
+// UnmuteUserByUsername operation middleware
+func (siw *ServerInterfaceWrapper) UnmuteUserByUsername(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "username" -------------
+	var username string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "username", r.PathValue("username"), &username, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "username", Err: err})
+		return
+	}
 
 	ctx := r.Context()
 
@@ -842,7 +1181,7 @@ func (siw *ServerInterfaceWrapper) UpdateCurrentUser(w http.ResponseWriter, r *h
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.UpdateCurrentUser(w, r)
+		siw.Handler.UnmuteUserByUsername(w, r, username)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -852,11 +1191,28 @@ func (siw *ServerInterfaceWrapper) UpdateCurrentUser(w http.ResponseWriter, r *h
 	handler.ServeHTTP(w, r)
 }
 
-// CreateUser operation middleware
-func (siw *ServerInterfaceWrapper) CreateUser(w http.ResponseWriter, r *http.Request) {
+// MuteUserByUsername operation middleware
+func (siw *ServerInterfaceWrapper) MuteUserByUsername(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "username" -------------
+	var username string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "username", r.PathValue("username"), &username, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "username", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, TokenScopes, []string{})
+
+	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.CreateUser(w, r)
+		siw.Handler.MuteUserByUsername(w, r, username)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -866,11 +1222,11 @@ func (siw *ServerInterfaceWrapper) CreateUser(w http.ResponseWriter, r *http.Req
 	handler.ServeHTTP(w, r)
 }
 
-// Login operation middleware
-func (siw *ServerInterfaceWrapper) Login(w http.ResponseWriter, r *http.Request) {
+// GetTags operation middleware
+func (siw *ServerInterfaceWrapper) GetTags(w http.ResponseWriter, r *http.Request) {
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.Login(w, r)
+		siw.Handler.GetTags(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -880,16 +1236,150 @@ func (siw *ServerInterfaceWrapper) Login(w http.ResponseWriter, r *http.Request)
 	handler.ServeHTTP(w, r)
 }
 
-type UnescapedCookieParamError struct {
-	ParamName string
-	Err       error
-}
+// GetCurrentUser operation middleware
+func (siw *ServerInterfaceWrapper) GetCurrentUser(w http.ResponseWriter, r *http.Request) {
 
-func (e *UnescapedCookieParamError) Error() string {
-	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
-}
+	ctx := r.Context()
 
-func (e *UnescapedCookieParamError) Unwrap() error {
+	ctx = context.WithValue(ctx, TokenScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetCurrentUser(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// UpdateCurrentUser operation middleware
+func (siw *ServerInterfaceWrapper) UpdateCurrentUser(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, TokenScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params UpdateCurrentUserParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatchParam
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false, Type: "string", Format: ""})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-Match", Err: err})
+			return
+		}
+
+		params.IfMatch = &IfMatch
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateCurrentUser(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// UploadCurrentUserAvatar operation middleware
+func (siw *ServerInterfaceWrapper) UploadCurrentUserAvatar(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, TokenScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UploadCurrentUserAvatar(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ExportCurrentUserData operation middleware
+func (siw *ServerInterfaceWrapper) ExportCurrentUserData(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, TokenScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ExportCurrentUserData(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateUser operation middleware
+func (siw *ServerInterfaceWrapper) CreateUser(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateUser(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// Login operation middleware
+func (siw *ServerInterfaceWrapper) Login(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.Login(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+type UnescapedCookieParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnescapedCookieParamError) Error() string {
+	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
+}
+
+func (e *UnescapedCookieParamError) Unwrap() error {
 	return e.Err
 }
 
@@ -1003,6 +1493,7 @@ func HandlerWithOptions(si ServerInterface, options StdHTTPServerOptions) http.H
 	m.HandleFunc("GET "+options.BaseURL+"/articles", wrapper.GetArticles)
 	m.HandleFunc("POST "+options.BaseURL+"/articles", wrapper.CreateArticle)
 	m.HandleFunc("GET "+options.BaseURL+"/articles/feed", wrapper.GetArticlesFeed)
+	m.HandleFunc("GET "+options.BaseURL+"/articles/search", wrapper.SearchArticles)
 	m.HandleFunc("DELETE "+options.BaseURL+"/articles/{slug}", wrapper.DeleteArticle)
 	m.HandleFunc("GET "+options.BaseURL+"/articles/{slug}", wrapper.GetArticle)
 	m.HandleFunc("PUT "+options.BaseURL+"/articles/{slug}", wrapper.UpdateArticle)
@@ -1011,21 +1502,35 @@ func HandlerWithOptions(si ServerInterface, options StdHTTPServerOptions) http.H
 	m.HandleFunc("DELETE "+options.BaseURL+"/articles/{slug}/comments/{id}", wrapper.DeleteArticleComment)
 	m.HandleFunc("DELETE "+options.BaseURL+"/articles/{slug}/favorite", wrapper.DeleteArticleFavorite)
 	m.HandleFunc("POST "+options.BaseURL+"/articles/{slug}/favorite", wrapper.CreateArticleFavorite)
+	m.HandleFunc("POST "+options.BaseURL+"/media/articles", wrapper.UploadArticleImage)
+	m.HandleFunc("GET "+options.BaseURL+"/media/{kind}/{name}", wrapper.GetMedia)
 	m.HandleFunc("GET "+options.BaseURL+"/profiles/{username}", wrapper.GetProfileByUsername)
+	m.HandleFunc("DELETE "+options.BaseURL+"/profiles/{username}/block", wrapper.UnblockUserByUsername)
+	m.HandleFunc("POST "+options.BaseURL+"/profiles/{username}/block", wrapper.BlockUserByUsername)
 	m.HandleFunc("DELETE "+options.BaseURL+"/profiles/{username}/follow", wrapper.UnfollowUserByUsername)
 	m.HandleFunc("POST "+options.BaseURL+"/profiles/{username}/follow", wrapper.FollowUserByUsername)
+	m.HandleFunc("DELETE "+options.BaseURL+"/profiles/{username}/mute", wrapper.UnmuteUserByUsername)
+	m.HandleFunc("POST "+options.BaseURL+"/profiles/{username}/mute", wrapper.MuteUserByUsername)
 	m.HandleFunc("GET "+options.BaseURL+"/tags", wrapper.GetTags)
 	m.HandleFunc("GET "+options.BaseURL+"/user", wrapper.GetCurrentUser)
 	m.HandleFunc("PUT "+options.BaseURL+"/user", wrapper.UpdateCurrentUser)
+	m.HandleFunc("POST "+options.BaseURL+"/user/avatar", wrapper.UploadCurrentUserAvatar)
+	m.HandleFunc("GET "+options.BaseURL+"/user/export", wrapper.ExportCurrentUserData)
 	m.HandleFunc("POST "+options.BaseURL+"/users", wrapper.CreateUser)
 	m.HandleFunc("POST "+options.BaseURL+"/users/login", wrapper.Login)
 
 	return m
 }
 
+type ArticleSearchResponseJSONResponse struct {
+	Results []ArticleSearchResult `json:"results"`
+}
+
 type EmptyOkResponseResponse struct {
 }
 
+type ForbiddenJSONResponse GenericErrorModel
+
 type GenericErrorJSONResponse GenericErrorModel
 
 type MultipleArticlesResponseJSONResponse struct {
@@ -1047,8 +1552,19 @@ type MultipleCommentsResponseJSONResponse struct {
 	Comments []Comment `json:"comments"`
 }
 
+type PayloadTooLargeJSONResponse GenericErrorModel
+
+type PreconditionFailedJSONResponse GenericErrorModel
+
+type ProfileResponseResponseHeaders struct {
+	ContentLocation string
+}
 type ProfileResponseJSONResponse struct {
-	Profile Profile `json:"profile"`
+	Body struct {
+		Profile Profile `json:"profile"`
+	}
+
+	Headers ProfileResponseResponseHeaders
 }
 
 type SingleArticleResponseJSONResponse struct {
@@ -1059,6 +1575,10 @@ type SingleCommentResponseJSONResponse struct {
 	Comment Comment `json:"comment"`
 }
 
+type StoredImageResponseJSONResponse struct {
+	Image StoredImage `json:"image"`
+}
+
 type TagsResponseJSONResponse struct {
 	Tags []string `json:"tags"`
 }
@@ -1066,10 +1586,34 @@ type TagsResponseJSONResponse struct {
 type UnauthorizedResponse struct {
 }
 
+type UnsupportedMediaTypeJSONResponse GenericErrorModel
+
 type UserResponseJSONResponse struct {
 	User User `json:"user"`
 }
 
+type VersionedSingleArticleResponseResponseHeaders struct {
+	ETag string
+}
+type VersionedSingleArticleResponseJSONResponse struct {
+	Body struct {
+		Article Article `json:"article"`
+	}
+
+	Headers VersionedSingleArticleResponseResponseHeaders
+}
+
+type VersionedUserResponseResponseHeaders struct {
+	ETag string
+}
+type VersionedUserResponseJSONResponse struct {
+	Body struct {
+		User User `json:"user"`
+	}
+
+	Headers VersionedUserResponseResponseHeaders
+}
+
 type GetArticlesRequestObject struct {
 	Params GetArticlesParams
 }
@@ -1175,6 +1719,34 @@ func (response GetArticlesFeed422JSONResponse) VisitGetArticlesFeedResponse(w ht
 	return json.NewEncoder(w).Encode(response)
 }
 
+type SearchArticlesRequestObject struct {
+	Params SearchArticlesParams
+}
+
+type SearchArticlesResponseObject interface {
+	VisitSearchArticlesResponse(w http.ResponseWriter) error
+}
+
+type SearchArticles200JSONResponse struct {
+	ArticleSearchResponseJSONResponse
+}
+
+func (response SearchArticles200JSONResponse) VisitSearchArticlesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SearchArticles422JSONResponse struct{ GenericErrorJSONResponse }
+
+func (response SearchArticles422JSONResponse) VisitSearchArticlesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
 type DeleteArticleRequestObject struct {
 	Slug string `json:"slug"`
 }
@@ -1235,8 +1807,9 @@ func (response GetArticle422JSONResponse) VisitGetArticleResponse(w http.Respons
 }
 
 type UpdateArticleRequestObject struct {
-	Slug string `json:"slug"`
-	Body *UpdateArticleJSONRequestBody
+	Slug   string `json:"slug"`
+	Params UpdateArticleParams
+	Body   *UpdateArticleJSONRequestBody
 }
 
 type UpdateArticleResponseObject interface {
@@ -1244,14 +1817,15 @@ type UpdateArticleResponseObject interface {
 }
 
 type UpdateArticle200JSONResponse struct {
-	SingleArticleResponseJSONResponse
+	VersionedSingleArticleResponseJSONResponse
 }
 
 func (response UpdateArticle200JSONResponse) VisitUpdateArticleResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", fmt.Sprint(response.Headers.ETag))
 	w.WriteHeader(200)
 
-	return json.NewEncoder(w).Encode(response)
+	return json.NewEncoder(w).Encode(response.Body)
 }
 
 type UpdateArticle401Response = UnauthorizedResponse
@@ -1261,6 +1835,24 @@ func (response UpdateArticle401Response) VisitUpdateArticleResponse(w http.Respo
 	return nil
 }
 
+type UpdateArticle403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response UpdateArticle403JSONResponse) VisitUpdateArticleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateArticle412JSONResponse struct{ PreconditionFailedJSONResponse }
+
+func (response UpdateArticle412JSONResponse) VisitUpdateArticleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(412)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
 type UpdateArticle422JSONResponse struct{ GenericErrorJSONResponse }
 
 func (response UpdateArticle422JSONResponse) VisitUpdateArticleResponse(w http.ResponseWriter) error {
@@ -1443,6 +2035,114 @@ func (response CreateArticleFavorite422JSONResponse) VisitCreateArticleFavoriteR
 	return json.NewEncoder(w).Encode(response)
 }
 
+type UploadArticleImageRequestObject struct {
+	Body *multipart.Reader
+}
+
+type UploadArticleImageResponseObject interface {
+	VisitUploadArticleImageResponse(w http.ResponseWriter) error
+}
+
+type UploadArticleImage201JSONResponse struct {
+	StoredImageResponseJSONResponse
+}
+
+func (response UploadArticleImage201JSONResponse) VisitUploadArticleImageResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UploadArticleImage401Response = UnauthorizedResponse
+
+func (response UploadArticleImage401Response) VisitUploadArticleImageResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type UploadArticleImage413JSONResponse struct{ PayloadTooLargeJSONResponse }
+
+func (response UploadArticleImage413JSONResponse) VisitUploadArticleImageResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(413)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UploadArticleImage415JSONResponse struct {
+	UnsupportedMediaTypeJSONResponse
+}
+
+func (response UploadArticleImage415JSONResponse) VisitUploadArticleImageResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(415)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UploadArticleImage422JSONResponse struct{ GenericErrorJSONResponse }
+
+func (response UploadArticleImage422JSONResponse) VisitUploadArticleImageResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetMediaRequestObject struct {
+	Kind GetMediaParamsKind `json:"kind"`
+	Name string             `json:"name"`
+}
+
+type GetMediaResponseObject interface {
+	VisitGetMediaResponse(w http.ResponseWriter) error
+}
+
+type GetMedia200ResponseHeaders struct {
+	CacheControl string
+}
+
+type GetMedia200ImageResponse struct {
+	Body          io.Reader
+	Headers       GetMedia200ResponseHeaders
+	ContentType   string
+	ContentLength int64
+}
+
+func (response GetMedia200ImageResponse) VisitGetMediaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", response.ContentType)
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.Header().Set("Cache-Control", fmt.Sprint(response.Headers.CacheControl))
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type GetMedia404JSONResponse struct{ GenericErrorJSONResponse }
+
+func (response GetMedia404JSONResponse) VisitGetMediaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetMedia422JSONResponse GenericErrorModel
+
+func (response GetMedia422JSONResponse) VisitGetMediaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
 type GetProfileByUsernameRequestObject struct {
 	Username string `json:"username"`
 }
@@ -1455,9 +2155,10 @@ type GetProfileByUsername200JSONResponse struct{ ProfileResponseJSONResponse }
 
 func (response GetProfileByUsername200JSONResponse) VisitGetProfileByUsernameResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Location", fmt.Sprint(response.Headers.ContentLocation))
 	w.WriteHeader(200)
 
-	return json.NewEncoder(w).Encode(response)
+	return json.NewEncoder(w).Encode(response.Body)
 }
 
 type GetProfileByUsername401Response = UnauthorizedResponse
@@ -1476,6 +2177,74 @@ func (response GetProfileByUsername422JSONResponse) VisitGetProfileByUsernameRes
 	return json.NewEncoder(w).Encode(response)
 }
 
+type UnblockUserByUsernameRequestObject struct {
+	Username string `json:"username"`
+}
+
+type UnblockUserByUsernameResponseObject interface {
+	VisitUnblockUserByUsernameResponse(w http.ResponseWriter) error
+}
+
+type UnblockUserByUsername200JSONResponse struct{ ProfileResponseJSONResponse }
+
+func (response UnblockUserByUsername200JSONResponse) VisitUnblockUserByUsernameResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Location", fmt.Sprint(response.Headers.ContentLocation))
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type UnblockUserByUsername401Response = UnauthorizedResponse
+
+func (response UnblockUserByUsername401Response) VisitUnblockUserByUsernameResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type UnblockUserByUsername422JSONResponse struct{ GenericErrorJSONResponse }
+
+func (response UnblockUserByUsername422JSONResponse) VisitUnblockUserByUsernameResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type BlockUserByUsernameRequestObject struct {
+	Username string `json:"username"`
+}
+
+type BlockUserByUsernameResponseObject interface {
+	VisitBlockUserByUsernameResponse(w http.ResponseWriter) error
+}
+
+type BlockUserByUsername200JSONResponse struct{ ProfileResponseJSONResponse }
+
+func (response BlockUserByUsername200JSONResponse) VisitBlockUserByUsernameResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Location", fmt.Sprint(response.Headers.ContentLocation))
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type BlockUserByUsername401Response = UnauthorizedResponse
+
+func (response BlockUserByUsername401Response) VisitBlockUserByUsernameResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type BlockUserByUsername422JSONResponse struct{ GenericErrorJSONResponse }
+
+func (response BlockUserByUsername422JSONResponse) VisitBlockUserByUsernameResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
 type UnfollowUserByUsernameRequestObject struct {
 	Username string `json:"username"`
 }
@@ -1488,9 +2257,10 @@ type UnfollowUserByUsername200JSONResponse struct{ ProfileResponseJSONResponse }
 
 func (response UnfollowUserByUsername200JSONResponse) VisitUnfollowUserByUsernameResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Location", fmt.Sprint(response.Headers.ContentLocation))
 	w.WriteHeader(200)
 
-	return json.NewEncoder(w).Encode(response)
+	return json.NewEncoder(w).Encode(response.Body)
 }
 
 type UnfollowUserByUsername401Response = UnauthorizedResponse
@@ -1517,25 +2287,94 @@ type FollowUserByUsernameResponseObject interface {
 	VisitFollowUserByUsernameResponse(w http.ResponseWriter) error
 }
 
-type FollowUserByUsername200JSONResponse struct{ ProfileResponseJSONResponse }
+type FollowUserByUsername200JSONResponse struct{ ProfileResponseJSONResponse }
+
+func (response FollowUserByUsername200JSONResponse) VisitFollowUserByUsernameResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Location", fmt.Sprint(response.Headers.ContentLocation))
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type FollowUserByUsername401Response = UnauthorizedResponse
+
+func (response FollowUserByUsername401Response) VisitFollowUserByUsernameResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type FollowUserByUsername422JSONResponse struct{ GenericErrorJSONResponse }
+
+func (response FollowUserByUsername422JSONResponse) VisitFollowUserByUsernameResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UnmuteUserByUsernameRequestObject struct {
+	Username string `json:"username"`
+}
+
+type UnmuteUserByUsernameResponseObject interface {
+	VisitUnmuteUserByUsernameResponse(w http.ResponseWriter) error
+}
+
+type UnmuteUserByUsername200JSONResponse struct{ ProfileResponseJSONResponse }
+
+func (response UnmuteUserByUsername200JSONResponse) VisitUnmuteUserByUsernameResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Location", fmt.Sprint(response.Headers.ContentLocation))
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type UnmuteUserByUsername401Response = UnauthorizedResponse
+
+func (response UnmuteUserByUsername401Response) VisitUnmuteUserByUsernameResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type UnmuteUserByUsername422JSONResponse struct{ GenericErrorJSONResponse }
+
+func (response UnmuteUserByUsername422JSONResponse) VisitUnmuteUserByUsernameResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type MuteUserByUsernameRequestObject struct {
+	Username string `json:"username"`
+}
+
+type MuteUserByUsernameResponseObject interface {
+	VisitMuteUserByUsernameResponse(w http.ResponseWriter) error
+}
+
+type MuteUserByUsername200JSONResponse struct{ ProfileResponseJSONResponse }
 
-func (response FollowUserByUsername200JSONResponse) VisitFollowUserByUsernameResponse(w http.ResponseWriter) error {
+func (response MuteUserByUsername200JSONResponse) VisitMuteUserByUsernameResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Location", fmt.Sprint(response.Headers.ContentLocation))
 	w.WriteHeader(200)
 
-	return json.NewEncoder(w).Encode(response)
+	return json.NewEncoder(w).Encode(response.Body)
 }
 
-type FollowUserByUsername401Response = UnauthorizedResponse
+type MuteUserByUsername401Response = UnauthorizedResponse
 
-func (response FollowUserByUsername401Response) VisitFollowUserByUsernameResponse(w http.ResponseWriter) error {
+func (response MuteUserByUsername401Response) VisitMuteUserByUsernameResponse(w http.ResponseWriter) error {
 	w.WriteHeader(401)
 	return nil
 }
 
-type FollowUserByUsername422JSONResponse struct{ GenericErrorJSONResponse }
+type MuteUserByUsername422JSONResponse struct{ GenericErrorJSONResponse }
 
-func (response FollowUserByUsername422JSONResponse) VisitFollowUserByUsernameResponse(w http.ResponseWriter) error {
+func (response MuteUserByUsername422JSONResponse) VisitMuteUserByUsernameResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(422)
 
@@ -1574,13 +2413,16 @@ type GetCurrentUserResponseObject interface {
 	VisitGetCurrentUserResponse(w http.ResponseWriter) error
 }
 
-type GetCurrentUser200JSONResponse struct{ UserResponseJSONResponse }
+type GetCurrentUser200JSONResponse struct {
+	VersionedUserResponseJSONResponse
+}
 
 func (response GetCurrentUser200JSONResponse) VisitGetCurrentUserResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", fmt.Sprint(response.Headers.ETag))
 	w.WriteHeader(200)
 
-	return json.NewEncoder(w).Encode(response)
+	return json.NewEncoder(w).Encode(response.Body)
 }
 
 type GetCurrentUser401Response = UnauthorizedResponse
@@ -1600,20 +2442,24 @@ func (response GetCurrentUser422JSONResponse) VisitGetCurrentUserResponse(w http
 }
 
 type UpdateCurrentUserRequestObject struct {
-	Body *UpdateCurrentUserJSONRequestBody
+	Params UpdateCurrentUserParams
+	Body   *UpdateCurrentUserJSONRequestBody
 }
 
 type UpdateCurrentUserResponseObject interface {
 	VisitUpdateCurrentUserResponse(w http.ResponseWriter) error
 }
 
-type UpdateCurrentUser200JSONResponse struct{ UserResponseJSONResponse }
+type UpdateCurrentUser200JSONResponse struct {
+	VersionedUserResponseJSONResponse
+}
 
 func (response UpdateCurrentUser200JSONResponse) VisitUpdateCurrentUserResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", fmt.Sprint(response.Headers.ETag))
 	w.WriteHeader(200)
 
-	return json.NewEncoder(w).Encode(response)
+	return json.NewEncoder(w).Encode(response.Body)
 }
 
 type UpdateCurrentUser401Response = UnauthorizedResponse
@@ -1623,6 +2469,15 @@ func (response UpdateCurrentUser401Response) VisitUpdateCurrentUserResponse(w ht
 	return nil
 }
 
+type UpdateCurrentUser412JSONResponse struct{ PreconditionFailedJSONResponse }
+
+func (response UpdateCurrentUser412JSONResponse) VisitUpdateCurrentUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(412)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
 type UpdateCurrentUser422JSONResponse struct{ GenericErrorJSONResponse }
 
 func (response UpdateCurrentUser422JSONResponse) VisitUpdateCurrentUserResponse(w http.ResponseWriter) error {
@@ -1632,6 +2487,113 @@ func (response UpdateCurrentUser422JSONResponse) VisitUpdateCurrentUserResponse(
 	return json.NewEncoder(w).Encode(response)
 }
 
+type UploadCurrentUserAvatarRequestObject struct {
+	Body *multipart.Reader
+}
+
+type UploadCurrentUserAvatarResponseObject interface {
+	VisitUploadCurrentUserAvatarResponse(w http.ResponseWriter) error
+}
+
+type UploadCurrentUserAvatar200JSONResponse struct {
+	VersionedUserResponseJSONResponse
+}
+
+func (response UploadCurrentUserAvatar200JSONResponse) VisitUploadCurrentUserAvatarResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", fmt.Sprint(response.Headers.ETag))
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type UploadCurrentUserAvatar401Response = UnauthorizedResponse
+
+func (response UploadCurrentUserAvatar401Response) VisitUploadCurrentUserAvatarResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type UploadCurrentUserAvatar412JSONResponse struct{ PreconditionFailedJSONResponse }
+
+func (response UploadCurrentUserAvatar412JSONResponse) VisitUploadCurrentUserAvatarResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(412)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UploadCurrentUserAvatar413JSONResponse struct{ PayloadTooLargeJSONResponse }
+
+func (response UploadCurrentUserAvatar413JSONResponse) VisitUploadCurrentUserAvatarResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(413)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UploadCurrentUserAvatar415JSONResponse struct {
+	UnsupportedMediaTypeJSONResponse
+}
+
+func (response UploadCurrentUserAvatar415JSONResponse) VisitUploadCurrentUserAvatarResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(415)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UploadCurrentUserAvatar422JSONResponse struct{ GenericErrorJSONResponse }
+
+func (response UploadCurrentUserAvatar422JSONResponse) VisitUploadCurrentUserAvatarResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ExportCurrentUserDataRequestObject struct {
+}
+
+type ExportCurrentUserDataResponseObject interface {
+	VisitExportCurrentUserDataResponse(w http.ResponseWriter) error
+}
+
+type ExportCurrentUserData200ApplicationzipResponse struct {
+	Body          io.Reader
+	ContentLength int64
+}
+
+func (response ExportCurrentUserData200ApplicationzipResponse) VisitExportCurrentUserDataResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/zip")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type ExportCurrentUserData401Response = UnauthorizedResponse
+
+func (response ExportCurrentUserData401Response) VisitExportCurrentUserDataResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type ExportCurrentUserData422JSONResponse struct{ GenericErrorJSONResponse }
+
+func (response ExportCurrentUserData422JSONResponse) VisitExportCurrentUserDataResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
 type CreateUserRequestObject struct {
 	Body *CreateUserJSONRequestBody
 }
@@ -1702,6 +2664,9 @@ type StrictServerInterface interface {
 	// Get recent articles from users you follow
 	// (GET /articles/feed)
 	GetArticlesFeed(ctx context.Context, request GetArticlesFeedRequestObject) (GetArticlesFeedResponseObject, error)
+	// Search articles
+	// (GET /articles/search)
+	SearchArticles(ctx context.Context, request SearchArticlesRequestObject) (SearchArticlesResponseObject, error)
 	// Delete an article
 	// (DELETE /articles/{slug})
 	DeleteArticle(ctx context.Context, request DeleteArticleRequestObject) (DeleteArticleResponseObject, error)
@@ -1726,15 +2691,33 @@ type StrictServerInterface interface {
 	// Favorite an article
 	// (POST /articles/{slug}/favorite)
 	CreateArticleFavorite(ctx context.Context, request CreateArticleFavoriteRequestObject) (CreateArticleFavoriteResponseObject, error)
+	// Upload an article image
+	// (POST /media/articles)
+	UploadArticleImage(ctx context.Context, request UploadArticleImageRequestObject) (UploadArticleImageResponseObject, error)
+	// Get a stored image
+	// (GET /media/{kind}/{name})
+	GetMedia(ctx context.Context, request GetMediaRequestObject) (GetMediaResponseObject, error)
 	// Get a profile
 	// (GET /profiles/{username})
 	GetProfileByUsername(ctx context.Context, request GetProfileByUsernameRequestObject) (GetProfileByUsernameResponseObject, error)
+	// Unblock a user
+	// (DELETE /profiles/{username}/block)
+	UnblockUserByUsername(ctx context.Context, request UnblockUserByUsernameRequestObject) (UnblockUserByUsernameResponseObject, error)
+	// Block a user
+	// (POST /profiles/{username}/block)
+	BlockUserByUsername(ctx context.Context, request BlockUserByUsernameRequestObject) (BlockUserByUsernameResponseObject, error)
 	// Unfollow a user
 	// (DELETE /profiles/{username}/follow)
 	UnfollowUserByUsername(ctx context.Context, request UnfollowUserByUsernameRequestObject) (UnfollowUserByUsernameResponseObject, error)
 	// Follow a user
 	// (POST /profiles/{username}/follow)
 	FollowUserByUsername(ctx context.Context, request FollowUserByUsernameRequestObject) (FollowUserByUsernameResponseObject, error)
+	// Unmute a user
+	// (DELETE /profiles/{username}/mute)
+	UnmuteUserByUsername(ctx context.Context, request UnmuteUserByUsernameRequestObject) (UnmuteUserByUsernameResponseObject, error)
+	// Mute a user
+	// (POST /profiles/{username}/mute)
+	MuteUserByUsername(ctx context.Context, request MuteUserByUsernameRequestObject) (MuteUserByUsernameResponseObject, error)
 	// Get tags
 	// (GET /tags)
 	GetTags(ctx context.Context, request GetTagsRequestObject) (GetTagsResponseObject, error)
@@ -1744,6 +2727,12 @@ type StrictServerInterface interface {
 	// Update current user
 	// (PUT /user)
 	UpdateCurrentUser(ctx context.Context, request UpdateCurrentUserRequestObject) (UpdateCurrentUserResponseObject, error)
+	// Upload avatar for current user
+	// (POST /user/avatar)
+	UploadCurrentUserAvatar(ctx context.Context, request UploadCurrentUserAvatarRequestObject) (UploadCurrentUserAvatarResponseObject, error)
+	// Export current user data
+	// (GET /user/export)
+	ExportCurrentUserData(ctx context.Context, request ExportCurrentUserDataRequestObject) (ExportCurrentUserDataResponseObject, error)
 
 	// (POST /users)
 	CreateUser(ctx context.Context, request CreateUserRequestObject) (CreateUserResponseObject, error)
@@ -1864,6 +2853,32 @@ func (sh *strictHandler) GetArticlesFeed(w http.ResponseWriter, r *http.Request,
 	}
 }
 
+// SearchArticles operation middleware
+func (sh *strictHandler) SearchArticles(w http.ResponseWriter, r *http.Request, params SearchArticlesParams) {
+	var request SearchArticlesRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.SearchArticles(ctx, request.(SearchArticlesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "SearchArticles")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(SearchArticlesResponseObject); ok {
+		if err := validResponse.VisitSearchArticlesResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // DeleteArticle operation middleware
 func (sh *strictHandler) DeleteArticle(w http.ResponseWriter, r *http.Request, slug string) {
 	var request DeleteArticleRequestObject
@@ -1917,10 +2932,11 @@ func (sh *strictHandler) GetArticle(w http.ResponseWriter, r *http.Request, slug
 }
 
 // UpdateArticle operation middleware
-func (sh *strictHandler) UpdateArticle(w http.ResponseWriter, r *http.Request, slug string) {
+func (sh *strictHandler) UpdateArticle(w http.ResponseWriter, r *http.Request, slug string, params UpdateArticleParams) {
 	var request UpdateArticleRequestObject
 
 	request.Slug = slug
+	request.Params = params
 
 	var body UpdateArticleJSONRequestBody
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
@@ -2087,6 +3103,64 @@ func (sh *strictHandler) CreateArticleFavorite(w http.ResponseWriter, r *http.Re
 	}
 }
 
+// UploadArticleImage operation middleware
+func (sh *strictHandler) UploadArticleImage(w http.ResponseWriter, r *http.Request) {
+	var request UploadArticleImageRequestObject
+
+	if reader, err := r.MultipartReader(); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode multipart body: %w", err))
+		return
+	} else {
+		request.Body = reader
+	}
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.UploadArticleImage(ctx, request.(UploadArticleImageRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "UploadArticleImage")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(UploadArticleImageResponseObject); ok {
+		if err := validResponse.VisitUploadArticleImageResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetMedia operation middleware
+func (sh *strictHandler) GetMedia(w http.ResponseWriter, r *http.Request, kind GetMediaParamsKind, name string) {
+	var request GetMediaRequestObject
+
+	request.Kind = kind
+	request.Name = name
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetMedia(ctx, request.(GetMediaRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetMedia")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetMediaResponseObject); ok {
+		if err := validResponse.VisitGetMediaResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // GetProfileByUsername operation middleware
 func (sh *strictHandler) GetProfileByUsername(w http.ResponseWriter, r *http.Request, username string) {
 	var request GetProfileByUsernameRequestObject
@@ -2113,6 +3187,58 @@ func (sh *strictHandler) GetProfileByUsername(w http.ResponseWriter, r *http.Req
 	}
 }
 
+// UnblockUserByUsername operation middleware
+func (sh *strictHandler) UnblockUserByUsername(w http.ResponseWriter, r *http.Request, username string) {
+	var request UnblockUserByUsernameRequestObject
+
+	request.Username = username
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.UnblockUserByUsername(ctx, request.(UnblockUserByUsernameRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "UnblockUserByUsername")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(UnblockUserByUsernameResponseObject); ok {
+		if err := validResponse.VisitUnblockUserByUsernameResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// BlockUserByUsername operation middleware
+func (sh *strictHandler) BlockUserByUsername(w http.ResponseWriter, r *http.Request, username string) {
+	var request BlockUserByUsernameRequestObject
+
+	request.Username = username
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.BlockUserByUsername(ctx, request.(BlockUserByUsernameRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "BlockUserByUsername")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(BlockUserByUsernameResponseObject); ok {
+		if err := validResponse.VisitBlockUserByUsernameResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // UnfollowUserByUsername operation middleware
 func (sh *strictHandler) UnfollowUserByUsername(w http.ResponseWriter, r *http.Request, username string) {
 	var request UnfollowUserByUsernameRequestObject
@@ -2165,6 +3291,58 @@ func (sh *strictHandler) FollowUserByUsername(w http.ResponseWriter, r *http.Req
 	}
 }
 
+// UnmuteUserByUsername operation middleware
+func (sh *strictHandler) UnmuteUserByUsername(w http.ResponseWriter, r *http.Request, username string) {
+	var request UnmuteUserByUsernameRequestObject
+
+	request.Username = username
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.UnmuteUserByUsername(ctx, request.(UnmuteUserByUsernameRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "UnmuteUserByUsername")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(UnmuteUserByUsernameResponseObject); ok {
+		if err := validResponse.VisitUnmuteUserByUsernameResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// MuteUserByUsername operation middleware
+func (sh *strictHandler) MuteUserByUsername(w http.ResponseWriter, r *http.Request, username string) {
+	var request MuteUserByUsernameRequestObject
+
+	request.Username = username
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.MuteUserByUsername(ctx, request.(MuteUserByUsernameRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "MuteUserByUsername")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(MuteUserByUsernameResponseObject); ok {
+		if err := validResponse.VisitMuteUserByUsernameResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // GetTags operation middleware
 func (sh *strictHandler) GetTags(w http.ResponseWriter, r *http.Request) {
 	var request GetTagsRequestObject
@@ -2214,9 +3392,11 @@ func (sh *strictHandler) GetCurrentUser(w http.ResponseWriter, r *http.Request)
 }
 
 // UpdateCurrentUser operation middleware
-func (sh *strictHandler) UpdateCurrentUser(w http.ResponseWriter, r *http.Request) {
+func (sh *strictHandler) UpdateCurrentUser(w http.ResponseWriter, r *http.Request, params UpdateCurrentUserParams) {
 	var request UpdateCurrentUserRequestObject
 
+	request.Params = params
+
 	var body UpdateCurrentUserJSONRequestBody
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
@@ -2244,6 +3424,61 @@ func (sh *strictHandler) UpdateCurrentUser(w http.ResponseWriter, r *http.Reques
 	}
 }
 
+// UploadCurrentUserAvatar operation middleware
+func (sh *strictHandler) UploadCurrentUserAvatar(w http.ResponseWriter, r *http.Request) {
+	var request UploadCurrentUserAvatarRequestObject
+
+	if reader, err := r.MultipartReader(); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode multipart body: %w", err))
+		return
+	} else {
+		request.Body = reader
+	}
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.UploadCurrentUserAvatar(ctx, request.(UploadCurrentUserAvatarRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "UploadCurrentUserAvatar")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(UploadCurrentUserAvatarResponseObject); ok {
+		if err := validResponse.VisitUploadCurrentUserAvatarResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ExportCurrentUserData operation middleware
+func (sh *strictHandler) ExportCurrentUserData(w http.ResponseWriter, r *http.Request) {
+	var request ExportCurrentUserDataRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ExportCurrentUserData(ctx, request.(ExportCurrentUserDataRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ExportCurrentUserData")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ExportCurrentUserDataResponseObject); ok {
+		if err := validResponse.VisitExportCurrentUserDataResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // CreateUser operation middleware
 func (sh *strictHandler) CreateUser(w http.ResponseWriter, r *http.Request) {
 	var request CreateUserRequestObject
@@ -2309,48 +3544,73 @@ func (sh *strictHandler) Login(w http.ResponseWriter, r *http.Request) {
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+xbT5PTOBb/KirtVs0ulY4DO4epnLanoafYBZaC7poDcFDsF0eDLXkkuSHTle++Jcmy",
-	"ZVtOnMRA7yxc6NjS0/vze3/0JN/jmOcFZ8CUxMt7XBBBclAgzK+M5lS91o/0rwRkLGihKGd4iW82gFiZ",
-	"r0BIxNeIKsglUhwJUKVgczzDVA/7vQSxxTPMSA54aSniGZbxBnJiqa5JmSm8fLKY4Zwympc5Xj6eYbUt",
-	"9AzKFKQg8G43w3y9lnCYoRY/8iMt0ArWXACSighFWaqfxzzLIFZIbQAJkGWmkAQ1xLdducV4zesiwOtu",
-	"hgX8XoJUP/OEgtHmC55SditBvLFv9LOYMwXM/EmKIqMx0dJEv0kt0r23WiF4AUJVpEoJQv//VwFrvMR/",
-	"iRorRnaOjOrlsOOGCkjw8p2d/aHmmq9+g1hZptsqvRKQAFOUZEaVpQTsU1KihN0Mv4JPl0LROIPzBSOW",
-	"0CHZmiV7wjkKY+SraBg4CCBqSLwrnufA1PnixZbQCPGqJXviOQqjzGfHoi0v0SfC1EE5vx44q8VOh+ZT",
-	"UIRmJvRoD2bwScNT2AiUUqlAhIS8LRKi4GvDtbXqVIgtDdFhIb+eMZv1Trenno2Syqi1dHN0qVAGRCr0",
-	"6BFn8OgRWlPIEkQlcsvM+yowTMiCM2mFeJYXavufj2+qZ/3c8UrnA6uf3Qz/AgwEjZ8JwcVRqtunJJ/o",
-	"S55AFtQCg88FxAoSBGb13Qy/LDNFi8zBR/pSnAdb87dJlIEhpdrwg5Z/LfiaakjPsA0syaVhZs1FThRe",
-	"Ym3EC0VzjdMKA1IJylLclf2+/35N7rigSpu1frviPAPC/NfyipdWB900PMMyK9MgbUXSF9R6Rq2B/iD7",
-	"gAhBtuY3VdbZeyMtXI+QvhsDrLp9Nbb142ujJ3olZyOVY9VnrO+FfQEdMAY1Gg5dEnenjnF5B2xUU/HQ",
-	"XuWuKdBepcw22vehus69XQWFc7E8Ttp61m6GK/+ZQMii8sSxDtsRxU0fI4nn9G8pS7MmnU4VmA5JMUUm",
-	"taw77DXC1LXeVLgbjbYzKr1KmLgB7g1Jp/AdRVJ5TIzsyGCmjxFAs6up3TIbCekfNup306P3Vo82Jc7Z",
-	"Qo6qcc6ubszsipxe7bLB+pmpd8WTbdA233PyJDnZ6Pdrp+arJno8IHjQJGzTL6lrmhxUVr+47qnN1NOB",
-	"Qtdp58QIZ6YHQkB7VLV4iPWmTdNnOSc0C7JUECk/cZG0tF0/PKRsS9ejEuLLa7EM6uzo6DGph4cs0fVM",
-	"O3dAvkEXG5BvnOnrTsoXNOjMpB7bm7w/1tre5BD3r5tKsqMVysMpgWcZ/6R/BFMCzUk6EKBHy6CX9hdy",
-	"VA+I0u67TAjjYUQO8BDGw5BGh3EyrEwfQUdqus/zF+dW8Y/AJgGFg7cDhKW8Fxi6BoG4FFRt3+p0acW7",
-	"cSy1S7drLhCJY5DSHBxsABWCK9ujuXz9HAmQvBQxyJnps+alVGhD7gAJiIHeQYIIIuiOZDRB//r1Bhn+",
-	"EFkrEHWnUlPmAmU8TfWflM3RzYZKb7whqzbA0ApQKSFBa81Xlnnc1Jyg1RZpOBhaClGG7igxrP9wWdXP",
-	"pjD+AW2AJCDm79l7dumtRiVKdUbVGVcT01O1rKstAqo2Hc418UirW7aF8F5Emc5yZp2bDaDakZGNdVa8",
-	"FehJw2yipZ6PEELGVOiz+Tff2n/zP8w/O+A9c+c4dmpzkNOi3ARWUtB/w9aW7pStudtNkFh5gRszmhOQ",
-	"Hx//M9UP5jHPG8qvaE7QM/mRsISILQ5041lSUmUUmfC41KnHcZHRGKo9TEXt5fMb9KJ6OsOl0KtvlCrk",
-	"Mop4Acyaes5FGlWTZfTy+Y0XnfAbINmvXGQJ8pbGM3wHQlqWHs8X84WeoimSguIl/sd8MX9ssoXaGLeI",
-	"/GZhCqrvIb+AQjmXyiCeqbqdg9KMr0iWbefoVgIyJ2qoOWBEiqM1zawfyDJTco60dTT6uKFNtF/rCGTU",
-	"9Dyxa102PSf/tPJdz3EtbQ1fkg4c69k3zb6wF3CGidqKFf3NxZm/DyxRV7YnrVLvJhBfI2KPOA4u6W9H",
-	"9q4a2kA0Oo3849YRw73T4t2HTgf+yWIxtGOpx0WDne7dDP+4eHyYQLc/8OOTJ4cntbr9JjeUea492CJ7",
-	"CNR2S6eBh2tEftB5mMuAj1yZ/QwizBFqsF6ntC7W7RxXvfgHytthqbwz56h/MLvrmWWEVsNNvq9skypf",
-	"Gz+vMvW7DxpmjbV6Og6aaIY/X8Q8gRTYRaWuC10IXjh/9XqLdeyL1mA7HscFwLXgObKZUdcGNu0NR0Pj",
-	"QCOA4QXBazDvO4Hwu1+fiqGQxwetGHb/FmbuZVamOwuXDFTg2PGpeX5cVLBzmqiwNwe+zcrUHZGT5ui4",
-	"4qfKHjrVN8mjale1T1T35ZGTINE9kX14SOjZZijgD4aEnlkZV2Oc+kSjpuZ+0jez6HCWmCAJH7ZCUQas",
-	"YPfex/lXu2dwminqqxmTWePIzB+85rKb1qwPy117pp4w+dtAHvnnyINOr5HgBto9egB8I7Y37vz7JARu",
-	"SPvSVwqqxdS3jBODB/zfssgfsJiHodoeh2t8R23Q/OMqftedngIAcYC1bxie+lcqz4hN3QP7h7sxGQBG",
-	"EGWHIlXs3RoYjFTRPU1GFaCnQ7ZVjk4J2STA2kSQ7fVbnj917MSBK7P7qmVzNnlw5eb61J+8WD4G4UHk",
-	"utbVPtDeMjfqDKheu4WmwGpZc/QwK/AHVqqF7OdBxJlmX669PgkBrfw6KQK+23+8/a9HW19HiOpmoozu",
-	"Xdt7t7cAJ6ia4XXMK+vJrVSQjyvDq1Pwn7e37hzxAEzcOLeY42LfzrxsaH9hbHQvmn7LYru2kGd1d+lg",
-	"0OZR1XbbnxfMGGf11RZ5Cu7s8qux2mrn27iTC1yD8P/Z4uPygG+vIBoG4/9oS19/ITt/t/LIaH/Qxtrj",
-	"3T3j4b4KSeXINqq5S3yKMlt3picKdsoy46Q2vFmR3a3jIZGl3ZCUQgBT2dZcrIDkgjKnyZ7gV3bsray+",
-	"fTtW/tZ16od5NlOpowsm8/UYYYkBCDBV3fw+1BlObPigzF5DoZyZjUtnkVB/uKvqk/qz/hd6uz+hwaqe",
-	"7LE2O9T6MNf2aheyV3qDSeJNdUkJkfr70IEtwal27Hwze9Lxes+IJ9ljaq3aG1vDujXXlo23wGcqzUf1",
-	"Qf2acaeotve1/P+ch9R+8MzXEMoqjUxlMeOM4i5cybzgMcmQfd+6R7aMoky/23Cplj8tflpE5nC/Yqq+",
-	"hnbZfJpXP7tqPmCrnzW7Nu9h8w1C/aj6zqf+PST87sPuvwEAAP//e22nz5tCAAA=",
+	"H4sIAAAAAAAC/+xdW3PctpL+K13crcqui5qRfbJVp+ZpZcfK8cZKUra0eYj9gCF6OIhIgAFAyWPV/Pct",
+	"ALyAQ3LIGVGykpVfJJG49OVDd6PRoO+CSKSZ4Mi1ChZ3wRoJRWl/fXtJYvOToookyzQTPFgEb3IpkWu4",
+	"QamY4CBWoNcIEpXIZYQhKOQUmIYlia6BKHi3OrkgOlqDFpCSawQCkeCUmfFIAnlGicYgDFS0xpSYCfUm",
+	"w2ARKC0Zj4PtNgx+lWLFEnwjuEau34uIOGpaxBEuOItIAhnR65K2zPWeweUaIVcoOUkRGC8I/zNHpSEl",
+	"G1giCI6gi2awJgoU45FpZfpQILdkAysp0r0Eb8MgI5KkqAtRspUVwa/mYZtqI+iS1lKsS2Q8LoRDQ0eS",
+	"/QOYAol/YKSRwi3TayDw/ctXwJp6sLRHa8JjpI6HWRAGzEznVByEgWEpWASlggZ0kLCU6R4OjGB5ni5R",
+	"KsMI05gqo2+JOpe8mvnPHOWmntiO2JiV4orkiQ4Wr07DIGWcpXkaLF6GJTmMa4xRWnrEaqVwmKAGPeqa",
+	"ZbDElZAIShOpjZC1gEgkCUa6lGCeaFCo++h2MzcIr2g97aB1GwYFyl4LytAi4l1KYrzKEkHoB/fOPI0c",
+	"wO2IeaJZRqSer4RMTyjRdqJ6ykyKDKUuxmNmPPOLaU10sAiWjBNLdVuVhhomkQaL34uOn6tmYmmw5ahu",
+	"ytSSbKSVW7JDyHgcwh8ZxkA4hZitgBi55lkmpEYa+BNpmeM2DN6LmPErhbKbaZJlCXOLe/6HEnwfx2aF",
+	"mp//LnEVLIJ/m9eGbO76qHk1XYtt23sM128kUuSakcQiKFfYxdfPeHsmNYsSvD9jxA00xFs9ZYu5coQx",
+	"/BVj2FUg0ZniLvbeiDRFru/PXuQGGsFeMWWLvXKEUepzbWEjcrglXA/y+XjgLCY7Hpo/oCYsUaXr4Hjr",
+	"3JY1vDFT2lr5FpNX1o88Nlwbs06F2Cp46GHy8ZRZz3e8Pk1voIVSK+5mcKYhQaI0vHghOL54ASuGCXWB",
+	"gJtm1haBJUJlgivHRCG1j0hktP5QvLmHUJybtL9a9zokoN35jZvfVkIhUpJNS3LlHIegQtkJoOy6DYO3",
+	"aaY3v1z7PDe7/iygFMI2DM6FXDJKkR8knH2s/4gcJYveSinkhaCYdNFvIhaS6zVybWZB6tYyU8CFBpIk",
+	"4hapjaEFZatNI9gzZPuTPC7lVxy/ZC4eRTv7NgwubPSSlOtdTQC4wkQ0EbfTJNdrMbhUi82EIdN5Anqm",
+	"G5GTWXUnmqXYDp52eL9rv1+RGyGZRuq9XQqRIOH+a/VG5Fx7bapwMQxUksedY2sSv2dKNyTQbtRYUWGg",
+	"mXbWudWy2F2M537XaDtx+2JsyseXRov1gs+aq5JUn7D2ym8zWAKjV6LdvkYFu13HmJkS2FCN4qG9CDam",
+	"QHsR44y3r1WwNGBTq4EP4rbqZTbjZGPi/0sh3hMZ4+ObSbf/APwSIVJlLaFiXxHcftJQKLFKMZwTliB9",
+	"fCKrjXhjE97Y4/vph1uiICUUgcSEcaVDwFk8s00Er5IVVS6l2MPXqZEJIFekSUabzx1gld3H4KocI/Tz",
+	"TUV258RP73QRUnSZ9ySF7HQfGY+TOsadyvmMDHLuFd460kv7ElTMVBuwqWzLaItyj+1XwUxUG6ePWkik",
+	"NpcwAStV2mMfI96U90h/uFHAddiGwSWJpzD0msTqEIe+Q7/tPoZ8Q64Z7Yo7t82+Oqu4G8t5b23rKp1z",
+	"gZSRy012GLNTGvwyFq5TTE4bYJk31Nrt3r1VMmq/d++dniH4f50nQPr3sVdh1xHCPjNu22y3vjT+UnoM",
+	"XRaeaQXRztkIUUA4WAaPFsu2TDP7u/gJtj5LQTed5uZ5TzTJnsjK97G3Rl1Jlie5S16zeJ2weK3bLuiM",
+	"28heZhqIFDmnNvJNTdhrTryEpMosrLVOk7B4rOzBw60kWWZ8AoeUyGvABN2epYM+RjvJkoRftyn6l7iF",
+	"W0wSS0hh50qCQjCsuETNErVGOYNfeLIxIY85A1wmaO0D4zaOd9mpIPTEKPJl4snQnVo9QWQz6sGwCeUa",
+	"pj7c6wnDellYCfv674LxmzpAfUJWjtFu0/SQJsMKff+ab0dRLbHZtFxHvqyUzpGxp+3e4SabrYrJu0iv",
+	"j+faJKeEJZ0kZUQpYwUa0q4eDgnbjeuN0kWXd7TWK7ODTd6ky7lLE61Vafv28Ne7xHr4G6f66gTtARUa",
+	"BmUJxzCZLW17nbuo/7VOgexIhYnuyEaYxLz5ozOyqban9+DBTO1PVI46wIq/6W2xs8bS93aYM9mtpltG",
+	"9XpEetX0L1uH5UxdFDZPBCdcaP1rpoeGbsT26bwfyf3q9jF+IBbaND84tVpcI58EtuUCLCHrRt4LXRPs",
+	"Y5RLpjcfjUN37F2WJDW0bw7rgEQRKmUreVyxl3aHUWe/vquysCq0FQBprjSsyQ2CxAjZDVIgQOCGJIzC",
+	"//x2CZY+ICuNsjpDNyMLCYmIY/Mr46aQjCmvvR1Wr9GUbUGukMLK0JUkHjUVJbDcgIGDHUubaPWGEUv6",
+	"d2dF7sVub78rkryzT/wTP/NmYwpi5CjtIeHSnQQaXpcbQKbXO5SbwedG3KrJhPdinhg/bOcx2ZbK1ICz",
+	"xo69ZZWE7iYTFqY/AIBVFXyx/2Yb92/21f5zDT7xvlK0xsi16ScZ+wk3bgPO+EqUOQESac+1BJylBNX1",
+	"y/+OzYNZJNJ65J9ZSuCtuiacErkJOupEOM2ZtoKkIsqNcyypSFiERSaiGO3i3SW8L54WFjNYa52pxXwu",
+	"MuRO1TMh43nRWc0v3l161in4gCT5TciEgjd1EAZF7iBYBC9np7NT08WMSDIWLIJ/zE5nL60/02u7LOb+",
+	"qWiMHdupH1FDKpS2iOe6OreCOBFLkiSbGVwpBFviBnXVImgBK5a4dWCP0mdgtGPQJzJXuxlY0qQV0zvq",
+	"5jqrD9fqwYLF762F68Y28CVxCYedOjv3Zk9NYv+gLqaG/yjtzH/2TFHF3kfNUm3bTQkOcQf2g1P6+/69",
+	"s3ZtcWqZzv36xxHNvfLN7eed2pBXp6d9e6qq3bz3SH8bBt+fvhweYDe3/P2rV8OdGmUN1jfkaWpWsEN2",
+	"H6jdptQAL6gQ+dn4YaE61sgbu+MyubpioBrrlUvbxbrrc1ZlPesKz00/V14R6LxdMrhtqWWEVLszx4+s",
+	"k8Jf23VeeOrfP28/+9pqybhTRWHw5SQSFGPkJ4W4TkwgeFKuVy9hXdm++QqRHm4ATRk3OM9oYgPn9vqt",
+	"oV1AI4DhGcFztO93DOHzuj4WQz/iSC12L/8GZopMXB9qznOT78MvuiwoMxa+LDw0LlyF4PVQtgR6aVd2",
+	"CEt3ocClJldMKj2D32zi0j4EYaM0E11VxZuKpGiTmyF8Cv7MhYntsrUkCtWnoOjGOAhJUYZ2MmKbQyZx",
+	"xb6UdwFOTO40ySmqklpla9sI4y7enNDbuyzzWIdvIstClEasPc7xz1Yh45Gu+S8QVTwxO9BdIDqBn3Yj",
+	"gld2NbQ278zxx9YtygR1R8nmD/b5YR7b9ak99l64fkzy6k4OqQuOC3oKtZswvNZ6cWYzHr5HqWm3mvXp",
+	"WemWbvqCsV533VIrF3qMwz1SqYaOb6jR/ghuggB5WAtZ3qEFlxc7bH0183nHqaIq6J9CG8NGs3Evz2nv",
+	"wCi+8zLF9hgYDNSCHL3OT/8x3KmueTc9Xo6AXUeJ5YMalRYgJ9w+OHcz90tue02TwWvZ0GX5OpbIiARJ",
+	"WSp81DpZk+aFphh1g6hvac16a6G/ZZqgR2Mehip9DGcJytF61T8uZ1DMOAkAog7SpgTB4amNneuC2+Md",
+	"427d69NNbfQAoxNlQ5Yq8opvey3V/I7RUWHy8ZBtBM1TQpZ2kDad39+5ufxDSU7UcR10X0zP6JiZ66PQ",
+	"v3lIfwjCO5FbJr/3gfaKl63uAdXzcqIpsJpXFD3NfcLTAkun/jyIlKrZ52vPj0JAw79OioBn/Y/X//lo",
+	"7RsLkSJlpHGM2Y0I960MW1Dtiv6NCcqVPZWuJwpBaSFRAQGJ5lIYhUhkG5swdR8jUbZS2xWp7O5fzQyF",
+	"fN8VBQsHxz4dH/Y47lyn457M0bp+OWIPuHutz/b7rzGTdVwReeC9oCHU0zpU5SUFyCwhDYDdXTNOt/M7",
+	"s2K3vTu8jyhv0MOYBZMtshAcSyNRXj/kNBOMm1S5fzXI1SJzvEHprmozpCEoYbpuICK2RiQitpiZcYor",
+	"xpnGZNOC44+oHRst+9VhgQx3ey0Q8jw1kiE3RBPp3331SnD8CKpjEvtjAjPnXeewIpu/aN7iGP6ETat8",
+	"45efdu4SGgGfmKuBUiTN0Vtj2VX1/aFYnSg9V0JsH4KLm5VqflcePGz35ihI+cUrryyhPGbaKI3puExF",
+	"UQz5enNVzDrkSct2O9/d2ptizeuxH9h97l6U/Zb5iEpDnsYL+vp1Pl8mIrreHznbJqXOlxvwxLvj7FxT",
+	"o7L7K7gZKzsy/39re1SY7CurEwh90fHrHi3P4AOmwngwdwyuTHC0FHoNlEmMTG8VQibxxjBQVgvaUewx",
+	"el35Z7RrT6ndWfOaUbQX7ZmsPwWwC6nXDwOoZziNgtPrITD1WRWn9IENuW0zzq64tg9hWKrajmcoDG3A",
+	"fX0dZFrOR2v6/IH0/KzlkdvsQR33rfg0H0rAmRbjVrtp+RBr3dL4jIHBle5p6qB1ftGt4Rn8q+Hq7UbN",
+	"BQcbkUtYIVIbEZSJ4IQprdwVeJFrIKsVRtrdHbARSAsyFw8CmGe4jIHLxQBYjMFwT/edgJNYjSzLsd8c",
+	"OUaUjW+rTLTn0o6YkmtLm2O5/E5EH8vKHR25jzskG3uJBukJ46UkW4wXH8m+cq/vUQvS+BLG0yzILeSy",
+	"iypDubUUZ/VHDY1EB0qOys8ecpcEYoLbRO/OJF2FR02ZH1ZzPVUdkP+90e0TUPwTruQ5FDZDB+bm96Ba",
+	"znOX5xx/nLDv4EChVsA0kIYhsJR/p6rMXddhgofJM0fQQ50o/KWw9fc8jrAK7rJWwyaxgi1+MaT3n09o",
+	"iSQ1IP3KMjBl1ezGZXnNXVOUyv6XCpRoAmtM3DXUg3zXWzu/B9sfiCbBYBrf/yrTV5ZNkc5/es7Oyaah",
+	"XCvqgzS854TzQ3FrF0j1Ke+eE+4qrji8Lmu/hxoj713jcZS8Jzb3xRXmftnaL43Y5YBfmLIblE752nbH",
+	"iLb1HxscZaK/Zbjn4dyTECSFRKbSmJnKnLJ2bvXMRzwTcO8bF6sX83li3q2F0ot/nv7zdG7DtIKo6l72",
+	"Wf1R3urZm/rTtdWzugjBe+iO3LwH9XeEqkfFVxSrv/uksf28/b8BAPmOrrdaZwAA",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -9,9 +9,21 @@ import (
 )
 
 func NewArticleModule() fx.Option {
+	return newArticleModule(internal.NewArticleRepository)
+}
+
+// NewInMemoryArticleModule is NewArticleModule with the repository kept in a RealWorldAppInMemoryDb
+func NewInMemoryArticleModule() fx.Option {
+	return newArticleModule(internal.NewInMemoryArticleRepository)
+}
+
+func newArticleModule(repositoryConstructor any) fx.Option {
 	return util.NewFxModule[article_types.ArticleService](
 		"article_service",
 		internal.NewArticleServiceImpl,
-		fx.Provide(internal.NewPostgresArticleRepository),
+		fx.Provide(repositoryConstructor),
 	)
 }
+
+var UpgradeArticleData = internal.UpgradeArticleData
+var EncodeArticleData = internal.EncodeArticleData
@@ -0,0 +1,36 @@
+package internal
+
+import (
+	article_types "github.com/nimaeskandary/go-realworld/pkg/article/types"
+	audit_types "github.com/nimaeskandary/go-realworld/pkg/audit/types"
+
+	"github.com/google/uuid"
+)
+
+// auditedArticle is the state of an article recorded in the audit log. Times and versions change on every write, so
+// they are left out, and an update that only touches them is not recorded
+type auditedArticle struct {
+	AuthorUserId uuid.UUID `json:"author_user_id"`
+	Title        string    `json:"title"`
+	Description  string    `json:"description"`
+	Body         string    `json:"body"`
+}
+
+func toAuditedArticle(article article_types.Article) auditedArticle {
+	return auditedArticle{
+		AuthorUserId: article.AuthorUserId,
+		Title:        article.Title,
+		Description:  article.Description,
+		Body:         article.Body,
+	}
+}
+
+func articleChange(action audit_types.AuditAction, id uuid.UUID, before any, after any) audit_types.AuditChange {
+	return audit_types.AuditChange{
+		Action:     action,
+		EntityType: audit_types.AuditEntityArticle,
+		EntityId:   id.String(),
+		Before:     before,
+		After:      after,
+	}
+}
@@ -0,0 +1,126 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	article_types "github.com/nimaeskandary/go-realworld/pkg/article/types"
+)
+
+// currentArticleDataSchemaVersion is the schema_version of the articles data payload written by this code.
+//
+// To change the payload shape, bump this, update articleData, add the upgrade from the previous version to
+// articleDataUpgrades, and add a code migration that calls UpgradeArticleData on every row, see
+// upgradeArticleDataMigration. Until the migration has run, old rows are upgraded each time they are read
+const currentArticleDataSchemaVersion = 2
+
+// articleData is the articles data payload at currentArticleDataSchemaVersion
+type articleData struct {
+	SchemaVersion int    `json:"schema_version"`
+	Title         string `json:"title"`
+	Description   string `json:"description"`
+	Body          string `json:"body"`
+}
+
+// articleDataUpgrades upgrades a payload from the schema_version it is keyed by to the next, changing the fields in
+// place. UpgradeArticleData sets the new schema_version after each one
+var articleDataUpgrades = map[int]func(data map[string]any) error{
+	// payloads written before schema_version was added are version 0, they have the same fields as version 1
+	0: func(_ map[string]any) error { return nil },
+	// version 2 added the body
+	1: func(data map[string]any) error {
+		if _, ok := data["body"]; !ok {
+			data["body"] = ""
+		}
+		return nil
+	},
+}
+
+// EncodeArticleData builds the data payload of an article, at the current schema version. Anything writing articles
+// other than through the repository, e.g. a bulk load, must write this payload
+func EncodeArticleData(article article_types.Article) ([]byte, error) {
+	return json.Marshal(articleData{
+		SchemaVersion: currentArticleDataSchemaVersion,
+		Title:         article.Title,
+		Description:   article.Description,
+		Body:          article.Body,
+	})
+}
+
+// decodeArticleData reads a data payload, upgrading it first if it is from an older schema version
+func decodeArticleData(data []byte) (articleData, error) {
+	var decoded articleData
+	if len(data) == 0 {
+		return decoded, nil
+	}
+
+	upgraded, _, err := UpgradeArticleData(data)
+	if err != nil {
+		return decoded, err
+	}
+	if err := json.Unmarshal(upgraded, &decoded); err != nil {
+		return decoded, fmt.Errorf("error unmarshalling article data json: %w", err)
+	}
+	return decoded, nil
+}
+
+// UpgradeArticleData applies the upgrades from the schema version of the data payload to the current one, returning
+// the upgraded payload and whether it changed. A payload at the current version is returned as is. A payload from a
+// newer version than this code knows is an error rather than being read with fields missing
+func UpgradeArticleData(data []byte) ([]byte, bool, error) {
+	var fields map[string]any
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	// keeps numbers as written, rather than round tripping them through float64
+	decoder.UseNumber()
+	if err := decoder.Decode(&fields); err != nil {
+		return nil, false, fmt.Errorf("error unmarshalling article data json: %w", err)
+	}
+
+	version, err := articleDataSchemaVersion(fields)
+	if err != nil {
+		return nil, false, err
+	}
+	if version > currentArticleDataSchemaVersion {
+		return nil, false, fmt.Errorf("article data schema version %v is newer than the latest known, %v",
+			version, currentArticleDataSchemaVersion)
+	}
+	if version == currentArticleDataSchemaVersion {
+		return data, false, nil
+	}
+
+	for ; version < currentArticleDataSchemaVersion; version++ {
+		upgrade, ok := articleDataUpgrades[version]
+		if !ok {
+			return nil, false, fmt.Errorf("no upgrade from article data schema version %v", version)
+		}
+		if err := upgrade(fields); err != nil {
+			return nil, false, fmt.Errorf("error upgrading article data from schema version %v: %w", version, err)
+		}
+		fields["schema_version"] = version + 1
+	}
+
+	upgraded, err := json.Marshal(fields)
+	if err != nil {
+		return nil, false, fmt.Errorf("error marshalling upgraded article data: %w", err)
+	}
+	return upgraded, true, nil
+}
+
+// articleDataSchemaVersion is 0 for payloads without a schema_version
+func articleDataSchemaVersion(fields map[string]any) (int, error) {
+	raw, ok := fields["schema_version"]
+	if !ok {
+		return 0, nil
+	}
+	number, ok := raw.(json.Number)
+	if !ok {
+		return 0, fmt.Errorf("article data schema_version is not a number: %v", raw)
+	}
+	version, err := strconv.Atoi(number.String())
+	if err != nil {
+		return 0, fmt.Errorf("article data schema_version is not an integer: %v", number)
+	}
+	return version, nil
+}
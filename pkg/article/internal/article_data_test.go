@@ -0,0 +1,70 @@
+package internal_test
+
+import (
+	"testing"
+
+	"github.com/nimaeskandary/go-realworld/pkg/article/internal"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_UpgradeArticleData(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should upgrade a payload written before schema_version", func(t *testing.T) {
+		t.Parallel()
+
+		upgraded, changed, err := internal.UpgradeArticleData([]byte(`{"title":"a title","description":"a description"}`))
+		require.NoError(t, err)
+
+		assert.True(t, changed)
+		assert.JSONEq(t, `{"schema_version":2,"title":"a title","description":"a description","body":""}`, string(upgraded))
+	})
+
+	t.Run("should upgrade a payload through each version in turn", func(t *testing.T) {
+		t.Parallel()
+
+		upgraded, changed, err := internal.UpgradeArticleData([]byte(`{"schema_version":1,"title":"a title"}`))
+		require.NoError(t, err)
+
+		assert.True(t, changed)
+		assert.JSONEq(t, `{"schema_version":2,"title":"a title","body":""}`, string(upgraded))
+	})
+
+	t.Run("should keep fields it does not know, as written", func(t *testing.T) {
+		t.Parallel()
+
+		upgraded, _, err := internal.UpgradeArticleData([]byte(`{"title":"a title","views":12345678901234567890}`))
+		require.NoError(t, err)
+
+		assert.JSONEq(t, `{"schema_version":2,"title":"a title","body":"","views":12345678901234567890}`, string(upgraded))
+	})
+
+	t.Run("should return a payload at the current version as is", func(t *testing.T) {
+		t.Parallel()
+		data := []byte(`{"schema_version":2,"title":"a title","description":"a description","body":"a body"}`)
+
+		upgraded, changed, err := internal.UpgradeArticleData(data)
+		require.NoError(t, err)
+
+		assert.False(t, changed)
+		assert.Equal(t, data, upgraded)
+	})
+
+	t.Run("should fail on a payload from a newer version", func(t *testing.T) {
+		t.Parallel()
+
+		_, _, err := internal.UpgradeArticleData([]byte(`{"schema_version":3,"title":"a title"}`))
+		assert.ErrorContains(t, err, "newer than the latest known")
+	})
+
+	t.Run("should fail on a schema_version that is not an integer", func(t *testing.T) {
+		t.Parallel()
+
+		_, _, err := internal.UpgradeArticleData([]byte(`{"schema_version":"1"}`))
+		assert.Error(t, err)
+		_, _, err = internal.UpgradeArticleData([]byte(`{"schema_version":1.5}`))
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,25 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+
+	article_types "github.com/nimaeskandary/go-realworld/pkg/article/types"
+	db_types "github.com/nimaeskandary/go-realworld/pkg/database/types"
+	obs_types "github.com/nimaeskandary/go-realworld/pkg/observability/types"
+)
+
+// errListArticlesUnimplemented is returned by ListArticles, which no route uses yet
+var errListArticlesUnimplemented = errors.New("listing articles is not implemented")
+
+// NewArticleRepository returns the repository for the database's dialect
+func NewArticleRepository(db db_types.RealWorldAppDb, logger obs_types.Logger) (article_types.ArticleRepository, error) {
+	switch db.GetDialect() {
+	case db_types.DialectPostgres:
+		return NewPostgresArticleRepository(db, logger), nil
+	case db_types.DialectSqlite:
+		return NewSqliteArticleRepository(db, logger), nil
+	default:
+		return nil, fmt.Errorf("no article repository for dialect: %v", db.GetDialect())
+	}
+}
@@ -0,0 +1,116 @@
+package internal
+
+import (
+	"html"
+	"strings"
+	"unicode"
+)
+
+// searchConfig is the postgres text search configuration, it decides how words are stemmed and which are too common
+// to index
+const searchConfig = "english"
+
+// highlightStart and highlightEnd mark matches in an excerpt coming from the database. They are control characters
+// rather than html so the excerpt can be escaped before the marks are turned into <mark> elements
+const (
+	highlightStart = "\x02"
+	highlightEnd   = "\x03"
+)
+
+// highlightHtml escapes an excerpt with marked matches, and wraps each match in a <mark> element
+func highlightHtml(excerpt string) string {
+	escaped := html.EscapeString(excerpt)
+	escaped = strings.ReplaceAll(escaped, highlightStart, "<mark>")
+	return strings.ReplaceAll(escaped, highlightEnd, "</mark>")
+}
+
+// searchTerms is the search text split up the way websearch_to_tsquery reads it in postgres, for the backends without
+// it. Each term is a word, or the words of a quoted phrase, lower cased
+type searchTerms struct {
+	include [][]string
+	exclude [][]string
+}
+
+// parseSearchText reads words, "quoted phrases", and -excluded words or phrases. Punctuation separates words, as in
+// postgres
+func parseSearchText(text string) searchTerms {
+	var terms searchTerms
+	add := func(words []string, excluded bool) {
+		if len(words) == 0 {
+			return
+		}
+		if excluded {
+			terms.exclude = append(terms.exclude, words)
+		} else {
+			terms.include = append(terms.include, words)
+		}
+	}
+
+	runes := []rune(text)
+	for i := 0; i < len(runes); {
+		if unicode.IsSpace(runes[i]) {
+			i++
+			continue
+		}
+
+		excluded := false
+		if runes[i] == '-' {
+			excluded = true
+			i++
+		}
+
+		if i < len(runes) && runes[i] == '"' {
+			end := i + 1
+			for end < len(runes) && runes[end] != '"' {
+				end++
+			}
+			add(searchWords(string(runes[i+1:end])), excluded)
+			i = end + 1
+			continue
+		}
+
+		end := i
+		for end < len(runes) && !unicode.IsSpace(runes[end]) && runes[end] != '"' {
+			end++
+		}
+		// a word joined by punctuation, e.g. "e-mail", is matched as a phrase
+		add(searchWords(string(runes[i:end])), excluded)
+		i = end
+	}
+	return terms
+}
+
+// searchWords splits text into lower cased words of letters and digits
+func searchWords(text string) []string {
+	words := strings.FieldsFunc(text, isNotSearchWordRune)
+	for i, word := range words {
+		words[i] = strings.ToLower(word)
+	}
+	return words
+}
+
+func isNotSearchWordRune(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+}
+
+// fts5Query builds the sqlite fts5 query for the terms, it is empty if there is nothing to match. Every term is
+// quoted, so words like AND or NEAR are searched for rather than read as operators
+func (t searchTerms) fts5Query() string {
+	if len(t.include) == 0 {
+		return ""
+	}
+
+	quote := func(words []string) string {
+		return `"` + strings.Join(words, " ") + `"`
+	}
+	included := make([]string, len(t.include))
+	for i, words := range t.include {
+		included[i] = quote(words)
+	}
+
+	query := "(" + strings.Join(included, " AND ") + ")"
+	for _, words := range t.exclude {
+		query += " NOT " + quote(words)
+	}
+	return query
+}
@@ -1,11 +1,236 @@
 package internal
 
-import article_types "github.com/nimaeskandary/go-realworld/pkg/article/types"
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	article_types "github.com/nimaeskandary/go-realworld/pkg/article/types"
+	audit_types "github.com/nimaeskandary/go-realworld/pkg/audit/types"
+	db_types "github.com/nimaeskandary/go-realworld/pkg/database/types"
+	outbox_types "github.com/nimaeskandary/go-realworld/pkg/outbox/types"
+	soft_delete_types "github.com/nimaeskandary/go-realworld/pkg/soft_delete/types"
+	user_types "github.com/nimaeskandary/go-realworld/pkg/user/types"
+
+	"github.com/google/uuid"
+	"github.com/samber/mo"
+)
+
+// maxSearchLimit bounds the results of one search, as each one is ranked and highlighted
+const maxSearchLimit = 100
 
 type articleServiceImpl struct {
-	articleRepo article_types.ArticleRepository
+	articleRepo   article_types.ArticleRepository
+	userService   user_types.UserService
+	softDeleteCfg soft_delete_types.SoftDeleteConfig
+	txManager     db_types.TxManager
+	outbox        outbox_types.Outbox
+	auditLog      audit_types.AuditLog
+}
+
+func NewArticleServiceImpl(
+	articleRepo article_types.ArticleRepository,
+	userService user_types.UserService,
+	softDeleteCfg soft_delete_types.SoftDeleteConfig,
+	txManager db_types.TxManager,
+	outbox outbox_types.Outbox,
+	auditLog audit_types.AuditLog,
+) article_types.ArticleService {
+	return &articleServiceImpl{
+		articleRepo:   articleRepo,
+		userService:   userService,
+		softDeleteCfg: softDeleteCfg,
+		txManager:     txManager,
+		outbox:        outbox,
+		auditLog:      auditLog,
+	}
+}
+
+func (s *articleServiceImpl) ListArticleFeed(ctx context.Context, viewer user_types.User, limit int, offset int) ([]article_types.Article, article_types.DomainError) {
+	// follows are removed when either user blocks the other, but a muted author can still be followed
+	hiddenUserIds, userErr := s.userService.GetHiddenUserIds(ctx, viewer)
+	if userErr != nil {
+		return nil, article_types.AsDomainError(userErr)
+	}
+
+	articles, err := s.articleRepo.ListArticleFeed(ctx, viewer.Id, limit, offset, hiddenUserIds)
+	if err != nil {
+		return nil, article_types.AsDomainError(err)
+	}
+	return articles, nil
+}
+
+func (s *articleServiceImpl) GetArticle(ctx context.Context, id uuid.UUID) (article_types.Article, article_types.DomainError) {
+	fromDb, err := s.articleRepo.GetArticleById(ctx, id)
+	if err != nil {
+		return article_types.Article{}, article_types.AsDomainError(err)
+	}
+	article, ok := fromDb.Get()
+	if !ok {
+		return article_types.Article{}, article_types.NotFoundError{Identifier: id.String()}
+	}
+	return article, nil
+}
+
+func (s *articleServiceImpl) UpsertArticle(ctx context.Context, article article_types.Article) (article_types.Article, article_types.DomainError) {
+	ctx = db_types.CtxWithPrimaryReads(ctx)
+
+	var saved article_types.Article
+	err := s.withAudit(ctx, func(ctx context.Context) (audit_types.AuditChange, error) {
+		existing, err := s.articleRepo.GetArticleById(ctx, article.Id)
+		if err != nil {
+			return audit_types.AuditChange{}, err
+		}
+		saved, err = s.articleRepo.UpsertArticle(ctx, article)
+		if err != nil {
+			return audit_types.AuditChange{}, err
+		}
+		err = s.outbox.Publish(ctx, article_types.ArticleSavedEventType, article_types.ArticleSavedEvent{
+			ArticleId:    saved.Id,
+			AuthorUserId: saved.AuthorUserId,
+			Version:      saved.Version,
+			Created:      existing.IsNone(),
+		})
+		if err != nil {
+			return audit_types.AuditChange{}, err
+		}
+
+		if before, ok := existing.Get(); ok {
+			return articleChange(audit_types.AuditActionUpdate, saved.Id, toAuditedArticle(before), toAuditedArticle(saved)), nil
+		}
+		return articleChange(audit_types.AuditActionCreate, saved.Id, nil, toAuditedArticle(saved)), nil
+	})
+	if err != nil {
+		return article_types.Article{}, article_types.AsDomainError(err)
+	}
+	return saved, nil
+}
+
+func (s *articleServiceImpl) AddArticleTags(ctx context.Context, id uuid.UUID, tags []string) article_types.DomainError {
+	if err := s.validateArticleExists(ctx, id); err != nil {
+		return err
+	}
+
+	err := s.articleRepo.AddArticleTags(ctx, id, tags)
+	if err != nil {
+		return article_types.AsDomainError(err)
+	}
+	return nil
+}
+
+func (s *articleServiceImpl) FavoriteArticle(ctx context.Context, viewer user_types.User, id uuid.UUID) article_types.DomainError {
+	if err := s.validateArticleExists(ctx, id); err != nil {
+		return err
+	}
+
+	err := s.articleRepo.FavoriteArticle(ctx, viewer.Id, id)
+	if err != nil {
+		return article_types.AsDomainError(err)
+	}
+	return nil
+}
+
+func (s *articleServiceImpl) DeleteArticle(ctx context.Context, id uuid.UUID) article_types.DomainError {
+	err := s.withAudit(ctx, func(ctx context.Context) (audit_types.AuditChange, error) {
+		existing, err := s.articleRepo.GetArticleById(ctx, id)
+		if err != nil {
+			return audit_types.AuditChange{}, err
+		}
+		before, ok := existing.Get()
+		if !ok {
+			return audit_types.AuditChange{}, article_types.NotFoundError{Identifier: id.String()}
+		}
+
+		if err := s.articleRepo.DeleteArticle(ctx, id); err != nil {
+			return audit_types.AuditChange{}, err
+		}
+		return articleChange(audit_types.AuditActionDelete, id, toAuditedArticle(before), nil), nil
+	})
+	if err != nil {
+		return article_types.AsDomainError(err)
+	}
+	return nil
+}
+
+func (s *articleServiceImpl) RestoreArticle(ctx context.Context, id uuid.UUID) (article_types.Article, article_types.DomainError) {
+	var restored mo.Option[article_types.Article]
+	err := s.withAudit(ctx, func(ctx context.Context) (audit_types.AuditChange, error) {
+		ok, err := s.articleRepo.RestoreArticle(ctx, id, s.softDeleteCfg.GracePeriodCutoff(time.Now()))
+		if err != nil {
+			return audit_types.AuditChange{}, err
+		}
+		if !ok {
+			return audit_types.AuditChange{}, article_types.NotFoundError{Identifier: id.String()}
+		}
+
+		restored, err = s.articleRepo.GetArticleById(ctx, id)
+		if err != nil {
+			return audit_types.AuditChange{}, err
+		}
+		if article, ok := restored.Get(); ok {
+			return articleChange(audit_types.AuditActionRestore, id, nil, toAuditedArticle(article)), nil
+		}
+		// its fields cannot be read while its author is soft deleted, they are those recorded when it was deleted
+		return articleChange(audit_types.AuditActionRestore, id, nil, struct{}{}), nil
+	})
+	if err != nil {
+		return article_types.Article{}, article_types.AsDomainError(err)
+	}
+
+	// the article is restored but stays hidden while its author is soft deleted
+	article, ok := restored.Get()
+	if !ok {
+		return article_types.Article{}, article_types.NotFoundError{Identifier: id.String()}
+	}
+	return article, nil
+}
+
+func (s *articleServiceImpl) SearchArticles(ctx context.Context, viewer mo.Option[user_types.User], query article_types.ArticleSearchQuery) ([]article_types.ArticleSearchResult, article_types.DomainError) {
+	if strings.TrimSpace(query.Text) == "" {
+		return nil, article_types.InvalidSearchQueryError{Reason: "search text is required"}
+	}
+	if query.Limit < 1 || query.Limit > maxSearchLimit {
+		return nil, article_types.InvalidSearchQueryError{Reason: fmt.Sprintf("limit must be between 1 and %v", maxSearchLimit)}
+	}
+	if query.Offset < 0 {
+		return nil, article_types.InvalidSearchQueryError{Reason: "offset cannot be negative"}
+	}
+
+	if viewer, ok := viewer.Get(); ok {
+		hiddenUserIds, userErr := s.userService.GetHiddenUserIds(ctx, viewer)
+		if userErr != nil {
+			return nil, article_types.AsDomainError(userErr)
+		}
+		query.ExcludedAuthorUserIds = hiddenUserIds
+	}
+
+	results, err := s.articleRepo.SearchArticles(ctx, query)
+	if err != nil {
+		return nil, article_types.AsDomainError(err)
+	}
+	return results, nil
+}
+
+// validateArticleExists returns a NotFoundError unless the article exists and is not soft deleted
+func (s *articleServiceImpl) validateArticleExists(ctx context.Context, id uuid.UUID) article_types.DomainError {
+	existing, err := s.articleRepo.GetArticleById(ctx, id)
+	if err != nil {
+		return article_types.AsDomainError(err)
+	}
+	if existing.IsNone() {
+		return article_types.NotFoundError{Identifier: id.String()}
+	}
+	return nil
 }
 
-func NewArticleServiceImpl(articleRepo article_types.ArticleRepository) article_types.ArticleService {
-	return &articleServiceImpl{articleRepo: articleRepo}
+// withAudit runs write in a transaction, recording the change it returns in the audit log
+func (s *articleServiceImpl) withAudit(ctx context.Context, write func(ctx context.Context) (audit_types.AuditChange, error)) error {
+	return s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		change, err := write(ctx)
+		if err != nil {
+			return err
+		}
+		return s.auditLog.Record(ctx, change)
+	})
 }
@@ -0,0 +1,374 @@
+package internal_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	article_types "github.com/nimaeskandary/go-realworld/pkg/article/types"
+	audit_types "github.com/nimaeskandary/go-realworld/pkg/audit/types"
+	"github.com/nimaeskandary/go-realworld/pkg/auth/context"
+	outbox_types "github.com/nimaeskandary/go-realworld/pkg/outbox/types"
+	"github.com/nimaeskandary/go-realworld/pkg/test_utils/fixtures"
+	"github.com/nimaeskandary/go-realworld/pkg/test_utils/helpers"
+	user_types "github.com/nimaeskandary/go-realworld/pkg/user/types"
+
+	"github.com/google/uuid"
+	"github.com/samber/mo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ArticleServiceImpl(t *testing.T) {
+	t.Parallel()
+
+	f := fixtures.SetupStandardFixture(t)
+
+	entriesOf := func(t *testing.T, f fixtures.StandardFixture, articleId uuid.UUID) []audit_types.AuditEntry {
+		entries, err := f.AuditLog.ListEntries(t.Context(), audit_types.AuditQuery{
+			EntityType: mo.Some(audit_types.AuditEntityArticle),
+			EntityId:   mo.Some(articleId.String()),
+			Limit:      100,
+		})
+		require.NoError(t, err)
+		return entries
+	}
+
+	// pendingEvents returns the events in the outbox waiting to be relayed
+	pendingEvents := func(t *testing.T, f fixtures.StandardFixture) []outbox_types.OutboxEvent {
+		var events []outbox_types.OutboxEvent
+		err := f.TxManager.WithinTx(t.Context(), func(ctx context.Context) error {
+			var err error
+			events, err = f.OutboxRepo.ClaimEvents(ctx, 100, time.Now())
+			return err
+		})
+		require.NoError(t, err)
+		return events
+	}
+
+	t.Run("ListArticleFeed", func(t *testing.T) {
+		t.Parallel()
+
+		t.Run("should leave out articles by authors the viewer has muted", func(t *testing.T) {
+			t.Parallel()
+			users := helpers.CreateUsers(t, f.UserService, 3)
+			viewer, followed, muted := users[0], users[1], users[2]
+			_, err := f.UserService.FollowProfile(t.Context(), viewer, followed.Username)
+			require.NoError(t, err)
+			_, err = f.UserService.FollowProfile(t.Context(), viewer, muted.Username)
+			require.NoError(t, err)
+			_, err = f.UserService.MuteProfile(t.Context(), viewer, muted.Username)
+			require.NoError(t, err)
+
+			article := helpers.GenArticle(followed.Id)
+			for _, a := range []article_types.Article{article, helpers.GenArticle(muted.Id)} {
+				_, err := f.ArticleRepo.UpsertArticle(t.Context(), a)
+				require.NoError(t, err)
+			}
+
+			feed, articleErr := f.ArticleService.ListArticleFeed(t.Context(), viewer, 10, 0)
+			assert.NoError(t, articleErr)
+			assert.Equal(t, []article_types.Article{article}, feed)
+		})
+	})
+
+	t.Run("SearchArticles", func(t *testing.T) {
+		t.Parallel()
+
+		t.Run("should return an InvalidSearchQueryError if there is no search text", func(t *testing.T) {
+			t.Parallel()
+
+			_, err := f.ArticleService.SearchArticles(t.Context(), mo.None[user_types.User](), article_types.ArticleSearchQuery{Text: " ", Limit: 10})
+			assert.IsType(t, article_types.InvalidSearchQueryError{}, err)
+		})
+
+		t.Run("should leave out authors the viewer has blocked or muted", func(t *testing.T) {
+			t.Parallel()
+			users := helpers.CreateUsers(t, f.UserService, 4)
+			viewer, blocked, muted, other := users[0], users[1], users[2], users[3]
+			_, err := f.UserService.BlockProfile(t.Context(), viewer, blocked.Username)
+			require.NoError(t, err)
+			_, err = f.UserService.MuteProfile(t.Context(), viewer, muted.Username)
+			require.NoError(t, err)
+
+			word := helpers.GenSearchWord()
+			for _, author := range []user_types.User{blocked, muted, other} {
+				article := helpers.GenArticle(author.Id)
+				article.Title = "Notes on " + word
+				_, err := f.ArticleRepo.UpsertArticle(t.Context(), article)
+				require.NoError(t, err)
+			}
+			query := article_types.ArticleSearchQuery{Text: word, Limit: 10}
+
+			results, articleErr := f.ArticleService.SearchArticles(t.Context(), mo.Some(viewer), query)
+			assert.NoError(t, articleErr)
+			require.Len(t, results, 1)
+			assert.Equal(t, other.Id, results[0].Article.AuthorUserId)
+
+			results, articleErr = f.ArticleService.SearchArticles(t.Context(), mo.None[user_types.User](), query)
+			assert.NoError(t, articleErr)
+			assert.Len(t, results, 3)
+		})
+	})
+
+	t.Run("GetArticle", func(t *testing.T) {
+		t.Parallel()
+
+		t.Run("should return not found for an article that does not exist", func(t *testing.T) {
+			t.Parallel()
+
+			_, err := f.ArticleService.GetArticle(t.Context(), uuid.New())
+			assert.IsType(t, article_types.NotFoundError{}, err)
+		})
+	})
+
+	t.Run("UpsertArticle", func(t *testing.T) {
+		t.Parallel()
+
+		t.Run("should publish an article.saved event for each save", func(t *testing.T) {
+			t.Parallel()
+			f := fixtures.SetupInMemoryFixture(t)
+			author := helpers.UpsertUsers(t, f.UserRepo, 1)[0]
+
+			created, err := f.ArticleService.UpsertArticle(t.Context(), helpers.GenArticle(author.Id))
+			require.NoError(t, err)
+			update := created
+			update.Title = "Updated Title"
+			updated, err := f.ArticleService.UpsertArticle(t.Context(), update)
+			require.NoError(t, err)
+
+			var published []article_types.ArticleSavedEvent
+			for _, event := range pendingEvents(t, f) {
+				assert.Equal(t, article_types.ArticleSavedEventType, event.Type)
+				var payload article_types.ArticleSavedEvent
+				require.NoError(t, json.Unmarshal(event.Payload, &payload))
+				published = append(published, payload)
+			}
+			// both saves can be made in the same millisecond, so the events are in no particular order
+			assert.ElementsMatch(t, []article_types.ArticleSavedEvent{
+				{ArticleId: created.Id, AuthorUserId: author.Id, Version: created.Version, Created: true},
+				{ArticleId: created.Id, AuthorUserId: author.Id, Version: updated.Version, Created: false},
+			}, published)
+		})
+
+		t.Run("should not publish an event when the save is rolled back", func(t *testing.T) {
+			t.Parallel()
+			f := fixtures.SetupInMemoryFixture(t)
+			author := helpers.UpsertUsers(t, f.UserRepo, 1)[0]
+			article := helpers.GenArticle(author.Id)
+			rollback := errors.New("rollback")
+
+			err := f.TxManager.WithinTx(t.Context(), func(ctx context.Context) error {
+				if _, err := f.ArticleService.UpsertArticle(ctx, article); err != nil {
+					return err
+				}
+				return rollback
+			})
+			require.ErrorIs(t, err, rollback)
+
+			saved, getErr := f.ArticleRepo.GetArticleById(t.Context(), article.Id)
+			require.NoError(t, getErr)
+			assert.True(t, saved.IsNone())
+			assert.Empty(t, pendingEvents(t, f))
+			assert.Empty(t, entriesOf(t, f, article.Id))
+		})
+
+		t.Run("should record a created article, then the fields an update changed, made by the signed in author", func(t *testing.T) {
+			t.Parallel()
+			f := fixtures.SetupInMemoryFixture(t)
+			author := helpers.UpsertUsers(t, f.UserRepo, 1)[0]
+			ctx := auth_context.CtxWithUser(t.Context(), author)
+
+			created, err := f.ArticleService.UpsertArticle(ctx, helpers.GenArticle(author.Id))
+			require.NoError(t, err)
+			update := created
+			update.Title = "Updated Title"
+			updated, err := f.ArticleService.UpsertArticle(ctx, update)
+			require.NoError(t, err)
+			assert.Equal(t, created.Version+1, updated.Version)
+
+			entries := entriesOf(t, f, created.Id)
+			require.Len(t, entries, 2)
+
+			assert.Equal(t, audit_types.AuditActionUpdate, entries[0].Action)
+			assert.Equal(t, mo.Some(author.Id), entries[0].ActorUserId)
+			assert.JSONEq(t, `{"title": "Test Article"}`, string(entries[0].Before))
+			assert.JSONEq(t, `{"title": "Updated Title"}`, string(entries[0].After))
+
+			assert.Equal(t, audit_types.AuditActionCreate, entries[1].Action)
+			assert.Nil(t, entries[1].Before)
+			assert.JSONEq(t, `{
+				"author_user_id": "`+author.Id.String()+`",
+				"title": "Test Article",
+				"description": "This is a test article",
+				"body": "The body of the test article"
+			}`, string(entries[1].After))
+		})
+
+		t.Run("should not record a rejected update", func(t *testing.T) {
+			t.Parallel()
+			f := fixtures.SetupInMemoryFixture(t)
+			author := helpers.UpsertUsers(t, f.UserRepo, 1)[0]
+
+			created, err := f.ArticleService.UpsertArticle(t.Context(), helpers.GenArticle(author.Id))
+			require.NoError(t, err)
+			stale := created
+			stale.Version = created.Version + 1
+			stale.Title = "Updated Title"
+
+			_, err = f.ArticleService.UpsertArticle(t.Context(), stale)
+			assert.ErrorAs(t, err, &article_types.VersionConflictError{})
+
+			entries := entriesOf(t, f, created.Id)
+			require.Len(t, entries, 1)
+			assert.Equal(t, audit_types.AuditActionCreate, entries[0].Action)
+		})
+
+		t.Run("should return a VersionConflictError when updating a stale version", func(t *testing.T) {
+			t.Parallel()
+			author := helpers.CreateUsers(t, f.UserService, 1)[0]
+			article, err := f.ArticleService.UpsertArticle(t.Context(), helpers.GenArticle(author.Id))
+			require.NoError(t, err)
+
+			updated := article
+			updated.Title = "Updated Title"
+			_, err = f.ArticleService.UpsertArticle(t.Context(), updated)
+			require.NoError(t, err)
+
+			_, err = f.ArticleService.UpsertArticle(t.Context(), updated)
+			assert.IsType(t, article_types.VersionConflictError{}, err)
+
+			fromDb, err := f.ArticleService.GetArticle(t.Context(), article.Id)
+			require.NoError(t, err)
+			assert.Equal(t, article.Version+1, fromDb.Version)
+		})
+	})
+
+	t.Run("AddArticleTags", func(t *testing.T) {
+		t.Parallel()
+
+		t.Run("should return not found for an article that is soft deleted", func(t *testing.T) {
+			t.Parallel()
+			user := helpers.CreateUsers(t, f.UserService, 1)[0]
+			article, err := f.ArticleService.UpsertArticle(t.Context(), helpers.GenArticle(user.Id))
+			require.NoError(t, err)
+			require.NoError(t, f.ArticleService.DeleteArticle(t.Context(), article.Id))
+
+			err = f.ArticleService.AddArticleTags(t.Context(), article.Id, []string{"go"})
+			assert.IsType(t, article_types.NotFoundError{}, err)
+		})
+	})
+
+	t.Run("FavoriteArticle", func(t *testing.T) {
+		t.Parallel()
+
+		t.Run("should return not found for an article that does not exist", func(t *testing.T) {
+			t.Parallel()
+			user := helpers.CreateUsers(t, f.UserService, 1)[0]
+
+			err := f.ArticleService.FavoriteArticle(t.Context(), user, uuid.New())
+			assert.IsType(t, article_types.NotFoundError{}, err)
+		})
+	})
+
+	t.Run("DeleteArticle", func(t *testing.T) {
+		t.Parallel()
+
+		t.Run("should soft delete the article so it can be restored", func(t *testing.T) {
+			t.Parallel()
+			author := helpers.CreateUsers(t, f.UserService, 1)[0]
+			article, err := f.ArticleRepo.UpsertArticle(t.Context(), helpers.GenArticle(author.Id))
+			require.NoError(t, err)
+
+			assert.NoError(t, f.ArticleService.DeleteArticle(t.Context(), article.Id))
+			fromDb, err := f.ArticleRepo.GetArticleById(t.Context(), article.Id)
+			assert.NoError(t, err)
+			assert.True(t, fromDb.IsNone())
+
+			restored, articleErr := f.ArticleService.RestoreArticle(t.Context(), article.Id)
+			assert.NoError(t, articleErr)
+			assert.Equal(t, article, restored)
+		})
+
+		t.Run("should record the deleted article", func(t *testing.T) {
+			t.Parallel()
+			f := fixtures.SetupInMemoryFixture(t)
+			author := helpers.UpsertUsers(t, f.UserRepo, 1)[0]
+			created, err := f.ArticleService.UpsertArticle(t.Context(), helpers.GenArticle(author.Id))
+			require.NoError(t, err)
+
+			require.NoError(t, f.ArticleService.DeleteArticle(t.Context(), created.Id))
+
+			entries := entriesOf(t, f, created.Id)
+			require.Len(t, entries, 2)
+			assert.Equal(t, audit_types.AuditActionDelete, entries[0].Action)
+			assert.Contains(t, string(entries[0].Before), `"title":"Test Article"`)
+			assert.Nil(t, entries[0].After)
+		})
+
+		t.Run("should return not found for an article that does not exist", func(t *testing.T) {
+			t.Parallel()
+
+			err := f.ArticleService.DeleteArticle(t.Context(), uuid.New())
+			assert.IsType(t, article_types.NotFoundError{}, err)
+		})
+	})
+
+	t.Run("RestoreArticle", func(t *testing.T) {
+		t.Parallel()
+
+		t.Run("should return not found for an article that is not deleted", func(t *testing.T) {
+			t.Parallel()
+			author := helpers.CreateUsers(t, f.UserService, 1)[0]
+			article, err := f.ArticleRepo.UpsertArticle(t.Context(), helpers.GenArticle(author.Id))
+			require.NoError(t, err)
+
+			_, articleErr := f.ArticleService.RestoreArticle(t.Context(), article.Id)
+			assert.IsType(t, article_types.NotFoundError{}, articleErr)
+		})
+
+		t.Run("should record the restored article", func(t *testing.T) {
+			t.Parallel()
+			f := fixtures.SetupInMemoryFixture(t)
+			author := helpers.UpsertUsers(t, f.UserRepo, 1)[0]
+			created, err := f.ArticleService.UpsertArticle(t.Context(), helpers.GenArticle(author.Id))
+			require.NoError(t, err)
+			require.NoError(t, f.ArticleService.DeleteArticle(t.Context(), created.Id))
+
+			_, err = f.ArticleService.RestoreArticle(t.Context(), created.Id)
+			require.NoError(t, err)
+
+			entries := entriesOf(t, f, created.Id)
+			require.Len(t, entries, 3)
+			assert.Equal(t, audit_types.AuditActionRestore, entries[0].Action)
+			assert.Nil(t, entries[0].Before)
+			assert.Contains(t, string(entries[0].After), `"title":"Test Article"`)
+		})
+
+		t.Run("should record the restore of an article that stays hidden while its author is deleted", func(t *testing.T) {
+			t.Parallel()
+			f := fixtures.SetupInMemoryFixture(t)
+			author := helpers.CreateUsers(t, f.UserService, 1)[0]
+			created, err := f.ArticleService.UpsertArticle(t.Context(), helpers.GenArticle(author.Id))
+			require.NoError(t, err)
+			require.NoError(t, f.ArticleService.DeleteArticle(t.Context(), created.Id))
+			require.NoError(t, f.UserService.DeleteUser(t.Context(), author.Id))
+
+			_, err = f.ArticleService.RestoreArticle(t.Context(), created.Id)
+			assert.IsType(t, article_types.NotFoundError{}, err)
+
+			entries := entriesOf(t, f, created.Id)
+			require.Len(t, entries, 3)
+			assert.Equal(t, audit_types.AuditActionRestore, entries[0].Action)
+			assert.JSONEq(t, `{}`, string(entries[0].After))
+
+			// once its author is restored, the article is visible again without restoring it a second time
+			_, userErr := f.UserService.RestoreUser(t.Context(), author.Id)
+			require.NoError(t, userErr)
+			_, err = f.ArticleService.GetArticle(t.Context(), created.Id)
+			assert.NoError(t, err)
+		})
+	})
+}
@@ -0,0 +1,399 @@
+package internal
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	article_types "github.com/nimaeskandary/go-realworld/pkg/article/types"
+	db_types "github.com/nimaeskandary/go-realworld/pkg/database/types"
+	"github.com/samber/mo"
+
+	"github.com/google/uuid"
+)
+
+// inMemoryArticleRepo is the in memory counterpart of postgresArticleRepo
+type inMemoryArticleRepo struct {
+	db db_types.RealWorldAppInMemoryDb
+}
+
+func NewInMemoryArticleRepository(db db_types.RealWorldAppInMemoryDb) article_types.ArticleRepository {
+	return &inMemoryArticleRepo{db: db}
+}
+
+// UpsertArticle implements [article_types.ArticleRepository.UpsertArticle]
+func (r *inMemoryArticleRepo) UpsertArticle(ctx context.Context, article article_types.Article) (article_types.Article, error) {
+	dataBytes, err := EncodeArticleData(article)
+	if err != nil {
+		return article_types.Article{}, fmt.Errorf("error marshalling article data for upsert, article=%v: %w", article, err)
+	}
+
+	var result db_types.InMemoryArticle
+	err = r.db.Write(ctx, func(tables *db_types.InMemoryTables) error {
+		existing, exists := tables.Articles[article.Id]
+		if !exists {
+			if _, ok := tables.Users[article.AuthorUserId]; !ok {
+				return fmt.Errorf("author does not exist, author_user_id=%v", article.AuthorUserId)
+			}
+			result = db_types.InMemoryArticle{
+				Id:           article.Id,
+				AuthorUserId: article.AuthorUserId,
+				Data:         dataBytes,
+				CreatedAt:    time.UnixMilli(article.CreatedAtMillis),
+				UpdatedAt:    time.UnixMilli(article.UpdatedAtMillis),
+				Version:      1,
+			}
+			tables.Articles[article.Id] = result
+			return nil
+		}
+
+		// only update the version the caller read
+		if existing.Version != article.Version {
+			return article_types.VersionConflictError{Identifier: article.Id.String()}
+		}
+		result = existing
+		result.Data = dataBytes
+		result.UpdatedAt = time.UnixMilli(article.UpdatedAtMillis)
+		result.Version = existing.Version + 1
+		tables.Articles[article.Id] = result
+		return nil
+	})
+	if err != nil {
+		if _, ok := errors.AsType[article_types.VersionConflictError](err); ok {
+			return article_types.Article{}, err
+		}
+		return article_types.Article{}, fmt.Errorf("error with article upsert, article=%v: %w", article, err)
+	}
+
+	return fromInMemoryArticle(result)
+}
+
+// GetArticleById implements [types.ArticleRepository.GetArticleById].
+func (r *inMemoryArticleRepo) GetArticleById(ctx context.Context, id uuid.UUID) (mo.Option[article_types.Article], error) {
+	var found mo.Option[db_types.InMemoryArticle]
+	err := r.db.Read(ctx, func(tables *db_types.InMemoryTables) error {
+		if article, ok := tables.Articles[id]; ok && isActiveInMemoryArticle(tables, article) {
+			found = mo.Some(article)
+		}
+		return nil
+	})
+	if err != nil {
+		return mo.None[article_types.Article](), err
+	}
+
+	article, ok := found.Get()
+	if !ok {
+		return mo.None[article_types.Article](), nil
+	}
+	asArticle, err := fromInMemoryArticle(article)
+	if err != nil {
+		return mo.None[article_types.Article](), err
+	}
+	return mo.Some(asArticle), nil
+}
+
+// ListArticles implements [types.ArticleRepository.ListArticles].
+func (r *inMemoryArticleRepo) ListArticles(ctx context.Context, limit int, offset int, authorUserId mo.Option[uuid.UUID], favoritedByUserId mo.Option[uuid.UUID], tag mo.Option[string]) ([]article_types.Article, error) {
+	return nil, errListArticlesUnimplemented
+}
+
+// ListArticleFeed implements [types.ArticleRepository.ListArticleFeed].
+func (r *inMemoryArticleRepo) ListArticleFeed(ctx context.Context, userId uuid.UUID, limit int, offset int, excludedAuthorUserIds []uuid.UUID) ([]article_types.Article, error) {
+	var page []db_types.InMemoryArticle
+	err := r.db.Read(ctx, func(tables *db_types.InMemoryTables) error {
+		var feed []db_types.InMemoryArticle
+		for _, article := range tables.Articles {
+			following := db_types.InMemoryUserPair{From: userId, To: article.AuthorUserId}
+			if _, ok := tables.UserFollowers[following]; !ok || !isActiveInMemoryArticle(tables, article) {
+				continue
+			}
+			if slices.Contains(excludedAuthorUserIds, article.AuthorUserId) {
+				continue
+			}
+			feed = append(feed, article)
+		}
+
+		// newest first, by id after that, like the postgres order by
+		slices.SortFunc(feed, func(a, b db_types.InMemoryArticle) int {
+			if c := b.CreatedAt.Compare(a.CreatedAt); c != 0 {
+				return c
+			}
+			return strings.Compare(b.Id.String(), a.Id.String())
+		})
+		page = feed[min(offset, len(feed)):min(offset+limit, len(feed))]
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error listing article feed, user_id=%v: %w", userId, err)
+	}
+
+	articles := make([]article_types.Article, len(page))
+	for i, article := range page {
+		articles[i], err = fromInMemoryArticle(article)
+		if err != nil {
+			return nil, fmt.Errorf("error converting in memory article to domain article: %w", err)
+		}
+	}
+	return articles, nil
+}
+
+// SearchArticles implements [types.ArticleRepository.SearchArticles]. Words match by prefix rather than by stem, and the
+// rank counts matches weighted by the field they are in, it is close enough to postgres for the order of results
+func (r *inMemoryArticleRepo) SearchArticles(ctx context.Context, query article_types.ArticleSearchQuery) ([]article_types.ArticleSearchResult, error) {
+	terms := parseSearchText(query.Text)
+	if len(terms.include) == 0 {
+		return []article_types.ArticleSearchResult{}, nil
+	}
+
+	var results []article_types.ArticleSearchResult
+	err := r.db.Read(ctx, func(tables *db_types.InMemoryTables) error {
+		for _, found := range tables.Articles {
+			if !isActiveInMemoryArticle(tables, found) {
+				continue
+			}
+			if authorUserId, ok := query.AuthorUserId.Get(); ok && found.AuthorUserId != authorUserId {
+				continue
+			}
+			if slices.Contains(query.ExcludedAuthorUserIds, found.AuthorUserId) {
+				continue
+			}
+			if tag, ok := query.Tag.Get(); ok {
+				if _, tagged := tables.ArticleTags[db_types.InMemoryArticleTag{ArticleId: found.Id, Tag: tag}]; !tagged {
+					continue
+				}
+			}
+
+			article, err := fromInMemoryArticle(found)
+			if err != nil {
+				return err
+			}
+			result, ok := matchInMemoryArticle(article, terms)
+			if !ok {
+				continue
+			}
+			for articleTag := range tables.ArticleTags {
+				if articleTag.ArticleId == found.Id {
+					result.Tags = append(result.Tags, articleTag.Tag)
+				}
+			}
+			slices.Sort(result.Tags)
+			results = append(results, result)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error with search articles, query=%v: %w", query, err)
+	}
+
+	slices.SortFunc(results, func(a, b article_types.ArticleSearchResult) int {
+		if byRank := cmp.Compare(b.Rank, a.Rank); byRank != 0 {
+			return byRank
+		}
+		if byCreatedAt := cmp.Compare(b.Article.CreatedAtMillis, a.Article.CreatedAtMillis); byCreatedAt != 0 {
+			return byCreatedAt
+		}
+		return cmp.Compare(a.Article.Id.String(), b.Article.Id.String())
+	})
+
+	if query.Offset >= len(results) {
+		return []article_types.ArticleSearchResult{}, nil
+	}
+	results = results[query.Offset:]
+	return results[:min(query.Limit, len(results))], nil
+}
+
+// AddArticleTags implements [types.ArticleRepository.AddArticleTags].
+func (r *inMemoryArticleRepo) AddArticleTags(ctx context.Context, articleId uuid.UUID, tags []string) error {
+	err := r.db.Write(ctx, func(tables *db_types.InMemoryTables) error {
+		if _, ok := tables.Articles[articleId]; !ok {
+			return fmt.Errorf("article does not exist, article_id=%v", articleId)
+		}
+		for _, tag := range tags {
+			key := db_types.InMemoryArticleTag{ArticleId: articleId, Tag: tag}
+			if _, ok := tables.ArticleTags[key]; !ok {
+				tables.ArticleTags[key] = time.Now()
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("error adding article tags, article_id=%v: %w", articleId, err)
+	}
+	return nil
+}
+
+// FavoriteArticle implements [types.ArticleRepository.FavoriteArticle].
+func (r *inMemoryArticleRepo) FavoriteArticle(ctx context.Context, favoritedByUserId uuid.UUID, articleId uuid.UUID) error {
+	err := r.db.Write(ctx, func(tables *db_types.InMemoryTables) error {
+		if _, ok := tables.Articles[articleId]; !ok {
+			return fmt.Errorf("article does not exist, article_id=%v", articleId)
+		}
+		if _, ok := tables.Users[favoritedByUserId]; !ok {
+			return fmt.Errorf("user does not exist, user_id=%v", favoritedByUserId)
+		}
+		key := db_types.InMemoryArticleFavorite{ArticleId: articleId, UserId: favoritedByUserId}
+		if _, ok := tables.ArticleFavorites[key]; !ok {
+			tables.ArticleFavorites[key] = time.Now()
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("error favoriting article, article_id=%v, user_id=%v: %w", articleId, favoritedByUserId, err)
+	}
+	return nil
+}
+
+// DeleteArticle implements [types.ArticleRepository.DeleteArticle].
+func (r *inMemoryArticleRepo) DeleteArticle(ctx context.Context, id uuid.UUID) error {
+	return r.db.Write(ctx, func(tables *db_types.InMemoryTables) error {
+		article, ok := tables.Articles[id]
+		if !ok || article.DeletedAt.IsPresent() {
+			return nil
+		}
+		article.DeletedAt = mo.Some(time.Now())
+		tables.Articles[id] = article
+		return nil
+	})
+}
+
+// RestoreArticle implements [types.ArticleRepository.RestoreArticle].
+func (r *inMemoryArticleRepo) RestoreArticle(ctx context.Context, id uuid.UUID, deletedAfter time.Time) (bool, error) {
+	var restored bool
+	err := r.db.Write(ctx, func(tables *db_types.InMemoryTables) error {
+		article, ok := tables.Articles[id]
+		if !ok {
+			return nil
+		}
+		if deletedAt, ok := article.DeletedAt.Get(); ok && deletedAt.After(deletedAfter) {
+			article.DeletedAt = mo.None[time.Time]()
+			tables.Articles[id] = article
+			restored = true
+		}
+		return nil
+	})
+	return restored, err
+}
+
+// PurgeDeletedArticles implements [types.ArticleRepository.PurgeDeletedArticles].
+func (r *inMemoryArticleRepo) PurgeDeletedArticles(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	var purged int64
+	err := r.db.Write(ctx, func(tables *db_types.InMemoryTables) error {
+		for id, article := range tables.Articles {
+			if deletedAt, ok := article.DeletedAt.Get(); ok && !deletedAt.After(deletedBefore) {
+				tables.DeleteArticle(id)
+				purged++
+			}
+		}
+		return nil
+	})
+	return purged, err
+}
+
+// isActiveInMemoryArticle is isActiveArticle for the in memory tables
+func isActiveInMemoryArticle(tables *db_types.InMemoryTables, article db_types.InMemoryArticle) bool {
+	author, ok := tables.Users[article.AuthorUserId]
+	return article.DeletedAt.IsAbsent() && ok && author.DeletedAt.IsAbsent()
+}
+
+// inMemorySearchWeights weigh a match by the field it is in, in the order title, description, body
+var inMemorySearchWeights = []float64{1.0, 0.4, 0.2}
+
+// matchInMemoryArticle matches the terms against the article, returning the rank and highlight of a match. Every
+// included term has to be in one of the fields, and no excluded term in any of them
+func matchInMemoryArticle(article article_types.Article, terms searchTerms) (article_types.ArticleSearchResult, bool) {
+	texts := []string{article.Title, article.Description, article.Body}
+	fields := make([][]string, len(texts))
+	matched := make([]map[int]bool, len(texts))
+	for i, text := range texts {
+		fields[i] = searchWords(text)
+		matched[i] = map[int]bool{}
+	}
+
+	var rank float64
+	for _, term := range terms.include {
+		found := false
+		for i, words := range fields {
+			for _, start := range findSearchPhrase(words, term) {
+				found = true
+				rank += inMemorySearchWeights[i]
+				for position := start; position < start+len(term); position++ {
+					matched[i][position] = true
+				}
+			}
+		}
+		if !found {
+			return article_types.ArticleSearchResult{}, false
+		}
+	}
+	for _, term := range terms.exclude {
+		for _, words := range fields {
+			if len(findSearchPhrase(words, term)) > 0 {
+				return article_types.ArticleSearchResult{}, false
+			}
+		}
+	}
+
+	highlighted := make([]string, len(texts))
+	for i, text := range texts {
+		highlighted[i] = markSearchWords(text, matched[i])
+	}
+
+	return article_types.ArticleSearchResult{
+		Article:   article,
+		Tags:      []string{},
+		Rank:      rank,
+		Highlight: highlightHtml(strings.Join(highlighted, " ")),
+	}, true
+}
+
+// markSearchWords wraps the words of text at the matched positions, counted the way searchWords splits text, in the
+// highlight marks. The rest of the text is kept as written
+func markSearchWords(text string, matched map[int]bool) string {
+	var marked strings.Builder
+	position, inWord := -1, false
+	for _, r := range text {
+		isWordRune := !isNotSearchWordRune(r)
+		if isWordRune && !inWord {
+			position++
+			if matched[position] {
+				marked.WriteString(highlightStart)
+			}
+		}
+		if !isWordRune && inWord && matched[position] {
+			marked.WriteString(highlightEnd)
+		}
+		inWord = isWordRune
+		marked.WriteRune(r)
+	}
+	if inWord && matched[position] {
+		marked.WriteString(highlightEnd)
+	}
+	return marked.String()
+}
+
+// findSearchPhrase returns where each run of words starting with the words of the phrase, in order, begins
+func findSearchPhrase(words []string, phrase []string) []int {
+	var starts []int
+	for start := 0; start+len(phrase) <= len(words); start++ {
+		if slices.EqualFunc(words[start:start+len(phrase)], phrase, strings.HasPrefix) {
+			starts = append(starts, start)
+		}
+	}
+	return starts
+}
+
+func fromInMemoryArticle(from db_types.InMemoryArticle) (article_types.Article, error) {
+	return fromPostgresArticle(postgresArticle{
+		Id:           from.Id,
+		AuthorUserId: from.AuthorUserId,
+		Data:         from.Data,
+		CreatedAt:    from.CreatedAt,
+		UpdatedAt:    from.UpdatedAt,
+		DeletedAt:    from.DeletedAt,
+		Version:      from.Version,
+	})
+}
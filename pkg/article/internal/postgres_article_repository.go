@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"slices"
 	"time"
 
 	article_types "github.com/nimaeskandary/go-realworld/pkg/article/types"
@@ -15,38 +16,47 @@ import (
 	"github.com/google/uuid"
 	"github.com/stephenafamo/bob"
 	"github.com/stephenafamo/bob/dialect/psql"
+	"github.com/stephenafamo/bob/dialect/psql/dialect"
 	"github.com/stephenafamo/bob/dialect/psql/dm"
 	"github.com/stephenafamo/bob/dialect/psql/im"
 	"github.com/stephenafamo/bob/dialect/psql/sm"
+	"github.com/stephenafamo/bob/dialect/psql/um"
 	"github.com/stephenafamo/scan"
 )
 
 const (
-	articlesTableName = "articles"
+	articlesTableName         = "articles"
+	articleTagsTableName      = "article_tags"
+	articleFavoritesTableName = "article_favorites"
+	usersTableName            = "users"
+	userFollowersTableName    = "user_followers"
 )
 
+// articleColumns are the columns of postgresArticle. The table has others, such as the search vector, that are never
+// read back
+var articleColumns = []any{"id", "author_user_id", "data", "created_at", "updated_at", "deleted_at", "version"}
+
+// headlineOptions configures the ts_headline excerpts of search results, a couple of fragments around the matches
+var headlineOptions = fmt.Sprintf(`StartSel="%v", StopSel="%v", MaxFragments=2, MinWords=10, MaxWords=30, FragmentDelimiter=" … "`,
+	highlightStart, highlightEnd)
+
 type postgresArticleRepo struct {
-	db     db_types.PostgresRealWorldAppDb
+	db     db_types.RealWorldAppDb
 	logger obs_types.Logger
 }
 
-func NewPostgresArticleRepository(db db_types.PostgresRealWorldAppDb, logger obs_types.Logger) article_types.ArticleRepository {
+func NewPostgresArticleRepository(db db_types.RealWorldAppDb, logger obs_types.Logger) article_types.ArticleRepository {
 	return &postgresArticleRepo{db: db, logger: logger}
 }
 
 // UpsertArticle implements [article_types.ArticleRepository.UpsertArticle]
 func (r *postgresArticleRepo) UpsertArticle(ctx context.Context, article article_types.Article) (article_types.Article, error) {
-	data := map[string]any{
-		"title":       article.Title,
-		"description": article.Description,
-	}
-
-	dataBytes, err := json.Marshal(data)
+	dataBytes, err := EncodeArticleData(article)
 	if err != nil {
 		return article_types.Article{}, fmt.Errorf("error marshalling article data for upsert, article=%v: %w", article, err)
 	}
 
-	createCols := []string{"id", "author_user_id", "data", "created_at", "updated_at"}
+	createCols := []string{"id", "author_user_id", "data", "created_at", "updated_at", "version"}
 	updateCols := []string{"data", "updated_at"}
 
 	q := psql.Insert(
@@ -57,15 +67,22 @@ func (r *postgresArticleRepo) UpsertArticle(ctx context.Context, article article
 			psql.Arg(dataBytes),
 			psql.Arg(time.UnixMilli(article.CreatedAtMillis)),
 			psql.Arg(time.UnixMilli(article.UpdatedAtMillis)),
+			psql.Arg(1),
 		),
 		im.OnConflict("id").DoUpdate(
 			im.SetExcluded(updateCols...),
+			im.SetCol("version").To(psql.Quote(articlesTableName, "version").Plus(psql.Arg(1))),
+			// only update the version the caller read, a mismatch updates no rows
+			im.Where(psql.Quote(articlesTableName, "version").EQ(psql.Arg(article.Version))),
 		),
-		im.Returning("*"),
+		im.Returning(articleColumns...),
 	)
 
-	result, err := bob.One(ctx, bob.NewDB(r.db.GetDB()), q, scan.StructMapper[postgresArticle]())
+	result, err := bob.One(ctx, db_types.ExecutorFromCtx(ctx, r.db), q, scan.StructMapper[postgresArticle]())
 	if err != nil {
+		if err == sql.ErrNoRows {
+			return article_types.Article{}, article_types.VersionConflictError{Identifier: article.Id.String()}
+		}
 		return article_types.Article{}, fmt.Errorf("error with article upsert query, article=%v: %w", article, err)
 	}
 
@@ -80,12 +97,13 @@ func (r *postgresArticleRepo) UpsertArticle(ctx context.Context, article article
 // GetArticleById implements [types.ArticleRepository.GetArticleById].
 func (r *postgresArticleRepo) GetArticleById(ctx context.Context, id uuid.UUID) (mo.Option[article_types.Article], error) {
 	q := psql.Select(
-		sm.Columns("*"),
+		sm.Columns(articleColumns...),
 		sm.From(articlesTableName),
 		sm.Where(psql.Quote("id").EQ(psql.Arg(id.String()))),
+		sm.Where(isActiveArticle()),
 	)
 
-	result, err := bob.One(ctx, bob.NewDB(r.db.GetDB()), q, scan.StructMapper[postgresArticle]())
+	result, err := bob.One(ctx, db_types.ReaderFromCtx(ctx, r.db), q, scan.StructMapper[postgresArticle]())
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return mo.None[article_types.Article](), nil
@@ -103,22 +121,177 @@ func (r *postgresArticleRepo) GetArticleById(ctx context.Context, id uuid.UUID)
 
 // ListArticles implements [types.ArticleRepository.ListArticles].
 func (r *postgresArticleRepo) ListArticles(ctx context.Context, limit int, offset int, authorUserId mo.Option[uuid.UUID], favoritedByUserId mo.Option[uuid.UUID], tag mo.Option[string]) ([]article_types.Article, error) {
-	panic("unimplemented")
+	return nil, errListArticlesUnimplemented
 }
 
 // ListArticleFeed implements [types.ArticleRepository.ListArticleFeed].
-func (r *postgresArticleRepo) ListArticleFeed(ctx context.Context, userId uuid.UUID, limit int, offset int) ([]article_types.Article, error) {
-	panic("unimplemented")
+func (r *postgresArticleRepo) ListArticleFeed(ctx context.Context, userId uuid.UUID, limit int, offset int, excludedAuthorUserIds []uuid.UUID) ([]article_types.Article, error) {
+	where := []bob.Expression{
+		psql.Quote(userFollowersTableName, "followed_by_user_id").EQ(psql.Arg(userId)),
+		isActiveArticle(),
+	}
+	if len(excludedAuthorUserIds) > 0 {
+		excluded := make([]bob.Expression, len(excludedAuthorUserIds))
+		for i, id := range excludedAuthorUserIds {
+			excluded[i] = psql.Arg(id.String())
+		}
+		where = append(where, psql.Quote(articlesTableName, "author_user_id").NotIn(excluded...))
+	}
+
+	q := psql.Select(
+		sm.Columns(articlesTableName+".*"),
+		sm.From(articlesTableName),
+		sm.InnerJoin(userFollowersTableName).OnEQ(
+			psql.Quote(userFollowersTableName, "following_user_id"),
+			psql.Quote(articlesTableName, "author_user_id"),
+		),
+		sm.Where(psql.And(where...)),
+		sm.OrderBy(psql.Quote(articlesTableName, "created_at")).Desc(),
+		sm.OrderBy(psql.Quote(articlesTableName, "id")).Desc(),
+		sm.Limit(limit),
+		sm.Offset(offset),
+	)
+
+	results, err := bob.All(ctx, db_types.ReaderFromCtx(ctx, r.db), q, scan.StructMapper[postgresArticle]())
+	if err != nil {
+		return nil, fmt.Errorf("error with list article feed query, user_id=%v: %w", userId, err)
+	}
+
+	articles := make([]article_types.Article, len(results))
+	for i, result := range results {
+		articles[i], err = fromPostgresArticle(result)
+		if err != nil {
+			return nil, fmt.Errorf("error converting postgres article to domain article: %w", err)
+		}
+	}
+
+	return articles, nil
+}
+
+// SearchArticles implements [types.ArticleRepository.SearchArticles]. The matches are ranked and paged before their
+// excerpts are built, as ts_headline reads through the whole article
+func (r *postgresArticleRepo) SearchArticles(ctx context.Context, query article_types.ArticleSearchQuery) ([]article_types.ArticleSearchResult, error) {
+	matchesMods := []bob.Mod[*dialect.SelectQuery]{
+		sm.Columns(
+			psql.Raw("articles.*"),
+			psql.Raw("ts_rank(articles.search_vector, search_query)").As("rank"),
+			psql.Quote("search_query"),
+		),
+		sm.From(articlesTableName),
+		sm.CrossJoin(psql.Raw("websearch_to_tsquery(?::regconfig, ?)", searchConfig, query.Text)).As("search_query"),
+		sm.Where(psql.Raw("articles.search_vector @@ search_query")),
+		sm.Where(isActiveArticle()),
+		sm.OrderBy("rank").Desc(),
+		sm.OrderBy(psql.Quote(articlesTableName, "created_at")).Desc(),
+		// a stable order for paging through equal matches
+		sm.OrderBy(psql.Quote(articlesTableName, "id")),
+		sm.Limit(query.Limit),
+		sm.Offset(query.Offset),
+	}
+	if authorUserId, ok := query.AuthorUserId.Get(); ok {
+		matchesMods = append(matchesMods, sm.Where(psql.Quote(articlesTableName, "author_user_id").EQ(psql.Arg(authorUserId.String()))))
+	}
+	if tag, ok := query.Tag.Get(); ok {
+		matchesMods = append(matchesMods, sm.Where(psql.Raw("EXISTS ?", psql.Select(
+			sm.Columns("tag"),
+			sm.From(articleTagsTableName),
+			sm.Where(psql.Quote(articleTagsTableName, "article_id").EQ(psql.Quote(articlesTableName, "id"))),
+			sm.Where(psql.Quote(articleTagsTableName, "tag").EQ(psql.Arg(tag))),
+		))))
+	}
+	if len(query.ExcludedAuthorUserIds) > 0 {
+		excluded := make([]bob.Expression, len(query.ExcludedAuthorUserIds))
+		for i, id := range query.ExcludedAuthorUserIds {
+			excluded[i] = psql.Arg(id.String())
+		}
+		matchesMods = append(matchesMods, sm.Where(psql.Quote(articlesTableName, "author_user_id").NotIn(excluded...)))
+	}
+
+	tags := psql.F("COALESCE",
+		psql.Group(psql.Select(
+			sm.Columns(psql.Raw("json_agg(tag ORDER BY tag)")),
+			sm.From(articleTagsTableName),
+			sm.Where(psql.Quote(articleTagsTableName, "article_id").EQ(psql.Quote("matches", "id"))),
+		)),
+		psql.Raw("'[]'::json"),
+	)
+
+	// the columns of the article are the articles columns the matches were selected with
+	q := psql.Select(
+		sm.Columns(slices.Concat(articleColumns, []any{
+			"rank",
+			psql.Raw("ts_headline(?::regconfig, concat_ws(' ', data->>'title', data->>'description', data->>'body'), search_query, ?)",
+				searchConfig, headlineOptions).As("highlight"),
+			psql.Group(tags).As("tags"),
+		})...),
+		sm.From(psql.Select(matchesMods...)).As("matches"),
+		sm.OrderBy("rank").Desc(),
+		sm.OrderBy("created_at").Desc(),
+		sm.OrderBy("id"),
+	)
+
+	results, err := bob.All(ctx, db_types.ReaderFromCtx(ctx, r.db), q, scan.StructMapper[postgresArticleSearchResult]())
+	if err != nil {
+		return nil, fmt.Errorf("error with search articles query, query=%v: %w", query, err)
+	}
+
+	searchResults := make([]article_types.ArticleSearchResult, len(results))
+	for i, result := range results {
+		searchResults[i], err = fromPostgresArticleSearchResult(result)
+		if err != nil {
+			return nil, fmt.Errorf("error converting postgres article search result: %w", err)
+		}
+	}
+	return searchResults, nil
+}
+
+// AddArticleTags implements [types.ArticleRepository.AddArticleTags].
+func (r *postgresArticleRepo) AddArticleTags(ctx context.Context, articleId uuid.UUID, tags []string) error {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	mods := []bob.Mod[*dialect.InsertQuery]{im.Into(articleTagsTableName, "article_id", "tag")}
+	for _, tag := range tags {
+		mods = append(mods, im.Values(psql.Arg(articleId.String()), psql.Arg(tag)))
+	}
+	mods = append(mods, im.OnConflict("article_id", "tag").DoNothing())
+	q := psql.Insert(mods...)
+
+	_, err := bob.Exec(ctx, db_types.ExecutorFromCtx(ctx, r.db), q)
+	if err != nil {
+		return fmt.Errorf("error with add article tags query, article_id=%v: %w", articleId, err)
+	}
+
+	return nil
+}
+
+// FavoriteArticle implements [types.ArticleRepository.FavoriteArticle].
+func (r *postgresArticleRepo) FavoriteArticle(ctx context.Context, favoritedByUserId uuid.UUID, articleId uuid.UUID) error {
+	q := psql.Insert(
+		im.Into(articleFavoritesTableName, "article_id", "user_id"),
+		im.Values(psql.Arg(articleId.String()), psql.Arg(favoritedByUserId.String())),
+		im.OnConflict("article_id", "user_id").DoNothing(),
+	)
+
+	_, err := bob.Exec(ctx, db_types.ExecutorFromCtx(ctx, r.db), q)
+	if err != nil {
+		return fmt.Errorf("error with favorite article query, article_id=%v, user_id=%v: %w", articleId, favoritedByUserId, err)
+	}
+
+	return nil
 }
 
 // DeleteArticle implements [types.ArticleRepository.DeleteArticle].
 func (r *postgresArticleRepo) DeleteArticle(ctx context.Context, id uuid.UUID) error {
-	q := psql.Delete(
-		dm.From(articlesTableName),
-		dm.Where(psql.Quote("id").EQ(psql.Arg(id.String()))),
+	q := psql.Update(
+		um.Table(articlesTableName),
+		um.SetCol("deleted_at").ToArg(time.Now()),
+		um.Where(psql.Quote("id").EQ(psql.Arg(id.String()))),
+		um.Where(psql.Quote("deleted_at").IsNull()),
 	)
 
-	_, err := bob.Exec(ctx, bob.NewDB(r.db.GetDB()), q)
+	_, err := bob.Exec(ctx, db_types.ExecutorFromCtx(ctx, r.db), q)
 	if err != nil {
 		return fmt.Errorf("error with delete article query, id=%v: %w", id, err)
 	}
@@ -126,25 +299,78 @@ func (r *postgresArticleRepo) DeleteArticle(ctx context.Context, id uuid.UUID) e
 	return nil
 }
 
-type postgresArticle struct {
-	Id           uuid.UUID `db:"id"`
-	AuthorUserId uuid.UUID `db:"author_user_id"`
-	Data         []byte    `db:"data"`
-	CreatedAt    time.Time `db:"created_at"`
-	UpdatedAt    time.Time `db:"updated_at"`
+// RestoreArticle implements [types.ArticleRepository.RestoreArticle].
+func (r *postgresArticleRepo) RestoreArticle(ctx context.Context, id uuid.UUID, deletedAfter time.Time) (bool, error) {
+	q := psql.Update(
+		um.Table(articlesTableName),
+		um.SetCol("deleted_at").To(psql.Raw("NULL")),
+		um.Where(psql.Quote("id").EQ(psql.Arg(id.String()))),
+		um.Where(psql.Quote("deleted_at").GT(psql.Arg(deletedAfter))),
+	)
+
+	result, err := bob.Exec(ctx, db_types.ExecutorFromCtx(ctx, r.db), q)
+	if err != nil {
+		return false, fmt.Errorf("error with restore article query, id=%v: %w", id, err)
+	}
+
+	restored, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("error reading restored articles count: %w", err)
+	}
+
+	return restored > 0, nil
 }
 
-// fromPostgresArticle converts postgres article into an article_types.Article
-func fromPostgresArticle(from postgresArticle) (article_types.Article, error) {
-	var data struct {
-		Title       string `json:"title"`
-		Description string `json:"description"`
+// PurgeDeletedArticles implements [types.ArticleRepository.PurgeDeletedArticles].
+func (r *postgresArticleRepo) PurgeDeletedArticles(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	q := psql.Delete(
+		dm.From(articlesTableName),
+		dm.Where(psql.Quote("deleted_at").LTE(psql.Arg(deletedBefore))),
+	)
+
+	result, err := bob.Exec(ctx, db_types.ExecutorFromCtx(ctx, r.db), q)
+	if err != nil {
+		return 0, fmt.Errorf("error with purge deleted articles query, deleted_before=%v: %w", deletedBefore, err)
 	}
 
-	if len(from.Data) > 0 {
-		if err := json.Unmarshal(from.Data, &data); err != nil {
-			return article_types.Article{}, fmt.Errorf("error unmarshalling article data json: %w", err)
-		}
+	purged, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("error reading purged articles count: %w", err)
+	}
+
+	return purged, nil
+}
+
+// isActiveArticle matches articles that are not soft deleted, and whose author is not soft deleted
+func isActiveArticle() bob.Expression {
+	return psql.And(
+		psql.Quote(articlesTableName, "deleted_at").IsNull(),
+		psql.F("EXISTS", psql.Select(
+			sm.Columns("id"),
+			sm.From(usersTableName),
+			sm.Where(psql.Quote(usersTableName, "id").EQ(psql.Quote(articlesTableName, "author_user_id"))),
+			sm.Where(psql.Quote(usersTableName, "deleted_at").IsNull()),
+		)),
+	)
+}
+
+// postgresArticle is the articles row, the sqlite repository scans into it too
+type postgresArticle struct {
+	Id           uuid.UUID            `db:"id"`
+	AuthorUserId uuid.UUID            `db:"author_user_id"`
+	Data         []byte               `db:"data"`
+	CreatedAt    time.Time            `db:"created_at"`
+	UpdatedAt    time.Time            `db:"updated_at"`
+	DeletedAt    mo.Option[time.Time] `db:"deleted_at"`
+	Version      int64                `db:"version"`
+}
+
+// fromPostgresArticle converts postgres article into an article_types.Article, upgrading data from an older schema
+// version if needed
+func fromPostgresArticle(from postgresArticle) (article_types.Article, error) {
+	data, err := decodeArticleData(from.Data)
+	if err != nil {
+		return article_types.Article{}, err
 	}
 
 	return article_types.Article{
@@ -152,7 +378,51 @@ func fromPostgresArticle(from postgresArticle) (article_types.Article, error) {
 		AuthorUserId:    from.AuthorUserId,
 		Title:           data.Title,
 		Description:     data.Description,
+		Body:            data.Body,
 		CreatedAtMillis: from.CreatedAt.UnixMilli(),
 		UpdatedAtMillis: from.UpdatedAt.UnixMilli(),
+		Version:         from.Version,
+	}, nil
+}
+
+// postgresArticleSearchResult is a search match, the sqlite repository scans into it too. It repeats the fields of
+// postgresArticle, scan does not map the fields of unexported embedded structs
+type postgresArticleSearchResult struct {
+	Id           uuid.UUID            `db:"id"`
+	AuthorUserId uuid.UUID            `db:"author_user_id"`
+	Data         []byte               `db:"data"`
+	CreatedAt    time.Time            `db:"created_at"`
+	UpdatedAt    time.Time            `db:"updated_at"`
+	DeletedAt    mo.Option[time.Time] `db:"deleted_at"`
+	Version      int64                `db:"version"`
+	Rank         float64              `db:"rank"`
+	Highlight    string               `db:"highlight"`
+	Tags         []byte               `db:"tags"`
+}
+
+func fromPostgresArticleSearchResult(from postgresArticleSearchResult) (article_types.ArticleSearchResult, error) {
+	article, err := fromPostgresArticle(postgresArticle{
+		Id:           from.Id,
+		AuthorUserId: from.AuthorUserId,
+		Data:         from.Data,
+		CreatedAt:    from.CreatedAt,
+		UpdatedAt:    from.UpdatedAt,
+		DeletedAt:    from.DeletedAt,
+		Version:      from.Version,
+	})
+	if err != nil {
+		return article_types.ArticleSearchResult{}, err
+	}
+
+	var tags []string
+	if err := json.Unmarshal(from.Tags, &tags); err != nil {
+		return article_types.ArticleSearchResult{}, fmt.Errorf("error unmarshalling article tags, article_id=%v: %w", from.Id, err)
+	}
+
+	return article_types.ArticleSearchResult{
+		Article:   article,
+		Tags:      tags,
+		Rank:      from.Rank,
+		Highlight: highlightHtml(from.Highlight),
 	}, nil
 }
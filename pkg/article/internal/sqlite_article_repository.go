@@ -0,0 +1,336 @@
+package internal
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	article_types "github.com/nimaeskandary/go-realworld/pkg/article/types"
+	db_types "github.com/nimaeskandary/go-realworld/pkg/database/types"
+	obs_types "github.com/nimaeskandary/go-realworld/pkg/observability/types"
+	"github.com/samber/mo"
+
+	"github.com/google/uuid"
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/bob/dialect/sqlite"
+	"github.com/stephenafamo/bob/dialect/sqlite/dialect"
+	"github.com/stephenafamo/bob/dialect/sqlite/dm"
+	"github.com/stephenafamo/bob/dialect/sqlite/im"
+	"github.com/stephenafamo/bob/dialect/sqlite/sm"
+	"github.com/stephenafamo/bob/dialect/sqlite/um"
+	"github.com/stephenafamo/scan"
+)
+
+// sqliteArticleRepo is the sqlite counterpart of postgresArticleRepo, the data column holds json text instead of
+// jsonb. Times are written in UTC, sqlite stores them as text so they only compare correctly in the same offset
+type sqliteArticleRepo struct {
+	db     db_types.RealWorldAppDb
+	logger obs_types.Logger
+}
+
+func NewSqliteArticleRepository(db db_types.RealWorldAppDb, logger obs_types.Logger) article_types.ArticleRepository {
+	return &sqliteArticleRepo{db: db, logger: logger}
+}
+
+// UpsertArticle implements [article_types.ArticleRepository.UpsertArticle]
+func (r *sqliteArticleRepo) UpsertArticle(ctx context.Context, article article_types.Article) (article_types.Article, error) {
+	dataBytes, err := EncodeArticleData(article)
+	if err != nil {
+		return article_types.Article{}, fmt.Errorf("error marshalling article data for upsert, article=%v: %w", article, err)
+	}
+
+	createCols := []string{"id", "author_user_id", "data", "created_at", "updated_at", "version"}
+	updateCols := []string{"data", "updated_at"}
+
+	q := sqlite.Insert(
+		im.Into(articlesTableName, createCols...),
+		im.Values(
+			sqlite.Arg(article.Id.String()),
+			sqlite.Arg(article.AuthorUserId.String()),
+			sqlite.Arg(string(dataBytes)),
+			sqlite.Arg(time.UnixMilli(article.CreatedAtMillis).UTC()),
+			sqlite.Arg(time.UnixMilli(article.UpdatedAtMillis).UTC()),
+			sqlite.Arg(1),
+		),
+		im.OnConflict("id").DoUpdate(
+			im.SetExcluded(updateCols...),
+			im.SetCol("version").To(sqlite.Quote(articlesTableName, "version").Plus(sqlite.Arg(1))),
+			// only update the version the caller read, a mismatch updates no rows
+			im.Where(sqlite.Quote(articlesTableName, "version").EQ(sqlite.Arg(article.Version))),
+		),
+		im.Returning("*"),
+	)
+
+	result, err := bob.One(ctx, db_types.ExecutorFromCtx(ctx, r.db), q, scan.StructMapper[postgresArticle]())
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return article_types.Article{}, article_types.VersionConflictError{Identifier: article.Id.String()}
+		}
+		return article_types.Article{}, fmt.Errorf("error with article upsert query, article=%v: %w", article, err)
+	}
+
+	asArticle, err := fromPostgresArticle(result)
+	if err != nil {
+		return article_types.Article{}, fmt.Errorf("error converting sqlite article to domain article: %w", err)
+	}
+
+	return asArticle, nil
+}
+
+// GetArticleById implements [types.ArticleRepository.GetArticleById].
+func (r *sqliteArticleRepo) GetArticleById(ctx context.Context, id uuid.UUID) (mo.Option[article_types.Article], error) {
+	q := sqlite.Select(
+		sm.Columns("*"),
+		sm.From(articlesTableName),
+		sm.Where(sqlite.Quote("id").EQ(sqlite.Arg(id.String()))),
+		sm.Where(isActiveSqliteArticle()),
+	)
+
+	result, err := bob.One(ctx, db_types.ReaderFromCtx(ctx, r.db), q, scan.StructMapper[postgresArticle]())
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return mo.None[article_types.Article](), nil
+		}
+		return mo.None[article_types.Article](), fmt.Errorf("error with get article by id query, id=%v: %w", id, err)
+	}
+
+	asArticle, err := fromPostgresArticle(result)
+	if err != nil {
+		return mo.None[article_types.Article](), fmt.Errorf("error converting sqlite article to domain article: %w", err)
+	}
+
+	return mo.Some(asArticle), nil
+}
+
+// ListArticles implements [types.ArticleRepository.ListArticles].
+func (r *sqliteArticleRepo) ListArticles(ctx context.Context, limit int, offset int, authorUserId mo.Option[uuid.UUID], favoritedByUserId mo.Option[uuid.UUID], tag mo.Option[string]) ([]article_types.Article, error) {
+	return nil, errListArticlesUnimplemented
+}
+
+// ListArticleFeed implements [types.ArticleRepository.ListArticleFeed].
+func (r *sqliteArticleRepo) ListArticleFeed(ctx context.Context, userId uuid.UUID, limit int, offset int, excludedAuthorUserIds []uuid.UUID) ([]article_types.Article, error) {
+	where := []bob.Expression{
+		sqlite.Quote(userFollowersTableName, "followed_by_user_id").EQ(sqlite.Arg(userId.String())),
+		isActiveSqliteArticle(),
+	}
+	if len(excludedAuthorUserIds) > 0 {
+		excluded := make([]bob.Expression, len(excludedAuthorUserIds))
+		for i, id := range excludedAuthorUserIds {
+			excluded[i] = sqlite.Arg(id.String())
+		}
+		where = append(where, sqlite.Quote(articlesTableName, "author_user_id").NotIn(excluded...))
+	}
+
+	q := sqlite.Select(
+		sm.Columns(articlesTableName+".*"),
+		sm.From(articlesTableName),
+		sm.InnerJoin(userFollowersTableName).OnEQ(
+			sqlite.Quote(userFollowersTableName, "following_user_id"),
+			sqlite.Quote(articlesTableName, "author_user_id"),
+		),
+		sm.Where(sqlite.And(where...)),
+		sm.OrderBy(sqlite.Quote(articlesTableName, "created_at")).Desc(),
+		sm.OrderBy(sqlite.Quote(articlesTableName, "id")).Desc(),
+		sm.Limit(limit),
+		sm.Offset(offset),
+	)
+
+	results, err := bob.All(ctx, db_types.ReaderFromCtx(ctx, r.db), q, scan.StructMapper[postgresArticle]())
+	if err != nil {
+		return nil, fmt.Errorf("error with list article feed query, user_id=%v: %w", userId, err)
+	}
+
+	articles := make([]article_types.Article, len(results))
+	for i, result := range results {
+		articles[i], err = fromPostgresArticle(result)
+		if err != nil {
+			return nil, fmt.Errorf("error converting sqlite article to domain article: %w", err)
+		}
+	}
+
+	return articles, nil
+}
+
+// SearchArticles implements [types.ArticleRepository.SearchArticles]. The search text is translated to an fts5 query
+// over articles_fts, which triggers keep in step with the articles table. bm25 scores are lower for better matches,
+// so they are negated to rank like postgres
+func (r *sqliteArticleRepo) SearchArticles(ctx context.Context, query article_types.ArticleSearchQuery) ([]article_types.ArticleSearchResult, error) {
+	matchQuery := parseSearchText(query.Text).fts5Query()
+	if matchQuery == "" {
+		return []article_types.ArticleSearchResult{}, nil
+	}
+
+	columns := make([]any, 0, len(articleColumns)+3)
+	for _, column := range articleColumns {
+		columns = append(columns, sqlite.Quote(articlesTableName, column.(string)))
+	}
+	columns = append(columns,
+		// the weights are of the article_id, title, description and body columns
+		sqlite.Raw("-bm25(articles_fts, 0.0, 10.0, 5.0, 1.0)").As("rank"),
+		sqlite.Raw("snippet(articles_fts, -1, ?, ?, ' … ', 24)", highlightStart, highlightEnd).As("highlight"),
+		sqlite.Group(sqlite.F("COALESCE",
+			sqlite.Group(sqlite.Select(
+				sm.Columns(sqlite.Raw("json_group_array(tag ORDER BY tag)")),
+				sm.From(articleTagsTableName),
+				sm.Where(sqlite.Quote(articleTagsTableName, "article_id").EQ(sqlite.Quote(articlesTableName, "id"))),
+			)),
+			sqlite.Raw("'[]'"),
+		)).As("tags"),
+	)
+
+	mods := []bob.Mod[*dialect.SelectQuery]{
+		sm.Columns(columns...),
+		sm.From("articles_fts"),
+		sm.InnerJoin(articlesTableName).On(sqlite.Quote(articlesTableName, "id").EQ(sqlite.Quote("articles_fts", "article_id"))),
+		sm.Where(sqlite.Raw("articles_fts MATCH ?", matchQuery)),
+		sm.Where(isActiveSqliteArticle()),
+		sm.OrderBy("rank").Desc(),
+		sm.OrderBy(sqlite.Quote(articlesTableName, "created_at")).Desc(),
+		// a stable order for paging through equal matches
+		sm.OrderBy(sqlite.Quote(articlesTableName, "id")),
+		sm.Limit(query.Limit),
+		sm.Offset(query.Offset),
+	}
+	if authorUserId, ok := query.AuthorUserId.Get(); ok {
+		mods = append(mods, sm.Where(sqlite.Quote(articlesTableName, "author_user_id").EQ(sqlite.Arg(authorUserId.String()))))
+	}
+	if tag, ok := query.Tag.Get(); ok {
+		mods = append(mods, sm.Where(sqlite.Raw("EXISTS ?", sqlite.Select(
+			sm.Columns("tag"),
+			sm.From(articleTagsTableName),
+			sm.Where(sqlite.Quote(articleTagsTableName, "article_id").EQ(sqlite.Quote(articlesTableName, "id"))),
+			sm.Where(sqlite.Quote(articleTagsTableName, "tag").EQ(sqlite.Arg(tag))),
+		))))
+	}
+	if len(query.ExcludedAuthorUserIds) > 0 {
+		excluded := make([]bob.Expression, len(query.ExcludedAuthorUserIds))
+		for i, id := range query.ExcludedAuthorUserIds {
+			excluded[i] = sqlite.Arg(id.String())
+		}
+		mods = append(mods, sm.Where(sqlite.Quote(articlesTableName, "author_user_id").NotIn(excluded...)))
+	}
+
+	results, err := bob.All(ctx, db_types.ReaderFromCtx(ctx, r.db), sqlite.Select(mods...), scan.StructMapper[postgresArticleSearchResult]())
+	if err != nil {
+		return nil, fmt.Errorf("error with search articles query, query=%v: %w", query, err)
+	}
+
+	searchResults := make([]article_types.ArticleSearchResult, len(results))
+	for i, result := range results {
+		searchResults[i], err = fromPostgresArticleSearchResult(result)
+		if err != nil {
+			return nil, fmt.Errorf("error converting sqlite article search result: %w", err)
+		}
+	}
+	return searchResults, nil
+}
+
+// AddArticleTags implements [types.ArticleRepository.AddArticleTags].
+func (r *sqliteArticleRepo) AddArticleTags(ctx context.Context, articleId uuid.UUID, tags []string) error {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	mods := []bob.Mod[*dialect.InsertQuery]{im.Into(articleTagsTableName, "article_id", "tag")}
+	for _, tag := range tags {
+		mods = append(mods, im.Values(sqlite.Arg(articleId.String()), sqlite.Arg(tag)))
+	}
+	mods = append(mods, im.OnConflict("article_id", "tag").DoNothing())
+	q := sqlite.Insert(mods...)
+
+	_, err := bob.Exec(ctx, db_types.ExecutorFromCtx(ctx, r.db), q)
+	if err != nil {
+		return fmt.Errorf("error with add article tags query, article_id=%v: %w", articleId, err)
+	}
+
+	return nil
+}
+
+// FavoriteArticle implements [types.ArticleRepository.FavoriteArticle].
+func (r *sqliteArticleRepo) FavoriteArticle(ctx context.Context, favoritedByUserId uuid.UUID, articleId uuid.UUID) error {
+	q := sqlite.Insert(
+		im.Into(articleFavoritesTableName, "article_id", "user_id"),
+		im.Values(sqlite.Arg(articleId.String()), sqlite.Arg(favoritedByUserId.String())),
+		im.OnConflict("article_id", "user_id").DoNothing(),
+	)
+
+	_, err := bob.Exec(ctx, db_types.ExecutorFromCtx(ctx, r.db), q)
+	if err != nil {
+		return fmt.Errorf("error with favorite article query, article_id=%v, user_id=%v: %w", articleId, favoritedByUserId, err)
+	}
+
+	return nil
+}
+
+// DeleteArticle implements [types.ArticleRepository.DeleteArticle].
+func (r *sqliteArticleRepo) DeleteArticle(ctx context.Context, id uuid.UUID) error {
+	q := sqlite.Update(
+		um.Table(articlesTableName),
+		um.SetCol("deleted_at").ToArg(time.Now().UTC()),
+		um.Where(sqlite.Quote("id").EQ(sqlite.Arg(id.String()))),
+		um.Where(sqlite.Quote("deleted_at").IsNull()),
+	)
+
+	_, err := bob.Exec(ctx, db_types.ExecutorFromCtx(ctx, r.db), q)
+	if err != nil {
+		return fmt.Errorf("error with delete article query, id=%v: %w", id, err)
+	}
+
+	return nil
+}
+
+// RestoreArticle implements [types.ArticleRepository.RestoreArticle].
+func (r *sqliteArticleRepo) RestoreArticle(ctx context.Context, id uuid.UUID, deletedAfter time.Time) (bool, error) {
+	q := sqlite.Update(
+		um.Table(articlesTableName),
+		um.SetCol("deleted_at").To(sqlite.Raw("NULL")),
+		um.Where(sqlite.Quote("id").EQ(sqlite.Arg(id.String()))),
+		um.Where(sqlite.Quote("deleted_at").GT(sqlite.Arg(deletedAfter.UTC()))),
+	)
+
+	result, err := bob.Exec(ctx, db_types.ExecutorFromCtx(ctx, r.db), q)
+	if err != nil {
+		return false, fmt.Errorf("error with restore article query, id=%v: %w", id, err)
+	}
+
+	restored, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("error reading restored articles count: %w", err)
+	}
+
+	return restored > 0, nil
+}
+
+// PurgeDeletedArticles implements [types.ArticleRepository.PurgeDeletedArticles].
+func (r *sqliteArticleRepo) PurgeDeletedArticles(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	q := sqlite.Delete(
+		dm.From(articlesTableName),
+		dm.Where(sqlite.Quote("deleted_at").LTE(sqlite.Arg(deletedBefore.UTC()))),
+	)
+
+	result, err := bob.Exec(ctx, db_types.ExecutorFromCtx(ctx, r.db), q)
+	if err != nil {
+		return 0, fmt.Errorf("error with purge deleted articles query, deleted_before=%v: %w", deletedBefore, err)
+	}
+
+	purged, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("error reading purged articles count: %w", err)
+	}
+
+	return purged, nil
+}
+
+// isActiveSqliteArticle is isActiveArticle for sqlite
+func isActiveSqliteArticle() bob.Expression {
+	return sqlite.And(
+		sqlite.Quote(articlesTableName, "deleted_at").IsNull(),
+		sqlite.Raw("EXISTS ?", sqlite.Select(
+			sm.Columns("id"),
+			sm.From(usersTableName),
+			sm.Where(sqlite.Quote(usersTableName, "id").EQ(sqlite.Quote(articlesTableName, "author_user_id"))),
+			sm.Where(sqlite.Quote(usersTableName, "deleted_at").IsNull()),
+		)),
+	)
+}
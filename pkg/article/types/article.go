@@ -9,6 +9,10 @@ type Article struct {
 	AuthorUserId    uuid.UUID
 	Title           string
 	Description     string
+	Body            string
 	CreatedAtMillis int64
 	UpdatedAtMillis int64
+	// Version is incremented on every update. When upserting an existing article it must be the version being
+	// updated, otherwise the update is rejected with a VersionConflictError
+	Version int64
 }
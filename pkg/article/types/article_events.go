@@ -0,0 +1,15 @@
+package article_types
+
+import "github.com/google/uuid"
+
+// ArticleSavedEventType is the outbox event type published when an article is created or updated
+const ArticleSavedEventType = "article.saved"
+
+// ArticleSavedEvent is the payload of an article.saved event
+type ArticleSavedEvent struct {
+	ArticleId    uuid.UUID `json:"article_id"`
+	AuthorUserId uuid.UUID `json:"author_user_id"`
+	Version      int64     `json:"version"`
+	// Created is true when the article was created, false when an existing one was updated
+	Created bool `json:"created"`
+}
@@ -2,12 +2,15 @@ package article_types
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/samber/mo"
 )
 
 type ArticleRepository interface {
+	// UpsertArticle inserts the article, or updates it if article.Version matches the stored version,
+	// returning a VersionConflictError otherwise
 	UpsertArticle(ctx context.Context, article Article) (Article, error)
 	GetArticleById(ctx context.Context, id uuid.UUID) (mo.Option[Article], error)
 	ListArticles(ctx context.Context,
@@ -17,11 +20,24 @@ type ArticleRepository interface {
 		favoritedByUserId mo.Option[uuid.UUID],
 		tag mo.Option[string],
 	) ([]Article, error)
-	// ListArticleFeed returns articles created by authors the user follows
+	// ListArticleFeed returns articles created by authors the user follows, newest first, leaving out those by the
+	// excluded authors
 	ListArticleFeed(ctx context.Context,
 		userId uuid.UUID,
 		limit int,
 		offset int,
+		excludedAuthorUserIds []uuid.UUID,
 	) ([]Article, error)
+	// SearchArticles returns the active articles matching the query, best match first
+	SearchArticles(ctx context.Context, query ArticleSearchQuery) ([]ArticleSearchResult, error)
+	// AddArticleTags tags the article, tags it already has are left as they are
+	AddArticleTags(ctx context.Context, articleId uuid.UUID, tags []string) error
+	// FavoriteArticle is a no-op if the user has already favorited the article
+	FavoriteArticle(ctx context.Context, favoritedByUserId uuid.UUID, articleId uuid.UUID) error
+	// DeleteArticle soft deletes the article, it is excluded from all reads until restored or purged
 	DeleteArticle(ctx context.Context, id uuid.UUID) error
+	// RestoreArticle restores the article if it was soft deleted after deletedAfter, returning false if there was nothing to restore
+	RestoreArticle(ctx context.Context, id uuid.UUID, deletedAfter time.Time) (bool, error)
+	// PurgeDeletedArticles hard deletes articles soft deleted at or before deletedBefore, returning the number purged
+	PurgeDeletedArticles(ctx context.Context, deletedBefore time.Time) (int64, error)
 }
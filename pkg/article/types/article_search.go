@@ -0,0 +1,32 @@
+package article_types
+
+import (
+	"github.com/google/uuid"
+	"github.com/samber/mo"
+)
+
+// ArticleSearchQuery is a full text search of articles, along with filters the matches must also pass
+type ArticleSearchQuery struct {
+	// Text is matched against the title, description and body. A word matches other forms with the same stem, e.g.
+	// "running" matches "run", a quoted phrase matches its words in order, and a word prefixed with - excludes the
+	// articles containing it. Every other word must be present
+	Text         string
+	AuthorUserId mo.Option[uuid.UUID]
+	Tag          mo.Option[string]
+	// ExcludedAuthorUserIds are left out of the results. ArticleService.SearchArticles sets them to the authors hidden
+	// from the viewer
+	ExcludedAuthorUserIds []uuid.UUID
+	Limit                 int
+	Offset                int
+}
+
+type ArticleSearchResult struct {
+	Article Article
+	Tags    []string
+	// Rank is how well the article matched, higher is better, title matches count the most and body matches the
+	// least. It is only comparable between the results of one search
+	Rank float64
+	// Highlight is an excerpt around the matched words, as html: the text is escaped and each match is wrapped in a
+	// <mark> element
+	Highlight string
+}
@@ -1,3 +1,33 @@
 package article_types
 
-type ArticleService any
+import (
+	"context"
+
+	"github.com/nimaeskandary/go-realworld/pkg/user/types"
+
+	"github.com/google/uuid"
+	"github.com/samber/mo"
+)
+
+//mockery:generate: true
+type ArticleService interface {
+	// ListArticleFeed returns the articles by authors the viewer follows, newest first, leaving out authors the viewer
+	// has blocked or muted
+	ListArticleFeed(ctx context.Context, viewer user_types.User, limit int, offset int) ([]Article, DomainError)
+	GetArticle(ctx context.Context, id uuid.UUID) (Article, DomainError)
+	// UpsertArticle inserts the article, or updates it if article.Version matches the stored version, failing with a
+	// VersionConflictError otherwise. An ArticleSavedEvent is published to the outbox in the same transaction, so it is
+	// relayed if and only if the article is saved
+	UpsertArticle(ctx context.Context, article Article) (Article, DomainError)
+	// AddArticleTags tags the article, tags it already has are left as they are
+	AddArticleTags(ctx context.Context, id uuid.UUID, tags []string) DomainError
+	// FavoriteArticle is a no-op if the viewer has already favorited the article
+	FavoriteArticle(ctx context.Context, viewer user_types.User, id uuid.UUID) DomainError
+	// DeleteArticle soft deletes the article, it can be restored until the grace period expires and it is purged
+	DeleteArticle(ctx context.Context, id uuid.UUID) DomainError
+	RestoreArticle(ctx context.Context, id uuid.UUID) (Article, DomainError)
+	// SearchArticles returns the articles matching the query, best match first, leaving out authors the viewer has
+	// blocked or muted. An InvalidSearchQueryError is returned if the query has nothing to search for, or its limit or
+	// offset is out of range
+	SearchArticles(ctx context.Context, viewer mo.Option[user_types.User], query ArticleSearchQuery) ([]ArticleSearchResult, DomainError)
+}
@@ -0,0 +1,55 @@
+package article_types
+
+import (
+	"errors"
+	"fmt"
+)
+
+type DomainError interface {
+	// unexported method keeps this sealed to the package
+	sealed()
+	error
+}
+
+func AsDomainError(err error) DomainError {
+	if de, ok := errors.AsType[DomainError](err); ok {
+		return de
+	}
+	return UnknownError{Err: err}
+}
+
+type UnknownError struct {
+	Err error
+}
+
+func (e UnknownError) sealed() {}
+func (e UnknownError) Error() string {
+	return fmt.Errorf("UnknownError: unknown article domain error: %w", e.Err).Error()
+}
+
+type NotFoundError struct {
+	Identifier string
+}
+
+func (e NotFoundError) sealed() {}
+func (e NotFoundError) Error() string {
+	return fmt.Sprintf("NotFoundError: could not find article with identifier: %v", e.Identifier)
+}
+
+type VersionConflictError struct {
+	Identifier string
+}
+
+func (e VersionConflictError) sealed() {}
+func (e VersionConflictError) Error() string {
+	return fmt.Sprintf("VersionConflictError: article with identifier %v was modified by another request", e.Identifier)
+}
+
+type InvalidSearchQueryError struct {
+	Reason string
+}
+
+func (e InvalidSearchQueryError) sealed() {}
+func (e InvalidSearchQueryError) Error() string {
+	return fmt.Sprintf("InvalidSearchQueryError: %v", e.Reason)
+}
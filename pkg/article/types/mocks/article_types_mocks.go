@@ -0,0 +1,593 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package article_types_mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/nimaeskandary/go-realworld/pkg/article/types"
+	"github.com/nimaeskandary/go-realworld/pkg/user/types"
+	"github.com/samber/mo"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockArticleService creates a new instance of MockArticleService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockArticleService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockArticleService {
+	mock := &MockArticleService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockArticleService is an autogenerated mock type for the ArticleService type
+type MockArticleService struct {
+	mock.Mock
+}
+
+type MockArticleService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockArticleService) EXPECT() *MockArticleService_Expecter {
+	return &MockArticleService_Expecter{mock: &_m.Mock}
+}
+
+// AddArticleTags provides a mock function for the type MockArticleService
+func (_mock *MockArticleService) AddArticleTags(ctx context.Context, id uuid.UUID, tags []string) article_types.DomainError {
+	ret := _mock.Called(ctx, id, tags)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddArticleTags")
+	}
+
+	var r0 article_types.DomainError
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, []string) article_types.DomainError); ok {
+		r0 = returnFunc(ctx, id, tags)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(article_types.DomainError)
+		}
+	}
+	return r0
+}
+
+// MockArticleService_AddArticleTags_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddArticleTags'
+type MockArticleService_AddArticleTags_Call struct {
+	*mock.Call
+}
+
+// AddArticleTags is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+//   - tags []string
+func (_e *MockArticleService_Expecter) AddArticleTags(ctx interface{}, id interface{}, tags interface{}) *MockArticleService_AddArticleTags_Call {
+	return &MockArticleService_AddArticleTags_Call{Call: _e.mock.On("AddArticleTags", ctx, id, tags)}
+}
+
+func (_c *MockArticleService_AddArticleTags_Call) Run(run func(ctx context.Context, id uuid.UUID, tags []string)) *MockArticleService_AddArticleTags_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 []string
+		if args[2] != nil {
+			arg2 = args[2].([]string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockArticleService_AddArticleTags_Call) Return(domainError article_types.DomainError) *MockArticleService_AddArticleTags_Call {
+	_c.Call.Return(domainError)
+	return _c
+}
+
+func (_c *MockArticleService_AddArticleTags_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID, tags []string) article_types.DomainError) *MockArticleService_AddArticleTags_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteArticle provides a mock function for the type MockArticleService
+func (_mock *MockArticleService) DeleteArticle(ctx context.Context, id uuid.UUID) article_types.DomainError {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteArticle")
+	}
+
+	var r0 article_types.DomainError
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) article_types.DomainError); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(article_types.DomainError)
+		}
+	}
+	return r0
+}
+
+// MockArticleService_DeleteArticle_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteArticle'
+type MockArticleService_DeleteArticle_Call struct {
+	*mock.Call
+}
+
+// DeleteArticle is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockArticleService_Expecter) DeleteArticle(ctx interface{}, id interface{}) *MockArticleService_DeleteArticle_Call {
+	return &MockArticleService_DeleteArticle_Call{Call: _e.mock.On("DeleteArticle", ctx, id)}
+}
+
+func (_c *MockArticleService_DeleteArticle_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockArticleService_DeleteArticle_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockArticleService_DeleteArticle_Call) Return(domainError article_types.DomainError) *MockArticleService_DeleteArticle_Call {
+	_c.Call.Return(domainError)
+	return _c
+}
+
+func (_c *MockArticleService_DeleteArticle_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID) article_types.DomainError) *MockArticleService_DeleteArticle_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FavoriteArticle provides a mock function for the type MockArticleService
+func (_mock *MockArticleService) FavoriteArticle(ctx context.Context, viewer user_types.User, id uuid.UUID) article_types.DomainError {
+	ret := _mock.Called(ctx, viewer, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FavoriteArticle")
+	}
+
+	var r0 article_types.DomainError
+	if returnFunc, ok := ret.Get(0).(func(context.Context, user_types.User, uuid.UUID) article_types.DomainError); ok {
+		r0 = returnFunc(ctx, viewer, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(article_types.DomainError)
+		}
+	}
+	return r0
+}
+
+// MockArticleService_FavoriteArticle_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FavoriteArticle'
+type MockArticleService_FavoriteArticle_Call struct {
+	*mock.Call
+}
+
+// FavoriteArticle is a helper method to define mock.On call
+//   - ctx context.Context
+//   - viewer user_types.User
+//   - id uuid.UUID
+func (_e *MockArticleService_Expecter) FavoriteArticle(ctx interface{}, viewer interface{}, id interface{}) *MockArticleService_FavoriteArticle_Call {
+	return &MockArticleService_FavoriteArticle_Call{Call: _e.mock.On("FavoriteArticle", ctx, viewer, id)}
+}
+
+func (_c *MockArticleService_FavoriteArticle_Call) Run(run func(ctx context.Context, viewer user_types.User, id uuid.UUID)) *MockArticleService_FavoriteArticle_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 user_types.User
+		if args[1] != nil {
+			arg1 = args[1].(user_types.User)
+		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockArticleService_FavoriteArticle_Call) Return(domainError article_types.DomainError) *MockArticleService_FavoriteArticle_Call {
+	_c.Call.Return(domainError)
+	return _c
+}
+
+func (_c *MockArticleService_FavoriteArticle_Call) RunAndReturn(run func(ctx context.Context, viewer user_types.User, id uuid.UUID) article_types.DomainError) *MockArticleService_FavoriteArticle_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetArticle provides a mock function for the type MockArticleService
+func (_mock *MockArticleService) GetArticle(ctx context.Context, id uuid.UUID) (article_types.Article, article_types.DomainError) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetArticle")
+	}
+
+	var r0 article_types.Article
+	var r1 article_types.DomainError
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) (article_types.Article, article_types.DomainError)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) article_types.Article); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Get(0).(article_types.Article)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) article_types.DomainError); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(article_types.DomainError)
+		}
+	}
+	return r0, r1
+}
+
+// MockArticleService_GetArticle_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetArticle'
+type MockArticleService_GetArticle_Call struct {
+	*mock.Call
+}
+
+// GetArticle is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockArticleService_Expecter) GetArticle(ctx interface{}, id interface{}) *MockArticleService_GetArticle_Call {
+	return &MockArticleService_GetArticle_Call{Call: _e.mock.On("GetArticle", ctx, id)}
+}
+
+func (_c *MockArticleService_GetArticle_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockArticleService_GetArticle_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockArticleService_GetArticle_Call) Return(article article_types.Article, domainError article_types.DomainError) *MockArticleService_GetArticle_Call {
+	_c.Call.Return(article, domainError)
+	return _c
+}
+
+func (_c *MockArticleService_GetArticle_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID) (article_types.Article, article_types.DomainError)) *MockArticleService_GetArticle_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListArticleFeed provides a mock function for the type MockArticleService
+func (_mock *MockArticleService) ListArticleFeed(ctx context.Context, viewer user_types.User, limit int, offset int) ([]article_types.Article, article_types.DomainError) {
+	ret := _mock.Called(ctx, viewer, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListArticleFeed")
+	}
+
+	var r0 []article_types.Article
+	var r1 article_types.DomainError
+	if returnFunc, ok := ret.Get(0).(func(context.Context, user_types.User, int, int) ([]article_types.Article, article_types.DomainError)); ok {
+		return returnFunc(ctx, viewer, limit, offset)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, user_types.User, int, int) []article_types.Article); ok {
+		r0 = returnFunc(ctx, viewer, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]article_types.Article)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, user_types.User, int, int) article_types.DomainError); ok {
+		r1 = returnFunc(ctx, viewer, limit, offset)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(article_types.DomainError)
+		}
+	}
+	return r0, r1
+}
+
+// MockArticleService_ListArticleFeed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListArticleFeed'
+type MockArticleService_ListArticleFeed_Call struct {
+	*mock.Call
+}
+
+// ListArticleFeed is a helper method to define mock.On call
+//   - ctx context.Context
+//   - viewer user_types.User
+//   - limit int
+//   - offset int
+func (_e *MockArticleService_Expecter) ListArticleFeed(ctx interface{}, viewer interface{}, limit interface{}, offset interface{}) *MockArticleService_ListArticleFeed_Call {
+	return &MockArticleService_ListArticleFeed_Call{Call: _e.mock.On("ListArticleFeed", ctx, viewer, limit, offset)}
+}
+
+func (_c *MockArticleService_ListArticleFeed_Call) Run(run func(ctx context.Context, viewer user_types.User, limit int, offset int)) *MockArticleService_ListArticleFeed_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 user_types.User
+		if args[1] != nil {
+			arg1 = args[1].(user_types.User)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		var arg3 int
+		if args[3] != nil {
+			arg3 = args[3].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockArticleService_ListArticleFeed_Call) Return(articles []article_types.Article, domainError article_types.DomainError) *MockArticleService_ListArticleFeed_Call {
+	_c.Call.Return(articles, domainError)
+	return _c
+}
+
+func (_c *MockArticleService_ListArticleFeed_Call) RunAndReturn(run func(ctx context.Context, viewer user_types.User, limit int, offset int) ([]article_types.Article, article_types.DomainError)) *MockArticleService_ListArticleFeed_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RestoreArticle provides a mock function for the type MockArticleService
+func (_mock *MockArticleService) RestoreArticle(ctx context.Context, id uuid.UUID) (article_types.Article, article_types.DomainError) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreArticle")
+	}
+
+	var r0 article_types.Article
+	var r1 article_types.DomainError
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) (article_types.Article, article_types.DomainError)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) article_types.Article); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Get(0).(article_types.Article)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) article_types.DomainError); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(article_types.DomainError)
+		}
+	}
+	return r0, r1
+}
+
+// MockArticleService_RestoreArticle_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreArticle'
+type MockArticleService_RestoreArticle_Call struct {
+	*mock.Call
+}
+
+// RestoreArticle is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockArticleService_Expecter) RestoreArticle(ctx interface{}, id interface{}) *MockArticleService_RestoreArticle_Call {
+	return &MockArticleService_RestoreArticle_Call{Call: _e.mock.On("RestoreArticle", ctx, id)}
+}
+
+func (_c *MockArticleService_RestoreArticle_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockArticleService_RestoreArticle_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockArticleService_RestoreArticle_Call) Return(article article_types.Article, domainError article_types.DomainError) *MockArticleService_RestoreArticle_Call {
+	_c.Call.Return(article, domainError)
+	return _c
+}
+
+func (_c *MockArticleService_RestoreArticle_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID) (article_types.Article, article_types.DomainError)) *MockArticleService_RestoreArticle_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SearchArticles provides a mock function for the type MockArticleService
+func (_mock *MockArticleService) SearchArticles(ctx context.Context, viewer mo.Option[user_types.User], query article_types.ArticleSearchQuery) ([]article_types.ArticleSearchResult, article_types.DomainError) {
+	ret := _mock.Called(ctx, viewer, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchArticles")
+	}
+
+	var r0 []article_types.ArticleSearchResult
+	var r1 article_types.DomainError
+	if returnFunc, ok := ret.Get(0).(func(context.Context, mo.Option[user_types.User], article_types.ArticleSearchQuery) ([]article_types.ArticleSearchResult, article_types.DomainError)); ok {
+		return returnFunc(ctx, viewer, query)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, mo.Option[user_types.User], article_types.ArticleSearchQuery) []article_types.ArticleSearchResult); ok {
+		r0 = returnFunc(ctx, viewer, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]article_types.ArticleSearchResult)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, mo.Option[user_types.User], article_types.ArticleSearchQuery) article_types.DomainError); ok {
+		r1 = returnFunc(ctx, viewer, query)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(article_types.DomainError)
+		}
+	}
+	return r0, r1
+}
+
+// MockArticleService_SearchArticles_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SearchArticles'
+type MockArticleService_SearchArticles_Call struct {
+	*mock.Call
+}
+
+// SearchArticles is a helper method to define mock.On call
+//   - ctx context.Context
+//   - viewer mo.Option[user_types.User]
+//   - query article_types.ArticleSearchQuery
+func (_e *MockArticleService_Expecter) SearchArticles(ctx interface{}, viewer interface{}, query interface{}) *MockArticleService_SearchArticles_Call {
+	return &MockArticleService_SearchArticles_Call{Call: _e.mock.On("SearchArticles", ctx, viewer, query)}
+}
+
+func (_c *MockArticleService_SearchArticles_Call) Run(run func(ctx context.Context, viewer mo.Option[user_types.User], query article_types.ArticleSearchQuery)) *MockArticleService_SearchArticles_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 mo.Option[user_types.User]
+		if args[1] != nil {
+			arg1 = args[1].(mo.Option[user_types.User])
+		}
+		var arg2 article_types.ArticleSearchQuery
+		if args[2] != nil {
+			arg2 = args[2].(article_types.ArticleSearchQuery)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockArticleService_SearchArticles_Call) Return(articleSearchResults []article_types.ArticleSearchResult, domainError article_types.DomainError) *MockArticleService_SearchArticles_Call {
+	_c.Call.Return(articleSearchResults, domainError)
+	return _c
+}
+
+func (_c *MockArticleService_SearchArticles_Call) RunAndReturn(run func(ctx context.Context, viewer mo.Option[user_types.User], query article_types.ArticleSearchQuery) ([]article_types.ArticleSearchResult, article_types.DomainError)) *MockArticleService_SearchArticles_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpsertArticle provides a mock function for the type MockArticleService
+func (_mock *MockArticleService) UpsertArticle(ctx context.Context, article article_types.Article) (article_types.Article, article_types.DomainError) {
+	ret := _mock.Called(ctx, article)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertArticle")
+	}
+
+	var r0 article_types.Article
+	var r1 article_types.DomainError
+	if returnFunc, ok := ret.Get(0).(func(context.Context, article_types.Article) (article_types.Article, article_types.DomainError)); ok {
+		return returnFunc(ctx, article)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, article_types.Article) article_types.Article); ok {
+		r0 = returnFunc(ctx, article)
+	} else {
+		r0 = ret.Get(0).(article_types.Article)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, article_types.Article) article_types.DomainError); ok {
+		r1 = returnFunc(ctx, article)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(article_types.DomainError)
+		}
+	}
+	return r0, r1
+}
+
+// MockArticleService_UpsertArticle_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpsertArticle'
+type MockArticleService_UpsertArticle_Call struct {
+	*mock.Call
+}
+
+// UpsertArticle is a helper method to define mock.On call
+//   - ctx context.Context
+//   - article article_types.Article
+func (_e *MockArticleService_Expecter) UpsertArticle(ctx interface{}, article interface{}) *MockArticleService_UpsertArticle_Call {
+	return &MockArticleService_UpsertArticle_Call{Call: _e.mock.On("UpsertArticle", ctx, article)}
+}
+
+func (_c *MockArticleService_UpsertArticle_Call) Run(run func(ctx context.Context, article article_types.Article)) *MockArticleService_UpsertArticle_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 article_types.Article
+		if args[1] != nil {
+			arg1 = args[1].(article_types.Article)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockArticleService_UpsertArticle_Call) Return(article1 article_types.Article, domainError article_types.DomainError) *MockArticleService_UpsertArticle_Call {
+	_c.Call.Return(article1, domainError)
+	return _c
+}
+
+func (_c *MockArticleService_UpsertArticle_Call) RunAndReturn(run func(ctx context.Context, article article_types.Article) (article_types.Article, article_types.DomainError)) *MockArticleService_UpsertArticle_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,28 @@
+package audit
+
+import (
+	"github.com/nimaeskandary/go-realworld/pkg/audit/internal"
+	audit_types "github.com/nimaeskandary/go-realworld/pkg/audit/types"
+	"github.com/nimaeskandary/go-realworld/pkg/util"
+
+	"go.uber.org/fx"
+)
+
+// NewAuditModule provides the AuditLog that changes to users, articles and follows are recorded in, along with its
+// repository
+func NewAuditModule() fx.Option {
+	return newAuditModule(internal.NewAuditRepository)
+}
+
+// NewInMemoryAuditModule is NewAuditModule with the repository kept in a RealWorldAppInMemoryDb
+func NewInMemoryAuditModule() fx.Option {
+	return newAuditModule(internal.NewInMemoryAuditRepository)
+}
+
+func newAuditModule(repositoryConstructor any) fx.Option {
+	return util.NewFxModule[audit_types.AuditLog](
+		"audit_log",
+		internal.NewAuditLogImpl,
+		fx.Provide(repositoryConstructor),
+	)
+}
@@ -0,0 +1,132 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"time"
+
+	audit_types "github.com/nimaeskandary/go-realworld/pkg/audit/types"
+	"github.com/nimaeskandary/go-realworld/pkg/auth/context"
+
+	"github.com/google/uuid"
+	"github.com/samber/mo"
+)
+
+// maxAuditQueryLimit bounds the entries returned by one query
+const maxAuditQueryLimit = 1000
+
+type auditLogImpl struct {
+	repo audit_types.AuditRepository
+}
+
+func NewAuditLogImpl(repo audit_types.AuditRepository) audit_types.AuditLog {
+	return &auditLogImpl{repo: repo}
+}
+
+func (a *auditLogImpl) Record(ctx context.Context, change audit_types.AuditChange) error {
+	before, after, err := diffAuditState(change.Before, change.After)
+	if err != nil {
+		return fmt.Errorf("error diffing audit state, entity_type=%v, entity_id=%v: %w", change.EntityType, change.EntityId, err)
+	}
+	if before == nil && after == nil {
+		return nil
+	}
+
+	// a version 7 id is ordered by the time it was made, even within a millisecond, so entries sort in the order they
+	// were recorded
+	id, err := uuid.NewV7()
+	if err != nil {
+		return fmt.Errorf("error generating audit entry id: %w", err)
+	}
+	createdAt := time.Unix(id.Time().UnixTime())
+
+	actorUserId := mo.None[uuid.UUID]()
+	if actor, ok := auth_context.UserFromCtx(ctx).Get(); ok {
+		actorUserId = mo.Some(actor.Id)
+	}
+
+	return a.repo.InsertEntry(ctx, audit_types.AuditEntry{
+		Id:              id,
+		ActorUserId:     actorUserId,
+		Action:          change.Action,
+		EntityType:      change.EntityType,
+		EntityId:        change.EntityId,
+		Before:          before,
+		After:           after,
+		CreatedAtMillis: createdAt.UnixMilli(),
+	})
+}
+
+func (a *auditLogImpl) ListEntries(ctx context.Context, query audit_types.AuditQuery) ([]audit_types.AuditEntry, error) {
+	if query.Limit < 1 || query.Limit > maxAuditQueryLimit {
+		return nil, fmt.Errorf("invalid audit query: limit must be between 1 and %v", maxAuditQueryLimit)
+	}
+	if query.Offset < 0 {
+		return nil, fmt.Errorf("invalid audit query: offset cannot be negative")
+	}
+
+	return a.repo.ListEntries(ctx, query)
+}
+
+// diffAuditState marshals the states to json objects, and keeps the fields of each that differ from the other. A nil
+// state stays nil, and both are nil if no field changed
+func diffAuditState(before any, after any) (json.RawMessage, json.RawMessage, error) {
+	beforeFields, err := auditStateFields(before)
+	if err != nil {
+		return nil, nil, err
+	}
+	afterFields, err := auditStateFields(after)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if beforeFields != nil && afterFields != nil {
+		for field, beforeValue := range maps.Clone(beforeFields) {
+			if afterValue, ok := afterFields[field]; ok && bytes.Equal(beforeValue, afterValue) {
+				delete(beforeFields, field)
+				delete(afterFields, field)
+			}
+		}
+		if len(beforeFields) == 0 && len(afterFields) == 0 {
+			return nil, nil, nil
+		}
+	}
+
+	beforeDiff, err := marshalAuditFields(beforeFields)
+	if err != nil {
+		return nil, nil, err
+	}
+	afterDiff, err := marshalAuditFields(afterFields)
+	if err != nil {
+		return nil, nil, err
+	}
+	return beforeDiff, afterDiff, nil
+}
+
+// auditStateFields returns the fields of the state's json object, nil for a nil state
+func auditStateFields(state any) (map[string]json.RawMessage, error) {
+	if state == nil {
+		return nil, nil
+	}
+
+	stateBytes, err := json.Marshal(state)
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling audit state: %w", err)
+	}
+
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(stateBytes, &fields); err != nil {
+		return nil, fmt.Errorf("audit state is not a json object: %w", err)
+	}
+	return fields, nil
+}
+
+func marshalAuditFields(fields map[string]json.RawMessage) (json.RawMessage, error) {
+	if fields == nil {
+		return nil, nil
+	}
+	return json.Marshal(fields)
+}
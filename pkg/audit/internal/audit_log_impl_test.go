@@ -0,0 +1,211 @@
+package internal_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	audit_types "github.com/nimaeskandary/go-realworld/pkg/audit/types"
+	"github.com/nimaeskandary/go-realworld/pkg/auth/context"
+	"github.com/nimaeskandary/go-realworld/pkg/test_utils/fixtures"
+	"github.com/nimaeskandary/go-realworld/pkg/test_utils/helpers"
+	user_types "github.com/nimaeskandary/go-realworld/pkg/user/types"
+
+	"github.com/google/uuid"
+	"github.com/samber/mo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_AuditLogImpl(t *testing.T) {
+	t.Parallel()
+
+	type profile struct {
+		Name string            `json:"name"`
+		Bio  mo.Option[string] `json:"bio"`
+	}
+
+	entriesOf := func(t *testing.T, f fixtures.StandardFixture, entityType audit_types.AuditEntityType, entityId string) []audit_types.AuditEntry {
+		entries, err := f.AuditLog.ListEntries(t.Context(), audit_types.AuditQuery{
+			EntityType: mo.Some(entityType),
+			EntityId:   mo.Some(entityId),
+			Limit:      100,
+		})
+		require.NoError(t, err)
+		return entries
+	}
+
+	t.Run("Record", func(t *testing.T) {
+		t.Parallel()
+
+		t.Run("should record every field of a created entity, with no actor outside of a request", func(t *testing.T) {
+			t.Parallel()
+			f := fixtures.SetupInMemoryFixture(t)
+			entityId := uuid.NewString()
+
+			err := f.AuditLog.Record(t.Context(), audit_types.AuditChange{
+				Action:     audit_types.AuditActionCreate,
+				EntityType: audit_types.AuditEntityUser,
+				EntityId:   entityId,
+				After:      profile{Name: "jake"},
+			})
+			require.NoError(t, err)
+
+			entries := entriesOf(t, f, audit_types.AuditEntityUser, entityId)
+			require.Len(t, entries, 1)
+			assert.True(t, entries[0].ActorUserId.IsNone())
+			assert.Equal(t, audit_types.AuditActionCreate, entries[0].Action)
+			assert.Nil(t, entries[0].Before)
+			assert.JSONEq(t, `{"name": "jake", "bio": null}`, string(entries[0].After))
+		})
+
+		t.Run("should record the user signed in to ctx as the actor", func(t *testing.T) {
+			t.Parallel()
+			f := fixtures.SetupInMemoryFixture(t)
+			actor := helpers.GenUser()
+			entityId := uuid.NewString()
+
+			err := f.AuditLog.Record(auth_context.CtxWithUser(t.Context(), actor), audit_types.AuditChange{
+				Action:     audit_types.AuditActionDelete,
+				EntityType: audit_types.AuditEntityUser,
+				EntityId:   entityId,
+				Before:     profile{Name: "jake"},
+			})
+			require.NoError(t, err)
+
+			entries := entriesOf(t, f, audit_types.AuditEntityUser, entityId)
+			require.Len(t, entries, 1)
+			assert.Equal(t, mo.Some(actor.Id), entries[0].ActorUserId)
+			assert.JSONEq(t, `{"name": "jake", "bio": null}`, string(entries[0].Before))
+			assert.Nil(t, entries[0].After)
+		})
+
+		t.Run("should record only the fields an update changed", func(t *testing.T) {
+			t.Parallel()
+			f := fixtures.SetupInMemoryFixture(t)
+			entityId := uuid.NewString()
+
+			err := f.AuditLog.Record(t.Context(), audit_types.AuditChange{
+				Action:     audit_types.AuditActionUpdate,
+				EntityType: audit_types.AuditEntityUser,
+				EntityId:   entityId,
+				Before:     profile{Name: "jake", Bio: mo.Some("I work at statefarm")},
+				After:      profile{Name: "jake", Bio: mo.None[string]()},
+			})
+			require.NoError(t, err)
+
+			entries := entriesOf(t, f, audit_types.AuditEntityUser, entityId)
+			require.Len(t, entries, 1)
+			assert.JSONEq(t, `{"bio": "I work at statefarm"}`, string(entries[0].Before))
+			assert.JSONEq(t, `{"bio": null}`, string(entries[0].After))
+		})
+
+		t.Run("should not record an update that changed nothing", func(t *testing.T) {
+			t.Parallel()
+			f := fixtures.SetupInMemoryFixture(t)
+			entityId := uuid.NewString()
+
+			err := f.AuditLog.Record(t.Context(), audit_types.AuditChange{
+				Action:     audit_types.AuditActionUpdate,
+				EntityType: audit_types.AuditEntityUser,
+				EntityId:   entityId,
+				Before:     profile{Name: "jake"},
+				After:      profile{Name: "jake"},
+			})
+			require.NoError(t, err)
+
+			assert.Empty(t, entriesOf(t, f, audit_types.AuditEntityUser, entityId))
+		})
+
+		t.Run("should fail for a state that is not a json object", func(t *testing.T) {
+			t.Parallel()
+			f := fixtures.SetupInMemoryFixture(t)
+
+			err := f.AuditLog.Record(t.Context(), audit_types.AuditChange{
+				Action:     audit_types.AuditActionCreate,
+				EntityType: audit_types.AuditEntityUser,
+				EntityId:   uuid.NewString(),
+				After:      "jake",
+			})
+			assert.Error(t, err)
+		})
+
+		t.Run("should not record a change whose transaction rolled back", func(t *testing.T) {
+			t.Parallel()
+			f := fixtures.SetupInMemoryFixture(t)
+			entityId := uuid.NewString()
+
+			rollback := errors.New("rollback")
+			err := f.TxManager.WithinTx(t.Context(), func(ctx context.Context) error {
+				require.NoError(t, f.AuditLog.Record(ctx, audit_types.AuditChange{
+					Action:     audit_types.AuditActionCreate,
+					EntityType: audit_types.AuditEntityUser,
+					EntityId:   entityId,
+					After:      profile{Name: "jake"},
+				}))
+				return rollback
+			})
+			require.ErrorIs(t, err, rollback)
+
+			assert.Empty(t, entriesOf(t, f, audit_types.AuditEntityUser, entityId))
+		})
+	})
+
+	t.Run("ListEntries", func(t *testing.T) {
+		t.Parallel()
+
+		t.Run("should fail if the limit or offset is out of range", func(t *testing.T) {
+			t.Parallel()
+			f := fixtures.SetupInMemoryFixture(t)
+
+			for _, query := range []audit_types.AuditQuery{{Limit: 0}, {Limit: 1001}, {Limit: 10, Offset: -1}} {
+				_, err := f.AuditLog.ListEntries(t.Context(), query)
+				assert.Error(t, err, "query=%+v", query)
+			}
+		})
+	})
+
+	t.Run("UserService", func(t *testing.T) {
+		t.Parallel()
+
+		t.Run("should record the changes made to users and follows, by the signed in user", func(t *testing.T) {
+			t.Parallel()
+			f := fixtures.SetupInMemoryFixture(t)
+
+			jake, err := f.UserService.CreateUser(t.Context(), user_types.UpsertUserParams{Username: "jake", Email: "jake@example.com"})
+			require.NoError(t, err)
+			celeb, err := f.UserService.CreateUser(t.Context(), user_types.UpsertUserParams{Username: "celeb", Email: "celeb@example.com"})
+			require.NoError(t, err)
+
+			ctx := auth_context.CtxWithUser(t.Context(), jake)
+			_, err = f.UserService.UpdateUser(ctx, jake.Id, user_types.UpsertUserParams{
+				Username: "jake",
+				Email:    "jake@example.com",
+				Bio:      mo.Some("I work at statefarm"),
+			}, mo.None[int64]())
+			require.NoError(t, err)
+			_, err = f.UserService.FollowProfile(ctx, jake, celeb.Username)
+			require.NoError(t, err)
+			_, err = f.UserService.UnfollowProfile(ctx, jake, celeb.Username)
+			require.NoError(t, err)
+
+			userEntries := entriesOf(t, f, audit_types.AuditEntityUser, jake.Id.String())
+			require.Len(t, userEntries, 2)
+			assert.Equal(t, audit_types.AuditActionUpdate, userEntries[0].Action)
+			assert.Equal(t, mo.Some(jake.Id), userEntries[0].ActorUserId)
+			assert.JSONEq(t, `{"bio": null}`, string(userEntries[0].Before))
+			assert.JSONEq(t, `{"bio": "I work at statefarm"}`, string(userEntries[0].After))
+			assert.Equal(t, audit_types.AuditActionCreate, userEntries[1].Action)
+			assert.True(t, userEntries[1].ActorUserId.IsNone())
+			assert.JSONEq(t, `{"username": "jake", "email": "jake@example.com", "bio": null, "image": null}`, string(userEntries[1].After))
+
+			followEntries := entriesOf(t, f, audit_types.AuditEntityFollow, celeb.Id.String())
+			require.Len(t, followEntries, 2)
+			follow := `{"followed_by_user_id": "` + jake.Id.String() + `", "following_user_id": "` + celeb.Id.String() + `"}`
+			assert.Equal(t, audit_types.AuditActionDelete, followEntries[0].Action)
+			assert.JSONEq(t, follow, string(followEntries[0].Before))
+			assert.Equal(t, audit_types.AuditActionCreate, followEntries[1].Action)
+			assert.JSONEq(t, follow, string(followEntries[1].After))
+		})
+	})
+}
@@ -0,0 +1,26 @@
+package internal
+
+import (
+	"fmt"
+
+	audit_types "github.com/nimaeskandary/go-realworld/pkg/audit/types"
+	db_types "github.com/nimaeskandary/go-realworld/pkg/database/types"
+	obs_types "github.com/nimaeskandary/go-realworld/pkg/observability/types"
+)
+
+const auditLogTableName = "audit_log"
+
+// auditEntryColumns are the columns of postgresAuditEntry
+var auditEntryColumns = []any{"id", "actor_user_id", "action", "entity_type", "entity_id", "before", "after", "created_at"}
+
+// NewAuditRepository returns the repository for the database's dialect
+func NewAuditRepository(db db_types.RealWorldAppDb, logger obs_types.Logger) (audit_types.AuditRepository, error) {
+	switch db.GetDialect() {
+	case db_types.DialectPostgres:
+		return NewPostgresAuditRepository(db, logger), nil
+	case db_types.DialectSqlite:
+		return NewSqliteAuditRepository(db, logger), nil
+	default:
+		return nil, fmt.Errorf("no audit repository for dialect: %v", db.GetDialect())
+	}
+}
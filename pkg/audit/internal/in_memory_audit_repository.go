@@ -0,0 +1,112 @@
+package internal
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	audit_types "github.com/nimaeskandary/go-realworld/pkg/audit/types"
+	db_types "github.com/nimaeskandary/go-realworld/pkg/database/types"
+
+	"github.com/samber/mo"
+)
+
+// inMemoryAuditRepo is the in memory counterpart of postgresAuditRepo
+type inMemoryAuditRepo struct {
+	db db_types.RealWorldAppInMemoryDb
+}
+
+func NewInMemoryAuditRepository(db db_types.RealWorldAppInMemoryDb) audit_types.AuditRepository {
+	return &inMemoryAuditRepo{db: db}
+}
+
+// InsertEntry implements [audit_types.AuditRepository.InsertEntry]
+func (r *inMemoryAuditRepo) InsertEntry(ctx context.Context, entry audit_types.AuditEntry) error {
+	return r.db.Write(ctx, func(tables *db_types.InMemoryTables) error {
+		if _, exists := tables.AuditLog[entry.Id]; exists {
+			return fmt.Errorf("error with insert audit entry, id=%v, entity_type=%v, entity_id=%v: duplicate id",
+				entry.Id, entry.EntityType, entry.EntityId)
+		}
+		tables.AuditLog[entry.Id] = db_types.InMemoryAuditEntry{
+			Id:          entry.Id,
+			ActorUserId: entry.ActorUserId,
+			Action:      string(entry.Action),
+			EntityType:  string(entry.EntityType),
+			EntityId:    entry.EntityId,
+			Before:      nullableJson(entry.Before),
+			After:       nullableJson(entry.After),
+			CreatedAt:   time.UnixMilli(entry.CreatedAtMillis),
+		}
+		return nil
+	})
+}
+
+// ListEntries implements [audit_types.AuditRepository.ListEntries]
+func (r *inMemoryAuditRepo) ListEntries(ctx context.Context, query audit_types.AuditQuery) ([]audit_types.AuditEntry, error) {
+	var matches []db_types.InMemoryAuditEntry
+	err := r.db.Read(ctx, func(tables *db_types.InMemoryTables) error {
+		for _, entry := range tables.AuditLog {
+			if matchesAuditQuery(entry, query) {
+				matches = append(matches, entry)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slices.SortFunc(matches, func(a, b db_types.InMemoryAuditEntry) int {
+		return cmp.Or(b.CreatedAt.Compare(a.CreatedAt), cmp.Compare(b.Id.String(), a.Id.String()))
+	})
+	matches = matches[min(query.Offset, len(matches)):]
+	matches = matches[:min(query.Limit, len(matches))]
+
+	entries := make([]audit_types.AuditEntry, 0, len(matches))
+	for _, entry := range matches {
+		entries = append(entries, audit_types.AuditEntry{
+			Id:              entry.Id,
+			ActorUserId:     entry.ActorUserId,
+			Action:          audit_types.AuditAction(entry.Action),
+			EntityType:      audit_types.AuditEntityType(entry.EntityType),
+			EntityId:        entry.EntityId,
+			Before:          entry.Before.OrEmpty(),
+			After:           entry.After.OrEmpty(),
+			CreatedAtMillis: entry.CreatedAt.UnixMilli(),
+		})
+	}
+	return entries, nil
+}
+
+// matchesAuditQuery applies the filters of the query, as the where clause of postgresAuditRepo.ListEntries does
+func matchesAuditQuery(entry db_types.InMemoryAuditEntry, query audit_types.AuditQuery) bool {
+	if actorUserId, ok := query.ActorUserId.Get(); ok {
+		if entryActorUserId, set := entry.ActorUserId.Get(); !set || entryActorUserId != actorUserId {
+			return false
+		}
+	}
+	if entityType, ok := query.EntityType.Get(); ok && entry.EntityType != string(entityType) {
+		return false
+	}
+	if entityId, ok := query.EntityId.Get(); ok && entry.EntityId != entityId {
+		return false
+	}
+	if from, ok := query.From.Get(); ok && entry.CreatedAt.Before(from) {
+		return false
+	}
+	if to, ok := query.To.Get(); ok && !entry.CreatedAt.Before(to) {
+		return false
+	}
+	return true
+}
+
+// nullableJson is the value of a nullable json column, unset when there is no json
+func nullableJson(raw json.RawMessage) mo.Option[json.RawMessage] {
+	if raw == nil {
+		return mo.None[json.RawMessage]()
+	}
+	return mo.Some(raw)
+}
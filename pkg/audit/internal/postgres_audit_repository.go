@@ -0,0 +1,119 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	audit_types "github.com/nimaeskandary/go-realworld/pkg/audit/types"
+	db_types "github.com/nimaeskandary/go-realworld/pkg/database/types"
+	obs_types "github.com/nimaeskandary/go-realworld/pkg/observability/types"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/samber/mo"
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/bob/dialect/psql"
+	"github.com/stephenafamo/bob/dialect/psql/dialect"
+	"github.com/stephenafamo/bob/dialect/psql/im"
+	"github.com/stephenafamo/bob/dialect/psql/sm"
+	"github.com/stephenafamo/scan"
+)
+
+type postgresAuditRepo struct {
+	db     db_types.RealWorldAppDb
+	logger obs_types.Logger
+}
+
+func NewPostgresAuditRepository(db db_types.RealWorldAppDb, logger obs_types.Logger) audit_types.AuditRepository {
+	return &postgresAuditRepo{db: db, logger: logger}
+}
+
+// InsertEntry implements [audit_types.AuditRepository.InsertEntry]
+func (r *postgresAuditRepo) InsertEntry(ctx context.Context, entry audit_types.AuditEntry) error {
+	q := psql.Insert(
+		im.Into(auditLogTableName, "id", "actor_user_id", "action", "entity_type", "entity_id", "before", "after", "created_at"),
+		im.Values(psql.Arg(
+			entry.Id.String(),
+			actorUserIdArg(entry.ActorUserId),
+			string(entry.Action),
+			string(entry.EntityType),
+			entry.EntityId,
+			[]byte(entry.Before),
+			[]byte(entry.After),
+			time.UnixMilli(entry.CreatedAtMillis),
+		)),
+	)
+
+	if _, err := bob.Exec(ctx, db_types.ExecutorFromCtx(ctx, r.db), q); err != nil {
+		return fmt.Errorf("error with insert audit entry query, id=%v, entity_type=%v, entity_id=%v: %w",
+			entry.Id, entry.EntityType, entry.EntityId, err)
+	}
+	return nil
+}
+
+// ListEntries implements [audit_types.AuditRepository.ListEntries]
+func (r *postgresAuditRepo) ListEntries(ctx context.Context, query audit_types.AuditQuery) ([]audit_types.AuditEntry, error) {
+	mods := []bob.Mod[*dialect.SelectQuery]{
+		sm.Columns(auditEntryColumns...),
+		sm.From(auditLogTableName),
+		sm.OrderBy("created_at").Desc(),
+		sm.OrderBy("id").Desc(),
+		sm.Limit(query.Limit),
+		sm.Offset(query.Offset),
+	}
+	if actorUserId, ok := query.ActorUserId.Get(); ok {
+		mods = append(mods, sm.Where(psql.Quote("actor_user_id").EQ(psql.Arg(actorUserId.String()))))
+	}
+	if entityType, ok := query.EntityType.Get(); ok {
+		mods = append(mods, sm.Where(psql.Quote("entity_type").EQ(psql.Arg(string(entityType)))))
+	}
+	if entityId, ok := query.EntityId.Get(); ok {
+		mods = append(mods, sm.Where(psql.Quote("entity_id").EQ(psql.Arg(entityId))))
+	}
+	if from, ok := query.From.Get(); ok {
+		mods = append(mods, sm.Where(psql.Quote("created_at").GTE(psql.Arg(from))))
+	}
+	if to, ok := query.To.Get(); ok {
+		mods = append(mods, sm.Where(psql.Quote("created_at").LT(psql.Arg(to))))
+	}
+
+	results, err := bob.All(ctx, db_types.ReaderFromCtx(ctx, r.db), psql.Select(mods...), scan.StructMapper[postgresAuditEntry]())
+	if err != nil {
+		return nil, fmt.Errorf("error with list audit entries query, query=%+v: %w", query, err)
+	}
+	return lo.Map(results, func(row postgresAuditEntry, _ int) audit_types.AuditEntry { return row.toAuditEntry() }), nil
+}
+
+// actorUserIdArg is the actor_user_id column value, null when there is no actor
+func actorUserIdArg(actorUserId mo.Option[uuid.UUID]) *string {
+	if id, ok := actorUserId.Get(); ok {
+		return lo.ToPtr(id.String())
+	}
+	return nil
+}
+
+// postgresAuditEntry is the audit_log row, the sqlite repository scans into it too
+type postgresAuditEntry struct {
+	Id          uuid.UUID            `db:"id"`
+	ActorUserId mo.Option[uuid.UUID] `db:"actor_user_id"`
+	Action      string               `db:"action"`
+	EntityType  string               `db:"entity_type"`
+	EntityId    string               `db:"entity_id"`
+	Before      []byte               `db:"before"`
+	After       []byte               `db:"after"`
+	CreatedAt   time.Time            `db:"created_at"`
+}
+
+func (e postgresAuditEntry) toAuditEntry() audit_types.AuditEntry {
+	return audit_types.AuditEntry{
+		Id:              e.Id,
+		ActorUserId:     e.ActorUserId,
+		Action:          audit_types.AuditAction(e.Action),
+		EntityType:      audit_types.AuditEntityType(e.EntityType),
+		EntityId:        e.EntityId,
+		Before:          e.Before,
+		After:           e.After,
+		CreatedAtMillis: e.CreatedAt.UnixMilli(),
+	}
+}
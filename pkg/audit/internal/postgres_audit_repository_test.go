@@ -0,0 +1,43 @@
+package internal_test
+
+import (
+	"testing"
+	"time"
+
+	audit_types "github.com/nimaeskandary/go-realworld/pkg/audit/types"
+	"github.com/nimaeskandary/go-realworld/pkg/test_utils/conformance"
+	"github.com/nimaeskandary/go-realworld/pkg/test_utils/fixtures"
+	"github.com/nimaeskandary/go-realworld/pkg/test_utils/helpers"
+
+	"github.com/google/uuid"
+	"github.com/samber/mo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_PostgresAuditRepo(t *testing.T) {
+	t.Parallel()
+
+	f := fixtures.SetupStandardFixture(t)
+	conformance.AuditRepositorySuite(t, f.AuditRepo)
+
+	t.Run("audit_log", func(t *testing.T) {
+		t.Parallel()
+
+		t.Run("should reject updates and deletes", func(t *testing.T) {
+			t.Parallel()
+			entry := helpers.GenAuditEntry(audit_types.AuditEntityUser, uuid.NewString(), time.Now())
+			require.NoError(t, f.AuditRepo.InsertEntry(t.Context(), entry))
+
+			_, err := f.Db.GetDB().ExecContext(t.Context(), "UPDATE audit_log SET action = 'delete' WHERE id = '"+entry.Id.String()+"'")
+			assert.ErrorContains(t, err, "append only")
+			_, err = f.Db.GetDB().ExecContext(t.Context(), "DELETE FROM audit_log WHERE id = '"+entry.Id.String()+"'")
+			assert.ErrorContains(t, err, "append only")
+
+			entries, err := f.AuditRepo.ListEntries(t.Context(), audit_types.AuditQuery{EntityId: mo.Some(entry.EntityId), Limit: 10})
+			require.NoError(t, err)
+			require.Len(t, entries, 1)
+			assert.Equal(t, audit_types.AuditActionUpdate, entries[0].Action)
+		})
+	})
+}
@@ -0,0 +1,95 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	audit_types "github.com/nimaeskandary/go-realworld/pkg/audit/types"
+	db_types "github.com/nimaeskandary/go-realworld/pkg/database/types"
+	obs_types "github.com/nimaeskandary/go-realworld/pkg/observability/types"
+
+	"github.com/samber/lo"
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/bob/dialect/sqlite"
+	"github.com/stephenafamo/bob/dialect/sqlite/dialect"
+	"github.com/stephenafamo/bob/dialect/sqlite/im"
+	"github.com/stephenafamo/bob/dialect/sqlite/sm"
+	"github.com/stephenafamo/scan"
+)
+
+// sqliteAuditRepo is the sqlite counterpart of postgresAuditRepo. Times are written in UTC, sqlite stores them as text
+// so they only compare correctly in the same offset
+type sqliteAuditRepo struct {
+	db     db_types.RealWorldAppDb
+	logger obs_types.Logger
+}
+
+func NewSqliteAuditRepository(db db_types.RealWorldAppDb, logger obs_types.Logger) audit_types.AuditRepository {
+	return &sqliteAuditRepo{db: db, logger: logger}
+}
+
+// InsertEntry implements [audit_types.AuditRepository.InsertEntry]
+func (r *sqliteAuditRepo) InsertEntry(ctx context.Context, entry audit_types.AuditEntry) error {
+	q := sqlite.Insert(
+		im.Into(auditLogTableName, "id", "actor_user_id", "action", "entity_type", "entity_id", "before", "after", "created_at"),
+		im.Values(sqlite.Arg(
+			entry.Id.String(),
+			actorUserIdArg(entry.ActorUserId),
+			string(entry.Action),
+			string(entry.EntityType),
+			entry.EntityId,
+			jsonTextArg(entry.Before),
+			jsonTextArg(entry.After),
+			time.UnixMilli(entry.CreatedAtMillis).UTC(),
+		)),
+	)
+
+	if _, err := bob.Exec(ctx, db_types.ExecutorFromCtx(ctx, r.db), q); err != nil {
+		return fmt.Errorf("error with insert audit entry query, id=%v, entity_type=%v, entity_id=%v: %w",
+			entry.Id, entry.EntityType, entry.EntityId, err)
+	}
+	return nil
+}
+
+// ListEntries implements [audit_types.AuditRepository.ListEntries]
+func (r *sqliteAuditRepo) ListEntries(ctx context.Context, query audit_types.AuditQuery) ([]audit_types.AuditEntry, error) {
+	mods := []bob.Mod[*dialect.SelectQuery]{
+		sm.Columns(auditEntryColumns...),
+		sm.From(auditLogTableName),
+		sm.OrderBy("created_at").Desc(),
+		sm.OrderBy("id").Desc(),
+		sm.Limit(query.Limit),
+		sm.Offset(query.Offset),
+	}
+	if actorUserId, ok := query.ActorUserId.Get(); ok {
+		mods = append(mods, sm.Where(sqlite.Quote("actor_user_id").EQ(sqlite.Arg(actorUserId.String()))))
+	}
+	if entityType, ok := query.EntityType.Get(); ok {
+		mods = append(mods, sm.Where(sqlite.Quote("entity_type").EQ(sqlite.Arg(string(entityType)))))
+	}
+	if entityId, ok := query.EntityId.Get(); ok {
+		mods = append(mods, sm.Where(sqlite.Quote("entity_id").EQ(sqlite.Arg(entityId))))
+	}
+	if from, ok := query.From.Get(); ok {
+		mods = append(mods, sm.Where(sqlite.Quote("created_at").GTE(sqlite.Arg(from.UTC()))))
+	}
+	if to, ok := query.To.Get(); ok {
+		mods = append(mods, sm.Where(sqlite.Quote("created_at").LT(sqlite.Arg(to.UTC()))))
+	}
+
+	results, err := bob.All(ctx, db_types.ReaderFromCtx(ctx, r.db), sqlite.Select(mods...), scan.StructMapper[postgresAuditEntry]())
+	if err != nil {
+		return nil, fmt.Errorf("error with list audit entries query, query=%+v: %w", query, err)
+	}
+	return lo.Map(results, func(row postgresAuditEntry, _ int) audit_types.AuditEntry { return row.toAuditEntry() }), nil
+}
+
+// jsonTextArg is the value of a nullable json text column, null when there is no json
+func jsonTextArg(raw json.RawMessage) *string {
+	if raw == nil {
+		return nil
+	}
+	return lo.ToPtr(string(raw))
+}
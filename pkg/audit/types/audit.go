@@ -0,0 +1,83 @@
+package audit_types
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/mo"
+)
+
+// AuditAction is what a change did to its entity
+type AuditAction string
+
+const (
+	AuditActionCreate  AuditAction = "create"
+	AuditActionUpdate  AuditAction = "update"
+	AuditActionDelete  AuditAction = "delete"
+	AuditActionRestore AuditAction = "restore"
+)
+
+// AuditEntityType is the kind of entity a change was made to
+type AuditEntityType string
+
+const (
+	AuditEntityUser    AuditEntityType = "user"
+	AuditEntityArticle AuditEntityType = "article"
+	// AuditEntityFollow is one user following another, its id is the id of the followed user
+	AuditEntityFollow AuditEntityType = "follow"
+	// AuditEntityBlock is one user blocking another, its id is the id of the blocked user
+	AuditEntityBlock AuditEntityType = "block"
+	// AuditEntityMute is one user muting another, its id is the id of the muted user
+	AuditEntityMute AuditEntityType = "mute"
+)
+
+// AuditChange is a change to an entity to be recorded. Before and After are the audited state of the entity, each is
+// marshalled to a json object. Before is nil when the entity is created or restored, After when it is deleted
+type AuditChange struct {
+	Action     AuditAction
+	EntityType AuditEntityType
+	EntityId   string
+	Before     any
+	After      any
+}
+
+// AuditEntry is a recorded change
+type AuditEntry struct {
+	Id uuid.UUID
+	// ActorUserId is the signed in user that made the change, unset for a change made on no one's behalf, e.g. a sign
+	// up or an admin task
+	ActorUserId mo.Option[uuid.UUID]
+	Action      AuditAction
+	EntityType  AuditEntityType
+	EntityId    string
+	// Before and After are json objects of the fields that changed, with their values before and after the change.
+	// Before is nil when the entity was created or restored, After when it was deleted
+	Before          json.RawMessage
+	After           json.RawMessage
+	CreatedAtMillis int64
+}
+
+// AuditQuery filters the audit log, each filter that is set must match
+type AuditQuery struct {
+	ActorUserId mo.Option[uuid.UUID]
+	EntityType  mo.Option[AuditEntityType]
+	EntityId    mo.Option[string]
+	// From and To bound when the change was made, From is inclusive and To exclusive
+	From   mo.Option[time.Time]
+	To     mo.Option[time.Time]
+	Limit  int
+	Offset int
+}
+
+//mockery:generate: true
+type AuditLog interface {
+	// Record appends an entry for the change, made by the user signed in to ctx, if any. It is written with the
+	// transaction in ctx, so call it in the TxManager.WithinTx that makes the change, and the entry is recorded if and
+	// only if the change is. Nothing is recorded if the change leaves every field as it was
+	Record(ctx context.Context, change AuditChange) error
+	// ListEntries returns the entries matching the query, newest first, also among entries made in the same
+	// millisecond. It fails if the limit or offset is out of range
+	ListEntries(ctx context.Context, query AuditQuery) ([]AuditEntry, error)
+}
@@ -0,0 +1,14 @@
+package audit_types
+
+import (
+	"context"
+)
+
+// AuditRepository is append only, entries are never updated or deleted
+//
+//mockery:generate: true
+type AuditRepository interface {
+	InsertEntry(ctx context.Context, entry AuditEntry) error
+	// ListEntries returns the entries matching the query, newest first by created at and then by id
+	ListEntries(ctx context.Context, query AuditQuery) ([]AuditEntry, error)
+}
@@ -0,0 +1,316 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package audit_types_mocks
+
+import (
+	"context"
+
+	"github.com/nimaeskandary/go-realworld/pkg/audit/types"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockAuditLog creates a new instance of MockAuditLog. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockAuditLog(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockAuditLog {
+	mock := &MockAuditLog{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockAuditLog is an autogenerated mock type for the AuditLog type
+type MockAuditLog struct {
+	mock.Mock
+}
+
+type MockAuditLog_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockAuditLog) EXPECT() *MockAuditLog_Expecter {
+	return &MockAuditLog_Expecter{mock: &_m.Mock}
+}
+
+// ListEntries provides a mock function for the type MockAuditLog
+func (_mock *MockAuditLog) ListEntries(ctx context.Context, query audit_types.AuditQuery) ([]audit_types.AuditEntry, error) {
+	ret := _mock.Called(ctx, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListEntries")
+	}
+
+	var r0 []audit_types.AuditEntry
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, audit_types.AuditQuery) ([]audit_types.AuditEntry, error)); ok {
+		return returnFunc(ctx, query)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, audit_types.AuditQuery) []audit_types.AuditEntry); ok {
+		r0 = returnFunc(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]audit_types.AuditEntry)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, audit_types.AuditQuery) error); ok {
+		r1 = returnFunc(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAuditLog_ListEntries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListEntries'
+type MockAuditLog_ListEntries_Call struct {
+	*mock.Call
+}
+
+// ListEntries is a helper method to define mock.On call
+//   - ctx context.Context
+//   - query audit_types.AuditQuery
+func (_e *MockAuditLog_Expecter) ListEntries(ctx interface{}, query interface{}) *MockAuditLog_ListEntries_Call {
+	return &MockAuditLog_ListEntries_Call{Call: _e.mock.On("ListEntries", ctx, query)}
+}
+
+func (_c *MockAuditLog_ListEntries_Call) Run(run func(ctx context.Context, query audit_types.AuditQuery)) *MockAuditLog_ListEntries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 audit_types.AuditQuery
+		if args[1] != nil {
+			arg1 = args[1].(audit_types.AuditQuery)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuditLog_ListEntries_Call) Return(auditEntrys []audit_types.AuditEntry, err error) *MockAuditLog_ListEntries_Call {
+	_c.Call.Return(auditEntrys, err)
+	return _c
+}
+
+func (_c *MockAuditLog_ListEntries_Call) RunAndReturn(run func(ctx context.Context, query audit_types.AuditQuery) ([]audit_types.AuditEntry, error)) *MockAuditLog_ListEntries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Record provides a mock function for the type MockAuditLog
+func (_mock *MockAuditLog) Record(ctx context.Context, change audit_types.AuditChange) error {
+	ret := _mock.Called(ctx, change)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Record")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, audit_types.AuditChange) error); ok {
+		r0 = returnFunc(ctx, change)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockAuditLog_Record_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Record'
+type MockAuditLog_Record_Call struct {
+	*mock.Call
+}
+
+// Record is a helper method to define mock.On call
+//   - ctx context.Context
+//   - change audit_types.AuditChange
+func (_e *MockAuditLog_Expecter) Record(ctx interface{}, change interface{}) *MockAuditLog_Record_Call {
+	return &MockAuditLog_Record_Call{Call: _e.mock.On("Record", ctx, change)}
+}
+
+func (_c *MockAuditLog_Record_Call) Run(run func(ctx context.Context, change audit_types.AuditChange)) *MockAuditLog_Record_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 audit_types.AuditChange
+		if args[1] != nil {
+			arg1 = args[1].(audit_types.AuditChange)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuditLog_Record_Call) Return(err error) *MockAuditLog_Record_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockAuditLog_Record_Call) RunAndReturn(run func(ctx context.Context, change audit_types.AuditChange) error) *MockAuditLog_Record_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockAuditRepository creates a new instance of MockAuditRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockAuditRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockAuditRepository {
+	mock := &MockAuditRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockAuditRepository is an autogenerated mock type for the AuditRepository type
+type MockAuditRepository struct {
+	mock.Mock
+}
+
+type MockAuditRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockAuditRepository) EXPECT() *MockAuditRepository_Expecter {
+	return &MockAuditRepository_Expecter{mock: &_m.Mock}
+}
+
+// InsertEntry provides a mock function for the type MockAuditRepository
+func (_mock *MockAuditRepository) InsertEntry(ctx context.Context, entry audit_types.AuditEntry) error {
+	ret := _mock.Called(ctx, entry)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertEntry")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, audit_types.AuditEntry) error); ok {
+		r0 = returnFunc(ctx, entry)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockAuditRepository_InsertEntry_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InsertEntry'
+type MockAuditRepository_InsertEntry_Call struct {
+	*mock.Call
+}
+
+// InsertEntry is a helper method to define mock.On call
+//   - ctx context.Context
+//   - entry audit_types.AuditEntry
+func (_e *MockAuditRepository_Expecter) InsertEntry(ctx interface{}, entry interface{}) *MockAuditRepository_InsertEntry_Call {
+	return &MockAuditRepository_InsertEntry_Call{Call: _e.mock.On("InsertEntry", ctx, entry)}
+}
+
+func (_c *MockAuditRepository_InsertEntry_Call) Run(run func(ctx context.Context, entry audit_types.AuditEntry)) *MockAuditRepository_InsertEntry_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 audit_types.AuditEntry
+		if args[1] != nil {
+			arg1 = args[1].(audit_types.AuditEntry)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuditRepository_InsertEntry_Call) Return(err error) *MockAuditRepository_InsertEntry_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockAuditRepository_InsertEntry_Call) RunAndReturn(run func(ctx context.Context, entry audit_types.AuditEntry) error) *MockAuditRepository_InsertEntry_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListEntries provides a mock function for the type MockAuditRepository
+func (_mock *MockAuditRepository) ListEntries(ctx context.Context, query audit_types.AuditQuery) ([]audit_types.AuditEntry, error) {
+	ret := _mock.Called(ctx, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListEntries")
+	}
+
+	var r0 []audit_types.AuditEntry
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, audit_types.AuditQuery) ([]audit_types.AuditEntry, error)); ok {
+		return returnFunc(ctx, query)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, audit_types.AuditQuery) []audit_types.AuditEntry); ok {
+		r0 = returnFunc(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]audit_types.AuditEntry)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, audit_types.AuditQuery) error); ok {
+		r1 = returnFunc(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAuditRepository_ListEntries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListEntries'
+type MockAuditRepository_ListEntries_Call struct {
+	*mock.Call
+}
+
+// ListEntries is a helper method to define mock.On call
+//   - ctx context.Context
+//   - query audit_types.AuditQuery
+func (_e *MockAuditRepository_Expecter) ListEntries(ctx interface{}, query interface{}) *MockAuditRepository_ListEntries_Call {
+	return &MockAuditRepository_ListEntries_Call{Call: _e.mock.On("ListEntries", ctx, query)}
+}
+
+func (_c *MockAuditRepository_ListEntries_Call) Run(run func(ctx context.Context, query audit_types.AuditQuery)) *MockAuditRepository_ListEntries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 audit_types.AuditQuery
+		if args[1] != nil {
+			arg1 = args[1].(audit_types.AuditQuery)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuditRepository_ListEntries_Call) Return(auditEntrys []audit_types.AuditEntry, err error) *MockAuditRepository_ListEntries_Call {
+	_c.Call.Return(auditEntrys, err)
+	return _c
+}
+
+func (_c *MockAuditRepository_ListEntries_Call) RunAndReturn(run func(ctx context.Context, query audit_types.AuditQuery) ([]audit_types.AuditEntry, error)) *MockAuditRepository_ListEntries_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,17 @@
+package blob_store
+
+import (
+	"github.com/nimaeskandary/go-realworld/pkg/blob_store/internal"
+	blob_store_types "github.com/nimaeskandary/go-realworld/pkg/blob_store/types"
+	"github.com/nimaeskandary/go-realworld/pkg/util"
+
+	"go.uber.org/fx"
+)
+
+// NewLocalBlobStoreModule stores blobs on the local filesystem, suitable for development and single instance deployments
+func NewLocalBlobStoreModule() fx.Option {
+	return util.NewFxModule[blob_store_types.BlobStore](
+		"local_blob_store",
+		internal.NewLocalBlobStoreImpl,
+	)
+}
@@ -0,0 +1,143 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	blob_store_types "github.com/nimaeskandary/go-realworld/pkg/blob_store/types"
+
+	"github.com/samber/mo"
+)
+
+// metadata is kept in a file next to each blob, since the filesystem has nowhere else to hold the content type
+const metadataSuffix = ".meta.json"
+
+type localBlobStoreImpl struct {
+	rootDir string
+}
+
+type blobMetadata struct {
+	ContentType string `json:"content_type"`
+}
+
+func NewLocalBlobStoreImpl(cfg blob_store_types.LocalBlobStoreConfig) blob_store_types.BlobStore {
+	return &localBlobStoreImpl{rootDir: cfg.RootDir}
+}
+
+func (s *localBlobStoreImpl) Put(_ context.Context, key string, contentType string, content io.Reader) error {
+	path, err := s.pathForKey(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("error creating blob directory, key=%v: %w", key, err)
+	}
+
+	metadata, err := json.Marshal(blobMetadata{ContentType: contentType})
+	if err != nil {
+		return fmt.Errorf("error marshalling blob metadata, key=%v: %w", key, err)
+	}
+
+	// the metadata is written first, so a blob is never visible without it
+	if err := writeFileAtomic(path+metadataSuffix, strings.NewReader(string(metadata))); err != nil {
+		return fmt.Errorf("error writing blob metadata, key=%v: %w", key, err)
+	}
+	if err := writeFileAtomic(path, content); err != nil {
+		return fmt.Errorf("error writing blob, key=%v: %w", key, err)
+	}
+
+	return nil
+}
+
+func (s *localBlobStoreImpl) Get(_ context.Context, key string) (mo.Option[blob_store_types.Blob], error) {
+	path, err := s.pathForKey(key)
+	if err != nil {
+		return mo.None[blob_store_types.Blob](), err
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return mo.None[blob_store_types.Blob](), nil
+		}
+		return mo.None[blob_store_types.Blob](), fmt.Errorf("error opening blob, key=%v: %w", key, err)
+	}
+
+	blob, err := func() (blob_store_types.Blob, error) {
+		info, err := file.Stat()
+		if err != nil {
+			return blob_store_types.Blob{}, fmt.Errorf("error reading blob size: %w", err)
+		}
+
+		metadataBytes, err := os.ReadFile(path + metadataSuffix)
+		if err != nil {
+			return blob_store_types.Blob{}, fmt.Errorf("error reading blob metadata: %w", err)
+		}
+		var metadata blobMetadata
+		if err := json.Unmarshal(metadataBytes, &metadata); err != nil {
+			return blob_store_types.Blob{}, fmt.Errorf("error unmarshalling blob metadata: %w", err)
+		}
+
+		return blob_store_types.Blob{ContentType: metadata.ContentType, Size: info.Size(), Body: file}, nil
+	}()
+	if err != nil {
+		_ = file.Close()
+		return mo.None[blob_store_types.Blob](), fmt.Errorf("error getting blob, key=%v: %w", key, err)
+	}
+
+	return mo.Some(blob), nil
+}
+
+func (s *localBlobStoreImpl) Delete(_ context.Context, key string) error {
+	path, err := s.pathForKey(key)
+	if err != nil {
+		return err
+	}
+
+	// the blob is removed before its metadata, the reverse of Put
+	for _, p := range []string{path, path + metadataSuffix} {
+		if err := os.Remove(p); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("error deleting blob, key=%v: %w", key, err)
+		}
+	}
+
+	return nil
+}
+
+// pathForKey maps a key to a path under the root dir, rejecting keys that could escape it or collide with metadata files
+func (s *localBlobStoreImpl) pathForKey(key string) (string, error) {
+	local := filepath.FromSlash(key)
+	if !filepath.IsLocal(local) || strings.HasSuffix(key, metadataSuffix) {
+		return "", fmt.Errorf("invalid blob key: %v", key)
+	}
+	return filepath.Join(s.rootDir, local), nil
+}
+
+// writeFileAtomic writes to a temporary file in the same directory and renames it into place
+func writeFileAtomic(path string, content io.Reader) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("error creating temp file: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	if _, err := io.Copy(tmp, content); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("error writing temp file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("error closing temp file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("error renaming temp file: %w", err)
+	}
+	return nil
+}
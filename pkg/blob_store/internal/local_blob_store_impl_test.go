@@ -0,0 +1,95 @@
+package internal_test
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/nimaeskandary/go-realworld/pkg/blob_store/internal"
+	blob_store_types "github.com/nimaeskandary/go-realworld/pkg/blob_store/types"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_LocalBlobStoreImpl(t *testing.T) {
+	t.Parallel()
+
+	underTest := internal.NewLocalBlobStoreImpl(blob_store_types.LocalBlobStoreConfig{RootDir: t.TempDir()})
+
+	readBlob := func(t *testing.T, key string) (blob_store_types.Blob, string) {
+		opt, err := underTest.Get(t.Context(), key)
+		require.NoError(t, err)
+		blob, ok := opt.Get()
+		require.True(t, ok)
+		defer func() { _ = blob.Body.Close() }()
+		content, err := io.ReadAll(blob.Body)
+		require.NoError(t, err)
+		return blob, string(content)
+	}
+
+	t.Run("Put and Get", func(t *testing.T) {
+		t.Parallel()
+
+		t.Run("should store and return a blob with its content type", func(t *testing.T) {
+			t.Parallel()
+			err := underTest.Put(t.Context(), "images/stored.png", "image/png", strings.NewReader("png bytes"))
+			require.NoError(t, err)
+
+			blob, content := readBlob(t, "images/stored.png")
+			assert.Equal(t, "image/png", blob.ContentType)
+			assert.Equal(t, int64(len("png bytes")), blob.Size)
+			assert.Equal(t, "png bytes", content)
+		})
+
+		t.Run("should replace an existing blob", func(t *testing.T) {
+			t.Parallel()
+			require.NoError(t, underTest.Put(t.Context(), "images/replaced.png", "image/png", strings.NewReader("first")))
+			require.NoError(t, underTest.Put(t.Context(), "images/replaced.png", "image/jpeg", strings.NewReader("second")))
+
+			blob, content := readBlob(t, "images/replaced.png")
+			assert.Equal(t, "image/jpeg", blob.ContentType)
+			assert.Equal(t, "second", content)
+		})
+
+		t.Run("should return none for a missing blob", func(t *testing.T) {
+			t.Parallel()
+			opt, err := underTest.Get(t.Context(), "images/missing.png")
+			assert.NoError(t, err)
+			assert.True(t, opt.IsNone())
+		})
+
+		t.Run("should reject keys that escape the root dir or collide with metadata", func(t *testing.T) {
+			t.Parallel()
+			for _, key := range []string{"", "../escaped.png", "/absolute.png", "images/stored.png.meta.json"} {
+				err := underTest.Put(t.Context(), key, "image/png", strings.NewReader("bytes"))
+				assert.Error(t, err, "key %q", key)
+
+				_, err = underTest.Get(t.Context(), key)
+				assert.Error(t, err, "key %q", key)
+			}
+		})
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		t.Parallel()
+
+		t.Run("should delete a blob", func(t *testing.T) {
+			t.Parallel()
+			require.NoError(t, underTest.Put(t.Context(), "images/deleted.png", "image/png", strings.NewReader("bytes")))
+
+			err := underTest.Delete(t.Context(), "images/deleted.png")
+			assert.NoError(t, err)
+
+			opt, err := underTest.Get(t.Context(), "images/deleted.png")
+			assert.NoError(t, err)
+			assert.True(t, opt.IsNone())
+		})
+
+		t.Run("should be a no-op for a missing blob", func(t *testing.T) {
+			t.Parallel()
+			err := underTest.Delete(t.Context(), "images/never-stored.png")
+			assert.NoError(t, err)
+		})
+	})
+}
@@ -0,0 +1,27 @@
+package blob_store_types
+
+import (
+	"context"
+	"io"
+
+	"github.com/samber/mo"
+)
+
+type Blob struct {
+	ContentType string
+	Size        int64
+	// Body must be closed by the caller
+	Body io.ReadCloser
+}
+
+// BlobStore stores opaque binary content under slash separated keys, e.g. "avatars/<id>.png"
+//
+//mockery:generate: true
+type BlobStore interface {
+	// Put stores the content under key, replacing any blob already stored there. Readers never see a partially written blob
+	Put(ctx context.Context, key string, contentType string, content io.Reader) error
+	// Get returns none if there is no blob stored under key
+	Get(ctx context.Context, key string) (mo.Option[Blob], error)
+	// Delete is a no-op if there is no blob stored under key
+	Delete(ctx context.Context, key string) error
+}
@@ -0,0 +1,6 @@
+package blob_store_types
+
+type LocalBlobStoreConfig struct {
+	// RootDir is the directory blobs are stored under, it is created if it does not exist
+	RootDir string `json:"root_dir" validate:"required"`
+}
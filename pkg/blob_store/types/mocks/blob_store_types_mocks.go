@@ -0,0 +1,233 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package blob_store_types_mocks
+
+import (
+	"context"
+	"io"
+
+	"github.com/nimaeskandary/go-realworld/pkg/blob_store/types"
+	"github.com/samber/mo"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockBlobStore creates a new instance of MockBlobStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockBlobStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockBlobStore {
+	mock := &MockBlobStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockBlobStore is an autogenerated mock type for the BlobStore type
+type MockBlobStore struct {
+	mock.Mock
+}
+
+type MockBlobStore_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockBlobStore) EXPECT() *MockBlobStore_Expecter {
+	return &MockBlobStore_Expecter{mock: &_m.Mock}
+}
+
+// Delete provides a mock function for the type MockBlobStore
+func (_mock *MockBlobStore) Delete(ctx context.Context, key string) error {
+	ret := _mock.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, key)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockBlobStore_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockBlobStore_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+func (_e *MockBlobStore_Expecter) Delete(ctx interface{}, key interface{}) *MockBlobStore_Delete_Call {
+	return &MockBlobStore_Delete_Call{Call: _e.mock.On("Delete", ctx, key)}
+}
+
+func (_c *MockBlobStore_Delete_Call) Run(run func(ctx context.Context, key string)) *MockBlobStore_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockBlobStore_Delete_Call) Return(err error) *MockBlobStore_Delete_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockBlobStore_Delete_Call) RunAndReturn(run func(ctx context.Context, key string) error) *MockBlobStore_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Get provides a mock function for the type MockBlobStore
+func (_mock *MockBlobStore) Get(ctx context.Context, key string) (mo.Option[blob_store_types.Blob], error) {
+	ret := _mock.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 mo.Option[blob_store_types.Blob]
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (mo.Option[blob_store_types.Blob], error)); ok {
+		return returnFunc(ctx, key)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) mo.Option[blob_store_types.Blob]); ok {
+		r0 = returnFunc(ctx, key)
+	} else {
+		r0 = ret.Get(0).(mo.Option[blob_store_types.Blob])
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockBlobStore_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type MockBlobStore_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+func (_e *MockBlobStore_Expecter) Get(ctx interface{}, key interface{}) *MockBlobStore_Get_Call {
+	return &MockBlobStore_Get_Call{Call: _e.mock.On("Get", ctx, key)}
+}
+
+func (_c *MockBlobStore_Get_Call) Run(run func(ctx context.Context, key string)) *MockBlobStore_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockBlobStore_Get_Call) Return(option mo.Option[blob_store_types.Blob], err error) *MockBlobStore_Get_Call {
+	_c.Call.Return(option, err)
+	return _c
+}
+
+func (_c *MockBlobStore_Get_Call) RunAndReturn(run func(ctx context.Context, key string) (mo.Option[blob_store_types.Blob], error)) *MockBlobStore_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Put provides a mock function for the type MockBlobStore
+func (_mock *MockBlobStore) Put(ctx context.Context, key string, contentType string, content io.Reader) error {
+	ret := _mock.Called(ctx, key, contentType, content)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Put")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, io.Reader) error); ok {
+		r0 = returnFunc(ctx, key, contentType, content)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockBlobStore_Put_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Put'
+type MockBlobStore_Put_Call struct {
+	*mock.Call
+}
+
+// Put is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - contentType string
+//   - content io.Reader
+func (_e *MockBlobStore_Expecter) Put(ctx interface{}, key interface{}, contentType interface{}, content interface{}) *MockBlobStore_Put_Call {
+	return &MockBlobStore_Put_Call{Call: _e.mock.On("Put", ctx, key, contentType, content)}
+}
+
+func (_c *MockBlobStore_Put_Call) Run(run func(ctx context.Context, key string, contentType string, content io.Reader)) *MockBlobStore_Put_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 io.Reader
+		if args[3] != nil {
+			arg3 = args[3].(io.Reader)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockBlobStore_Put_Call) Return(err error) *MockBlobStore_Put_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockBlobStore_Put_Call) RunAndReturn(run func(ctx context.Context, key string, contentType string, content io.Reader) error) *MockBlobStore_Put_Call {
+	_c.Call.Return(run)
+	return _c
+}
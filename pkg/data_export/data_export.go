@@ -0,0 +1,26 @@
+package data_export
+
+import (
+	"github.com/nimaeskandary/go-realworld/pkg/data_export/internal"
+	data_export_types "github.com/nimaeskandary/go-realworld/pkg/data_export/types"
+	"github.com/nimaeskandary/go-realworld/pkg/util"
+
+	"go.uber.org/fx"
+)
+
+func NewDataExportModule() fx.Option {
+	return newDataExportModule(internal.NewDataExportRepository)
+}
+
+// NewInMemoryDataExportModule is NewDataExportModule with the repository reading a RealWorldAppInMemoryDb
+func NewInMemoryDataExportModule() fx.Option {
+	return newDataExportModule(internal.NewInMemoryDataExportRepository)
+}
+
+func newDataExportModule(repositoryConstructor any) fx.Option {
+	return util.NewFxModule[data_export_types.DataExportService](
+		"data_export_service",
+		internal.NewDataExportServiceImpl,
+		fx.Provide(repositoryConstructor),
+	)
+}
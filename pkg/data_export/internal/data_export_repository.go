@@ -0,0 +1,21 @@
+package internal
+
+import (
+	"fmt"
+
+	data_export_types "github.com/nimaeskandary/go-realworld/pkg/data_export/types"
+	db_types "github.com/nimaeskandary/go-realworld/pkg/database/types"
+	obs_types "github.com/nimaeskandary/go-realworld/pkg/observability/types"
+)
+
+// NewDataExportRepository returns the repository for the database's dialect
+func NewDataExportRepository(db db_types.RealWorldAppDb, logger obs_types.Logger) (data_export_types.DataExportRepository, error) {
+	switch db.GetDialect() {
+	case db_types.DialectPostgres:
+		return NewPostgresDataExportRepository(db, logger), nil
+	case db_types.DialectSqlite:
+		return NewSqliteDataExportRepository(db, logger), nil
+	default:
+		return nil, fmt.Errorf("no data export repository for dialect: %v", db.GetDialect())
+	}
+}
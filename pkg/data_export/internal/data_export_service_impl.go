@@ -0,0 +1,135 @@
+package internal
+
+import (
+	"archive/zip"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	data_export_types "github.com/nimaeskandary/go-realworld/pkg/data_export/types"
+	db_types "github.com/nimaeskandary/go-realworld/pkg/database/types"
+	user_types "github.com/nimaeskandary/go-realworld/pkg/user/types"
+)
+
+const (
+	profileFileName   = "profile.json"
+	articlesFileName  = "articles.json"
+	favoritesFileName = "favorites.json"
+	followsFileName   = "follows.json"
+)
+
+// the archive is read in a single snapshot, so e.g. an article deleted part way through does not leave a dangling favorite
+var exportTxOptions = sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true}
+
+type dataExportServiceImpl struct {
+	exportRepo data_export_types.DataExportRepository
+	txManager  db_types.TxManager
+}
+
+func NewDataExportServiceImpl(exportRepo data_export_types.DataExportRepository, txManager db_types.TxManager) data_export_types.DataExportService {
+	return &dataExportServiceImpl{exportRepo: exportRepo, txManager: txManager}
+}
+
+func (s *dataExportServiceImpl) ExportUserData(ctx context.Context, user user_types.User, w io.Writer) user_types.DomainError {
+	archive := zip.NewWriter(w)
+
+	err := s.txManager.WithinTxOptions(ctx, exportTxOptions, func(ctx context.Context) error {
+		return s.writeArchive(ctx, archive, user)
+	})
+	if err != nil {
+		return user_types.AsDomainError(err)
+	}
+
+	if err := archive.Close(); err != nil {
+		return user_types.AsDomainError(fmt.Errorf("error closing export archive: %w", err))
+	}
+
+	return nil
+}
+
+func (s *dataExportServiceImpl) writeArchive(ctx context.Context, archive *zip.Writer, user user_types.User) error {
+	profile, err := createFile(archive, profileFileName)
+	if err != nil {
+		return err
+	}
+	err = json.NewEncoder(profile).Encode(data_export_types.ExportedProfile{
+		Id:        user.Id,
+		Username:  user.Username,
+		Email:     user.Email,
+		Bio:       user.Bio.ToPointer(),
+		Image:     user.Image.ToPointer(),
+		CreatedAt: time.UnixMilli(user.CreatedAtMillis).UTC(),
+		UpdatedAt: time.UnixMilli(user.UpdatedAtMillis).UTC(),
+	})
+	if err != nil {
+		return fmt.Errorf("error writing %v: %w", profileFileName, err)
+	}
+
+	err = writeJsonArrayFile(archive, articlesFileName, func(fn func(data_export_types.ExportedArticle) error) error {
+		return s.exportRepo.StreamArticles(ctx, user.Id, fn)
+	})
+	if err != nil {
+		return err
+	}
+
+	err = writeJsonArrayFile(archive, favoritesFileName, func(fn func(data_export_types.ExportedFavorite) error) error {
+		return s.exportRepo.StreamFavorites(ctx, user.Id, fn)
+	})
+	if err != nil {
+		return err
+	}
+
+	return writeJsonArrayFile(archive, followsFileName, func(fn func(data_export_types.ExportedFollow) error) error {
+		return s.exportRepo.StreamFollows(ctx, user.Id, fn)
+	})
+}
+
+func createFile(archive *zip.Writer, name string) (io.Writer, error) {
+	f, err := archive.Create(name)
+	if err != nil {
+		return nil, fmt.Errorf("error creating %v in export archive: %w", name, err)
+	}
+	return f, nil
+}
+
+// writeJsonArrayFile writes each element produced by stream into a JSON array file, one element at a time,
+// so the full array is never held in memory
+func writeJsonArrayFile[T any](archive *zip.Writer, name string, stream func(fn func(T) error) error) error {
+	f, err := createFile(archive, name)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.WriteString(f, "["); err != nil {
+		return fmt.Errorf("error writing %v: %w", name, err)
+	}
+
+	first := true
+	err = stream(func(element T) error {
+		if !first {
+			if _, err := io.WriteString(f, ","); err != nil {
+				return err
+			}
+		}
+		first = false
+
+		data, err := json.Marshal(element)
+		if err != nil {
+			return err
+		}
+		_, err = f.Write(data)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("error writing %v: %w", name, err)
+	}
+
+	if _, err := io.WriteString(f, "]"); err != nil {
+		return fmt.Errorf("error writing %v: %w", name, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,133 @@
+package internal_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/nimaeskandary/go-realworld/pkg/data_export/internal"
+	data_export_types "github.com/nimaeskandary/go-realworld/pkg/data_export/types"
+	data_export_types_mocks "github.com/nimaeskandary/go-realworld/pkg/data_export/types/mocks"
+	db_types_mocks "github.com/nimaeskandary/go-realworld/pkg/database/types/mocks"
+	"github.com/nimaeskandary/go-realworld/pkg/test_utils/helpers"
+	user_types "github.com/nimaeskandary/go-realworld/pkg/user/types"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_DataExportServiceImpl(t *testing.T) {
+	t.Parallel()
+
+	type testFixture struct {
+		exportRepoMock *data_export_types_mocks.MockDataExportRepository
+		underTest      data_export_types.DataExportService
+	}
+
+	setup := func(t *testing.T) testFixture {
+		exportRepoMock := data_export_types_mocks.NewMockDataExportRepository(t)
+		txManagerMock := db_types_mocks.NewMockTxManager(t)
+		// the export must read a consistent snapshot
+		txManagerMock.EXPECT().
+			WithinTxOptions(mock.Anything, sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true}, mock.Anything).
+			RunAndReturn(func(ctx context.Context, _ sql.TxOptions, fn func(ctx context.Context) error) error {
+				return fn(ctx)
+			})
+		return testFixture{
+			exportRepoMock: exportRepoMock,
+			underTest:      internal.NewDataExportServiceImpl(exportRepoMock, txManagerMock),
+		}
+	}
+
+	readArchive := func(t *testing.T, data []byte) map[string][]byte {
+		reader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+		require.NoError(t, err)
+
+		files := map[string][]byte{}
+		for _, f := range reader.File {
+			rc, err := f.Open()
+			require.NoError(t, err)
+			content, err := io.ReadAll(rc)
+			require.NoError(t, err)
+			_ = rc.Close()
+			files[f.Name] = content
+		}
+		return files
+	}
+
+	t.Run("ExportUserData", func(t *testing.T) {
+		t.Parallel()
+		user := helpers.GenUser()
+
+		t.Run("should write all files to the archive", func(t *testing.T) {
+			t.Parallel()
+			f := setup(t)
+
+			article := data_export_types.ExportedArticle{
+				Id:        uuid.New(),
+				Data:      json.RawMessage(`{"title":"a","body":"b"}`),
+				Tags:      []string{"go", "sql"},
+				CreatedAt: time.UnixMilli(1000).UTC(),
+				UpdatedAt: time.UnixMilli(2000).UTC(),
+			}
+			favorites := []data_export_types.ExportedFavorite{
+				data_export_types.ExportedFavorite{ArticleId: uuid.New(), CreatedAt: time.UnixMilli(1000).UTC()},
+				data_export_types.ExportedFavorite{ArticleId: uuid.New(), CreatedAt: time.UnixMilli(2000).UTC()},
+			}
+
+			f.exportRepoMock.EXPECT().StreamArticles(mock.Anything, user.Id, mock.Anything).RunAndReturn(streamRows(article))
+			f.exportRepoMock.EXPECT().StreamFavorites(mock.Anything, user.Id, mock.Anything).RunAndReturn(streamRows(favorites...))
+			f.exportRepoMock.EXPECT().StreamFollows(mock.Anything, user.Id, mock.Anything).RunAndReturn(streamRows[data_export_types.ExportedFollow]())
+
+			var out bytes.Buffer
+			err := f.underTest.ExportUserData(t.Context(), user, &out)
+			require.NoError(t, err)
+
+			files := readArchive(t, out.Bytes())
+
+			var profile data_export_types.ExportedProfile
+			require.NoError(t, json.Unmarshal(files["profile.json"], &profile))
+			assert.Equal(t, user.Username, profile.Username)
+			assert.Equal(t, user.Email, profile.Email)
+
+			var articles []data_export_types.ExportedArticle
+			require.NoError(t, json.Unmarshal(files["articles.json"], &articles))
+			assert.Equal(t, []data_export_types.ExportedArticle{article}, articles)
+
+			var exportedFavorites []data_export_types.ExportedFavorite
+			require.NoError(t, json.Unmarshal(files["favorites.json"], &exportedFavorites))
+			assert.Len(t, exportedFavorites, 2)
+
+			assert.JSONEq(t, "[]", string(files["follows.json"]))
+		})
+
+		t.Run("should return UnknownError if streaming fails", func(t *testing.T) {
+			t.Parallel()
+			f := setup(t)
+			f.exportRepoMock.EXPECT().StreamArticles(mock.Anything, user.Id, mock.Anything).Return(errors.New("query failure"))
+
+			err := f.underTest.ExportUserData(t.Context(), user, io.Discard)
+			assert.IsType(t, user_types.UnknownError{}, err)
+		})
+	})
+}
+
+// streamRows returns a mock implementation of a Stream repository method that calls fn for each of the given rows
+func streamRows[T any](rows ...T) func(ctx context.Context, userId uuid.UUID, fn func(T) error) error {
+	return func(_ context.Context, _ uuid.UUID, fn func(T) error) error {
+		for _, row := range rows {
+			if err := fn(row); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,119 @@
+package internal
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+
+	data_export_types "github.com/nimaeskandary/go-realworld/pkg/data_export/types"
+	db_types "github.com/nimaeskandary/go-realworld/pkg/database/types"
+
+	"github.com/google/uuid"
+)
+
+// inMemoryDataExportRepo is the in memory counterpart of postgresDataExportRepo. Rows are copied out under the db's
+// lock and fn is called after it is released, so fn is free to use other repositories
+type inMemoryDataExportRepo struct {
+	db db_types.RealWorldAppInMemoryDb
+}
+
+func NewInMemoryDataExportRepository(db db_types.RealWorldAppInMemoryDb) data_export_types.DataExportRepository {
+	return &inMemoryDataExportRepo{db: db}
+}
+
+// StreamArticles implements [data_export_types.DataExportRepository.StreamArticles].
+// Soft deleted articles are included, as they are still held until purged.
+func (r *inMemoryDataExportRepo) StreamArticles(ctx context.Context, authorUserId uuid.UUID, fn func(data_export_types.ExportedArticle) error) error {
+	var rows []data_export_types.ExportedArticle
+	err := r.db.Read(ctx, func(tables *db_types.InMemoryTables) error {
+		for _, article := range tables.Articles {
+			if article.AuthorUserId != authorUserId {
+				continue
+			}
+
+			tags := []string{}
+			for tag := range tables.ArticleTags {
+				if tag.ArticleId == article.Id {
+					tags = append(tags, tag.Tag)
+				}
+			}
+			slices.Sort(tags)
+
+			rows = append(rows, data_export_types.ExportedArticle{
+				Id:        article.Id,
+				Data:      article.Data,
+				Tags:      tags,
+				CreatedAt: article.CreatedAt,
+				UpdatedAt: article.UpdatedAt,
+				DeletedAt: article.DeletedAt.ToPointer(),
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	slices.SortFunc(rows, func(a, b data_export_types.ExportedArticle) int { return a.CreatedAt.Compare(b.CreatedAt) })
+	return forEach(rows, fn)
+}
+
+// StreamFavorites implements [data_export_types.DataExportRepository.StreamFavorites].
+func (r *inMemoryDataExportRepo) StreamFavorites(ctx context.Context, userId uuid.UUID, fn func(data_export_types.ExportedFavorite) error) error {
+	var rows []data_export_types.ExportedFavorite
+	err := r.db.Read(ctx, func(tables *db_types.InMemoryTables) error {
+		for favorite, createdAt := range tables.ArticleFavorites {
+			if favorite.UserId == userId {
+				rows = append(rows, data_export_types.ExportedFavorite{ArticleId: favorite.ArticleId, CreatedAt: createdAt})
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	slices.SortFunc(rows, func(a, b data_export_types.ExportedFavorite) int { return a.CreatedAt.Compare(b.CreatedAt) })
+	return forEach(rows, fn)
+}
+
+// StreamFollows implements [data_export_types.DataExportRepository.StreamFollows].
+func (r *inMemoryDataExportRepo) StreamFollows(ctx context.Context, userId uuid.UUID, fn func(data_export_types.ExportedFollow) error) error {
+	var rows []data_export_types.ExportedFollow
+	err := r.db.Read(ctx, func(tables *db_types.InMemoryTables) error {
+		add := func(direction data_export_types.FollowDirection, otherUserId uuid.UUID, createdAt time.Time) {
+			if other, ok := tables.Users[otherUserId]; ok {
+				rows = append(rows, data_export_types.ExportedFollow{Direction: direction, Username: other.Username, CreatedAt: createdAt})
+			}
+		}
+
+		for pair, createdAt := range tables.UserFollowers {
+			if pair.From == userId {
+				add(data_export_types.FollowDirectionFollowing, pair.To, createdAt)
+			}
+			if pair.To == userId {
+				add(data_export_types.FollowDirectionFollower, pair.From, createdAt)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	slices.SortFunc(rows, func(a, b data_export_types.ExportedFollow) int {
+		return cmp.Or(a.CreatedAt.Compare(b.CreatedAt), cmp.Compare(a.Username, b.Username))
+	})
+	return forEach(rows, fn)
+}
+
+// forEach calls fn for each row, stopping at the first error
+func forEach[T any](rows []T, fn func(T) error) error {
+	for _, row := range rows {
+		if err := fn(row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
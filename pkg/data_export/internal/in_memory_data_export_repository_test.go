@@ -0,0 +1,15 @@
+package internal_test
+
+import (
+	"testing"
+
+	"github.com/nimaeskandary/go-realworld/pkg/test_utils/conformance"
+	"github.com/nimaeskandary/go-realworld/pkg/test_utils/fixtures"
+)
+
+func Test_InMemoryDataExportRepository(t *testing.T) {
+	t.Parallel()
+
+	f := fixtures.SetupInMemoryFixture(t)
+	conformance.DataExportRepositorySuite(t, f.UserRepo, f.ArticleRepo, f.DataExportRepo)
+}
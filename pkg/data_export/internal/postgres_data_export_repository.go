@@ -0,0 +1,188 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	data_export_types "github.com/nimaeskandary/go-realworld/pkg/data_export/types"
+	db_types "github.com/nimaeskandary/go-realworld/pkg/database/types"
+	obs_types "github.com/nimaeskandary/go-realworld/pkg/observability/types"
+
+	"github.com/google/uuid"
+	"github.com/samber/mo"
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/bob/dialect/psql"
+	"github.com/stephenafamo/bob/dialect/psql/dialect"
+	"github.com/stephenafamo/bob/dialect/psql/sm"
+	"github.com/stephenafamo/scan"
+)
+
+const (
+	articlesTableName         = "articles"
+	articleTagsTableName      = "article_tags"
+	articleFavoritesTableName = "article_favorites"
+	followersTableName        = "user_followers"
+	usersTableName            = "users"
+)
+
+type postgresDataExportRepo struct {
+	db     db_types.RealWorldAppDb
+	logger obs_types.Logger
+}
+
+func NewPostgresDataExportRepository(db db_types.RealWorldAppDb, logger obs_types.Logger) data_export_types.DataExportRepository {
+	return &postgresDataExportRepo{db: db, logger: logger}
+}
+
+// StreamArticles implements [data_export_types.DataExportRepository.StreamArticles].
+// Soft deleted articles are included, as they are still held until purged.
+func (r *postgresDataExportRepo) StreamArticles(ctx context.Context, authorUserId uuid.UUID, fn func(data_export_types.ExportedArticle) error) error {
+	tags := psql.F("COALESCE",
+		psql.Group(psql.Select(
+			sm.Columns(psql.Raw("json_agg(tag ORDER BY tag)")),
+			sm.From(articleTagsTableName),
+			sm.Where(psql.Quote(articleTagsTableName, "article_id").EQ(psql.Quote(articlesTableName, "id"))),
+		)),
+		psql.Raw("'[]'::json"),
+	)
+
+	q := psql.Select(
+		sm.Columns("id", "data", psql.Group(tags).As("tags"), "created_at", "updated_at", "deleted_at"),
+		sm.From(articlesTableName),
+		sm.Where(psql.Quote("author_user_id").EQ(psql.Arg(authorUserId.String()))),
+		sm.OrderBy("created_at"),
+	)
+
+	err := streamRows(ctx, r.db, q, scan.StructMapper[postgresExportedArticle](), func(row postgresExportedArticle) error {
+		var tags []string
+		if err := json.Unmarshal(row.Tags, &tags); err != nil {
+			return fmt.Errorf("error unmarshalling article tags, article_id=%v: %w", row.Id, err)
+		}
+
+		return fn(data_export_types.ExportedArticle{
+			Id:        row.Id,
+			Data:      row.Data,
+			Tags:      tags,
+			CreatedAt: row.CreatedAt,
+			UpdatedAt: row.UpdatedAt,
+			DeletedAt: row.DeletedAt.ToPointer(),
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("error streaming articles, author_user_id=%v: %w", authorUserId, err)
+	}
+
+	return nil
+}
+
+// StreamFavorites implements [data_export_types.DataExportRepository.StreamFavorites].
+func (r *postgresDataExportRepo) StreamFavorites(ctx context.Context, userId uuid.UUID, fn func(data_export_types.ExportedFavorite) error) error {
+	q := psql.Select(
+		sm.Columns("article_id", "created_at"),
+		sm.From(articleFavoritesTableName),
+		sm.Where(psql.Quote("user_id").EQ(psql.Arg(userId.String()))),
+		sm.OrderBy("created_at"),
+	)
+
+	err := streamRows(ctx, r.db, q, scan.StructMapper[postgresExportedFavorite](), func(row postgresExportedFavorite) error {
+		return fn(data_export_types.ExportedFavorite{
+			ArticleId: row.ArticleId,
+			CreatedAt: row.CreatedAt,
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("error streaming favorites, user_id=%v: %w", userId, err)
+	}
+
+	return nil
+}
+
+// StreamFollows implements [data_export_types.DataExportRepository.StreamFollows].
+func (r *postgresDataExportRepo) StreamFollows(ctx context.Context, userId uuid.UUID, fn func(data_export_types.ExportedFollow) error) error {
+	// userCol is the column matching the user, otherUserCol is joined to get the other user's username
+	follows := func(direction data_export_types.FollowDirection, userCol string, otherUserCol string) []bob.Mod[*dialect.SelectQuery] {
+		return []bob.Mod[*dialect.SelectQuery]{
+			sm.Columns(
+				psql.S(string(direction)).As("direction"),
+				psql.Quote(usersTableName, "username").As("username"),
+				psql.Quote(followersTableName, "created_at").As("created_at"),
+			),
+			sm.From(followersTableName),
+			sm.InnerJoin(usersTableName).On(psql.Quote(usersTableName, "id").EQ(psql.Quote(followersTableName, otherUserCol))),
+			sm.Where(psql.Quote(followersTableName, userCol).EQ(psql.Arg(userId.String()))),
+		}
+	}
+
+	both := psql.Select(append(
+		follows(data_export_types.FollowDirectionFollowing, "followed_by_user_id", "following_user_id"),
+		sm.UnionAll(psql.Select(follows(data_export_types.FollowDirectionFollower, "following_user_id", "followed_by_user_id")...)),
+	)...)
+
+	// wrapped so the ordering applies to the whole union
+	q := psql.Select(
+		sm.Columns("*"),
+		sm.From(psql.Group(both)).As("follows"),
+		sm.OrderBy("created_at"),
+	)
+
+	err := streamRows(ctx, r.db, q, scan.StructMapper[postgresExportedFollow](), func(row postgresExportedFollow) error {
+		return fn(data_export_types.ExportedFollow{
+			Direction: data_export_types.FollowDirection(row.Direction),
+			Username:  row.Username,
+			CreatedAt: row.CreatedAt,
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("error streaming follows, user_id=%v: %w", userId, err)
+	}
+
+	return nil
+}
+
+// streamRows runs the query with a cursor, calling fn for each row as it is read
+func streamRows[T any](ctx context.Context, db db_types.SQLDatabase, q bob.Query, m scan.Mapper[T], fn func(T) error) error {
+	cursor, err := bob.Cursor(ctx, db_types.ReaderFromCtx(ctx, db), q, m)
+	if err != nil {
+		return fmt.Errorf("error executing query: %w", err)
+	}
+	defer func() { _ = cursor.Close() }()
+
+	for cursor.Next() {
+		row, err := cursor.Get()
+		if err != nil {
+			return fmt.Errorf("error scanning row: %w", err)
+		}
+		if err := fn(row); err != nil {
+			return err
+		}
+	}
+
+	if err := cursor.Err(); err != nil {
+		return fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return nil
+}
+
+// the export row types are shared with the sqlite repository
+type postgresExportedArticle struct {
+	Id        uuid.UUID            `db:"id"`
+	Data      []byte               `db:"data"`
+	Tags      []byte               `db:"tags"`
+	CreatedAt time.Time            `db:"created_at"`
+	UpdatedAt time.Time            `db:"updated_at"`
+	DeletedAt mo.Option[time.Time] `db:"deleted_at"`
+}
+
+type postgresExportedFavorite struct {
+	ArticleId uuid.UUID `db:"article_id"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+type postgresExportedFollow struct {
+	Direction string    `db:"direction"`
+	Username  string    `db:"username"`
+	CreatedAt time.Time `db:"created_at"`
+}
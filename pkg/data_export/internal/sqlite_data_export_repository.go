@@ -0,0 +1,130 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	data_export_types "github.com/nimaeskandary/go-realworld/pkg/data_export/types"
+	db_types "github.com/nimaeskandary/go-realworld/pkg/database/types"
+	obs_types "github.com/nimaeskandary/go-realworld/pkg/observability/types"
+
+	"github.com/google/uuid"
+	"github.com/stephenafamo/bob/dialect/sqlite"
+	"github.com/stephenafamo/bob/dialect/sqlite/sm"
+	"github.com/stephenafamo/scan"
+)
+
+// sqliteDataExportRepo is the sqlite counterpart of postgresDataExportRepo
+type sqliteDataExportRepo struct {
+	db     db_types.RealWorldAppDb
+	logger obs_types.Logger
+}
+
+func NewSqliteDataExportRepository(db db_types.RealWorldAppDb, logger obs_types.Logger) data_export_types.DataExportRepository {
+	return &sqliteDataExportRepo{db: db, logger: logger}
+}
+
+// StreamArticles implements [data_export_types.DataExportRepository.StreamArticles].
+// Soft deleted articles are included, as they are still held until purged.
+func (r *sqliteDataExportRepo) StreamArticles(ctx context.Context, authorUserId uuid.UUID, fn func(data_export_types.ExportedArticle) error) error {
+	tags := sqlite.F("COALESCE",
+		sqlite.Group(sqlite.Select(
+			sm.Columns(sqlite.Raw("json_group_array(tag ORDER BY tag)")),
+			sm.From(articleTagsTableName),
+			sm.Where(sqlite.Quote(articleTagsTableName, "article_id").EQ(sqlite.Quote(articlesTableName, "id"))),
+		)),
+		sqlite.S("[]"),
+	)
+
+	q := sqlite.Select(
+		sm.Columns("id", "data", sqlite.Group(tags).As("tags"), "created_at", "updated_at", "deleted_at"),
+		sm.From(articlesTableName),
+		sm.Where(sqlite.Quote("author_user_id").EQ(sqlite.Arg(authorUserId.String()))),
+		sm.OrderBy("created_at"),
+	)
+
+	err := streamRows(ctx, r.db, q, scan.StructMapper[postgresExportedArticle](), func(row postgresExportedArticle) error {
+		var tags []string
+		if err := json.Unmarshal(row.Tags, &tags); err != nil {
+			return fmt.Errorf("error unmarshalling article tags, article_id=%v: %w", row.Id, err)
+		}
+
+		return fn(data_export_types.ExportedArticle{
+			Id:        row.Id,
+			Data:      row.Data,
+			Tags:      tags,
+			CreatedAt: row.CreatedAt,
+			UpdatedAt: row.UpdatedAt,
+			DeletedAt: row.DeletedAt.ToPointer(),
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("error streaming articles, author_user_id=%v: %w", authorUserId, err)
+	}
+
+	return nil
+}
+
+// StreamFavorites implements [data_export_types.DataExportRepository.StreamFavorites].
+func (r *sqliteDataExportRepo) StreamFavorites(ctx context.Context, userId uuid.UUID, fn func(data_export_types.ExportedFavorite) error) error {
+	q := sqlite.Select(
+		sm.Columns("article_id", "created_at"),
+		sm.From(articleFavoritesTableName),
+		sm.Where(sqlite.Quote("user_id").EQ(sqlite.Arg(userId.String()))),
+		sm.OrderBy("created_at"),
+	)
+
+	err := streamRows(ctx, r.db, q, scan.StructMapper[postgresExportedFavorite](), func(row postgresExportedFavorite) error {
+		return fn(data_export_types.ExportedFavorite{
+			ArticleId: row.ArticleId,
+			CreatedAt: row.CreatedAt,
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("error streaming favorites, user_id=%v: %w", userId, err)
+	}
+
+	return nil
+}
+
+// StreamFollows implements [data_export_types.DataExportRepository.StreamFollows].
+func (r *sqliteDataExportRepo) StreamFollows(ctx context.Context, userId uuid.UUID, fn func(data_export_types.ExportedFollow) error) error {
+	// bob parenthesizes the right side of a UNION, which sqlite rejects, so both directions are read in one pass and
+	// told apart by which side of the follow the user is on. Self follows are not allowed, so it is never both
+	isFollowing := sqlite.Quote(followersTableName, "followed_by_user_id").EQ(sqlite.Arg(userId.String()))
+	otherUserId := sqlite.Case().
+		When(isFollowing, sqlite.Quote(followersTableName, "following_user_id")).
+		Else(sqlite.Quote(followersTableName, "followed_by_user_id"))
+
+	q := sqlite.Select(
+		sm.Columns(
+			sqlite.Case().
+				When(isFollowing, sqlite.S(string(data_export_types.FollowDirectionFollowing))).
+				Else(sqlite.S(string(data_export_types.FollowDirectionFollower))).
+				As("direction"),
+			sqlite.Quote(usersTableName, "username").As("username"),
+			sqlite.Quote(followersTableName, "created_at").As("created_at"),
+		),
+		sm.From(followersTableName),
+		sm.InnerJoin(usersTableName).On(sqlite.Quote(usersTableName, "id").EQ(otherUserId)),
+		sm.Where(sqlite.Or(
+			isFollowing,
+			sqlite.Quote(followersTableName, "following_user_id").EQ(sqlite.Arg(userId.String())),
+		)),
+		sm.OrderBy(sqlite.Quote(followersTableName, "created_at")),
+	)
+
+	err := streamRows(ctx, r.db, q, scan.StructMapper[postgresExportedFollow](), func(row postgresExportedFollow) error {
+		return fn(data_export_types.ExportedFollow{
+			Direction: data_export_types.FollowDirection(row.Direction),
+			Username:  row.Username,
+			CreatedAt: row.CreatedAt,
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("error streaming follows, user_id=%v: %w", userId, err)
+	}
+
+	return nil
+}
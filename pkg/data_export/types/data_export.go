@@ -0,0 +1,50 @@
+package data_export_types
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ExportedProfile is the user's own account data
+type ExportedProfile struct {
+	Id        uuid.UUID `json:"id"`
+	Username  string    `json:"username"`
+	Email     string    `json:"email"`
+	Bio       *string   `json:"bio"`
+	Image     *string   `json:"image"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ExportedArticle is an article authored by the user, Data is the stored article document as is, so
+// every field held, including the body, is exported
+type ExportedArticle struct {
+	Id        uuid.UUID       `json:"id"`
+	Data      json.RawMessage `json:"data"`
+	Tags      []string        `json:"tags"`
+	CreatedAt time.Time       `json:"created_at"`
+	UpdatedAt time.Time       `json:"updated_at"`
+	DeletedAt *time.Time      `json:"deleted_at,omitempty"`
+}
+
+type ExportedFavorite struct {
+	ArticleId uuid.UUID `json:"article_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type FollowDirection string
+
+const (
+	// FollowDirectionFollowing - the user follows the other user
+	FollowDirectionFollowing FollowDirection = "following"
+	// FollowDirectionFollower - the other user follows the user
+	FollowDirectionFollower FollowDirection = "follower"
+)
+
+type ExportedFollow struct {
+	Direction FollowDirection `json:"direction"`
+	Username  string          `json:"username"`
+	CreatedAt time.Time       `json:"created_at"`
+}
@@ -0,0 +1,17 @@
+package data_export_types
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// DataExportRepository reads a user's data row by row, calling fn for each row so callers
+// never need to hold a full result set in memory. Iteration stops at the first error returned by fn.
+//
+//mockery:generate: true
+type DataExportRepository interface {
+	StreamArticles(ctx context.Context, authorUserId uuid.UUID, fn func(ExportedArticle) error) error
+	StreamFavorites(ctx context.Context, userId uuid.UUID, fn func(ExportedFavorite) error) error
+	StreamFollows(ctx context.Context, userId uuid.UUID, fn func(ExportedFollow) error) error
+}
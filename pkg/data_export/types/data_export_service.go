@@ -0,0 +1,14 @@
+package data_export_types
+
+import (
+	"context"
+	"io"
+
+	user_types "github.com/nimaeskandary/go-realworld/pkg/user/types"
+)
+
+type DataExportService interface {
+	// ExportUserData writes a zip archive of all personal data held for the user to w. The archive
+	// is written as it is read from the database, so w should not buffer it in full.
+	ExportUserData(ctx context.Context, user user_types.User, w io.Writer) user_types.DomainError
+}
@@ -0,0 +1,229 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package data_export_types_mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/nimaeskandary/go-realworld/pkg/data_export/types"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockDataExportRepository creates a new instance of MockDataExportRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockDataExportRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockDataExportRepository {
+	mock := &MockDataExportRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockDataExportRepository is an autogenerated mock type for the DataExportRepository type
+type MockDataExportRepository struct {
+	mock.Mock
+}
+
+type MockDataExportRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockDataExportRepository) EXPECT() *MockDataExportRepository_Expecter {
+	return &MockDataExportRepository_Expecter{mock: &_m.Mock}
+}
+
+// StreamArticles provides a mock function for the type MockDataExportRepository
+func (_mock *MockDataExportRepository) StreamArticles(ctx context.Context, authorUserId uuid.UUID, fn func(data_export_types.ExportedArticle) error) error {
+	ret := _mock.Called(ctx, authorUserId, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StreamArticles")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, func(data_export_types.ExportedArticle) error) error); ok {
+		r0 = returnFunc(ctx, authorUserId, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockDataExportRepository_StreamArticles_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StreamArticles'
+type MockDataExportRepository_StreamArticles_Call struct {
+	*mock.Call
+}
+
+// StreamArticles is a helper method to define mock.On call
+//   - ctx context.Context
+//   - authorUserId uuid.UUID
+//   - fn func(data_export_types.ExportedArticle) error
+func (_e *MockDataExportRepository_Expecter) StreamArticles(ctx interface{}, authorUserId interface{}, fn interface{}) *MockDataExportRepository_StreamArticles_Call {
+	return &MockDataExportRepository_StreamArticles_Call{Call: _e.mock.On("StreamArticles", ctx, authorUserId, fn)}
+}
+
+func (_c *MockDataExportRepository_StreamArticles_Call) Run(run func(ctx context.Context, authorUserId uuid.UUID, fn func(data_export_types.ExportedArticle) error)) *MockDataExportRepository_StreamArticles_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 func(data_export_types.ExportedArticle) error
+		if args[2] != nil {
+			arg2 = args[2].(func(data_export_types.ExportedArticle) error)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDataExportRepository_StreamArticles_Call) Return(err error) *MockDataExportRepository_StreamArticles_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockDataExportRepository_StreamArticles_Call) RunAndReturn(run func(ctx context.Context, authorUserId uuid.UUID, fn func(data_export_types.ExportedArticle) error) error) *MockDataExportRepository_StreamArticles_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StreamFavorites provides a mock function for the type MockDataExportRepository
+func (_mock *MockDataExportRepository) StreamFavorites(ctx context.Context, userId uuid.UUID, fn func(data_export_types.ExportedFavorite) error) error {
+	ret := _mock.Called(ctx, userId, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StreamFavorites")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, func(data_export_types.ExportedFavorite) error) error); ok {
+		r0 = returnFunc(ctx, userId, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockDataExportRepository_StreamFavorites_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StreamFavorites'
+type MockDataExportRepository_StreamFavorites_Call struct {
+	*mock.Call
+}
+
+// StreamFavorites is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userId uuid.UUID
+//   - fn func(data_export_types.ExportedFavorite) error
+func (_e *MockDataExportRepository_Expecter) StreamFavorites(ctx interface{}, userId interface{}, fn interface{}) *MockDataExportRepository_StreamFavorites_Call {
+	return &MockDataExportRepository_StreamFavorites_Call{Call: _e.mock.On("StreamFavorites", ctx, userId, fn)}
+}
+
+func (_c *MockDataExportRepository_StreamFavorites_Call) Run(run func(ctx context.Context, userId uuid.UUID, fn func(data_export_types.ExportedFavorite) error)) *MockDataExportRepository_StreamFavorites_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 func(data_export_types.ExportedFavorite) error
+		if args[2] != nil {
+			arg2 = args[2].(func(data_export_types.ExportedFavorite) error)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDataExportRepository_StreamFavorites_Call) Return(err error) *MockDataExportRepository_StreamFavorites_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockDataExportRepository_StreamFavorites_Call) RunAndReturn(run func(ctx context.Context, userId uuid.UUID, fn func(data_export_types.ExportedFavorite) error) error) *MockDataExportRepository_StreamFavorites_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StreamFollows provides a mock function for the type MockDataExportRepository
+func (_mock *MockDataExportRepository) StreamFollows(ctx context.Context, userId uuid.UUID, fn func(data_export_types.ExportedFollow) error) error {
+	ret := _mock.Called(ctx, userId, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StreamFollows")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, func(data_export_types.ExportedFollow) error) error); ok {
+		r0 = returnFunc(ctx, userId, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockDataExportRepository_StreamFollows_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StreamFollows'
+type MockDataExportRepository_StreamFollows_Call struct {
+	*mock.Call
+}
+
+// StreamFollows is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userId uuid.UUID
+//   - fn func(data_export_types.ExportedFollow) error
+func (_e *MockDataExportRepository_Expecter) StreamFollows(ctx interface{}, userId interface{}, fn interface{}) *MockDataExportRepository_StreamFollows_Call {
+	return &MockDataExportRepository_StreamFollows_Call{Call: _e.mock.On("StreamFollows", ctx, userId, fn)}
+}
+
+func (_c *MockDataExportRepository_StreamFollows_Call) Run(run func(ctx context.Context, userId uuid.UUID, fn func(data_export_types.ExportedFollow) error)) *MockDataExportRepository_StreamFollows_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 func(data_export_types.ExportedFollow) error
+		if args[2] != nil {
+			arg2 = args[2].(func(data_export_types.ExportedFollow) error)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDataExportRepository_StreamFollows_Call) Return(err error) *MockDataExportRepository_StreamFollows_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockDataExportRepository_StreamFollows_Call) RunAndReturn(run func(ctx context.Context, userId uuid.UUID, fn func(data_export_types.ExportedFollow) error) error) *MockDataExportRepository_StreamFollows_Call {
+	_c.Call.Return(run)
+	return _c
+}
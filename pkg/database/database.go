@@ -1,19 +1,64 @@
 package database
 
 import (
+	"fmt"
+
 	"github.com/nimaeskandary/go-realworld/pkg/database/internal"
 	db_types "github.com/nimaeskandary/go-realworld/pkg/database/types"
+	obs_types "github.com/nimaeskandary/go-realworld/pkg/observability/types"
 	"github.com/nimaeskandary/go-realworld/pkg/util"
 
 	"go.uber.org/fx"
 )
 
-// NewRealworldAppDbModule provides the RealWorld application database module, this can be casted to help with
-// running migrations, as that expects there to just be one generic SQLDatabase type loaded
-func NewPostgresRealworldAppDbModule[T db_types.SQLDatabase]() fx.Option {
+// NewRealworldAppDbModule provides the RealWorld application database module, backed by the driver in its config.
+// This can be casted to help with running migrations, as that expects there to just be one generic SQLDatabase type
+// loaded
+func NewRealworldAppDbModule[T db_types.SQLDatabase]() fx.Option {
 	return util.NewFxModuleWithLifecycle[T]("realworld_app_db",
-		func(cfg db_types.RealWorldAppDbConfig) (db_types.SQLDatabase, error) {
-			return internal.NewPostgresSQLDatabase(db_types.SqlDbConfig(cfg))
+		func(cfg db_types.RealWorldAppDbConfig, logger obs_types.Logger) (db_types.SQLDatabase, error) {
+			return NewSQLDatabase(db_types.SqlDbConfig(cfg), logger)
+		})
+}
+
+// NewRealworldAppTxManagerModule provides a TxManager for the RealWorld application database
+func NewRealworldAppTxManagerModule() fx.Option {
+	return util.NewFxModule[db_types.TxManager]("realworld_app_tx_manager",
+		func(db db_types.RealWorldAppDb) db_types.TxManager {
+			return internal.NewSqlTxManager(db)
+		})
+}
+
+// NewRealworldAppInMemoryDbModule provides an in memory stand in for the RealWorld application database, used by the
+// in memory module of each component
+func NewRealworldAppInMemoryDbModule() fx.Option {
+	return util.NewFxModule[db_types.RealWorldAppInMemoryDb]("realworld_app_in_memory_db", internal.NewInMemoryDb)
+}
+
+// NewRealworldAppInMemoryTxManagerModule provides a TxManager for the in memory RealWorld application database
+func NewRealworldAppInMemoryTxManagerModule() fx.Option {
+	return util.NewFxModule[db_types.TxManager]("realworld_app_in_memory_tx_manager",
+		func(db db_types.RealWorldAppInMemoryDb) (db_types.TxManager, error) {
+			return internal.NewInMemoryTxManager(db)
+		})
+}
+
+// NewRealworldAppStartupMigrationsModule applies or checks the RealWorld application database migrations as the app
+// starts, see StartupMigrationsConfig. It needs the MigrationsProvider of those migrations. List it right after the
+// database module, so it runs before the modules that use the database start
+func NewRealworldAppStartupMigrationsModule() fx.Option {
+	return util.NewFxModuleWithLifecycle[db_types.StartupMigrations]("realworld_app_startup_migrations",
+		func(
+			cfg db_types.StartupMigrationsConfig,
+			db db_types.RealWorldAppDb,
+			migrationsProvider db_types.MigrationsProvider,
+			logger obs_types.Logger,
+		) (db_types.StartupMigrations, error) {
+			runner, err := internal.NewGooseMigrationRunner(db, migrationsProvider)
+			if err != nil {
+				return nil, err
+			}
+			return internal.NewStartupMigrations(cfg, runner, logger), nil
 		})
 }
 
@@ -24,5 +69,29 @@ func NewGooseMigrationRunnerModule() fx.Option {
 	)
 }
 
+// NewSQLDatabase creates a database for the driver in cfg, slow queries are logged with logger if it is not nil
+func NewSQLDatabase(cfg db_types.SqlDbConfig, logger obs_types.Logger) (db_types.SQLDatabase, error) {
+	switch cfg.Driver {
+	case "", db_types.DialectPostgres:
+		return internal.NewPostgresSQLDatabase(cfg, logger)
+	case db_types.DialectSqlite:
+		return internal.NewSqliteSQLDatabase(cfg, logger)
+	default:
+		return nil, fmt.Errorf("unknown database driver: %v", cfg.Driver)
+	}
+}
+
 var NewPostgresSQLDatabase = internal.NewPostgresSQLDatabase
+var NewSqliteSQLDatabase = internal.NewSqliteSQLDatabase
 var NewGooseMigrationRunner = internal.NewGooseMigrationRunner
+var NewStartupMigrations = internal.NewStartupMigrations
+var NewSqlTxManager = internal.NewSqlTxManager
+var NewInMemoryDb = internal.NewInMemoryDb
+var NewInMemoryTxManager = internal.NewInMemoryTxManager
+var CheckMigrationVersions = internal.CheckMigrationVersions
+var RegisterMigrationTarget = internal.RegisterMigrationTarget
+var MigrationTargets = internal.MigrationTargets
+var GetMigrationTarget = internal.GetMigrationTarget
+var SnapshotSchema = internal.SnapshotSchema
+var BulkInsert = internal.BulkInsert
+var DiffSchemas = internal.DiffSchemas
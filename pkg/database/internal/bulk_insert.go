@@ -0,0 +1,85 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	db_types "github.com/nimaeskandary/go-realworld/pkg/database/types"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
+)
+
+// sqliteMaxVariables is the most parameters sqlite takes in one statement
+const sqliteMaxVariables = 32766
+
+// BulkInsert writes the rows to the table as fast as the dialect allows, for loading large datasets rather than
+// serving requests. Postgres streams them with COPY, sqlite inserts many rows per statement in one transaction.
+// Either way, all the rows are written or none are, and a row that conflicts with an existing one is an error
+func BulkInsert(ctx context.Context, db db_types.SQLDatabase, table string, columns []string, rows [][]any) error {
+	if len(rows) == 0 {
+		return nil
+	}
+	switch db.GetDialect() {
+	case db_types.DialectPostgres:
+		return copyPostgres(ctx, db, table, columns, rows)
+	case db_types.DialectSqlite:
+		return insertSqlite(ctx, db, table, columns, rows)
+	default:
+		return fmt.Errorf("bulk insert is not supported for dialect %v", db.GetDialect())
+	}
+}
+
+func copyPostgres(ctx context.Context, db db_types.SQLDatabase, table string, columns []string, rows [][]any) error {
+	conn, err := db.GetDB().Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get a connection to copy into %v: %w", table, err)
+	}
+	defer func() { _ = conn.Close() }()
+
+	return conn.Raw(func(driverConn any) error {
+		// COPY is not part of database/sql, it is run on pgx's own connection, under the instrumentation
+		if instrumented, ok := driverConn.(*instrumentedConn); ok {
+			driverConn = instrumented.conn
+		}
+		pgxConn, ok := driverConn.(*stdlib.Conn)
+		if !ok {
+			return fmt.Errorf("failed to copy into %v: connection is a %T, not pgx", table, driverConn)
+		}
+		if _, err := pgxConn.Conn().CopyFrom(ctx, pgx.Identifier{table}, columns, pgx.CopyFromRows(rows)); err != nil {
+			return fmt.Errorf("failed to copy into %v: %w", table, err)
+		}
+		return nil
+	})
+}
+
+func insertSqlite(ctx context.Context, db db_types.SQLDatabase, table string, columns []string, rows [][]any) error {
+	tx, err := db.GetDB().BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction to insert into %v: %w", table, err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	rowPlaceholders := "(" + strings.TrimSuffix(strings.Repeat("?, ", len(columns)), ", ") + ")"
+	rowsPerStatement := max(1, sqliteMaxVariables/len(columns))
+	for start := 0; start < len(rows); start += rowsPerStatement {
+		batch := rows[start:min(start+rowsPerStatement, len(rows))]
+		values := make([]string, len(batch))
+		args := make([]any, 0, len(batch)*len(columns))
+		for i, row := range batch {
+			values[i] = rowPlaceholders
+			args = append(args, row...)
+		}
+
+		query := fmt.Sprintf("INSERT INTO %v (%v) VALUES %v", table, strings.Join(columns, ", "), strings.Join(values, ", "))
+		if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+			return fmt.Errorf("failed to insert into %v: %w", table, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit inserts into %v: %w", table, err)
+	}
+	return nil
+}
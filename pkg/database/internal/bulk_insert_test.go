@@ -0,0 +1,68 @@
+package internal_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/nimaeskandary/go-realworld/pkg/database"
+	"github.com/nimaeskandary/go-realworld/pkg/database/internal"
+	db_config_provider "github.com/nimaeskandary/go-realworld/pkg/test_utils/db_config_provider"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_BulkInsert(t *testing.T) {
+	t.Parallel()
+
+	provider := db_config_provider.RealWorldAppDbConfigProvider()
+	cfg, err := provider.GetFreshDbConfig(t.Context())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = provider.Cleanup(context.Background(), cfg) })
+
+	db, err := database.NewSQLDatabase(cfg, nil)
+	require.NoError(t, err)
+	require.NoError(t, db.Start(t.Context()))
+	t.Cleanup(func() { _ = db.Stop(context.Background()) })
+
+	columns := []string{"id", "username", "email", "bio", "created_at", "updated_at"}
+	userRows := func(prefix string, count int) [][]any {
+		now := time.Now().UTC()
+		rows := make([][]any, count)
+		for i := range rows {
+			username := fmt.Sprintf("%v%v", prefix, i)
+			rows[i] = []any{uuid.NewString(), username, username + "@example.com", nil, now, now}
+		}
+		return rows
+	}
+	countUsers := func(t *testing.T, prefix string) int {
+		var count int
+		query := fmt.Sprintf("SELECT COUNT(*) FROM users WHERE username LIKE '%v%%'", prefix)
+		require.NoError(t, db.GetDB().QueryRowContext(t.Context(), query).Scan(&count))
+		return count
+	}
+
+	t.Run("should insert more rows than fit in one sqlite statement", func(t *testing.T) {
+		t.Parallel()
+		require.NoError(t, internal.BulkInsert(t.Context(), db, "users", columns, userRows("bulk_many_", 10000)))
+		assert.Equal(t, 10000, countUsers(t, "bulk_many_"))
+	})
+
+	t.Run("should insert none of the rows if one fails", func(t *testing.T) {
+		t.Parallel()
+		rows := userRows("bulk_conflict_", 3)
+		rows = append(rows, rows[0])
+
+		err := internal.BulkInsert(t.Context(), db, "users", columns, rows)
+		assert.Error(t, err)
+		assert.Equal(t, 0, countUsers(t, "bulk_conflict_"))
+	})
+
+	t.Run("should do nothing without rows", func(t *testing.T) {
+		t.Parallel()
+		assert.NoError(t, internal.BulkInsert(t.Context(), db, "users", columns, nil))
+	})
+}
@@ -4,16 +4,22 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io/fs"
+	"slices"
 
 	"github.com/nimaeskandary/go-realworld/pkg/database/types"
 	"github.com/pressly/goose/v3"
 	"github.com/samber/lo"
+	"github.com/samber/mo"
 )
 
 type gooseMigrationRunner struct {
 	db                db_types.SQLDatabase
 	migrationsProvder db_types.MigrationsProvider
 	gooseProvider     *goose.Provider
+	sqlMigrationsFs   fs.FS
+	// sources are the migrations known to the provider, keyed by version
+	sources map[int64]migrationSource
 }
 
 func NewGooseMigrationRunner(db db_types.SQLDatabase, migrationsProvider db_types.MigrationsProvider) (db_types.SqlMigrationRunner, error) {
@@ -26,7 +32,7 @@ func NewGooseMigrationRunner(db db_types.SQLDatabase, migrationsProvider db_type
 
 func (r *gooseMigrationRunner) Start(ctx context.Context) error {
 	mp := r.migrationsProvder
-	gooseCodeMigrations := lo.Map(*mp.GetCodeMigrations(), func(cm db_types.GoMigration, _ int) *goose.Migration {
+	gooseCodeMigrations := lo.Map(*mp.GetCodeMigrations(r.db.GetDialect()), func(cm db_types.GoMigration, _ int) *goose.Migration {
 		return goose.NewGoMigration(
 			int64(cm.Version()),
 			&goose.GoFunc{RunTx: cm.Up()},
@@ -36,18 +42,37 @@ func (r *gooseMigrationRunner) Start(ctx context.Context) error {
 
 	var dialect goose.Dialect
 	switch r.db.GetDialect() {
-	case "postgres":
+	case db_types.DialectPostgres:
 		dialect = goose.DialectPostgres
+	case db_types.DialectSqlite:
+		dialect = goose.DialectSQLite3
 	default:
 		return fmt.Errorf("unknown dialect for goose migration runner: %v", r.db.GetDialect())
 	}
 
-	gooseProvider, err := goose.NewProvider(dialect, r.db.GetDB(), mp.GetSqlMigrationsFs(), goose.WithGoMigrations(gooseCodeMigrations...))
+	sqlMigrationsFs, err := mp.GetSqlMigrationsFs(r.db.GetDialect())
+	if err != nil {
+		return fmt.Errorf("failed to get sql migrations: %w", err)
+	}
+
+	codeMigrationsSourceFs, err := mp.GetCodeMigrationsSourceFs(r.db.GetDialect())
+	if err != nil {
+		return fmt.Errorf("failed to get code migration sources: %w", err)
+	}
+
+	gooseProvider, err := goose.NewProvider(dialect, r.db.GetDB(), sqlMigrationsFs, goose.WithGoMigrations(gooseCodeMigrations...))
 	if err != nil {
 		return fmt.Errorf("failed to create goose provider: %w", err)
 	}
 
+	sources, err := collectMigrationSources(gooseProvider, sqlMigrationsFs, codeMigrationsSourceFs)
+	if err != nil {
+		return err
+	}
+
 	r.gooseProvider = gooseProvider
+	r.sqlMigrationsFs = sqlMigrationsFs
+	r.sources = sources
 	return nil
 }
 
@@ -57,6 +82,16 @@ func (r *gooseMigrationRunner) Stop(_ context.Context) error {
 }
 
 func (r *gooseMigrationRunner) Apply(ctx context.Context, version int64) error {
+	unlock, err := r.lock(ctx)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if err := r.prepareChecksums(ctx); err != nil {
+		return err
+	}
+
 	result, err := r.gooseProvider.ApplyVersion(ctx, version, true)
 	if err != nil {
 		return fmt.Errorf("failed to apply migration version %v: %w", version, err)
@@ -65,11 +100,24 @@ func (r *gooseMigrationRunner) Apply(ctx context.Context, version int64) error {
 		return fmt.Errorf("failed to apply migration version %v: %w", version, result.Error)
 	}
 
-	return nil
+	return r.recordResults(ctx, result)
 }
 
 func (r *gooseMigrationRunner) ApplyAll(ctx context.Context) error {
+	unlock, err := r.lock(ctx)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if err := r.prepareChecksums(ctx); err != nil {
+		return err
+	}
+
 	result, err := r.gooseProvider.Up(ctx)
+	if recordErr := r.recordResults(ctx, completedResults(result, err)...); recordErr != nil {
+		return recordErr
+	}
 	if err != nil {
 		return fmt.Errorf("failed to apply migrations: %w", err)
 	}
@@ -85,6 +133,16 @@ func (r *gooseMigrationRunner) ApplyAll(ctx context.Context) error {
 }
 
 func (r *gooseMigrationRunner) Rollback(ctx context.Context, version int64) error {
+	unlock, err := r.lock(ctx)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if err := r.prepareChecksums(ctx); err != nil {
+		return err
+	}
+
 	result, err := r.gooseProvider.ApplyVersion(ctx, version, false)
 	if err != nil {
 		return fmt.Errorf("failed to rollback migration %v: %w", version, err)
@@ -94,11 +152,24 @@ func (r *gooseMigrationRunner) Rollback(ctx context.Context, version int64) erro
 		return fmt.Errorf("failed to rollback migration %v: %w", version, result.Error)
 	}
 
-	return nil
+	return r.recordResults(ctx, result)
 }
 
 func (r *gooseMigrationRunner) RollbackTo(ctx context.Context, version int64) error {
+	unlock, err := r.lock(ctx)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if err := r.prepareChecksums(ctx); err != nil {
+		return err
+	}
+
 	result, err := r.gooseProvider.DownTo(ctx, version)
+	if recordErr := r.recordResults(ctx, completedResults(result, err)...); recordErr != nil {
+		return recordErr
+	}
 	if err != nil {
 		return fmt.Errorf("failed to rollback migrations: %w", err)
 	}
@@ -113,16 +184,91 @@ func (r *gooseMigrationRunner) RollbackTo(ctx context.Context, version int64) er
 	return resultErrs
 }
 
-func (r *gooseMigrationRunner) Status(ctx context.Context) (string, error) {
+func (r *gooseMigrationRunner) Status(ctx context.Context) ([]db_types.MigrationStatus, error) {
 	result, err := r.gooseProvider.Status(ctx)
 	if err != nil {
-		return "", fmt.Errorf("failed to get migration status: %w", err)
+		return nil, fmt.Errorf("failed to get migration status: %w", err)
 	}
 
-	return lo.Reduce(result, func(agg string, item *goose.MigrationStatus, _ int) string {
-		s := fmt.Sprintf("%v - Version %v", item.State, item.Source.Version)
-		return fmt.Sprintf("%v\n%v", agg, s)
-	}, ""), nil
+	return lo.Map(result, func(item *goose.MigrationStatus, _ int) db_types.MigrationStatus {
+		status := db_types.MigrationStatus{
+			Version:    item.Source.Version,
+			State:      db_types.MigrationPending,
+			SourceType: db_types.MigrationSourceSql,
+			Source:     r.sources[item.Source.Version].path,
+		}
+		if item.State == goose.StateApplied {
+			status.State = db_types.MigrationApplied
+			status.AppliedAt = mo.Some(item.AppliedAt)
+		}
+		if item.Source.Type == goose.TypeGo {
+			status.SourceType = db_types.MigrationSourceGo
+		}
+		return status
+	}), nil
+}
+
+func (r *gooseMigrationRunner) UpByOne(ctx context.Context) (int64, error) {
+	unlock, err := r.lock(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+
+	if err := r.prepareChecksums(ctx); err != nil {
+		return 0, err
+	}
+
+	result, err := r.gooseProvider.UpByOne(ctx)
+	if errors.Is(err, goose.ErrNoNextVersion) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to apply the next migration: %w", err)
+	}
+
+	return result.Source.Version, r.recordResults(ctx, result)
+}
+
+func (r *gooseMigrationRunner) Redo(ctx context.Context) (int64, error) {
+	unlock, err := r.lock(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+
+	if err := r.prepareChecksums(ctx); err != nil {
+		return 0, err
+	}
+
+	down, err := r.gooseProvider.Down(ctx)
+	if errors.Is(err, goose.ErrNoNextVersion) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to rollback the latest migration: %w", err)
+	}
+	if err := r.recordResults(ctx, down); err != nil {
+		return 0, err
+	}
+
+	version := down.Source.Version
+	up, err := r.gooseProvider.ApplyVersion(ctx, version, true)
+	if err != nil {
+		return 0, fmt.Errorf("failed to reapply migration version %v: %w", version, err)
+	}
+	if up.Error != nil {
+		return 0, fmt.Errorf("failed to reapply migration version %v: %w", version, up.Error)
+	}
+	return version, r.recordResults(ctx, up)
+}
+
+func (r *gooseMigrationRunner) LatestVersion() int64 {
+	latest := int64(0)
+	for version := range r.sources {
+		latest = max(latest, version)
+	}
+	return latest
 }
 
 func (r *gooseMigrationRunner) CurrentVersion(ctx context.Context) (int64, error) {
@@ -133,3 +279,112 @@ func (r *gooseMigrationRunner) CurrentVersion(ctx context.Context) (int64, error
 
 	return version, nil
 }
+
+func (r *gooseMigrationRunner) PlanApply(ctx context.Context, version int64) ([]db_types.PlannedMigration, error) {
+	applied, err := r.appliedVersions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if slices.Contains(applied, version) {
+		return nil, fmt.Errorf("migration version %v is already applied", version)
+	}
+	return r.plan(true, []int64{version})
+}
+
+// PlanApplyAll fails like ApplyAll does when a version before the latest applied one was never applied
+func (r *gooseMigrationRunner) PlanApplyAll(ctx context.Context) ([]db_types.PlannedMigration, error) {
+	applied, err := r.appliedVersions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	latestApplied := int64(0)
+	if len(applied) > 0 {
+		latestApplied = applied[len(applied)-1]
+	}
+
+	var pending []int64
+	for version := range r.sources {
+		if slices.Contains(applied, version) {
+			continue
+		}
+		if version < latestApplied {
+			return nil, fmt.Errorf("migration version %v was never applied, but later versions were", version)
+		}
+		pending = append(pending, version)
+	}
+	slices.Sort(pending)
+	return r.plan(true, pending)
+}
+
+func (r *gooseMigrationRunner) PlanRollback(ctx context.Context, version int64) ([]db_types.PlannedMigration, error) {
+	applied, err := r.appliedVersions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !slices.Contains(applied, version) {
+		return nil, fmt.Errorf("migration version %v is not applied", version)
+	}
+	return r.plan(false, []int64{version})
+}
+
+func (r *gooseMigrationRunner) PlanRollbackTo(ctx context.Context, version int64) ([]db_types.PlannedMigration, error) {
+	applied, err := r.appliedVersions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var rollback []int64
+	for _, appliedVersion := range slices.Backward(applied) {
+		if appliedVersion > version {
+			rollback = append(rollback, appliedVersion)
+		}
+	}
+	return r.plan(false, rollback)
+}
+
+// PlanRedo is the latest applied migration rolled back, then applied again. Like Redo, it is empty if none is applied
+func (r *gooseMigrationRunner) PlanRedo(ctx context.Context) ([]db_types.PlannedMigration, error) {
+	version, err := r.CurrentVersion(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if version == 0 {
+		return nil, nil
+	}
+
+	down, err := r.plan(false, []int64{version})
+	if err != nil {
+		return nil, err
+	}
+	up, err := r.plan(true, []int64{version})
+	if err != nil {
+		return nil, err
+	}
+	return append(down, up...), nil
+}
+
+// plan reads the statements each version would run in the direction, in the order given
+func (r *gooseMigrationRunner) plan(up bool, versions []int64) ([]db_types.PlannedMigration, error) {
+	planned := make([]db_types.PlannedMigration, 0, len(versions))
+	for _, version := range versions {
+		source, ok := r.sources[version]
+		if !ok {
+			return nil, fmt.Errorf("no source for migration version %v", version)
+		}
+
+		migration := db_types.PlannedMigration{Version: version, Up: up, Source: source.path}
+		if !source.isCode {
+			file, err := r.sqlMigrationsFs.Open(source.path)
+			if err != nil {
+				return nil, fmt.Errorf("failed to open migration %v: %w", source.path, err)
+			}
+			migration.Statements, err = splitSqlMigration(file, up)
+			_ = file.Close()
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse migration %v: %w", source.path, err)
+			}
+		}
+		planned = append(planned, migration)
+	}
+	return planned, nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"io/fs"
+	"path/filepath"
 	"testing"
 	"testing/fstest"
 
@@ -59,6 +60,7 @@ DROP TABLE users;
 
 	mockProvider := &StubMigrationsProvider{
 		fs:             mockFs,
+		codeFs:         fstest.MapFS{},
 		codeMigrations: []db_types.GoMigration{codeMigration},
 	}
 
@@ -71,7 +73,7 @@ DROP TABLE users;
 		})
 
 		// setup db connection
-		db, err := database.NewPostgresSQLDatabase(dbCfg)
+		db, err := database.NewPostgresSQLDatabase(dbCfg, nil)
 		require.NoError(t, err)
 		require.NoError(t, db.Start(t.Context()))
 		defer func() { _ = db.Stop(t.Context()) }()
@@ -118,6 +120,166 @@ DROP TABLE users;
 		err = db.GetDB().QueryRowContext(t.Context(), "SELECT tablename FROM pg_tables WHERE tablename = 'users'").Scan(&tableName)
 		assert.Equal(t, err, sql.ErrNoRows)
 	})
+
+	t.Run("Concurrent runners take turns", func(t *testing.T) {
+		dbCfg, err := provider.GetFreshDbConfig(t.Context())
+		require.NoError(t, err)
+
+		t.Cleanup(func() {
+			_ = provider.Cleanup(context.Background(), dbCfg)
+		})
+
+		// a database and runner per replica
+		runners := make([]db_types.SqlMigrationRunner, 3)
+		for i := range runners {
+			db, err := database.NewPostgresSQLDatabase(dbCfg, nil)
+			require.NoError(t, err)
+			require.NoError(t, db.Start(t.Context()))
+			defer func() { _ = db.Stop(t.Context()) }()
+
+			runners[i], err = internal.NewGooseMigrationRunner(db, mockProvider)
+			require.NoError(t, err)
+			require.NoError(t, runners[i].Start(t.Context()))
+			defer func() { _ = runners[i].Stop(t.Context()) }()
+		}
+
+		// without the lock, more than one would try to create the users table
+		errs := make(chan error, len(runners))
+		for _, runner := range runners {
+			go func() { errs <- runner.ApplyAll(t.Context()) }()
+		}
+		for range runners {
+			assert.NoError(t, <-errs)
+		}
+
+		ver, err := runners[0].CurrentVersion(t.Context())
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), ver)
+	})
+}
+
+func Test_GooseMigrationRunnerActions(t *testing.T) {
+	t.Parallel()
+
+	provider := &StubMigrationsProvider{
+		fs: fstest.MapFS{
+			"001_create_users.sql": {Data: []byte("-- +goose Up\nCREATE TABLE users (id INTEGER PRIMARY KEY, username TEXT);\n-- +goose Down\nDROP TABLE users;\n")},
+		},
+		codeFs: fstest.MapFS{"002_add_user.go": {Data: []byte("package migrations\n")}},
+		codeMigrations: []db_types.GoMigration{&StubGoMigration{
+			version: 2,
+			up: func(ctx context.Context, tx *sql.Tx) error {
+				_, err := tx.ExecContext(ctx, "INSERT INTO users (username) VALUES ('testuser')")
+				return err
+			},
+			down: func(ctx context.Context, tx *sql.Tx) error {
+				_, err := tx.ExecContext(ctx, "DELETE FROM users")
+				return err
+			},
+		}},
+	}
+
+	startRunner := func(t *testing.T) (db_types.SQLDatabase, db_types.SqlMigrationRunner) {
+		db, err := database.NewSqliteSQLDatabase(db_types.SqlDbConfig{
+			Driver:     db_types.DialectSqlite,
+			SqlitePath: filepath.Join(t.TempDir(), "test.db"),
+		}, nil)
+		require.NoError(t, err)
+		require.NoError(t, db.Start(t.Context()))
+		t.Cleanup(func() { _ = db.Stop(context.Background()) })
+
+		runner, err := internal.NewGooseMigrationRunner(db, provider)
+		require.NoError(t, err)
+		require.NoError(t, runner.Start(t.Context()))
+		t.Cleanup(func() { _ = runner.Stop(context.Background()) })
+		return db, runner
+	}
+
+	t.Run("Status", func(t *testing.T) {
+		t.Parallel()
+
+		t.Run("should list applied and pending migrations", func(t *testing.T) {
+			t.Parallel()
+			_, runner := startRunner(t)
+			require.NoError(t, runner.Apply(t.Context(), 1))
+
+			statuses, err := runner.Status(t.Context())
+			require.NoError(t, err)
+			require.Len(t, statuses, 2)
+
+			assert.Equal(t, int64(1), statuses[0].Version)
+			assert.Equal(t, db_types.MigrationApplied, statuses[0].State)
+			assert.True(t, statuses[0].AppliedAt.IsPresent())
+			assert.Equal(t, db_types.MigrationSourceSql, statuses[0].SourceType)
+			assert.Equal(t, "001_create_users.sql", statuses[0].Source)
+
+			assert.Equal(t, db_types.MigrationStatus{
+				Version:    2,
+				State:      db_types.MigrationPending,
+				SourceType: db_types.MigrationSourceGo,
+				Source:     "002_add_user.go",
+			}, statuses[1])
+			assert.Equal(t, int64(2), runner.LatestVersion())
+		})
+	})
+
+	t.Run("UpByOne", func(t *testing.T) {
+		t.Parallel()
+
+		t.Run("should apply one migration at a time", func(t *testing.T) {
+			t.Parallel()
+			_, runner := startRunner(t)
+
+			for _, expected := range []int64{1, 2, 0} {
+				version, err := runner.UpByOne(t.Context())
+				require.NoError(t, err)
+				assert.Equal(t, expected, version)
+			}
+
+			current, err := runner.CurrentVersion(t.Context())
+			require.NoError(t, err)
+			assert.Equal(t, int64(2), current)
+		})
+	})
+
+	t.Run("Redo", func(t *testing.T) {
+		t.Parallel()
+
+		t.Run("should rollback and reapply the latest migration", func(t *testing.T) {
+			t.Parallel()
+			db, runner := startRunner(t)
+			require.NoError(t, runner.ApplyAll(t.Context()))
+
+			plan, err := runner.PlanRedo(t.Context())
+			require.NoError(t, err)
+			assert.Equal(t, []db_types.PlannedMigration{
+				{Version: 2, Up: false, Source: "002_add_user.go"},
+				{Version: 2, Up: true, Source: "002_add_user.go"},
+			}, plan)
+
+			version, err := runner.Redo(t.Context())
+			require.NoError(t, err)
+			assert.Equal(t, int64(2), version)
+
+			// the code migration's down deleted the user, and its up inserted it again
+			var count int
+			require.NoError(t, db.GetDB().QueryRowContext(t.Context(), "SELECT count(*) FROM users").Scan(&count))
+			assert.Equal(t, 1, count)
+
+			drift, err := runner.Verify(t.Context())
+			require.NoError(t, err)
+			assert.Empty(t, drift)
+		})
+
+		t.Run("should do nothing without applied migrations", func(t *testing.T) {
+			t.Parallel()
+			_, runner := startRunner(t)
+
+			version, err := runner.Redo(t.Context())
+			require.NoError(t, err)
+			assert.Equal(t, int64(0), version)
+		})
+	})
 }
 
 type StubGoMigration struct {
@@ -132,13 +294,18 @@ func (m *StubGoMigration) Down() db_types.MigrationFn { return m.down }
 
 type StubMigrationsProvider struct {
 	fs             fs.FS
+	codeFs         fs.FS
 	codeMigrations []db_types.GoMigration
 }
 
-func (p *StubMigrationsProvider) GetSqlMigrationsFs() fs.FS {
-	return p.fs
+func (p *StubMigrationsProvider) GetSqlMigrationsFs(_ string) (fs.FS, error) {
+	return p.fs, nil
+}
+
+func (p *StubMigrationsProvider) GetCodeMigrationsSourceFs(_ string) (fs.FS, error) {
+	return p.codeFs, nil
 }
 
-func (p *StubMigrationsProvider) GetCodeMigrations() *[]db_types.GoMigration {
+func (p *StubMigrationsProvider) GetCodeMigrations(_ string) *[]db_types.GoMigration {
 	return &p.codeMigrations
 }
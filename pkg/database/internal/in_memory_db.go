@@ -0,0 +1,57 @@
+package internal
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync"
+
+	"github.com/nimaeskandary/go-realworld/pkg/database/types"
+)
+
+type inMemoryDb struct {
+	mu     sync.RWMutex
+	tables *db_types.InMemoryTables
+}
+
+func NewInMemoryDb() db_types.InMemoryDb {
+	return &inMemoryDb{tables: db_types.NewInMemoryTables()}
+}
+
+// inMemoryTxKey is scoped to a db, like the sql txKey
+type inMemoryTxKey struct {
+	db *inMemoryDb
+}
+
+// inMemoryTxState is carried in the ctx of a transaction, which holds the write lock for its whole duration
+type inMemoryTxState struct {
+	opts sql.TxOptions
+}
+
+func (d *inMemoryDb) Read(ctx context.Context, fn func(tables *db_types.InMemoryTables) error) error {
+	if _, ok := d.txFromCtx(ctx); ok {
+		return fn(d.tables)
+	}
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return fn(d.tables)
+}
+
+func (d *inMemoryDb) Write(ctx context.Context, fn func(tables *db_types.InMemoryTables) error) error {
+	if state, ok := d.txFromCtx(ctx); ok {
+		if state.opts.ReadOnly {
+			return errors.New("cannot write in a read only transaction")
+		}
+		return fn(d.tables)
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return fn(d.tables)
+}
+
+func (d *inMemoryDb) txFromCtx(ctx context.Context) (*inMemoryTxState, bool) {
+	state, ok := ctx.Value(inMemoryTxKey{db: d}).(*inMemoryTxState)
+	return state, ok
+}
@@ -0,0 +1,66 @@
+package internal
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/nimaeskandary/go-realworld/pkg/database/types"
+)
+
+// inMemoryTxManager runs transactions against an in memory db. A transaction holds the db's write lock until it ends,
+// so transactions are serializable, and is rolled back by restoring a copy of the tables taken when it began
+type inMemoryTxManager struct {
+	db *inMemoryDb
+}
+
+// NewInMemoryTxManager returns a TxManager for a db made with NewInMemoryDb
+func NewInMemoryTxManager(db db_types.InMemoryDb) (db_types.TxManager, error) {
+	inMemory, ok := db.(*inMemoryDb)
+	if !ok {
+		return nil, fmt.Errorf("unsupported in memory db: %T", db)
+	}
+	return &inMemoryTxManager{db: inMemory}, nil
+}
+
+func (m *inMemoryTxManager) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := m.db.txFromCtx(ctx); ok {
+		return m.withinSavepoint(ctx, fn)
+	}
+	return m.withinNewTx(ctx, sql.TxOptions{}, fn)
+}
+
+func (m *inMemoryTxManager) WithinTxOptions(ctx context.Context, opts sql.TxOptions, fn func(ctx context.Context) error) error {
+	if state, ok := m.db.txFromCtx(ctx); ok {
+		if state.opts != opts {
+			return fmt.Errorf("nested transaction options %+v do not match the enclosing transaction's %+v", opts, state.opts)
+		}
+		return m.withinSavepoint(ctx, fn)
+	}
+	return m.withinNewTx(ctx, opts, fn)
+}
+
+func (m *inMemoryTxManager) withinNewTx(ctx context.Context, opts sql.TxOptions, fn func(ctx context.Context) error) error {
+	m.db.mu.Lock()
+	defer m.db.mu.Unlock()
+
+	return m.withinSavepoint(context.WithValue(ctx, inMemoryTxKey{db: m.db}, &inMemoryTxState{opts: opts}), fn)
+}
+
+// withinSavepoint must be called with the write lock held
+func (m *inMemoryTxManager) withinSavepoint(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	snapshot := m.db.tables.Clone()
+
+	defer func() {
+		// roll back on panic too, then let it continue unwinding
+		if rec := recover(); rec != nil {
+			*m.db.tables = *snapshot
+			panic(rec)
+		}
+		if err != nil {
+			*m.db.tables = *snapshot
+		}
+	}()
+
+	return fn(ctx)
+}
@@ -0,0 +1,250 @@
+package internal
+
+import (
+	"cmp"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"slices"
+
+	db_types "github.com/nimaeskandary/go-realworld/pkg/database/types"
+
+	"github.com/pressly/goose/v3"
+)
+
+const (
+	// migrationChecksumsTable holds the checksum of each applied migration's source, as it was when applied
+	migrationChecksumsTable = "migration_checksums"
+	// gooseVersionTable is where goose records applied versions, it is goose's default
+	gooseVersionTable = "goose_db_version"
+)
+
+// migrationSource is a migration known to the runner
+type migrationSource struct {
+	version int64
+	path    string
+	isCode  bool
+	// checksum is empty for a code migration without a source file
+	checksum string
+}
+
+// collectMigrationSources checksums the source of each migration the goose provider knows about
+func collectMigrationSources(gooseProvider *goose.Provider, sqlFs fs.FS, codeFs fs.FS) (map[int64]migrationSource, error) {
+	codePaths := map[int64]string{}
+	codeFiles, err := fs.Glob(codeFs, "*.go")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list code migration sources: %w", err)
+	}
+	for _, path := range codeFiles {
+		// files without a version, e.g. shared helpers, are not migrations
+		if version, err := goose.NumericComponent(path); err == nil {
+			codePaths[version] = path
+		}
+	}
+
+	sources := map[int64]migrationSource{}
+	for _, source := range gooseProvider.ListSources() {
+		migration := migrationSource{version: source.Version, path: source.Path, isCode: source.Type == goose.TypeGo}
+		sourceFs := sqlFs
+		if migration.isCode {
+			migration.path = codePaths[source.Version]
+			sourceFs = codeFs
+		}
+
+		if migration.path != "" {
+			content, err := fs.ReadFile(sourceFs, migration.path)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read migration %v: %w", migration.path, err)
+			}
+			sum := sha256.Sum256(content)
+			migration.checksum = hex.EncodeToString(sum[:])
+		}
+		sources[source.Version] = migration
+	}
+	return sources, nil
+}
+
+// placeholder is the nth query parameter in the dialect, starting at 1
+func (r *gooseMigrationRunner) placeholder(n int) string {
+	if r.db.GetDialect() == db_types.DialectPostgres {
+		return fmt.Sprintf("$%v", n)
+	}
+	return "?"
+}
+
+// prepareChecksums creates the checksums table. The first time, the migrations already applied are recorded with
+// their current sources, as there is nothing older to compare them with
+func (r *gooseMigrationRunner) prepareChecksums(ctx context.Context) error {
+	_, err := r.db.GetDB().ExecContext(ctx, fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %v (
+		version BIGINT PRIMARY KEY,
+		checksum TEXT NOT NULL,
+		recorded_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+	)`, migrationChecksumsTable))
+	if err != nil {
+		return fmt.Errorf("failed to create migration checksums table: %w", err)
+	}
+
+	recorded, err := r.recordedChecksums(ctx)
+	if err != nil {
+		return err
+	}
+	if len(recorded) > 0 {
+		return nil
+	}
+
+	applied, err := r.appliedVersions(ctx)
+	if err != nil {
+		return err
+	}
+	for _, version := range applied {
+		if err := r.recordChecksum(ctx, version); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// recordChecksum records the checksum of an applied migration. It runs after goose has applied the migration, so a
+// failure in between leaves it unrecorded, which Verify reports
+func (r *gooseMigrationRunner) recordChecksum(ctx context.Context, version int64) error {
+	source, ok := r.sources[version]
+	if !ok {
+		return nil
+	}
+
+	_, err := r.db.GetDB().ExecContext(ctx, fmt.Sprintf(
+		`INSERT INTO %v (version, checksum) VALUES (%v, %v)
+		ON CONFLICT (version) DO UPDATE SET checksum = excluded.checksum, recorded_at = CURRENT_TIMESTAMP`,
+		migrationChecksumsTable, r.placeholder(1), r.placeholder(2)), version, source.checksum)
+	if err != nil {
+		return fmt.Errorf("failed to record checksum of migration version %v: %w", version, err)
+	}
+	return nil
+}
+
+func (r *gooseMigrationRunner) deleteChecksum(ctx context.Context, version int64) error {
+	_, err := r.db.GetDB().ExecContext(ctx, fmt.Sprintf(`DELETE FROM %v WHERE version = %v`,
+		migrationChecksumsTable, r.placeholder(1)), version)
+	if err != nil {
+		return fmt.Errorf("failed to delete checksum of migration version %v: %w", version, err)
+	}
+	return nil
+}
+
+// recordResults records or deletes the checksums of the migrations that were run, skipping the ones that failed
+func (r *gooseMigrationRunner) recordResults(ctx context.Context, results ...*goose.MigrationResult) error {
+	for _, result := range results {
+		if result == nil || result.Error != nil {
+			continue
+		}
+		var err error
+		if result.Direction == "up" {
+			err = r.recordChecksum(ctx, result.Source.Version)
+		} else {
+			err = r.deleteChecksum(ctx, result.Source.Version)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// completedResults are the migrations that ran, when goose stops at a failed migration it returns them in the error
+func completedResults(results []*goose.MigrationResult, err error) []*goose.MigrationResult {
+	if partial, ok := errors.AsType[*goose.PartialError](err); ok {
+		return partial.Applied
+	}
+	return results
+}
+
+func (r *gooseMigrationRunner) recordedChecksums(ctx context.Context) (map[int64]string, error) {
+	rows, err := r.db.GetDB().QueryContext(ctx, fmt.Sprintf(`SELECT version, checksum FROM %v`, migrationChecksumsTable))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migration checksums: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	checksums := map[int64]string{}
+	for rows.Next() {
+		var version int64
+		var checksum string
+		if err := rows.Scan(&version, &checksum); err != nil {
+			return nil, fmt.Errorf("failed to scan migration checksum: %w", err)
+		}
+		checksums[version] = checksum
+	}
+	return checksums, rows.Err()
+}
+
+// appliedVersions reads the versions goose has applied, ascending, including ones without a source
+func (r *gooseMigrationRunner) appliedVersions(ctx context.Context) ([]int64, error) {
+	// makes sure goose's table exists on a new database
+	if _, err := r.gooseProvider.GetDBVersion(ctx); err != nil {
+		return nil, fmt.Errorf("failed to get current migration version: %w", err)
+	}
+
+	rows, err := r.db.GetDB().QueryContext(ctx, fmt.Sprintf(
+		`SELECT DISTINCT version_id FROM %v WHERE version_id > 0 AND is_applied ORDER BY version_id`, gooseVersionTable))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read applied migration versions: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var versions []int64
+	for rows.Next() {
+		var version int64
+		if err := rows.Scan(&version); err != nil {
+			return nil, fmt.Errorf("failed to scan applied migration version: %w", err)
+		}
+		versions = append(versions, version)
+	}
+	return versions, rows.Err()
+}
+
+func (r *gooseMigrationRunner) Verify(ctx context.Context) ([]db_types.MigrationDrift, error) {
+	if err := r.prepareChecksums(ctx); err != nil {
+		return nil, err
+	}
+	recorded, err := r.recordedChecksums(ctx)
+	if err != nil {
+		return nil, err
+	}
+	applied, err := r.appliedVersions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var drift []db_types.MigrationDrift
+	for _, version := range applied {
+		source, ok := r.sources[version]
+		if !ok {
+			drift = append(drift, db_types.MigrationDrift{Version: version, Kind: db_types.MigrationUnknown})
+			continue
+		}
+		checksum, ok := recorded[version]
+		switch {
+		case !ok:
+			drift = append(drift, db_types.MigrationDrift{Version: version, Kind: db_types.MigrationUnrecorded, Source: source.path})
+		// a code migration without a source has nothing to compare
+		case source.checksum != "" && checksum != source.checksum:
+			drift = append(drift, db_types.MigrationDrift{Version: version, Kind: db_types.MigrationModified, Source: source.path})
+		}
+	}
+
+	latestApplied := int64(0)
+	if len(applied) > 0 {
+		latestApplied = applied[len(applied)-1]
+	}
+	for version, source := range r.sources {
+		if version < latestApplied && !slices.Contains(applied, version) {
+			drift = append(drift, db_types.MigrationDrift{Version: version, Kind: db_types.MigrationMissing, Source: source.path})
+		}
+	}
+
+	slices.SortFunc(drift, func(a, b db_types.MigrationDrift) int { return cmp.Compare(a.Version, b.Version) })
+	return drift, nil
+}
@@ -0,0 +1,212 @@
+package internal_test
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/nimaeskandary/go-realworld/pkg/database/internal"
+	db_types "github.com/nimaeskandary/go-realworld/pkg/database/types"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_MigrationChecksums(t *testing.T) {
+	t.Parallel()
+
+	usersMigration := `-- +goose Up
+-- users of the app
+CREATE TABLE users (id INTEGER PRIMARY KEY, username TEXT);
+CREATE INDEX users_username ON users (username); -- lookups by name
+
+-- +goose StatementBegin
+CREATE TRIGGER users_lower AFTER INSERT ON users BEGIN
+  UPDATE users SET username = lower(username) WHERE id = new.id;
+END;
+-- +goose StatementEnd
+
+-- +goose Down
+DROP TABLE users;
+`
+	postsMigration := `-- +goose Up
+CREATE TABLE posts (id INTEGER PRIMARY KEY);
+-- +goose Down
+DROP TABLE posts;
+`
+	codeMigration := &StubGoMigration{
+		version: 2,
+		up: func(ctx context.Context, tx *sql.Tx) error {
+			_, err := tx.ExecContext(ctx, "INSERT INTO users (username) VALUES ('TestUser')")
+			return err
+		},
+		down: func(ctx context.Context, tx *sql.Tx) error {
+			_, err := tx.ExecContext(ctx, "DELETE FROM users")
+			return err
+		},
+	}
+
+	newSources := func() (fstest.MapFS, fstest.MapFS) {
+		sqlFs := fstest.MapFS{
+			"001_create_users.sql": {Data: []byte(usersMigration)},
+			"003_create_posts.sql": {Data: []byte(postsMigration)},
+		}
+		codeFs := fstest.MapFS{
+			"002_add_user.go": {Data: []byte("package migrations\n")},
+			"helpers.go":      {Data: []byte("package migrations\n")},
+		}
+		return sqlFs, codeFs
+	}
+
+	openDb := func(t *testing.T) db_types.SQLDatabase {
+		db, err := internal.NewSqliteSQLDatabase(db_types.SqlDbConfig{
+			Driver:     db_types.DialectSqlite,
+			SqlitePath: filepath.Join(t.TempDir(), "test.db"),
+		}, nil)
+		require.NoError(t, err)
+		require.NoError(t, db.Start(t.Context()))
+		t.Cleanup(func() { _ = db.Stop(context.Background()) })
+		return db
+	}
+
+	startRunner := func(t *testing.T, db db_types.SQLDatabase, sqlFs, codeFs fstest.MapFS) db_types.SqlMigrationRunner {
+		runner, err := internal.NewGooseMigrationRunner(db, &StubMigrationsProvider{
+			fs:             sqlFs,
+			codeFs:         codeFs,
+			codeMigrations: []db_types.GoMigration{codeMigration},
+		})
+		require.NoError(t, err)
+		require.NoError(t, runner.Start(t.Context()))
+		t.Cleanup(func() { _ = runner.Stop(context.Background()) })
+		return runner
+	}
+
+	t.Run("Verify", func(t *testing.T) {
+		t.Parallel()
+
+		t.Run("should find no drift after applying migrations", func(t *testing.T) {
+			t.Parallel()
+			sqlFs, codeFs := newSources()
+			runner := startRunner(t, openDb(t), sqlFs, codeFs)
+			require.NoError(t, runner.ApplyAll(t.Context()))
+
+			drift, err := runner.Verify(t.Context())
+			require.NoError(t, err)
+			assert.Empty(t, drift)
+		})
+
+		t.Run("should report migrations changed since they were applied", func(t *testing.T) {
+			t.Parallel()
+			db := openDb(t)
+			sqlFs, codeFs := newSources()
+			require.NoError(t, startRunner(t, db, sqlFs, codeFs).ApplyAll(t.Context()))
+
+			sqlFs["001_create_users.sql"] = &fstest.MapFile{Data: []byte(usersMigration + "-- edited\n")}
+			codeFs["002_add_user.go"] = &fstest.MapFile{Data: []byte("package migrations // edited\n")}
+			drift, err := startRunner(t, db, sqlFs, codeFs).Verify(t.Context())
+			require.NoError(t, err)
+			assert.Equal(t, []db_types.MigrationDrift{
+				{Version: 1, Kind: db_types.MigrationModified, Source: "001_create_users.sql"},
+				{Version: 2, Kind: db_types.MigrationModified, Source: "002_add_user.go"},
+			}, drift)
+		})
+
+		t.Run("should report applied migrations without a source", func(t *testing.T) {
+			t.Parallel()
+			db := openDb(t)
+			sqlFs, codeFs := newSources()
+			require.NoError(t, startRunner(t, db, sqlFs, codeFs).ApplyAll(t.Context()))
+
+			delete(sqlFs, "003_create_posts.sql")
+			drift, err := startRunner(t, db, sqlFs, codeFs).Verify(t.Context())
+			require.NoError(t, err)
+			assert.Equal(t, []db_types.MigrationDrift{{Version: 3, Kind: db_types.MigrationUnknown}}, drift)
+		})
+
+		t.Run("should report migrations older than the latest applied that never ran", func(t *testing.T) {
+			t.Parallel()
+			db := openDb(t)
+			sqlFs, codeFs := newSources()
+			runner := startRunner(t, db, sqlFs, codeFs)
+			require.NoError(t, runner.ApplyAll(t.Context()))
+			require.NoError(t, runner.Rollback(t.Context(), 2))
+
+			drift, err := runner.Verify(t.Context())
+			require.NoError(t, err)
+			assert.Equal(t, []db_types.MigrationDrift{
+				{Version: 2, Kind: db_types.MigrationMissing, Source: "002_add_user.go"},
+			}, drift)
+		})
+
+		t.Run("should baseline migrations applied before checksums were recorded", func(t *testing.T) {
+			t.Parallel()
+			db := openDb(t)
+			sqlFs, codeFs := newSources()
+			require.NoError(t, startRunner(t, db, sqlFs, codeFs).ApplyAll(t.Context()))
+			_, err := db.GetDB().ExecContext(t.Context(), "DROP TABLE migration_checksums")
+			require.NoError(t, err)
+
+			drift, err := startRunner(t, db, sqlFs, codeFs).Verify(t.Context())
+			require.NoError(t, err)
+			assert.Empty(t, drift)
+		})
+	})
+
+	t.Run("Plan", func(t *testing.T) {
+		t.Parallel()
+
+		t.Run("should list the statements of pending migrations without running them", func(t *testing.T) {
+			t.Parallel()
+			db := openDb(t)
+			sqlFs, codeFs := newSources()
+			runner := startRunner(t, db, sqlFs, codeFs)
+
+			plan, err := runner.PlanApplyAll(t.Context())
+			require.NoError(t, err)
+			assert.Equal(t, []db_types.PlannedMigration{
+				{Version: 1, Up: true, Source: "001_create_users.sql", Statements: []string{
+					"CREATE TABLE users (id INTEGER PRIMARY KEY, username TEXT);",
+					"CREATE INDEX users_username ON users (username); -- lookups by name",
+					"CREATE TRIGGER users_lower AFTER INSERT ON users BEGIN\n" +
+						"  UPDATE users SET username = lower(username) WHERE id = new.id;\nEND;",
+				}},
+				{Version: 2, Up: true, Source: "002_add_user.go"},
+				{Version: 3, Up: true, Source: "003_create_posts.sql", Statements: []string{
+					"CREATE TABLE posts (id INTEGER PRIMARY KEY);",
+				}},
+			}, plan)
+
+			version, err := runner.CurrentVersion(t.Context())
+			require.NoError(t, err)
+			assert.Equal(t, int64(0), version)
+		})
+
+		t.Run("should list rollbacks newest first", func(t *testing.T) {
+			t.Parallel()
+			sqlFs, codeFs := newSources()
+			runner := startRunner(t, openDb(t), sqlFs, codeFs)
+			require.NoError(t, runner.ApplyAll(t.Context()))
+
+			plan, err := runner.PlanRollbackTo(t.Context(), 1)
+			require.NoError(t, err)
+			assert.Equal(t, []db_types.PlannedMigration{
+				{Version: 3, Up: false, Source: "003_create_posts.sql", Statements: []string{"DROP TABLE posts;"}},
+				{Version: 2, Up: false, Source: "002_add_user.go"},
+			}, plan)
+		})
+
+		t.Run("should refuse to plan a migration that is already applied", func(t *testing.T) {
+			t.Parallel()
+			sqlFs, codeFs := newSources()
+			runner := startRunner(t, openDb(t), sqlFs, codeFs)
+			require.NoError(t, runner.Apply(t.Context(), 1))
+
+			_, err := runner.PlanApply(t.Context(), 1)
+			assert.Error(t, err)
+			_, err = runner.PlanRollback(t.Context(), 3)
+			assert.Error(t, err)
+		})
+	})
+}
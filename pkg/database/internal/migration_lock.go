@@ -0,0 +1,38 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+
+	db_types "github.com/nimaeskandary/go-realworld/pkg/database/types"
+)
+
+// migrationLockKey identifies the postgres advisory lock held while migrations run, the value is arbitrary but must
+// not be used for any other lock
+const migrationLockKey int64 = 4_106_212_388_547_110_209
+
+// lock holds the migration lock until the returned unlock is called, so concurrent runners, e.g. replicas migrating
+// as they start, run migrations one at a time. A runner waits for the lock for as long as ctx allows. Sqlite allows
+// one writer at a time already, and shares a single connection, so there is no lock for it
+func (r *gooseMigrationRunner) lock(ctx context.Context) (func(), error) {
+	if r.db.GetDialect() != db_types.DialectPostgres {
+		return func() {}, nil
+	}
+
+	// an advisory lock belongs to a session, so it is taken and released on one connection held for the duration
+	conn, err := r.db.GetDB().Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get a connection for the migration lock: %w", err)
+	}
+	if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", migrationLockKey); err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("failed to acquire the migration lock: %w", err)
+	}
+
+	return func() {
+		// the lock is released even if ctx is done, closing the connection would release it too but it goes back to
+		// the pool instead
+		_, _ = conn.ExecContext(context.WithoutCancel(ctx), "SELECT pg_advisory_unlock($1)", migrationLockKey)
+		_ = conn.Close()
+	}, nil
+}
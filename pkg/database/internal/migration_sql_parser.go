@@ -0,0 +1,86 @@
+package internal
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// splitSqlMigration returns the statements of one direction of a goose sql migration, the way goose splits them:
+// statements end at a line ending in a semicolon, unless they are between StatementBegin and StatementEnd
+// annotations. Comments before a statement are dropped. It is used to show what a migration would run, goose parses
+// the file itself when it runs
+func splitSqlMigration(r io.Reader, up bool) ([]string, error) {
+	const (
+		sectionNone = iota
+		sectionUp
+		sectionDown
+	)
+	section := sectionNone
+	inBlock := false
+
+	var statements []string
+	var statement strings.Builder
+	flush := func() {
+		if text := strings.TrimSpace(statement.String()); text != "" {
+			statements = append(statements, text)
+		}
+		statement.Reset()
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+
+		if annotation, ok := strings.CutPrefix(trimmed, "-- +goose "); ok {
+			switch strings.TrimSpace(annotation) {
+			case "Up":
+				section = sectionUp
+			case "Down":
+				flush()
+				section = sectionDown
+			case "StatementBegin":
+				inBlock = true
+			case "StatementEnd":
+				inBlock = false
+				if (section == sectionUp) == up {
+					flush()
+				}
+			}
+			continue
+		}
+
+		if section == sectionNone || (section == sectionUp) != up {
+			continue
+		}
+		if statement.Len() == 0 && (trimmed == "" || strings.HasPrefix(trimmed, "--")) {
+			continue
+		}
+
+		statement.WriteString(line)
+		statement.WriteString("\n")
+		if !inBlock && endsWithSemicolon(line) {
+			flush()
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read sql migration: %w", err)
+	}
+	if inBlock {
+		return nil, fmt.Errorf("sql migration is missing a StatementEnd annotation")
+	}
+
+	flush()
+	return statements, nil
+}
+
+// endsWithSemicolon ignores a trailing comment
+func endsWithSemicolon(line string) bool {
+	if before, _, found := strings.Cut(line, "--"); found {
+		line = before
+	}
+	return strings.HasSuffix(strings.TrimSpace(line), ";")
+}
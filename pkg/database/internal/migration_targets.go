@@ -0,0 +1,45 @@
+package internal
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+
+	db_types "github.com/nimaeskandary/go-realworld/pkg/database/types"
+)
+
+var migrationTargets = map[string]db_types.MigrationTarget{}
+var migrationTargetsLock = &sync.Mutex{}
+
+// RegisterMigrationTarget adds a database to the ones the migrations command can run against, call it from an init()
+// function of the database's migrations package. It panics if the name is already registered, as two databases
+// sharing a name is a programming error
+func RegisterMigrationTarget(target db_types.MigrationTarget) {
+	migrationTargetsLock.Lock()
+	defer migrationTargetsLock.Unlock()
+	if _, ok := migrationTargets[target.Name]; ok {
+		panic(fmt.Sprintf("migration target %v is already registered", target.Name))
+	}
+	migrationTargets[target.Name] = target
+}
+
+// MigrationTargets are the registered databases, ordered by name
+func MigrationTargets() []db_types.MigrationTarget {
+	migrationTargetsLock.Lock()
+	defer migrationTargetsLock.Unlock()
+	return slices.SortedFunc(maps.Values(migrationTargets), func(a, b db_types.MigrationTarget) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+}
+
+func GetMigrationTarget(name string) (db_types.MigrationTarget, error) {
+	migrationTargetsLock.Lock()
+	defer migrationTargetsLock.Unlock()
+	target, ok := migrationTargets[name]
+	if !ok {
+		return db_types.MigrationTarget{}, fmt.Errorf("unknown target database: %v", name)
+	}
+	return target, nil
+}
@@ -0,0 +1,61 @@
+package internal_test
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/nimaeskandary/go-realworld/pkg/database/internal"
+	db_types "github.com/nimaeskandary/go-realworld/pkg/database/types"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_MigrationTargets(t *testing.T) {
+	t.Parallel()
+
+	// the registry is global, so each test registers its own names
+	newTarget := func(name string) db_types.MigrationTarget {
+		return db_types.MigrationTarget{
+			Name:               name,
+			ConfigKey:          name + "_db",
+			MigrationsProvider: &StubMigrationsProvider{fs: fstest.MapFS{}, codeFs: fstest.MapFS{}},
+		}
+	}
+
+	t.Run("should get a registered target by name", func(t *testing.T) {
+		t.Parallel()
+		internal.RegisterMigrationTarget(newTarget("test_get"))
+
+		target, err := internal.GetMigrationTarget("test_get")
+		require.NoError(t, err)
+		assert.Equal(t, "test_get_db", target.ConfigKey)
+	})
+
+	t.Run("should fail to get an unknown target", func(t *testing.T) {
+		t.Parallel()
+		_, err := internal.GetMigrationTarget("test_unknown")
+		assert.Error(t, err)
+	})
+
+	t.Run("should list targets by name", func(t *testing.T) {
+		t.Parallel()
+		internal.RegisterMigrationTarget(newTarget("test_list_b"))
+		internal.RegisterMigrationTarget(newTarget("test_list_a"))
+
+		var names []string
+		for _, target := range internal.MigrationTargets() {
+			if target.Name == "test_list_a" || target.Name == "test_list_b" {
+				names = append(names, target.Name)
+			}
+		}
+		assert.Equal(t, []string{"test_list_a", "test_list_b"}, names)
+	})
+
+	t.Run("should panic when a name is registered twice", func(t *testing.T) {
+		t.Parallel()
+		internal.RegisterMigrationTarget(newTarget("test_twice"))
+
+		assert.Panics(t, func() { internal.RegisterMigrationTarget(newTarget("test_twice")) })
+	})
+}
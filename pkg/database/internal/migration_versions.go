@@ -0,0 +1,104 @@
+package internal
+
+import (
+	"cmp"
+	"fmt"
+	"io/fs"
+	"slices"
+
+	db_types "github.com/nimaeskandary/go-realworld/pkg/database/types"
+
+	"github.com/pressly/goose/v3"
+)
+
+// CheckMigrationVersions checks that the migrations of each dialect, sql and code together, have exactly one
+// migration for every version from 1 to the latest of any dialect, and that every code migration source file is
+// registered. It also returns that latest version, which a new migration follows
+func CheckMigrationVersions(provider db_types.MigrationsProvider) (int64, []db_types.MigrationVersionIssue, error) {
+	latest := int64(0)
+	var issues []db_types.MigrationVersionIssue
+	codePaths := map[int64][]string{}
+	registered := map[int64]bool{}
+
+	for _, dialect := range db_types.Dialects {
+		codeFs, err := provider.GetCodeMigrationsSourceFs(dialect)
+		if err != nil {
+			return 0, nil, err
+		}
+		codeFiles, err := fs.Glob(codeFs, "*.go")
+		if err != nil {
+			return 0, nil, fmt.Errorf("failed to list code migration sources: %w", err)
+		}
+		for _, path := range codeFiles {
+			// files without a version, e.g. shared helpers, are not migrations
+			if version, err := goose.NumericComponent(path); err == nil && !slices.Contains(codePaths[version], path) {
+				codePaths[version] = append(codePaths[version], path)
+			}
+		}
+	}
+
+	dialectSources := map[string]map[int64][]string{}
+	for _, dialect := range db_types.Dialects {
+		sqlFs, err := provider.GetSqlMigrationsFs(dialect)
+		if err != nil {
+			return 0, nil, err
+		}
+		sqlFiles, err := fs.Glob(sqlFs, "*.sql")
+		if err != nil {
+			return 0, nil, fmt.Errorf("failed to list %v sql migrations: %w", dialect, err)
+		}
+
+		sources := map[int64][]string{}
+		for _, path := range sqlFiles {
+			version, err := goose.NumericComponent(path)
+			if err != nil {
+				return 0, nil, fmt.Errorf("%v sql migration %v is not named with its version: %w", dialect, path, err)
+			}
+			sources[version] = append(sources[version], path)
+			latest = max(latest, version)
+		}
+		for _, migration := range *provider.GetCodeMigrations(dialect) {
+			version := migration.Version()
+			registered[version] = true
+			source := fmt.Sprintf("%T", migration)
+			if paths := codePaths[version]; len(paths) == 1 {
+				source = paths[0]
+			}
+			sources[version] = append(sources[version], source)
+			latest = max(latest, version)
+		}
+		dialectSources[dialect] = sources
+	}
+
+	// every dialect needs every version, so one behind the others has gaps up to the latest
+	for _, dialect := range db_types.Dialects {
+		sources := dialectSources[dialect]
+		for version := int64(1); version <= latest; version++ {
+			switch {
+			case len(sources[version]) == 0:
+				issues = append(issues, db_types.MigrationVersionIssue{
+					Version: version, Kind: db_types.MigrationGap, Dialect: dialect,
+				})
+			case len(sources[version]) > 1:
+				slices.Sort(sources[version])
+				issues = append(issues, db_types.MigrationVersionIssue{
+					Version: version, Kind: db_types.MigrationDuplicate, Dialect: dialect, Sources: sources[version],
+				})
+			}
+		}
+	}
+
+	for version, paths := range codePaths {
+		if !registered[version] {
+			slices.Sort(paths)
+			issues = append(issues, db_types.MigrationVersionIssue{
+				Version: version, Kind: db_types.MigrationUnregistered, Sources: paths,
+			})
+		}
+	}
+
+	slices.SortFunc(issues, func(a, b db_types.MigrationVersionIssue) int {
+		return cmp.Or(cmp.Compare(a.Version, b.Version), cmp.Compare(a.Kind, b.Kind), cmp.Compare(a.Dialect, b.Dialect))
+	})
+	return latest, issues, nil
+}
@@ -0,0 +1,83 @@
+package internal_test
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/nimaeskandary/go-realworld/pkg/database/internal"
+	db_types "github.com/nimaeskandary/go-realworld/pkg/database/types"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_CheckMigrationVersions(t *testing.T) {
+	t.Parallel()
+
+	sqlMigration := &fstest.MapFile{Data: []byte("-- +goose Up\nSELECT 1;\n")}
+	codeSource := &fstest.MapFile{Data: []byte("package migrations\n")}
+
+	t.Run("should return the latest version of sql and code migrations without issues", func(t *testing.T) {
+		t.Parallel()
+		latest, issues, err := internal.CheckMigrationVersions(&StubMigrationsProvider{
+			fs:             fstest.MapFS{"00001_a.sql": sqlMigration, "00003_c.sql": sqlMigration},
+			codeFs:         fstest.MapFS{"00002_b.go": codeSource, "helpers.go": codeSource},
+			codeMigrations: []db_types.GoMigration{&StubGoMigration{version: 2}},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), latest)
+		assert.Empty(t, issues)
+	})
+
+	t.Run("should report versions used by a sql and a code migration", func(t *testing.T) {
+		t.Parallel()
+		_, issues, err := internal.CheckMigrationVersions(&StubMigrationsProvider{
+			fs:             fstest.MapFS{"00001_a.sql": sqlMigration},
+			codeFs:         fstest.MapFS{"00001_b.go": codeSource},
+			codeMigrations: []db_types.GoMigration{&StubGoMigration{version: 1}},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []db_types.MigrationVersionIssue{
+			{Version: 1, Kind: db_types.MigrationDuplicate, Dialect: db_types.DialectPostgres, Sources: []string{"00001_a.sql", "00001_b.go"}},
+			{Version: 1, Kind: db_types.MigrationDuplicate, Dialect: db_types.DialectSqlite, Sources: []string{"00001_a.sql", "00001_b.go"}},
+		}, issues)
+	})
+
+	t.Run("should report versions without a migration", func(t *testing.T) {
+		t.Parallel()
+		_, issues, err := internal.CheckMigrationVersions(&StubMigrationsProvider{
+			fs:     fstest.MapFS{"00001_a.sql": sqlMigration, "00003_c.sql": sqlMigration},
+			codeFs: fstest.MapFS{},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []db_types.MigrationVersionIssue{
+			{Version: 2, Kind: db_types.MigrationGap, Dialect: db_types.DialectPostgres},
+			{Version: 2, Kind: db_types.MigrationGap, Dialect: db_types.DialectSqlite},
+		}, issues)
+	})
+
+	t.Run("should report code migration sources that are not registered with their version", func(t *testing.T) {
+		t.Parallel()
+		_, issues, err := internal.CheckMigrationVersions(&StubMigrationsProvider{
+			fs:     fstest.MapFS{"00001_a.sql": sqlMigration},
+			codeFs: fstest.MapFS{"00002_b.go": codeSource},
+			// the Version() does not match the file name
+			codeMigrations: []db_types.GoMigration{&StubGoMigration{version: 3}},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []db_types.MigrationVersionIssue{
+			{Version: 2, Kind: db_types.MigrationGap, Dialect: db_types.DialectPostgres},
+			{Version: 2, Kind: db_types.MigrationGap, Dialect: db_types.DialectSqlite},
+			{Version: 2, Kind: db_types.MigrationUnregistered, Sources: []string{"00002_b.go"}},
+		}, issues)
+	})
+
+	t.Run("should fail on a sql migration not named with its version", func(t *testing.T) {
+		t.Parallel()
+		_, _, err := internal.CheckMigrationVersions(&StubMigrationsProvider{
+			fs:     fstest.MapFS{"create_users.sql": sqlMigration},
+			codeFs: fstest.MapFS{},
+		})
+		assert.Error(t, err)
+	})
+}
@@ -5,45 +5,154 @@ import (
 	"database/sql"
 	"fmt"
 	"net/url"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/nimaeskandary/go-realworld/pkg/database/types"
+	obs_types "github.com/nimaeskandary/go-realworld/pkg/observability/types"
 
 	_ "github.com/jackc/pgx/v5/stdlib"
+	"github.com/samber/mo"
 )
 
-type postgresDb struct {
-	cfg db_types.SqlDbConfig
+// replicaStatusSql reads what a replica reports about its replication into a ReplicaStatus. pg_stat_wal_receiver only
+// shows the status to superusers and members of pg_read_all_stats, which Start checks for when replicas are configured
+const replicaStatusSql = `SELECT
+	pg_is_in_recovery(),
+	COALESCE(pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn(), false),
+	EXISTS (SELECT 1 FROM pg_stat_wal_receiver WHERE status = 'streaming'),
+	EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp()) * 1000`
+
+// canReadReplicaStatusSql checks the database user can see the status columns of pg_stat_wal_receiver, superusers are
+// members of every role
+const canReadReplicaStatusSql = `SELECT pg_has_role(current_user, 'pg_read_all_stats', 'MEMBER')`
+
+// ReplicaStatus is what a replica reports about its replication
+type ReplicaStatus struct {
+	InRecovery bool
+	// ReplayedAllReceived is true when the replica has replayed all the WAL it has received
+	ReplayedAllReceived bool
+	// Streaming is true while the replica's WAL receiver is connected to the primary
+	Streaming bool
+	// ReplayAgeMillis is how long ago the last replayed transaction was committed, null until one has been replayed
+	ReplayAgeMillis sql.NullFloat64
+}
+
+// LagMillis is how far behind its primary the replica is, none if it has not replayed anything yet. A streaming
+// replica that has replayed everything it has received is caught up even if the last replayed transaction is old, e.g.
+// when the primary is idle. A replica that is not streaming may be missing WAL it has not received, so it is as far
+// behind as its last replayed transaction
+func (s ReplicaStatus) LagMillis() mo.Option[float64] {
+	if !s.InRecovery || (s.Streaming && s.ReplayedAllReceived) {
+		return mo.Some(0.0)
+	}
+	if !s.ReplayAgeMillis.Valid {
+		return mo.None[float64]()
+	}
+	return mo.Some(s.ReplayAgeMillis.Float64)
+}
+
+type postgresReplica struct {
+	cfg db_types.SqlDbReplicaConfig
 	db  *sql.DB
+	// inLag is true while the replica is reachable and within the configured lag, only then does it serve reads
+	inLag atomic.Bool
 }
 
-func NewPostgresSQLDatabase(cfg db_types.SqlDbConfig) (db_types.SQLDatabase, error) {
+type postgresDb struct {
+	cfg      db_types.SqlDbConfig
+	db       *sql.DB
+	recorder *queryRecorder
+	replicas []*postgresReplica
+	// next round robins reads across replicas
+	next atomic.Uint64
+
+	stopMonitor context.CancelFunc
+	monitorDone sync.WaitGroup
+}
+
+// NewPostgresSQLDatabase creates a database that records every query run against the primary and replicas, slow
+// queries are logged with logger, which can be nil to only record them
+func NewPostgresSQLDatabase(cfg db_types.SqlDbConfig, logger obs_types.Logger) (db_types.SQLDatabase, error) {
 	return &postgresDb{
-		cfg: cfg,
-		db:  nil,
+		cfg:      cfg,
+		db:       nil,
+		recorder: newQueryRecorder(logger, cfg),
 	}, nil
 }
 
-func (d *postgresDb) Start(ctx context.Context) error {
-	connectionString := fmt.Sprintf("postgresql://%v:%v@%v:%v/%v?sslmode=%v",
-		url.PathEscape(d.cfg.Username),
-		url.PathEscape(string(d.cfg.Password)),
-		d.cfg.Host,
-		d.cfg.Port,
-		url.PathEscape(d.cfg.DBName),
-		d.cfg.SslMode)
-	db, err := sql.Open("pgx", connectionString)
+func (d *postgresDb) Start(ctx context.Context) (err error) {
+	// fx does not call Stop after a failed Start, so whatever was opened is closed here
+	defer func() {
+		if err != nil {
+			_ = d.Stop(ctx)
+		}
+	}()
+
+	d.db, err = d.open(d.cfg.Host, d.cfg.Port)
 	if err != nil {
 		return fmt.Errorf("error connecting to postgres database: %w", err)
 	}
-	err = db.PingContext(ctx)
+	err = d.pingWithBackoff(ctx, d.db)
 	if err != nil {
 		return fmt.Errorf("error pinging postgres database: %w", err)
 	}
-	d.db = db
+
+	if len(d.cfg.Replicas) > 0 {
+		err = d.checkCanReadReplicaStatus(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	// an unreachable replica does not fail startup, it just does not serve reads until the monitor sees it catch up
+	for _, replicaCfg := range d.cfg.Replicas {
+		replicaDb, err := d.open(replicaCfg.Host, replicaCfg.Port)
+		if err != nil {
+			return fmt.Errorf("error connecting to postgres replica %v:%v: %w", replicaCfg.Host, replicaCfg.Port, err)
+		}
+		d.replicas = append(d.replicas, &postgresReplica{cfg: replicaCfg, db: replicaDb})
+	}
+
+	if len(d.replicas) > 0 {
+		d.checkReplicaLag(ctx)
+
+		monitorCtx, cancel := context.WithCancel(context.Background())
+		d.stopMonitor = cancel
+		d.monitorDone.Go(func() {
+			d.monitorReplicaLag(monitorCtx)
+		})
+	}
+
+	return nil
+}
+
+// checkCanReadReplicaStatus fails if the database user can not read pg_stat_wal_receiver, without it no replica is
+// ever seen as streaming, so replicas of an idle primary would never serve reads. Roles are shared by the primary and
+// its replicas, so this is checked on the primary, which unlike a replica has to be reachable at startup
+func (d *postgresDb) checkCanReadReplicaStatus(ctx context.Context) error {
+	var canRead bool
+	err := d.db.QueryRowContext(ctx, canReadReplicaStatusSql).Scan(&canRead)
+	if err != nil {
+		return fmt.Errorf("error checking the database user can read replica status: %w", err)
+	}
+	if !canRead {
+		return fmt.Errorf("database user %v must be a superuser or a member of pg_read_all_stats to use replicas", d.cfg.Username)
+	}
 	return nil
 }
 
 func (d *postgresDb) Stop(_ context.Context) error {
+	if d.stopMonitor != nil {
+		d.stopMonitor()
+		d.monitorDone.Wait()
+		d.stopMonitor = nil
+	}
+	for _, replica := range d.replicas {
+		_ = replica.db.Close()
+	}
+	d.replicas = nil
 	if d.db != nil {
 		_ = d.db.Close()
 		d.db = nil
@@ -55,6 +164,129 @@ func (d *postgresDb) GetDB() *sql.DB {
 	return d.db
 }
 
+func (d *postgresDb) GetReadDB() *sql.DB {
+	count := uint64(len(d.replicas))
+	start := d.next.Add(1)
+	for i := range count {
+		replica := d.replicas[(start+i)%count]
+		if replica.inLag.Load() {
+			return replica.db
+		}
+	}
+	return d.db
+}
+
 func (d *postgresDb) GetDialect() string {
-	return "postgres"
+	return db_types.DialectPostgres
+}
+
+func (d *postgresDb) HealthCheck(ctx context.Context) (db_types.HealthStatus, error) {
+	status := db_types.HealthStatus{
+		Replicas: make([]db_types.ReplicaHealthStatus, len(d.replicas)),
+	}
+
+	for i, replica := range d.replicas {
+		latency, err := ping(ctx, replica.db)
+		status.Replicas[i] = db_types.ReplicaHealthStatus{
+			Host:    replica.cfg.Host,
+			Port:    replica.cfg.Port,
+			InLag:   replica.inLag.Load(),
+			Latency: latency,
+			Pool:    replica.db.Stats(),
+			Err:     err,
+		}
+	}
+
+	latency, err := ping(ctx, d.db)
+	status.Latency = latency
+	status.Pool = d.db.Stats()
+	if err != nil {
+		return status, fmt.Errorf("error pinging postgres database: %w", err)
+	}
+	return status, nil
+}
+
+func (d *postgresDb) QueryStats() []db_types.QueryStats {
+	return d.recorder.snapshot()
+}
+
+// open creates a connection pool, it does not connect until first used
+func (d *postgresDb) open(host string, port int) (*sql.DB, error) {
+	db, err := openInstrumented("pgx", d.connectionString(host, port), d.recorder)
+	if err != nil {
+		return nil, err
+	}
+	db.SetMaxOpenConns(d.cfg.MaxOpenConns)
+	// database/sql treats zero as "no idle connections", not as unset
+	if d.cfg.MaxIdleConns > 0 {
+		db.SetMaxIdleConns(d.cfg.MaxIdleConns)
+	}
+	db.SetConnMaxLifetime(time.Duration(d.cfg.ConnMaxLifetimeSeconds) * time.Second)
+	db.SetConnMaxIdleTime(time.Duration(d.cfg.ConnMaxIdleTimeSeconds) * time.Second)
+	return db, nil
+}
+
+// pingWithBackoff retries the ping until it succeeds, the attempts run out, or ctx is done
+func (d *postgresDb) pingWithBackoff(ctx context.Context, db *sql.DB) error {
+	attempts := max(d.cfg.StartupPingAttempts, 1)
+	backoff := time.Duration(d.cfg.StartupPingBackoffMillis) * time.Millisecond
+
+	var err error
+	for attempt := 1; ; attempt++ {
+		err = db.PingContext(ctx)
+		if err == nil || attempt >= attempts {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("gave up after %v attempts: %w", attempt, err)
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+func ping(ctx context.Context, db *sql.DB) (time.Duration, error) {
+	started := time.Now()
+	err := db.PingContext(ctx)
+	return time.Since(started), err
+}
+
+func (d *postgresDb) connectionString(host string, port int) string {
+	return fmt.Sprintf("postgresql://%v:%v@%v:%v/%v?sslmode=%v",
+		url.PathEscape(d.cfg.Username),
+		url.PathEscape(string(d.cfg.Password)),
+		host,
+		port,
+		url.PathEscape(d.cfg.DBName),
+		d.cfg.SslMode)
+}
+
+func (d *postgresDb) monitorReplicaLag(ctx context.Context) {
+	ticker := time.NewTicker(time.Duration(d.cfg.ReplicaLagCheckIntervalMillis) * time.Millisecond)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			d.checkReplicaLag(ctx)
+		}
+	}
+}
+
+func (d *postgresDb) checkReplicaLag(ctx context.Context) {
+	// a check must not outlast the interval, or a hung replica would keep serving on a stale result
+	checkCtx, cancel := context.WithTimeout(ctx, time.Duration(d.cfg.ReplicaLagCheckIntervalMillis)*time.Millisecond)
+	defer cancel()
+
+	for _, replica := range d.replicas {
+		var status ReplicaStatus
+		err := replica.db.QueryRowContext(checkCtx, replicaStatusSql).
+			Scan(&status.InRecovery, &status.ReplayedAllReceived, &status.Streaming, &status.ReplayAgeMillis)
+		lagMillis, ok := status.LagMillis().Get()
+		replica.inLag.Store(err == nil && ok && lagMillis <= float64(d.cfg.ReplicaMaxLagMillis))
+	}
 }
@@ -0,0 +1,235 @@
+package internal_test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nimaeskandary/go-realworld/pkg/database/internal"
+	db_types "github.com/nimaeskandary/go-realworld/pkg/database/types"
+
+	"github.com/google/uuid"
+	"github.com/samber/mo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_PostgresSQLDatabase(t *testing.T) {
+	t.Parallel()
+
+	// the test postgres is not a replica, so it reports no lag when used as one
+	testCfg := db_types.SqlDbConfig{
+		Host:                          "localhost",
+		Port:                          15432,
+		Username:                      "testpostgres",
+		Password:                      "testpassword",
+		DBName:                        "postgres",
+		SslMode:                       "disable",
+		ReplicaMaxLagMillis:           1000,
+		ReplicaLagCheckIntervalMillis: 100,
+	}
+
+	start := func(t *testing.T, cfg db_types.SqlDbConfig) db_types.SQLDatabase {
+		db, err := internal.NewPostgresSQLDatabase(cfg, nil)
+		require.NoError(t, err)
+		require.NoError(t, db.Start(t.Context()))
+		t.Cleanup(func() { _ = db.Stop(context.Background()) })
+		return db
+	}
+
+	t.Run("GetReadDB", func(t *testing.T) {
+		t.Parallel()
+
+		t.Run("should return the primary when there are no replicas", func(t *testing.T) {
+			t.Parallel()
+			db := start(t, testCfg)
+
+			assert.Same(t, db.GetDB(), db.GetReadDB())
+		})
+
+		t.Run("should return a replica that is within the lag", func(t *testing.T) {
+			t.Parallel()
+			cfg := testCfg
+			cfg.Replicas = []db_types.SqlDbReplicaConfig{{Host: testCfg.Host, Port: testCfg.Port}}
+			db := start(t, cfg)
+
+			assert.NotSame(t, db.GetDB(), db.GetReadDB())
+			assert.NoError(t, db.GetReadDB().PingContext(t.Context()))
+		})
+
+		t.Run("should fall back to the primary when replicas are unreachable", func(t *testing.T) {
+			t.Parallel()
+			cfg := testCfg
+			cfg.Replicas = []db_types.SqlDbReplicaConfig{{Host: testCfg.Host, Port: 1}}
+			db := start(t, cfg)
+
+			assert.Same(t, db.GetDB(), db.GetReadDB())
+		})
+	})
+
+	t.Run("Start", func(t *testing.T) {
+		t.Parallel()
+
+		t.Run("should fail and close the primary if replicas are configured and the user can not read replica status", func(t *testing.T) {
+			t.Parallel()
+			admin := start(t, testCfg)
+			username := "no_stats_" + strings.ReplaceAll(uuid.NewString(), "-", "")
+			_, err := admin.GetDB().ExecContext(t.Context(), fmt.Sprintf("CREATE ROLE %v LOGIN PASSWORD 'testpassword'", username))
+			require.NoError(t, err)
+			t.Cleanup(func() {
+				_, _ = admin.GetDB().ExecContext(context.Background(), fmt.Sprintf("DROP ROLE %v", username))
+			})
+
+			cfg := testCfg
+			cfg.Username = username
+			cfg.Replicas = []db_types.SqlDbReplicaConfig{{Host: testCfg.Host, Port: testCfg.Port}}
+			db, err := internal.NewPostgresSQLDatabase(cfg, nil)
+			require.NoError(t, err)
+
+			err = db.Start(t.Context())
+			assert.ErrorContains(t, err, "pg_read_all_stats")
+			assert.Nil(t, db.GetDB())
+		})
+
+		t.Run("should apply the pool settings", func(t *testing.T) {
+			t.Parallel()
+			cfg := testCfg
+			cfg.MaxOpenConns = 3
+			db := start(t, cfg)
+
+			assert.Equal(t, 3, db.GetDB().Stats().MaxOpenConnections)
+		})
+
+		t.Run("should retry the ping with backoff before failing", func(t *testing.T) {
+			t.Parallel()
+			cfg := testCfg
+			cfg.Port = 1
+			cfg.StartupPingAttempts = 3
+			cfg.StartupPingBackoffMillis = 20
+			db, err := internal.NewPostgresSQLDatabase(cfg, nil)
+			require.NoError(t, err)
+
+			started := time.Now()
+			err = db.Start(t.Context())
+			assert.Error(t, err)
+			// waits of 20ms then 40ms between the three attempts
+			assert.GreaterOrEqual(t, time.Since(started), 60*time.Millisecond)
+		})
+
+		t.Run("should stop retrying when ctx is done", func(t *testing.T) {
+			t.Parallel()
+			cfg := testCfg
+			cfg.Port = 1
+			cfg.StartupPingAttempts = 100
+			cfg.StartupPingBackoffMillis = 1000
+			db, err := internal.NewPostgresSQLDatabase(cfg, nil)
+			require.NoError(t, err)
+
+			ctx, cancel := context.WithTimeout(t.Context(), 50*time.Millisecond)
+			defer cancel()
+
+			started := time.Now()
+			err = db.Start(ctx)
+			assert.Error(t, err)
+			assert.Less(t, time.Since(started), time.Second)
+		})
+	})
+
+	t.Run("HealthCheck", func(t *testing.T) {
+		t.Parallel()
+
+		t.Run("should report the primary's latency and pool stats", func(t *testing.T) {
+			t.Parallel()
+			db := start(t, testCfg)
+
+			status, err := db.HealthCheck(t.Context())
+			require.NoError(t, err)
+			assert.Positive(t, status.Latency)
+			assert.GreaterOrEqual(t, status.Pool.OpenConnections, 1)
+			assert.Empty(t, status.Replicas)
+		})
+
+		t.Run("should report an unreachable replica without failing", func(t *testing.T) {
+			t.Parallel()
+			cfg := testCfg
+			cfg.Replicas = []db_types.SqlDbReplicaConfig{{Host: testCfg.Host, Port: 1}}
+			db := start(t, cfg)
+
+			status, err := db.HealthCheck(t.Context())
+			require.NoError(t, err)
+			require.Len(t, status.Replicas, 1)
+			assert.Equal(t, 1, status.Replicas[0].Port)
+			assert.False(t, status.Replicas[0].InLag)
+			assert.Error(t, status.Replicas[0].Err)
+		})
+
+		t.Run("should fail once the primary is unreachable", func(t *testing.T) {
+			t.Parallel()
+			db := start(t, testCfg)
+			require.NoError(t, db.GetDB().Close())
+
+			_, err := db.HealthCheck(t.Context())
+			assert.Error(t, err)
+		})
+	})
+}
+
+func Test_ReplicaStatus(t *testing.T) {
+	t.Parallel()
+
+	t.Run("LagMillis", func(t *testing.T) {
+		t.Parallel()
+
+		t.Run("should report no lag for a database that is not a replica", func(t *testing.T) {
+			t.Parallel()
+			status := internal.ReplicaStatus{InRecovery: false}
+
+			assert.Equal(t, mo.Some(0.0), status.LagMillis())
+		})
+
+		t.Run("should report no lag for a streaming replica that has replayed everything it received", func(t *testing.T) {
+			t.Parallel()
+			status := internal.ReplicaStatus{
+				InRecovery:          true,
+				ReplayedAllReceived: true,
+				Streaming:           true,
+				ReplayAgeMillis:     sql.NullFloat64{Float64: 60_000, Valid: true},
+			}
+
+			assert.Equal(t, mo.Some(0.0), status.LagMillis())
+		})
+
+		t.Run("should report the replay age of a streaming replica that is replaying", func(t *testing.T) {
+			t.Parallel()
+			status := internal.ReplicaStatus{
+				InRecovery:      true,
+				Streaming:       true,
+				ReplayAgeMillis: sql.NullFloat64{Float64: 250, Valid: true},
+			}
+
+			assert.Equal(t, mo.Some(250.0), status.LagMillis())
+		})
+
+		t.Run("should report the replay age of a disconnected replica, even though it replayed everything it received", func(t *testing.T) {
+			t.Parallel()
+			status := internal.ReplicaStatus{
+				InRecovery:          true,
+				ReplayedAllReceived: true,
+				Streaming:           false,
+				ReplayAgeMillis:     sql.NullFloat64{Float64: 60_000, Valid: true},
+			}
+
+			assert.Equal(t, mo.Some(60_000.0), status.LagMillis())
+		})
+
+		t.Run("should report no lag to compare for a replica that has not replayed anything", func(t *testing.T) {
+			t.Parallel()
+			status := internal.ReplicaStatus{InRecovery: true}
+
+			assert.True(t, status.LagMillis().IsNone())
+		})
+	})
+}
@@ -0,0 +1,168 @@
+package internal
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	// placeholderListRegex matches a parenthesized list of placeholders, such as the values of an IN or a row of an
+	// INSERT, so lists of different lengths share a fingerprint
+	placeholderListRegex = regexp.MustCompile(`\(\?(?:, \?)*\)`)
+	// repeatedListRegex matches the rows of a multi row INSERT once each has been collapsed
+	repeatedListRegex = regexp.MustCompile(`\(\.\.\.\)(?:, \(\.\.\.\))+`)
+)
+
+// FingerprintQuery normalizes a query so that every run of it shares a fingerprint regardless of its arguments. String
+// and number literals and placeholders become ?, lists of them become (...), comments are dropped, and whitespace is
+// collapsed. Identifiers and keywords are kept as written
+func FingerprintQuery(query string) string {
+	var b strings.Builder
+	b.Grow(len(query))
+
+	// space is set when whitespace or a comment was skipped, it is written as a single space before the next token
+	space := false
+	write := func(s string) {
+		if space && b.Len() > 0 {
+			b.WriteByte(' ')
+		}
+		space = false
+		b.WriteString(s)
+	}
+
+	for i := 0; i < len(query); {
+		c := query[i]
+		switch {
+		case isSpace(c):
+			space = true
+			i++
+		case c == '-' && strings.HasPrefix(query[i:], "--"):
+			end := strings.IndexByte(query[i:], '\n')
+			if end < 0 {
+				end = len(query) - i
+			}
+			space = true
+			i += end
+		case c == '/' && strings.HasPrefix(query[i:], "/*"):
+			end := strings.Index(query[i+2:], "*/")
+			if end < 0 {
+				end = len(query) - i - 4
+			}
+			space = true
+			i += end + 4
+		case c == '\'':
+			write("?")
+			i = skipQuoted(query, i, '\'')
+		case c == '"':
+			// a quoted identifier is kept, it names a column or table rather than being an argument
+			end := skipQuoted(query, i, '"')
+			write(query[i:end])
+			i = end
+		case c == '?':
+			write("?")
+			i++
+		case c == '$':
+			// $1 placeholders, or $tag$ quoted strings as used in function bodies
+			if end, ok := skipDollarQuoted(query, i); ok {
+				write("?")
+				i = end
+				break
+			}
+			end := i + 1
+			for end < len(query) && isDigit(query[end]) {
+				end++
+			}
+			if end > i+1 {
+				write("?")
+			} else {
+				write("$")
+			}
+			i = end
+		case isDigit(c) || (c == '.' && i+1 < len(query) && isDigit(query[i+1])):
+			end := i + 1
+			for end < len(query) && (isDigit(query[end]) || isIdentifier(query[end]) || query[end] == '.') {
+				// the sign of an exponent, as in 1e-5
+				if (query[end] == 'e' || query[end] == 'E') && end+1 < len(query) && (query[end+1] == '-' || query[end+1] == '+') {
+					end++
+				}
+				end++
+			}
+			write("?")
+			i = end
+		case isIdentifier(c):
+			end := i + 1
+			for end < len(query) && (isIdentifier(query[end]) || isDigit(query[end]) || query[end] == '$') {
+				end++
+			}
+			write(query[i:end])
+			i = end
+		case c == ',':
+			// a comma is written with a space after, however it was spaced, so lists normalize the same
+			space = false
+			b.WriteString(", ")
+			for i++; i < len(query) && isSpace(query[i]); i++ {
+			}
+		case c == '(':
+			write("(")
+			// the space after an opening paren, or before a closing one, is dropped
+			for i++; i < len(query) && isSpace(query[i]); i++ {
+			}
+		case c == ')':
+			space = false
+			b.WriteByte(')')
+			i++
+		default:
+			write(string(c))
+			i++
+		}
+	}
+
+	fingerprint := strings.TrimSpace(strings.TrimSuffix(b.String(), ";"))
+	fingerprint = placeholderListRegex.ReplaceAllString(fingerprint, "(...)")
+	return repeatedListRegex.ReplaceAllString(fingerprint, "(...)")
+}
+
+// skipQuoted returns the index after the quoted section starting at start, a doubled quote is an escaped one
+func skipQuoted(query string, start int, quote byte) int {
+	for i := start + 1; i < len(query); i++ {
+		if query[i] != quote {
+			continue
+		}
+		if i+1 < len(query) && query[i+1] == quote {
+			i++
+			continue
+		}
+		return i + 1
+	}
+	return len(query)
+}
+
+// skipDollarQuoted returns the index after a $tag$...$tag$ string starting at start, if there is one
+func skipDollarQuoted(query string, start int) (int, bool) {
+	tagEnd := start + 1
+	for tagEnd < len(query) && (isIdentifier(query[tagEnd]) || (tagEnd > start+1 && isDigit(query[tagEnd]))) {
+		tagEnd++
+	}
+	if tagEnd >= len(query) || query[tagEnd] != '$' {
+		return 0, false
+	}
+
+	tag := query[start : tagEnd+1]
+	end := strings.Index(query[tagEnd+1:], tag)
+	if end < 0 {
+		return len(query), true
+	}
+	return tagEnd + 1 + end + len(tag), true
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f'
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isIdentifier(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || c >= 0x80
+}
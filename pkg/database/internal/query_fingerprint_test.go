@@ -0,0 +1,70 @@
+package internal_test
+
+import (
+	"testing"
+
+	"github.com/nimaeskandary/go-realworld/pkg/database/internal"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_FingerprintQuery(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		query    string
+		expected string
+	}{
+		{
+			name:     "should replace placeholders",
+			query:    `SELECT * FROM users WHERE id = $1 AND email = ?`,
+			expected: `SELECT * FROM users WHERE id = ? AND email = ?`,
+		},
+		{
+			name:     "should replace string and number literals",
+			query:    `SELECT * FROM users WHERE username = 'o''brien' AND version > 3 AND score < -1.5e-3`,
+			expected: `SELECT * FROM users WHERE username = ? AND version > ? AND score < -?`,
+		},
+		{
+			name:     "should keep identifiers containing digits and quoted identifiers",
+			query:    `SELECT "user 1".col2 FROM t1 AS "user 1"`,
+			expected: `SELECT "user 1".col2 FROM t1 AS "user 1"`,
+		},
+		{
+			name:     "should collapse whitespace and drop comments",
+			query:    "SELECT id -- the id\n\tFROM   users /* all of them */\n WHERE ( id = $1 ) ;",
+			expected: `SELECT id FROM users WHERE (id = ?)`,
+		},
+		{
+			name:     "should collapse IN lists of any length",
+			query:    `SELECT * FROM articles WHERE id IN ($1,$2, $3)`,
+			expected: `SELECT * FROM articles WHERE id IN (...)`,
+		},
+		{
+			name:     "should collapse the rows of a multi row insert",
+			query:    `INSERT INTO article_tags ("article_id", "tag") VALUES ($1, $2), ($3, $4), ($5, $6)`,
+			expected: `INSERT INTO article_tags ("article_id", "tag") VALUES (...)`,
+		},
+		{
+			name:     "should replace dollar quoted strings and keep casts",
+			query:    `SELECT $body$ it's a string $body$, $1::int`,
+			expected: `SELECT ?, ?::int`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.expected, internal.FingerprintQuery(tc.query))
+		})
+	}
+
+	t.Run("should give runs of a query with different arguments the same fingerprint", func(t *testing.T) {
+		t.Parallel()
+		assert.Equal(t,
+			internal.FingerprintQuery(`SELECT * FROM users WHERE id IN (1, 2) LIMIT 10`),
+			internal.FingerprintQuery(`SELECT * FROM users  WHERE id IN (7) LIMIT 20`),
+		)
+	})
+}
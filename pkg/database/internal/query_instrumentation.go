@@ -0,0 +1,430 @@
+package internal
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"reflect"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/nimaeskandary/go-realworld/pkg/database/types"
+	obs_types "github.com/nimaeskandary/go-realworld/pkg/observability/types"
+)
+
+// queryRecorder records the duration, rows and error of every query run through the connections it instruments, and
+// logs those over the slow query threshold
+type queryRecorder struct {
+	// logger may be nil, e.g. for the short lived databases of test tooling, then nothing is logged
+	logger        obs_types.Logger
+	slowThreshold time.Duration
+
+	mu    sync.Mutex
+	stats map[string]*db_types.QueryStats
+}
+
+func newQueryRecorder(logger obs_types.Logger, cfg db_types.SqlDbConfig) *queryRecorder {
+	return &queryRecorder{
+		logger:        logger,
+		slowThreshold: time.Duration(cfg.SlowQueryThresholdMillis) * time.Millisecond,
+		stats:         map[string]*db_types.QueryStats{},
+	}
+}
+
+// record is called once a query is done, for a query returning rows that is when the rows are closed, so the duration
+// includes reading them. rows is -1 when the driver does not report rows affected
+func (r *queryRecorder) record(ctx context.Context, query string, duration time.Duration, rows int64, err error) {
+	// ErrSkip asks database/sql to fall back to another way of running the query, which is recorded instead
+	if errors.Is(err, driver.ErrSkip) {
+		return
+	}
+
+	fingerprint := FingerprintQuery(query)
+
+	r.mu.Lock()
+	stats, ok := r.stats[fingerprint]
+	if !ok {
+		stats = &db_types.QueryStats{Fingerprint: fingerprint}
+		r.stats[fingerprint] = stats
+	}
+	stats.Calls++
+	if err != nil {
+		stats.Errors++
+	}
+	stats.Rows += max(rows, 0)
+	stats.TotalDuration += duration
+	stats.MaxDuration = max(stats.MaxDuration, duration)
+	r.mu.Unlock()
+
+	if r.logger == nil || r.slowThreshold <= 0 || duration < r.slowThreshold {
+		return
+	}
+	attrs := []any{
+		"fingerprint", fingerprint,
+		"duration_ms", duration.Milliseconds(),
+		"rows", rows,
+	}
+	if err != nil {
+		attrs = append(attrs, "error", err)
+	}
+	r.logger.Warn(ctx, "slow query", attrs...)
+}
+
+// snapshot returns a copy of the stats, slowest in total first
+func (r *queryRecorder) snapshot() []db_types.QueryStats {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stats := make([]db_types.QueryStats, 0, len(r.stats))
+	for _, s := range r.stats {
+		stats = append(stats, *s)
+	}
+	slices.SortFunc(stats, func(a, b db_types.QueryStats) int {
+		return cmp.Or(cmp.Compare(b.TotalDuration, a.TotalDuration), cmp.Compare(a.Fingerprint, b.Fingerprint))
+	})
+	return stats
+}
+
+// openInstrumented opens a connection pool like sql.Open, with every query run through it recorded by recorder. The
+// pool is still a plain *sql.DB, so it can be handed to bob, goose, and anything else that expects one
+func openInstrumented(driverName string, dsn string, recorder *queryRecorder) (*sql.DB, error) {
+	// sql.Open does not connect, it is only used to look up the registered driver
+	lookup, err := sql.Open(driverName, dsn)
+	if err != nil {
+		return nil, err
+	}
+	drv := lookup.Driver()
+	_ = lookup.Close()
+
+	var connector driver.Connector = dsnConnector{dsn: dsn, driver: drv}
+	if driverCtx, ok := drv.(driver.DriverContext); ok {
+		connector, err = driverCtx.OpenConnector(dsn)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return sql.OpenDB(&instrumentedConnector{connector: connector, recorder: recorder}), nil
+}
+
+// dsnConnector opens connections for a driver without its own connector, as sql.Open does
+type dsnConnector struct {
+	dsn    string
+	driver driver.Driver
+}
+
+func (c dsnConnector) Connect(_ context.Context) (driver.Conn, error) {
+	return c.driver.Open(c.dsn)
+}
+
+func (c dsnConnector) Driver() driver.Driver {
+	return c.driver
+}
+
+type instrumentedConnector struct {
+	connector driver.Connector
+	recorder  *queryRecorder
+}
+
+func (c *instrumentedConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.connector.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &instrumentedConn{conn: conn, recorder: c.recorder}, nil
+}
+
+func (c *instrumentedConnector) Driver() driver.Driver {
+	return c.connector.Driver()
+}
+
+// instrumentedConn passes everything through to conn, timing queries on the way. The optional interfaces database/sql
+// checks for are implemented by falling back to what it would do if conn did not implement them
+type instrumentedConn struct {
+	conn     driver.Conn
+	recorder *queryRecorder
+}
+
+func (c *instrumentedConn) Prepare(query string) (driver.Stmt, error) {
+	return c.PrepareContext(context.Background(), query)
+}
+
+func (c *instrumentedConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	var stmt driver.Stmt
+	var err error
+	if preparer, ok := c.conn.(driver.ConnPrepareContext); ok {
+		stmt, err = preparer.PrepareContext(ctx, query)
+	} else {
+		stmt, err = c.conn.Prepare(query)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &instrumentedStmt{stmt: stmt, query: query, recorder: c.recorder}, nil
+}
+
+func (c *instrumentedConn) Close() error {
+	return c.conn.Close()
+}
+
+func (c *instrumentedConn) Begin() (driver.Tx, error) {
+	return c.BeginTx(context.Background(), driver.TxOptions{})
+}
+
+func (c *instrumentedConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if beginner, ok := c.conn.(driver.ConnBeginTx); ok {
+		return beginner.BeginTx(ctx, opts)
+	}
+	if opts.ReadOnly || opts.Isolation != driver.IsolationLevel(sql.LevelDefault) {
+		return nil, errors.New("driver does not support transaction options")
+	}
+	//nolint:staticcheck // only reached for drivers without BeginTx
+	return c.conn.Begin()
+}
+
+func (c *instrumentedConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	execer, ok := c.conn.(driver.ExecerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+
+	started := time.Now()
+	result, err := execer.ExecContext(ctx, query, args)
+	c.recorder.record(ctx, query, time.Since(started), rowsAffected(result, err), err)
+	return result, err
+}
+
+func (c *instrumentedConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	queryer, ok := c.conn.(driver.QueryerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+
+	started := time.Now()
+	rows, err := queryer.QueryContext(ctx, query, args)
+	if err != nil {
+		c.recorder.record(ctx, query, time.Since(started), 0, err)
+		return nil, err
+	}
+	return newInstrumentedRows(ctx, rows, query, started, c.recorder), nil
+}
+
+func (c *instrumentedConn) Ping(ctx context.Context) error {
+	if pinger, ok := c.conn.(driver.Pinger); ok {
+		return pinger.Ping(ctx)
+	}
+	return nil
+}
+
+func (c *instrumentedConn) ResetSession(ctx context.Context) error {
+	if resetter, ok := c.conn.(driver.SessionResetter); ok {
+		return resetter.ResetSession(ctx)
+	}
+	return nil
+}
+
+func (c *instrumentedConn) IsValid() bool {
+	if validator, ok := c.conn.(driver.Validator); ok {
+		return validator.IsValid()
+	}
+	return true
+}
+
+// CheckNamedValue lets the driver accept the argument types it supports beyond the database/sql defaults, pgx takes
+// uuids and json for example
+func (c *instrumentedConn) CheckNamedValue(value *driver.NamedValue) error {
+	if checker, ok := c.conn.(driver.NamedValueChecker); ok {
+		return checker.CheckNamedValue(value)
+	}
+	return driver.ErrSkip
+}
+
+type instrumentedStmt struct {
+	stmt     driver.Stmt
+	query    string
+	recorder *queryRecorder
+}
+
+func (s *instrumentedStmt) Close() error {
+	return s.stmt.Close()
+}
+
+func (s *instrumentedStmt) NumInput() int {
+	return s.stmt.NumInput()
+}
+
+func (s *instrumentedStmt) Exec(args []driver.Value) (driver.Result, error) {
+	return s.ExecContext(context.Background(), namedValues(args))
+}
+
+func (s *instrumentedStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return s.QueryContext(context.Background(), namedValues(args))
+}
+
+func (s *instrumentedStmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	started := time.Now()
+	var result driver.Result
+	var err error
+	if execer, ok := s.stmt.(driver.StmtExecContext); ok {
+		result, err = execer.ExecContext(ctx, args)
+	} else if values, valuesErr := values(args); valuesErr != nil {
+		return nil, valuesErr
+	} else {
+		//nolint:staticcheck // only reached for drivers without ExecContext
+		result, err = s.stmt.Exec(values)
+	}
+	s.recorder.record(ctx, s.query, time.Since(started), rowsAffected(result, err), err)
+	return result, err
+}
+
+func (s *instrumentedStmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	started := time.Now()
+	var rows driver.Rows
+	var err error
+	if queryer, ok := s.stmt.(driver.StmtQueryContext); ok {
+		rows, err = queryer.QueryContext(ctx, args)
+	} else if values, valuesErr := values(args); valuesErr != nil {
+		return nil, valuesErr
+	} else {
+		//nolint:staticcheck // only reached for drivers without QueryContext
+		rows, err = s.stmt.Query(values)
+	}
+	if err != nil {
+		s.recorder.record(ctx, s.query, time.Since(started), 0, err)
+		return nil, err
+	}
+	return newInstrumentedRows(ctx, rows, s.query, started, s.recorder), nil
+}
+
+func (s *instrumentedStmt) CheckNamedValue(value *driver.NamedValue) error {
+	if checker, ok := s.stmt.(driver.NamedValueChecker); ok {
+		return checker.CheckNamedValue(value)
+	}
+	return driver.ErrSkip
+}
+
+// instrumentedRows counts the rows read and records the query when closed
+type instrumentedRows struct {
+	ctx      context.Context
+	rows     driver.Rows
+	query    string
+	started  time.Time
+	recorder *queryRecorder
+
+	count int64
+	err   error
+}
+
+func newInstrumentedRows(
+	ctx context.Context,
+	rows driver.Rows,
+	query string,
+	started time.Time,
+	recorder *queryRecorder,
+) *instrumentedRows {
+	return &instrumentedRows{ctx: ctx, rows: rows, query: query, started: started, recorder: recorder}
+}
+
+func (r *instrumentedRows) Columns() []string {
+	return r.rows.Columns()
+}
+
+func (r *instrumentedRows) Close() error {
+	err := r.rows.Close()
+	r.recorder.record(r.ctx, r.query, time.Since(r.started), r.count, r.err)
+	return err
+}
+
+func (r *instrumentedRows) Next(dest []driver.Value) error {
+	err := r.rows.Next(dest)
+	switch {
+	case err == nil:
+		r.count++
+	case !errors.Is(err, io.EOF):
+		r.err = err
+	}
+	return err
+}
+
+func (r *instrumentedRows) HasNextResultSet() bool {
+	if sets, ok := r.rows.(driver.RowsNextResultSet); ok {
+		return sets.HasNextResultSet()
+	}
+	return false
+}
+
+func (r *instrumentedRows) NextResultSet() error {
+	if sets, ok := r.rows.(driver.RowsNextResultSet); ok {
+		return sets.NextResultSet()
+	}
+	return io.EOF
+}
+
+func (r *instrumentedRows) ColumnTypeScanType(index int) reflect.Type {
+	if types, ok := r.rows.(driver.RowsColumnTypeScanType); ok {
+		return types.ColumnTypeScanType(index)
+	}
+	return reflect.TypeFor[any]()
+}
+
+func (r *instrumentedRows) ColumnTypeDatabaseTypeName(index int) string {
+	if types, ok := r.rows.(driver.RowsColumnTypeDatabaseTypeName); ok {
+		return types.ColumnTypeDatabaseTypeName(index)
+	}
+	return ""
+}
+
+func (r *instrumentedRows) ColumnTypeLength(index int) (int64, bool) {
+	if types, ok := r.rows.(driver.RowsColumnTypeLength); ok {
+		return types.ColumnTypeLength(index)
+	}
+	return 0, false
+}
+
+func (r *instrumentedRows) ColumnTypeNullable(index int) (bool, bool) {
+	if types, ok := r.rows.(driver.RowsColumnTypeNullable); ok {
+		return types.ColumnTypeNullable(index)
+	}
+	return false, false
+}
+
+func (r *instrumentedRows) ColumnTypePrecisionScale(index int) (int64, int64, bool) {
+	if types, ok := r.rows.(driver.RowsColumnTypePrecisionScale); ok {
+		return types.ColumnTypePrecisionScale(index)
+	}
+	return 0, 0, false
+}
+
+// rowsAffected is -1 if unknown, some statements such as DDL do not report it
+func rowsAffected(result driver.Result, err error) int64 {
+	if err != nil || result == nil {
+		return -1
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return -1
+	}
+	return n
+}
+
+func namedValues(args []driver.Value) []driver.NamedValue {
+	named := make([]driver.NamedValue, len(args))
+	for i, arg := range args {
+		named[i] = driver.NamedValue{Ordinal: i + 1, Value: arg}
+	}
+	return named
+}
+
+func values(args []driver.NamedValue) ([]driver.Value, error) {
+	vals := make([]driver.Value, len(args))
+	for i, arg := range args {
+		if arg.Name != "" {
+			return nil, errors.New("driver does not support named parameters")
+		}
+		vals[i] = arg.Value
+	}
+	return vals, nil
+}
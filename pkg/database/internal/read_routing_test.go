@@ -0,0 +1,99 @@
+package internal_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	db_types "github.com/nimaeskandary/go-realworld/pkg/database/types"
+
+	"github.com/stephenafamo/bob"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ReadRouting(t *testing.T) {
+	t.Parallel()
+
+	// sql.Open does not connect, so these handles only need to be distinguishable
+	openHandle := func(t *testing.T) *sql.DB {
+		db, err := sql.Open("pgx", "postgresql://unused@localhost:1/unused")
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = db.Close() })
+		return db
+	}
+
+	setup := func(t *testing.T) *StubSQLDatabase {
+		return &StubSQLDatabase{primary: openHandle(t), replica: openHandle(t)}
+	}
+
+	usesDb := func(t *testing.T, expected *sql.DB, executor bob.Executor) {
+		asDb, ok := executor.(bob.DB)
+		require.True(t, ok, "expected a database, got %T", executor)
+		assert.Same(t, expected, asDb.DB)
+	}
+
+	t.Run("ReaderFromCtx", func(t *testing.T) {
+		t.Parallel()
+
+		t.Run("should read from the replica outside of a read your writes scope", func(t *testing.T) {
+			t.Parallel()
+			db := setup(t)
+
+			_ = db_types.ExecutorFromCtx(t.Context(), db)
+			usesDb(t, db.replica, db_types.ReaderFromCtx(t.Context(), db))
+		})
+
+		t.Run("should read from the replica in a scope until a write is made", func(t *testing.T) {
+			t.Parallel()
+			db := setup(t)
+			ctx := db_types.CtxWithReadYourWrites(t.Context())
+
+			usesDb(t, db.replica, db_types.ReaderFromCtx(ctx, db))
+
+			usesDb(t, db.primary, db_types.ExecutorFromCtx(ctx, db))
+			usesDb(t, db.primary, db_types.ReaderFromCtx(ctx, db))
+		})
+
+		t.Run("should not share writes between scopes", func(t *testing.T) {
+			t.Parallel()
+			db := setup(t)
+			writingCtx := db_types.CtxWithReadYourWrites(t.Context())
+			otherCtx := db_types.CtxWithReadYourWrites(t.Context())
+
+			_ = db_types.ExecutorFromCtx(writingCtx, db)
+			usesDb(t, db.replica, db_types.ReaderFromCtx(otherCtx, db))
+		})
+
+		t.Run("should read from the primary with primary reads", func(t *testing.T) {
+			t.Parallel()
+			db := setup(t)
+
+			usesDb(t, db.primary, db_types.ReaderFromCtx(db_types.CtxWithPrimaryReads(t.Context()), db))
+		})
+
+		t.Run("should read from the transaction in ctx", func(t *testing.T) {
+			t.Parallel()
+			db := setup(t)
+			ctx := db_types.CtxWithTx(t.Context(), db.primary, &db_types.TxState{Tx: &sql.Tx{}})
+
+			_, ok := db_types.ReaderFromCtx(ctx, db).(bob.Tx)
+			assert.True(t, ok)
+		})
+	})
+}
+
+type StubSQLDatabase struct {
+	primary *sql.DB
+	replica *sql.DB
+}
+
+func (d *StubSQLDatabase) Start(_ context.Context) error { return nil }
+func (d *StubSQLDatabase) Stop(_ context.Context) error  { return nil }
+func (d *StubSQLDatabase) GetDB() *sql.DB                { return d.primary }
+func (d *StubSQLDatabase) GetReadDB() *sql.DB            { return d.replica }
+func (d *StubSQLDatabase) GetDialect() string            { return "postgres" }
+func (d *StubSQLDatabase) HealthCheck(_ context.Context) (db_types.HealthStatus, error) {
+	return db_types.HealthStatus{}, nil
+}
+func (d *StubSQLDatabase) QueryStats() []db_types.QueryStats { return nil }
@@ -0,0 +1,101 @@
+package internal
+
+import (
+	"fmt"
+
+	db_types "github.com/nimaeskandary/go-realworld/pkg/database/types"
+
+	"github.com/samber/lo"
+)
+
+// DiffSchemas compares a schema with the one expected, e.g. a live database with one freshly migrated. A table
+// missing from either side is one difference rather than one per column. The differences are in the order of the
+// snapshots, by table then by object
+func DiffSchemas(expected, actual db_types.SchemaSnapshot) []db_types.SchemaDifference {
+	var differences []db_types.SchemaDifference
+	actualTables := lo.KeyBy(actual.Tables, func(table db_types.TableSchema) string { return table.Name })
+	expectedTables := lo.KeyBy(expected.Tables, func(table db_types.TableSchema) string { return table.Name })
+
+	for _, table := range expected.Tables {
+		actualTable, ok := actualTables[table.Name]
+		if !ok {
+			differences = append(differences, db_types.SchemaDifference{
+				Kind: db_types.SchemaMissing, Object: "table " + table.Name,
+			})
+			continue
+		}
+
+		differences = append(differences,
+			diffObjects("column", table.Name, table.Columns, actualTable.Columns, columnName, db_types.ColumnSchema.Definition)...)
+		differences = append(differences,
+			diffObjects("index", table.Name, table.Indexes, actualTable.Indexes, indexName, indexDefinition)...)
+		differences = append(differences, diffObjects("constraint", table.Name, table.Constraints,
+			actualTable.Constraints, constraintName, constraintDefinition)...)
+	}
+
+	for _, table := range actual.Tables {
+		if _, ok := expectedTables[table.Name]; !ok {
+			differences = append(differences, db_types.SchemaDifference{
+				Kind: db_types.SchemaUnexpected, Object: "table " + table.Name,
+			})
+		}
+	}
+	return differences
+}
+
+// diffObjects compares the objects of one kind, e.g. columns, of a table in both schemas by name
+func diffObjects[T any](
+	kind string,
+	table string,
+	expected, actual []T,
+	name func(T) string,
+	definition func(T) string,
+) []db_types.SchemaDifference {
+	var differences []db_types.SchemaDifference
+	object := func(o T) string { return fmt.Sprintf("%v %v.%v", kind, table, name(o)) }
+	actualByName := lo.KeyBy(actual, name)
+	expectedByName := lo.KeyBy(expected, name)
+
+	for _, e := range expected {
+		a, ok := actualByName[name(e)]
+		switch {
+		case !ok:
+			differences = append(differences, db_types.SchemaDifference{
+				Kind: db_types.SchemaMissing, Object: object(e), Expected: definition(e),
+			})
+		case definition(a) != definition(e):
+			differences = append(differences, db_types.SchemaDifference{
+				Kind: db_types.SchemaChanged, Object: object(e), Expected: definition(e), Actual: definition(a),
+			})
+		}
+	}
+	for _, a := range actual {
+		if _, ok := expectedByName[name(a)]; !ok {
+			differences = append(differences, db_types.SchemaDifference{
+				Kind: db_types.SchemaUnexpected, Object: object(a), Actual: definition(a),
+			})
+		}
+	}
+	return differences
+}
+
+func columnName(column db_types.ColumnSchema) string {
+	return column.Name
+}
+
+func indexName(index db_types.IndexSchema) string {
+	return index.Name
+}
+
+func indexDefinition(index db_types.IndexSchema) string {
+	return index.Definition
+}
+
+func constraintName(constraint db_types.ConstraintSchema) string {
+	return constraint.Name
+}
+
+// constraintDefinition is the definition as the database prints it, which starts with the kind of constraint
+func constraintDefinition(constraint db_types.ConstraintSchema) string {
+	return constraint.Definition
+}
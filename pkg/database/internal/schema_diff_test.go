@@ -0,0 +1,76 @@
+package internal_test
+
+import (
+	"testing"
+
+	"github.com/nimaeskandary/go-realworld/pkg/database/internal"
+	db_types "github.com/nimaeskandary/go-realworld/pkg/database/types"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_DiffSchemas(t *testing.T) {
+	t.Parallel()
+
+	users := db_types.TableSchema{
+		Name: "users",
+		Columns: []db_types.ColumnSchema{
+			{Name: "bio", Type: "text", Nullable: true},
+			{Name: "id", Type: "uuid", Default: "gen_random_uuid()"},
+		},
+		Indexes: []db_types.IndexSchema{
+			{Name: "users_bio_idx", Definition: "CREATE INDEX users_bio_idx ON public.users USING btree (bio)"},
+		},
+		Constraints: []db_types.ConstraintSchema{
+			{Name: "users_pkey", Type: "primary key", Definition: "PRIMARY KEY (id)"},
+		},
+	}
+	tags := db_types.TableSchema{Name: "tags", Columns: []db_types.ColumnSchema{{Name: "tag", Type: "text"}}}
+
+	t.Run("should find no differences between equal schemas", func(t *testing.T) {
+		t.Parallel()
+		snapshot := db_types.SchemaSnapshot{Tables: []db_types.TableSchema{tags, users}}
+		assert.Empty(t, internal.DiffSchemas(snapshot, snapshot))
+	})
+
+	t.Run("should report missing and unexpected tables once", func(t *testing.T) {
+		t.Parallel()
+		differences := internal.DiffSchemas(
+			db_types.SchemaSnapshot{Tables: []db_types.TableSchema{users}},
+			db_types.SchemaSnapshot{Tables: []db_types.TableSchema{tags}},
+		)
+		assert.Equal(t, []db_types.SchemaDifference{
+			{Kind: db_types.SchemaMissing, Object: "table users"},
+			{Kind: db_types.SchemaUnexpected, Object: "table tags"},
+		}, differences)
+	})
+
+	t.Run("should report the columns, indexes and constraints that differ", func(t *testing.T) {
+		t.Parallel()
+		actual := db_types.TableSchema{
+			Name: "users",
+			Columns: []db_types.ColumnSchema{
+				{Name: "bio", Type: "character varying(255)", Nullable: true},
+				{Name: "email", Type: "text"},
+				{Name: "id", Type: "uuid", Default: "gen_random_uuid()"},
+			},
+			Constraints: []db_types.ConstraintSchema{
+				{Name: "users_pkey", Type: "primary key", Definition: "PRIMARY KEY (id)"},
+			},
+		}
+
+		differences := internal.DiffSchemas(
+			db_types.SchemaSnapshot{Tables: []db_types.TableSchema{users}},
+			db_types.SchemaSnapshot{Tables: []db_types.TableSchema{actual}},
+		)
+		assert.Equal(t, []db_types.SchemaDifference{
+			{Kind: db_types.SchemaChanged, Object: "column users.bio", Expected: "text", Actual: "character varying(255)"},
+			{Kind: db_types.SchemaUnexpected, Object: "column users.email", Actual: "text NOT NULL"},
+			{
+				Kind:     db_types.SchemaMissing,
+				Object:   "index users.users_bio_idx",
+				Expected: "CREATE INDEX users_bio_idx ON public.users USING btree (bio)",
+			},
+		}, differences)
+	})
+}
@@ -0,0 +1,311 @@
+package internal
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"strings"
+
+	db_types "github.com/nimaeskandary/go-realworld/pkg/database/types"
+)
+
+// postgresConstraintTypes are the constraint kinds in a snapshot, by pg_constraint.contype. Not null constraints are
+// part of the column and trigger constraints are not part of the schema a migration describes
+var postgresConstraintTypes = map[string]string{
+	"p": "primary key",
+	"u": "unique",
+	"f": "foreign key",
+	"c": "check",
+	"x": "exclude",
+}
+
+// SnapshotSchema reads the tables, columns, indexes and constraints of the database from its catalog. For postgres
+// that is the current schema. The tables the migration runner keeps its own state in are left out, as is anything
+// internal to the database. Sqlite keeps check constraints only in the table's sql, so they are not in its snapshot
+func SnapshotSchema(ctx context.Context, db db_types.SQLDatabase) (db_types.SchemaSnapshot, error) {
+	var tables map[string]*db_types.TableSchema
+	var err error
+	switch db.GetDialect() {
+	case db_types.DialectPostgres:
+		tables, err = snapshotPostgres(ctx, db.GetDB())
+	case db_types.DialectSqlite:
+		tables, err = snapshotSqlite(ctx, db.GetDB())
+	default:
+		err = fmt.Errorf("schema snapshots are not supported for dialect %v", db.GetDialect())
+	}
+	if err != nil {
+		return db_types.SchemaSnapshot{}, err
+	}
+
+	snapshot := db_types.SchemaSnapshot{Dialect: db.GetDialect()}
+	for _, table := range tables {
+		slices.SortFunc(table.Columns, func(a, b db_types.ColumnSchema) int { return cmp.Compare(a.Name, b.Name) })
+		slices.SortFunc(table.Indexes, func(a, b db_types.IndexSchema) int { return cmp.Compare(a.Name, b.Name) })
+		slices.SortFunc(table.Constraints, func(a, b db_types.ConstraintSchema) int { return cmp.Compare(a.Name, b.Name) })
+		snapshot.Tables = append(snapshot.Tables, *table)
+	}
+	slices.SortFunc(snapshot.Tables, func(a, b db_types.TableSchema) int { return cmp.Compare(a.Name, b.Name) })
+	return snapshot, nil
+}
+
+func isRunnerTable(name string) bool {
+	return name == gooseVersionTable || name == migrationChecksumsTable
+}
+
+// queryEach runs the query and calls scan for each row. The rows are closed before it returns, which matters for
+// sqlite, where the next query waits for the single connection
+func queryEach(ctx context.Context, db *sql.DB, query string, scan func(rows *sql.Rows) error, args ...any) error {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		if err := scan(rows); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+func snapshotPostgres(ctx context.Context, db *sql.DB) (map[string]*db_types.TableSchema, error) {
+	tables := map[string]*db_types.TableSchema{}
+	// the table of a column, index or constraint, nil for the runner's tables
+	table := func(name string) *db_types.TableSchema {
+		if isRunnerTable(name) {
+			return nil
+		}
+		if _, ok := tables[name]; !ok {
+			tables[name] = &db_types.TableSchema{Name: name}
+		}
+		return tables[name]
+	}
+
+	err := queryEach(ctx, db, `
+		SELECT c.relname, a.attname, format_type(a.atttypid, a.atttypmod), NOT a.attnotnull,
+			COALESCE(pg_get_expr(d.adbin, d.adrelid), '')
+		FROM pg_attribute a
+		JOIN pg_class c ON c.oid = a.attrelid
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		LEFT JOIN pg_attrdef d ON d.adrelid = a.attrelid AND d.adnum = a.attnum
+		WHERE n.nspname = current_schema() AND c.relkind IN ('r', 'p') AND a.attnum > 0 AND NOT a.attisdropped`,
+		func(rows *sql.Rows) error {
+			var tableName string
+			var column db_types.ColumnSchema
+			if err := rows.Scan(&tableName, &column.Name, &column.Type, &column.Nullable, &column.Default); err != nil {
+				return err
+			}
+			if t := table(tableName); t != nil {
+				t.Columns = append(t.Columns, column)
+			}
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read columns: %w", err)
+	}
+
+	// indexes backing a constraint are left to the constraint
+	err = queryEach(ctx, db, `
+		SELECT c.relname, i.relname, pg_get_indexdef(x.indexrelid)
+		FROM pg_index x
+		JOIN pg_class c ON c.oid = x.indrelid
+		JOIN pg_class i ON i.oid = x.indexrelid
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		WHERE n.nspname = current_schema()
+			AND NOT EXISTS (SELECT 1 FROM pg_constraint con WHERE con.conindid = x.indexrelid)`,
+		func(rows *sql.Rows) error {
+			var tableName string
+			var index db_types.IndexSchema
+			if err := rows.Scan(&tableName, &index.Name, &index.Definition); err != nil {
+				return err
+			}
+			if t := table(tableName); t != nil {
+				t.Indexes = append(t.Indexes, index)
+			}
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read indexes: %w", err)
+	}
+
+	err = queryEach(ctx, db, `
+		SELECT c.relname, con.conname, con.contype::text, pg_get_constraintdef(con.oid)
+		FROM pg_constraint con
+		JOIN pg_class c ON c.oid = con.conrelid
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		WHERE n.nspname = current_schema()`,
+		func(rows *sql.Rows) error {
+			var tableName, contype string
+			var constraint db_types.ConstraintSchema
+			if err := rows.Scan(&tableName, &constraint.Name, &contype, &constraint.Definition); err != nil {
+				return err
+			}
+			constraintType, ok := postgresConstraintTypes[contype]
+			if !ok {
+				return nil
+			}
+			constraint.Type = constraintType
+			if t := table(tableName); t != nil {
+				t.Constraints = append(t.Constraints, constraint)
+			}
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read constraints: %w", err)
+	}
+
+	return tables, nil
+}
+
+func snapshotSqlite(ctx context.Context, db *sql.DB) (map[string]*db_types.TableSchema, error) {
+	tables := map[string]*db_types.TableSchema{}
+	var names []string
+	// shadow tables hold the contents of a virtual table, e.g. an fts5 index, and are created along with it
+	err := queryEach(ctx, db, `SELECT name FROM pragma_table_list
+		WHERE schema = 'main' AND type IN ('table', 'virtual') AND name NOT LIKE 'sqlite_%'`,
+		func(rows *sql.Rows) error {
+			var name string
+			if err := rows.Scan(&name); err != nil {
+				return err
+			}
+			if !isRunnerTable(name) {
+				names = append(names, name)
+			}
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tables: %w", err)
+	}
+
+	for _, name := range names {
+		table, err := snapshotSqliteTable(ctx, db, name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read table %v: %w", name, err)
+		}
+		tables[name] = table
+	}
+	return tables, nil
+}
+
+func snapshotSqliteTable(ctx context.Context, db *sql.DB, name string) (*db_types.TableSchema, error) {
+	table := &db_types.TableSchema{Name: name}
+
+	// pk is the column's position in the primary key, 0 if it is not part of it
+	primaryKey := map[int]string{}
+	// hidden is 1 for the hidden columns of a virtual table, which are part of how it works rather than its schema
+	err := queryEach(ctx, db, `SELECT name, type, "notnull", COALESCE(dflt_value, ''), pk
+		FROM pragma_table_xinfo(?) WHERE hidden != 1`,
+		func(rows *sql.Rows) error {
+			var column db_types.ColumnSchema
+			var notNull bool
+			var pk int
+			if err := rows.Scan(&column.Name, &column.Type, &notNull, &column.Default, &pk); err != nil {
+				return err
+			}
+			column.Nullable = !notNull
+			table.Columns = append(table.Columns, column)
+			if pk > 0 {
+				primaryKey[pk] = column.Name
+			}
+			return nil
+		}, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read columns: %w", err)
+	}
+	if len(primaryKey) > 0 {
+		var columns []string
+		for i := 1; i <= len(primaryKey); i++ {
+			columns = append(columns, primaryKey[i])
+		}
+		table.Constraints = append(table.Constraints, db_types.ConstraintSchema{
+			Name:       name + "_pkey",
+			Type:       "primary key",
+			Definition: fmt.Sprintf("PRIMARY KEY (%v)", strings.Join(columns, ", ")),
+		})
+	}
+
+	// origin is c for a created index, u for one backing a unique constraint, pk for the primary key's
+	type sqliteIndex struct{ name, origin, definition string }
+	var indexes []sqliteIndex
+	err = queryEach(ctx, db, `
+		SELECT l.name, l.origin, COALESCE(m.sql, '')
+		FROM pragma_index_list(?) l
+		LEFT JOIN sqlite_master m ON m.type = 'index' AND m.name = l.name`,
+		func(rows *sql.Rows) error {
+			var index sqliteIndex
+			if err := rows.Scan(&index.name, &index.origin, &index.definition); err != nil {
+				return err
+			}
+			indexes = append(indexes, index)
+			return nil
+		}, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read indexes: %w", err)
+	}
+	for _, index := range indexes {
+		switch index.origin {
+		case "c":
+			table.Indexes = append(table.Indexes, db_types.IndexSchema{
+				Name:       index.name,
+				Definition: strings.Join(strings.Fields(index.definition), " "),
+			})
+		case "u":
+			var columns []string
+			err := queryEach(ctx, db, `SELECT name FROM pragma_index_info(?) ORDER BY seqno`, func(rows *sql.Rows) error {
+				var column string
+				if err := rows.Scan(&column); err != nil {
+					return err
+				}
+				columns = append(columns, column)
+				return nil
+			}, index.name)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read columns of index %v: %w", index.name, err)
+			}
+			table.Constraints = append(table.Constraints, db_types.ConstraintSchema{
+				Name:       index.name,
+				Type:       "unique",
+				Definition: fmt.Sprintf("UNIQUE (%v)", strings.Join(columns, ", ")),
+			})
+		}
+	}
+
+	// a foreign key over several columns is a row per column, with the same id
+	type foreignKey struct {
+		from, to                  []string
+		table, onUpdate, onDelete string
+	}
+	foreignKeys := map[int]*foreignKey{}
+	err = queryEach(ctx, db, `SELECT id, "table", "from", COALESCE("to", ''), on_update, on_delete
+		FROM pragma_foreign_key_list(?) ORDER BY id, seq`,
+		func(rows *sql.Rows) error {
+			var id int
+			var referenced, from, to, onUpdate, onDelete string
+			if err := rows.Scan(&id, &referenced, &from, &to, &onUpdate, &onDelete); err != nil {
+				return err
+			}
+			if _, ok := foreignKeys[id]; !ok {
+				foreignKeys[id] = &foreignKey{table: referenced, onUpdate: onUpdate, onDelete: onDelete}
+			}
+			foreignKeys[id].from = append(foreignKeys[id].from, from)
+			foreignKeys[id].to = append(foreignKeys[id].to, to)
+			return nil
+		}, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read foreign keys: %w", err)
+	}
+	for _, key := range foreignKeys {
+		// sqlite does not keep the names of foreign keys, so they are named the way postgres would by default
+		table.Constraints = append(table.Constraints, db_types.ConstraintSchema{
+			Name: fmt.Sprintf("%v_%v_fkey", name, strings.Join(key.from, "_")),
+			Type: "foreign key",
+			Definition: fmt.Sprintf("FOREIGN KEY (%v) REFERENCES %v(%v) ON UPDATE %v ON DELETE %v",
+				strings.Join(key.from, ", "), key.table, strings.Join(key.to, ", "), key.onUpdate, key.onDelete),
+		})
+	}
+
+	return table, nil
+}
@@ -0,0 +1,109 @@
+package internal_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/nimaeskandary/go-realworld/pkg/database"
+	"github.com/nimaeskandary/go-realworld/pkg/database/internal"
+	db_types "github.com/nimaeskandary/go-realworld/pkg/database/types"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_SnapshotSchema(t *testing.T) {
+	t.Parallel()
+
+	provider := &StubMigrationsProvider{
+		fs: fstest.MapFS{
+			"001_create_tables.sql": {Data: []byte(`-- +goose Up
+CREATE TABLE users (id TEXT PRIMARY KEY, email TEXT NOT NULL UNIQUE, bio TEXT DEFAULT '');
+CREATE TABLE follows (
+    user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    follower_id TEXT NOT NULL,
+    PRIMARY KEY (user_id, follower_id)
+);
+CREATE INDEX idx_follows_follower_id ON follows(follower_id);
+-- +goose Down
+DROP TABLE follows;
+DROP TABLE users;
+`)},
+		},
+		codeFs: fstest.MapFS{},
+	}
+
+	startDb := func(t *testing.T) db_types.SQLDatabase {
+		db, err := database.NewSqliteSQLDatabase(db_types.SqlDbConfig{
+			Driver:     db_types.DialectSqlite,
+			SqlitePath: filepath.Join(t.TempDir(), "test.db"),
+		}, nil)
+		require.NoError(t, err)
+		require.NoError(t, db.Start(t.Context()))
+		t.Cleanup(func() { _ = db.Stop(context.Background()) })
+
+		runner, err := internal.NewGooseMigrationRunner(db, provider)
+		require.NoError(t, err)
+		require.NoError(t, runner.Start(t.Context()))
+		t.Cleanup(func() { _ = runner.Stop(context.Background()) })
+		require.NoError(t, runner.ApplyAll(t.Context()))
+		return db
+	}
+
+	t.Run("should read the tables, columns, indexes and constraints, without the runner's tables", func(t *testing.T) {
+		t.Parallel()
+		snapshot, err := internal.SnapshotSchema(t.Context(), startDb(t))
+		require.NoError(t, err)
+
+		assert.Equal(t, db_types.SchemaSnapshot{
+			Dialect: db_types.DialectSqlite,
+			Tables: []db_types.TableSchema{
+				{
+					Name: "follows",
+					Columns: []db_types.ColumnSchema{
+						{Name: "follower_id", Type: "TEXT"},
+						{Name: "user_id", Type: "TEXT"},
+					},
+					Indexes: []db_types.IndexSchema{{
+						Name:       "idx_follows_follower_id",
+						Definition: "CREATE INDEX idx_follows_follower_id ON follows(follower_id)",
+					}},
+					Constraints: []db_types.ConstraintSchema{
+						{Name: "follows_pkey", Type: "primary key", Definition: "PRIMARY KEY (user_id, follower_id)"},
+						{
+							Name: "follows_user_id_fkey",
+							Type: "foreign key",
+							Definition: "FOREIGN KEY (user_id) REFERENCES users(id) ON UPDATE NO ACTION " +
+								"ON DELETE CASCADE",
+						},
+					},
+				},
+				{
+					Name: "users",
+					Columns: []db_types.ColumnSchema{
+						{Name: "bio", Type: "TEXT", Nullable: true, Default: "''"},
+						{Name: "email", Type: "TEXT"},
+						{Name: "id", Type: "TEXT", Nullable: true},
+					},
+					Constraints: []db_types.ConstraintSchema{
+						{Name: "sqlite_autoindex_users_2", Type: "unique", Definition: "UNIQUE (email)"},
+						{Name: "users_pkey", Type: "primary key", Definition: "PRIMARY KEY (id)"},
+					},
+				},
+			},
+		}, snapshot)
+	})
+
+	t.Run("should snapshot databases migrated the same way equally", func(t *testing.T) {
+		t.Parallel()
+		expected, err := internal.SnapshotSchema(t.Context(), startDb(t))
+		require.NoError(t, err)
+		actual, err := internal.SnapshotSchema(t.Context(), startDb(t))
+		require.NoError(t, err)
+
+		assert.Equal(t, expected, actual)
+		assert.Empty(t, internal.DiffSchemas(expected, actual))
+	})
+}
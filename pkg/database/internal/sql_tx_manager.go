@@ -0,0 +1,89 @@
+package internal
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/nimaeskandary/go-realworld/pkg/database/types"
+)
+
+type sqlTxManager struct {
+	db db_types.SQLDatabase
+}
+
+func NewSqlTxManager(db db_types.SQLDatabase) db_types.TxManager {
+	return &sqlTxManager{db: db}
+}
+
+func (m *sqlTxManager) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if state, ok := db_types.TxFromCtx(ctx, m.db.GetDB()); ok {
+		return m.withinSavepoint(ctx, state, fn)
+	}
+	return m.withinNewTx(ctx, sql.TxOptions{}, fn)
+}
+
+func (m *sqlTxManager) WithinTxOptions(ctx context.Context, opts sql.TxOptions, fn func(ctx context.Context) error) error {
+	if state, ok := db_types.TxFromCtx(ctx, m.db.GetDB()); ok {
+		if state.Opts != opts {
+			return fmt.Errorf("nested transaction options %+v do not match the enclosing transaction's %+v", opts, state.Opts)
+		}
+		return m.withinSavepoint(ctx, state, fn)
+	}
+	return m.withinNewTx(ctx, opts, fn)
+}
+
+func (m *sqlTxManager) withinNewTx(ctx context.Context, opts sql.TxOptions, fn func(ctx context.Context) error) (err error) {
+	tx, err := m.db.GetDB().BeginTx(ctx, &opts)
+	if err != nil {
+		return fmt.Errorf("error beginning transaction: %w", err)
+	}
+
+	defer func() {
+		// roll back on panic too, then let it continue unwinding
+		if rec := recover(); rec != nil {
+			_ = tx.Rollback()
+			panic(rec)
+		}
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				err = errors.Join(err, fmt.Errorf("error rolling back transaction: %w", rollbackErr))
+			}
+			return
+		}
+		if commitErr := tx.Commit(); commitErr != nil {
+			err = fmt.Errorf("error committing transaction: %w", commitErr)
+		}
+	}()
+
+	return fn(db_types.CtxWithTx(ctx, m.db.GetDB(), &db_types.TxState{Tx: tx, Opts: opts}))
+}
+
+func (m *sqlTxManager) withinSavepoint(ctx context.Context, state *db_types.TxState, fn func(ctx context.Context) error) (err error) {
+	state.Savepoints++
+	// the name is generated, never user input, so it is safe to interpolate
+	name := fmt.Sprintf("sp_%d", state.Savepoints)
+
+	if _, err := state.Tx.ExecContext(ctx, "SAVEPOINT "+name); err != nil {
+		return fmt.Errorf("error creating savepoint %v: %w", name, err)
+	}
+
+	defer func() {
+		if rec := recover(); rec != nil {
+			_, _ = state.Tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+name)
+			panic(rec)
+		}
+		if err != nil {
+			if _, rollbackErr := state.Tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+name); rollbackErr != nil {
+				err = errors.Join(err, fmt.Errorf("error rolling back to savepoint %v: %w", name, rollbackErr))
+			}
+			return
+		}
+		if _, releaseErr := state.Tx.ExecContext(ctx, "RELEASE SAVEPOINT "+name); releaseErr != nil {
+			err = fmt.Errorf("error releasing savepoint %v: %w", name, releaseErr)
+		}
+	}()
+
+	return fn(ctx)
+}
@@ -0,0 +1,181 @@
+package internal_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	db_types "github.com/nimaeskandary/go-realworld/pkg/database/types"
+	"github.com/nimaeskandary/go-realworld/pkg/test_utils/fixtures"
+	"github.com/nimaeskandary/go-realworld/pkg/test_utils/helpers"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_SqlTxManager(t *testing.T) {
+	t.Parallel()
+
+	txManagerSuite(t, fixtures.SetupStandardFixture(t))
+}
+
+func Test_InMemoryTxManager(t *testing.T) {
+	t.Parallel()
+
+	txManagerSuite(t, fixtures.SetupInMemoryFixture(t))
+}
+
+// txManagerSuite checks a TxManager through the repositories of f, which must use it
+func txManagerSuite(t *testing.T, f fixtures.StandardFixture) {
+	errFn := errors.New("fn failed")
+
+	t.Run("WithinTx", func(t *testing.T) {
+		t.Parallel()
+
+		t.Run("should commit the repository calls made in fn", func(t *testing.T) {
+			t.Parallel()
+			user := helpers.GenUser()
+
+			err := f.TxManager.WithinTx(t.Context(), func(ctx context.Context) error {
+				_, err := f.UserRepo.UpsertUser(ctx, user)
+				return err
+			})
+			require.NoError(t, err)
+
+			fromDb, err := f.UserRepo.GetUserById(t.Context(), user.Id)
+			assert.NoError(t, err)
+			assert.True(t, fromDb.IsPresent())
+		})
+
+		t.Run("should roll back the repository calls made in fn if it fails", func(t *testing.T) {
+			t.Parallel()
+			user := helpers.GenUser()
+
+			err := f.TxManager.WithinTx(t.Context(), func(ctx context.Context) error {
+				_, err := f.UserRepo.UpsertUser(ctx, user)
+				require.NoError(t, err)
+
+				// visible inside the transaction
+				fromTx, err := f.UserRepo.GetUserById(ctx, user.Id)
+				require.NoError(t, err)
+				assert.True(t, fromTx.IsPresent())
+				return errFn
+			})
+			assert.ErrorIs(t, err, errFn)
+
+			fromDb, err := f.UserRepo.GetUserById(t.Context(), user.Id)
+			assert.NoError(t, err)
+			assert.True(t, fromDb.IsAbsent())
+		})
+
+		t.Run("should roll back if fn panics", func(t *testing.T) {
+			t.Parallel()
+			user := helpers.GenUser()
+
+			assert.Panics(t, func() {
+				_ = f.TxManager.WithinTx(t.Context(), func(ctx context.Context) error {
+					_, err := f.UserRepo.UpsertUser(ctx, user)
+					require.NoError(t, err)
+					panic("fn panicked")
+				})
+			})
+
+			fromDb, err := f.UserRepo.GetUserById(t.Context(), user.Id)
+			assert.NoError(t, err)
+			assert.True(t, fromDb.IsAbsent())
+		})
+
+		t.Run("should only roll back a failed nested call to its savepoint", func(t *testing.T) {
+			t.Parallel()
+			outerUser := helpers.GenUser()
+			nestedUser := helpers.GenUser()
+
+			err := f.TxManager.WithinTx(t.Context(), func(ctx context.Context) error {
+				_, err := f.UserRepo.UpsertUser(ctx, outerUser)
+				require.NoError(t, err)
+
+				nestedErr := f.TxManager.WithinTx(ctx, func(ctx context.Context) error {
+					_, err := f.UserRepo.UpsertUser(ctx, nestedUser)
+					require.NoError(t, err)
+					return errFn
+				})
+				assert.ErrorIs(t, nestedErr, errFn)
+				return nil
+			})
+			require.NoError(t, err)
+
+			fromDb, err := f.UserRepo.GetUserById(t.Context(), outerUser.Id)
+			assert.NoError(t, err)
+			assert.True(t, fromDb.IsPresent())
+
+			fromDb, err = f.UserRepo.GetUserById(t.Context(), nestedUser.Id)
+			assert.NoError(t, err)
+			assert.True(t, fromDb.IsAbsent())
+		})
+
+		t.Run("should roll back a successful nested call if the outer call fails", func(t *testing.T) {
+			t.Parallel()
+			nestedUser := helpers.GenUser()
+
+			err := f.TxManager.WithinTx(t.Context(), func(ctx context.Context) error {
+				require.NoError(t, f.TxManager.WithinTx(ctx, func(ctx context.Context) error {
+					_, err := f.UserRepo.UpsertUser(ctx, nestedUser)
+					return err
+				}))
+				return errFn
+			})
+			assert.ErrorIs(t, err, errFn)
+
+			fromDb, err := f.UserRepo.GetUserById(t.Context(), nestedUser.Id)
+			assert.NoError(t, err)
+			assert.True(t, fromDb.IsAbsent())
+		})
+	})
+
+	t.Run("WithinTxOptions", func(t *testing.T) {
+		t.Parallel()
+
+		t.Run("should reject writes in a read only transaction", func(t *testing.T) {
+			t.Parallel()
+			if f.Db != nil && f.Db.GetDialect() == db_types.DialectSqlite {
+				t.Skip("sqlite does not enforce read only transactions")
+			}
+
+			err := f.TxManager.WithinTxOptions(t.Context(), sql.TxOptions{ReadOnly: true}, func(ctx context.Context) error {
+				_, err := f.UserRepo.UpsertUser(ctx, helpers.GenUser())
+				return err
+			})
+			assert.Error(t, err)
+		})
+
+		t.Run("should fail a nested call with different options", func(t *testing.T) {
+			t.Parallel()
+
+			err := f.TxManager.WithinTxOptions(t.Context(), sql.TxOptions{Isolation: sql.LevelSerializable}, func(ctx context.Context) error {
+				return f.TxManager.WithinTxOptions(ctx, sql.TxOptions{Isolation: sql.LevelReadCommitted}, func(ctx context.Context) error {
+					return nil
+				})
+			})
+			assert.ErrorContains(t, err, "do not match the enclosing transaction")
+		})
+
+		t.Run("should run a nested call with the same options in the enclosing transaction", func(t *testing.T) {
+			t.Parallel()
+			opts := sql.TxOptions{Isolation: sql.LevelRepeatableRead}
+			user := helpers.GenUser()
+
+			err := f.TxManager.WithinTxOptions(t.Context(), opts, func(ctx context.Context) error {
+				return f.TxManager.WithinTxOptions(ctx, opts, func(ctx context.Context) error {
+					_, err := f.UserRepo.UpsertUser(ctx, user)
+					return err
+				})
+			})
+			require.NoError(t, err)
+
+			fromDb, err := f.UserRepo.GetUserById(t.Context(), user.Id)
+			assert.NoError(t, err)
+			assert.True(t, fromDb.IsPresent())
+		})
+	})
+}
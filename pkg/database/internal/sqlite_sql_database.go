@@ -0,0 +1,108 @@
+package internal
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	"github.com/nimaeskandary/go-realworld/pkg/database/types"
+	obs_types "github.com/nimaeskandary/go-realworld/pkg/observability/types"
+
+	_ "modernc.org/sqlite"
+)
+
+const sqliteInMemoryPath = ":memory:"
+
+type sqliteDb struct {
+	cfg      db_types.SqlDbConfig
+	db       *sql.DB
+	recorder *queryRecorder
+}
+
+// NewSqliteSQLDatabase creates a database that records every query run against it, slow queries are logged with
+// logger, which can be nil to only record them
+func NewSqliteSQLDatabase(cfg db_types.SqlDbConfig, logger obs_types.Logger) (db_types.SQLDatabase, error) {
+	return &sqliteDb{
+		cfg:      cfg,
+		db:       nil,
+		recorder: newQueryRecorder(logger, cfg),
+	}, nil
+}
+
+func (d *sqliteDb) Start(ctx context.Context) error {
+	if d.cfg.SqlitePath != sqliteInMemoryPath {
+		if err := os.MkdirAll(filepath.Dir(d.cfg.SqlitePath), 0o755); err != nil {
+			return fmt.Errorf("error creating sqlite database directory: %w", err)
+		}
+	}
+
+	db, err := openInstrumented("sqlite", d.connectionString(), d.recorder)
+	if err != nil {
+		return fmt.Errorf("error connecting to sqlite database: %w", err)
+	}
+	// sqlite allows one writer at a time, a single connection queues writers instead of failing them with SQLITE_BUSY,
+	// and keeps an in memory database alive as it is dropped along with its last connection
+	db.SetMaxOpenConns(1)
+	db.SetConnMaxLifetime(0)
+	db.SetConnMaxIdleTime(0)
+
+	if err := db.PingContext(ctx); err != nil {
+		_ = db.Close()
+		return fmt.Errorf("error pinging sqlite database: %w", err)
+	}
+
+	d.db = db
+	return nil
+}
+
+func (d *sqliteDb) Stop(_ context.Context) error {
+	if d.db != nil {
+		_ = d.db.Close()
+		d.db = nil
+	}
+	return nil
+}
+
+func (d *sqliteDb) GetDB() *sql.DB {
+	return d.db
+}
+
+// GetReadDB returns the only connection pool, sqlite has no replicas
+func (d *sqliteDb) GetReadDB() *sql.DB {
+	return d.db
+}
+
+func (d *sqliteDb) GetDialect() string {
+	return db_types.DialectSqlite
+}
+
+func (d *sqliteDb) HealthCheck(ctx context.Context) (db_types.HealthStatus, error) {
+	latency, err := ping(ctx, d.db)
+	status := db_types.HealthStatus{
+		Latency:  latency,
+		Pool:     d.db.Stats(),
+		Replicas: []db_types.ReplicaHealthStatus{},
+	}
+	if err != nil {
+		return status, fmt.Errorf("error pinging sqlite database: %w", err)
+	}
+	return status, nil
+}
+
+func (d *sqliteDb) QueryStats() []db_types.QueryStats {
+	return d.recorder.snapshot()
+}
+
+// connectionString enables what postgres does by default: foreign keys, waiting on locks, and times that are written in
+// a format that sorts and parses back
+func (d *sqliteDb) connectionString() string {
+	params := url.Values{}
+	params.Add("_pragma", "foreign_keys(1)")
+	params.Add("_pragma", "busy_timeout(5000)")
+	params.Add("_pragma", "journal_mode(WAL)")
+	params.Set("_time_format", "sqlite")
+	return fmt.Sprintf("file:%v?%v", d.cfg.SqlitePath, params.Encode())
+}
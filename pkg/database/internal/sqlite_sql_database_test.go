@@ -0,0 +1,219 @@
+package internal_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/nimaeskandary/go-realworld/pkg/database/internal"
+	db_types "github.com/nimaeskandary/go-realworld/pkg/database/types"
+
+	obs_types "github.com/nimaeskandary/go-realworld/pkg/observability/types"
+	obs_types_mocks "github.com/nimaeskandary/go-realworld/pkg/observability/types/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_SqliteSQLDatabase(t *testing.T) {
+	t.Parallel()
+
+	startWith := func(t *testing.T, cfg db_types.SqlDbConfig, logger obs_types.Logger) db_types.SQLDatabase {
+		db, err := internal.NewSqliteSQLDatabase(cfg, logger)
+		require.NoError(t, err)
+		require.NoError(t, db.Start(t.Context()))
+		t.Cleanup(func() { _ = db.Stop(context.Background()) })
+		return db
+	}
+	start := func(t *testing.T, path string) db_types.SQLDatabase {
+		return startWith(t, db_types.SqlDbConfig{Driver: db_types.DialectSqlite, SqlitePath: path}, nil)
+	}
+
+	t.Run("Start", func(t *testing.T) {
+		t.Parallel()
+
+		t.Run("should create the database file and its directory", func(t *testing.T) {
+			t.Parallel()
+			path := filepath.Join(t.TempDir(), "nested", "test.db")
+			db := start(t, path)
+
+			assert.FileExists(t, path)
+			assert.Equal(t, db_types.DialectSqlite, db.GetDialect())
+		})
+
+		t.Run("should open an in memory database", func(t *testing.T) {
+			t.Parallel()
+			db := start(t, ":memory:")
+
+			_, err := db.GetDB().ExecContext(t.Context(), "CREATE TABLE t (id INTEGER PRIMARY KEY)")
+			require.NoError(t, err)
+			_, err = db.GetDB().ExecContext(t.Context(), "INSERT INTO t (id) VALUES (1)")
+			assert.NoError(t, err)
+		})
+
+		t.Run("should use a single connection", func(t *testing.T) {
+			t.Parallel()
+			db := start(t, filepath.Join(t.TempDir(), "test.db"))
+
+			assert.Equal(t, 1, db.GetDB().Stats().MaxOpenConnections)
+			assert.Same(t, db.GetDB(), db.GetReadDB())
+		})
+
+		t.Run("should enforce foreign keys", func(t *testing.T) {
+			t.Parallel()
+			db := start(t, ":memory:")
+
+			_, err := db.GetDB().ExecContext(t.Context(), `
+				CREATE TABLE parents (id INTEGER PRIMARY KEY);
+				CREATE TABLE children (parent_id INTEGER NOT NULL REFERENCES parents (id));
+			`)
+			require.NoError(t, err)
+			_, err = db.GetDB().ExecContext(t.Context(), "INSERT INTO children (parent_id) VALUES (1)")
+			assert.Error(t, err)
+		})
+	})
+
+	t.Run("HealthCheck", func(t *testing.T) {
+		t.Parallel()
+
+		t.Run("should report the latency and pool stats", func(t *testing.T) {
+			t.Parallel()
+			db := start(t, ":memory:")
+
+			status, err := db.HealthCheck(t.Context())
+			require.NoError(t, err)
+			assert.Positive(t, status.Latency)
+			assert.Equal(t, 1, status.Pool.OpenConnections)
+			assert.Empty(t, status.Replicas)
+		})
+
+		t.Run("should fail once the database is closed", func(t *testing.T) {
+			t.Parallel()
+			db := start(t, ":memory:")
+			require.NoError(t, db.GetDB().Close())
+
+			_, err := db.HealthCheck(t.Context())
+			assert.Error(t, err)
+		})
+	})
+	t.Run("QueryStats", func(t *testing.T) {
+		t.Parallel()
+
+		setup := func(t *testing.T, db db_types.SQLDatabase) {
+			_, err := db.GetDB().ExecContext(t.Context(), "CREATE TABLE t (id INTEGER PRIMARY KEY, name TEXT NOT NULL)")
+			require.NoError(t, err)
+			_, err = db.GetDB().ExecContext(t.Context(), "INSERT INTO t (id, name) VALUES (?, ?), (?, ?)", 1, "a", 2, "b")
+			require.NoError(t, err)
+		}
+		statsFor := func(t *testing.T, db db_types.SQLDatabase, fingerprint string) db_types.QueryStats {
+			for _, stats := range db.QueryStats() {
+				if stats.Fingerprint == fingerprint {
+					return stats
+				}
+			}
+			require.Failf(t, "no stats recorded", "fingerprint: %v", fingerprint)
+			return db_types.QueryStats{}
+		}
+
+		t.Run("should group queries by fingerprint and count the rows read", func(t *testing.T) {
+			t.Parallel()
+			db := start(t, ":memory:")
+			setup(t, db)
+
+			for _, id := range []int{1, 2, 3} {
+				rows, err := db.GetDB().QueryContext(t.Context(), "SELECT name FROM t WHERE id <= ?", id)
+				require.NoError(t, err)
+				for rows.Next() {
+				}
+				require.NoError(t, rows.Close())
+			}
+
+			stats := statsFor(t, db, "SELECT name FROM t WHERE id <= ?")
+			assert.Equal(t, int64(3), stats.Calls)
+			assert.Equal(t, int64(0), stats.Errors)
+			assert.Equal(t, int64(1+2+2), stats.Rows)
+			assert.Positive(t, stats.TotalDuration)
+			assert.LessOrEqual(t, stats.MaxDuration, stats.TotalDuration)
+		})
+
+		t.Run("should record rows affected and errors of statements", func(t *testing.T) {
+			t.Parallel()
+			db := start(t, ":memory:")
+			setup(t, db)
+
+			_, err := db.GetDB().ExecContext(t.Context(), "UPDATE t SET name = ? WHERE id > ?", "c", 0)
+			require.NoError(t, err)
+			_, err = db.GetDB().ExecContext(t.Context(), "INSERT INTO t (id, name) VALUES (?, ?)", 1, "duplicate")
+			require.Error(t, err)
+
+			updates := statsFor(t, db, "UPDATE t SET name = ? WHERE id > ?")
+			assert.Equal(t, int64(2), updates.Rows)
+			inserts := statsFor(t, db, "INSERT INTO t (id, name) VALUES (...)")
+			assert.Equal(t, int64(2), inserts.Calls)
+			assert.Equal(t, int64(1), inserts.Errors)
+		})
+
+		t.Run("should record queries run in a transaction", func(t *testing.T) {
+			t.Parallel()
+			db := start(t, ":memory:")
+			setup(t, db)
+
+			tx, err := db.GetDB().BeginTx(t.Context(), nil)
+			require.NoError(t, err)
+			_, err = tx.ExecContext(t.Context(), "DELETE FROM t WHERE id = ?", 1)
+			require.NoError(t, err)
+			require.NoError(t, tx.Commit())
+
+			assert.Equal(t, int64(1), statsFor(t, db, "DELETE FROM t WHERE id = ?").Rows)
+		})
+
+		t.Run("should log slow queries with the context's attributes", func(t *testing.T) {
+			t.Parallel()
+			logger := obs_types_mocks.NewMockLogger(t)
+			db := startWith(t, db_types.SqlDbConfig{
+				Driver:                   db_types.DialectSqlite,
+				SqlitePath:               ":memory:",
+				SlowQueryThresholdMillis: 1,
+			}, logger)
+
+			type requestKey struct{}
+			ctx := context.WithValue(t.Context(), requestKey{}, "request")
+			var attrs []any
+			logger.EXPECT().
+				Warn(mock.MatchedBy(func(ctx context.Context) bool { return ctx.Value(requestKey{}) == "request" }), "slow query", mock.Anything).
+				Run(func(_ context.Context, _ string, attributes ...any) { attrs = attributes }).
+				Once()
+
+			// counting to a hundred thousand takes well over a millisecond
+			var count int
+			err := db.GetDB().QueryRowContext(ctx, `
+				WITH RECURSIVE n(i) AS (SELECT 1 UNION ALL SELECT i + 1 FROM n WHERE i < 100000)
+				SELECT count(*) FROM n
+			`).Scan(&count)
+			require.NoError(t, err)
+
+			require.Len(t, attrs, 6)
+			assert.Equal(t, []any{
+				"fingerprint",
+				"WITH RECURSIVE n(i) AS (SELECT ? UNION ALL SELECT i + ? FROM n WHERE i < ?) SELECT count(*) FROM n",
+			}, attrs[:2])
+			assert.Equal(t, "duration_ms", attrs[2])
+			assert.Equal(t, []any{"rows", int64(1)}, attrs[4:])
+		})
+
+		t.Run("should not log queries under the threshold", func(t *testing.T) {
+			t.Parallel()
+			// the mock fails the test on any unexpected call
+			logger := obs_types_mocks.NewMockLogger(t)
+			db := startWith(t, db_types.SqlDbConfig{
+				Driver:                   db_types.DialectSqlite,
+				SqlitePath:               ":memory:",
+				SlowQueryThresholdMillis: 60000,
+			}, logger)
+
+			setup(t, db)
+			assert.NotEmpty(t, db.QueryStats())
+		})
+	})
+}
@@ -0,0 +1,64 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	db_types "github.com/nimaeskandary/go-realworld/pkg/database/types"
+	obs_types "github.com/nimaeskandary/go-realworld/pkg/observability/types"
+)
+
+type startupMigrations struct {
+	cfg    db_types.StartupMigrationsConfig
+	runner db_types.SqlMigrationRunner
+	logger obs_types.Logger
+}
+
+// NewStartupMigrations runs on start with runner, which it starts and stops itself
+func NewStartupMigrations(cfg db_types.StartupMigrationsConfig, runner db_types.SqlMigrationRunner, logger obs_types.Logger) db_types.StartupMigrations {
+	return &startupMigrations{cfg: cfg, runner: runner, logger: logger}
+}
+
+func (s *startupMigrations) Start(ctx context.Context) error {
+	if s.cfg.Mode == "" || s.cfg.Mode == db_types.StartupMigrationsOff {
+		return nil
+	}
+	if err := s.runner.Start(ctx); err != nil {
+		return err
+	}
+
+	if s.cfg.Mode == db_types.StartupMigrationsApply {
+		applyCtx := ctx
+		if s.cfg.TimeoutSeconds > 0 {
+			var cancel context.CancelFunc
+			applyCtx, cancel = context.WithTimeout(ctx, time.Duration(s.cfg.TimeoutSeconds)*time.Second)
+			defer cancel()
+		}
+		s.logger.Info(ctx, "applying pending migrations")
+		if err := s.runner.ApplyAll(applyCtx); err != nil {
+			return fmt.Errorf("failed to apply migrations on startup: %w", err)
+		}
+	}
+
+	version, err := s.runner.CurrentVersion(ctx)
+	if err != nil {
+		return err
+	}
+	// a schema ahead of the build is expected while a deploy rolls out, or after a rollback of the code
+	expected := s.runner.LatestVersion()
+	if version < expected {
+		return fmt.Errorf("database schema is at migration version %v, behind version %v expected by this build, apply the pending migrations first",
+			version, expected)
+	}
+
+	s.logger.Info(ctx, "database schema is up to date", "version", version, "expected_version", expected)
+	return nil
+}
+
+func (s *startupMigrations) Stop(ctx context.Context) error {
+	if s.cfg.Mode == "" || s.cfg.Mode == db_types.StartupMigrationsOff {
+		return nil
+	}
+	return s.runner.Stop(ctx)
+}
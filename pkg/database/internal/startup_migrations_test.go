@@ -0,0 +1,116 @@
+package internal_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/nimaeskandary/go-realworld/pkg/database/internal"
+	db_types "github.com/nimaeskandary/go-realworld/pkg/database/types"
+	obs_types_mocks "github.com/nimaeskandary/go-realworld/pkg/observability/types/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_StartupMigrations(t *testing.T) {
+	t.Parallel()
+
+	provider := &StubMigrationsProvider{
+		fs: fstest.MapFS{
+			"001_create_users.sql": {Data: []byte("-- +goose Up\nCREATE TABLE users (id INTEGER PRIMARY KEY);\n-- +goose Down\nDROP TABLE users;\n")},
+			"002_create_posts.sql": {Data: []byte("-- +goose Up\nCREATE TABLE posts (id INTEGER PRIMARY KEY);\n-- +goose Down\nDROP TABLE posts;\n")},
+		},
+		codeFs: fstest.MapFS{},
+	}
+
+	openDb := func(t *testing.T) db_types.SQLDatabase {
+		db, err := internal.NewSqliteSQLDatabase(db_types.SqlDbConfig{
+			Driver:     db_types.DialectSqlite,
+			SqlitePath: filepath.Join(t.TempDir(), "test.db"),
+		}, nil)
+		require.NoError(t, err)
+		require.NoError(t, db.Start(t.Context()))
+		t.Cleanup(func() { _ = db.Stop(context.Background()) })
+		return db
+	}
+
+	newRunner := func(t *testing.T, db db_types.SQLDatabase) db_types.SqlMigrationRunner {
+		runner, err := internal.NewGooseMigrationRunner(db, provider)
+		require.NoError(t, err)
+		return runner
+	}
+
+	currentVersion := func(t *testing.T, db db_types.SQLDatabase) int64 {
+		runner := newRunner(t, db)
+		require.NoError(t, runner.Start(t.Context()))
+		version, err := runner.CurrentVersion(t.Context())
+		require.NoError(t, err)
+		return version
+	}
+
+	t.Run("should leave pending migrations when off", func(t *testing.T) {
+		t.Parallel()
+		db := openDb(t)
+		// the mock fails the test on any unexpected call
+		startup := internal.NewStartupMigrations(db_types.StartupMigrationsConfig{}, newRunner(t, db), obs_types_mocks.NewMockLogger(t))
+
+		require.NoError(t, startup.Start(t.Context()))
+		assert.Equal(t, int64(0), currentVersion(t, db))
+		assert.NoError(t, startup.Stop(t.Context()))
+	})
+
+	t.Run("should apply pending migrations", func(t *testing.T) {
+		t.Parallel()
+		db := openDb(t)
+		logger := obs_types_mocks.NewMockLogger(t)
+		logger.EXPECT().Info(mock.Anything, mock.Anything, mock.Anything).Maybe()
+		startup := internal.NewStartupMigrations(db_types.StartupMigrationsConfig{
+			Mode:           db_types.StartupMigrationsApply,
+			TimeoutSeconds: 60,
+		}, newRunner(t, db), logger)
+
+		require.NoError(t, startup.Start(t.Context()))
+		assert.Equal(t, int64(2), currentVersion(t, db))
+		assert.NoError(t, startup.Stop(t.Context()))
+	})
+
+	t.Run("should refuse to start when the schema is behind", func(t *testing.T) {
+		t.Parallel()
+		db := openDb(t)
+		runner := newRunner(t, db)
+		require.NoError(t, runner.Start(t.Context()))
+		require.NoError(t, runner.Apply(t.Context(), 1))
+
+		startup := internal.NewStartupMigrations(db_types.StartupMigrationsConfig{
+			Mode: db_types.StartupMigrationsRequire,
+		}, newRunner(t, db), obs_types_mocks.NewMockLogger(t))
+
+		err := startup.Start(t.Context())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "behind version 2")
+		assert.Equal(t, int64(1), currentVersion(t, db))
+	})
+
+	t.Run("should start when the schema is up to date", func(t *testing.T) {
+		t.Parallel()
+		db := openDb(t)
+		runner := newRunner(t, db)
+		require.NoError(t, runner.Start(t.Context()))
+		require.NoError(t, runner.ApplyAll(t.Context()))
+
+		logger := obs_types_mocks.NewMockLogger(t)
+		var attrs []any
+		logger.EXPECT().Info(mock.Anything, "database schema is up to date", mock.Anything).
+			Run(func(_ context.Context, _ string, attributes ...any) { attrs = attributes }).
+			Once()
+		startup := internal.NewStartupMigrations(db_types.StartupMigrationsConfig{
+			Mode: db_types.StartupMigrationsRequire,
+		}, newRunner(t, db), logger)
+
+		require.NoError(t, startup.Start(t.Context()))
+		assert.Equal(t, []any{"version", int64(2), "expected_version", int64(2)}, attrs)
+	})
+}
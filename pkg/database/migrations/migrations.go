@@ -0,0 +1,7 @@
+// Package migrations registers the migrations of every database with database.RegisterMigrationTarget, import it for
+// its side effects to run migrations against any of them. A new database's migrations package is added here
+package migrations
+
+import (
+	_ "github.com/nimaeskandary/go-realworld/pkg/database/migrations/realworld_app"
+)
@@ -16,7 +16,7 @@ import (
 type createUserFollowersTableMigration struct{}
 
 func init() {
-	registerCodeMigration(&createUserFollowersTableMigration{})
+	registerCodeMigration(domain.DialectPostgres, &createUserFollowersTableMigration{})
 }
 
 func (m *createUserFollowersTableMigration) Version() int64 {
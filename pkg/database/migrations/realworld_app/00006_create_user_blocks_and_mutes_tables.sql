@@ -0,0 +1,22 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS user_blocks (
+    blocked_by_user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    blocked_user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (blocked_by_user_id, blocked_user_id),
+    CONSTRAINT user_blocks_no_self_block CHECK (blocked_by_user_id != blocked_user_id)
+);
+-- supports checking whether a user has been blocked by anyone, e.g. before a follow
+CREATE INDEX idx_user_blocks_blocked_user_id ON user_blocks(blocked_user_id);
+
+CREATE TABLE IF NOT EXISTS user_mutes (
+    muted_by_user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    muted_user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (muted_by_user_id, muted_user_id),
+    CONSTRAINT user_mutes_no_self_mute CHECK (muted_by_user_id != muted_user_id)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS user_mutes;
+DROP TABLE IF EXISTS user_blocks;
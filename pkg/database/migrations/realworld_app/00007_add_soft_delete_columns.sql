@@ -0,0 +1,26 @@
+-- +goose Up
+ALTER TABLE users ADD COLUMN deleted_at TIMESTAMPTZ NULL;
+-- usernames and emails are only reserved by active users, a restore re-validates them
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_username_key;
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_email_key;
+CREATE UNIQUE INDEX users_username_active_key ON users(username) WHERE deleted_at IS NULL;
+CREATE UNIQUE INDEX users_email_active_key ON users(email) WHERE deleted_at IS NULL;
+-- supports the background purge
+CREATE INDEX idx_users_deleted_at ON users(deleted_at) WHERE deleted_at IS NOT NULL;
+
+ALTER TABLE articles ADD COLUMN deleted_at TIMESTAMPTZ NULL;
+CREATE INDEX idx_articles_deleted_at ON articles(deleted_at) WHERE deleted_at IS NOT NULL;
+
+-- +goose Down
+-- soft deleted rows can not be represented without the column, and may violate the restored unique constraints
+DELETE FROM articles WHERE deleted_at IS NOT NULL;
+DROP INDEX IF EXISTS idx_articles_deleted_at;
+ALTER TABLE articles DROP COLUMN IF EXISTS deleted_at;
+
+DELETE FROM users WHERE deleted_at IS NOT NULL;
+DROP INDEX IF EXISTS idx_users_deleted_at;
+DROP INDEX IF EXISTS users_email_active_key;
+DROP INDEX IF EXISTS users_username_active_key;
+ALTER TABLE users ADD CONSTRAINT users_username_key UNIQUE (username);
+ALTER TABLE users ADD CONSTRAINT users_email_key UNIQUE (email);
+ALTER TABLE users DROP COLUMN IF EXISTS deleted_at;
@@ -0,0 +1,97 @@
+package realworld_app
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/nimaeskandary/go-realworld/pkg/article"
+	domain "github.com/nimaeskandary/go-realworld/pkg/database/types"
+)
+
+const upgradeArticleDataBatchSize = 500
+
+// upgradeArticleDataMigration rewrites the data of every article at the latest schema version, so reads no longer
+// upgrade old payloads one at a time. Articles are read in batches in id order, to bound memory on a large table, and
+// rows already at the latest version are skipped. When the payload schema version is bumped, register it again in a
+// new file with the next migration version
+type upgradeArticleDataMigration struct {
+	dialect string
+	version int64
+}
+
+func init() {
+	registerCodeMigration(domain.DialectPostgres, &upgradeArticleDataMigration{dialect: domain.DialectPostgres, version: 11})
+	registerCodeMigration(domain.DialectSqlite, &upgradeArticleDataMigration{dialect: domain.DialectSqlite, version: 11})
+}
+
+func (m *upgradeArticleDataMigration) Version() int64 {
+	return m.version
+}
+
+func (m *upgradeArticleDataMigration) Up() domain.MigrationFn {
+	selectBatchSql := `SELECT id, data FROM articles WHERE id > $1 ORDER BY id LIMIT $2`
+	updateSql := `UPDATE articles SET data = $1 WHERE id = $2`
+	if m.dialect == domain.DialectSqlite {
+		selectBatchSql = `SELECT id, data FROM articles WHERE id > ? ORDER BY id LIMIT ?`
+		updateSql = `UPDATE articles SET data = ? WHERE id = ?`
+	}
+
+	return func(ctx context.Context, tx *sql.Tx) error {
+		lastId := ""
+		for {
+			batch, err := m.readBatch(ctx, tx, selectBatchSql, lastId)
+			if err != nil {
+				return err
+			}
+			if len(batch) == 0 {
+				return nil
+			}
+
+			for _, row := range batch {
+				upgraded, changed, err := article.UpgradeArticleData(row.data)
+				if err != nil {
+					return fmt.Errorf("error upgrading data of article %v: %w", row.id, err)
+				}
+				if !changed {
+					continue
+				}
+				// written as text, which postgres parses into jsonb and sqlite stores as is
+				if _, err := tx.ExecContext(ctx, updateSql, string(upgraded), row.id); err != nil {
+					return fmt.Errorf("error updating data of article %v: %w", row.id, err)
+				}
+			}
+			lastId = batch[len(batch)-1].id
+		}
+	}
+}
+
+// Down leaves the data as is, code from before schema_version was added ignores the field
+func (m *upgradeArticleDataMigration) Down() domain.MigrationFn {
+	return func(_ context.Context, _ *sql.Tx) error {
+		return nil
+	}
+}
+
+type articleDataRow struct {
+	id   string
+	data []byte
+}
+
+func (m *upgradeArticleDataMigration) readBatch(ctx context.Context, tx *sql.Tx, query string, afterId string) ([]articleDataRow, error) {
+	rows, err := tx.QueryContext(ctx, query, afterId, upgradeArticleDataBatchSize)
+	if err != nil {
+		return nil, fmt.Errorf("error reading articles after %v: %w", afterId, err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var batch []articleDataRow
+	for rows.Next() {
+		var row articleDataRow
+		if err := rows.Scan(&row.id, &row.data); err != nil {
+			return nil, fmt.Errorf("error scanning article: %w", err)
+		}
+		batch = append(batch, row)
+	}
+	return batch, rows.Err()
+}
@@ -0,0 +1,11 @@
+package realworld_app
+
+import (
+	domain "github.com/nimaeskandary/go-realworld/pkg/database/types"
+)
+
+// rewrites article data at schema version 2, which added the body
+func init() {
+	registerCodeMigration(domain.DialectPostgres, &upgradeArticleDataMigration{dialect: domain.DialectPostgres, version: 12})
+	registerCodeMigration(domain.DialectSqlite, &upgradeArticleDataMigration{dialect: domain.DialectSqlite, version: 12})
+}
@@ -0,0 +1,13 @@
+-- +goose Up
+-- the searchable text of an article, kept up to date by postgres from the data payload. Title words rank highest
+-- and body words lowest. Adding a stored column rewrites the table
+ALTER TABLE articles ADD COLUMN search_vector TSVECTOR GENERATED ALWAYS AS (
+    setweight(to_tsvector('english'::regconfig, COALESCE(data->>'title', '')), 'A') ||
+    setweight(to_tsvector('english'::regconfig, COALESCE(data->>'description', '')), 'B') ||
+    setweight(to_tsvector('english'::regconfig, COALESCE(data->>'body', '')), 'C')
+) STORED;
+CREATE INDEX idx_articles_search_vector ON articles USING GIN (search_vector);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_articles_search_vector;
+ALTER TABLE articles DROP COLUMN IF EXISTS search_vector;
@@ -0,0 +1,32 @@
+-- +goose Up
+-- a record of who changed what, written in the transaction of the change. Entries outlive the users and entities
+-- they refer to, so there are no foreign keys, and they are never updated or deleted
+CREATE TABLE IF NOT EXISTS audit_log (
+    id TEXT PRIMARY KEY,
+    -- null when the change was not made by a signed in user, e.g. a sign up or an admin task
+    actor_user_id TEXT NULL,
+    action TEXT NOT NULL,
+    entity_type TEXT NOT NULL,
+    entity_id TEXT NOT NULL,
+    -- the fields that changed, with their values before and after. before is null for a create, after for a delete
+    before JSONB NULL,
+    after JSONB NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE INDEX idx_audit_log_created_at ON audit_log(created_at);
+CREATE INDEX idx_audit_log_actor_user_id ON audit_log(actor_user_id, created_at) WHERE actor_user_id IS NOT NULL;
+CREATE INDEX idx_audit_log_entity ON audit_log(entity_type, entity_id, created_at);
+
+-- +goose StatementBegin
+CREATE FUNCTION audit_log_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_log is append only';
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+CREATE TRIGGER audit_log_append_only BEFORE UPDATE OR DELETE ON audit_log
+    FOR EACH ROW EXECUTE FUNCTION audit_log_append_only();
+
+-- +goose Down
+DROP TABLE IF EXISTS audit_log;
+DROP FUNCTION IF EXISTS audit_log_append_only();
@@ -2,6 +2,7 @@ package realworld_app
 
 import (
 	"embed"
+	"fmt"
 	"io/fs"
 	"sync"
 
@@ -11,11 +12,21 @@ import (
 	"go.uber.org/fx"
 )
 
-// bundles .sql files to be used as an embedded FileSystem by the migration runner
+// bundles .sql files to be used as an embedded FileSystem by the migration runner, the postgres migrations are at
+// the root and the sqlite ones under sqlite/
 //
-//go:embed *.sql
+//go:embed *.sql sqlite/*.sql
 var sqlMigrations embed.FS
-var codeMigrations = &[]db_types.GoMigration{}
+
+// the sources of the code migrations, so the migration runner can tell when an applied one has been edited. A code
+// migration is registered for each dialect from one file
+//
+//go:embed *.go
+var codeMigrationSources embed.FS
+var codeMigrations = map[string]*[]db_types.GoMigration{
+	db_types.DialectPostgres: {},
+	db_types.DialectSqlite:   {},
+}
 var lock = &sync.Mutex{}
 
 type migrationsProviderImpl struct{}
@@ -35,18 +46,35 @@ func NewMigrationProviderModule() fx.Option {
 	)
 }
 
-func (m *migrationsProviderImpl) GetSqlMigrationsFs() fs.FS {
-	return sqlMigrations
+func (m *migrationsProviderImpl) GetSqlMigrationsFs(dialect string) (fs.FS, error) {
+	switch dialect {
+	case db_types.DialectPostgres:
+		return sqlMigrations, nil
+	case db_types.DialectSqlite:
+		return fs.Sub(sqlMigrations, "sqlite")
+	default:
+		return nil, fmt.Errorf("no realworld_app migrations for dialect: %v", dialect)
+	}
+}
+
+func (m *migrationsProviderImpl) GetCodeMigrationsSourceFs(dialect string) (fs.FS, error) {
+	if _, ok := codeMigrations[dialect]; !ok {
+		return nil, fmt.Errorf("no realworld_app migrations for dialect: %v", dialect)
+	}
+	return codeMigrationSources, nil
 }
 
-func (m *migrationsProviderImpl) GetCodeMigrations() *[]db_types.GoMigration {
-	return codeMigrations
+func (m *migrationsProviderImpl) GetCodeMigrations(dialect string) *[]db_types.GoMigration {
+	if migrations, ok := codeMigrations[dialect]; ok {
+		return migrations
+	}
+	return &[]db_types.GoMigration{}
 }
 
-// registerCodeMigration registers a code migration to be included in the migrations list,
+// registerCodeMigration registers a code migration for a dialect to be included in the migrations list,
 // you MUST include this in an init() function in any go file that defines a code based migration
-func registerCodeMigration(migration db_types.GoMigration) {
+func registerCodeMigration(dialect string, migration db_types.GoMigration) {
 	lock.Lock()
 	defer lock.Unlock()
-	*codeMigrations = append(*codeMigrations, migration)
+	*codeMigrations[dialect] = append(*codeMigrations[dialect], migration)
 }
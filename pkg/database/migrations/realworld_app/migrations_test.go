@@ -0,0 +1,30 @@
+package realworld_app_test
+
+import (
+	"testing"
+
+	"github.com/nimaeskandary/go-realworld/pkg/database"
+	"github.com/nimaeskandary/go-realworld/pkg/database/migrations/realworld_app"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_MigrationVersions(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should have one migration per version for every dialect", func(t *testing.T) {
+		t.Parallel()
+		_, issues, err := database.CheckMigrationVersions(realworld_app.NewMigrationProvider())
+		require.NoError(t, err)
+		assert.Empty(t, issues)
+	})
+
+	t.Run("should be registered as a migration target", func(t *testing.T) {
+		t.Parallel()
+		target, err := database.GetMigrationTarget("realworld_app")
+		require.NoError(t, err)
+		assert.Equal(t, "realworld_app_db", target.ConfigKey)
+		assert.Same(t, realworld_app.NewMigrationProvider(), target.MigrationsProvider)
+	})
+}
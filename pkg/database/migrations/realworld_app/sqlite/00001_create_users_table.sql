@@ -0,0 +1,17 @@
+-- +goose Up
+-- the unique constraints are named indexes rather than inline UNIQUE columns, as sqlite can only drop the former.
+-- Timestamps default to the current UTC time with milliseconds, CURRENT_TIMESTAMP only has seconds
+CREATE TABLE IF NOT EXISTS users (
+    id TEXT PRIMARY KEY,
+    username TEXT NOT NULL,
+    email TEXT NOT NULL,
+    bio TEXT NULL,
+    image TEXT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    updated_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now'))
+);
+CREATE UNIQUE INDEX users_username_key ON users(username);
+CREATE UNIQUE INDEX users_email_key ON users(email);
+
+-- +goose Down
+DROP TABLE IF EXISTS users;
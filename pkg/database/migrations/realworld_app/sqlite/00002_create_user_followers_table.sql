@@ -0,0 +1,11 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS user_followers (
+    followed_by_user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    following_user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    PRIMARY KEY (followed_by_user_id, following_user_id),
+    CONSTRAINT followers_no_self_follow CHECK (followed_by_user_id != following_user_id)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS user_followers;
@@ -0,0 +1,12 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS articles (
+    id TEXT PRIMARY KEY,
+    author_user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    -- json text, sqlite has no jsonb column type
+    data TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    updated_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now'))
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS articles;
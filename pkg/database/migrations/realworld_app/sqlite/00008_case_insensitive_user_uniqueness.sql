@@ -0,0 +1,13 @@
+-- +goose Up
+-- usernames and emails keep the case they were entered with, but are unique ignoring case,
+-- this fails if there are existing active users that only differ by case, which must be resolved by hand
+DROP INDEX IF EXISTS users_username_active_key;
+DROP INDEX IF EXISTS users_email_active_key;
+CREATE UNIQUE INDEX users_username_lower_active_key ON users(LOWER(username)) WHERE deleted_at IS NULL;
+CREATE UNIQUE INDEX users_email_lower_active_key ON users(LOWER(email)) WHERE deleted_at IS NULL;
+
+-- +goose Down
+DROP INDEX IF EXISTS users_email_lower_active_key;
+DROP INDEX IF EXISTS users_username_lower_active_key;
+CREATE UNIQUE INDEX users_username_active_key ON users(username) WHERE deleted_at IS NULL;
+CREATE UNIQUE INDEX users_email_active_key ON users(email) WHERE deleted_at IS NULL;
@@ -0,0 +1,8 @@
+-- +goose Up
+-- incremented on every update, used for optimistic concurrency control
+ALTER TABLE users ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
+ALTER TABLE articles ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
+
+-- +goose Down
+ALTER TABLE articles DROP COLUMN version;
+ALTER TABLE users DROP COLUMN version;
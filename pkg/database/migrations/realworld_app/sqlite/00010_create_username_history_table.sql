@@ -0,0 +1,14 @@
+-- +goose Up
+-- previous usernames are kept so old profile links resolve to the user, and so a released name
+-- cannot be claimed by another user until its cooldown has passed
+CREATE TABLE IF NOT EXISTS username_history (
+    user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    username TEXT NOT NULL,
+    changed_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now'))
+);
+-- a user that takes back an old name and renames again only bumps changed_at
+CREATE UNIQUE INDEX username_history_user_id_username_lower_key ON username_history(user_id, LOWER(username));
+CREATE INDEX idx_username_history_username_lower ON username_history(LOWER(username), changed_at DESC);
+
+-- +goose Down
+DROP TABLE IF EXISTS username_history;
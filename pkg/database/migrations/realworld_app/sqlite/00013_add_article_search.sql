@@ -0,0 +1,51 @@
+-- +goose Up
+-- sqlite has no tsvector, an fts5 table holds the searchable text of each article instead, kept in sync by triggers.
+-- The porter tokenizer matches words by their stem, like the english config does in postgres. Rows are found by
+-- article_id rather than rowid, as VACUUM can renumber the rowids of articles
+CREATE VIRTUAL TABLE IF NOT EXISTS articles_fts USING fts5(
+    article_id UNINDEXED,
+    title,
+    description,
+    body,
+    tokenize = 'porter unicode61'
+);
+
+INSERT INTO articles_fts (article_id, title, description, body)
+SELECT id,
+       COALESCE(json_extract(data, '$.title'), ''),
+       COALESCE(json_extract(data, '$.description'), ''),
+       COALESCE(json_extract(data, '$.body'), '')
+FROM articles;
+
+-- +goose StatementBegin
+CREATE TRIGGER articles_fts_insert AFTER INSERT ON articles BEGIN
+    INSERT INTO articles_fts (article_id, title, description, body)
+    VALUES (new.id,
+            COALESCE(json_extract(new.data, '$.title'), ''),
+            COALESCE(json_extract(new.data, '$.description'), ''),
+            COALESCE(json_extract(new.data, '$.body'), ''));
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER articles_fts_update AFTER UPDATE OF data ON articles BEGIN
+    DELETE FROM articles_fts WHERE article_id = old.id;
+    INSERT INTO articles_fts (article_id, title, description, body)
+    VALUES (new.id,
+            COALESCE(json_extract(new.data, '$.title'), ''),
+            COALESCE(json_extract(new.data, '$.description'), ''),
+            COALESCE(json_extract(new.data, '$.body'), ''));
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER articles_fts_delete AFTER DELETE ON articles BEGIN
+    DELETE FROM articles_fts WHERE article_id = old.id;
+END;
+-- +goose StatementEnd
+
+-- +goose Down
+DROP TRIGGER IF EXISTS articles_fts_delete;
+DROP TRIGGER IF EXISTS articles_fts_update;
+DROP TRIGGER IF EXISTS articles_fts_insert;
+DROP TABLE IF EXISTS articles_fts;
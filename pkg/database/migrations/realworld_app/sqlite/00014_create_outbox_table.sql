@@ -0,0 +1,20 @@
+-- +goose Up
+-- domain events are written here in the transaction of the change they describe, so one is never recorded without the
+-- other, and the outbox relay delivers them to their handlers once committed
+CREATE TABLE IF NOT EXISTS outbox (
+    id TEXT PRIMARY KEY,
+    event_type TEXT NOT NULL,
+    payload TEXT NOT NULL,
+    -- attempts counts failed deliveries, last_error is the error of the latest
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT NULL,
+    -- when the event is next due, null once it is delivered or has run out of attempts
+    next_attempt_at TIMESTAMP NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    delivered_at TIMESTAMP NULL
+);
+CREATE INDEX idx_outbox_next_attempt_at ON outbox(next_attempt_at) WHERE next_attempt_at IS NOT NULL;
+CREATE INDEX idx_outbox_delivered_at ON outbox(delivered_at) WHERE delivered_at IS NOT NULL;
+
+-- +goose Down
+DROP TABLE IF EXISTS outbox;
@@ -0,0 +1,33 @@
+-- +goose Up
+-- a record of who changed what, written in the transaction of the change. Entries outlive the users and entities
+-- they refer to, so there are no foreign keys, and they are never updated or deleted
+CREATE TABLE IF NOT EXISTS audit_log (
+    id TEXT PRIMARY KEY,
+    -- null when the change was not made by a signed in user, e.g. a sign up or an admin task
+    actor_user_id TEXT NULL,
+    action TEXT NOT NULL,
+    entity_type TEXT NOT NULL,
+    entity_id TEXT NOT NULL,
+    -- the fields that changed, with their values before and after. before is null for a create, after for a delete
+    before TEXT NULL,
+    after TEXT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now'))
+);
+CREATE INDEX idx_audit_log_created_at ON audit_log(created_at);
+CREATE INDEX idx_audit_log_actor_user_id ON audit_log(actor_user_id, created_at) WHERE actor_user_id IS NOT NULL;
+CREATE INDEX idx_audit_log_entity ON audit_log(entity_type, entity_id, created_at);
+
+-- +goose StatementBegin
+CREATE TRIGGER audit_log_no_update BEFORE UPDATE ON audit_log BEGIN
+    SELECT RAISE(ABORT, 'audit_log is append only');
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER audit_log_no_delete BEFORE DELETE ON audit_log BEGIN
+    SELECT RAISE(ABORT, 'audit_log is append only');
+END;
+-- +goose StatementEnd
+
+-- +goose Down
+DROP TABLE IF EXISTS audit_log;
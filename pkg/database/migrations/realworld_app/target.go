@@ -0,0 +1,26 @@
+package realworld_app
+
+import (
+	"path/filepath"
+
+	"github.com/nimaeskandary/go-realworld/pkg/database"
+	db_types "github.com/nimaeskandary/go-realworld/pkg/database/types"
+
+	"go.uber.org/fx"
+)
+
+func init() {
+	database.RegisterMigrationTarget(db_types.MigrationTarget{
+		Name:      "realworld_app",
+		ConfigKey: "realworld_app_db",
+		DbModule: fx.Options(
+			fx.Provide(func(cfg db_types.SqlDbConfig) db_types.RealWorldAppDbConfig {
+				return db_types.RealWorldAppDbConfig(cfg)
+			}),
+			database.NewRealworldAppDbModule[db_types.SQLDatabase](),
+		),
+		MigrationsProvider: NewMigrationProvider(),
+		SourceDir:          filepath.Join("pkg", "database", "migrations", "realworld_app"),
+		SqlDirs:            map[string]string{db_types.DialectPostgres: ".", db_types.DialectSqlite: "sqlite"},
+	})
+}
@@ -5,12 +5,60 @@ import (
 )
 
 type SqlDbConfig struct {
-	Username string                    `json:"username" validate:"required"`
-	Password config_types.SecretString `json:"password" validate:"required"`
-	Host     string                    `json:"host" validate:"required"`
-	Port     int                       `json:"port" validate:"required"`
-	DBName   string                    `json:"db_name" validate:"required"`
-	SslMode  string                    `json:"ssl_mode" validate:"required,oneof=disable require verify-ca verify-full"`
+	// Driver selects the database backend, postgres if empty. Only SqlitePath applies to sqlite, everything else
+	// configures postgres
+	Driver   string                    `json:"driver" validate:"omitempty,oneof=postgres sqlite"`
+	Username string                    `json:"username" validate:"required_unless=Driver sqlite"`
+	Password config_types.SecretString `json:"password" validate:"required_unless=Driver sqlite"`
+	Host     string                    `json:"host" validate:"required_unless=Driver sqlite"`
+	Port     int                       `json:"port" validate:"required_unless=Driver sqlite"`
+	DBName   string                    `json:"db_name" validate:"required_unless=Driver sqlite"`
+	SslMode  string                    `json:"ssl_mode" validate:"required_unless=Driver sqlite,omitempty,oneof=disable require verify-ca verify-full"`
+	// SqlitePath is the database file for the sqlite driver, created if it does not exist. ":memory:" keeps the
+	// database in memory for the life of the process
+	SqlitePath string `json:"sqlite_path" validate:"required_if=Driver sqlite"`
+	// pool settings apply to the primary and each replica, zero keeps the database/sql default
+	MaxOpenConns           int   `json:"max_open_conns" validate:"gte=0"`
+	MaxIdleConns           int   `json:"max_idle_conns" validate:"gte=0"`
+	ConnMaxLifetimeSeconds int64 `json:"conn_max_lifetime_seconds" validate:"gte=0"`
+	ConnMaxIdleTimeSeconds int64 `json:"conn_max_idle_time_seconds" validate:"gte=0"`
+	// StartupPingAttempts is how many times the primary is pinged on start before giving up, so a restarting database
+	// does not fail a deploy. The wait between attempts starts at StartupPingBackoffMillis and doubles each time
+	StartupPingAttempts      int   `json:"startup_ping_attempts" validate:"gte=0"`
+	StartupPingBackoffMillis int64 `json:"startup_ping_backoff_millis" validate:"gte=0"`
+	// SlowQueryThresholdMillis logs any query that takes at least this long, along with its fingerprint and the
+	// request's log attributes. Zero disables the log, query stats are recorded either way
+	SlowQueryThresholdMillis int64 `json:"slow_query_threshold_millis" validate:"gte=0"`
+	// Replicas are read only copies of the database, connected to with the same credentials. When set, reads that do
+	// not need to see the caller's own writes are spread across them. The user must then be a superuser or a member of
+	// pg_read_all_stats, so replica lag can be measured
+	Replicas []SqlDbReplicaConfig `json:"replicas" validate:"dive"`
+	// ReplicaMaxLagMillis is how far a replica can fall behind the primary and still serve reads
+	ReplicaMaxLagMillis int64 `json:"replica_max_lag_millis" validate:"required_with=Replicas"`
+	// ReplicaLagCheckIntervalMillis is how often replica lag is measured
+	ReplicaLagCheckIntervalMillis int64 `json:"replica_lag_check_interval_millis" validate:"required_with=Replicas"`
+}
+
+type SqlDbReplicaConfig struct {
+	Host string `json:"host" validate:"required"`
+	Port int    `json:"port" validate:"required"`
 }
 
 type RealWorldAppDbConfig SqlDbConfig
+
+// modes of StartupMigrationsConfig
+const (
+	StartupMigrationsOff     = "off"
+	StartupMigrationsApply   = "apply"
+	StartupMigrationsRequire = "require"
+)
+
+// StartupMigrationsConfig decides what the app does about pending migrations as it starts. Off, the default, leaves
+// them to the migrations command. Apply runs them, one replica at a time, with the others waiting their turn and then
+// finding nothing to apply. Require refuses to start while the schema is behind the latest migration of the build
+type StartupMigrationsConfig struct {
+	Mode string `json:"mode" validate:"omitempty,oneof=off apply require"`
+	// TimeoutSeconds bounds applying migrations, including the wait for other replicas to finish theirs. Zero waits
+	// indefinitely
+	TimeoutSeconds int64 `json:"timeout_seconds" validate:"gte=0"`
+}
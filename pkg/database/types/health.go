@@ -0,0 +1,25 @@
+package db_types
+
+import (
+	"database/sql"
+	"time"
+)
+
+type HealthStatus struct {
+	// Latency is the round trip of a ping to the primary
+	Latency  time.Duration
+	Pool     sql.DBStats
+	Replicas []ReplicaHealthStatus
+}
+
+type ReplicaHealthStatus struct {
+	Host string
+	Port int
+	// InLag is true if the replica is serving reads, i.e. it was reachable and within the lag at the last check
+	InLag   bool
+	Latency time.Duration
+	Pool    sql.DBStats
+	// Err is set if the replica could not be pinged, an unhealthy replica does not fail the check as reads fall back
+	// to the primary
+	Err error
+}
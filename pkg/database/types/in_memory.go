@@ -0,0 +1,180 @@
+package db_types
+
+import (
+	"context"
+	"encoding/json"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/mo"
+)
+
+// InMemoryDb stands in for a SQLDatabase in tests and the playground. It holds the tables the in memory repositories
+// of each component read and write, under a lock, so work spanning tables, such as cascades, is atomic
+type InMemoryDb interface {
+	// Read runs fn with the tables locked for reading
+	Read(ctx context.Context, fn func(tables *InMemoryTables) error) error
+	// Write runs fn with the tables locked for writing. Changes are not rolled back if fn returns an error, so fn should
+	// check everything that can fail before making any, like a constraint would. A TxManager for the db rolls back a
+	// failed transaction as a whole
+	Write(ctx context.Context, fn func(tables *InMemoryTables) error) error
+}
+
+type RealWorldAppInMemoryDb InMemoryDb
+
+// InMemoryTables mirrors the realworld app schema, see the realworld_app migrations. Keys are the primary keys
+type InMemoryTables struct {
+	Users            map[uuid.UUID]InMemoryUser
+	UserFollowers    map[InMemoryUserPair]time.Time
+	UserBlocks       map[InMemoryUserPair]time.Time
+	UserMutes        map[InMemoryUserPair]time.Time
+	UsernameHistory  map[InMemoryUsernameHistoryKey]InMemoryUsernameHistory
+	Articles         map[uuid.UUID]InMemoryArticle
+	ArticleTags      map[InMemoryArticleTag]time.Time
+	ArticleFavorites map[InMemoryArticleFavorite]time.Time
+	Outbox           map[uuid.UUID]InMemoryOutboxEvent
+	AuditLog         map[uuid.UUID]InMemoryAuditEntry
+}
+
+type InMemoryUser struct {
+	Id        uuid.UUID
+	Username  string
+	Email     string
+	Bio       mo.Option[string]
+	Image     mo.Option[string]
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt mo.Option[time.Time]
+	Version   int64
+}
+
+// InMemoryUserPair keys the user_followers, user_blocks and user_mutes tables, From is the user that follows, blocks or
+// mutes To
+type InMemoryUserPair struct {
+	From uuid.UUID
+	To   uuid.UUID
+}
+
+// InMemoryUsernameHistoryKey is unique on the lower cased username, like the index on username_history
+type InMemoryUsernameHistoryKey struct {
+	UserId        uuid.UUID
+	LowerUsername string
+}
+
+type InMemoryUsernameHistory struct {
+	Username  string
+	ChangedAt time.Time
+}
+
+type InMemoryArticle struct {
+	Id           uuid.UUID
+	AuthorUserId uuid.UUID
+	Data         json.RawMessage
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+	DeletedAt    mo.Option[time.Time]
+	Version      int64
+}
+
+type InMemoryArticleTag struct {
+	ArticleId uuid.UUID
+	Tag       string
+}
+
+type InMemoryArticleFavorite struct {
+	ArticleId uuid.UUID
+	UserId    uuid.UUID
+}
+
+// InMemoryOutboxEvent is a row of the outbox table, NextAttemptAt is unset once it is delivered or out of attempts
+type InMemoryOutboxEvent struct {
+	Id            uuid.UUID
+	EventType     string
+	Payload       json.RawMessage
+	Attempts      int
+	LastError     mo.Option[string]
+	NextAttemptAt mo.Option[time.Time]
+	CreatedAt     time.Time
+	DeliveredAt   mo.Option[time.Time]
+}
+
+// InMemoryAuditEntry is a row of the audit_log table, Before and After are unset where the column is null
+type InMemoryAuditEntry struct {
+	Id          uuid.UUID
+	ActorUserId mo.Option[uuid.UUID]
+	Action      string
+	EntityType  string
+	EntityId    string
+	Before      mo.Option[json.RawMessage]
+	After       mo.Option[json.RawMessage]
+	CreatedAt   time.Time
+}
+
+func NewInMemoryTables() *InMemoryTables {
+	return &InMemoryTables{
+		Users:            map[uuid.UUID]InMemoryUser{},
+		UserFollowers:    map[InMemoryUserPair]time.Time{},
+		UserBlocks:       map[InMemoryUserPair]time.Time{},
+		UserMutes:        map[InMemoryUserPair]time.Time{},
+		UsernameHistory:  map[InMemoryUsernameHistoryKey]InMemoryUsernameHistory{},
+		Articles:         map[uuid.UUID]InMemoryArticle{},
+		ArticleTags:      map[InMemoryArticleTag]time.Time{},
+		ArticleFavorites: map[InMemoryArticleFavorite]time.Time{},
+		Outbox:           map[uuid.UUID]InMemoryOutboxEvent{},
+		AuditLog:         map[uuid.UUID]InMemoryAuditEntry{},
+	}
+}
+
+// Clone copies every table. Rows are replaced rather than changed in place, so copying the maps is enough
+func (t *InMemoryTables) Clone() *InMemoryTables {
+	return &InMemoryTables{
+		Users:            maps.Clone(t.Users),
+		UserFollowers:    maps.Clone(t.UserFollowers),
+		UserBlocks:       maps.Clone(t.UserBlocks),
+		UserMutes:        maps.Clone(t.UserMutes),
+		UsernameHistory:  maps.Clone(t.UsernameHistory),
+		Articles:         maps.Clone(t.Articles),
+		ArticleTags:      maps.Clone(t.ArticleTags),
+		ArticleFavorites: maps.Clone(t.ArticleFavorites),
+		Outbox:           maps.Clone(t.Outbox),
+		AuditLog:         maps.Clone(t.AuditLog),
+	}
+}
+
+// DeleteUser hard deletes the user along with every row referencing it, as ON DELETE CASCADE does
+func (t *InMemoryTables) DeleteUser(id uuid.UUID) {
+	delete(t.Users, id)
+
+	for _, pairs := range []map[InMemoryUserPair]time.Time{t.UserFollowers, t.UserBlocks, t.UserMutes} {
+		maps.DeleteFunc(pairs, func(pair InMemoryUserPair, _ time.Time) bool {
+			return pair.From == id || pair.To == id
+		})
+	}
+	maps.DeleteFunc(t.UsernameHistory, func(key InMemoryUsernameHistoryKey, _ InMemoryUsernameHistory) bool {
+		return key.UserId == id
+	})
+	maps.DeleteFunc(t.ArticleFavorites, func(key InMemoryArticleFavorite, _ time.Time) bool {
+		return key.UserId == id
+	})
+
+	articleIds := slices.Collect(maps.Keys(t.Articles))
+	for _, articleId := range articleIds {
+		if t.Articles[articleId].AuthorUserId == id {
+			t.DeleteArticle(articleId)
+		}
+	}
+}
+
+// DeleteArticle hard deletes the article along with its tags and favorites, as ON DELETE CASCADE does
+func (t *InMemoryTables) DeleteArticle(id uuid.UUID) {
+	delete(t.Articles, id)
+
+	maps.DeleteFunc(t.ArticleTags, func(key InMemoryArticleTag, _ time.Time) bool {
+		return key.ArticleId == id
+	})
+	maps.DeleteFunc(t.ArticleFavorites, func(key InMemoryArticleFavorite, _ time.Time) bool {
+		return key.ArticleId == id
+	})
+}
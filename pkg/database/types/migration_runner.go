@@ -4,18 +4,126 @@ import (
 	"context"
 	"database/sql"
 	"io/fs"
+	"time"
 
 	"github.com/nimaeskandary/go-realworld/pkg/util"
+
+	"github.com/samber/mo"
 )
 
+// SqlMigrationRunner applies migrations, recording a checksum of each one's source as it is applied so later edits to
+// applied migrations can be found with Verify. The Plan methods return what the matching action would run, without
+// running it. Applying and rolling back hold a lock, on databases that need one, so concurrent runners
+// take turns
 type SqlMigrationRunner interface {
 	util.FxLifecycle
 	Apply(ctx context.Context, version int64) error
 	ApplyAll(ctx context.Context) error
 	Rollback(ctx context.Context, version int64) error
 	RollbackTo(ctx context.Context, version int64) error
-	Status(ctx context.Context) (string, error)
+	// Status lists every migration with a source, in version order
+	Status(ctx context.Context) ([]MigrationStatus, error)
+	// UpByOne applies the next pending migration, returning its version, or 0 if there is none
+	UpByOne(ctx context.Context) (int64, error)
+	// Redo rolls back the latest applied migration and applies it again, returning its version, or 0 if none is
+	// applied. It picks up edits to a migration while it is being written
+	Redo(ctx context.Context) (int64, error)
 	CurrentVersion(ctx context.Context) (int64, error)
+	// LatestVersion is the version of the newest migration, the schema this build of the code expects
+	LatestVersion() int64
+	// Verify compares the applied migrations with the migration sources, an empty result means they match
+	Verify(ctx context.Context) ([]MigrationDrift, error)
+	PlanApply(ctx context.Context, version int64) ([]PlannedMigration, error)
+	PlanApplyAll(ctx context.Context) ([]PlannedMigration, error)
+	PlanRollback(ctx context.Context, version int64) ([]PlannedMigration, error)
+	PlanRollbackTo(ctx context.Context, version int64) ([]PlannedMigration, error)
+	PlanRedo(ctx context.Context) ([]PlannedMigration, error)
+}
+
+// MigrationState is whether a migration has been applied
+type MigrationState string
+
+const (
+	MigrationApplied MigrationState = "applied"
+	MigrationPending MigrationState = "pending"
+)
+
+// MigrationSourceType is whether a migration is written in sql or go
+type MigrationSourceType string
+
+const (
+	MigrationSourceSql MigrationSourceType = "sql"
+	MigrationSourceGo  MigrationSourceType = "go"
+)
+
+type MigrationStatus struct {
+	Version    int64
+	State      MigrationState
+	AppliedAt  mo.Option[time.Time]
+	SourceType MigrationSourceType
+	// Source is the file of the migration, empty for a code migration without a source file
+	Source string
+}
+
+// MigrationDriftKind is how an applied migration differs from the migration sources
+type MigrationDriftKind string
+
+const (
+	// MigrationModified was applied, and its source has changed since
+	MigrationModified MigrationDriftKind = "modified"
+	// MigrationMissing was not applied, but a later version was. ApplyAll will not apply it
+	MigrationMissing MigrationDriftKind = "missing"
+	// MigrationUnknown was applied, but has no source, e.g. it was applied from another branch
+	MigrationUnknown MigrationDriftKind = "unknown"
+	// MigrationUnrecorded was applied without its checksum being recorded, e.g. by goose directly, so it is not known
+	// whether it changed
+	MigrationUnrecorded MigrationDriftKind = "unrecorded"
+)
+
+type MigrationDrift struct {
+	Version int64
+	Kind    MigrationDriftKind
+	// Source is the file of the migration, empty for an unknown version
+	Source string
+}
+
+// PlannedMigration is a migration an action would run, plans list them in the order they would run
+type PlannedMigration struct {
+	Version int64
+	// Up is false when rolling back
+	Up     bool
+	Source string
+	// Statements are the statements of a sql migration. Code migrations have none, what they run is only known when
+	// they run
+	Statements []string
+}
+
+// MigrationVersionIssueKind is what is wrong with the versions of a migration set
+type MigrationVersionIssueKind string
+
+const (
+	// MigrationDuplicate is a version used by more than one migration of a dialect
+	MigrationDuplicate MigrationVersionIssueKind = "duplicate"
+	// MigrationGap is a version below the latest that a dialect has no migration for
+	MigrationGap MigrationVersionIssueKind = "gap"
+	// MigrationUnregistered is a code migration source file whose version no registered code migration has, e.g. its
+	// init() does not register it, or its Version() does not match the file name
+	MigrationUnregistered MigrationVersionIssueKind = "unregistered"
+)
+
+type MigrationVersionIssue struct {
+	Version int64
+	Kind    MigrationVersionIssueKind
+	// Dialect is empty for an unregistered code migration, as those are shared by all dialects
+	Dialect string
+	// Sources are the files of the migrations with the version, a registered code migration without a source file
+	// of its version is listed by its type
+	Sources []string
+}
+
+// StartupMigrations applies or checks migrations as the app starts, see StartupMigrationsConfig
+type StartupMigrations interface {
+	util.FxLifecycle
 }
 
 type MigrationFn func(ctx context.Context, tx *sql.Tx) error
@@ -27,7 +135,13 @@ type GoMigration interface {
 	Down() MigrationFn
 }
 
+// MigrationsProvider provides a migration set per dialect, as the sql differs between databases. Versions are kept
+// the same across dialects, so a version means the same schema change whichever database it ran on
 type MigrationsProvider interface {
-	GetCodeMigrations() *[]GoMigration
-	GetSqlMigrationsFs() fs.FS
+	GetCodeMigrations(dialect string) *[]GoMigration
+	// GetSqlMigrationsFs returns an error if there are no migrations for the dialect
+	GetSqlMigrationsFs(dialect string) (fs.FS, error)
+	// GetCodeMigrationsSourceFs returns the source files of the code migrations, named with their version like the sql
+	// migrations, so they are checksummed too. A code migration without a source file is not checked for changes
+	GetCodeMigrationsSourceFs(dialect string) (fs.FS, error)
 }
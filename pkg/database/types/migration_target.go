@@ -0,0 +1,21 @@
+package db_types
+
+import (
+	"go.uber.org/fx"
+)
+
+// MigrationTarget is a database the migrations command can run against. Each database component registers its own
+// with database.RegisterMigrationTarget, and is imported by pkg/database/migrations so the command finds it
+type MigrationTarget struct {
+	// Name is how the migrations command refers to the database, e.g. realworld_app
+	Name string
+	// ConfigKey is the key of the database's SqlDbConfig in a config file
+	ConfigKey string
+	// DbModule provides the database as a SQLDatabase. The SqlDbConfig read from ConfigKey is in the graph
+	DbModule           fx.Option
+	MigrationsProvider MigrationsProvider
+	// SourceDir is where the migration sources are, relative to the project root, new migrations are written there
+	SourceDir string
+	// SqlDirs are the directories of each dialect's sql migrations, relative to SourceDir
+	SqlDirs map[string]string
+}
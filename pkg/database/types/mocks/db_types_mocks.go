@@ -0,0 +1,159 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package db_types_mocks
+
+import (
+	"context"
+	"database/sql"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockTxManager creates a new instance of MockTxManager. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockTxManager(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockTxManager {
+	mock := &MockTxManager{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockTxManager is an autogenerated mock type for the TxManager type
+type MockTxManager struct {
+	mock.Mock
+}
+
+type MockTxManager_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockTxManager) EXPECT() *MockTxManager_Expecter {
+	return &MockTxManager_Expecter{mock: &_m.Mock}
+}
+
+// WithinTx provides a mock function for the type MockTxManager
+func (_mock *MockTxManager) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	ret := _mock.Called(ctx, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WithinTx")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, func(ctx context.Context) error) error); ok {
+		r0 = returnFunc(ctx, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockTxManager_WithinTx_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WithinTx'
+type MockTxManager_WithinTx_Call struct {
+	*mock.Call
+}
+
+// WithinTx is a helper method to define mock.On call
+//   - ctx context.Context
+//   - fn func(ctx context.Context) error
+func (_e *MockTxManager_Expecter) WithinTx(ctx interface{}, fn interface{}) *MockTxManager_WithinTx_Call {
+	return &MockTxManager_WithinTx_Call{Call: _e.mock.On("WithinTx", ctx, fn)}
+}
+
+func (_c *MockTxManager_WithinTx_Call) Run(run func(ctx context.Context, fn func(ctx context.Context) error)) *MockTxManager_WithinTx_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 func(ctx context.Context) error
+		if args[1] != nil {
+			arg1 = args[1].(func(ctx context.Context) error)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTxManager_WithinTx_Call) Return(err error) *MockTxManager_WithinTx_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockTxManager_WithinTx_Call) RunAndReturn(run func(ctx context.Context, fn func(ctx context.Context) error) error) *MockTxManager_WithinTx_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// WithinTxOptions provides a mock function for the type MockTxManager
+func (_mock *MockTxManager) WithinTxOptions(ctx context.Context, opts sql.TxOptions, fn func(ctx context.Context) error) error {
+	ret := _mock.Called(ctx, opts, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WithinTxOptions")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, sql.TxOptions, func(ctx context.Context) error) error); ok {
+		r0 = returnFunc(ctx, opts, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockTxManager_WithinTxOptions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WithinTxOptions'
+type MockTxManager_WithinTxOptions_Call struct {
+	*mock.Call
+}
+
+// WithinTxOptions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - opts sql.TxOptions
+//   - fn func(ctx context.Context) error
+func (_e *MockTxManager_Expecter) WithinTxOptions(ctx interface{}, opts interface{}, fn interface{}) *MockTxManager_WithinTxOptions_Call {
+	return &MockTxManager_WithinTxOptions_Call{Call: _e.mock.On("WithinTxOptions", ctx, opts, fn)}
+}
+
+func (_c *MockTxManager_WithinTxOptions_Call) Run(run func(ctx context.Context, opts sql.TxOptions, fn func(ctx context.Context) error)) *MockTxManager_WithinTxOptions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 sql.TxOptions
+		if args[1] != nil {
+			arg1 = args[1].(sql.TxOptions)
+		}
+		var arg2 func(ctx context.Context) error
+		if args[2] != nil {
+			arg2 = args[2].(func(ctx context.Context) error)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTxManager_WithinTxOptions_Call) Return(err error) *MockTxManager_WithinTxOptions_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockTxManager_WithinTxOptions_Call) RunAndReturn(run func(ctx context.Context, opts sql.TxOptions, fn func(ctx context.Context) error) error) *MockTxManager_WithinTxOptions_Call {
+	_c.Call.Return(run)
+	return _c
+}
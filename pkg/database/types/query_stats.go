@@ -0,0 +1,18 @@
+package db_types
+
+import (
+	"time"
+)
+
+// QueryStats aggregates every query run against a SQLDatabase that normalizes to the same fingerprint, i.e. the same
+// query with different arguments or literals
+type QueryStats struct {
+	Fingerprint string
+	Calls       int64
+	// Errors counts the calls that failed, including those cancelled by their context
+	Errors int64
+	// Rows is the total rows returned by queries, or affected by statements where the driver reports it
+	Rows          int64
+	TotalDuration time.Duration
+	MaxDuration   time.Duration
+}
@@ -0,0 +1,55 @@
+package db_types
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/stephenafamo/bob"
+)
+
+type readYourWritesKey struct{}
+
+// readYourWritesScope records whether a write has been made, after which reads in the scope go to the primary
+type readYourWritesScope struct {
+	wrote atomic.Bool
+}
+
+// CtxWithReadYourWrites starts a scope, e.g. a request, in which reads made after a write are routed to the primary
+// instead of a replica that may not have caught up yet
+func CtxWithReadYourWrites(ctx context.Context) context.Context {
+	return context.WithValue(ctx, readYourWritesKey{}, &readYourWritesScope{})
+}
+
+// CtxWithPrimaryReads routes every read made with the returned ctx to the primary, for read-modify-write operations
+// where a stale replica read would turn into a spurious version conflict
+func CtxWithPrimaryReads(ctx context.Context) context.Context {
+	scope := &readYourWritesScope{}
+	scope.wrote.Store(true)
+	return context.WithValue(ctx, readYourWritesKey{}, scope)
+}
+
+// ExecutorFromCtx returns the executor for writes, and reads that must see them: the transaction in ctx for the
+// database if there is one, otherwise the primary. Calling it pins later reads in the ctx's read your writes scope to
+// the primary. Repositories use this instead of the database directly, so they take part in transactions started by a
+// TxManager without knowing about them
+func ExecutorFromCtx(ctx context.Context, db SQLDatabase) bob.Executor {
+	if scope, ok := ctx.Value(readYourWritesKey{}).(*readYourWritesScope); ok {
+		scope.wrote.Store(true)
+	}
+	if state, ok := TxFromCtx(ctx, db.GetDB()); ok {
+		return bob.NewTx(state.Tx)
+	}
+	return bob.NewDB(db.GetDB())
+}
+
+// ReaderFromCtx returns the executor for reads: the transaction in ctx for the database if there is one, the primary
+// if a write has been made in the ctx's read your writes scope, otherwise a replica
+func ReaderFromCtx(ctx context.Context, db SQLDatabase) bob.Executor {
+	if state, ok := TxFromCtx(ctx, db.GetDB()); ok {
+		return bob.NewTx(state.Tx)
+	}
+	if scope, ok := ctx.Value(readYourWritesKey{}).(*readYourWritesScope); ok && scope.wrote.Load() {
+		return bob.NewDB(db.GetDB())
+	}
+	return bob.NewDB(db.GetReadDB())
+}
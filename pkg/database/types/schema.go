@@ -0,0 +1,75 @@
+package db_types
+
+import "strings"
+
+// SchemaSnapshot is the schema of a database read from its catalog, normalized so two databases with the same schema
+// have equal snapshots whatever order their objects were created in. Everything is sorted by name
+type SchemaSnapshot struct {
+	Dialect string
+	Tables  []TableSchema
+}
+
+type TableSchema struct {
+	Name        string
+	Columns     []ColumnSchema
+	Indexes     []IndexSchema
+	Constraints []ConstraintSchema
+}
+
+type ColumnSchema struct {
+	Name     string
+	Type     string
+	Nullable bool
+	// Default is the default or generated expression as the database prints it, empty if there is none
+	Default string
+}
+
+// Definition is the column as it would be declared, without its name, e.g. "timestamp NOT NULL DEFAULT now()"
+func (c ColumnSchema) Definition() string {
+	var parts []string
+	// sqlite columns can be declared without a type
+	if c.Type != "" {
+		parts = append(parts, c.Type)
+	}
+	if !c.Nullable {
+		parts = append(parts, "NOT NULL")
+	}
+	if c.Default != "" {
+		parts = append(parts, "DEFAULT "+c.Default)
+	}
+	return strings.Join(parts, " ")
+}
+
+// IndexSchema is an index that is not backing a constraint, those are part of the constraint
+type IndexSchema struct {
+	Name       string
+	Definition string
+}
+
+type ConstraintSchema struct {
+	Name string
+	// Type is one of primary key, unique, foreign key, check or exclude
+	Type       string
+	Definition string
+}
+
+// SchemaDifferenceKind is how a schema differs from the expected one
+type SchemaDifferenceKind string
+
+const (
+	// SchemaMissing is in the expected schema only
+	SchemaMissing SchemaDifferenceKind = "missing"
+	// SchemaUnexpected is in the actual schema only
+	SchemaUnexpected SchemaDifferenceKind = "unexpected"
+	// SchemaChanged is in both, with a different definition
+	SchemaChanged SchemaDifferenceKind = "changed"
+)
+
+type SchemaDifference struct {
+	Kind SchemaDifferenceKind
+	// Object names what differs, e.g. "table users", "column users.bio" or "index users.users_email_key"
+	Object string
+	// Expected and Actual are the definitions, empty for the side the object is missing from, and for a table
+	Expected string
+	Actual   string
+}
@@ -1,15 +1,35 @@
 package db_types
 
 import (
+	"context"
 	"database/sql"
 
 	"github.com/nimaeskandary/go-realworld/pkg/util"
 )
 
+// dialects returned by SQLDatabase.GetDialect, matching the driver names in SqlDbConfig
+const (
+	DialectPostgres = "postgres"
+	DialectSqlite   = "sqlite"
+)
+
+// Dialects are all the supported dialects, each migration set has migrations for every one
+var Dialects = []string{DialectPostgres, DialectSqlite}
+
 type SQLDatabase interface {
 	util.FxLifecycle
+	// GetDB returns the primary, which takes all writes
 	GetDB() *sql.DB
+	// GetReadDB returns a replica that is within the configured lag of the primary, or the primary if there is none.
+	// Repositories should use ReaderFromCtx rather than calling this directly, so reads after a write see it
+	GetReadDB() *sql.DB
 	GetDialect() string
+	// HealthCheck pings the primary and each replica, returning an error if the primary cannot be reached. The status
+	// is filled in either way
+	HealthCheck(ctx context.Context) (HealthStatus, error)
+	// QueryStats returns what has been recorded about the queries run since start, grouped by fingerprint, slowest in
+	// total first
+	QueryStats() []QueryStats
 }
 
-type PostgresRealWorldAppDb SQLDatabase
+type RealWorldAppDb SQLDatabase
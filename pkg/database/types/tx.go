@@ -0,0 +1,39 @@
+package db_types
+
+import (
+	"context"
+	"database/sql"
+)
+
+//mockery:generate: true
+type TxManager interface {
+	// WithinTx runs fn in a transaction that is committed if fn returns nil and rolled back otherwise. The transaction is
+	// carried in the ctx passed to fn, and repositories use it for any query made with that ctx. A nested call joins the
+	// enclosing transaction through a savepoint, so only the nested work is rolled back if it fails
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+	// WithinTxOptions is WithinTx with an isolation level and read only mode. These can only be set by the outermost call,
+	// a nested call fails if they differ from the enclosing transaction's
+	WithinTxOptions(ctx context.Context, opts sql.TxOptions, fn func(ctx context.Context) error) error
+}
+
+// txKey is scoped to a connection pool, so a transaction on one database is never used for queries against another
+type txKey struct {
+	db *sql.DB
+}
+
+// TxState is the transaction carried in a context by a TxManager
+type TxState struct {
+	Tx   *sql.Tx
+	Opts sql.TxOptions
+	// Savepoints counts the savepoints made so far, to give nested calls unique names
+	Savepoints int
+}
+
+func CtxWithTx(ctx context.Context, db *sql.DB, state *TxState) context.Context {
+	return context.WithValue(ctx, txKey{db: db}, state)
+}
+
+func TxFromCtx(ctx context.Context, db *sql.DB) (*TxState, bool) {
+	state, ok := ctx.Value(txKey{db: db}).(*TxState)
+	return state, ok
+}
@@ -0,0 +1,184 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	// registers the gif decoder with image.Decode, gifs are stored as pngs of their first frame
+	_ "image/gif"
+
+	blob_store_types "github.com/nimaeskandary/go-realworld/pkg/blob_store/types"
+	media_types "github.com/nimaeskandary/go-realworld/pkg/media/types"
+
+	"github.com/google/uuid"
+)
+
+const jpegQuality = 85
+
+// sniffed content types that can be decoded, and the content type each is stored as
+var storedContentTypes = map[string]string{
+	"image/png":  "image/png",
+	"image/jpeg": "image/jpeg",
+	"image/gif":  "image/png",
+}
+
+type mediaServiceImpl struct {
+	cfg       media_types.MediaConfig
+	blobStore blob_store_types.BlobStore
+}
+
+func NewMediaServiceImpl(cfg media_types.MediaConfig, blobStore blob_store_types.BlobStore) media_types.MediaService {
+	return &mediaServiceImpl{
+		cfg:       cfg,
+		blobStore: blobStore,
+	}
+}
+
+func (s *mediaServiceImpl) UploadImage(ctx context.Context, kind media_types.ImageKind, content io.Reader) (media_types.StoredImage, media_types.DomainError) {
+	maxDimension, ok := s.maxDimension(kind)
+	if !ok {
+		return media_types.StoredImage{}, media_types.BadParamsError{Err: fmt.Errorf("unknown image kind: %v", kind)}
+	}
+
+	// read one byte past the limit to tell an upload of exactly the limit from a larger one
+	data, err := io.ReadAll(io.LimitReader(content, s.cfg.MaxUploadBytes+1))
+	if err != nil {
+		return media_types.StoredImage{}, media_types.UnknownError{Err: fmt.Errorf("error reading upload: %w", err)}
+	}
+	if int64(len(data)) > s.cfg.MaxUploadBytes {
+		return media_types.StoredImage{}, media_types.TooLargeError{MaxBytes: s.cfg.MaxUploadBytes}
+	}
+
+	// the content type is sniffed rather than trusted from the request
+	sniffed := http.DetectContentType(data)
+	contentType, ok := storedContentTypes[sniffed]
+	if !ok {
+		return media_types.StoredImage{}, media_types.UnsupportedMediaTypeError{ContentType: sniffed}
+	}
+
+	imgCfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return media_types.StoredImage{}, media_types.BadParamsError{Err: fmt.Errorf("error reading image header: %w", err)}
+	}
+	if int64(imgCfg.Width)*int64(imgCfg.Height) > s.cfg.MaxSourcePixels {
+		return media_types.StoredImage{}, media_types.BadParamsError{
+			Err: fmt.Errorf("image is %vx%v, which exceeds the limit of %v pixels", imgCfg.Width, imgCfg.Height, s.cfg.MaxSourcePixels),
+		}
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return media_types.StoredImage{}, media_types.BadParamsError{Err: fmt.Errorf("error decoding image: %w", err)}
+	}
+	img = resizeToFit(img, maxDimension)
+
+	encoded := new(bytes.Buffer)
+	switch contentType {
+	case "image/jpeg":
+		err = jpeg.Encode(encoded, img, &jpeg.Options{Quality: jpegQuality})
+	default:
+		err = png.Encode(encoded, img)
+	}
+	if err != nil {
+		return media_types.StoredImage{}, media_types.UnknownError{Err: fmt.Errorf("error encoding image: %w", err)}
+	}
+
+	name := uuid.New().String() + extensionFor(contentType)
+	err = s.blobStore.Put(ctx, blobKey(kind, name), contentType, encoded)
+	if err != nil {
+		return media_types.StoredImage{}, media_types.UnknownError{Err: fmt.Errorf("error storing image: %w", err)}
+	}
+
+	return media_types.StoredImage{
+		Kind:   kind,
+		Name:   name,
+		Url:    s.cfg.PublicBaseUrl + "/media/" + url.PathEscape(string(kind)) + "/" + url.PathEscape(name),
+		Width:  img.Bounds().Dx(),
+		Height: img.Bounds().Dy(),
+	}, nil
+}
+
+func (s *mediaServiceImpl) GetImage(ctx context.Context, kind media_types.ImageKind, name string) (blob_store_types.Blob, media_types.DomainError) {
+	key := blobKey(kind, name)
+	if !s.isServedName(kind, name) {
+		return blob_store_types.Blob{}, media_types.NotFoundError{Identifier: key}
+	}
+
+	blobOpt, err := s.blobStore.Get(ctx, key)
+	if err != nil {
+		return blob_store_types.Blob{}, media_types.UnknownError{Err: fmt.Errorf("error getting image: %w", err)}
+	}
+	blob, ok := blobOpt.Get()
+	if !ok {
+		return blob_store_types.Blob{}, media_types.NotFoundError{Identifier: key}
+	}
+
+	return blob, nil
+}
+
+func (s *mediaServiceImpl) DeleteImage(ctx context.Context, kind media_types.ImageKind, name string) media_types.DomainError {
+	if !s.isServedName(kind, name) {
+		return media_types.NotFoundError{Identifier: blobKey(kind, name)}
+	}
+
+	if err := s.blobStore.Delete(ctx, blobKey(kind, name)); err != nil {
+		return media_types.UnknownError{Err: fmt.Errorf("error deleting image: %w", err)}
+	}
+	return nil
+}
+
+func (s *mediaServiceImpl) DeleteImageByUrl(ctx context.Context, imageUrl string) media_types.DomainError {
+	rest, ok := strings.CutPrefix(imageUrl, s.cfg.PublicBaseUrl+"/media/")
+	if !ok {
+		return nil
+	}
+	escapedKind, escapedName, ok := strings.Cut(rest, "/")
+	if !ok {
+		return nil
+	}
+	kind, kindErr := url.PathUnescape(escapedKind)
+	name, nameErr := url.PathUnescape(escapedName)
+	if kindErr != nil || nameErr != nil || !s.isServedName(media_types.ImageKind(kind), name) {
+		return nil
+	}
+
+	return s.DeleteImage(ctx, media_types.ImageKind(kind), name)
+}
+
+// isServedName reports if name could be one this service generated for kind, which also keeps arbitrary keys away
+// from the blob store
+func (s *mediaServiceImpl) isServedName(kind media_types.ImageKind, name string) bool {
+	_, ok := s.maxDimension(kind)
+	return ok && path.Base(name) == name && !strings.HasPrefix(name, ".")
+}
+
+func (s *mediaServiceImpl) maxDimension(kind media_types.ImageKind) (int, bool) {
+	switch kind {
+	case media_types.ImageKindAvatar:
+		return s.cfg.AvatarMaxDimension, true
+	case media_types.ImageKindArticle:
+		return s.cfg.ArticleImageMaxDimension, true
+	default:
+		return 0, false
+	}
+}
+
+func blobKey(kind media_types.ImageKind, name string) string {
+	return string(kind) + "/" + name
+}
+
+func extensionFor(contentType string) string {
+	if contentType == "image/jpeg" {
+		return ".jpg"
+	}
+	return ".png"
+}
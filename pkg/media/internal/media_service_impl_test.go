@@ -0,0 +1,253 @@
+package internal_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"io"
+	"strings"
+	"testing"
+
+	blob_store_types "github.com/nimaeskandary/go-realworld/pkg/blob_store/types"
+	blob_store_types_mocks "github.com/nimaeskandary/go-realworld/pkg/blob_store/types/mocks"
+	"github.com/nimaeskandary/go-realworld/pkg/media/internal"
+	media_types "github.com/nimaeskandary/go-realworld/pkg/media/types"
+	"github.com/nimaeskandary/go-realworld/pkg/test_utils/config"
+	"github.com/nimaeskandary/go-realworld/pkg/test_utils/helpers"
+
+	"github.com/samber/mo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_MediaServiceImpl(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.NewTestConfig().Media
+
+	type testFixture struct {
+		blobStoreMock *blob_store_types_mocks.MockBlobStore
+		underTest     media_types.MediaService
+	}
+
+	setup := func(t *testing.T) testFixture {
+		blobStoreMock := blob_store_types_mocks.NewMockBlobStore(t)
+		return testFixture{
+			blobStoreMock: blobStoreMock,
+			underTest:     internal.NewMediaServiceImpl(cfg, blobStoreMock),
+		}
+	}
+
+	// expectPut captures what is stored, so tests can decode it
+	expectPut := func(f testFixture, keyPrefix string, contentType string) *bytes.Buffer {
+		stored := new(bytes.Buffer)
+		f.blobStoreMock.EXPECT().
+			Put(mock.Anything, mock.MatchedBy(func(key string) bool { return strings.HasPrefix(key, keyPrefix) }), contentType, mock.Anything).
+			RunAndReturn(func(_ context.Context, _ string, _ string, content io.Reader) error {
+				_, err := io.Copy(stored, content)
+				return err
+			})
+		return stored
+	}
+
+	t.Run("UploadImage", func(t *testing.T) {
+		t.Parallel()
+
+		t.Run("should return TooLargeError if the upload exceeds the limit", func(t *testing.T) {
+			t.Parallel()
+			f := setup(t)
+
+			stored, err := f.underTest.UploadImage(t.Context(), media_types.ImageKindAvatar, bytes.NewReader(make([]byte, cfg.MaxUploadBytes+1)))
+			assert.Empty(t, stored)
+			assert.IsType(t, media_types.TooLargeError{}, err)
+		})
+
+		t.Run("should return UnsupportedMediaTypeError if the content is not a supported image", func(t *testing.T) {
+			t.Parallel()
+			f := setup(t)
+
+			stored, err := f.underTest.UploadImage(t.Context(), media_types.ImageKindAvatar, strings.NewReader("<html>not an image</html>"))
+			assert.Empty(t, stored)
+			assert.IsType(t, media_types.UnsupportedMediaTypeError{}, err)
+		})
+
+		t.Run("should return BadParamsError if the image is corrupt", func(t *testing.T) {
+			t.Parallel()
+			f := setup(t)
+			truncated := helpers.GenImage(t, "png", 10, 10)[:40]
+
+			stored, err := f.underTest.UploadImage(t.Context(), media_types.ImageKindAvatar, bytes.NewReader(truncated))
+			assert.Empty(t, stored)
+			assert.IsType(t, media_types.BadParamsError{}, err)
+		})
+
+		t.Run("should return BadParamsError if the image has too many pixels", func(t *testing.T) {
+			t.Parallel()
+			blobStoreMock := blob_store_types_mocks.NewMockBlobStore(t)
+			smallCfg := cfg
+			smallCfg.MaxSourcePixels = 99
+			underTest := internal.NewMediaServiceImpl(smallCfg, blobStoreMock)
+
+			stored, err := underTest.UploadImage(t.Context(), media_types.ImageKindAvatar, bytes.NewReader(helpers.GenImage(t, "png", 10, 10)))
+			assert.Empty(t, stored)
+			assert.IsType(t, media_types.BadParamsError{}, err)
+		})
+
+		t.Run("should return UnknownError if the blob store fails", func(t *testing.T) {
+			t.Parallel()
+			f := setup(t)
+			f.blobStoreMock.EXPECT().Put(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(errors.New("disk full"))
+
+			stored, err := f.underTest.UploadImage(t.Context(), media_types.ImageKindAvatar, bytes.NewReader(helpers.GenImage(t, "png", 10, 10)))
+			assert.Empty(t, stored)
+			assert.IsType(t, media_types.UnknownError{}, err)
+		})
+
+		t.Run("should store a small image at its original size", func(t *testing.T) {
+			t.Parallel()
+			f := setup(t)
+			storedBytes := expectPut(f, "avatars/", "image/png")
+
+			stored, err := f.underTest.UploadImage(t.Context(), media_types.ImageKindAvatar, bytes.NewReader(helpers.GenImage(t, "png", 20, 10)))
+			require.NoError(t, err)
+
+			assert.Equal(t, media_types.ImageKindAvatar, stored.Kind)
+			assert.Equal(t, cfg.PublicBaseUrl+"/media/avatars/"+stored.Name, stored.Url)
+			assert.True(t, strings.HasSuffix(stored.Name, ".png"))
+			assert.Equal(t, 20, stored.Width)
+			assert.Equal(t, 10, stored.Height)
+
+			decoded, format, decodeErr := image.Decode(storedBytes)
+			require.NoError(t, decodeErr)
+			assert.Equal(t, "png", format)
+			assert.Equal(t, image.Rect(0, 0, 20, 10), decoded.Bounds())
+		})
+
+		t.Run("should scale a large image down to fit the limit for its kind, keeping the aspect ratio", func(t *testing.T) {
+			t.Parallel()
+			f := setup(t)
+			storedBytes := expectPut(f, "articles/", "image/jpeg")
+
+			stored, err := f.underTest.UploadImage(t.Context(), media_types.ImageKindArticle, bytes.NewReader(helpers.GenImage(t, "jpeg", 1024, 512)))
+			require.NoError(t, err)
+
+			assert.True(t, strings.HasSuffix(stored.Name, ".jpg"))
+			assert.Equal(t, cfg.ArticleImageMaxDimension, stored.Width)
+			assert.Equal(t, cfg.ArticleImageMaxDimension/2, stored.Height)
+
+			decoded, format, decodeErr := image.Decode(storedBytes)
+			require.NoError(t, decodeErr)
+			assert.Equal(t, "jpeg", format)
+			assert.Equal(t, image.Rect(0, 0, cfg.ArticleImageMaxDimension, cfg.ArticleImageMaxDimension/2), decoded.Bounds())
+		})
+
+		t.Run("should store a gif as a png", func(t *testing.T) {
+			t.Parallel()
+			f := setup(t)
+			storedBytes := expectPut(f, "avatars/", "image/png")
+
+			_, err := f.underTest.UploadImage(t.Context(), media_types.ImageKindAvatar, bytes.NewReader(helpers.GenImage(t, "gif", 10, 10)))
+			require.NoError(t, err)
+
+			_, format, decodeErr := image.Decode(storedBytes)
+			require.NoError(t, decodeErr)
+			assert.Equal(t, "png", format)
+		})
+	})
+
+	t.Run("GetImage", func(t *testing.T) {
+		t.Parallel()
+
+		t.Run("should return NotFoundError for names that were not generated by the service", func(t *testing.T) {
+			t.Parallel()
+			f := setup(t)
+
+			for _, name := range []string{"..", ".hidden.png", "nested/name.png"} {
+				_, err := f.underTest.GetImage(t.Context(), media_types.ImageKindAvatar, name)
+				assert.IsType(t, media_types.NotFoundError{}, err, "name %q", name)
+			}
+
+			_, err := f.underTest.GetImage(t.Context(), media_types.ImageKind("unknown"), "image.png")
+			assert.IsType(t, media_types.NotFoundError{}, err)
+		})
+
+		t.Run("should return NotFoundError if the image is not stored", func(t *testing.T) {
+			t.Parallel()
+			f := setup(t)
+			f.blobStoreMock.EXPECT().Get(mock.Anything, "avatars/missing.png").Return(mo.None[blob_store_types.Blob](), nil)
+
+			_, err := f.underTest.GetImage(t.Context(), media_types.ImageKindAvatar, "missing.png")
+			assert.IsType(t, media_types.NotFoundError{}, err)
+		})
+
+		t.Run("should return the stored image", func(t *testing.T) {
+			t.Parallel()
+			f := setup(t)
+			blob := blob_store_types.Blob{ContentType: "image/png", Size: 3, Body: io.NopCloser(strings.NewReader("png"))}
+			f.blobStoreMock.EXPECT().Get(mock.Anything, "avatars/stored.png").Return(mo.Some(blob), nil)
+
+			result, err := f.underTest.GetImage(t.Context(), media_types.ImageKindAvatar, "stored.png")
+			assert.NoError(t, err)
+			assert.Equal(t, blob, result)
+		})
+	})
+
+	t.Run("DeleteImage", func(t *testing.T) {
+		t.Parallel()
+
+		t.Run("should return NotFoundError for names that were not generated by the service", func(t *testing.T) {
+			t.Parallel()
+			f := setup(t)
+
+			err := f.underTest.DeleteImage(t.Context(), media_types.ImageKindAvatar, "../name.png")
+			assert.IsType(t, media_types.NotFoundError{}, err)
+		})
+
+		t.Run("should return UnknownError if the blob store fails", func(t *testing.T) {
+			t.Parallel()
+			f := setup(t)
+			f.blobStoreMock.EXPECT().Delete(mock.Anything, "avatars/stored.png").Return(errors.New("disk failure"))
+
+			err := f.underTest.DeleteImage(t.Context(), media_types.ImageKindAvatar, "stored.png")
+			assert.IsType(t, media_types.UnknownError{}, err)
+		})
+
+		t.Run("should delete the stored image", func(t *testing.T) {
+			t.Parallel()
+			f := setup(t)
+			f.blobStoreMock.EXPECT().Delete(mock.Anything, "avatars/stored.png").Return(nil)
+
+			err := f.underTest.DeleteImage(t.Context(), media_types.ImageKindAvatar, "stored.png")
+			assert.NoError(t, err)
+		})
+	})
+
+	t.Run("DeleteImageByUrl", func(t *testing.T) {
+		t.Parallel()
+
+		t.Run("should do nothing for urls the service did not generate", func(t *testing.T) {
+			t.Parallel()
+			f := setup(t)
+
+			for _, imageUrl := range []string{
+				"https://example.com/media/avatars/stored.png",
+				cfg.PublicBaseUrl + "/media/avatars/..%2Fstored.png",
+				cfg.PublicBaseUrl + "/media/unknown/stored.png",
+				cfg.PublicBaseUrl + "/media/avatars",
+			} {
+				assert.NoError(t, f.underTest.DeleteImageByUrl(t.Context(), imageUrl), imageUrl)
+			}
+		})
+
+		t.Run("should delete the stored image the url points to", func(t *testing.T) {
+			t.Parallel()
+			f := setup(t)
+			f.blobStoreMock.EXPECT().Delete(mock.Anything, "avatars/stored.png").Return(nil)
+
+			err := f.underTest.DeleteImageByUrl(t.Context(), cfg.PublicBaseUrl+"/media/avatars/stored.png")
+			assert.NoError(t, err)
+		})
+	})
+}
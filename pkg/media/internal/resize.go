@@ -0,0 +1,47 @@
+package internal
+
+import (
+	"image"
+	"image/color"
+)
+
+// resizeToFit scales img down, keeping its aspect ratio, so neither side exceeds maxDimension. Images that already
+// fit are returned as is. Each destination pixel is the average of the source pixels it covers, which avoids the
+// aliasing nearest neighbour sampling gives when shrinking by large factors
+func resizeToFit(img image.Image, maxDimension int) image.Image {
+	bounds := img.Bounds()
+	srcW, srcH := bounds.Dx(), bounds.Dy()
+	if srcW <= maxDimension && srcH <= maxDimension {
+		return img
+	}
+
+	dstW, dstH := maxDimension, maxDimension
+	if srcW > srcH {
+		dstH = max(1, srcH*maxDimension/srcW)
+	} else {
+		dstW = max(1, srcW*maxDimension/srcH)
+	}
+
+	dst := image.NewRGBA64(image.Rect(0, 0, dstW, dstH))
+	for y := range dstH {
+		srcY0 := bounds.Min.Y + y*srcH/dstH
+		srcY1 := max(srcY0+1, bounds.Min.Y+(y+1)*srcH/dstH)
+		for x := range dstW {
+			srcX0 := bounds.Min.X + x*srcW/dstW
+			srcX1 := max(srcX0+1, bounds.Min.X+(x+1)*srcW/dstW)
+
+			// RGBA() is alpha premultiplied, so averaging the channels directly is correct for transparent pixels
+			var r, g, b, a, n uint64
+			for sy := srcY0; sy < srcY1; sy++ {
+				for sx := srcX0; sx < srcX1; sx++ {
+					sr, sg, sb, sa := img.At(sx, sy).RGBA()
+					r, g, b, a = r+uint64(sr), g+uint64(sg), b+uint64(sb), a+uint64(sa)
+					n++
+				}
+			}
+			dst.SetRGBA64(x, y, color.RGBA64{R: uint16(r / n), G: uint16(g / n), B: uint16(b / n), A: uint16(a / n)})
+		}
+	}
+
+	return dst
+}
@@ -0,0 +1,16 @@
+package media
+
+import (
+	"github.com/nimaeskandary/go-realworld/pkg/media/internal"
+	media_types "github.com/nimaeskandary/go-realworld/pkg/media/types"
+	"github.com/nimaeskandary/go-realworld/pkg/util"
+
+	"go.uber.org/fx"
+)
+
+func NewMediaModule() fx.Option {
+	return util.NewFxModule[media_types.MediaService](
+		"media_service",
+		internal.NewMediaServiceImpl,
+	)
+}
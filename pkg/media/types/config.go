@@ -0,0 +1,15 @@
+package media_types
+
+type MediaConfig struct {
+	// PublicBaseUrl is prepended to the media route to build the urls of stored images, e.g. "https://api.example.com"
+	PublicBaseUrl string `json:"public_base_url" validate:"required"`
+	// MaxUploadBytes is the largest upload accepted, before re-encoding
+	MaxUploadBytes int64 `json:"max_upload_bytes" validate:"required"`
+	// MaxSourcePixels bounds width * height of an upload, it is checked before decoding so a small file that
+	// decompresses to a huge image is rejected without allocating it
+	MaxSourcePixels int64 `json:"max_source_pixels" validate:"required"`
+	// AvatarMaxDimension is the largest width or height an avatar is stored at, larger images are scaled down
+	AvatarMaxDimension int `json:"avatar_max_dimension" validate:"required"`
+	// ArticleImageMaxDimension is the largest width or height an article image is stored at, larger images are scaled down
+	ArticleImageMaxDimension int `json:"article_image_max_dimension" validate:"required"`
+}
@@ -0,0 +1,64 @@
+package media_types
+
+import (
+	"errors"
+	"fmt"
+)
+
+type DomainError interface {
+	// unexported method keeps this sealed to the package
+	sealed()
+	error
+}
+
+func AsDomainError(err error) DomainError {
+	if de, ok := errors.AsType[DomainError](err); ok {
+		return de
+	}
+	return UnknownError{Err: err}
+}
+
+type UnknownError struct {
+	Err error
+}
+
+func (e UnknownError) sealed() {}
+func (e UnknownError) Error() string {
+	return fmt.Errorf("UnknownError: unknown media domain error: %w", e.Err).Error()
+}
+
+type NotFoundError struct {
+	Identifier string
+}
+
+func (e NotFoundError) sealed() {}
+func (e NotFoundError) Error() string {
+	return fmt.Sprintf("NotFoundError: could not find media with identifier: %v", e.Identifier)
+}
+
+type TooLargeError struct {
+	MaxBytes int64
+}
+
+func (e TooLargeError) sealed() {}
+func (e TooLargeError) Error() string {
+	return fmt.Sprintf("TooLargeError: upload exceeds the limit of %v bytes", e.MaxBytes)
+}
+
+type UnsupportedMediaTypeError struct {
+	ContentType string
+}
+
+func (e UnsupportedMediaTypeError) sealed() {}
+func (e UnsupportedMediaTypeError) Error() string {
+	return fmt.Sprintf("UnsupportedMediaTypeError: content type %v is not supported, upload a png, jpeg or gif image", e.ContentType)
+}
+
+type BadParamsError struct {
+	Err error
+}
+
+func (e BadParamsError) sealed() {}
+func (e BadParamsError) Error() string {
+	return fmt.Errorf("BadParamsError: media params validations error: %w", e.Err).Error()
+}
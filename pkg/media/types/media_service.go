@@ -0,0 +1,38 @@
+package media_types
+
+import (
+	"context"
+	"io"
+
+	blob_store_types "github.com/nimaeskandary/go-realworld/pkg/blob_store/types"
+)
+
+type ImageKind string
+
+const (
+	ImageKindAvatar  ImageKind = "avatars"
+	ImageKindArticle ImageKind = "articles"
+)
+
+type StoredImage struct {
+	Kind   ImageKind
+	Name   string
+	Url    string
+	Width  int
+	Height int
+}
+
+//mockery:generate: true
+type MediaService interface {
+	// UploadImage sniffs and decodes the content, scales it down to the size limit for its kind, and stores it
+	// re-encoded, which also strips any metadata embedded in the original
+	UploadImage(ctx context.Context, kind ImageKind, content io.Reader) (StoredImage, DomainError)
+	// GetImage returns the stored image, the caller must close its body
+	GetImage(ctx context.Context, kind ImageKind, name string) (blob_store_types.Blob, DomainError)
+	// DeleteImage removes a stored image, e.g. one uploaded for a change that then failed. It is a no-op if the image is
+	// not stored
+	DeleteImage(ctx context.Context, kind ImageKind, name string) DomainError
+	// DeleteImageByUrl removes the stored image the url points to, e.g. an avatar that was replaced. It is a no-op for
+	// urls this service did not generate, such as an external image set through PUT /user
+	DeleteImageByUrl(ctx context.Context, imageUrl string) DomainError
+}
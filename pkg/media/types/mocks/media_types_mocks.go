@@ -0,0 +1,313 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package media_types_mocks
+
+import (
+	"context"
+	"io"
+
+	"github.com/nimaeskandary/go-realworld/pkg/blob_store/types"
+	"github.com/nimaeskandary/go-realworld/pkg/media/types"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockMediaService creates a new instance of MockMediaService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockMediaService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockMediaService {
+	mock := &MockMediaService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockMediaService is an autogenerated mock type for the MediaService type
+type MockMediaService struct {
+	mock.Mock
+}
+
+type MockMediaService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockMediaService) EXPECT() *MockMediaService_Expecter {
+	return &MockMediaService_Expecter{mock: &_m.Mock}
+}
+
+// DeleteImage provides a mock function for the type MockMediaService
+func (_mock *MockMediaService) DeleteImage(ctx context.Context, kind media_types.ImageKind, name string) media_types.DomainError {
+	ret := _mock.Called(ctx, kind, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteImage")
+	}
+
+	var r0 media_types.DomainError
+	if returnFunc, ok := ret.Get(0).(func(context.Context, media_types.ImageKind, string) media_types.DomainError); ok {
+		r0 = returnFunc(ctx, kind, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(media_types.DomainError)
+		}
+	}
+	return r0
+}
+
+// MockMediaService_DeleteImage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteImage'
+type MockMediaService_DeleteImage_Call struct {
+	*mock.Call
+}
+
+// DeleteImage is a helper method to define mock.On call
+//   - ctx context.Context
+//   - kind media_types.ImageKind
+//   - name string
+func (_e *MockMediaService_Expecter) DeleteImage(ctx interface{}, kind interface{}, name interface{}) *MockMediaService_DeleteImage_Call {
+	return &MockMediaService_DeleteImage_Call{Call: _e.mock.On("DeleteImage", ctx, kind, name)}
+}
+
+func (_c *MockMediaService_DeleteImage_Call) Run(run func(ctx context.Context, kind media_types.ImageKind, name string)) *MockMediaService_DeleteImage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 media_types.ImageKind
+		if args[1] != nil {
+			arg1 = args[1].(media_types.ImageKind)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMediaService_DeleteImage_Call) Return(domainError media_types.DomainError) *MockMediaService_DeleteImage_Call {
+	_c.Call.Return(domainError)
+	return _c
+}
+
+func (_c *MockMediaService_DeleteImage_Call) RunAndReturn(run func(ctx context.Context, kind media_types.ImageKind, name string) media_types.DomainError) *MockMediaService_DeleteImage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteImageByUrl provides a mock function for the type MockMediaService
+func (_mock *MockMediaService) DeleteImageByUrl(ctx context.Context, imageUrl string) media_types.DomainError {
+	ret := _mock.Called(ctx, imageUrl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteImageByUrl")
+	}
+
+	var r0 media_types.DomainError
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) media_types.DomainError); ok {
+		r0 = returnFunc(ctx, imageUrl)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(media_types.DomainError)
+		}
+	}
+	return r0
+}
+
+// MockMediaService_DeleteImageByUrl_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteImageByUrl'
+type MockMediaService_DeleteImageByUrl_Call struct {
+	*mock.Call
+}
+
+// DeleteImageByUrl is a helper method to define mock.On call
+//   - ctx context.Context
+//   - imageUrl string
+func (_e *MockMediaService_Expecter) DeleteImageByUrl(ctx interface{}, imageUrl interface{}) *MockMediaService_DeleteImageByUrl_Call {
+	return &MockMediaService_DeleteImageByUrl_Call{Call: _e.mock.On("DeleteImageByUrl", ctx, imageUrl)}
+}
+
+func (_c *MockMediaService_DeleteImageByUrl_Call) Run(run func(ctx context.Context, imageUrl string)) *MockMediaService_DeleteImageByUrl_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMediaService_DeleteImageByUrl_Call) Return(domainError media_types.DomainError) *MockMediaService_DeleteImageByUrl_Call {
+	_c.Call.Return(domainError)
+	return _c
+}
+
+func (_c *MockMediaService_DeleteImageByUrl_Call) RunAndReturn(run func(ctx context.Context, imageUrl string) media_types.DomainError) *MockMediaService_DeleteImageByUrl_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetImage provides a mock function for the type MockMediaService
+func (_mock *MockMediaService) GetImage(ctx context.Context, kind media_types.ImageKind, name string) (blob_store_types.Blob, media_types.DomainError) {
+	ret := _mock.Called(ctx, kind, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetImage")
+	}
+
+	var r0 blob_store_types.Blob
+	var r1 media_types.DomainError
+	if returnFunc, ok := ret.Get(0).(func(context.Context, media_types.ImageKind, string) (blob_store_types.Blob, media_types.DomainError)); ok {
+		return returnFunc(ctx, kind, name)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, media_types.ImageKind, string) blob_store_types.Blob); ok {
+		r0 = returnFunc(ctx, kind, name)
+	} else {
+		r0 = ret.Get(0).(blob_store_types.Blob)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, media_types.ImageKind, string) media_types.DomainError); ok {
+		r1 = returnFunc(ctx, kind, name)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(media_types.DomainError)
+		}
+	}
+	return r0, r1
+}
+
+// MockMediaService_GetImage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetImage'
+type MockMediaService_GetImage_Call struct {
+	*mock.Call
+}
+
+// GetImage is a helper method to define mock.On call
+//   - ctx context.Context
+//   - kind media_types.ImageKind
+//   - name string
+func (_e *MockMediaService_Expecter) GetImage(ctx interface{}, kind interface{}, name interface{}) *MockMediaService_GetImage_Call {
+	return &MockMediaService_GetImage_Call{Call: _e.mock.On("GetImage", ctx, kind, name)}
+}
+
+func (_c *MockMediaService_GetImage_Call) Run(run func(ctx context.Context, kind media_types.ImageKind, name string)) *MockMediaService_GetImage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 media_types.ImageKind
+		if args[1] != nil {
+			arg1 = args[1].(media_types.ImageKind)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMediaService_GetImage_Call) Return(blob blob_store_types.Blob, domainError media_types.DomainError) *MockMediaService_GetImage_Call {
+	_c.Call.Return(blob, domainError)
+	return _c
+}
+
+func (_c *MockMediaService_GetImage_Call) RunAndReturn(run func(ctx context.Context, kind media_types.ImageKind, name string) (blob_store_types.Blob, media_types.DomainError)) *MockMediaService_GetImage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UploadImage provides a mock function for the type MockMediaService
+func (_mock *MockMediaService) UploadImage(ctx context.Context, kind media_types.ImageKind, content io.Reader) (media_types.StoredImage, media_types.DomainError) {
+	ret := _mock.Called(ctx, kind, content)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UploadImage")
+	}
+
+	var r0 media_types.StoredImage
+	var r1 media_types.DomainError
+	if returnFunc, ok := ret.Get(0).(func(context.Context, media_types.ImageKind, io.Reader) (media_types.StoredImage, media_types.DomainError)); ok {
+		return returnFunc(ctx, kind, content)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, media_types.ImageKind, io.Reader) media_types.StoredImage); ok {
+		r0 = returnFunc(ctx, kind, content)
+	} else {
+		r0 = ret.Get(0).(media_types.StoredImage)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, media_types.ImageKind, io.Reader) media_types.DomainError); ok {
+		r1 = returnFunc(ctx, kind, content)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(media_types.DomainError)
+		}
+	}
+	return r0, r1
+}
+
+// MockMediaService_UploadImage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UploadImage'
+type MockMediaService_UploadImage_Call struct {
+	*mock.Call
+}
+
+// UploadImage is a helper method to define mock.On call
+//   - ctx context.Context
+//   - kind media_types.ImageKind
+//   - content io.Reader
+func (_e *MockMediaService_Expecter) UploadImage(ctx interface{}, kind interface{}, content interface{}) *MockMediaService_UploadImage_Call {
+	return &MockMediaService_UploadImage_Call{Call: _e.mock.On("UploadImage", ctx, kind, content)}
+}
+
+func (_c *MockMediaService_UploadImage_Call) Run(run func(ctx context.Context, kind media_types.ImageKind, content io.Reader)) *MockMediaService_UploadImage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 media_types.ImageKind
+		if args[1] != nil {
+			arg1 = args[1].(media_types.ImageKind)
+		}
+		var arg2 io.Reader
+		if args[2] != nil {
+			arg2 = args[2].(io.Reader)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMediaService_UploadImage_Call) Return(storedImage media_types.StoredImage, domainError media_types.DomainError) *MockMediaService_UploadImage_Call {
+	_c.Call.Return(storedImage, domainError)
+	return _c
+}
+
+func (_c *MockMediaService_UploadImage_Call) RunAndReturn(run func(ctx context.Context, kind media_types.ImageKind, content io.Reader) (media_types.StoredImage, media_types.DomainError)) *MockMediaService_UploadImage_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -42,6 +42,10 @@ func (l *slogger) Info(ctx context.Context, msg string, attributes ...any) {
 	l.logger.Info(msg, append(attributesFromContext(ctx), attributes...)...)
 }
 
+func (l *slogger) Warn(ctx context.Context, msg string, attributes ...any) {
+	l.logger.Warn(msg, append(attributesFromContext(ctx), attributes...)...)
+}
+
 func (l *slogger) Error(ctx context.Context, msg string, err error, attributes ...any) {
 	finalAttrs := append(attributesFromContext(ctx), attributes...)
 	finalAttrs = append(finalAttrs, "error", err)
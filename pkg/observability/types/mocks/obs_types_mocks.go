@@ -230,3 +230,63 @@ func (_c *MockLogger_Info_Call) RunAndReturn(run func(ctx context.Context, msg s
 	_c.Run(run)
 	return _c
 }
+
+// Warn provides a mock function for the type MockLogger
+func (_mock *MockLogger) Warn(ctx context.Context, msg string, attributes ...any) {
+	if len(attributes) > 0 {
+		_mock.Called(ctx, msg, attributes)
+	} else {
+		_mock.Called(ctx, msg)
+	}
+
+	return
+}
+
+// MockLogger_Warn_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Warn'
+type MockLogger_Warn_Call struct {
+	*mock.Call
+}
+
+// Warn is a helper method to define mock.On call
+//   - ctx context.Context
+//   - msg string
+//   - attributes ...any
+func (_e *MockLogger_Expecter) Warn(ctx interface{}, msg interface{}, attributes ...interface{}) *MockLogger_Warn_Call {
+	return &MockLogger_Warn_Call{Call: _e.mock.On("Warn",
+		append([]interface{}{ctx, msg}, attributes...)...)}
+}
+
+func (_c *MockLogger_Warn_Call) Run(run func(ctx context.Context, msg string, attributes ...any)) *MockLogger_Warn_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 []any
+		var variadicArgs []any
+		if len(args) > 2 {
+			variadicArgs = args[2].([]any)
+		}
+		arg2 = variadicArgs
+		run(
+			arg0,
+			arg1,
+			arg2...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockLogger_Warn_Call) Return() *MockLogger_Warn_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockLogger_Warn_Call) RunAndReturn(run func(ctx context.Context, msg string, attributes ...any)) *MockLogger_Warn_Call {
+	_c.Run(run)
+	return _c
+}
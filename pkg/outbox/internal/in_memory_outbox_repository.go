@@ -0,0 +1,123 @@
+package internal
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	db_types "github.com/nimaeskandary/go-realworld/pkg/database/types"
+	outbox_types "github.com/nimaeskandary/go-realworld/pkg/outbox/types"
+
+	"github.com/google/uuid"
+	"github.com/samber/mo"
+)
+
+// inMemoryOutboxRepo is the in memory counterpart of postgresOutboxRepo. A transaction of the in memory TxManager holds
+// the db's write lock, so claimed events are not claimed again until it ends
+type inMemoryOutboxRepo struct {
+	db db_types.RealWorldAppInMemoryDb
+}
+
+func NewInMemoryOutboxRepository(db db_types.RealWorldAppInMemoryDb) outbox_types.OutboxRepository {
+	return &inMemoryOutboxRepo{db: db}
+}
+
+// InsertEvent implements [outbox_types.OutboxRepository.InsertEvent]
+func (r *inMemoryOutboxRepo) InsertEvent(ctx context.Context, event outbox_types.OutboxEvent) error {
+	return r.db.Write(ctx, func(tables *db_types.InMemoryTables) error {
+		if _, exists := tables.Outbox[event.Id]; exists {
+			return fmt.Errorf("error with insert outbox event, id=%v, type=%v: duplicate id", event.Id, event.Type)
+		}
+		createdAt := time.UnixMilli(event.CreatedAtMillis)
+		tables.Outbox[event.Id] = db_types.InMemoryOutboxEvent{
+			Id:            event.Id,
+			EventType:     event.Type,
+			Payload:       event.Payload,
+			Attempts:      event.Attempts,
+			NextAttemptAt: mo.Some(createdAt),
+			CreatedAt:     createdAt,
+		}
+		return nil
+	})
+}
+
+// ClaimEvents implements [outbox_types.OutboxRepository.ClaimEvents]
+func (r *inMemoryOutboxRepo) ClaimEvents(ctx context.Context, limit int, now time.Time) ([]outbox_types.OutboxEvent, error) {
+	var due []db_types.InMemoryOutboxEvent
+	err := r.db.Read(ctx, func(tables *db_types.InMemoryTables) error {
+		for _, event := range tables.Outbox {
+			if nextAttemptAt, ok := event.NextAttemptAt.Get(); ok && !nextAttemptAt.After(now) {
+				due = append(due, event)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slices.SortFunc(due, func(a, b db_types.InMemoryOutboxEvent) int {
+		return cmp.Or(a.CreatedAt.Compare(b.CreatedAt), cmp.Compare(a.Id.String(), b.Id.String()))
+	})
+	events := make([]outbox_types.OutboxEvent, 0, min(limit, len(due)))
+	for _, event := range due[:min(limit, len(due))] {
+		events = append(events, outbox_types.OutboxEvent{
+			Id:              event.Id,
+			Type:            event.EventType,
+			Payload:         event.Payload,
+			Attempts:        event.Attempts,
+			CreatedAtMillis: event.CreatedAt.UnixMilli(),
+		})
+	}
+	return events, nil
+}
+
+// MarkDelivered implements [outbox_types.OutboxRepository.MarkDelivered]
+func (r *inMemoryOutboxRepo) MarkDelivered(ctx context.Context, id uuid.UUID, deliveredAt time.Time) error {
+	return r.update(ctx, id, func(event *db_types.InMemoryOutboxEvent) {
+		event.DeliveredAt = mo.Some(deliveredAt)
+		event.NextAttemptAt = mo.None[time.Time]()
+	})
+}
+
+// MarkFailed implements [outbox_types.OutboxRepository.MarkFailed]
+func (r *inMemoryOutboxRepo) MarkFailed(ctx context.Context, id uuid.UUID, lastError string, retryAt mo.Option[time.Time]) error {
+	return r.update(ctx, id, func(event *db_types.InMemoryOutboxEvent) {
+		event.Attempts++
+		event.LastError = mo.Some(lastError)
+		event.NextAttemptAt = retryAt
+	})
+}
+
+// PurgeDelivered implements [outbox_types.OutboxRepository.PurgeDelivered]
+func (r *inMemoryOutboxRepo) PurgeDelivered(ctx context.Context, deliveredBefore time.Time) (int64, error) {
+	var purged int64
+	err := r.db.Write(ctx, func(tables *db_types.InMemoryTables) error {
+		maps.DeleteFunc(tables.Outbox, func(_ uuid.UUID, event db_types.InMemoryOutboxEvent) bool {
+			deliveredAt, ok := event.DeliveredAt.Get()
+			if ok && deliveredAt.Before(deliveredBefore) {
+				purged++
+				return true
+			}
+			return false
+		})
+		return nil
+	})
+	return purged, err
+}
+
+// update replaces the event with the id after applying fn, it is an error if there is no such event
+func (r *inMemoryOutboxRepo) update(ctx context.Context, id uuid.UUID, fn func(event *db_types.InMemoryOutboxEvent)) error {
+	return r.db.Write(ctx, func(tables *db_types.InMemoryTables) error {
+		event, ok := tables.Outbox[id]
+		if !ok {
+			return fmt.Errorf("outbox event not found, id=%v", id)
+		}
+		fn(&event)
+		tables.Outbox[id] = event
+		return nil
+	})
+}
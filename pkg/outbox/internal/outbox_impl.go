@@ -0,0 +1,34 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	outbox_types "github.com/nimaeskandary/go-realworld/pkg/outbox/types"
+
+	"github.com/google/uuid"
+)
+
+type outboxImpl struct {
+	repo outbox_types.OutboxRepository
+}
+
+func NewOutboxImpl(repo outbox_types.OutboxRepository) outbox_types.Outbox {
+	return &outboxImpl{repo: repo}
+}
+
+func (o *outboxImpl) Publish(ctx context.Context, eventType string, payload any) error {
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("error marshalling outbox event payload, type=%v: %w", eventType, err)
+	}
+
+	return o.repo.InsertEvent(ctx, outbox_types.OutboxEvent{
+		Id:              uuid.New(),
+		Type:            eventType,
+		Payload:         payloadBytes,
+		CreatedAtMillis: time.Now().UnixMilli(),
+	})
+}
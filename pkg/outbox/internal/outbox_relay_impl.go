@@ -0,0 +1,168 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	db_types "github.com/nimaeskandary/go-realworld/pkg/database/types"
+	obs_types "github.com/nimaeskandary/go-realworld/pkg/observability/types"
+	outbox_types "github.com/nimaeskandary/go-realworld/pkg/outbox/types"
+
+	"github.com/samber/mo"
+)
+
+type outboxRelayImpl struct {
+	cfg       outbox_types.OutboxConfig
+	repo      outbox_types.OutboxRepository
+	txManager db_types.TxManager
+	logger    obs_types.Logger
+
+	handlersMu sync.RWMutex
+	handlers   map[string][]outbox_types.OutboxHandler
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewOutboxRelayImpl(
+	cfg outbox_types.OutboxConfig,
+	repo outbox_types.OutboxRepository,
+	txManager db_types.TxManager,
+	logger obs_types.Logger,
+) outbox_types.OutboxRelay {
+	return &outboxRelayImpl{
+		cfg:       cfg,
+		repo:      repo,
+		txManager: txManager,
+		logger:    logger,
+		handlers:  map[string][]outbox_types.OutboxHandler{},
+	}
+}
+
+func (r *outboxRelayImpl) RegisterHandler(eventType string, handler outbox_types.OutboxHandler) {
+	r.handlersMu.Lock()
+	defer r.handlersMu.Unlock()
+	r.handlers[eventType] = append(r.handlers[eventType], handler)
+}
+
+func (r *outboxRelayImpl) Start(_ context.Context) error {
+	// the start context is only valid for the duration of the fx start hook
+	ctx, cancel := context.WithCancel(context.Background())
+	r.cancel = cancel
+
+	r.wg.Go(func() {
+		ticker := time.NewTicker(time.Duration(r.cfg.PollIntervalMillis) * time.Millisecond)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				r.relayAll(ctx)
+			}
+		}
+	})
+
+	return nil
+}
+
+func (r *outboxRelayImpl) Stop(_ context.Context) error {
+	if r.cancel != nil {
+		r.cancel()
+		r.wg.Wait()
+		r.cancel = nil
+	}
+	return nil
+}
+
+// relayAll relays batches until the due events run out, then purges the delivered events past retention
+func (r *outboxRelayImpl) relayAll(ctx context.Context) {
+	for ctx.Err() == nil {
+		claimed, err := r.RelayPending(ctx)
+		if err != nil {
+			r.logger.Error(ctx, "outbox relay failed", err)
+			return
+		}
+		if claimed < r.cfg.BatchSize {
+			break
+		}
+	}
+
+	purged, err := r.repo.PurgeDelivered(ctx, r.cfg.DeliveredCutoff(time.Now()))
+	if err != nil {
+		r.logger.Error(ctx, "outbox purge failed", err)
+		return
+	}
+	if purged > 0 {
+		r.logger.Info(ctx, "purged delivered outbox events", "events", purged)
+	}
+}
+
+// RelayPending implements [outbox_types.OutboxRelay.RelayPending]. The batch is claimed and settled in one
+// transaction, so the claim holds until every event in it is marked delivered or failed. Each event is dispatched in
+// a nested transaction, a handler's writes made with its ctx are rolled back if the delivery fails, and committed
+// along with the event being marked delivered if it succeeds
+func (r *outboxRelayImpl) RelayPending(ctx context.Context) (int, error) {
+	claimed := 0
+	err := r.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		now := time.Now()
+		events, err := r.repo.ClaimEvents(ctx, r.cfg.BatchSize, now)
+		if err != nil {
+			return err
+		}
+		claimed = len(events)
+
+		for _, event := range events {
+			dispatchErr := r.txManager.WithinTx(ctx, func(ctx context.Context) error {
+				return r.dispatch(ctx, event)
+			})
+			if dispatchErr == nil {
+				if err := r.repo.MarkDelivered(ctx, event.Id, time.Now()); err != nil {
+					return err
+				}
+				continue
+			}
+
+			attempts := event.Attempts + 1
+			retryAt := mo.None[time.Time]()
+			if attempts < r.cfg.MaxAttempts {
+				retryAt = mo.Some(now.Add(r.cfg.RetryDelay(attempts)))
+			}
+			r.logger.Error(ctx, "outbox event delivery failed", dispatchErr,
+				"id", event.Id, "type", event.Type, "attempts", attempts, "gave_up", retryAt.IsAbsent())
+			if err := r.repo.MarkFailed(ctx, event.Id, dispatchErr.Error(), retryAt); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("error relaying outbox events: %w", err)
+	}
+
+	return claimed, nil
+}
+
+// dispatch runs every handler of the event's type, a failing handler does not stop the others. An event with no
+// handlers fails, rather than being lost, e.g. when it is relayed before its handler is registered
+func (r *outboxRelayImpl) dispatch(ctx context.Context, event outbox_types.OutboxEvent) error {
+	r.handlersMu.RLock()
+	handlers := r.handlers[event.Type]
+	r.handlersMu.RUnlock()
+
+	if len(handlers) == 0 {
+		return fmt.Errorf("no handlers are registered for event type %v", event.Type)
+	}
+
+	var errs []error
+	for _, handler := range handlers {
+		if err := handler(ctx, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
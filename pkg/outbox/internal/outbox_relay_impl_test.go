@@ -0,0 +1,207 @@
+package internal_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	outbox_types "github.com/nimaeskandary/go-realworld/pkg/outbox/types"
+	"github.com/nimaeskandary/go-realworld/pkg/test_utils/fixtures"
+	"github.com/nimaeskandary/go-realworld/pkg/test_utils/helpers"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_OutboxRelayImpl(t *testing.T) {
+	t.Parallel()
+
+	type articleSaved struct {
+		Title string `json:"title"`
+	}
+
+	// recorder is a handler that records the events it is given, failing while fail is set
+	type recorder struct {
+		events []outbox_types.OutboxEvent
+		fail   error
+	}
+	handler := func(r *recorder) outbox_types.OutboxHandler {
+		return func(_ context.Context, event outbox_types.OutboxEvent) error {
+			r.events = append(r.events, event)
+			return r.fail
+		}
+	}
+
+	// due returns the events due within the window, as the relay would claim them then
+	due := func(t *testing.T, f fixtures.StandardFixture, within time.Duration) []outbox_types.OutboxEvent {
+		var events []outbox_types.OutboxEvent
+		err := f.TxManager.WithinTx(t.Context(), func(ctx context.Context) error {
+			var err error
+			events, err = f.OutboxRepo.ClaimEvents(ctx, 100, time.Now().Add(within))
+			return err
+		})
+		require.NoError(t, err)
+		return events
+	}
+
+	t.Run("RelayPending", func(t *testing.T) {
+		t.Parallel()
+
+		t.Run("should deliver published events to every handler of their type, once", func(t *testing.T) {
+			t.Parallel()
+			f := fixtures.SetupInMemoryFixture(t)
+			saved, alsoSaved, other := &recorder{}, &recorder{}, &recorder{}
+			f.OutboxRelay.RegisterHandler("article.saved", handler(saved))
+			f.OutboxRelay.RegisterHandler("article.saved", handler(alsoSaved))
+			f.OutboxRelay.RegisterHandler("article.deleted", handler(other))
+
+			require.NoError(t, f.Outbox.Publish(t.Context(), "article.saved", articleSaved{Title: "Hello"}))
+
+			claimed, err := f.OutboxRelay.RelayPending(t.Context())
+			require.NoError(t, err)
+			assert.Equal(t, 1, claimed)
+			require.Len(t, saved.events, 1)
+			assert.Equal(t, "article.saved", saved.events[0].Type)
+			assert.JSONEq(t, `{"title": "Hello"}`, string(saved.events[0].Payload))
+			assert.Len(t, alsoSaved.events, 1)
+			assert.Empty(t, other.events)
+
+			claimed, err = f.OutboxRelay.RelayPending(t.Context())
+			require.NoError(t, err)
+			assert.Equal(t, 0, claimed)
+			assert.Len(t, saved.events, 1)
+		})
+
+		t.Run("should not deliver an event whose transaction rolled back", func(t *testing.T) {
+			t.Parallel()
+			f := fixtures.SetupInMemoryFixture(t)
+			saved := &recorder{}
+			f.OutboxRelay.RegisterHandler("article.saved", handler(saved))
+
+			rollback := errors.New("rollback")
+			err := f.TxManager.WithinTx(t.Context(), func(ctx context.Context) error {
+				require.NoError(t, f.Outbox.Publish(ctx, "article.saved", articleSaved{Title: "Hello"}))
+				return rollback
+			})
+			require.ErrorIs(t, err, rollback)
+
+			claimed, err := f.OutboxRelay.RelayPending(t.Context())
+			require.NoError(t, err)
+			assert.Equal(t, 0, claimed)
+			assert.Empty(t, saved.events)
+		})
+
+		t.Run("should fail the delivery of an event with no handlers, keeping it to retry", func(t *testing.T) {
+			t.Parallel()
+			f := fixtures.SetupInMemoryFixture(t)
+
+			require.NoError(t, f.Outbox.Publish(t.Context(), "article.saved", articleSaved{Title: "Hello"}))
+
+			claimed, err := f.OutboxRelay.RelayPending(t.Context())
+			require.NoError(t, err)
+			assert.Equal(t, 1, claimed)
+
+			// the test config's first retry is a second later
+			assert.Empty(t, due(t, f, 0))
+			retried := due(t, f, 2*time.Second)
+			require.Len(t, retried, 1)
+			assert.Equal(t, "article.saved", retried[0].Type)
+			assert.Equal(t, 1, retried[0].Attempts)
+		})
+
+		t.Run("should retry a failed delivery after the retry delay", func(t *testing.T) {
+			t.Parallel()
+			f := fixtures.SetupInMemoryFixture(t)
+			failing := &recorder{fail: errors.New("index unavailable")}
+			f.OutboxRelay.RegisterHandler("article.saved", handler(failing))
+
+			require.NoError(t, f.Outbox.Publish(t.Context(), "article.saved", articleSaved{Title: "Hello"}))
+
+			claimed, err := f.OutboxRelay.RelayPending(t.Context())
+			require.NoError(t, err)
+			assert.Equal(t, 1, claimed)
+			assert.Len(t, failing.events, 1)
+
+			// the test config's first retry is a second later
+			assert.Empty(t, due(t, f, 0))
+			retried := due(t, f, 2*time.Second)
+			require.Len(t, retried, 1)
+			assert.Equal(t, 1, retried[0].Attempts)
+		})
+
+		t.Run("should give up on an event once it runs out of attempts", func(t *testing.T) {
+			t.Parallel()
+			f := fixtures.SetupInMemoryFixture(t)
+			failing := &recorder{fail: errors.New("index unavailable")}
+			f.OutboxRelay.RegisterHandler("article.saved", handler(failing))
+
+			// one attempt left, as the test config allows 3
+			event := helpers.GenOutboxEvent("article.saved", time.Now())
+			event.Attempts = 2
+			require.NoError(t, f.OutboxRepo.InsertEvent(t.Context(), event))
+
+			_, err := f.OutboxRelay.RelayPending(t.Context())
+			require.NoError(t, err)
+			assert.Len(t, failing.events, 1)
+			assert.Empty(t, due(t, f, 24*time.Hour))
+		})
+
+		t.Run("should roll back the writes of a failed delivery", func(t *testing.T) {
+			t.Parallel()
+			f := fixtures.SetupInMemoryFixture(t)
+			// the handler writes a follow up event, then fails
+			f.OutboxRelay.RegisterHandler("article.saved", func(ctx context.Context, _ outbox_types.OutboxEvent) error {
+				if err := f.Outbox.Publish(ctx, "article.indexed", articleSaved{Title: "Hello"}); err != nil {
+					return err
+				}
+				return errors.New("index unavailable")
+			})
+
+			require.NoError(t, f.Outbox.Publish(t.Context(), "article.saved", articleSaved{Title: "Hello"}))
+
+			_, err := f.OutboxRelay.RelayPending(t.Context())
+			require.NoError(t, err)
+			for _, event := range due(t, f, 24*time.Hour) {
+				assert.NotEqual(t, "article.indexed", event.Type)
+			}
+		})
+	})
+}
+
+func Test_OutboxRelayImpl_Database(t *testing.T) {
+	t.Parallel()
+
+	f := fixtures.SetupStandardFixture(t)
+
+	t.Run("should commit the writes of a delivery and roll back those of a failed one", func(t *testing.T) {
+		// the handlers write a follow up event, the failing one then fails
+		f.OutboxRelay.RegisterHandler("test.delivered", func(ctx context.Context, _ outbox_types.OutboxEvent) error {
+			return f.Outbox.Publish(ctx, "test.follow_up", map[string]string{"from": "delivered"})
+		})
+		f.OutboxRelay.RegisterHandler("test.failed", func(ctx context.Context, _ outbox_types.OutboxEvent) error {
+			if err := f.Outbox.Publish(ctx, "test.follow_up", map[string]string{"from": "failed"}); err != nil {
+				return err
+			}
+			return errors.New("handler failed")
+		})
+		followUps := &[]outbox_types.OutboxEvent{}
+		f.OutboxRelay.RegisterHandler("test.follow_up", func(_ context.Context, event outbox_types.OutboxEvent) error {
+			*followUps = append(*followUps, event)
+			return nil
+		})
+
+		require.NoError(t, f.Outbox.Publish(t.Context(), "test.delivered", map[string]string{}))
+		require.NoError(t, f.Outbox.Publish(t.Context(), "test.failed", map[string]string{}))
+
+		claimed, err := f.OutboxRelay.RelayPending(t.Context())
+		require.NoError(t, err)
+		assert.Equal(t, 2, claimed)
+
+		claimed, err = f.OutboxRelay.RelayPending(t.Context())
+		require.NoError(t, err)
+		assert.Equal(t, 1, claimed)
+		require.Len(t, *followUps, 1)
+		assert.JSONEq(t, `{"from": "delivered"}`, string((*followUps)[0].Payload))
+	})
+}
@@ -0,0 +1,26 @@
+package internal
+
+import (
+	"fmt"
+
+	db_types "github.com/nimaeskandary/go-realworld/pkg/database/types"
+	obs_types "github.com/nimaeskandary/go-realworld/pkg/observability/types"
+	outbox_types "github.com/nimaeskandary/go-realworld/pkg/outbox/types"
+)
+
+const outboxTableName = "outbox"
+
+// outboxEventColumns are the columns of postgresOutboxEvent
+var outboxEventColumns = []any{"id", "event_type", "payload", "attempts", "created_at"}
+
+// NewOutboxRepository returns the repository for the database's dialect
+func NewOutboxRepository(db db_types.RealWorldAppDb, logger obs_types.Logger) (outbox_types.OutboxRepository, error) {
+	switch db.GetDialect() {
+	case db_types.DialectPostgres:
+		return NewPostgresOutboxRepository(db, logger), nil
+	case db_types.DialectSqlite:
+		return NewSqliteOutboxRepository(db, logger), nil
+	default:
+		return nil, fmt.Errorf("no outbox repository for dialect: %v", db.GetDialect())
+	}
+}
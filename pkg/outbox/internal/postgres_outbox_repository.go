@@ -0,0 +1,143 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	db_types "github.com/nimaeskandary/go-realworld/pkg/database/types"
+	obs_types "github.com/nimaeskandary/go-realworld/pkg/observability/types"
+	outbox_types "github.com/nimaeskandary/go-realworld/pkg/outbox/types"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/samber/mo"
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/bob/dialect/psql"
+	"github.com/stephenafamo/bob/dialect/psql/dm"
+	"github.com/stephenafamo/bob/dialect/psql/im"
+	"github.com/stephenafamo/bob/dialect/psql/sm"
+	"github.com/stephenafamo/bob/dialect/psql/um"
+	"github.com/stephenafamo/scan"
+)
+
+type postgresOutboxRepo struct {
+	db     db_types.RealWorldAppDb
+	logger obs_types.Logger
+}
+
+func NewPostgresOutboxRepository(db db_types.RealWorldAppDb, logger obs_types.Logger) outbox_types.OutboxRepository {
+	return &postgresOutboxRepo{db: db, logger: logger}
+}
+
+// InsertEvent implements [outbox_types.OutboxRepository.InsertEvent]
+func (r *postgresOutboxRepo) InsertEvent(ctx context.Context, event outbox_types.OutboxEvent) error {
+	createdAt := time.UnixMilli(event.CreatedAtMillis)
+	q := psql.Insert(
+		im.Into(outboxTableName, "id", "event_type", "payload", "attempts", "next_attempt_at", "created_at"),
+		im.Values(psql.Arg(event.Id.String(), event.Type, []byte(event.Payload), event.Attempts, createdAt, createdAt)),
+	)
+
+	if _, err := bob.Exec(ctx, db_types.ExecutorFromCtx(ctx, r.db), q); err != nil {
+		return fmt.Errorf("error with insert outbox event query, id=%v, type=%v: %w", event.Id, event.Type, err)
+	}
+	return nil
+}
+
+// ClaimEvents implements [outbox_types.OutboxRepository.ClaimEvents] with FOR UPDATE SKIP LOCKED
+func (r *postgresOutboxRepo) ClaimEvents(ctx context.Context, limit int, now time.Time) ([]outbox_types.OutboxEvent, error) {
+	q := psql.Select(
+		sm.Columns(outboxEventColumns...),
+		sm.From(outboxTableName),
+		sm.Where(psql.Quote("next_attempt_at").LTE(psql.Arg(now))),
+		sm.OrderBy("created_at"),
+		sm.OrderBy("id"),
+		sm.Limit(limit),
+		sm.ForUpdate().SkipLocked(),
+	)
+
+	results, err := bob.All(ctx, db_types.ExecutorFromCtx(ctx, r.db), q, scan.StructMapper[postgresOutboxEvent]())
+	if err != nil {
+		return nil, fmt.Errorf("error with claim outbox events query, limit=%v: %w", limit, err)
+	}
+	return lo.Map(results, func(row postgresOutboxEvent, _ int) outbox_types.OutboxEvent { return row.toOutboxEvent() }), nil
+}
+
+// MarkDelivered implements [outbox_types.OutboxRepository.MarkDelivered]
+func (r *postgresOutboxRepo) MarkDelivered(ctx context.Context, id uuid.UUID, deliveredAt time.Time) error {
+	q := psql.Update(
+		um.Table(outboxTableName),
+		um.SetCol("delivered_at").ToArg(deliveredAt),
+		um.SetCol("next_attempt_at").To(psql.Raw("NULL")),
+		um.Where(psql.Quote("id").EQ(psql.Arg(id.String()))),
+	)
+	return execOutboxUpdate(ctx, r.db, q, "mark outbox event delivered", id)
+}
+
+// MarkFailed implements [outbox_types.OutboxRepository.MarkFailed]
+func (r *postgresOutboxRepo) MarkFailed(ctx context.Context, id uuid.UUID, lastError string, retryAt mo.Option[time.Time]) error {
+	q := psql.Update(
+		um.Table(outboxTableName),
+		um.SetCol("attempts").To(psql.Quote("attempts").Plus(psql.Arg(1))),
+		um.SetCol("last_error").ToArg(lastError),
+		um.SetCol("next_attempt_at").ToArg(retryAt.ToPointer()),
+		um.Where(psql.Quote("id").EQ(psql.Arg(id.String()))),
+	)
+	return execOutboxUpdate(ctx, r.db, q, "mark outbox event failed", id)
+}
+
+// PurgeDelivered implements [outbox_types.OutboxRepository.PurgeDelivered]
+func (r *postgresOutboxRepo) PurgeDelivered(ctx context.Context, deliveredBefore time.Time) (int64, error) {
+	q := psql.Delete(
+		dm.From(outboxTableName),
+		dm.Where(psql.Quote("delivered_at").LT(psql.Arg(deliveredBefore))),
+	)
+
+	result, err := bob.Exec(ctx, db_types.ExecutorFromCtx(ctx, r.db), q)
+	if err != nil {
+		return 0, fmt.Errorf("error with purge delivered outbox events query, delivered_before=%v: %w", deliveredBefore, err)
+	}
+
+	purged, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("error reading purged outbox events count: %w", err)
+	}
+
+	return purged, nil
+}
+
+// execOutboxUpdate runs an update of one event, it is an error if there is no event with the id
+func execOutboxUpdate(ctx context.Context, db db_types.RealWorldAppDb, q bob.Query, name string, id uuid.UUID) error {
+	result, err := bob.Exec(ctx, db_types.ExecutorFromCtx(ctx, db), q)
+	if err != nil {
+		return fmt.Errorf("error with %v query, id=%v: %w", name, id, err)
+	}
+
+	updated, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error reading %v count, id=%v: %w", name, id, err)
+	}
+	if updated == 0 {
+		return fmt.Errorf("outbox event not found, id=%v", id)
+	}
+
+	return nil
+}
+
+type postgresOutboxEvent struct {
+	Id        uuid.UUID `db:"id"`
+	EventType string    `db:"event_type"`
+	Payload   []byte    `db:"payload"`
+	Attempts  int       `db:"attempts"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+func (e postgresOutboxEvent) toOutboxEvent() outbox_types.OutboxEvent {
+	return outbox_types.OutboxEvent{
+		Id:              e.Id,
+		Type:            e.EventType,
+		Payload:         e.Payload,
+		Attempts:        e.Attempts,
+		CreatedAtMillis: e.CreatedAt.UnixMilli(),
+	}
+}
@@ -0,0 +1,113 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	db_types "github.com/nimaeskandary/go-realworld/pkg/database/types"
+	obs_types "github.com/nimaeskandary/go-realworld/pkg/observability/types"
+	outbox_types "github.com/nimaeskandary/go-realworld/pkg/outbox/types"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/samber/mo"
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/bob/dialect/sqlite"
+	"github.com/stephenafamo/bob/dialect/sqlite/dm"
+	"github.com/stephenafamo/bob/dialect/sqlite/im"
+	"github.com/stephenafamo/bob/dialect/sqlite/sm"
+	"github.com/stephenafamo/bob/dialect/sqlite/um"
+	"github.com/stephenafamo/scan"
+)
+
+// sqliteOutboxRepo is the sqlite counterpart of postgresOutboxRepo. Times are written in UTC, sqlite stores them as
+// text so they only compare correctly in the same offset
+type sqliteOutboxRepo struct {
+	db     db_types.RealWorldAppDb
+	logger obs_types.Logger
+}
+
+func NewSqliteOutboxRepository(db db_types.RealWorldAppDb, logger obs_types.Logger) outbox_types.OutboxRepository {
+	return &sqliteOutboxRepo{db: db, logger: logger}
+}
+
+// InsertEvent implements [outbox_types.OutboxRepository.InsertEvent]
+func (r *sqliteOutboxRepo) InsertEvent(ctx context.Context, event outbox_types.OutboxEvent) error {
+	createdAt := time.UnixMilli(event.CreatedAtMillis).UTC()
+	q := sqlite.Insert(
+		im.Into(outboxTableName, "id", "event_type", "payload", "attempts", "next_attempt_at", "created_at"),
+		im.Values(sqlite.Arg(event.Id.String(), event.Type, string(event.Payload), event.Attempts, createdAt, createdAt)),
+	)
+
+	if _, err := bob.Exec(ctx, db_types.ExecutorFromCtx(ctx, r.db), q); err != nil {
+		return fmt.Errorf("error with insert outbox event query, id=%v, type=%v: %w", event.Id, event.Type, err)
+	}
+	return nil
+}
+
+// ClaimEvents implements [outbox_types.OutboxRepository.ClaimEvents]. Sqlite has no row locks, a transaction that
+// writes holds the database's only write lock, so the claimed events are not claimed again until it ends
+func (r *sqliteOutboxRepo) ClaimEvents(ctx context.Context, limit int, now time.Time) ([]outbox_types.OutboxEvent, error) {
+	q := sqlite.Select(
+		sm.Columns(outboxEventColumns...),
+		sm.From(outboxTableName),
+		sm.Where(sqlite.Quote("next_attempt_at").LTE(sqlite.Arg(now.UTC()))),
+		sm.OrderBy("created_at"),
+		sm.OrderBy("id"),
+		sm.Limit(limit),
+	)
+
+	results, err := bob.All(ctx, db_types.ExecutorFromCtx(ctx, r.db), q, scan.StructMapper[postgresOutboxEvent]())
+	if err != nil {
+		return nil, fmt.Errorf("error with claim outbox events query, limit=%v: %w", limit, err)
+	}
+	return lo.Map(results, func(row postgresOutboxEvent, _ int) outbox_types.OutboxEvent { return row.toOutboxEvent() }), nil
+}
+
+// MarkDelivered implements [outbox_types.OutboxRepository.MarkDelivered]
+func (r *sqliteOutboxRepo) MarkDelivered(ctx context.Context, id uuid.UUID, deliveredAt time.Time) error {
+	q := sqlite.Update(
+		um.Table(outboxTableName),
+		um.SetCol("delivered_at").ToArg(deliveredAt.UTC()),
+		um.SetCol("next_attempt_at").To(sqlite.Raw("NULL")),
+		um.Where(sqlite.Quote("id").EQ(sqlite.Arg(id.String()))),
+	)
+	return execOutboxUpdate(ctx, r.db, q, "mark outbox event delivered", id)
+}
+
+// MarkFailed implements [outbox_types.OutboxRepository.MarkFailed]
+func (r *sqliteOutboxRepo) MarkFailed(ctx context.Context, id uuid.UUID, lastError string, retryAt mo.Option[time.Time]) error {
+	var nextAttemptAt *time.Time
+	if at, ok := retryAt.Get(); ok {
+		nextAttemptAt = lo.ToPtr(at.UTC())
+	}
+	q := sqlite.Update(
+		um.Table(outboxTableName),
+		um.SetCol("attempts").To(sqlite.Quote("attempts").Plus(sqlite.Arg(1))),
+		um.SetCol("last_error").ToArg(lastError),
+		um.SetCol("next_attempt_at").ToArg(nextAttemptAt),
+		um.Where(sqlite.Quote("id").EQ(sqlite.Arg(id.String()))),
+	)
+	return execOutboxUpdate(ctx, r.db, q, "mark outbox event failed", id)
+}
+
+// PurgeDelivered implements [outbox_types.OutboxRepository.PurgeDelivered]
+func (r *sqliteOutboxRepo) PurgeDelivered(ctx context.Context, deliveredBefore time.Time) (int64, error) {
+	q := sqlite.Delete(
+		dm.From(outboxTableName),
+		dm.Where(sqlite.Quote("delivered_at").LT(sqlite.Arg(deliveredBefore.UTC()))),
+	)
+
+	result, err := bob.Exec(ctx, db_types.ExecutorFromCtx(ctx, r.db), q)
+	if err != nil {
+		return 0, fmt.Errorf("error with purge delivered outbox events query, delivered_before=%v: %w", deliveredBefore, err)
+	}
+
+	purged, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("error reading purged outbox events count: %w", err)
+	}
+
+	return purged, nil
+}
@@ -0,0 +1,37 @@
+package outbox
+
+import (
+	"github.com/nimaeskandary/go-realworld/pkg/outbox/internal"
+	outbox_types "github.com/nimaeskandary/go-realworld/pkg/outbox/types"
+	"github.com/nimaeskandary/go-realworld/pkg/util"
+
+	"go.uber.org/fx"
+)
+
+// NewOutboxModule provides the Outbox that domain events are published to, along with its repository
+func NewOutboxModule() fx.Option {
+	return newOutboxModule(internal.NewOutboxRepository)
+}
+
+// NewInMemoryOutboxModule is NewOutboxModule with the repository kept in a RealWorldAppInMemoryDb
+func NewInMemoryOutboxModule() fx.Option {
+	return newOutboxModule(internal.NewInMemoryOutboxRepository)
+}
+
+func newOutboxModule(repositoryConstructor any) fx.Option {
+	return util.NewFxModule[outbox_types.Outbox](
+		"outbox",
+		internal.NewOutboxImpl,
+		fx.Provide(repositoryConstructor),
+	)
+}
+
+// NewOutboxRelayModule runs the background delivery of outbox events for the lifetime of the app. Handlers are
+// registered with an fx.Invoke taking the OutboxRelay, e.g.
+// fx.Invoke(func(relay outbox_types.OutboxRelay) { relay.RegisterHandler("article.saved", indexArticle) })
+func NewOutboxRelayModule() fx.Option {
+	return util.NewFxModuleWithLifecycle[outbox_types.OutboxRelay](
+		"outbox_relay",
+		internal.NewOutboxRelayImpl,
+	)
+}
@@ -0,0 +1,36 @@
+package outbox_types
+
+import "time"
+
+type OutboxConfig struct {
+	// PollIntervalMillis is how often the relay looks for due events
+	PollIntervalMillis int64 `json:"poll_interval_millis" validate:"required"`
+	// BatchSize is how many events one pass claims at most
+	BatchSize int `json:"batch_size" validate:"required"`
+	// MaxAttempts is how many failed deliveries an event gets before it is given up on
+	MaxAttempts int `json:"max_attempts" validate:"required"`
+	// RetryDelayMillis is the delay before the first retry, it doubles with each further attempt up to
+	// MaxRetryDelayMillis
+	RetryDelayMillis    int64 `json:"retry_delay_millis" validate:"required"`
+	MaxRetryDelayMillis int64 `json:"max_retry_delay_millis" validate:"required"`
+	// DeliveredRetentionSeconds is how long delivered events are kept before they are purged
+	DeliveredRetentionSeconds int64 `json:"delivered_retention_seconds" validate:"required"`
+}
+
+// RetryDelay returns how long to wait before retrying an event that has failed attempts times
+func (c OutboxConfig) RetryDelay(attempts int) time.Duration {
+	maxDelay := time.Duration(c.MaxRetryDelayMillis) * time.Millisecond
+	delay := time.Duration(c.RetryDelayMillis) * time.Millisecond
+	for range attempts - 1 {
+		if delay >= maxDelay {
+			break
+		}
+		delay *= 2
+	}
+	return min(delay, maxDelay)
+}
+
+// DeliveredCutoff returns the point in time before which delivered events are past retention
+func (c OutboxConfig) DeliveredCutoff(now time.Time) time.Time {
+	return now.Add(-time.Duration(c.DeliveredRetentionSeconds) * time.Second)
+}
@@ -0,0 +1,461 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package outbox_types_mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nimaeskandary/go-realworld/pkg/outbox/types"
+	"github.com/samber/mo"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockOutbox creates a new instance of MockOutbox. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockOutbox(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockOutbox {
+	mock := &MockOutbox{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockOutbox is an autogenerated mock type for the Outbox type
+type MockOutbox struct {
+	mock.Mock
+}
+
+type MockOutbox_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockOutbox) EXPECT() *MockOutbox_Expecter {
+	return &MockOutbox_Expecter{mock: &_m.Mock}
+}
+
+// Publish provides a mock function for the type MockOutbox
+func (_mock *MockOutbox) Publish(ctx context.Context, eventType string, payload any) error {
+	ret := _mock.Called(ctx, eventType, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Publish")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, any) error); ok {
+		r0 = returnFunc(ctx, eventType, payload)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockOutbox_Publish_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Publish'
+type MockOutbox_Publish_Call struct {
+	*mock.Call
+}
+
+// Publish is a helper method to define mock.On call
+//   - ctx context.Context
+//   - eventType string
+//   - payload any
+func (_e *MockOutbox_Expecter) Publish(ctx interface{}, eventType interface{}, payload interface{}) *MockOutbox_Publish_Call {
+	return &MockOutbox_Publish_Call{Call: _e.mock.On("Publish", ctx, eventType, payload)}
+}
+
+func (_c *MockOutbox_Publish_Call) Run(run func(ctx context.Context, eventType string, payload any)) *MockOutbox_Publish_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 any
+		if args[2] != nil {
+			arg2 = args[2].(any)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockOutbox_Publish_Call) Return(err error) *MockOutbox_Publish_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockOutbox_Publish_Call) RunAndReturn(run func(ctx context.Context, eventType string, payload any) error) *MockOutbox_Publish_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockOutboxRepository creates a new instance of MockOutboxRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockOutboxRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockOutboxRepository {
+	mock := &MockOutboxRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockOutboxRepository is an autogenerated mock type for the OutboxRepository type
+type MockOutboxRepository struct {
+	mock.Mock
+}
+
+type MockOutboxRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockOutboxRepository) EXPECT() *MockOutboxRepository_Expecter {
+	return &MockOutboxRepository_Expecter{mock: &_m.Mock}
+}
+
+// ClaimEvents provides a mock function for the type MockOutboxRepository
+func (_mock *MockOutboxRepository) ClaimEvents(ctx context.Context, limit int, now time.Time) ([]outbox_types.OutboxEvent, error) {
+	ret := _mock.Called(ctx, limit, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimEvents")
+	}
+
+	var r0 []outbox_types.OutboxEvent
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, time.Time) ([]outbox_types.OutboxEvent, error)); ok {
+		return returnFunc(ctx, limit, now)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, time.Time) []outbox_types.OutboxEvent); ok {
+		r0 = returnFunc(ctx, limit, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]outbox_types.OutboxEvent)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int, time.Time) error); ok {
+		r1 = returnFunc(ctx, limit, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockOutboxRepository_ClaimEvents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClaimEvents'
+type MockOutboxRepository_ClaimEvents_Call struct {
+	*mock.Call
+}
+
+// ClaimEvents is a helper method to define mock.On call
+//   - ctx context.Context
+//   - limit int
+//   - now time.Time
+func (_e *MockOutboxRepository_Expecter) ClaimEvents(ctx interface{}, limit interface{}, now interface{}) *MockOutboxRepository_ClaimEvents_Call {
+	return &MockOutboxRepository_ClaimEvents_Call{Call: _e.mock.On("ClaimEvents", ctx, limit, now)}
+}
+
+func (_c *MockOutboxRepository_ClaimEvents_Call) Run(run func(ctx context.Context, limit int, now time.Time)) *MockOutboxRepository_ClaimEvents_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockOutboxRepository_ClaimEvents_Call) Return(outboxEvents []outbox_types.OutboxEvent, err error) *MockOutboxRepository_ClaimEvents_Call {
+	_c.Call.Return(outboxEvents, err)
+	return _c
+}
+
+func (_c *MockOutboxRepository_ClaimEvents_Call) RunAndReturn(run func(ctx context.Context, limit int, now time.Time) ([]outbox_types.OutboxEvent, error)) *MockOutboxRepository_ClaimEvents_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InsertEvent provides a mock function for the type MockOutboxRepository
+func (_mock *MockOutboxRepository) InsertEvent(ctx context.Context, event outbox_types.OutboxEvent) error {
+	ret := _mock.Called(ctx, event)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertEvent")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, outbox_types.OutboxEvent) error); ok {
+		r0 = returnFunc(ctx, event)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockOutboxRepository_InsertEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InsertEvent'
+type MockOutboxRepository_InsertEvent_Call struct {
+	*mock.Call
+}
+
+// InsertEvent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - event outbox_types.OutboxEvent
+func (_e *MockOutboxRepository_Expecter) InsertEvent(ctx interface{}, event interface{}) *MockOutboxRepository_InsertEvent_Call {
+	return &MockOutboxRepository_InsertEvent_Call{Call: _e.mock.On("InsertEvent", ctx, event)}
+}
+
+func (_c *MockOutboxRepository_InsertEvent_Call) Run(run func(ctx context.Context, event outbox_types.OutboxEvent)) *MockOutboxRepository_InsertEvent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 outbox_types.OutboxEvent
+		if args[1] != nil {
+			arg1 = args[1].(outbox_types.OutboxEvent)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockOutboxRepository_InsertEvent_Call) Return(err error) *MockOutboxRepository_InsertEvent_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockOutboxRepository_InsertEvent_Call) RunAndReturn(run func(ctx context.Context, event outbox_types.OutboxEvent) error) *MockOutboxRepository_InsertEvent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkDelivered provides a mock function for the type MockOutboxRepository
+func (_mock *MockOutboxRepository) MarkDelivered(ctx context.Context, id uuid.UUID, deliveredAt time.Time) error {
+	ret := _mock.Called(ctx, id, deliveredAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkDelivered")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time) error); ok {
+		r0 = returnFunc(ctx, id, deliveredAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockOutboxRepository_MarkDelivered_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkDelivered'
+type MockOutboxRepository_MarkDelivered_Call struct {
+	*mock.Call
+}
+
+// MarkDelivered is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+//   - deliveredAt time.Time
+func (_e *MockOutboxRepository_Expecter) MarkDelivered(ctx interface{}, id interface{}, deliveredAt interface{}) *MockOutboxRepository_MarkDelivered_Call {
+	return &MockOutboxRepository_MarkDelivered_Call{Call: _e.mock.On("MarkDelivered", ctx, id, deliveredAt)}
+}
+
+func (_c *MockOutboxRepository_MarkDelivered_Call) Run(run func(ctx context.Context, id uuid.UUID, deliveredAt time.Time)) *MockOutboxRepository_MarkDelivered_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockOutboxRepository_MarkDelivered_Call) Return(err error) *MockOutboxRepository_MarkDelivered_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockOutboxRepository_MarkDelivered_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID, deliveredAt time.Time) error) *MockOutboxRepository_MarkDelivered_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkFailed provides a mock function for the type MockOutboxRepository
+func (_mock *MockOutboxRepository) MarkFailed(ctx context.Context, id uuid.UUID, lastError string, retryAt mo.Option[time.Time]) error {
+	ret := _mock.Called(ctx, id, lastError, retryAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkFailed")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, mo.Option[time.Time]) error); ok {
+		r0 = returnFunc(ctx, id, lastError, retryAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockOutboxRepository_MarkFailed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkFailed'
+type MockOutboxRepository_MarkFailed_Call struct {
+	*mock.Call
+}
+
+// MarkFailed is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+//   - lastError string
+//   - retryAt mo.Option[time.Time]
+func (_e *MockOutboxRepository_Expecter) MarkFailed(ctx interface{}, id interface{}, lastError interface{}, retryAt interface{}) *MockOutboxRepository_MarkFailed_Call {
+	return &MockOutboxRepository_MarkFailed_Call{Call: _e.mock.On("MarkFailed", ctx, id, lastError, retryAt)}
+}
+
+func (_c *MockOutboxRepository_MarkFailed_Call) Run(run func(ctx context.Context, id uuid.UUID, lastError string, retryAt mo.Option[time.Time])) *MockOutboxRepository_MarkFailed_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 mo.Option[time.Time]
+		if args[3] != nil {
+			arg3 = args[3].(mo.Option[time.Time])
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockOutboxRepository_MarkFailed_Call) Return(err error) *MockOutboxRepository_MarkFailed_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockOutboxRepository_MarkFailed_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID, lastError string, retryAt mo.Option[time.Time]) error) *MockOutboxRepository_MarkFailed_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PurgeDelivered provides a mock function for the type MockOutboxRepository
+func (_mock *MockOutboxRepository) PurgeDelivered(ctx context.Context, deliveredBefore time.Time) (int64, error) {
+	ret := _mock.Called(ctx, deliveredBefore)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeDelivered")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) (int64, error)); ok {
+		return returnFunc(ctx, deliveredBefore)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) int64); ok {
+		r0 = returnFunc(ctx, deliveredBefore)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = returnFunc(ctx, deliveredBefore)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockOutboxRepository_PurgeDelivered_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PurgeDelivered'
+type MockOutboxRepository_PurgeDelivered_Call struct {
+	*mock.Call
+}
+
+// PurgeDelivered is a helper method to define mock.On call
+//   - ctx context.Context
+//   - deliveredBefore time.Time
+func (_e *MockOutboxRepository_Expecter) PurgeDelivered(ctx interface{}, deliveredBefore interface{}) *MockOutboxRepository_PurgeDelivered_Call {
+	return &MockOutboxRepository_PurgeDelivered_Call{Call: _e.mock.On("PurgeDelivered", ctx, deliveredBefore)}
+}
+
+func (_c *MockOutboxRepository_PurgeDelivered_Call) Run(run func(ctx context.Context, deliveredBefore time.Time)) *MockOutboxRepository_PurgeDelivered_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 time.Time
+		if args[1] != nil {
+			arg1 = args[1].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockOutboxRepository_PurgeDelivered_Call) Return(n int64, err error) *MockOutboxRepository_PurgeDelivered_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockOutboxRepository_PurgeDelivered_Call) RunAndReturn(run func(ctx context.Context, deliveredBefore time.Time) (int64, error)) *MockOutboxRepository_PurgeDelivered_Call {
+	_c.Call.Return(run)
+	return _c
+}
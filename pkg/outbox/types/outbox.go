@@ -0,0 +1,32 @@
+package outbox_types
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/google/uuid"
+)
+
+// OutboxEvent is a domain event, e.g. an article being saved, written to the outbox in the transaction of the change
+// it describes. The relay delivers it to the handlers of its type after that transaction commits
+type OutboxEvent struct {
+	Id uuid.UUID
+	// Type names the event, e.g. article.saved, and picks the handlers it is delivered to
+	Type    string
+	Payload json.RawMessage
+	// Attempts counts the failed deliveries so far
+	Attempts        int
+	CreatedAtMillis int64
+}
+
+//mockery:generate: true
+type Outbox interface {
+	// Publish writes an event of the type, with the payload marshalled to json. It is written with the transaction in
+	// ctx, so call it in the TxManager.WithinTx that makes the change it describes, and the event is recorded if and
+	// only if the change is
+	Publish(ctx context.Context, eventType string, payload any) error
+}
+
+// OutboxHandler acts on a delivered event. Delivery is at least once, an event is retried until every handler of its
+// type succeeds in the same attempt, so a handler must be safe to run again for an event it has already handled
+type OutboxHandler func(ctx context.Context, event OutboxEvent) error
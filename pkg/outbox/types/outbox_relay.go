@@ -0,0 +1,19 @@
+package outbox_types
+
+import (
+	"context"
+
+	"github.com/nimaeskandary/go-realworld/pkg/util"
+)
+
+// OutboxRelay periodically delivers the events in the outbox to the handlers registered for their types, retrying
+// failed deliveries with a growing delay until the attempts run out
+type OutboxRelay interface {
+	util.FxLifecycle
+	// RegisterHandler adds a handler for the event type, usually from an fx.Invoke so it is in place before the relay
+	// starts. Relaying an event of a type with no handlers is a failed delivery, so it is retried like one
+	RegisterHandler(eventType string, handler OutboxHandler)
+	// RelayPending runs a single pass over up to a batch of due events, returning how many it claimed. A full batch
+	// means more may be waiting
+	RelayPending(ctx context.Context) (int, error)
+}
@@ -0,0 +1,25 @@
+package outbox_types
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/mo"
+)
+
+//mockery:generate: true
+type OutboxRepository interface {
+	// InsertEvent writes the event, due for delivery straight away
+	InsertEvent(ctx context.Context, event OutboxEvent) error
+	// ClaimEvents returns up to limit events that are due at now, oldest first, locking them until the transaction in
+	// ctx ends. Events locked by another transaction are skipped rather than waited for, so relays running in several
+	// instances share the outbox. It must be called in a transaction
+	ClaimEvents(ctx context.Context, limit int, now time.Time) ([]OutboxEvent, error)
+	MarkDelivered(ctx context.Context, id uuid.UUID, deliveredAt time.Time) error
+	// MarkFailed records a failed delivery, the event is due again at retryAt, or never again if it is not set
+	MarkFailed(ctx context.Context, id uuid.UUID, lastError string, retryAt mo.Option[time.Time]) error
+	// PurgeDelivered deletes the events delivered before deliveredBefore, returning how many were deleted. Events that
+	// ran out of attempts are kept for inspection
+	PurgeDelivered(ctx context.Context, deliveredBefore time.Time) (int64, error)
+}
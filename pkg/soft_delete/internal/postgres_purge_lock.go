@@ -0,0 +1,46 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+
+	db_types "github.com/nimaeskandary/go-realworld/pkg/database/types"
+	soft_delete_types "github.com/nimaeskandary/go-realworld/pkg/soft_delete/types"
+)
+
+// purgeLockKey identifies the postgres advisory lock held during a purge pass, the value is arbitrary but must not be
+// used for any other lock
+const purgeLockKey int64 = 7_351_904_826_113_487_552
+
+type postgresPurgeLock struct {
+	db db_types.RealWorldAppDb
+}
+
+func NewPostgresPurgeLock(db db_types.RealWorldAppDb) soft_delete_types.PurgeLock {
+	return &postgresPurgeLock{db: db}
+}
+
+func (l *postgresPurgeLock) TryLock(ctx context.Context) (func(), bool, error) {
+	// an advisory lock belongs to a session, so it is taken and released on one connection held for the duration
+	conn, err := l.db.GetDB().Conn(ctx)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get a connection for the purge lock: %w", err)
+	}
+
+	var acquired bool
+	if err := conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", purgeLockKey).Scan(&acquired); err != nil {
+		_ = conn.Close()
+		return nil, false, fmt.Errorf("failed to try the purge lock: %w", err)
+	}
+	if !acquired {
+		_ = conn.Close()
+		return nil, false, nil
+	}
+
+	return func() {
+		// the lock is released even if ctx is done, closing the connection would release it too but it goes back to
+		// the pool instead
+		_, _ = conn.ExecContext(context.WithoutCancel(ctx), "SELECT pg_advisory_unlock($1)", purgeLockKey)
+		_ = conn.Close()
+	}, true, nil
+}
@@ -0,0 +1,38 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	db_types "github.com/nimaeskandary/go-realworld/pkg/database/types"
+	soft_delete_types "github.com/nimaeskandary/go-realworld/pkg/soft_delete/types"
+)
+
+// NewPurgeLock returns the purge lock for the database's dialect
+func NewPurgeLock(db db_types.RealWorldAppDb) (soft_delete_types.PurgeLock, error) {
+	switch db.GetDialect() {
+	case db_types.DialectPostgres:
+		return NewPostgresPurgeLock(db), nil
+	case db_types.DialectSqlite:
+		return NewLocalPurgeLock(), nil
+	default:
+		return nil, fmt.Errorf("no purge lock for dialect: %v", db.GetDialect())
+	}
+}
+
+// localPurgeLock only excludes purges within this process, for a database only one instance of the app uses, e.g. a sqlite file
+type localPurgeLock struct {
+	mu sync.Mutex
+}
+
+func NewLocalPurgeLock() soft_delete_types.PurgeLock {
+	return &localPurgeLock{}
+}
+
+func (l *localPurgeLock) TryLock(_ context.Context) (func(), bool, error) {
+	if !l.mu.TryLock() {
+		return nil, false, nil
+	}
+	return l.mu.Unlock, true, nil
+}
@@ -0,0 +1,104 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	article_types "github.com/nimaeskandary/go-realworld/pkg/article/types"
+	obs_types "github.com/nimaeskandary/go-realworld/pkg/observability/types"
+	soft_delete_types "github.com/nimaeskandary/go-realworld/pkg/soft_delete/types"
+	user_types "github.com/nimaeskandary/go-realworld/pkg/user/types"
+)
+
+type softDeletePurgerImpl struct {
+	cfg         soft_delete_types.SoftDeleteConfig
+	userRepo    user_types.UserRepository
+	articleRepo article_types.ArticleRepository
+	lock        soft_delete_types.PurgeLock
+	logger      obs_types.Logger
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewSoftDeletePurgerImpl(
+	cfg soft_delete_types.SoftDeleteConfig,
+	userRepo user_types.UserRepository,
+	articleRepo article_types.ArticleRepository,
+	lock soft_delete_types.PurgeLock,
+	logger obs_types.Logger,
+) soft_delete_types.SoftDeletePurger {
+	return &softDeletePurgerImpl{
+		cfg:         cfg,
+		userRepo:    userRepo,
+		articleRepo: articleRepo,
+		lock:        lock,
+		logger:      logger,
+	}
+}
+
+func (p *softDeletePurgerImpl) Start(_ context.Context) error {
+	// the start context is only valid for the duration of the fx start hook
+	ctx, cancel := context.WithCancel(context.Background())
+	p.cancel = cancel
+
+	p.wg.Go(func() {
+		ticker := time.NewTicker(time.Duration(p.cfg.PurgeIntervalSeconds) * time.Second)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := p.PurgeExpired(ctx); err != nil {
+					p.logger.Error(ctx, "soft delete purge failed", err)
+				}
+			}
+		}
+	})
+
+	return nil
+}
+
+func (p *softDeletePurgerImpl) Stop(_ context.Context) error {
+	if p.cancel != nil {
+		p.cancel()
+		p.wg.Wait()
+		p.cancel = nil
+	}
+	return nil
+}
+
+func (p *softDeletePurgerImpl) PurgeExpired(ctx context.Context) error {
+	unlock, acquired, err := p.lock.TryLock(ctx)
+	if err != nil {
+		return err
+	}
+	if !acquired {
+		// another instance is purging, this one tries again at its next interval
+		return nil
+	}
+	defer unlock()
+
+	cutoff := p.cfg.GracePeriodCutoff(time.Now())
+
+	// articles are purged first, purging a user also cascades into their articles
+	purgedArticles, err := p.articleRepo.PurgeDeletedArticles(ctx, cutoff)
+	if err != nil {
+		return fmt.Errorf("error purging deleted articles: %w", err)
+	}
+
+	purgedUsers, err := p.userRepo.PurgeDeletedUsers(ctx, cutoff)
+	if err != nil {
+		return fmt.Errorf("error purging deleted users: %w", err)
+	}
+
+	if purgedArticles > 0 || purgedUsers > 0 {
+		p.logger.Info(ctx, "purged soft deleted rows", "articles", purgedArticles, "users", purgedUsers)
+	}
+
+	return nil
+}
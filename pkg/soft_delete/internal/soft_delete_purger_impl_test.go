@@ -0,0 +1,74 @@
+package internal_test
+
+import (
+	"testing"
+	"time"
+
+	soft_delete_types "github.com/nimaeskandary/go-realworld/pkg/soft_delete/types"
+	"github.com/nimaeskandary/go-realworld/pkg/test_utils/fixtures"
+	"github.com/nimaeskandary/go-realworld/pkg/test_utils/helpers"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_SoftDeletePurgerImpl(t *testing.T) {
+	t.Parallel()
+
+	var lock soft_delete_types.PurgeLock
+	f := fixtures.SetupStandardFixture(t,
+		func(cfg soft_delete_types.SoftDeleteConfig) soft_delete_types.SoftDeleteConfig {
+			// everything soft deleted is immediately past the grace period
+			cfg.GracePeriodSeconds = 0
+			return cfg
+		},
+		func(purgeLock soft_delete_types.PurgeLock) soft_delete_types.PurgeLock {
+			lock = purgeLock
+			return purgeLock
+		},
+	)
+
+	t.Run("PurgeExpired", func(t *testing.T) {
+		t.Parallel()
+
+		t.Run("should purge deleted users and articles", func(t *testing.T) {
+			t.Parallel()
+			users := helpers.CreateUsers(t, f.UserService, 2)
+			deletedArticle, err := f.ArticleRepo.UpsertArticle(t.Context(), helpers.GenArticle(users[1].Id))
+			require.NoError(t, err)
+			activeArticle, err := f.ArticleRepo.UpsertArticle(t.Context(), helpers.GenArticle(users[1].Id))
+			require.NoError(t, err)
+
+			require.NoError(t, f.UserService.DeleteUser(t.Context(), users[0].Id))
+			require.NoError(t, f.ArticleRepo.DeleteArticle(t.Context(), deletedArticle.Id))
+
+			err = f.SoftDeletePurger.PurgeExpired(t.Context())
+			assert.NoError(t, err)
+
+			_, restoreErr := f.UserService.RestoreUser(t.Context(), users[0].Id)
+			assert.Error(t, restoreErr)
+
+			fromDb, err := f.ArticleRepo.GetArticleById(t.Context(), activeArticle.Id)
+			assert.NoError(t, err)
+			assert.True(t, fromDb.IsSome())
+		})
+
+		// not parallel, it holds the lock the other purges need, and runs before them
+		t.Run("should not purge while another process holds the lock", func(t *testing.T) {
+			user := helpers.CreateUsers(t, f.UserService, 1)[0]
+			require.NoError(t, f.UserService.DeleteUser(t.Context(), user.Id))
+
+			unlock, acquired, err := lock.TryLock(t.Context())
+			require.NoError(t, err)
+			require.True(t, acquired)
+
+			err = f.SoftDeletePurger.PurgeExpired(t.Context())
+			assert.NoError(t, err)
+			unlock()
+
+			deleted, err := f.UserRepo.GetDeletedUserById(t.Context(), user.Id, time.Time{})
+			assert.NoError(t, err)
+			assert.True(t, deleted.IsSome())
+		})
+	})
+}
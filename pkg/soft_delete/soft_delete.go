@@ -0,0 +1,29 @@
+package soft_delete
+
+import (
+	"github.com/nimaeskandary/go-realworld/pkg/soft_delete/internal"
+	soft_delete_types "github.com/nimaeskandary/go-realworld/pkg/soft_delete/types"
+	"github.com/nimaeskandary/go-realworld/pkg/util"
+
+	"go.uber.org/fx"
+)
+
+// NewSoftDeletePurgerModule runs the background purge of soft deleted rows for the lifetime of the app. Each instance of
+// the app runs a purger, an advisory lock lets only one purge at a time
+func NewSoftDeletePurgerModule() fx.Option {
+	return newSoftDeletePurgerModule(internal.NewPurgeLock)
+}
+
+// NewInMemorySoftDeletePurgerModule is NewSoftDeletePurgerModule for a RealWorldAppInMemoryDb, which only this process
+// can use, so purges are only excluded within it
+func NewInMemorySoftDeletePurgerModule() fx.Option {
+	return newSoftDeletePurgerModule(internal.NewLocalPurgeLock)
+}
+
+func newSoftDeletePurgerModule(purgeLockConstructor any) fx.Option {
+	return util.NewFxModuleWithLifecycle[soft_delete_types.SoftDeletePurger](
+		"soft_delete_purger",
+		internal.NewSoftDeletePurgerImpl,
+		fx.Provide(purgeLockConstructor),
+	)
+}
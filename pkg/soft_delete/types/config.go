@@ -0,0 +1,15 @@
+package soft_delete_types
+
+import "time"
+
+type SoftDeleteConfig struct {
+	// GracePeriodSeconds is how long a soft deleted row can be restored before it is eligible for purging
+	GracePeriodSeconds int64 `json:"grace_period_seconds" validate:"required"`
+	// PurgeIntervalSeconds is how often the background purge runs
+	PurgeIntervalSeconds int64 `json:"purge_interval_seconds" validate:"required"`
+}
+
+// GracePeriodCutoff returns the point in time before which soft deleted rows are past the grace period
+func (c SoftDeleteConfig) GracePeriodCutoff(now time.Time) time.Time {
+	return now.Add(-time.Duration(c.GracePeriodSeconds) * time.Second)
+}
@@ -0,0 +1,21 @@
+package soft_delete_types
+
+import (
+	"context"
+
+	"github.com/nimaeskandary/go-realworld/pkg/util"
+)
+
+// SoftDeletePurger periodically hard deletes rows that have been soft deleted for longer than the grace period
+type SoftDeletePurger interface {
+	util.FxLifecycle
+	// PurgeExpired runs a single purge pass, or does nothing if another process is running one
+	PurgeExpired(ctx context.Context) error
+}
+
+// PurgeLock is held for a purge pass, so every instance of the app can run a purger while only one at a time purges
+type PurgeLock interface {
+	// TryLock takes the lock if it is free, returning false without waiting if another process holds it. If the lock
+	// is taken, the returned unlock must be called to release it
+	TryLock(ctx context.Context) (unlock func(), acquired bool, err error)
+}
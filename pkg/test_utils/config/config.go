@@ -1,13 +1,26 @@
 package config
 
 import (
+	"os"
+	"path/filepath"
+
 	auth_types "github.com/nimaeskandary/go-realworld/pkg/auth/types"
+	blob_store_types "github.com/nimaeskandary/go-realworld/pkg/blob_store/types"
+	media_types "github.com/nimaeskandary/go-realworld/pkg/media/types"
 	obs_types "github.com/nimaeskandary/go-realworld/pkg/observability/types"
+	outbox_types "github.com/nimaeskandary/go-realworld/pkg/outbox/types"
+	soft_delete_types "github.com/nimaeskandary/go-realworld/pkg/soft_delete/types"
+	user_types "github.com/nimaeskandary/go-realworld/pkg/user/types"
 )
 
 type Config struct {
 	Slog           obs_types.SlogLoggerConfig
 	JwtAuthService auth_types.JwtAuthServiceConfig
+	SoftDelete     soft_delete_types.SoftDeleteConfig
+	Outbox         outbox_types.OutboxConfig
+	User           user_types.UserConfig
+	LocalBlobStore blob_store_types.LocalBlobStoreConfig
+	Media          media_types.MediaConfig
 }
 
 func NewTestConfig() Config {
@@ -20,5 +33,32 @@ func NewTestConfig() Config {
 			SecretBase64:         "1TjsQI3mv84OxUhS55owxZwLDXKMGj2PVUUQIr+E604=",
 			TokenDurationSeconds: 3600,
 		},
+		SoftDelete: soft_delete_types.SoftDeleteConfig{
+			GracePeriodSeconds:   3600,
+			PurgeIntervalSeconds: 3600,
+		},
+		Outbox: outbox_types.OutboxConfig{
+			// tests run passes with RelayPending rather than waiting on the poll
+			PollIntervalMillis:        3_600_000,
+			BatchSize:                 10,
+			MaxAttempts:               3,
+			RetryDelayMillis:          1000,
+			MaxRetryDelayMillis:       60_000,
+			DeliveredRetentionSeconds: 3600,
+		},
+		User: user_types.UserConfig{
+			UsernameReleaseCooldownSeconds: 3600,
+		},
+		LocalBlobStore: blob_store_types.LocalBlobStoreConfig{
+			// blob keys are random, so tests can share a directory
+			RootDir: filepath.Join(os.TempDir(), "go-realworld-test-blobs"),
+		},
+		Media: media_types.MediaConfig{
+			PublicBaseUrl:            "http://localhost:8080",
+			MaxUploadBytes:           1 << 20,
+			MaxSourcePixels:          4_000_000,
+			AvatarMaxDimension:       64,
+			ArticleImageMaxDimension: 256,
+		},
 	}
 }
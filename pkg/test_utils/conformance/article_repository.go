@@ -0,0 +1,453 @@
+package conformance
+
+import (
+	"testing"
+	"time"
+
+	article_types "github.com/nimaeskandary/go-realworld/pkg/article/types"
+	"github.com/nimaeskandary/go-realworld/pkg/test_utils/helpers"
+	user_types "github.com/nimaeskandary/go-realworld/pkg/user/types"
+
+	"github.com/google/uuid"
+	"github.com/samber/mo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ArticleRepositorySuite checks the behaviour every ArticleRepository implementation must share. userRepo must share
+// the same storage, articles reference their author
+func ArticleRepositorySuite(t *testing.T, userRepo user_types.UserRepository, underTest article_types.ArticleRepository) {
+	t.Run("UpsertArticle", func(t *testing.T) {
+		t.Parallel()
+		user := helpers.UpsertUsers(t, userRepo, 1)[0]
+
+		t.Run("should insert a new article", func(t *testing.T) {
+			t.Parallel()
+
+			article := helpers.GenArticle(user.Id)
+			created, err := underTest.UpsertArticle(t.Context(), article)
+			assert.NoError(t, err)
+			assert.Equal(t, article, created)
+		})
+
+		t.Run("should update an existing article", func(t *testing.T) {
+			t.Parallel()
+			article := helpers.GenArticle(user.Id)
+			_, err := underTest.UpsertArticle(t.Context(), article)
+			assert.NoError(t, err)
+
+			updatedArticle := article
+			updatedArticle.Title = "Updated Title"
+			updatedArticle.Description = "Updated Description"
+			// this should not update
+			updatedArticle.CreatedAtMillis = time.Now().UnixMilli()
+
+			expectedUpdatedArticle := updatedArticle
+			expectedUpdatedArticle.CreatedAtMillis = article.CreatedAtMillis
+			expectedUpdatedArticle.Version = article.Version + 1
+
+			actual, err := underTest.UpsertArticle(t.Context(), updatedArticle)
+			assert.NoError(t, err)
+			assert.Equal(t, expectedUpdatedArticle, actual)
+		})
+
+		t.Run("should return VersionConflictError when updating a stale version", func(t *testing.T) {
+			t.Parallel()
+			article := helpers.GenArticle(user.Id)
+			_, err := underTest.UpsertArticle(t.Context(), article)
+			require.NoError(t, err)
+
+			first := article
+			first.Title = "First Title"
+			_, err = underTest.UpsertArticle(t.Context(), first)
+			require.NoError(t, err)
+
+			stale := article
+			stale.Title = "Stale Title"
+			_, err = underTest.UpsertArticle(t.Context(), stale)
+			assert.IsType(t, article_types.VersionConflictError{}, err)
+
+			fromDb, err := underTest.GetArticleById(t.Context(), article.Id)
+			require.NoError(t, err)
+			assert.Equal(t, "First Title", fromDb.MustGet().Title)
+		})
+	})
+
+	t.Run("GetArticleById", func(t *testing.T) {
+		t.Parallel()
+		user := helpers.UpsertUsers(t, userRepo, 1)[0]
+
+		t.Run("should get article by id", func(t *testing.T) {
+			t.Parallel()
+
+			article := helpers.GenArticle(user.Id)
+			created, err := underTest.UpsertArticle(t.Context(), article)
+			assert.NoError(t, err)
+
+			fromDb, err := underTest.GetArticleById(t.Context(), article.Id)
+			assert.NoError(t, err)
+			assert.True(t, fromDb.IsSome())
+			assert.Equal(t, created, fromDb.MustGet())
+		})
+
+		t.Run("should return none for non-existent id", func(t *testing.T) {
+			t.Parallel()
+
+			fromDb, err := underTest.GetArticleById(t.Context(), uuid.New())
+			assert.NoError(t, err)
+			assert.True(t, fromDb.IsNone())
+		})
+	})
+
+	t.Run("ListArticleFeed", func(t *testing.T) {
+		t.Parallel()
+
+		t.Run("should list articles by followed authors newest first, leaving out excluded authors", func(t *testing.T) {
+			t.Parallel()
+			users := helpers.UpsertUsers(t, userRepo, 4)
+			viewer, followed, excluded, notFollowed := users[0], users[1], users[2], users[3]
+			require.NoError(t, userRepo.Follow(t.Context(), viewer.Id, followed.Id))
+			require.NoError(t, userRepo.Follow(t.Context(), viewer.Id, excluded.Id))
+
+			older := helpers.GenArticle(followed.Id)
+			older.CreatedAtMillis -= 1000
+			newer := helpers.GenArticle(followed.Id)
+			for _, article := range []article_types.Article{
+				older, newer, helpers.GenArticle(excluded.Id), helpers.GenArticle(notFollowed.Id),
+			} {
+				_, err := underTest.UpsertArticle(t.Context(), article)
+				require.NoError(t, err)
+			}
+
+			feed, err := underTest.ListArticleFeed(t.Context(), viewer.Id, 10, 0, []uuid.UUID{excluded.Id})
+			assert.NoError(t, err)
+			assert.Equal(t, []article_types.Article{newer, older}, feed)
+
+			feed, err = underTest.ListArticleFeed(t.Context(), viewer.Id, 1, 1, []uuid.UUID{excluded.Id})
+			assert.NoError(t, err)
+			assert.Equal(t, []article_types.Article{older}, feed)
+		})
+
+		t.Run("should return an empty feed when following no one", func(t *testing.T) {
+			t.Parallel()
+			viewer := helpers.UpsertUsers(t, userRepo, 1)[0]
+
+			feed, err := underTest.ListArticleFeed(t.Context(), viewer.Id, 10, 0, nil)
+			assert.NoError(t, err)
+			assert.Empty(t, feed)
+		})
+
+		t.Run("should leave out soft deleted articles", func(t *testing.T) {
+			t.Parallel()
+			users := helpers.UpsertUsers(t, userRepo, 2)
+			require.NoError(t, userRepo.Follow(t.Context(), users[0].Id, users[1].Id))
+			article := helpers.GenArticle(users[1].Id)
+			_, err := underTest.UpsertArticle(t.Context(), article)
+			require.NoError(t, err)
+			require.NoError(t, underTest.DeleteArticle(t.Context(), article.Id))
+
+			feed, err := underTest.ListArticleFeed(t.Context(), users[0].Id, 10, 0, nil)
+			assert.NoError(t, err)
+			assert.Empty(t, feed)
+		})
+	})
+
+	t.Run("ListArticles", func(t *testing.T) {
+		t.Parallel()
+
+		t.Run("should return an error rather than panic", func(t *testing.T) {
+			t.Parallel()
+
+			_, err := underTest.ListArticles(t.Context(), 10, 0, mo.None[uuid.UUID](), mo.None[uuid.UUID](), mo.None[string]())
+			assert.Error(t, err)
+		})
+	})
+
+	t.Run("AddArticleTags", func(t *testing.T) {
+		t.Parallel()
+		user := helpers.UpsertUsers(t, userRepo, 1)[0]
+
+		t.Run("should add tags, leaving the tags the article already has", func(t *testing.T) {
+			t.Parallel()
+			word := helpers.GenSearchWord()
+			article := helpers.GenArticle(user.Id)
+			article.Title = word
+			_, err := underTest.UpsertArticle(t.Context(), article)
+			require.NoError(t, err)
+
+			require.NoError(t, underTest.AddArticleTags(t.Context(), article.Id, []string{"go", "sql"}))
+			require.NoError(t, underTest.AddArticleTags(t.Context(), article.Id, []string{"sql", "testing"}))
+			require.NoError(t, underTest.AddArticleTags(t.Context(), article.Id, nil))
+
+			results, err := underTest.SearchArticles(t.Context(), article_types.ArticleSearchQuery{Text: word, Limit: 10})
+			require.NoError(t, err)
+			require.Len(t, results, 1)
+			assert.Equal(t, []string{"go", "sql", "testing"}, results[0].Tags)
+		})
+
+		t.Run("should fail to tag an article that does not exist", func(t *testing.T) {
+			t.Parallel()
+
+			assert.Error(t, underTest.AddArticleTags(t.Context(), uuid.New(), []string{"go"}))
+		})
+	})
+
+	t.Run("FavoriteArticle", func(t *testing.T) {
+		t.Parallel()
+		users := helpers.UpsertUsers(t, userRepo, 2)
+		article, err := underTest.UpsertArticle(t.Context(), helpers.GenArticle(users[0].Id))
+		require.NoError(t, err)
+
+		t.Run("should be idempotent if already favorited", func(t *testing.T) {
+			t.Parallel()
+
+			assert.NoError(t, underTest.FavoriteArticle(t.Context(), users[1].Id, article.Id))
+			assert.NoError(t, underTest.FavoriteArticle(t.Context(), users[1].Id, article.Id))
+		})
+
+		t.Run("should fail to favorite an article or as a user that does not exist", func(t *testing.T) {
+			t.Parallel()
+
+			assert.Error(t, underTest.FavoriteArticle(t.Context(), users[1].Id, uuid.New()))
+			assert.Error(t, underTest.FavoriteArticle(t.Context(), uuid.New(), article.Id))
+		})
+	})
+
+	t.Run("DeleteArticle", func(t *testing.T) {
+		t.Parallel()
+		user := helpers.UpsertUsers(t, userRepo, 1)[0]
+		article := helpers.GenArticle(user.Id)
+		_, err := underTest.UpsertArticle(t.Context(), article)
+		require.NoError(t, err)
+
+		t.Run("should delete existing article", func(t *testing.T) {
+			t.Parallel()
+
+			err := underTest.DeleteArticle(t.Context(), article.Id)
+			assert.NoError(t, err)
+
+			fromDb, err := underTest.GetArticleById(t.Context(), article.Id)
+			assert.NoError(t, err)
+			assert.True(t, fromDb.IsNone())
+		})
+
+		t.Run("should return no error when deleting non-existing article", func(t *testing.T) {
+			t.Parallel()
+
+			err := underTest.DeleteArticle(t.Context(), uuid.New())
+			assert.NoError(t, err)
+		})
+	})
+
+	t.Run("RestoreArticle", func(t *testing.T) {
+		t.Parallel()
+		user := helpers.UpsertUsers(t, userRepo, 1)[0]
+
+		t.Run("should restore an article deleted after the cutoff", func(t *testing.T) {
+			t.Parallel()
+			article, err := underTest.UpsertArticle(t.Context(), helpers.GenArticle(user.Id))
+			require.NoError(t, err)
+			require.NoError(t, underTest.DeleteArticle(t.Context(), article.Id))
+
+			restored, err := underTest.RestoreArticle(t.Context(), article.Id, time.Now().Add(-time.Hour))
+			assert.NoError(t, err)
+			assert.True(t, restored)
+
+			fromDb, err := underTest.GetArticleById(t.Context(), article.Id)
+			assert.NoError(t, err)
+			assert.Equal(t, article, fromDb.MustGet())
+		})
+
+		t.Run("should not restore an article past the cutoff", func(t *testing.T) {
+			t.Parallel()
+			article, err := underTest.UpsertArticle(t.Context(), helpers.GenArticle(user.Id))
+			require.NoError(t, err)
+			require.NoError(t, underTest.DeleteArticle(t.Context(), article.Id))
+
+			restored, err := underTest.RestoreArticle(t.Context(), article.Id, time.Now().Add(time.Hour))
+			assert.NoError(t, err)
+			assert.False(t, restored)
+		})
+	})
+
+	t.Run("PurgeDeletedArticles", func(t *testing.T) {
+		t.Parallel()
+		user := helpers.UpsertUsers(t, userRepo, 1)[0]
+
+		t.Run("should hard delete articles deleted before the cutoff", func(t *testing.T) {
+			t.Parallel()
+			article, err := underTest.UpsertArticle(t.Context(), helpers.GenArticle(user.Id))
+			require.NoError(t, err)
+			require.NoError(t, underTest.DeleteArticle(t.Context(), article.Id))
+
+			purged, err := underTest.PurgeDeletedArticles(t.Context(), time.Now().Add(time.Hour))
+			assert.NoError(t, err)
+			assert.GreaterOrEqual(t, purged, int64(1))
+
+			restored, err := underTest.RestoreArticle(t.Context(), article.Id, time.Time{})
+			assert.NoError(t, err)
+			assert.False(t, restored)
+		})
+	})
+
+	t.Run("SearchArticles", func(t *testing.T) {
+		t.Parallel()
+		users := helpers.UpsertUsers(t, userRepo, 2)
+
+		// upsert saves articles with text made of words no other test uses, so the results of a search are known
+		upsert := func(t *testing.T, authorUserId uuid.UUID, title string, description string, body string) article_types.Article {
+			article := helpers.GenArticle(authorUserId)
+			article.Title = title
+			article.Description = description
+			article.Body = body
+			created, err := underTest.UpsertArticle(t.Context(), article)
+			require.NoError(t, err)
+			return created
+		}
+		search := func(t *testing.T, query article_types.ArticleSearchQuery) []article_types.ArticleSearchResult {
+			if query.Limit == 0 {
+				query.Limit = 10
+			}
+			results, err := underTest.SearchArticles(t.Context(), query)
+			require.NoError(t, err)
+			return results
+		}
+		ids := func(results []article_types.ArticleSearchResult) []uuid.UUID {
+			found := make([]uuid.UUID, len(results))
+			for i, result := range results {
+				found[i] = result.Article.Id
+			}
+			return found
+		}
+
+		t.Run("should rank title matches above body matches and highlight them", func(t *testing.T) {
+			t.Parallel()
+			word := helpers.GenSearchWord()
+			inBody := upsert(t, users[0].Id, "Gardening", "Notes", "Planting "+word+" in spring")
+			inTitle := upsert(t, users[0].Id, "All about "+word, "Notes", "Some text")
+
+			results := search(t, article_types.ArticleSearchQuery{Text: word})
+			assert.Equal(t, []uuid.UUID{inTitle.Id, inBody.Id}, ids(results))
+			assert.Equal(t, inTitle, results[0].Article)
+			assert.Greater(t, results[0].Rank, results[1].Rank)
+			assert.Empty(t, results[0].Tags)
+			for _, result := range results {
+				assert.Contains(t, result.Highlight, "<mark>"+word+"</mark>")
+			}
+		})
+
+		t.Run("should require every word and leave out excluded words", func(t *testing.T) {
+			t.Parallel()
+			first, second := helpers.GenSearchWord(), helpers.GenSearchWord()
+			both := upsert(t, users[0].Id, first+" and "+second, "Notes", "Some text")
+			onlyFirst := upsert(t, users[0].Id, first+" alone", "Notes", "Some text")
+
+			assert.Equal(t, []uuid.UUID{both.Id}, ids(search(t, article_types.ArticleSearchQuery{Text: first + " " + second})))
+			assert.Equal(t, []uuid.UUID{onlyFirst.Id}, ids(search(t, article_types.ArticleSearchQuery{Text: first + " -" + second})))
+			assert.Empty(t, search(t, article_types.ArticleSearchQuery{Text: "-" + first}))
+		})
+
+		t.Run("should match quoted phrases in order", func(t *testing.T) {
+			t.Parallel()
+			first, second := helpers.GenSearchWord(), helpers.GenSearchWord()
+			inOrder := upsert(t, users[0].Id, "Phrases", "Notes", "Text with "+first+" "+second+" in it")
+			upsert(t, users[0].Id, "Phrases", "Notes", "Text with "+second+" "+first+" in it")
+
+			results := search(t, article_types.ArticleSearchQuery{Text: `"` + first + " " + second + `"`})
+			assert.Equal(t, []uuid.UUID{inOrder.Id}, ids(results))
+		})
+
+		t.Run("should filter by author, excluded authors, and tag", func(t *testing.T) {
+			t.Parallel()
+			word := helpers.GenSearchWord()
+			byFirst := upsert(t, users[0].Id, word, "Notes", "Some text")
+			bySecond := upsert(t, users[1].Id, word, "Notes", "Some text")
+
+			byAuthor := search(t, article_types.ArticleSearchQuery{Text: word, AuthorUserId: mo.Some(users[1].Id)})
+			assert.Equal(t, []uuid.UUID{bySecond.Id}, ids(byAuthor))
+
+			excluding := search(t, article_types.ArticleSearchQuery{Text: word, ExcludedAuthorUserIds: []uuid.UUID{users[1].Id}})
+			assert.Equal(t, []uuid.UUID{byFirst.Id}, ids(excluding))
+
+			require.NoError(t, underTest.AddArticleTags(t.Context(), byFirst.Id, []string{"golang"}))
+			byTag := search(t, article_types.ArticleSearchQuery{Text: word, Tag: mo.Some("golang")})
+			assert.Equal(t, []uuid.UUID{byFirst.Id}, ids(byTag))
+		})
+
+		t.Run("should page through results", func(t *testing.T) {
+			t.Parallel()
+			word := helpers.GenSearchWord()
+			for range 3 {
+				upsert(t, users[0].Id, word, "Notes", "Some text")
+			}
+
+			firstPage := search(t, article_types.ArticleSearchQuery{Text: word, Limit: 2})
+			secondPage := search(t, article_types.ArticleSearchQuery{Text: word, Limit: 2, Offset: 2})
+			assert.Len(t, firstPage, 2)
+			assert.Len(t, secondPage, 1)
+			assert.NotContains(t, ids(firstPage), secondPage[0].Article.Id)
+		})
+
+		t.Run("should find updated text and not deleted articles", func(t *testing.T) {
+			t.Parallel()
+			before, after := helpers.GenSearchWord(), helpers.GenSearchWord()
+			article := upsert(t, users[0].Id, before, "Notes", "Some text")
+
+			article.Title = after
+			article, err := underTest.UpsertArticle(t.Context(), article)
+			require.NoError(t, err)
+			assert.Empty(t, search(t, article_types.ArticleSearchQuery{Text: before}))
+			assert.Equal(t, []uuid.UUID{article.Id}, ids(search(t, article_types.ArticleSearchQuery{Text: after})))
+
+			require.NoError(t, underTest.DeleteArticle(t.Context(), article.Id))
+			assert.Empty(t, search(t, article_types.ArticleSearchQuery{Text: after}))
+		})
+
+		t.Run("should escape html in highlights", func(t *testing.T) {
+			t.Parallel()
+			word := helpers.GenSearchWord()
+			upsert(t, users[0].Id, "Markup", "Notes", "a & b "+word)
+
+			results := search(t, article_types.ArticleSearchQuery{Text: word})
+			require.Len(t, results, 1)
+			assert.Contains(t, results[0].Highlight, "&amp;")
+			assert.Contains(t, results[0].Highlight, "<mark>"+word+"</mark>")
+		})
+	})
+
+	t.Run("should fail to insert an article by an author that does not exist", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := underTest.UpsertArticle(t.Context(), helpers.GenArticle(uuid.New()))
+		assert.Error(t, err)
+	})
+
+	t.Run("should hard delete the articles of purged authors", func(t *testing.T) {
+		t.Parallel()
+		user := helpers.UpsertUsers(t, userRepo, 1)[0]
+		article, err := underTest.UpsertArticle(t.Context(), helpers.GenArticle(user.Id))
+		require.NoError(t, err)
+		require.NoError(t, underTest.DeleteArticle(t.Context(), article.Id))
+
+		require.NoError(t, userRepo.DeleteUser(t.Context(), user.Id))
+		_, err = userRepo.PurgeDeletedUsers(t.Context(), time.Now().Add(time.Hour))
+		require.NoError(t, err)
+
+		restored, err := underTest.RestoreArticle(t.Context(), article.Id, time.Time{})
+		assert.NoError(t, err)
+		assert.False(t, restored)
+	})
+
+	t.Run("should exclude articles of deleted authors", func(t *testing.T) {
+		t.Parallel()
+		user := helpers.UpsertUsers(t, userRepo, 1)[0]
+		article, err := underTest.UpsertArticle(t.Context(), helpers.GenArticle(user.Id))
+		require.NoError(t, err)
+
+		require.NoError(t, userRepo.DeleteUser(t.Context(), user.Id))
+
+		fromDb, err := underTest.GetArticleById(t.Context(), article.Id)
+		assert.NoError(t, err)
+		assert.True(t, fromDb.IsNone())
+	})
+}
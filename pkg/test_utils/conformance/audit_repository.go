@@ -0,0 +1,166 @@
+package conformance
+
+import (
+	"testing"
+	"time"
+
+	audit_types "github.com/nimaeskandary/go-realworld/pkg/audit/types"
+	"github.com/nimaeskandary/go-realworld/pkg/test_utils/helpers"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/samber/mo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// AuditRepositorySuite checks the behaviour every AuditRepository implementation must share. Entries are never deleted,
+// so each case writes entries for its own entity and filters on it
+func AuditRepositorySuite(t *testing.T, underTest audit_types.AuditRepository) {
+	insert := func(t *testing.T, entries ...audit_types.AuditEntry) {
+		for _, entry := range entries {
+			require.NoError(t, underTest.InsertEntry(t.Context(), entry))
+		}
+	}
+	list := func(t *testing.T, query audit_types.AuditQuery) []audit_types.AuditEntry {
+		if query.Limit == 0 {
+			query.Limit = 100
+		}
+		entries, err := underTest.ListEntries(t.Context(), query)
+		require.NoError(t, err)
+		return entries
+	}
+	ids := func(entries []audit_types.AuditEntry) []uuid.UUID {
+		return lo.Map(entries, func(entry audit_types.AuditEntry, _ int) uuid.UUID { return entry.Id })
+	}
+	now := time.Now()
+
+	t.Run("ListEntries", func(t *testing.T) {
+		t.Parallel()
+
+		t.Run("should return the entries of the entity newest first, as they were inserted", func(t *testing.T) {
+			t.Parallel()
+			entityId := uuid.NewString()
+			oldest := helpers.GenAuditEntry(audit_types.AuditEntityArticle, entityId, now.Add(-3*time.Minute))
+			oldest.Action = audit_types.AuditActionCreate
+			oldest.ActorUserId = mo.None[uuid.UUID]()
+			oldest.Before = nil
+			middle := helpers.GenAuditEntry(audit_types.AuditEntityArticle, entityId, now.Add(-2*time.Minute))
+			newest := helpers.GenAuditEntry(audit_types.AuditEntityArticle, entityId, now.Add(-1*time.Minute))
+			newest.Action = audit_types.AuditActionDelete
+			newest.After = nil
+			insert(t, middle, newest, oldest)
+
+			entries := list(t, audit_types.AuditQuery{EntityId: mo.Some(entityId)})
+			assert.Equal(t, []uuid.UUID{newest.Id, middle.Id, oldest.Id}, ids(entries))
+
+			assert.Equal(t, oldest.Id, entries[2].Id)
+			assert.True(t, entries[2].ActorUserId.IsNone())
+			assert.Equal(t, audit_types.AuditActionCreate, entries[2].Action)
+			assert.Equal(t, audit_types.AuditEntityArticle, entries[2].EntityType)
+			assert.Equal(t, entityId, entries[2].EntityId)
+			assert.Nil(t, entries[2].Before)
+			assert.JSONEq(t, string(oldest.After), string(entries[2].After))
+			assert.Equal(t, oldest.CreatedAtMillis, entries[2].CreatedAtMillis)
+
+			assert.Equal(t, middle.ActorUserId, entries[1].ActorUserId)
+			assert.JSONEq(t, string(middle.Before), string(entries[1].Before))
+			assert.Nil(t, entries[0].After)
+		})
+
+		t.Run("should filter by actor", func(t *testing.T) {
+			t.Parallel()
+			actorUserId := uuid.New()
+			byActor := helpers.GenAuditEntry(audit_types.AuditEntityUser, uuid.NewString(), now)
+			byActor.ActorUserId = mo.Some(actorUserId)
+			alsoByActor := helpers.GenAuditEntry(audit_types.AuditEntityArticle, uuid.NewString(), now.Add(-time.Minute))
+			alsoByActor.ActorUserId = mo.Some(actorUserId)
+			insert(t, byActor, alsoByActor, helpers.GenAuditEntry(audit_types.AuditEntityUser, byActor.EntityId, now))
+
+			entries := list(t, audit_types.AuditQuery{ActorUserId: mo.Some(actorUserId)})
+			assert.Equal(t, []uuid.UUID{byActor.Id, alsoByActor.Id}, ids(entries))
+		})
+
+		t.Run("should filter by entity type and id", func(t *testing.T) {
+			t.Parallel()
+			// a follow has the id of the followed user, so the same id is used by entities of two types
+			entityId := uuid.NewString()
+			user := helpers.GenAuditEntry(audit_types.AuditEntityUser, entityId, now)
+			follow := helpers.GenAuditEntry(audit_types.AuditEntityFollow, entityId, now)
+			insert(t, user, follow)
+
+			entries := list(t, audit_types.AuditQuery{
+				EntityType: mo.Some(audit_types.AuditEntityFollow),
+				EntityId:   mo.Some(entityId),
+			})
+			assert.Equal(t, []uuid.UUID{follow.Id}, ids(entries))
+
+			byType := list(t, audit_types.AuditQuery{
+				EntityType: mo.Some(audit_types.AuditEntityUser),
+				From:       mo.Some(now.Add(-time.Second)),
+				Limit:      1000,
+			})
+			assert.Contains(t, ids(byType), user.Id)
+			assert.NotContains(t, ids(byType), follow.Id)
+		})
+
+		t.Run("should filter by time range, from inclusive and to exclusive", func(t *testing.T) {
+			t.Parallel()
+			entityId := uuid.NewString()
+			from := now.Add(-time.Hour).Truncate(time.Millisecond)
+			to := now.Add(-30 * time.Minute).Truncate(time.Millisecond)
+			before := helpers.GenAuditEntry(audit_types.AuditEntityArticle, entityId, from.Add(-time.Millisecond))
+			atFrom := helpers.GenAuditEntry(audit_types.AuditEntityArticle, entityId, from)
+			within := helpers.GenAuditEntry(audit_types.AuditEntityArticle, entityId, from.Add(time.Minute))
+			atTo := helpers.GenAuditEntry(audit_types.AuditEntityArticle, entityId, to)
+			insert(t, before, atFrom, within, atTo)
+
+			entries := list(t, audit_types.AuditQuery{EntityId: mo.Some(entityId), From: mo.Some(from), To: mo.Some(to)})
+			assert.Equal(t, []uuid.UUID{within.Id, atFrom.Id}, ids(entries))
+
+			entries = list(t, audit_types.AuditQuery{EntityId: mo.Some(entityId), To: mo.Some(to)})
+			assert.Equal(t, []uuid.UUID{within.Id, atFrom.Id, before.Id}, ids(entries))
+		})
+
+		t.Run("should page with limit and offset", func(t *testing.T) {
+			t.Parallel()
+			entityId := uuid.NewString()
+			var entries []audit_types.AuditEntry
+			for i := range 5 {
+				entries = append(entries, helpers.GenAuditEntry(audit_types.AuditEntityUser, entityId, now.Add(-time.Duration(i)*time.Minute)))
+			}
+			insert(t, entries...)
+
+			page := list(t, audit_types.AuditQuery{EntityId: mo.Some(entityId), Limit: 2, Offset: 1})
+			assert.Equal(t, ids(entries[1:3]), ids(page))
+
+			page = list(t, audit_types.AuditQuery{EntityId: mo.Some(entityId), Limit: 2, Offset: 4})
+			assert.Equal(t, ids(entries[4:]), ids(page))
+		})
+
+		t.Run("should return no entries when nothing matches", func(t *testing.T) {
+			t.Parallel()
+
+			entries := list(t, audit_types.AuditQuery{EntityId: mo.Some(uuid.NewString())})
+			assert.Empty(t, entries)
+		})
+	})
+
+	t.Run("InsertEntry", func(t *testing.T) {
+		t.Parallel()
+
+		t.Run("should fail for an id that is already recorded", func(t *testing.T) {
+			t.Parallel()
+			entry := helpers.GenAuditEntry(audit_types.AuditEntityUser, uuid.NewString(), now)
+			insert(t, entry)
+
+			duplicate := helpers.GenAuditEntry(audit_types.AuditEntityUser, entry.EntityId, now)
+			duplicate.Id = entry.Id
+			assert.Error(t, underTest.InsertEntry(t.Context(), duplicate))
+
+			entries := list(t, audit_types.AuditQuery{EntityId: mo.Some(entry.EntityId)})
+			require.Len(t, entries, 1)
+			assert.JSONEq(t, string(entry.After), string(entries[0].After))
+		})
+	})
+}
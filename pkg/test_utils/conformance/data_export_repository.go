@@ -0,0 +1,167 @@
+package conformance
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	article_types "github.com/nimaeskandary/go-realworld/pkg/article/types"
+	data_export_types "github.com/nimaeskandary/go-realworld/pkg/data_export/types"
+	"github.com/nimaeskandary/go-realworld/pkg/test_utils/helpers"
+	user_types "github.com/nimaeskandary/go-realworld/pkg/user/types"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// DataExportRepositorySuite checks the behaviour every DataExportRepository implementation must share. userRepo and
+// articleRepo must share the same storage, they are used to write the rows being exported
+func DataExportRepositorySuite(
+	t *testing.T,
+	userRepo user_types.UserRepository,
+	articleRepo article_types.ArticleRepository,
+	underTest data_export_types.DataExportRepository,
+) {
+	t.Run("StreamArticles", func(t *testing.T) {
+		t.Parallel()
+
+		t.Run("should stream the author's articles in creation order, including soft deleted ones", func(t *testing.T) {
+			t.Parallel()
+			users := helpers.UpsertUsers(t, userRepo, 2)
+
+			first := helpers.GenArticle(users[0].Id)
+			first.CreatedAtMillis = time.Now().Add(-time.Hour).UnixMilli()
+			_, err := articleRepo.UpsertArticle(t.Context(), first)
+			require.NoError(t, err)
+			require.NoError(t, articleRepo.AddArticleTags(t.Context(), first.Id, []string{"go", "sql"}))
+			second, err := articleRepo.UpsertArticle(t.Context(), helpers.GenArticle(users[0].Id))
+			require.NoError(t, err)
+			require.NoError(t, articleRepo.DeleteArticle(t.Context(), second.Id))
+			// another author's article is not exported
+			_, err = articleRepo.UpsertArticle(t.Context(), helpers.GenArticle(users[1].Id))
+			require.NoError(t, err)
+
+			var rows []data_export_types.ExportedArticle
+			err = underTest.StreamArticles(t.Context(), users[0].Id, func(row data_export_types.ExportedArticle) error {
+				rows = append(rows, row)
+				return nil
+			})
+			require.NoError(t, err)
+			require.Len(t, rows, 2)
+
+			assert.Equal(t, first.Id, rows[0].Id)
+			assert.Nil(t, rows[0].DeletedAt)
+			assert.ElementsMatch(t, []string{"go", "sql"}, rows[0].Tags)
+			var data map[string]any
+			require.NoError(t, json.Unmarshal(rows[0].Data, &data))
+			assert.Equal(t, map[string]any{
+				"schema_version": float64(2),
+				"title":          first.Title,
+				"description":    first.Description,
+				"body":           first.Body,
+			}, data)
+
+			assert.Equal(t, second.Id, rows[1].Id)
+			assert.NotNil(t, rows[1].DeletedAt)
+		})
+
+		t.Run("should stop at the first error returned by fn", func(t *testing.T) {
+			t.Parallel()
+			user := helpers.UpsertUsers(t, userRepo, 1)[0]
+			for range 2 {
+				_, err := articleRepo.UpsertArticle(t.Context(), helpers.GenArticle(user.Id))
+				require.NoError(t, err)
+			}
+
+			stop := errors.New("stop")
+			calls := 0
+			err := underTest.StreamArticles(t.Context(), user.Id, func(data_export_types.ExportedArticle) error {
+				calls++
+				return stop
+			})
+			assert.ErrorIs(t, err, stop)
+			assert.Equal(t, 1, calls)
+		})
+	})
+
+	t.Run("StreamFavorites", func(t *testing.T) {
+		t.Parallel()
+
+		t.Run("should stream nothing for a user without favorites", func(t *testing.T) {
+			t.Parallel()
+			user := helpers.UpsertUsers(t, userRepo, 1)[0]
+
+			calls := 0
+			err := underTest.StreamFavorites(t.Context(), user.Id, func(data_export_types.ExportedFavorite) error {
+				calls++
+				return nil
+			})
+			assert.NoError(t, err)
+			assert.Zero(t, calls)
+		})
+
+		t.Run("should stream the articles the user favorited", func(t *testing.T) {
+			t.Parallel()
+			users := helpers.UpsertUsers(t, userRepo, 2)
+			article, err := articleRepo.UpsertArticle(t.Context(), helpers.GenArticle(users[0].Id))
+			require.NoError(t, err)
+			require.NoError(t, articleRepo.FavoriteArticle(t.Context(), users[1].Id, article.Id))
+
+			var rows []data_export_types.ExportedFavorite
+			err = underTest.StreamFavorites(t.Context(), users[1].Id, func(row data_export_types.ExportedFavorite) error {
+				rows = append(rows, row)
+				return nil
+			})
+			require.NoError(t, err)
+			require.Len(t, rows, 1)
+			assert.Equal(t, article.Id, rows[0].ArticleId)
+		})
+	})
+
+	t.Run("StreamFollows", func(t *testing.T) {
+		t.Parallel()
+
+		t.Run("should stream follows in both directions with the other user's username", func(t *testing.T) {
+			t.Parallel()
+			users := helpers.UpsertUsers(t, userRepo, 3)
+			require.NoError(t, userRepo.Follow(t.Context(), users[0].Id, users[1].Id))
+			require.NoError(t, userRepo.Follow(t.Context(), users[2].Id, users[0].Id))
+			// follows between other users are not exported
+			require.NoError(t, userRepo.Follow(t.Context(), users[1].Id, users[2].Id))
+
+			var rows []data_export_types.ExportedFollow
+			err := underTest.StreamFollows(t.Context(), users[0].Id, func(row data_export_types.ExportedFollow) error {
+				rows = append(rows, row)
+				return nil
+			})
+			require.NoError(t, err)
+
+			type follow struct {
+				direction data_export_types.FollowDirection
+				username  string
+			}
+			follows := make([]follow, len(rows))
+			for i, row := range rows {
+				follows[i] = follow{direction: row.Direction, username: row.Username}
+			}
+			assert.ElementsMatch(t, []follow{
+				{direction: data_export_types.FollowDirectionFollowing, username: users[1].Username},
+				{direction: data_export_types.FollowDirectionFollower, username: users[2].Username},
+			}, follows)
+		})
+
+		t.Run("should stream nothing for a user that does not exist", func(t *testing.T) {
+			t.Parallel()
+
+			calls := 0
+			err := underTest.StreamFollows(t.Context(), uuid.New(), func(data_export_types.ExportedFollow) error {
+				calls++
+				return nil
+			})
+			assert.NoError(t, err)
+			assert.Zero(t, calls)
+		})
+	})
+}
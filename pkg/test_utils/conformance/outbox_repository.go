@@ -0,0 +1,183 @@
+package conformance
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	db_types "github.com/nimaeskandary/go-realworld/pkg/database/types"
+	outbox_types "github.com/nimaeskandary/go-realworld/pkg/outbox/types"
+	"github.com/nimaeskandary/go-realworld/pkg/test_utils/helpers"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/samber/mo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// OutboxRepositorySuite checks the behaviour every OutboxRepository implementation must share. txManager must be for
+// the same storage. The cases are not parallel, every due event is visible to every claim, so each case delivers the
+// events it inserts before it ends
+func OutboxRepositorySuite(t *testing.T, txManager db_types.TxManager, underTest outbox_types.OutboxRepository) {
+	// insert writes the events, they are marked delivered when the test ends so they are not claimed by the next
+	insert := func(t *testing.T, events ...outbox_types.OutboxEvent) {
+		for _, event := range events {
+			require.NoError(t, underTest.InsertEvent(t.Context(), event))
+		}
+		t.Cleanup(func() {
+			for _, event := range events {
+				_ = underTest.MarkDelivered(context.Background(), event.Id, time.Now())
+			}
+		})
+	}
+	claim := func(t *testing.T, limit int, now time.Time) []outbox_types.OutboxEvent {
+		var events []outbox_types.OutboxEvent
+		err := txManager.WithinTx(t.Context(), func(ctx context.Context) error {
+			var err error
+			events, err = underTest.ClaimEvents(ctx, limit, now)
+			return err
+		})
+		require.NoError(t, err)
+		return events
+	}
+	ids := func(events []outbox_types.OutboxEvent) []uuid.UUID {
+		return lo.Map(events, func(event outbox_types.OutboxEvent, _ int) uuid.UUID { return event.Id })
+	}
+	now := time.Now()
+
+	t.Run("ClaimEvents", func(t *testing.T) {
+		t.Run("should claim due events oldest first, up to the limit", func(t *testing.T) {
+			oldest := helpers.GenOutboxEvent("test.claimed", now.Add(-3*time.Minute))
+			middle := helpers.GenOutboxEvent("test.claimed", now.Add(-2*time.Minute))
+			newest := helpers.GenOutboxEvent("test.claimed", now.Add(-1*time.Minute))
+			insert(t, newest, oldest, middle)
+
+			claimed := claim(t, 2, now)
+			assert.Equal(t, []uuid.UUID{oldest.Id, middle.Id}, ids(claimed))
+			assert.Equal(t, oldest.Type, claimed[0].Type)
+			assert.Equal(t, oldest.CreatedAtMillis, claimed[0].CreatedAtMillis)
+			assert.Equal(t, 0, claimed[0].Attempts)
+			assert.JSONEq(t, string(oldest.Payload), string(claimed[0].Payload))
+		})
+
+		t.Run("should not claim events that are not yet due", func(t *testing.T) {
+			future := helpers.GenOutboxEvent("test.future", now.Add(time.Hour))
+			insert(t, future)
+
+			assert.NotContains(t, ids(claim(t, 100, now)), future.Id)
+			assert.Contains(t, ids(claim(t, 100, now.Add(2*time.Hour))), future.Id)
+		})
+
+		t.Run("should not claim delivered events", func(t *testing.T) {
+			event := helpers.GenOutboxEvent("test.delivered", now.Add(-time.Minute))
+			insert(t, event)
+			require.NoError(t, underTest.MarkDelivered(t.Context(), event.Id, now))
+
+			assert.Empty(t, claim(t, 100, now.Add(time.Hour)))
+		})
+
+		t.Run("should claim a failed event again once its retry is due, counting the attempt", func(t *testing.T) {
+			event := helpers.GenOutboxEvent("test.retried", now.Add(-time.Minute))
+			insert(t, event)
+			require.NoError(t, underTest.MarkFailed(t.Context(), event.Id, "handler failed", mo.Some(now.Add(time.Minute))))
+
+			assert.Empty(t, claim(t, 100, now))
+			claimed := claim(t, 100, now.Add(time.Minute))
+			require.Equal(t, []uuid.UUID{event.Id}, ids(claimed))
+			assert.Equal(t, 1, claimed[0].Attempts)
+		})
+
+		t.Run("should never claim an event that failed without a retry", func(t *testing.T) {
+			event := helpers.GenOutboxEvent("test.given_up", now.Add(-time.Minute))
+			insert(t, event)
+			require.NoError(t, underTest.MarkFailed(t.Context(), event.Id, "handler failed", mo.None[time.Time]()))
+
+			assert.Empty(t, claim(t, 100, now.Add(24*time.Hour)))
+		})
+
+		t.Run("should not claim the events claimed by another transaction", func(t *testing.T) {
+			first := helpers.GenOutboxEvent("test.concurrent", now.Add(-2*time.Minute))
+			second := helpers.GenOutboxEvent("test.concurrent", now.Add(-1*time.Minute))
+			insert(t, first, second)
+
+			type claimResult struct {
+				ids []uuid.UUID
+				err error
+			}
+			otherClaimed := make(chan claimResult, 2)
+			err := txManager.WithinTx(t.Context(), func(ctx context.Context) error {
+				claimed, err := underTest.ClaimEvents(ctx, 1, now)
+				if err != nil {
+					return err
+				}
+				require.Equal(t, []uuid.UUID{first.Id}, ids(claimed))
+
+				// depending on the database, the other claim skips the locked event, or waits for this transaction
+				go func() {
+					err := txManager.WithinTx(t.Context(), func(ctx context.Context) error {
+						claimed, err := underTest.ClaimEvents(ctx, 100, now)
+						otherClaimed <- claimResult{ids: ids(claimed), err: err}
+						return err
+					})
+					if err != nil {
+						otherClaimed <- claimResult{err: err}
+					}
+				}()
+				time.Sleep(50 * time.Millisecond)
+				return underTest.MarkDelivered(ctx, first.Id, now)
+			})
+			require.NoError(t, err)
+
+			other := <-otherClaimed
+			require.NoError(t, other.err)
+			assert.Equal(t, []uuid.UUID{second.Id}, other.ids)
+		})
+	})
+
+	t.Run("InsertEvent", func(t *testing.T) {
+		t.Run("should write nothing when the transaction rolls back", func(t *testing.T) {
+			event := helpers.GenOutboxEvent("test.rolled_back", now.Add(-time.Minute))
+			rollback := errors.New("rollback")
+
+			err := txManager.WithinTx(t.Context(), func(ctx context.Context) error {
+				require.NoError(t, underTest.InsertEvent(ctx, event))
+				return rollback
+			})
+			require.ErrorIs(t, err, rollback)
+
+			assert.Empty(t, claim(t, 100, now))
+			assert.Error(t, underTest.MarkDelivered(t.Context(), event.Id, now))
+		})
+	})
+
+	t.Run("MarkDelivered and MarkFailed", func(t *testing.T) {
+		t.Run("should fail for an event that does not exist", func(t *testing.T) {
+			assert.Error(t, underTest.MarkDelivered(t.Context(), uuid.New(), now))
+			assert.Error(t, underTest.MarkFailed(t.Context(), uuid.New(), "handler failed", mo.None[time.Time]()))
+		})
+	})
+
+	t.Run("PurgeDelivered", func(t *testing.T) {
+		t.Run("should delete only the events delivered before the cutoff", func(t *testing.T) {
+			// delivered long ago, so no other event is delivered before the cutoff
+			deliveredAt := time.Date(2001, 1, 1, 0, 0, 0, 0, time.UTC)
+			purged := helpers.GenOutboxEvent("test.purged", now.Add(-time.Minute))
+			kept := helpers.GenOutboxEvent("test.purged", now.Add(-time.Minute))
+			failed := helpers.GenOutboxEvent("test.purged", now.Add(-time.Minute))
+			insert(t, purged, kept, failed)
+			require.NoError(t, underTest.MarkDelivered(t.Context(), purged.Id, deliveredAt))
+			require.NoError(t, underTest.MarkDelivered(t.Context(), kept.Id, deliveredAt.Add(time.Hour)))
+			require.NoError(t, underTest.MarkFailed(t.Context(), failed.Id, "handler failed", mo.None[time.Time]()))
+
+			count, err := underTest.PurgeDelivered(t.Context(), deliveredAt.Add(time.Minute))
+			require.NoError(t, err)
+			assert.Equal(t, int64(1), count)
+
+			assert.Error(t, underTest.MarkDelivered(t.Context(), purged.Id, now))
+			assert.NoError(t, underTest.MarkDelivered(t.Context(), kept.Id, now))
+			assert.NoError(t, underTest.MarkDelivered(t.Context(), failed.Id, now))
+		})
+	})
+}
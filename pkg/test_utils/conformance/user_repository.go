@@ -0,0 +1,775 @@
+package conformance
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nimaeskandary/go-realworld/pkg/test_utils/helpers"
+	user_types "github.com/nimaeskandary/go-realworld/pkg/user/types"
+
+	"github.com/google/uuid"
+	"github.com/samber/mo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// UserRepositorySuite checks the behaviour every UserRepository implementation must share
+func UserRepositorySuite(t *testing.T, underTest user_types.UserRepository) {
+	t.Run("UpsertUser", func(t *testing.T) {
+		t.Parallel()
+		t.Run("should insert new user with all fields", func(t *testing.T) {
+			t.Parallel()
+
+			user := helpers.GenUser()
+			user.Bio = mo.Some("test bio")
+			user.Image = mo.Some("http://example.com/image.png")
+
+			created, err := underTest.UpsertUser(t.Context(), user)
+			assert.NoError(t, err)
+			assert.Equal(t, user, created)
+
+			fromDb, err := underTest.GetUserById(t.Context(), user.Id)
+			assert.NoError(t, err)
+			assert.True(t, fromDb.IsSome())
+			assert.Equal(t, user, fromDb.MustGet())
+		})
+
+		t.Run("should insert a user without optional fields", func(t *testing.T) {
+			t.Parallel()
+
+			user := helpers.GenUser()
+			user.Bio = mo.None[string]()
+			user.Image = mo.None[string]()
+
+			created, err := underTest.UpsertUser(t.Context(), user)
+			assert.NoError(t, err)
+			assert.Equal(t, user, created)
+		})
+
+		t.Run("should update existing user with optional fields", func(t *testing.T) {
+			t.Parallel()
+
+			created, err := underTest.UpsertUser(t.Context(), helpers.GenUser())
+			assert.NoError(t, err)
+
+			updatedUser := user_types.User{
+				Id:              created.Id,
+				Username:        "updateduseroptional",
+				Email:           "updatedoptional@example.com",
+				Bio:             mo.Some("updated bio"),
+				Image:           mo.Some("http://example.com/updated-image.png"),
+				CreatedAtMillis: created.CreatedAtMillis,
+				UpdatedAtMillis: time.Now().UnixMilli(),
+				Version:         created.Version,
+			}
+			expectedUser := updatedUser
+			expectedUser.Version = created.Version + 1
+
+			updated, err := underTest.UpsertUser(t.Context(), updatedUser)
+			assert.NoError(t, err)
+			assert.Equal(t, expectedUser, updated)
+		})
+
+		t.Run("should update existing user without optional fields", func(t *testing.T) {
+			t.Parallel()
+
+			created, err := underTest.UpsertUser(t.Context(), helpers.GenUser())
+			assert.NoError(t, err)
+
+			updatedUser := user_types.User{
+				Id:              created.Id,
+				Username:        "updateduser",
+				Email:           "updated@example.com",
+				Bio:             mo.None[string](),
+				Image:           mo.None[string](),
+				CreatedAtMillis: created.CreatedAtMillis,
+				UpdatedAtMillis: time.Now().UnixMilli(),
+				Version:         created.Version,
+			}
+			expectedUser := updatedUser
+			expectedUser.Version = created.Version + 1
+
+			updated, err := underTest.UpsertUser(t.Context(), updatedUser)
+			assert.NoError(t, err)
+			assert.Equal(t, expectedUser, updated)
+		})
+	})
+
+	t.Run("UpsertUser versioning", func(t *testing.T) {
+		t.Parallel()
+
+		t.Run("should return VersionConflictError when updating a stale version", func(t *testing.T) {
+			t.Parallel()
+			created, err := underTest.UpsertUser(t.Context(), helpers.GenUser())
+			require.NoError(t, err)
+
+			first := created
+			first.Bio = mo.Some("first update")
+			_, err = underTest.UpsertUser(t.Context(), first)
+			require.NoError(t, err)
+
+			stale := created
+			stale.Bio = mo.Some("stale update")
+			_, err = underTest.UpsertUser(t.Context(), stale)
+			assert.IsType(t, user_types.VersionConflictError{}, err)
+
+			fromDb, err := underTest.GetUserById(t.Context(), created.Id)
+			require.NoError(t, err)
+			assert.Equal(t, mo.Some("first update"), fromDb.MustGet().Bio)
+			assert.Equal(t, created.Version+1, fromDb.MustGet().Version)
+		})
+	})
+
+	t.Run("Username history", func(t *testing.T) {
+		t.Parallel()
+
+		rename := func(t *testing.T, user user_types.User, username string) user_types.User {
+			renamed := user
+			renamed.Username = username
+			renamed.UpdatedAtMillis = time.Now().UnixMilli()
+			updated, err := underTest.UpsertUser(t.Context(), renamed)
+			require.NoError(t, err)
+			return updated
+		}
+
+		t.Run("should resolve a previous username to the renamed user", func(t *testing.T) {
+			t.Parallel()
+			created, err := underTest.UpsertUser(t.Context(), helpers.GenUser())
+			require.NoError(t, err)
+			renamed := rename(t, created, "renamed-"+created.Id.String())
+
+			fromDb, err := underTest.GetUserByPreviousUsername(t.Context(), strings.ToUpper(created.Username))
+			require.NoError(t, err)
+			assert.Equal(t, mo.Some(renamed), fromDb)
+		})
+
+		t.Run("should not record a change in case only", func(t *testing.T) {
+			t.Parallel()
+			created, err := underTest.UpsertUser(t.Context(), helpers.GenUser())
+			require.NoError(t, err)
+			rename(t, created, strings.ToUpper(created.Username))
+
+			fromDb, err := underTest.GetUserByPreviousUsername(t.Context(), created.Username)
+			require.NoError(t, err)
+			assert.True(t, fromDb.IsNone())
+		})
+
+		t.Run("should reserve a previous username for other users until the cutoff", func(t *testing.T) {
+			t.Parallel()
+			created, err := underTest.UpsertUser(t.Context(), helpers.GenUser())
+			require.NoError(t, err)
+			rename(t, created, "renamed-"+created.Id.String())
+
+			reserved, err := underTest.IsUsernameReserved(t.Context(), created.Username, uuid.New(), time.Now().Add(-time.Hour))
+			require.NoError(t, err)
+			assert.True(t, reserved)
+
+			reserved, err = underTest.IsUsernameReserved(t.Context(), created.Username, created.Id, time.Now().Add(-time.Hour))
+			require.NoError(t, err)
+			assert.False(t, reserved, "a user can always take back their own previous username")
+
+			reserved, err = underTest.IsUsernameReserved(t.Context(), created.Username, uuid.New(), time.Now().Add(time.Hour))
+			require.NoError(t, err)
+			assert.False(t, reserved, "the username is released once the cooldown has passed")
+		})
+
+		t.Run("should release previous usernames of deleted users", func(t *testing.T) {
+			t.Parallel()
+			created, err := underTest.UpsertUser(t.Context(), helpers.GenUser())
+			require.NoError(t, err)
+			rename(t, created, "renamed-"+created.Id.String())
+			require.NoError(t, underTest.DeleteUser(t.Context(), created.Id))
+
+			fromDb, err := underTest.GetUserByPreviousUsername(t.Context(), created.Username)
+			require.NoError(t, err)
+			assert.True(t, fromDb.IsNone())
+
+			reserved, err := underTest.IsUsernameReserved(t.Context(), created.Username, uuid.New(), time.Now().Add(-time.Hour))
+			require.NoError(t, err)
+			assert.False(t, reserved)
+		})
+	})
+
+	t.Run("UpsertUser uniqueness", func(t *testing.T) {
+		t.Parallel()
+
+		t.Run("should return ConflictError for a username differing only by case", func(t *testing.T) {
+			t.Parallel()
+			user := helpers.GenUser()
+			_, err := underTest.UpsertUser(t.Context(), user)
+			require.NoError(t, err)
+
+			other := helpers.GenUser()
+			other.Username = strings.ToUpper(user.Username)
+			_, err = underTest.UpsertUser(t.Context(), other)
+			assert.Equal(t, user_types.ConflictError{Msg: "username already exists"}, err)
+		})
+
+		t.Run("should return ConflictError for an email differing only by case", func(t *testing.T) {
+			t.Parallel()
+			user := helpers.GenUser()
+			_, err := underTest.UpsertUser(t.Context(), user)
+			require.NoError(t, err)
+
+			other := helpers.GenUser()
+			other.Email = strings.ToUpper(user.Email)
+			_, err = underTest.UpsertUser(t.Context(), other)
+			assert.Equal(t, user_types.ConflictError{Msg: "email already exists"}, err)
+		})
+
+		t.Run("should let only one of many concurrent inserts of the same username succeed", func(t *testing.T) {
+			t.Parallel()
+			username := helpers.GenUser().Username
+
+			var wg sync.WaitGroup
+			errs := make([]error, 5)
+			for i := range errs {
+				wg.Go(func() {
+					user := helpers.GenUser()
+					user.Username = username
+					_, errs[i] = underTest.UpsertUser(t.Context(), user)
+				})
+			}
+			wg.Wait()
+
+			succeeded := 0
+			for _, err := range errs {
+				if err == nil {
+					succeeded++
+					continue
+				}
+				assert.IsType(t, user_types.ConflictError{}, err)
+			}
+			assert.Equal(t, 1, succeeded)
+		})
+	})
+
+	t.Run("GetUserByUsername and GetUserByEmail", func(t *testing.T) {
+		t.Parallel()
+
+		t.Run("should match ignoring case", func(t *testing.T) {
+			t.Parallel()
+			user := helpers.UpsertUsers(t, underTest, 1)[0]
+
+			byUsername, err := underTest.GetUserByUsername(t.Context(), strings.ToUpper(user.Username))
+			assert.NoError(t, err)
+			assert.Equal(t, mo.Some(user), byUsername)
+
+			byEmail, err := underTest.GetUserByEmail(t.Context(), strings.ToUpper(user.Email))
+			assert.NoError(t, err)
+			assert.Equal(t, mo.Some(user), byEmail)
+		})
+	})
+
+	t.Run("DeleteUser", func(t *testing.T) {
+		t.Parallel()
+
+		t.Run("should return no error when deleting non-existing user", func(t *testing.T) {
+			t.Parallel()
+
+			err := underTest.DeleteUser(t.Context(), uuid.New())
+			assert.NoError(t, err)
+		})
+
+		t.Run("should delete existing user", func(t *testing.T) {
+			t.Parallel()
+
+			user := helpers.GenUser()
+			_, err := underTest.UpsertUser(t.Context(), user)
+			assert.NoError(t, err)
+
+			err = underTest.DeleteUser(t.Context(), user.Id)
+			assert.NoError(t, err)
+
+			fromDb, err := underTest.GetUserById(t.Context(), user.Id)
+			assert.NoError(t, err)
+			assert.True(t, fromDb.IsNone())
+		})
+
+		t.Run("should release the username and email of a deleted user", func(t *testing.T) {
+			t.Parallel()
+
+			user := helpers.GenUser()
+			_, err := underTest.UpsertUser(t.Context(), user)
+			assert.NoError(t, err)
+			assert.NoError(t, underTest.DeleteUser(t.Context(), user.Id))
+
+			reusing := helpers.GenUser()
+			reusing.Username = user.Username
+			reusing.Email = user.Email
+			_, err = underTest.UpsertUser(t.Context(), reusing)
+			assert.NoError(t, err)
+		})
+	})
+
+	t.Run("GetDeletedUserById", func(t *testing.T) {
+		t.Parallel()
+
+		t.Run("should return none for a user that is not deleted", func(t *testing.T) {
+			t.Parallel()
+			user := helpers.UpsertUsers(t, underTest, 1)[0]
+
+			fromDb, err := underTest.GetDeletedUserById(t.Context(), user.Id, time.Now().Add(-time.Hour))
+			assert.NoError(t, err)
+			assert.True(t, fromDb.IsNone())
+		})
+
+		t.Run("should only return users deleted after the cutoff", func(t *testing.T) {
+			t.Parallel()
+			user := helpers.UpsertUsers(t, underTest, 1)[0]
+			assert.NoError(t, underTest.DeleteUser(t.Context(), user.Id))
+
+			fromDb, err := underTest.GetDeletedUserById(t.Context(), user.Id, time.Now().Add(-time.Hour))
+			assert.NoError(t, err)
+			assert.Equal(t, mo.Some(user), fromDb)
+
+			fromDb, err = underTest.GetDeletedUserById(t.Context(), user.Id, time.Now().Add(time.Hour))
+			assert.NoError(t, err)
+			assert.True(t, fromDb.IsNone())
+		})
+	})
+
+	t.Run("RestoreUser", func(t *testing.T) {
+		t.Parallel()
+
+		t.Run("should make a deleted user readable again", func(t *testing.T) {
+			t.Parallel()
+			user := helpers.UpsertUsers(t, underTest, 1)[0]
+			assert.NoError(t, underTest.DeleteUser(t.Context(), user.Id))
+			assert.NoError(t, underTest.RestoreUser(t.Context(), user.Id))
+
+			fromDb, err := underTest.GetUserByUsername(t.Context(), user.Username)
+			assert.NoError(t, err)
+			assert.Equal(t, mo.Some(user), fromDb)
+		})
+	})
+
+	t.Run("PurgeDeletedUsers", func(t *testing.T) {
+		t.Parallel()
+
+		t.Run("should hard delete users deleted before the cutoff", func(t *testing.T) {
+			t.Parallel()
+			users := helpers.UpsertUsers(t, underTest, 2)
+			assert.NoError(t, underTest.DeleteUser(t.Context(), users[0].Id))
+
+			purged, err := underTest.PurgeDeletedUsers(t.Context(), time.Now().Add(time.Hour))
+			assert.NoError(t, err)
+			assert.GreaterOrEqual(t, purged, int64(1))
+
+			fromDb, err := underTest.GetDeletedUserById(t.Context(), users[0].Id, time.Time{})
+			assert.NoError(t, err)
+			assert.True(t, fromDb.IsNone())
+
+			// active users are never purged
+			active, err := underTest.GetUserById(t.Context(), users[1].Id)
+			assert.NoError(t, err)
+			assert.True(t, active.IsSome())
+		})
+	})
+
+	t.Run("PurgeDeletedUsers cascade", func(t *testing.T) {
+		t.Parallel()
+
+		t.Run("should remove the follows, blocks and mutes of purged users", func(t *testing.T) {
+			t.Parallel()
+			users := helpers.UpsertUsers(t, underTest, 3)
+			require.NoError(t, underTest.Follow(t.Context(), users[1].Id, users[0].Id))
+			require.NoError(t, underTest.Block(t.Context(), users[0].Id, users[2].Id))
+			require.NoError(t, underTest.Mute(t.Context(), users[1].Id, users[0].Id))
+			require.NoError(t, underTest.DeleteUser(t.Context(), users[0].Id))
+
+			_, err := underTest.PurgeDeletedUsers(t.Context(), time.Now().Add(time.Hour))
+			require.NoError(t, err)
+
+			isFollowing, err := underTest.IsFollowing(t.Context(), users[1].Id, users[0].Id)
+			assert.NoError(t, err)
+			assert.False(t, isFollowing)
+
+			isBlocking, err := underTest.IsBlocking(t.Context(), users[0].Id, users[2].Id)
+			assert.NoError(t, err)
+			assert.False(t, isBlocking)
+
+			hidden, err := underTest.ListHiddenUserIds(t.Context(), users[1].Id)
+			assert.NoError(t, err)
+			assert.Empty(t, hidden)
+		})
+	})
+
+	t.Run("GetUserById", func(t *testing.T) {
+		t.Parallel()
+
+		t.Run("should return none for non-existing user", func(t *testing.T) {
+			t.Parallel()
+
+			result, err := underTest.GetUserById(t.Context(), uuid.New())
+			assert.NoError(t, err)
+			assert.True(t, result.IsNone())
+		})
+
+		t.Run("should return existing user", func(t *testing.T) {
+			t.Parallel()
+
+			user := helpers.GenUser()
+			_, err := underTest.UpsertUser(t.Context(), user)
+			assert.NoError(t, err)
+
+			fromDb, err := underTest.GetUserById(t.Context(), user.Id)
+			assert.NoError(t, err)
+			assert.True(t, fromDb.IsSome())
+			assert.Equal(t, user, fromDb.MustGet())
+		})
+	})
+
+	t.Run("GetUsersByIds", func(t *testing.T) {
+		t.Parallel()
+
+		t.Run("should return an empty result for no ids", func(t *testing.T) {
+			t.Parallel()
+
+			result, err := underTest.GetUsersByIds(t.Context(), nil)
+			assert.NoError(t, err)
+			assert.Empty(t, result)
+		})
+
+		t.Run("should return the existing active users and omit the rest", func(t *testing.T) {
+			t.Parallel()
+			users := helpers.UpsertUsers(t, underTest, 3)
+			require.NoError(t, underTest.DeleteUser(t.Context(), users[2].Id))
+
+			result, err := underTest.GetUsersByIds(t.Context(), []uuid.UUID{users[0].Id, users[1].Id, users[2].Id, uuid.New()})
+			assert.NoError(t, err)
+			assert.ElementsMatch(t, []user_types.User{users[0], users[1]}, result)
+		})
+	})
+
+	t.Run("GetUserByUsername", func(t *testing.T) {
+		t.Parallel()
+
+		t.Run("should return none for non-existing user", func(t *testing.T) {
+			t.Parallel()
+
+			result, err := underTest.GetUserByUsername(t.Context(), "nonexistinguser")
+			assert.NoError(t, err)
+			assert.True(t, result.IsNone())
+		})
+
+		t.Run("should return existing user", func(t *testing.T) {
+			t.Parallel()
+
+			user := helpers.GenUser()
+			created, err := underTest.UpsertUser(t.Context(), user)
+			assert.NoError(t, err)
+
+			fromDb, err := underTest.GetUserByUsername(t.Context(), created.Username)
+			assert.NoError(t, err)
+			assert.True(t, fromDb.IsSome())
+			assert.Equal(t, user, fromDb.MustGet())
+		})
+	})
+
+	t.Run("GetUserByEmail", func(t *testing.T) {
+		t.Parallel()
+
+		t.Run("should return none for non-existing user", func(t *testing.T) {
+			t.Parallel()
+
+			result, err := underTest.GetUserByEmail(t.Context(), "nonexisting@example.com")
+			assert.NoError(t, err)
+			assert.True(t, result.IsNone())
+		})
+
+		t.Run("should return existing user", func(t *testing.T) {
+			t.Parallel()
+
+			user := helpers.GenUser()
+			_, err := underTest.UpsertUser(t.Context(), user)
+			assert.NoError(t, err)
+
+			fromDb, err := underTest.GetUserByEmail(t.Context(), user.Email)
+			assert.NoError(t, err)
+			assert.True(t, fromDb.IsSome())
+			assert.Equal(t, user, fromDb.MustGet())
+		})
+	})
+
+	t.Run("IsFollowing", func(t *testing.T) {
+		t.Parallel()
+
+		t.Run("should return false when not following", func(t *testing.T) {
+			t.Parallel()
+			users := helpers.UpsertUsers(t, underTest, 2)
+
+			isFollowing, err := underTest.IsFollowing(t.Context(), users[0].Id, users[1].Id)
+			assert.NoError(t, err)
+			assert.False(t, isFollowing)
+		})
+
+		t.Run("should return true when following", func(t *testing.T) {
+			t.Parallel()
+			users := helpers.UpsertUsers(t, underTest, 2)
+
+			err := underTest.Follow(t.Context(), users[0].Id, users[1].Id)
+			assert.NoError(t, err)
+
+			isFollowing, err := underTest.IsFollowing(t.Context(), users[0].Id, users[1].Id)
+			assert.NoError(t, err)
+			assert.True(t, isFollowing)
+		})
+	})
+
+	t.Run("AreFollowing", func(t *testing.T) {
+		t.Parallel()
+
+		t.Run("should return an entry for every id", func(t *testing.T) {
+			t.Parallel()
+			users := helpers.UpsertUsers(t, underTest, 4)
+			require.NoError(t, underTest.Follow(t.Context(), users[0].Id, users[1].Id))
+			require.NoError(t, underTest.Follow(t.Context(), users[0].Id, users[3].Id))
+			// a follow by someone else must not leak into the viewer's result
+			require.NoError(t, underTest.Follow(t.Context(), users[1].Id, users[2].Id))
+
+			unknownId := uuid.New()
+			result, err := underTest.AreFollowing(t.Context(), users[0].Id, []uuid.UUID{users[1].Id, users[2].Id, users[3].Id, unknownId})
+			assert.NoError(t, err)
+			assert.Equal(t, map[uuid.UUID]bool{
+				users[1].Id: true,
+				users[2].Id: false,
+				users[3].Id: true,
+				unknownId:   false,
+			}, result)
+		})
+
+		t.Run("should return an empty result for no ids", func(t *testing.T) {
+			t.Parallel()
+
+			result, err := underTest.AreFollowing(t.Context(), uuid.New(), nil)
+			assert.NoError(t, err)
+			assert.Empty(t, result)
+		})
+	})
+
+	t.Run("Follow", func(t *testing.T) {
+		t.Parallel()
+
+		t.Run("should be idempotent if already following", func(t *testing.T) {
+			t.Parallel()
+			users := helpers.UpsertUsers(t, underTest, 2)
+
+			err := underTest.Follow(t.Context(), users[0].Id, users[1].Id)
+			assert.NoError(t, err)
+
+			err = underTest.Follow(t.Context(), users[0].Id, users[1].Id)
+			assert.NoError(t, err)
+
+			isFollowing, err := underTest.IsFollowing(t.Context(), users[0].Id, users[1].Id)
+			assert.NoError(t, err)
+			assert.True(t, isFollowing)
+		})
+
+		t.Run("should not follow if either user has blocked the other", func(t *testing.T) {
+			t.Parallel()
+			users := helpers.UpsertUsers(t, underTest, 3)
+			assert.NoError(t, underTest.Block(t.Context(), users[0].Id, users[1].Id))
+			assert.NoError(t, underTest.Block(t.Context(), users[2].Id, users[0].Id))
+
+			err := underTest.Follow(t.Context(), users[0].Id, users[1].Id)
+			assert.ErrorIs(t, err, user_types.CannotFollowBlockedUserError{})
+
+			err = underTest.Follow(t.Context(), users[0].Id, users[2].Id)
+			assert.ErrorIs(t, err, user_types.CannotFollowBlockedUserError{})
+
+			isFollowing, err := underTest.IsFollowing(t.Context(), users[0].Id, users[1].Id)
+			assert.NoError(t, err)
+			assert.False(t, isFollowing)
+
+			isFollowing, err = underTest.IsFollowing(t.Context(), users[0].Id, users[2].Id)
+			assert.NoError(t, err)
+			assert.False(t, isFollowing)
+		})
+	})
+
+	t.Run("Follow constraints", func(t *testing.T) {
+		t.Parallel()
+
+		t.Run("should fail to follow a user that does not exist", func(t *testing.T) {
+			t.Parallel()
+			user := helpers.UpsertUsers(t, underTest, 1)[0]
+
+			assert.Error(t, underTest.Follow(t.Context(), user.Id, uuid.New()))
+		})
+
+		t.Run("should fail to follow yourself", func(t *testing.T) {
+			t.Parallel()
+			user := helpers.UpsertUsers(t, underTest, 1)[0]
+
+			assert.Error(t, underTest.Follow(t.Context(), user.Id, user.Id))
+		})
+	})
+
+	t.Run("Unfollow", func(t *testing.T) {
+		t.Parallel()
+
+		t.Run("should unfollow successfully", func(t *testing.T) {
+			t.Parallel()
+			users := helpers.UpsertUsers(t, underTest, 2)
+
+			err := underTest.Follow(t.Context(), users[0].Id, users[1].Id)
+			assert.NoError(t, err)
+
+			err = underTest.Unfollow(t.Context(), users[0].Id, users[1].Id)
+			assert.NoError(t, err)
+
+			isFollowing, err := underTest.IsFollowing(t.Context(), users[0].Id, users[1].Id)
+			assert.NoError(t, err)
+			assert.False(t, isFollowing)
+		})
+
+		t.Run("should be idempotent if already not followed", func(t *testing.T) {
+			t.Parallel()
+			users := helpers.UpsertUsers(t, underTest, 2)
+
+			err := underTest.Unfollow(t.Context(), users[0].Id, users[1].Id)
+			assert.NoError(t, err)
+
+			isFollowing, err := underTest.IsFollowing(t.Context(), users[0].Id, users[1].Id)
+			assert.NoError(t, err)
+			assert.False(t, isFollowing)
+		})
+	})
+
+	t.Run("Block", func(t *testing.T) {
+		t.Parallel()
+
+		t.Run("should block and remove follows in both directions", func(t *testing.T) {
+			t.Parallel()
+			users := helpers.UpsertUsers(t, underTest, 2)
+
+			assert.NoError(t, underTest.Follow(t.Context(), users[0].Id, users[1].Id))
+			assert.NoError(t, underTest.Follow(t.Context(), users[1].Id, users[0].Id))
+
+			err := underTest.Block(t.Context(), users[0].Id, users[1].Id)
+			assert.NoError(t, err)
+
+			isBlocking, err := underTest.IsBlocking(t.Context(), users[0].Id, users[1].Id)
+			assert.NoError(t, err)
+			assert.True(t, isBlocking)
+
+			isFollowing, err := underTest.IsFollowing(t.Context(), users[0].Id, users[1].Id)
+			assert.NoError(t, err)
+			assert.False(t, isFollowing)
+
+			isFollowing, err = underTest.IsFollowing(t.Context(), users[1].Id, users[0].Id)
+			assert.NoError(t, err)
+			assert.False(t, isFollowing)
+		})
+
+		t.Run("should be idempotent if already blocking", func(t *testing.T) {
+			t.Parallel()
+			users := helpers.UpsertUsers(t, underTest, 2)
+
+			assert.NoError(t, underTest.Block(t.Context(), users[0].Id, users[1].Id))
+			assert.NoError(t, underTest.Block(t.Context(), users[0].Id, users[1].Id))
+
+			isBlocking, err := underTest.IsBlocking(t.Context(), users[0].Id, users[1].Id)
+			assert.NoError(t, err)
+			assert.True(t, isBlocking)
+		})
+	})
+
+	t.Run("Unblock", func(t *testing.T) {
+		t.Parallel()
+
+		t.Run("should unblock successfully", func(t *testing.T) {
+			t.Parallel()
+			users := helpers.UpsertUsers(t, underTest, 2)
+
+			assert.NoError(t, underTest.Block(t.Context(), users[0].Id, users[1].Id))
+			assert.NoError(t, underTest.Unblock(t.Context(), users[0].Id, users[1].Id))
+
+			isBlocking, err := underTest.IsBlocking(t.Context(), users[0].Id, users[1].Id)
+			assert.NoError(t, err)
+			assert.False(t, isBlocking)
+		})
+	})
+
+	t.Run("IsBlockedEitherWay", func(t *testing.T) {
+		t.Parallel()
+
+		t.Run("should return false when neither user has blocked the other", func(t *testing.T) {
+			t.Parallel()
+			users := helpers.UpsertUsers(t, underTest, 2)
+
+			isBlocked, err := underTest.IsBlockedEitherWay(t.Context(), users[0].Id, users[1].Id)
+			assert.NoError(t, err)
+			assert.False(t, isBlocked)
+		})
+
+		t.Run("should return true in both directions", func(t *testing.T) {
+			t.Parallel()
+			users := helpers.UpsertUsers(t, underTest, 2)
+
+			assert.NoError(t, underTest.Block(t.Context(), users[1].Id, users[0].Id))
+
+			isBlocked, err := underTest.IsBlockedEitherWay(t.Context(), users[0].Id, users[1].Id)
+			assert.NoError(t, err)
+			assert.True(t, isBlocked)
+
+			isBlocked, err = underTest.IsBlockedEitherWay(t.Context(), users[1].Id, users[0].Id)
+			assert.NoError(t, err)
+			assert.True(t, isBlocked)
+		})
+	})
+
+	t.Run("Mute", func(t *testing.T) {
+		t.Parallel()
+
+		t.Run("should mute without affecting follows", func(t *testing.T) {
+			t.Parallel()
+			users := helpers.UpsertUsers(t, underTest, 2)
+
+			assert.NoError(t, underTest.Follow(t.Context(), users[0].Id, users[1].Id))
+			assert.NoError(t, underTest.Mute(t.Context(), users[0].Id, users[1].Id))
+			assert.NoError(t, underTest.Mute(t.Context(), users[0].Id, users[1].Id))
+
+			isMuting, err := underTest.IsMuting(t.Context(), users[0].Id, users[1].Id)
+			assert.NoError(t, err)
+			assert.True(t, isMuting)
+
+			isFollowing, err := underTest.IsFollowing(t.Context(), users[0].Id, users[1].Id)
+			assert.NoError(t, err)
+			assert.True(t, isFollowing)
+		})
+
+		t.Run("should unmute successfully", func(t *testing.T) {
+			t.Parallel()
+			users := helpers.UpsertUsers(t, underTest, 2)
+
+			assert.NoError(t, underTest.Mute(t.Context(), users[0].Id, users[1].Id))
+			assert.NoError(t, underTest.Unmute(t.Context(), users[0].Id, users[1].Id))
+
+			isMuting, err := underTest.IsMuting(t.Context(), users[0].Id, users[1].Id)
+			assert.NoError(t, err)
+			assert.False(t, isMuting)
+		})
+	})
+
+	t.Run("ListHiddenUserIds", func(t *testing.T) {
+		t.Parallel()
+
+		t.Run("should return blocked and muted users", func(t *testing.T) {
+			t.Parallel()
+			users := helpers.UpsertUsers(t, underTest, 4)
+
+			assert.NoError(t, underTest.Block(t.Context(), users[0].Id, users[1].Id))
+			assert.NoError(t, underTest.Mute(t.Context(), users[0].Id, users[2].Id))
+			// blocks made by other users are not hidden from the viewer
+			assert.NoError(t, underTest.Block(t.Context(), users[3].Id, users[0].Id))
+
+			ids, err := underTest.ListHiddenUserIds(t.Context(), users[0].Id)
+			assert.NoError(t, err)
+			assert.ElementsMatch(t, []uuid.UUID{users[1].Id, users[2].Id}, ids)
+		})
+	})
+}
@@ -2,12 +2,17 @@ package db_config_provider
 
 import (
 	"context"
+	"os"
 
 	"github.com/nimaeskandary/go-realworld/pkg/database"
 	"github.com/nimaeskandary/go-realworld/pkg/database/migrations/realworld_app"
 	db_types "github.com/nimaeskandary/go-realworld/pkg/database/types"
 )
 
+// TestDbDriverEnvVar selects the driver integration tests run against, postgres if unset. Set it to sqlite to run
+// them without the test postgres docker container
+const TestDbDriverEnvVar = "TEST_DB_DRIVER"
+
 // SqlDbConfigProvider - interface for providing SQL database configs, with the ability to cleanup after use.
 // The intention here, is to implement providers that create isolated databases on each GetFreshDbConfig call and return its config,
 // this allows for integration tests that rely on databases to run in parallel
@@ -19,8 +24,15 @@ type SqlDbConfigProvider interface {
 	Cleanup(ctx context.Context, dbconfig db_types.SqlDbConfig) error
 }
 
-// RealWorldAppDbConfigProvider - provides configs for a realworld app db
+// RealWorldAppDbConfigProvider - provides configs for a realworld app db, using the driver from TestDbDriverEnvVar
 func RealWorldAppDbConfigProvider() SqlDbConfigProvider {
+	if os.Getenv(TestDbDriverEnvVar) == db_types.DialectSqlite {
+		return NewSqliteSqlDbConfigProvider(
+			db_types.SqlDbConfig{Driver: db_types.DialectSqlite},
+			runRealWorldAppMigrations,
+		)
+	}
+
 	// config to hit test postgres docker container
 	cfg := db_types.RealWorldAppDbConfig{
 		Host:     "localhost",
@@ -31,21 +43,36 @@ func RealWorldAppDbConfigProvider() SqlDbConfigProvider {
 		SslMode:  "disable",
 	}
 
-	return NewPostgresSqlDbConfigProvider(
-		db_types.SqlDbConfig(cfg),
-		func(ctx context.Context, db db_types.SQLDatabase) error {
-			migrationRunner, err := database.NewGooseMigrationRunner(db, realworld_app.NewMigrationProvider())
-			if err != nil {
-				return err
-			}
-			err = migrationRunner.Start(ctx)
-			if err != nil {
-				return err
-			}
-			defer func() {
-				_ = migrationRunner.Stop(ctx)
-			}()
-			return migrationRunner.ApplyAll(ctx)
-		},
-	)
+	return NewPostgresSqlDbConfigProvider(db_types.SqlDbConfig(cfg), runRealWorldAppMigrations)
+}
+
+// NewSqlDbConfigProvider - provides configs for databases with the given migrations applied, using the driver from cfg.
+// For postgres, cfg.DBName names the template database
+func NewSqlDbConfigProvider(cfg db_types.SqlDbConfig, migrationsProvider db_types.MigrationsProvider) SqlDbConfigProvider {
+	runMigrationsFn := func(ctx context.Context, db db_types.SQLDatabase) error {
+		return runMigrations(ctx, db, migrationsProvider)
+	}
+	if cfg.Driver == db_types.DialectSqlite {
+		return NewSqliteSqlDbConfigProvider(cfg, runMigrationsFn)
+	}
+	return NewPostgresSqlDbConfigProvider(cfg, runMigrationsFn)
+}
+
+func runRealWorldAppMigrations(ctx context.Context, db db_types.SQLDatabase) error {
+	return runMigrations(ctx, db, realworld_app.NewMigrationProvider())
+}
+
+func runMigrations(ctx context.Context, db db_types.SQLDatabase, migrationsProvider db_types.MigrationsProvider) error {
+	migrationRunner, err := database.NewGooseMigrationRunner(db, migrationsProvider)
+	if err != nil {
+		return err
+	}
+	err = migrationRunner.Start(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = migrationRunner.Stop(ctx)
+	}()
+	return migrationRunner.ApplyAll(ctx)
 }
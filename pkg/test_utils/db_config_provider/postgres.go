@@ -226,7 +226,7 @@ func (p *postgresSqlDbConfigProvider) rebuildTemplate(ctx context.Context, mainD
 }
 
 func (p *postgresSqlDbConfigProvider) runTemplateMigrations(ctx context.Context, cfg db_types.SqlDbConfig) error {
-	tempDb, err := database.NewPostgresSQLDatabase(cfg)
+	tempDb, err := database.NewPostgresSQLDatabase(cfg, nil)
 	if err != nil {
 		return fmt.Errorf("failed to connect to temp template db: %v", err)
 	}
@@ -278,7 +278,7 @@ func cleanupExpiredTestDatabases(ctx context.Context, mainDb *sql.DB) error {
 func createPostgresMaintenanceConnection(ctx context.Context, cfg db_types.SqlDbConfig) (*sql.DB, error) {
 	mainCfg := cfg
 	mainCfg.DBName = "postgres"
-	db, err := database.NewPostgresSQLDatabase(mainCfg)
+	db, err := database.NewPostgresSQLDatabase(mainCfg, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to maintenance DB: %v", err)
 	}
@@ -0,0 +1,66 @@
+package db_config_provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/nimaeskandary/go-realworld/pkg/database"
+	"github.com/nimaeskandary/go-realworld/pkg/database/types"
+)
+
+// sqliteSqlDbConfigProvider implements SqlDbConfigProvider for sqlite databases. Each fresh database is a new file in
+// the temp dir with migrations applied, migrating a file is quick enough that there is no template to clone
+type sqliteSqlDbConfigProvider struct {
+	cfg             db_types.SqlDbConfig
+	runMigrationsFn func(ctx context.Context, db db_types.SQLDatabase) error
+}
+
+func NewSqliteSqlDbConfigProvider(
+	cfg db_types.SqlDbConfig,
+	runMigrationsFn func(ctx context.Context, db db_types.SQLDatabase) error,
+) SqlDbConfigProvider {
+	return &sqliteSqlDbConfigProvider{
+		cfg:             cfg,
+		runMigrationsFn: runMigrationsFn,
+	}
+}
+
+// GetFreshDbConfig creates a new database file and runs migrations on it. It is safe for concurrent use
+func (p *sqliteSqlDbConfigProvider) GetFreshDbConfig(ctx context.Context) (db_types.SqlDbConfig, error) {
+	file, err := os.CreateTemp("", "test_realworld_*.db")
+	if err != nil {
+		return db_types.SqlDbConfig{}, fmt.Errorf("failed to create sqlite database file: %v", err)
+	}
+	_ = file.Close()
+
+	newCfg := p.cfg
+	newCfg.SqlitePath = file.Name()
+
+	db, err := database.NewSqliteSQLDatabase(newCfg, nil)
+	if err != nil {
+		return db_types.SqlDbConfig{}, fmt.Errorf("failed to create sqlite database: %v", err)
+	}
+	err = db.Start(ctx)
+	if err != nil {
+		return db_types.SqlDbConfig{}, fmt.Errorf("failed to start sqlite database: %v", err)
+	}
+	defer func() { _ = db.Stop(ctx) }()
+
+	err = p.runMigrationsFn(ctx, db)
+	if err != nil {
+		return db_types.SqlDbConfig{}, fmt.Errorf("failed to run migrations on sqlite database: %v", err)
+	}
+
+	return newCfg, nil
+}
+
+// Cleanup removes the database file created with GetFreshDbConfig, along with its write ahead log
+func (p *sqliteSqlDbConfigProvider) Cleanup(_ context.Context, cfg db_types.SqlDbConfig) error {
+	for _, path := range []string{cfg.SqlitePath, cfg.SqlitePath + "-wal", cfg.SqlitePath + "-shm"} {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove sqlite database file %v: %v", path, err)
+		}
+	}
+	return nil
+}
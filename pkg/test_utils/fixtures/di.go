@@ -7,15 +7,26 @@ import (
 
 	"github.com/nimaeskandary/go-realworld/cmd/http_server/app/http_handler"
 	"github.com/nimaeskandary/go-realworld/pkg/article"
+	"github.com/nimaeskandary/go-realworld/pkg/audit"
 	"github.com/nimaeskandary/go-realworld/pkg/auth"
 	auth_types "github.com/nimaeskandary/go-realworld/pkg/auth/types"
+	"github.com/nimaeskandary/go-realworld/pkg/blob_store"
+	blob_store_types "github.com/nimaeskandary/go-realworld/pkg/blob_store/types"
+	"github.com/nimaeskandary/go-realworld/pkg/data_export"
 	"github.com/nimaeskandary/go-realworld/pkg/database"
 	db_types "github.com/nimaeskandary/go-realworld/pkg/database/types"
+	"github.com/nimaeskandary/go-realworld/pkg/media"
+	media_types "github.com/nimaeskandary/go-realworld/pkg/media/types"
 	obs "github.com/nimaeskandary/go-realworld/pkg/observability"
 	obs_types "github.com/nimaeskandary/go-realworld/pkg/observability/types"
+	"github.com/nimaeskandary/go-realworld/pkg/outbox"
+	outbox_types "github.com/nimaeskandary/go-realworld/pkg/outbox/types"
+	"github.com/nimaeskandary/go-realworld/pkg/soft_delete"
+	soft_delete_types "github.com/nimaeskandary/go-realworld/pkg/soft_delete/types"
 	"github.com/nimaeskandary/go-realworld/pkg/test_utils/config"
 	"github.com/nimaeskandary/go-realworld/pkg/test_utils/db_config_provider"
 	"github.com/nimaeskandary/go-realworld/pkg/user"
+	user_types "github.com/nimaeskandary/go-realworld/pkg/user/types"
 
 	"go.uber.org/fx"
 )
@@ -30,6 +41,34 @@ func AllTestModules(t *testing.T) ([]fx.Option, error) {
 }
 
 func TestModules() []fx.Option {
+	return append(
+		commonTestModules(),
+		user.NewUserModule(),
+		article.NewArticleModule(),
+		audit.NewAuditModule(),
+		data_export.NewDataExportModule(),
+		outbox.NewOutboxModule(),
+		soft_delete.NewSoftDeletePurgerModule(),
+	)
+}
+
+// InMemoryTestModules is TestModules with repositories kept in memory, it needs no test databases
+func InMemoryTestModules() []fx.Option {
+	return append(
+		commonTestModules(),
+		user.NewInMemoryUserModule(),
+		article.NewInMemoryArticleModule(),
+		audit.NewInMemoryAuditModule(),
+		data_export.NewInMemoryDataExportModule(),
+		outbox.NewInMemoryOutboxModule(),
+		database.NewRealworldAppInMemoryDbModule(),
+		database.NewRealworldAppInMemoryTxManagerModule(),
+		soft_delete.NewInMemorySoftDeletePurgerModule(),
+	)
+}
+
+// commonTestModules are the test modules that do not depend on how repositories are stored
+func commonTestModules() []fx.Option {
 	return []fx.Option{
 		fx.Provide(
 			func() config.Config {
@@ -41,12 +80,28 @@ func TestModules() []fx.Option {
 			func(c config.Config) auth_types.JwtAuthServiceConfig {
 				return c.JwtAuthService
 			},
+			func(c config.Config) soft_delete_types.SoftDeleteConfig {
+				return c.SoftDelete
+			},
+			func(c config.Config) outbox_types.OutboxConfig {
+				return c.Outbox
+			},
+			func(c config.Config) user_types.UserConfig {
+				return c.User
+			},
+			func(c config.Config) blob_store_types.LocalBlobStoreConfig {
+				return c.LocalBlobStore
+			},
+			func(c config.Config) media_types.MediaConfig {
+				return c.Media
+			},
 		),
 		auth.NewAuthModule(),
 		http_handler.NewHttpHandlerModule(),
 		obs.NewSlogLoggerModule(),
-		user.NewUserModule(),
-		article.NewArticleModule(),
+		blob_store.NewLocalBlobStoreModule(),
+		media.NewMediaModule(),
+		outbox.NewOutboxRelayModule(),
 	}
 }
 
@@ -73,12 +128,13 @@ func NewRealWorldAppDbTestModule(t *testing.T) (fx.Option, error) {
 
 	t.Cleanup(func() { _ = realWorldDatabaseProvider.Cleanup(context.Background(), realWorldDbCfg) })
 
-	return fx.Module("test_postgres_realworld_app_db",
+	return fx.Module("test_realworld_app_db",
 		fx.Provide(
 			func() db_types.RealWorldAppDbConfig {
 				return db_types.RealWorldAppDbConfig(realWorldDbCfg)
 			},
 		),
-		database.NewPostgresRealworldAppDbModule[db_types.PostgresRealWorldAppDb](),
+		database.NewRealworldAppDbModule[db_types.RealWorldAppDb](),
+		database.NewRealworldAppTxManagerModule(),
 	), nil
 }
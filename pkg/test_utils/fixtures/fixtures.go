@@ -6,7 +6,12 @@ import (
 
 	http_handler_types "github.com/nimaeskandary/go-realworld/cmd/http_server/app/http_handler/types"
 	article_types "github.com/nimaeskandary/go-realworld/pkg/article/types"
+	audit_types "github.com/nimaeskandary/go-realworld/pkg/audit/types"
 	auth_types "github.com/nimaeskandary/go-realworld/pkg/auth/types"
+	data_export_types "github.com/nimaeskandary/go-realworld/pkg/data_export/types"
+	db_types "github.com/nimaeskandary/go-realworld/pkg/database/types"
+	outbox_types "github.com/nimaeskandary/go-realworld/pkg/outbox/types"
+	soft_delete_types "github.com/nimaeskandary/go-realworld/pkg/soft_delete/types"
 	user_types "github.com/nimaeskandary/go-realworld/pkg/user/types"
 	"github.com/nimaeskandary/go-realworld/pkg/util"
 
@@ -15,12 +20,21 @@ import (
 )
 
 type StandardFixture struct {
-	ArticleRepo    article_types.ArticleRepository
-	ArticleService article_types.ArticleService
-	AuthService    auth_types.AuthService
-	HttpHandler    http_handler_types.HttpHandler
-	UserRepo       user_types.UserRepository
-	UserService    user_types.UserService
+	ArticleRepo      article_types.ArticleRepository
+	ArticleService   article_types.ArticleService
+	AuditLog         audit_types.AuditLog
+	AuditRepo        audit_types.AuditRepository
+	AuthService      auth_types.AuthService
+	DataExportRepo   data_export_types.DataExportRepository
+	Db               db_types.RealWorldAppDb
+	HttpHandler      http_handler_types.HttpHandler
+	Outbox           outbox_types.Outbox
+	OutboxRelay      outbox_types.OutboxRelay
+	OutboxRepo       outbox_types.OutboxRepository
+	SoftDeletePurger soft_delete_types.SoftDeletePurger
+	TxManager        db_types.TxManager
+	UserRepo         user_types.UserRepository
+	UserService      user_types.UserService
 }
 
 // SetupStandardFixture sets up a standard fixture with all dependencies injected.
@@ -34,24 +48,44 @@ func SetupStandardFixture(t *testing.T, overrides ...any) StandardFixture {
 	testModules, err := AllTestModules(t)
 	require.NoError(t, err)
 
+	f := StandardFixture{}
+	setupFixture(t, &f, testModules, overrides, &f.Db)
+	return f
+}
+
+// SetupInMemoryFixture is SetupStandardFixture with repositories kept in memory, it is cheap enough to set up per sub
+// test. There is no sql database, so Db is left nil
+func SetupInMemoryFixture(t *testing.T, overrides ...any) StandardFixture {
+	f := StandardFixture{}
+	setupFixture(t, &f, InMemoryTestModules(), overrides)
+	return f
+}
+
+func setupFixture(t *testing.T, f *StandardFixture, testModules []fx.Option, overrides []any, extract ...any) {
 	for _, constructorFn := range overrides {
 		testModules = append(testModules, fx.Decorate(constructorFn))
 	}
 
-	f := StandardFixture{}
-
 	fxApp := util.CreateFxAppAndExtract(
 		testModules,
-		&f.ArticleRepo,
-		&f.ArticleService,
-		&f.AuthService,
-		&f.HttpHandler,
-		&f.UserRepo,
-		&f.UserService,
+		append([]any{
+			&f.ArticleRepo,
+			&f.ArticleService,
+			&f.AuditLog,
+			&f.AuditRepo,
+			&f.AuthService,
+			&f.DataExportRepo,
+			&f.HttpHandler,
+			&f.Outbox,
+			&f.OutboxRelay,
+			&f.OutboxRepo,
+			&f.SoftDeletePurger,
+			&f.TxManager,
+			&f.UserRepo,
+			&f.UserService,
+		}, extract...)...,
 	)
 
 	require.NoError(t, fxApp.Start(t.Context()))
 	t.Cleanup(func() { _ = fxApp.Stop(context.Background()) })
-
-	return f
 }
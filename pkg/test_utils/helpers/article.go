@@ -1,6 +1,8 @@
 package helpers
 
 import (
+	"strings"
+
 	article_types "github.com/nimaeskandary/go-realworld/pkg/article/types"
 
 	"github.com/google/uuid"
@@ -12,7 +14,24 @@ func GenArticle(authorUserId uuid.UUID) article_types.Article {
 		AuthorUserId:    authorUserId,
 		Title:           "Test Article",
 		Description:     "This is a test article",
+		Body:            "The body of the test article",
 		CreatedAtMillis: now.UnixMilli(),
 		UpdatedAtMillis: now.UnixMilli(),
+		Version:         1,
 	}
 }
+
+// GenSearchWord returns a word of random letters, for text that only one test searches for
+func GenSearchWord() string {
+	// the hex digits of a uuid, shifted to letters
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= '0' && r <= '9':
+			return 'a' + r - '0'
+		case r >= 'a' && r <= 'f':
+			return 'k' + r - 'a'
+		default:
+			return -1
+		}
+	}, uuid.NewString())
+}
@@ -0,0 +1,59 @@
+package helpers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/nimaeskandary/go-realworld/pkg/api_gen"
+	"github.com/nimaeskandary/go-realworld/pkg/auth/types"
+	"github.com/nimaeskandary/go-realworld/pkg/user/types"
+
+	"github.com/samber/mo"
+	"github.com/stretchr/testify/require"
+)
+
+func UpdateArticleRequest(t *testing.T, authService auth_types.AuthService, authUser user_types.User, slug string, updateArticle api_gen.UpdateArticle) *http.Request {
+	body, err := json.Marshal(
+		api_gen.UpdateArticleJSONRequestBody{
+			Article: updateArticle,
+		})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPut, "/articles/"+slug, bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	return WithAuthHeader(t, authService, authUser, req)
+}
+
+func SearchArticlesRequest(
+	t *testing.T,
+	authService auth_types.AuthService,
+	authUser mo.Option[user_types.User],
+	params api_gen.SearchArticlesParams) *http.Request {
+	query := url.Values{}
+	query.Set("q", params.Q)
+	if params.Tag != nil {
+		query.Set("tag", *params.Tag)
+	}
+	if params.Author != nil {
+		query.Set("author", *params.Author)
+	}
+	if params.Offset != nil {
+		query.Set("offset", strconv.Itoa(*params.Offset))
+	}
+	if params.Limit != nil {
+		query.Set("limit", strconv.Itoa(*params.Limit))
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/articles/search?"+query.Encode(), nil)
+
+	if authUser.IsSome() {
+		return WithAuthHeader(t, authService, authUser.MustGet(), req)
+	}
+
+	return req
+}
@@ -0,0 +1,25 @@
+package helpers
+
+import (
+	"encoding/json"
+	"time"
+
+	audit_types "github.com/nimaeskandary/go-realworld/pkg/audit/types"
+
+	"github.com/google/uuid"
+	"github.com/samber/mo"
+)
+
+// GenAuditEntry returns an update of the entity by a new actor, made at createdAt
+func GenAuditEntry(entityType audit_types.AuditEntityType, entityId string, createdAt time.Time) audit_types.AuditEntry {
+	return audit_types.AuditEntry{
+		Id:              uuid.New(),
+		ActorUserId:     mo.Some(uuid.New()),
+		Action:          audit_types.AuditActionUpdate,
+		EntityType:      entityType,
+		EntityId:        entityId,
+		Before:          json.RawMessage(`{"title":"before"}`),
+		After:           json.RawMessage(`{"title":"after"}`),
+		CreatedAtMillis: createdAt.UnixMilli(),
+	}
+}
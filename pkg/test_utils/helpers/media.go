@@ -0,0 +1,39 @@
+package helpers
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// GenImage encodes a width x height gradient in the given format, one of "png", "jpeg" or "gif"
+func GenImage(t *testing.T, format string, width int, height int) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := range height {
+		for x := range width {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 128, A: 255})
+		}
+	}
+
+	buf := new(bytes.Buffer)
+	var err error
+	switch format {
+	case "png":
+		err = png.Encode(buf, img)
+	case "jpeg":
+		err = jpeg.Encode(buf, img, nil)
+	case "gif":
+		err = gif.Encode(buf, img, nil)
+	default:
+		t.Fatalf("unsupported image format: %v", format)
+	}
+	require.NoError(t, err)
+
+	return buf.Bytes()
+}
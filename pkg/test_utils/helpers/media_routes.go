@@ -0,0 +1,41 @@
+package helpers
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/nimaeskandary/go-realworld/pkg/auth/types"
+	"github.com/nimaeskandary/go-realworld/pkg/user/types"
+
+	"github.com/stretchr/testify/require"
+)
+
+func UploadCurrentUserAvatarRequest(t *testing.T, authService auth_types.AuthService, authUser user_types.User, image []byte) *http.Request {
+	return uploadImageRequest(t, authService, authUser, "/user/avatar", image)
+}
+
+func UploadArticleImageRequest(t *testing.T, authService auth_types.AuthService, authUser user_types.User, image []byte) *http.Request {
+	return uploadImageRequest(t, authService, authUser, "/media/articles", image)
+}
+
+func GetMediaRequest(url string) *http.Request {
+	// this is an unauthorized route
+	return httptest.NewRequest(http.MethodGet, url, nil)
+}
+
+func uploadImageRequest(t *testing.T, authService auth_types.AuthService, authUser user_types.User, path string, image []byte) *http.Request {
+	body := new(bytes.Buffer)
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile("image", "upload")
+	require.NoError(t, err)
+	_, err = part.Write(image)
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	req := httptest.NewRequest(http.MethodPost, path, body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	return WithAuthHeader(t, authService, authUser, req)
+}
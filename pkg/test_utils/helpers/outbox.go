@@ -0,0 +1,22 @@
+package helpers
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	outbox_types "github.com/nimaeskandary/go-realworld/pkg/outbox/types"
+
+	"github.com/google/uuid"
+)
+
+// GenOutboxEvent returns an event of the type created at createdAt, which is when it is first due
+func GenOutboxEvent(eventType string, createdAt time.Time) outbox_types.OutboxEvent {
+	id := uuid.New()
+	return outbox_types.OutboxEvent{
+		Id:              id,
+		Type:            eventType,
+		Payload:         json.RawMessage(fmt.Sprintf(`{"id":"%v"}`, id)),
+		CreatedAtMillis: createdAt.UnixMilli(),
+	}
+}
@@ -56,3 +56,43 @@ func FollowUserByUsernameRequest(
 	req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/profiles/%v/follow", targetUsername), nil)
 	return WithAuthHeader(t, authService, authUser, req)
 }
+
+func BlockUserByUsernameRequest(
+	t *testing.T,
+	authService auth_types.AuthService,
+	authUser user_types.User,
+	targetUsername string) *http.Request {
+
+	req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/profiles/%v/block", targetUsername), nil)
+	return WithAuthHeader(t, authService, authUser, req)
+}
+
+func UnblockUserByUsernameRequest(
+	t *testing.T,
+	authService auth_types.AuthService,
+	authUser user_types.User,
+	targetUsername string) *http.Request {
+
+	req := httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/profiles/%v/block", targetUsername), nil)
+	return WithAuthHeader(t, authService, authUser, req)
+}
+
+func MuteUserByUsernameRequest(
+	t *testing.T,
+	authService auth_types.AuthService,
+	authUser user_types.User,
+	targetUsername string) *http.Request {
+
+	req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/profiles/%v/mute", targetUsername), nil)
+	return WithAuthHeader(t, authService, authUser, req)
+}
+
+func UnmuteUserByUsernameRequest(
+	t *testing.T,
+	authService auth_types.AuthService,
+	authUser user_types.User,
+	targetUsername string) *http.Request {
+
+	req := httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/profiles/%v/mute", targetUsername), nil)
+	return WithAuthHeader(t, authService, authUser, req)
+}
@@ -22,6 +22,7 @@ func GenUser() user_types.User {
 		Image:           mo.Some("https://example.com/testuser.jpg"),
 		CreatedAtMillis: now.UnixMilli(),
 		UpdatedAtMillis: now.UnixMilli(),
+		Version:         1,
 	}
 }
 
@@ -34,6 +35,7 @@ func GenUserWithUpsertParams(params user_types.UpsertUserParams) user_types.User
 		Image:           params.Image,
 		CreatedAtMillis: now.UnixMilli(),
 		UpdatedAtMillis: now.UnixMilli(),
+		Version:         1,
 	}
 }
 
@@ -63,3 +65,15 @@ func CreateUsers(t *testing.T, userService user_types.UserService, n int) []user
 	}
 	return users
 }
+
+// UpsertUsers is CreateUsers for tests of a UserRepository, the users are written with the repository directly
+func UpsertUsers(t *testing.T, userRepo user_types.UserRepository, n int) []user_types.User {
+	users := make([]user_types.User, n)
+	for i := range n {
+		createdUser, err := userRepo.UpsertUser(t.Context(), GenUser())
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = userRepo.DeleteUser(context.Background(), createdUser.Id) })
+		users[i] = createdUser
+	}
+	return users
+}
@@ -19,6 +19,11 @@ func GetCurrentUserRequest(t *testing.T, authService auth_types.AuthService, aut
 	return WithAuthHeader(t, authService, authUser, req)
 }
 
+func ExportCurrentUserDataRequest(t *testing.T, authService auth_types.AuthService, authUser user_types.User) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, "/user/export", nil)
+	return WithAuthHeader(t, authService, authUser, req)
+}
+
 func UpdateCurrentUserRequest(t *testing.T, authService auth_types.AuthService, authUser user_types.User, updateUser api_gen.UpdateUser) *http.Request {
 	body, err := json.Marshal(
 		api_gen.UpdateUserRequest{
@@ -0,0 +1,458 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/nimaeskandary/go-realworld/pkg/database/types"
+	"github.com/nimaeskandary/go-realworld/pkg/user/types"
+
+	"github.com/google/uuid"
+	"github.com/samber/mo"
+)
+
+// inMemoryUserRepo is the in memory counterpart of postgresUserRepo. Each method checks what the schema's
+// constraints would before writing anything, so a failed write leaves the tables as they were
+type inMemoryUserRepo struct {
+	db db_types.RealWorldAppInMemoryDb
+}
+
+func NewInMemoryUserRepository(db db_types.RealWorldAppInMemoryDb) user_types.UserRepository {
+	return &inMemoryUserRepo{db: db}
+}
+
+func (r *inMemoryUserRepo) UpsertUser(ctx context.Context, user user_types.User) (user_types.User, error) {
+	var result db_types.InMemoryUser
+	err := r.db.Write(ctx, func(tables *db_types.InMemoryTables) error {
+		existing, exists := tables.Users[user.Id]
+		if exists && existing.Version != user.Version {
+			return user_types.VersionConflictError{Identifier: user.Id.String()}
+		}
+
+		// only active users hold their username and email, matching the partial unique indexes
+		if !exists || existing.DeletedAt.IsAbsent() {
+			if err := checkUnique(tables, user.Id, user.Username, user.Email); err != nil {
+				return err
+			}
+		}
+
+		updatedAt := time.UnixMilli(user.UpdatedAtMillis)
+		if !exists {
+			result = db_types.InMemoryUser{
+				Id:        user.Id,
+				Username:  user.Username,
+				Email:     user.Email,
+				Bio:       user.Bio,
+				Image:     user.Image,
+				CreatedAt: time.UnixMilli(user.CreatedAtMillis),
+				UpdatedAt: updatedAt,
+				Version:   1,
+			}
+			tables.Users[user.Id] = result
+			return nil
+		}
+
+		// a change in case only is not a rename. Renaming away from a name a second time only moves its changed_at
+		if !strings.EqualFold(existing.Username, user.Username) {
+			key := db_types.InMemoryUsernameHistoryKey{UserId: user.Id, LowerUsername: strings.ToLower(existing.Username)}
+			history, ok := tables.UsernameHistory[key]
+			if !ok {
+				history.Username = existing.Username
+			}
+			history.ChangedAt = updatedAt
+			tables.UsernameHistory[key] = history
+		}
+
+		result = existing
+		result.Username = user.Username
+		result.Email = user.Email
+		result.Bio = user.Bio
+		result.Image = user.Image
+		result.UpdatedAt = updatedAt
+		result.Version = existing.Version + 1
+		tables.Users[user.Id] = result
+		return nil
+	})
+	if err != nil {
+		return user_types.User{}, err
+	}
+
+	return fromInMemoryUser(result), nil
+}
+
+func (r *inMemoryUserRepo) DeleteUser(ctx context.Context, id uuid.UUID) error {
+	return r.db.Write(ctx, func(tables *db_types.InMemoryTables) error {
+		user, ok := tables.Users[id]
+		if !ok || user.DeletedAt.IsPresent() {
+			return nil
+		}
+		user.DeletedAt = mo.Some(time.Now())
+		tables.Users[id] = user
+		return nil
+	})
+}
+
+func (r *inMemoryUserRepo) GetDeletedUserById(ctx context.Context, id uuid.UUID, deletedAfter time.Time) (mo.Option[user_types.User], error) {
+	return r.getOne(ctx, func(tables *db_types.InMemoryTables) mo.Option[db_types.InMemoryUser] {
+		user, ok := tables.Users[id]
+		if !ok || !user.DeletedAt.IsPresent() || !user.DeletedAt.MustGet().After(deletedAfter) {
+			return mo.None[db_types.InMemoryUser]()
+		}
+		return mo.Some(user)
+	})
+}
+
+func (r *inMemoryUserRepo) RestoreUser(ctx context.Context, id uuid.UUID) error {
+	return r.db.Write(ctx, func(tables *db_types.InMemoryTables) error {
+		user, ok := tables.Users[id]
+		if !ok || !user.DeletedAt.IsPresent() {
+			return nil
+		}
+
+		if err := checkUnique(tables, user.Id, user.Username, user.Email); err != nil {
+			return err
+		}
+
+		user.DeletedAt = mo.None[time.Time]()
+		tables.Users[id] = user
+		return nil
+	})
+}
+
+func (r *inMemoryUserRepo) PurgeDeletedUsers(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	var purged int64
+	err := r.db.Write(ctx, func(tables *db_types.InMemoryTables) error {
+		for id, user := range tables.Users {
+			if deletedAt, ok := user.DeletedAt.Get(); ok && !deletedAt.After(deletedBefore) {
+				tables.DeleteUser(id)
+				purged++
+			}
+		}
+		return nil
+	})
+	return purged, err
+}
+
+func (r *inMemoryUserRepo) GetUserByUsername(ctx context.Context, username string) (mo.Option[user_types.User], error) {
+	return r.getOne(ctx, func(tables *db_types.InMemoryTables) mo.Option[db_types.InMemoryUser] {
+		for _, user := range activeUsers(tables) {
+			if strings.EqualFold(user.Username, username) {
+				return mo.Some(user)
+			}
+		}
+		return mo.None[db_types.InMemoryUser]()
+	})
+}
+
+func (r *inMemoryUserRepo) GetUserByPreviousUsername(ctx context.Context, username string) (mo.Option[user_types.User], error) {
+	return r.getOne(ctx, func(tables *db_types.InMemoryTables) mo.Option[db_types.InMemoryUser] {
+		latest := mo.None[db_types.InMemoryUser]()
+		var latestChangedAt time.Time
+		for key, history := range tables.UsernameHistory {
+			if key.LowerUsername != strings.ToLower(username) {
+				continue
+			}
+			user, ok := tables.Users[key.UserId]
+			if !ok || user.DeletedAt.IsPresent() {
+				continue
+			}
+			if latest.IsNone() || history.ChangedAt.After(latestChangedAt) {
+				latest = mo.Some(user)
+				latestChangedAt = history.ChangedAt
+			}
+		}
+		return latest
+	})
+}
+
+func (r *inMemoryUserRepo) IsUsernameReserved(ctx context.Context, username string, claimantUserId uuid.UUID, changedAfter time.Time) (bool, error) {
+	var reserved bool
+	err := r.db.Read(ctx, func(tables *db_types.InMemoryTables) error {
+		for key, history := range tables.UsernameHistory {
+			if key.LowerUsername != strings.ToLower(username) || key.UserId == claimantUserId || !history.ChangedAt.After(changedAfter) {
+				continue
+			}
+			// a deleted user's names are released along with their current username
+			if user, ok := tables.Users[key.UserId]; ok && user.DeletedAt.IsAbsent() {
+				reserved = true
+				return nil
+			}
+		}
+		return nil
+	})
+	return reserved, err
+}
+
+func (r *inMemoryUserRepo) GetUserById(ctx context.Context, id uuid.UUID) (mo.Option[user_types.User], error) {
+	return r.getOne(ctx, func(tables *db_types.InMemoryTables) mo.Option[db_types.InMemoryUser] {
+		user, ok := tables.Users[id]
+		if !ok || user.DeletedAt.IsPresent() {
+			return mo.None[db_types.InMemoryUser]()
+		}
+		return mo.Some(user)
+	})
+}
+
+func (r *inMemoryUserRepo) GetUsersByIds(ctx context.Context, ids []uuid.UUID) ([]user_types.User, error) {
+	users := []user_types.User{}
+	err := r.db.Read(ctx, func(tables *db_types.InMemoryTables) error {
+		seen := make(map[uuid.UUID]bool, len(ids))
+		for _, id := range ids {
+			user, ok := tables.Users[id]
+			if !ok || user.DeletedAt.IsPresent() || seen[id] {
+				continue
+			}
+			seen[id] = true
+			users = append(users, fromInMemoryUser(user))
+		}
+		return nil
+	})
+	return users, err
+}
+
+func (r *inMemoryUserRepo) GetUserByEmail(ctx context.Context, email string) (mo.Option[user_types.User], error) {
+	return r.getOne(ctx, func(tables *db_types.InMemoryTables) mo.Option[db_types.InMemoryUser] {
+		for _, user := range activeUsers(tables) {
+			if strings.EqualFold(user.Email, email) {
+				return mo.Some(user)
+			}
+		}
+		return mo.None[db_types.InMemoryUser]()
+	})
+}
+
+func (r *inMemoryUserRepo) IsFollowing(ctx context.Context, followedByUserId uuid.UUID, followingUserId uuid.UUID) (bool, error) {
+	return r.hasPair(ctx, func(tables *db_types.InMemoryTables) map[db_types.InMemoryUserPair]time.Time {
+		return tables.UserFollowers
+	}, followedByUserId, followingUserId)
+}
+
+func (r *inMemoryUserRepo) AreFollowing(ctx context.Context, followedByUserId uuid.UUID, followingUserIds []uuid.UUID) (map[uuid.UUID]bool, error) {
+	following := make(map[uuid.UUID]bool, len(followingUserIds))
+	err := r.db.Read(ctx, func(tables *db_types.InMemoryTables) error {
+		for _, id := range followingUserIds {
+			_, following[id] = tables.UserFollowers[db_types.InMemoryUserPair{From: followedByUserId, To: id}]
+		}
+		return nil
+	})
+	return following, err
+}
+
+func (r *inMemoryUserRepo) Follow(ctx context.Context, followedByUserId uuid.UUID, followingUserId uuid.UUID) error {
+	err := r.db.Write(ctx, func(tables *db_types.InMemoryTables) error {
+		pair := db_types.InMemoryUserPair{From: followedByUserId, To: followingUserId}
+		if err := checkPair(tables, pair); err != nil {
+			return err
+		}
+
+		// checked under the same lock as the insert, like the guarded insert of the sql repositories
+		_, blocked := tables.UserBlocks[pair]
+		_, blockedBy := tables.UserBlocks[db_types.InMemoryUserPair{From: followingUserId, To: followedByUserId}]
+		if blocked || blockedBy {
+			return user_types.CannotFollowBlockedUserError{}
+		}
+
+		if _, ok := tables.UserFollowers[pair]; !ok {
+			tables.UserFollowers[pair] = time.Now()
+		}
+		return nil
+	})
+	if err != nil {
+		if _, ok := errors.AsType[user_types.CannotFollowBlockedUserError](err); ok {
+			return err
+		}
+		return fmt.Errorf("error executing follow query: %w", err)
+	}
+	return nil
+}
+
+func (r *inMemoryUserRepo) Unfollow(ctx context.Context, followedByUserId uuid.UUID, followingUserId uuid.UUID) error {
+	return r.db.Write(ctx, func(tables *db_types.InMemoryTables) error {
+		delete(tables.UserFollowers, db_types.InMemoryUserPair{From: followedByUserId, To: followingUserId})
+		return nil
+	})
+}
+
+func (r *inMemoryUserRepo) Block(ctx context.Context, blockedByUserId uuid.UUID, blockedUserId uuid.UUID) error {
+	err := r.db.Write(ctx, func(tables *db_types.InMemoryTables) error {
+		pair := db_types.InMemoryUserPair{From: blockedByUserId, To: blockedUserId}
+		if err := checkPair(tables, pair); err != nil {
+			return err
+		}
+
+		delete(tables.UserFollowers, pair)
+		delete(tables.UserFollowers, db_types.InMemoryUserPair{From: blockedUserId, To: blockedByUserId})
+		if _, ok := tables.UserBlocks[pair]; !ok {
+			tables.UserBlocks[pair] = time.Now()
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("error executing block query: %w", err)
+	}
+	return nil
+}
+
+func (r *inMemoryUserRepo) Unblock(ctx context.Context, blockedByUserId uuid.UUID, blockedUserId uuid.UUID) error {
+	return r.db.Write(ctx, func(tables *db_types.InMemoryTables) error {
+		delete(tables.UserBlocks, db_types.InMemoryUserPair{From: blockedByUserId, To: blockedUserId})
+		return nil
+	})
+}
+
+func (r *inMemoryUserRepo) IsBlocking(ctx context.Context, blockedByUserId uuid.UUID, blockedUserId uuid.UUID) (bool, error) {
+	return r.hasPair(ctx, func(tables *db_types.InMemoryTables) map[db_types.InMemoryUserPair]time.Time {
+		return tables.UserBlocks
+	}, blockedByUserId, blockedUserId)
+}
+
+func (r *inMemoryUserRepo) IsBlockedEitherWay(ctx context.Context, userIdA uuid.UUID, userIdB uuid.UUID) (bool, error) {
+	var blocked bool
+	err := r.db.Read(ctx, func(tables *db_types.InMemoryTables) error {
+		_, aBlockedB := tables.UserBlocks[db_types.InMemoryUserPair{From: userIdA, To: userIdB}]
+		_, bBlockedA := tables.UserBlocks[db_types.InMemoryUserPair{From: userIdB, To: userIdA}]
+		blocked = aBlockedB || bBlockedA
+		return nil
+	})
+	return blocked, err
+}
+
+func (r *inMemoryUserRepo) Mute(ctx context.Context, mutedByUserId uuid.UUID, mutedUserId uuid.UUID) error {
+	err := r.addPair(ctx, func(tables *db_types.InMemoryTables) map[db_types.InMemoryUserPair]time.Time {
+		return tables.UserMutes
+	}, mutedByUserId, mutedUserId)
+	if err != nil {
+		return fmt.Errorf("error executing mute query: %w", err)
+	}
+	return nil
+}
+
+func (r *inMemoryUserRepo) Unmute(ctx context.Context, mutedByUserId uuid.UUID, mutedUserId uuid.UUID) error {
+	return r.db.Write(ctx, func(tables *db_types.InMemoryTables) error {
+		delete(tables.UserMutes, db_types.InMemoryUserPair{From: mutedByUserId, To: mutedUserId})
+		return nil
+	})
+}
+
+func (r *inMemoryUserRepo) IsMuting(ctx context.Context, mutedByUserId uuid.UUID, mutedUserId uuid.UUID) (bool, error) {
+	return r.hasPair(ctx, func(tables *db_types.InMemoryTables) map[db_types.InMemoryUserPair]time.Time {
+		return tables.UserMutes
+	}, mutedByUserId, mutedUserId)
+}
+
+func (r *inMemoryUserRepo) ListHiddenUserIds(ctx context.Context, viewerUserId uuid.UUID) ([]uuid.UUID, error) {
+	ids := []uuid.UUID{}
+	err := r.db.Read(ctx, func(tables *db_types.InMemoryTables) error {
+		hidden := map[uuid.UUID]bool{}
+		for _, pairs := range []map[db_types.InMemoryUserPair]time.Time{tables.UserBlocks, tables.UserMutes} {
+			for pair := range pairs {
+				if pair.From == viewerUserId && !hidden[pair.To] {
+					hidden[pair.To] = true
+					ids = append(ids, pair.To)
+				}
+			}
+		}
+		return nil
+	})
+	return ids, err
+}
+
+// getOne reads a single user, selected by find
+func (r *inMemoryUserRepo) getOne(ctx context.Context, find func(tables *db_types.InMemoryTables) mo.Option[db_types.InMemoryUser]) (mo.Option[user_types.User], error) {
+	var found mo.Option[db_types.InMemoryUser]
+	err := r.db.Read(ctx, func(tables *db_types.InMemoryTables) error {
+		found = find(tables)
+		return nil
+	})
+	if err != nil {
+		return mo.None[user_types.User](), err
+	}
+
+	user, ok := found.Get()
+	if !ok {
+		return mo.None[user_types.User](), nil
+	}
+	return mo.Some(fromInMemoryUser(user)), nil
+}
+
+// hasPair returns true if the pair is in the table
+func (r *inMemoryUserRepo) hasPair(ctx context.Context, table func(tables *db_types.InMemoryTables) map[db_types.InMemoryUserPair]time.Time, from uuid.UUID, to uuid.UUID) (bool, error) {
+	var ok bool
+	err := r.db.Read(ctx, func(tables *db_types.InMemoryTables) error {
+		_, ok = table(tables)[db_types.InMemoryUserPair{From: from, To: to}]
+		return nil
+	})
+	return ok, err
+}
+
+// addPair adds the pair to the table if it is not there already
+func (r *inMemoryUserRepo) addPair(ctx context.Context, table func(tables *db_types.InMemoryTables) map[db_types.InMemoryUserPair]time.Time, from uuid.UUID, to uuid.UUID) error {
+	return r.db.Write(ctx, func(tables *db_types.InMemoryTables) error {
+		pair := db_types.InMemoryUserPair{From: from, To: to}
+		if err := checkPair(tables, pair); err != nil {
+			return err
+		}
+
+		if _, ok := table(tables)[pair]; !ok {
+			table(tables)[pair] = time.Now()
+		}
+		return nil
+	})
+}
+
+// checkPair applies the constraints shared by the follow, block and mute tables, both users must exist and be
+// different users
+func checkPair(tables *db_types.InMemoryTables, pair db_types.InMemoryUserPair) error {
+	if pair.From == pair.To {
+		return fmt.Errorf("a user cannot be paired with themself, user_id=%v", pair.From)
+	}
+	for _, id := range []uuid.UUID{pair.From, pair.To} {
+		if _, ok := tables.Users[id]; !ok {
+			return fmt.Errorf("user does not exist, user_id=%v", id)
+		}
+	}
+	return nil
+}
+
+// checkUnique returns a ConflictError if an active user other than userId has the username or email, ignoring case
+func checkUnique(tables *db_types.InMemoryTables, userId uuid.UUID, username string, email string) error {
+	for _, other := range activeUsers(tables) {
+		if other.Id == userId {
+			continue
+		}
+		if strings.EqualFold(other.Username, username) {
+			return user_types.ConflictError{Msg: "username already exists"}
+		}
+		if strings.EqualFold(other.Email, email) {
+			return user_types.ConflictError{Msg: "email already exists"}
+		}
+	}
+	return nil
+}
+
+// activeUsers returns the users that are not soft deleted
+func activeUsers(tables *db_types.InMemoryTables) []db_types.InMemoryUser {
+	users := make([]db_types.InMemoryUser, 0, len(tables.Users))
+	for _, user := range tables.Users {
+		if user.DeletedAt.IsAbsent() {
+			users = append(users, user)
+		}
+	}
+	return users
+}
+
+func fromInMemoryUser(from db_types.InMemoryUser) user_types.User {
+	return user_types.User{
+		Id:              from.Id,
+		Username:        from.Username,
+		Email:           from.Email,
+		Bio:             from.Bio,
+		Image:           from.Image,
+		CreatedAtMillis: from.CreatedAt.UnixMilli(),
+		UpdatedAtMillis: from.UpdatedAt.UnixMilli(),
+		Version:         from.Version,
+	}
+}
@@ -3,6 +3,7 @@ package internal
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"time"
 
@@ -11,33 +12,59 @@ import (
 	"github.com/nimaeskandary/go-realworld/pkg/user/types"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/samber/mo"
 	"github.com/stephenafamo/bob"
 	"github.com/stephenafamo/bob/dialect/psql"
 	"github.com/stephenafamo/bob/dialect/psql/dm"
 	"github.com/stephenafamo/bob/dialect/psql/im"
 	"github.com/stephenafamo/bob/dialect/psql/sm"
+	"github.com/stephenafamo/bob/dialect/psql/um"
 	"github.com/stephenafamo/scan"
 )
 
 const (
 	usersTableName     = "users"
 	followersTableName = "user_followers"
+	blocksTableName    = "user_blocks"
+	mutesTableName     = "user_mutes"
+	historyTableName   = "username_history"
+
+	usernameUniqueIndexName = "users_username_lower_active_key"
+	emailUniqueIndexName    = "users_email_lower_active_key"
+	// https://www.postgresql.org/docs/current/errcodes-appendix.html
+	uniqueViolationCode = "23505"
 )
 
 type postgresUserRepo struct {
-	db     db_types.PostgresRealWorldAppDb
+	db     db_types.RealWorldAppDb
 	logger obs_types.Logger
 }
 
-func NewPostgresUserRepository(db db_types.PostgresRealWorldAppDb, logger obs_types.Logger) user_types.UserRepository {
+func NewPostgresUserRepository(db db_types.RealWorldAppDb, logger obs_types.Logger) user_types.UserRepository {
 	return &postgresUserRepo{db: db, logger: logger}
 }
 
 func (r *postgresUserRepo) UpsertUser(ctx context.Context, user user_types.User) (user_types.User, error) {
-	createCols := []string{"id", "username", "email", "bio", "image", "created_at", "updated_at"}
+	createCols := []string{"id", "username", "email", "bio", "image", "created_at", "updated_at", "version"}
 	updateCols := []string{"username", "email", "bio", "image", "updated_at"}
 	q := psql.Insert(
+		// the previous username is recorded in the same statement, so a rename and its history are written atomically.
+		// The CTE sees the row as it was before the upsert, and matches nothing for inserts or version conflicts
+		im.With("previous_username").As(psql.Insert(
+			im.Into(historyTableName, "user_id", "username", "changed_at"),
+			im.Query(psql.Select(
+				// parameters in a select list are untyped, so the timestamp needs an explicit cast
+				sm.Columns(psql.Quote("id"), psql.Quote("username"), psql.Cast(psql.Arg(time.UnixMilli(user.UpdatedAtMillis)), "TIMESTAMPTZ")),
+				sm.From(usersTableName),
+				sm.Where(psql.Quote("id").EQ(psql.Arg(user.Id))),
+				sm.Where(psql.Quote("version").EQ(psql.Arg(user.Version))),
+				sm.Where(psql.Not(caseInsensitiveEQ("username", user.Username))),
+			)),
+			im.OnConflict(psql.Quote("user_id"), psql.Group(psql.F("LOWER", psql.Quote("username"))())).DoUpdate(
+				im.SetExcluded("changed_at"),
+			),
+		)),
 		im.Into(usersTableName, createCols...),
 		im.Values(
 			psql.Arg(
@@ -48,16 +75,26 @@ func (r *postgresUserRepo) UpsertUser(ctx context.Context, user user_types.User)
 				user.Image,
 				time.UnixMilli(user.CreatedAtMillis),
 				time.UnixMilli(user.UpdatedAtMillis),
+				1,
 			),
 		),
 		im.OnConflict("id").DoUpdate(
 			im.SetExcluded(updateCols...),
+			im.SetCol("version").To(psql.Quote(usersTableName, "version").Plus(psql.Arg(1))),
+			// only update the version the caller read, a mismatch updates no rows
+			im.Where(psql.Quote(usersTableName, "version").EQ(psql.Arg(user.Version))),
 		),
 		im.Returning("*"),
 	)
 
-	result, err := bob.One(ctx, bob.NewDB(r.db.GetDB()), q, scan.StructMapper[postgresUser]())
+	result, err := bob.One(ctx, db_types.ExecutorFromCtx(ctx, r.db), q, scan.StructMapper[postgresUser]())
 	if err != nil {
+		if err == sql.ErrNoRows {
+			return user_types.User{}, user_types.VersionConflictError{Identifier: user.Id.String()}
+		}
+		if conflictErr, ok := asConflictError(err); ok {
+			return user_types.User{}, conflictErr
+		}
 		return user_types.User{}, fmt.Errorf("error with user upsert query, user=%v: %w", user, err)
 	}
 
@@ -65,26 +102,83 @@ func (r *postgresUserRepo) UpsertUser(ctx context.Context, user user_types.User)
 }
 
 func (r *postgresUserRepo) DeleteUser(ctx context.Context, id uuid.UUID) error {
-	q := psql.Delete(
-		dm.From(usersTableName),
-		dm.Where(psql.Quote("id").EQ(psql.Arg(id.String()))),
+	q := psql.Update(
+		um.Table(usersTableName),
+		um.SetCol("deleted_at").ToArg(time.Now()),
+		um.Where(psql.Quote("id").EQ(psql.Arg(id.String()))),
+		um.Where(psql.Quote("deleted_at").IsNull()),
 	)
 
-	_, err := bob.Exec(ctx, bob.NewDB(r.db.GetDB()), q)
+	_, err := bob.Exec(ctx, db_types.ExecutorFromCtx(ctx, r.db), q)
 	if err != nil {
 		return fmt.Errorf("error with user delete query, id=%v: %w", id.String(), err)
 	}
 	return nil
 }
 
+func (r *postgresUserRepo) GetDeletedUserById(ctx context.Context, id uuid.UUID, deletedAfter time.Time) (mo.Option[user_types.User], error) {
+	q := psql.Select(
+		sm.Columns("*"),
+		sm.From(usersTableName),
+		sm.Where(psql.Quote("id").EQ(psql.Arg(id.String()))),
+		sm.Where(psql.Quote("deleted_at").GT(psql.Arg(deletedAfter))),
+	)
+
+	result, err := bob.One(ctx, db_types.ReaderFromCtx(ctx, r.db), q, scan.StructMapper[postgresUser]())
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return mo.None[user_types.User](), nil
+		}
+		return mo.None[user_types.User](), fmt.Errorf("error with get deleted user by id query, id=%v: %w", id.String(), err)
+	}
+
+	return mo.Some(fromPostgresUser(result)), nil
+}
+
+func (r *postgresUserRepo) RestoreUser(ctx context.Context, id uuid.UUID) error {
+	q := psql.Update(
+		um.Table(usersTableName),
+		um.SetCol("deleted_at").To(psql.Raw("NULL")),
+		um.Where(psql.Quote("id").EQ(psql.Arg(id.String()))),
+	)
+
+	_, err := bob.Exec(ctx, db_types.ExecutorFromCtx(ctx, r.db), q)
+	if err != nil {
+		if conflictErr, ok := asConflictError(err); ok {
+			return conflictErr
+		}
+		return fmt.Errorf("error with user restore query, id=%v: %w", id.String(), err)
+	}
+	return nil
+}
+
+func (r *postgresUserRepo) PurgeDeletedUsers(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	q := psql.Delete(
+		dm.From(usersTableName),
+		dm.Where(psql.Quote("deleted_at").LTE(psql.Arg(deletedBefore))),
+	)
+
+	result, err := bob.Exec(ctx, db_types.ExecutorFromCtx(ctx, r.db), q)
+	if err != nil {
+		return 0, fmt.Errorf("error with purge deleted users query, deleted_before=%v: %w", deletedBefore, err)
+	}
+
+	purged, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("error reading purged users count: %w", err)
+	}
+	return purged, nil
+}
+
 func (r *postgresUserRepo) GetUserByUsername(ctx context.Context, username string) (mo.Option[user_types.User], error) {
 	q := psql.Select(
 		sm.Columns("*"),
 		sm.From(usersTableName),
-		sm.Where(psql.Quote("username").EQ(psql.Arg(username))),
+		sm.Where(caseInsensitiveEQ("username", username)),
+		sm.Where(psql.Quote("deleted_at").IsNull()),
 	)
 
-	result, err := bob.One(ctx, bob.NewDB(r.db.GetDB()), q, scan.StructMapper[postgresUser]())
+	result, err := bob.One(ctx, db_types.ReaderFromCtx(ctx, r.db), q, scan.StructMapper[postgresUser]())
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return mo.None[user_types.User](), nil
@@ -95,14 +189,55 @@ func (r *postgresUserRepo) GetUserByUsername(ctx context.Context, username strin
 	return mo.Some(fromPostgresUser(result)), nil
 }
 
+func (r *postgresUserRepo) GetUserByPreviousUsername(ctx context.Context, username string) (mo.Option[user_types.User], error) {
+	q := psql.Select(
+		sm.Columns(usersTableName+".*"),
+		sm.From(historyTableName),
+		sm.InnerJoin(usersTableName).On(psql.Quote(usersTableName, "id").EQ(psql.Quote(historyTableName, "user_id"))),
+		sm.Where(psql.F("LOWER", psql.Quote(historyTableName, "username"))().EQ(psql.F("LOWER", psql.Arg(username))())),
+		sm.Where(psql.Quote(usersTableName, "deleted_at").IsNull()),
+		sm.OrderBy(psql.Quote(historyTableName, "changed_at")).Desc(),
+		sm.Limit(1),
+	)
+
+	result, err := bob.One(ctx, db_types.ReaderFromCtx(ctx, r.db), q, scan.StructMapper[postgresUser]())
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return mo.None[user_types.User](), nil
+		}
+		return mo.None[user_types.User](), fmt.Errorf("error with get user by previous username query, username=%v: %w", username, err)
+	}
+
+	return mo.Some(fromPostgresUser(result)), nil
+}
+
+func (r *postgresUserRepo) IsUsernameReserved(ctx context.Context, username string, claimantUserId uuid.UUID, changedAfter time.Time) (bool, error) {
+	exists, err := r.exists(ctx, psql.Select(
+		sm.Columns(psql.Quote(historyTableName, "user_id")),
+		sm.From(historyTableName),
+		sm.InnerJoin(usersTableName).On(psql.Quote(usersTableName, "id").EQ(psql.Quote(historyTableName, "user_id"))),
+		sm.Where(psql.F("LOWER", psql.Quote(historyTableName, "username"))().EQ(psql.F("LOWER", psql.Arg(username))())),
+		sm.Where(psql.Quote(historyTableName, "user_id").NE(psql.Arg(claimantUserId))),
+		sm.Where(psql.Quote(historyTableName, "changed_at").GT(psql.Arg(changedAfter))),
+		// a deleted user's names are released along with their current username
+		sm.Where(psql.Quote(usersTableName, "deleted_at").IsNull()),
+	))
+	if err != nil {
+		return false, fmt.Errorf("error with is username reserved query, username=%v: %w", username, err)
+	}
+
+	return exists, nil
+}
+
 func (r *postgresUserRepo) GetUserById(ctx context.Context, id uuid.UUID) (mo.Option[user_types.User], error) {
 	q := psql.Select(
 		sm.Columns("*"),
 		sm.From(usersTableName),
 		sm.Where(psql.Quote("id").EQ(psql.Arg(id.String()))),
+		sm.Where(psql.Quote("deleted_at").IsNull()),
 	)
 
-	result, err := bob.One(ctx, bob.NewDB(r.db.GetDB()), q, scan.StructMapper[postgresUser]())
+	result, err := bob.One(ctx, db_types.ReaderFromCtx(ctx, r.db), q, scan.StructMapper[postgresUser]())
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return mo.None[user_types.User](), nil
@@ -113,14 +248,39 @@ func (r *postgresUserRepo) GetUserById(ctx context.Context, id uuid.UUID) (mo.Op
 	return mo.Some(fromPostgresUser(result)), nil
 }
 
+func (r *postgresUserRepo) GetUsersByIds(ctx context.Context, ids []uuid.UUID) ([]user_types.User, error) {
+	if len(ids) == 0 {
+		return []user_types.User{}, nil
+	}
+
+	q := psql.Select(
+		sm.Columns("*"),
+		sm.From(usersTableName),
+		sm.Where(psql.Quote("id").In(uuidArgs(ids))),
+		sm.Where(psql.Quote("deleted_at").IsNull()),
+	)
+
+	result, err := bob.All(ctx, db_types.ReaderFromCtx(ctx, r.db), q, scan.StructMapper[postgresUser]())
+	if err != nil {
+		return nil, fmt.Errorf("error with get users by ids query, count=%v: %w", len(ids), err)
+	}
+
+	users := make([]user_types.User, len(result))
+	for i, u := range result {
+		users[i] = fromPostgresUser(u)
+	}
+	return users, nil
+}
+
 func (r *postgresUserRepo) GetUserByEmail(ctx context.Context, email string) (mo.Option[user_types.User], error) {
 	q := psql.Select(
 		sm.Columns("*"),
 		sm.From(usersTableName),
-		sm.Where(psql.Quote("email").EQ(psql.Arg(email))),
+		sm.Where(caseInsensitiveEQ("email", email)),
+		sm.Where(psql.Quote("deleted_at").IsNull()),
 	)
 
-	result, err := bob.One(ctx, bob.NewDB(r.db.GetDB()), q, scan.StructMapper[postgresUser]())
+	result, err := bob.One(ctx, db_types.ReaderFromCtx(ctx, r.db), q, scan.StructMapper[postgresUser]())
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return mo.None[user_types.User](), nil
@@ -132,47 +292,82 @@ func (r *postgresUserRepo) GetUserByEmail(ctx context.Context, email string) (mo
 }
 
 func (r *postgresUserRepo) IsFollowing(ctx context.Context, followedByUserId uuid.UUID, followingUserId uuid.UUID) (bool, error) {
-	q, args, err := psql.Select(
-		sm.Columns(psql.F("EXISTS",
-			psql.Select(
-				sm.From(followersTableName),
-				sm.Columns("followed_by_user_id"),
-				sm.Where(
-					psql.And(
-						psql.Quote("followed_by_user_id").EQ(psql.Arg(followedByUserId)),
-						psql.Quote("following_user_id").EQ(psql.Arg(followingUserId)),
-					),
-				),
+	exists, err := r.exists(ctx, psql.Select(
+		sm.From(followersTableName),
+		sm.Columns("followed_by_user_id"),
+		sm.Where(
+			psql.And(
+				psql.Quote("followed_by_user_id").EQ(psql.Arg(followedByUserId)),
+				psql.Quote("following_user_id").EQ(psql.Arg(followingUserId)),
 			),
-		)),
-	).Build(ctx)
-
+		),
+	))
 	if err != nil {
-		return false, fmt.Errorf("error building is following query: %w", err)
+		return false, fmt.Errorf("error with is following query: %w", err)
 	}
 
-	var exists bool
-	err = r.db.GetDB().QueryRowContext(ctx, q, args...).Scan(&exists)
+	return exists, nil
+}
+
+func (r *postgresUserRepo) AreFollowing(ctx context.Context, followedByUserId uuid.UUID, followingUserIds []uuid.UUID) (map[uuid.UUID]bool, error) {
+	following := make(map[uuid.UUID]bool, len(followingUserIds))
+	if len(followingUserIds) == 0 {
+		return following, nil
+	}
+
+	q := psql.Select(
+		sm.Columns(psql.Quote("following_user_id")),
+		sm.From(followersTableName),
+		sm.Where(psql.Quote("followed_by_user_id").EQ(psql.Arg(followedByUserId))),
+		sm.Where(psql.Quote("following_user_id").In(uuidArgs(followingUserIds))),
+	)
+
+	result, err := bob.All(ctx, db_types.ReaderFromCtx(ctx, r.db), q, scan.SingleColumnMapper[uuid.UUID])
 	if err != nil {
-		return false, fmt.Errorf("error executing is following query: %w", err)
+		return nil, fmt.Errorf("error with are following query, followed_by_user_id=%v: %w", followedByUserId.String(), err)
 	}
 
-	return exists, nil
+	for _, id := range followingUserIds {
+		following[id] = false
+	}
+	for _, id := range result {
+		following[id] = true
+	}
+	return following, nil
 }
 
 func (r *postgresUserRepo) Follow(ctx context.Context, followedByUserId uuid.UUID, followingUserId uuid.UUID) error {
+	// the block check is part of the insert, so a block made after the service validated the follow cannot be raced
 	q := psql.Insert(
 		im.Into(followersTableName, "followed_by_user_id", "following_user_id"),
-		im.Values(psql.Arg(followedByUserId), psql.Arg(followingUserId)),
+		im.Query(psql.Select(
+			// without the casts the select's parameters would be typed as text rather than taking the column types
+			sm.Columns(psql.Cast(psql.Arg(followedByUserId), "uuid"), psql.Cast(psql.Arg(followingUserId), "uuid")),
+			sm.Where(psql.Not(psql.F("EXISTS", blockedEitherWayQuery(followedByUserId, followingUserId)))),
+		)),
 		im.OnConflict("followed_by_user_id", "following_user_id").DoNothing(),
 	)
 
-	_, err := bob.Exec(ctx, bob.NewDB(r.db.GetDB()), q)
-
+	result, err := bob.Exec(ctx, db_types.ExecutorFromCtx(ctx, r.db), q)
 	if err != nil {
 		return fmt.Errorf("error executing follow query: %w", err)
 	}
 
+	inserted, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error reading follow query result: %w", err)
+	}
+	if inserted == 0 {
+		// nothing is inserted if the follow already exists, or if either user has blocked the other
+		blocked, err := r.IsBlockedEitherWay(ctx, followedByUserId, followingUserId)
+		if err != nil {
+			return err
+		}
+		if blocked {
+			return user_types.CannotFollowBlockedUserError{}
+		}
+	}
+
 	return nil
 }
 
@@ -187,7 +382,7 @@ func (r *postgresUserRepo) Unfollow(ctx context.Context, followedByUserId uuid.U
 		),
 	)
 
-	_, err := bob.Exec(ctx, bob.NewDB(r.db.GetDB()), q)
+	_, err := bob.Exec(ctx, db_types.ExecutorFromCtx(ctx, r.db), q)
 	if err != nil {
 		return fmt.Errorf("error executing unfollow query: %w", err)
 	}
@@ -195,14 +390,231 @@ func (r *postgresUserRepo) Unfollow(ctx context.Context, followedByUserId uuid.U
 	return nil
 }
 
+func (r *postgresUserRepo) Block(ctx context.Context, blockedByUserId uuid.UUID, blockedUserId uuid.UUID) error {
+	// a data modifying CTE runs in the same statement as the insert, so removing the follows and recording
+	// the block happen atomically
+	q := psql.Insert(
+		im.With("removed_follows").As(psql.Delete(
+			dm.From(followersTableName),
+			dm.Where(
+				psql.Or(
+					psql.And(
+						psql.Quote("followed_by_user_id").EQ(psql.Arg(blockedByUserId)),
+						psql.Quote("following_user_id").EQ(psql.Arg(blockedUserId)),
+					),
+					psql.And(
+						psql.Quote("followed_by_user_id").EQ(psql.Arg(blockedUserId)),
+						psql.Quote("following_user_id").EQ(psql.Arg(blockedByUserId)),
+					),
+				),
+			),
+		)),
+		im.Into(blocksTableName, "blocked_by_user_id", "blocked_user_id"),
+		im.Values(psql.Arg(blockedByUserId), psql.Arg(blockedUserId)),
+		im.OnConflict("blocked_by_user_id", "blocked_user_id").DoNothing(),
+	)
+
+	_, err := bob.Exec(ctx, db_types.ExecutorFromCtx(ctx, r.db), q)
+	if err != nil {
+		return fmt.Errorf("error executing block query: %w", err)
+	}
+
+	return nil
+}
+
+func (r *postgresUserRepo) Unblock(ctx context.Context, blockedByUserId uuid.UUID, blockedUserId uuid.UUID) error {
+	q := psql.Delete(
+		dm.From(blocksTableName),
+		dm.Where(
+			psql.And(
+				psql.Quote("blocked_by_user_id").EQ(psql.Arg(blockedByUserId)),
+				psql.Quote("blocked_user_id").EQ(psql.Arg(blockedUserId)),
+			),
+		),
+	)
+
+	_, err := bob.Exec(ctx, db_types.ExecutorFromCtx(ctx, r.db), q)
+	if err != nil {
+		return fmt.Errorf("error executing unblock query: %w", err)
+	}
+
+	return nil
+}
+
+func (r *postgresUserRepo) IsBlocking(ctx context.Context, blockedByUserId uuid.UUID, blockedUserId uuid.UUID) (bool, error) {
+	exists, err := r.exists(ctx, psql.Select(
+		sm.From(blocksTableName),
+		sm.Columns("blocked_by_user_id"),
+		sm.Where(
+			psql.And(
+				psql.Quote("blocked_by_user_id").EQ(psql.Arg(blockedByUserId)),
+				psql.Quote("blocked_user_id").EQ(psql.Arg(blockedUserId)),
+			),
+		),
+	))
+	if err != nil {
+		return false, fmt.Errorf("error with is blocking query: %w", err)
+	}
+
+	return exists, nil
+}
+
+func (r *postgresUserRepo) IsBlockedEitherWay(ctx context.Context, userIdA uuid.UUID, userIdB uuid.UUID) (bool, error) {
+	exists, err := r.exists(ctx, blockedEitherWayQuery(userIdA, userIdB))
+	if err != nil {
+		return false, fmt.Errorf("error with is blocked either way query: %w", err)
+	}
+
+	return exists, nil
+}
+
+// blockedEitherWayQuery selects the blocks between the two users, in either direction
+func blockedEitherWayQuery(userIdA uuid.UUID, userIdB uuid.UUID) bob.Query {
+	return psql.Select(
+		sm.From(blocksTableName),
+		sm.Columns("blocked_by_user_id"),
+		sm.Where(
+			psql.Or(
+				psql.And(
+					psql.Quote("blocked_by_user_id").EQ(psql.Arg(userIdA)),
+					psql.Quote("blocked_user_id").EQ(psql.Arg(userIdB)),
+				),
+				psql.And(
+					psql.Quote("blocked_by_user_id").EQ(psql.Arg(userIdB)),
+					psql.Quote("blocked_user_id").EQ(psql.Arg(userIdA)),
+				),
+			),
+		),
+	)
+}
+
+func (r *postgresUserRepo) Mute(ctx context.Context, mutedByUserId uuid.UUID, mutedUserId uuid.UUID) error {
+	q := psql.Insert(
+		im.Into(mutesTableName, "muted_by_user_id", "muted_user_id"),
+		im.Values(psql.Arg(mutedByUserId), psql.Arg(mutedUserId)),
+		im.OnConflict("muted_by_user_id", "muted_user_id").DoNothing(),
+	)
+
+	_, err := bob.Exec(ctx, db_types.ExecutorFromCtx(ctx, r.db), q)
+	if err != nil {
+		return fmt.Errorf("error executing mute query: %w", err)
+	}
+
+	return nil
+}
+
+func (r *postgresUserRepo) Unmute(ctx context.Context, mutedByUserId uuid.UUID, mutedUserId uuid.UUID) error {
+	q := psql.Delete(
+		dm.From(mutesTableName),
+		dm.Where(
+			psql.And(
+				psql.Quote("muted_by_user_id").EQ(psql.Arg(mutedByUserId)),
+				psql.Quote("muted_user_id").EQ(psql.Arg(mutedUserId)),
+			),
+		),
+	)
+
+	_, err := bob.Exec(ctx, db_types.ExecutorFromCtx(ctx, r.db), q)
+	if err != nil {
+		return fmt.Errorf("error executing unmute query: %w", err)
+	}
+
+	return nil
+}
+
+func (r *postgresUserRepo) IsMuting(ctx context.Context, mutedByUserId uuid.UUID, mutedUserId uuid.UUID) (bool, error) {
+	exists, err := r.exists(ctx, psql.Select(
+		sm.From(mutesTableName),
+		sm.Columns("muted_by_user_id"),
+		sm.Where(
+			psql.And(
+				psql.Quote("muted_by_user_id").EQ(psql.Arg(mutedByUserId)),
+				psql.Quote("muted_user_id").EQ(psql.Arg(mutedUserId)),
+			),
+		),
+	))
+	if err != nil {
+		return false, fmt.Errorf("error with is muting query: %w", err)
+	}
+
+	return exists, nil
+}
+
+func (r *postgresUserRepo) ListHiddenUserIds(ctx context.Context, viewerUserId uuid.UUID) ([]uuid.UUID, error) {
+	q := psql.Select(
+		sm.Columns(psql.Quote("blocked_user_id")),
+		sm.From(blocksTableName),
+		sm.Where(psql.Quote("blocked_by_user_id").EQ(psql.Arg(viewerUserId))),
+		sm.Union(psql.Select(
+			sm.Columns(psql.Quote("muted_user_id")),
+			sm.From(mutesTableName),
+			sm.Where(psql.Quote("muted_by_user_id").EQ(psql.Arg(viewerUserId))),
+		)),
+	)
+
+	result, err := bob.All(ctx, db_types.ReaderFromCtx(ctx, r.db), q, scan.SingleColumnMapper[uuid.UUID])
+	if err != nil {
+		return nil, fmt.Errorf("error with list hidden user ids query, viewer_user_id=%v: %w", viewerUserId.String(), err)
+	}
+
+	return result, nil
+}
+
+// asConflictError translates a unique violation on the username or email indexes into a [user_types.ConflictError],
+// so concurrent writes that both passed validation are still reported as conflicts
+func asConflictError(err error) (user_types.ConflictError, bool) {
+	pgErr, ok := errors.AsType[*pgconn.PgError](err)
+	if !ok || pgErr.Code != uniqueViolationCode {
+		return user_types.ConflictError{}, false
+	}
+
+	switch pgErr.ConstraintName {
+	case usernameUniqueIndexName:
+		return user_types.ConflictError{Msg: "username already exists"}, true
+	case emailUniqueIndexName:
+		return user_types.ConflictError{Msg: "email already exists"}, true
+	default:
+		return user_types.ConflictError{}, false
+	}
+}
+
+// caseInsensitiveEQ compares a column to a value ignoring case, matching the LOWER() unique indexes on users
+func caseInsensitiveEQ(column string, value string) bob.Expression {
+	return psql.F("LOWER", psql.Quote(column))().EQ(psql.F("LOWER", psql.Arg(value))())
+}
+
+// uuidArgs builds the argument list of an IN clause, one placeholder per id
+func uuidArgs(ids []uuid.UUID) bob.Expression {
+	args := make([]any, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+	return psql.Arg(args...)
+}
+
+// exists wraps the subquery in a SELECT EXISTS(...) and returns the result
+func (r *postgresUserRepo) exists(ctx context.Context, subquery bob.Query) (bool, error) {
+	q := psql.Select(sm.Columns(psql.F("EXISTS", subquery)))
+
+	exists, err := bob.One(ctx, db_types.ReaderFromCtx(ctx, r.db), q, scan.SingleColumnMapper[bool])
+	if err != nil {
+		return false, fmt.Errorf("error executing exists query: %w", err)
+	}
+
+	return exists, nil
+}
+
+// postgresUser is the users row, the sqlite repository scans into it too
 type postgresUser struct {
-	Id        uuid.UUID         `db:"id"`
-	Username  string            `db:"username"`
-	Email     string            `db:"email"`
-	Bio       mo.Option[string] `db:"bio"`
-	Image     mo.Option[string] `db:"image"`
-	CreatedAt time.Time         `db:"created_at"`
-	UpdatedAt time.Time         `db:"updated_at"`
+	Id        uuid.UUID            `db:"id"`
+	Username  string               `db:"username"`
+	Email     string               `db:"email"`
+	Bio       mo.Option[string]    `db:"bio"`
+	Image     mo.Option[string]    `db:"image"`
+	CreatedAt time.Time            `db:"created_at"`
+	UpdatedAt time.Time            `db:"updated_at"`
+	DeletedAt mo.Option[time.Time] `db:"deleted_at"`
+	Version   int64                `db:"version"`
 }
 
 func fromPostgresUser(from postgresUser) user_types.User {
@@ -214,5 +626,6 @@ func fromPostgresUser(from postgresUser) user_types.User {
 		Image:           from.Image,
 		CreatedAtMillis: from.CreatedAt.UnixMilli(),
 		UpdatedAtMillis: from.UpdatedAt.UnixMilli(),
+		Version:         from.Version,
 	}
 }
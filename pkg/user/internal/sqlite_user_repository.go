@@ -0,0 +1,575 @@
+package internal
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/nimaeskandary/go-realworld/pkg/database/types"
+	"github.com/nimaeskandary/go-realworld/pkg/observability/types"
+	"github.com/nimaeskandary/go-realworld/pkg/user/types"
+
+	"github.com/google/uuid"
+	"github.com/samber/mo"
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/bob/dialect/sqlite"
+	"github.com/stephenafamo/bob/dialect/sqlite/dm"
+	"github.com/stephenafamo/bob/dialect/sqlite/im"
+	"github.com/stephenafamo/bob/dialect/sqlite/sm"
+	"github.com/stephenafamo/bob/dialect/sqlite/um"
+	"github.com/stephenafamo/scan"
+	sqlite_driver "modernc.org/sqlite"
+	sqlite_lib "modernc.org/sqlite/lib"
+)
+
+// sqliteUserRepo is the sqlite counterpart of postgresUserRepo. sqlite has no data modifying CTEs, so writes that
+// postgres makes atomic in one statement are made in a transaction instead.
+//
+// Times are written in UTC, sqlite stores them as text so they only compare correctly in the same offset
+type sqliteUserRepo struct {
+	db        db_types.RealWorldAppDb
+	txManager db_types.TxManager
+	logger    obs_types.Logger
+}
+
+func NewSqliteUserRepository(db db_types.RealWorldAppDb, txManager db_types.TxManager, logger obs_types.Logger) user_types.UserRepository {
+	return &sqliteUserRepo{db: db, txManager: txManager, logger: logger}
+}
+
+func (r *sqliteUserRepo) UpsertUser(ctx context.Context, user user_types.User) (user_types.User, error) {
+	createCols := []string{"id", "username", "email", "bio", "image", "created_at", "updated_at", "version"}
+	updateCols := []string{"username", "email", "bio", "image", "updated_at"}
+
+	// the previous username is recorded first, it matches nothing for inserts or version conflicts
+	recordPrevious := sqlite.Insert(
+		im.Into(historyTableName, "user_id", "username", "changed_at"),
+		im.Query(sqlite.Select(
+			sm.Columns(sqlite.Quote("id"), sqlite.Quote("username"), sqlite.Arg(time.UnixMilli(user.UpdatedAtMillis).UTC())),
+			sm.From(usersTableName),
+			sm.Where(sqlite.Quote("id").EQ(sqlite.Arg(user.Id))),
+			sm.Where(sqlite.Quote("version").EQ(sqlite.Arg(user.Version))),
+			sm.Where(sqlite.Not(sqliteCaseInsensitiveEQ("username", user.Username))),
+		)),
+		im.OnConflict(sqlite.Quote("user_id"), sqlite.F("LOWER", sqlite.Quote("username"))()).DoUpdate(
+			im.SetExcluded("changed_at"),
+		),
+	)
+
+	upsert := sqlite.Insert(
+		im.Into(usersTableName, createCols...),
+		im.Values(
+			sqlite.Arg(
+				user.Id,
+				user.Username,
+				user.Email,
+				user.Bio,
+				user.Image,
+				time.UnixMilli(user.CreatedAtMillis).UTC(),
+				time.UnixMilli(user.UpdatedAtMillis).UTC(),
+				1,
+			),
+		),
+		im.OnConflict("id").DoUpdate(
+			im.SetExcluded(updateCols...),
+			im.SetCol("version").To(sqlite.Quote(usersTableName, "version").Plus(sqlite.Arg(1))),
+			// only update the version the caller read, a mismatch updates no rows
+			im.Where(sqlite.Quote(usersTableName, "version").EQ(sqlite.Arg(user.Version))),
+		),
+		im.Returning("*"),
+	)
+
+	var result postgresUser
+	err := r.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		executor := db_types.ExecutorFromCtx(ctx, r.db)
+		if _, err := bob.Exec(ctx, executor, recordPrevious); err != nil {
+			return err
+		}
+
+		var err error
+		result, err = bob.One(ctx, executor, upsert, scan.StructMapper[postgresUser]())
+		return err
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return user_types.User{}, user_types.VersionConflictError{Identifier: user.Id.String()}
+		}
+		if conflictErr, ok := asSqliteConflictError(err); ok {
+			return user_types.User{}, conflictErr
+		}
+		return user_types.User{}, fmt.Errorf("error with user upsert query, user=%v: %w", user, err)
+	}
+
+	return fromPostgresUser(result), nil
+}
+
+func (r *sqliteUserRepo) DeleteUser(ctx context.Context, id uuid.UUID) error {
+	q := sqlite.Update(
+		um.Table(usersTableName),
+		um.SetCol("deleted_at").ToArg(time.Now().UTC()),
+		um.Where(sqlite.Quote("id").EQ(sqlite.Arg(id.String()))),
+		um.Where(sqlite.Quote("deleted_at").IsNull()),
+	)
+
+	_, err := bob.Exec(ctx, db_types.ExecutorFromCtx(ctx, r.db), q)
+	if err != nil {
+		return fmt.Errorf("error with user delete query, id=%v: %w", id.String(), err)
+	}
+	return nil
+}
+
+func (r *sqliteUserRepo) GetDeletedUserById(ctx context.Context, id uuid.UUID, deletedAfter time.Time) (mo.Option[user_types.User], error) {
+	q := sqlite.Select(
+		sm.Columns("*"),
+		sm.From(usersTableName),
+		sm.Where(sqlite.Quote("id").EQ(sqlite.Arg(id.String()))),
+		sm.Where(sqlite.Quote("deleted_at").GT(sqlite.Arg(deletedAfter.UTC()))),
+	)
+
+	return r.getOne(ctx, q, fmt.Sprintf("get deleted user by id query, id=%v", id.String()))
+}
+
+func (r *sqliteUserRepo) RestoreUser(ctx context.Context, id uuid.UUID) error {
+	q := sqlite.Update(
+		um.Table(usersTableName),
+		um.SetCol("deleted_at").To(sqlite.Raw("NULL")),
+		um.Where(sqlite.Quote("id").EQ(sqlite.Arg(id.String()))),
+	)
+
+	_, err := bob.Exec(ctx, db_types.ExecutorFromCtx(ctx, r.db), q)
+	if err != nil {
+		if conflictErr, ok := asSqliteConflictError(err); ok {
+			return conflictErr
+		}
+		return fmt.Errorf("error with user restore query, id=%v: %w", id.String(), err)
+	}
+	return nil
+}
+
+func (r *sqliteUserRepo) PurgeDeletedUsers(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	q := sqlite.Delete(
+		dm.From(usersTableName),
+		dm.Where(sqlite.Quote("deleted_at").LTE(sqlite.Arg(deletedBefore.UTC()))),
+	)
+
+	result, err := bob.Exec(ctx, db_types.ExecutorFromCtx(ctx, r.db), q)
+	if err != nil {
+		return 0, fmt.Errorf("error with purge deleted users query, deleted_before=%v: %w", deletedBefore, err)
+	}
+
+	purged, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("error reading purged users count: %w", err)
+	}
+	return purged, nil
+}
+
+func (r *sqliteUserRepo) GetUserByUsername(ctx context.Context, username string) (mo.Option[user_types.User], error) {
+	q := sqlite.Select(
+		sm.Columns("*"),
+		sm.From(usersTableName),
+		sm.Where(sqliteCaseInsensitiveEQ("username", username)),
+		sm.Where(sqlite.Quote("deleted_at").IsNull()),
+	)
+
+	return r.getOne(ctx, q, fmt.Sprintf("get user by username query, username=%v", username))
+}
+
+func (r *sqliteUserRepo) GetUserByPreviousUsername(ctx context.Context, username string) (mo.Option[user_types.User], error) {
+	q := sqlite.Select(
+		sm.Columns(usersTableName+".*"),
+		sm.From(historyTableName),
+		sm.InnerJoin(usersTableName).On(sqlite.Quote(usersTableName, "id").EQ(sqlite.Quote(historyTableName, "user_id"))),
+		sm.Where(sqlite.F("LOWER", sqlite.Quote(historyTableName, "username"))().EQ(sqlite.F("LOWER", sqlite.Arg(username))())),
+		sm.Where(sqlite.Quote(usersTableName, "deleted_at").IsNull()),
+		sm.OrderBy(sqlite.Quote(historyTableName, "changed_at")).Desc(),
+		sm.Limit(1),
+	)
+
+	return r.getOne(ctx, q, fmt.Sprintf("get user by previous username query, username=%v", username))
+}
+
+func (r *sqliteUserRepo) IsUsernameReserved(ctx context.Context, username string, claimantUserId uuid.UUID, changedAfter time.Time) (bool, error) {
+	exists, err := r.exists(ctx, sqlite.Select(
+		sm.Columns(sqlite.Quote(historyTableName, "user_id")),
+		sm.From(historyTableName),
+		sm.InnerJoin(usersTableName).On(sqlite.Quote(usersTableName, "id").EQ(sqlite.Quote(historyTableName, "user_id"))),
+		sm.Where(sqlite.F("LOWER", sqlite.Quote(historyTableName, "username"))().EQ(sqlite.F("LOWER", sqlite.Arg(username))())),
+		sm.Where(sqlite.Quote(historyTableName, "user_id").NE(sqlite.Arg(claimantUserId))),
+		sm.Where(sqlite.Quote(historyTableName, "changed_at").GT(sqlite.Arg(changedAfter.UTC()))),
+		// a deleted user's names are released along with their current username
+		sm.Where(sqlite.Quote(usersTableName, "deleted_at").IsNull()),
+	))
+	if err != nil {
+		return false, fmt.Errorf("error with is username reserved query, username=%v: %w", username, err)
+	}
+
+	return exists, nil
+}
+
+func (r *sqliteUserRepo) GetUserById(ctx context.Context, id uuid.UUID) (mo.Option[user_types.User], error) {
+	q := sqlite.Select(
+		sm.Columns("*"),
+		sm.From(usersTableName),
+		sm.Where(sqlite.Quote("id").EQ(sqlite.Arg(id.String()))),
+		sm.Where(sqlite.Quote("deleted_at").IsNull()),
+	)
+
+	return r.getOne(ctx, q, fmt.Sprintf("get user by id query, id=%v", id.String()))
+}
+
+func (r *sqliteUserRepo) GetUsersByIds(ctx context.Context, ids []uuid.UUID) ([]user_types.User, error) {
+	if len(ids) == 0 {
+		return []user_types.User{}, nil
+	}
+
+	q := sqlite.Select(
+		sm.Columns("*"),
+		sm.From(usersTableName),
+		sm.Where(sqlite.Quote("id").In(sqliteUuidArgs(ids))),
+		sm.Where(sqlite.Quote("deleted_at").IsNull()),
+	)
+
+	result, err := bob.All(ctx, db_types.ReaderFromCtx(ctx, r.db), q, scan.StructMapper[postgresUser]())
+	if err != nil {
+		return nil, fmt.Errorf("error with get users by ids query, count=%v: %w", len(ids), err)
+	}
+
+	users := make([]user_types.User, len(result))
+	for i, u := range result {
+		users[i] = fromPostgresUser(u)
+	}
+	return users, nil
+}
+
+func (r *sqliteUserRepo) GetUserByEmail(ctx context.Context, email string) (mo.Option[user_types.User], error) {
+	q := sqlite.Select(
+		sm.Columns("*"),
+		sm.From(usersTableName),
+		sm.Where(sqliteCaseInsensitiveEQ("email", email)),
+		sm.Where(sqlite.Quote("deleted_at").IsNull()),
+	)
+
+	return r.getOne(ctx, q, fmt.Sprintf("get user by email query, email=%v", email))
+}
+
+func (r *sqliteUserRepo) IsFollowing(ctx context.Context, followedByUserId uuid.UUID, followingUserId uuid.UUID) (bool, error) {
+	exists, err := r.exists(ctx, sqlite.Select(
+		sm.From(followersTableName),
+		sm.Columns("followed_by_user_id"),
+		sm.Where(sqlite.Quote("followed_by_user_id").EQ(sqlite.Arg(followedByUserId))),
+		sm.Where(sqlite.Quote("following_user_id").EQ(sqlite.Arg(followingUserId))),
+	))
+	if err != nil {
+		return false, fmt.Errorf("error with is following query: %w", err)
+	}
+
+	return exists, nil
+}
+
+func (r *sqliteUserRepo) AreFollowing(ctx context.Context, followedByUserId uuid.UUID, followingUserIds []uuid.UUID) (map[uuid.UUID]bool, error) {
+	following := make(map[uuid.UUID]bool, len(followingUserIds))
+	if len(followingUserIds) == 0 {
+		return following, nil
+	}
+
+	q := sqlite.Select(
+		sm.Columns(sqlite.Quote("following_user_id")),
+		sm.From(followersTableName),
+		sm.Where(sqlite.Quote("followed_by_user_id").EQ(sqlite.Arg(followedByUserId))),
+		sm.Where(sqlite.Quote("following_user_id").In(sqliteUuidArgs(followingUserIds))),
+	)
+
+	result, err := bob.All(ctx, db_types.ReaderFromCtx(ctx, r.db), q, scan.SingleColumnMapper[uuid.UUID])
+	if err != nil {
+		return nil, fmt.Errorf("error with are following query, followed_by_user_id=%v: %w", followedByUserId.String(), err)
+	}
+
+	for _, id := range followingUserIds {
+		following[id] = false
+	}
+	for _, id := range result {
+		following[id] = true
+	}
+	return following, nil
+}
+
+func (r *sqliteUserRepo) Follow(ctx context.Context, followedByUserId uuid.UUID, followingUserId uuid.UUID) error {
+	// the block check is part of the insert, so a block made after the service validated the follow cannot be raced
+	q := sqlite.Insert(
+		im.Into(followersTableName, "followed_by_user_id", "following_user_id"),
+		im.Query(sqlite.Select(
+			sm.Columns(sqlite.Arg(followedByUserId), sqlite.Arg(followingUserId)),
+			// sqlite requires a where clause on an insert's select when it has an upsert clause, which this has anyway
+			sm.Where(sqlite.Not(sqlite.Raw("EXISTS ?", sqliteBlockedEitherWayQuery(followedByUserId, followingUserId)))),
+		)),
+		im.OnConflict("followed_by_user_id", "following_user_id").DoNothing(),
+	)
+
+	result, err := bob.Exec(ctx, db_types.ExecutorFromCtx(ctx, r.db), q)
+	if err != nil {
+		return fmt.Errorf("error executing follow query: %w", err)
+	}
+
+	inserted, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error reading follow query result: %w", err)
+	}
+	if inserted == 0 {
+		// nothing is inserted if the follow already exists, or if either user has blocked the other
+		blocked, err := r.IsBlockedEitherWay(ctx, followedByUserId, followingUserId)
+		if err != nil {
+			return err
+		}
+		if blocked {
+			return user_types.CannotFollowBlockedUserError{}
+		}
+	}
+
+	return nil
+}
+
+func (r *sqliteUserRepo) Unfollow(ctx context.Context, followedByUserId uuid.UUID, followingUserId uuid.UUID) error {
+	q := sqlite.Delete(
+		dm.From(followersTableName),
+		dm.Where(sqlite.Quote("followed_by_user_id").EQ(sqlite.Arg(followedByUserId))),
+		dm.Where(sqlite.Quote("following_user_id").EQ(sqlite.Arg(followingUserId))),
+	)
+
+	_, err := bob.Exec(ctx, db_types.ExecutorFromCtx(ctx, r.db), q)
+	if err != nil {
+		return fmt.Errorf("error executing unfollow query: %w", err)
+	}
+
+	return nil
+}
+
+func (r *sqliteUserRepo) Block(ctx context.Context, blockedByUserId uuid.UUID, blockedUserId uuid.UUID) error {
+	removeFollows := sqlite.Delete(
+		dm.From(followersTableName),
+		dm.Where(
+			sqlite.Or(
+				sqlite.And(
+					sqlite.Quote("followed_by_user_id").EQ(sqlite.Arg(blockedByUserId)),
+					sqlite.Quote("following_user_id").EQ(sqlite.Arg(blockedUserId)),
+				),
+				sqlite.And(
+					sqlite.Quote("followed_by_user_id").EQ(sqlite.Arg(blockedUserId)),
+					sqlite.Quote("following_user_id").EQ(sqlite.Arg(blockedByUserId)),
+				),
+			),
+		),
+	)
+
+	recordBlock := sqlite.Insert(
+		im.Into(blocksTableName, "blocked_by_user_id", "blocked_user_id"),
+		im.Values(sqlite.Arg(blockedByUserId), sqlite.Arg(blockedUserId)),
+		im.OnConflict("blocked_by_user_id", "blocked_user_id").DoNothing(),
+	)
+
+	err := r.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		executor := db_types.ExecutorFromCtx(ctx, r.db)
+		if _, err := bob.Exec(ctx, executor, removeFollows); err != nil {
+			return err
+		}
+		_, err := bob.Exec(ctx, executor, recordBlock)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("error executing block query: %w", err)
+	}
+
+	return nil
+}
+
+func (r *sqliteUserRepo) Unblock(ctx context.Context, blockedByUserId uuid.UUID, blockedUserId uuid.UUID) error {
+	q := sqlite.Delete(
+		dm.From(blocksTableName),
+		dm.Where(sqlite.Quote("blocked_by_user_id").EQ(sqlite.Arg(blockedByUserId))),
+		dm.Where(sqlite.Quote("blocked_user_id").EQ(sqlite.Arg(blockedUserId))),
+	)
+
+	_, err := bob.Exec(ctx, db_types.ExecutorFromCtx(ctx, r.db), q)
+	if err != nil {
+		return fmt.Errorf("error executing unblock query: %w", err)
+	}
+
+	return nil
+}
+
+func (r *sqliteUserRepo) IsBlocking(ctx context.Context, blockedByUserId uuid.UUID, blockedUserId uuid.UUID) (bool, error) {
+	exists, err := r.exists(ctx, sqlite.Select(
+		sm.From(blocksTableName),
+		sm.Columns("blocked_by_user_id"),
+		sm.Where(sqlite.Quote("blocked_by_user_id").EQ(sqlite.Arg(blockedByUserId))),
+		sm.Where(sqlite.Quote("blocked_user_id").EQ(sqlite.Arg(blockedUserId))),
+	))
+	if err != nil {
+		return false, fmt.Errorf("error with is blocking query: %w", err)
+	}
+
+	return exists, nil
+}
+
+func (r *sqliteUserRepo) IsBlockedEitherWay(ctx context.Context, userIdA uuid.UUID, userIdB uuid.UUID) (bool, error) {
+	exists, err := r.exists(ctx, sqliteBlockedEitherWayQuery(userIdA, userIdB))
+	if err != nil {
+		return false, fmt.Errorf("error with is blocked either way query: %w", err)
+	}
+
+	return exists, nil
+}
+
+// sqliteBlockedEitherWayQuery is blockedEitherWayQuery for sqlite
+func sqliteBlockedEitherWayQuery(userIdA uuid.UUID, userIdB uuid.UUID) bob.Query {
+	return sqlite.Select(
+		sm.From(blocksTableName),
+		sm.Columns("blocked_by_user_id"),
+		sm.Where(
+			sqlite.Or(
+				sqlite.And(
+					sqlite.Quote("blocked_by_user_id").EQ(sqlite.Arg(userIdA)),
+					sqlite.Quote("blocked_user_id").EQ(sqlite.Arg(userIdB)),
+				),
+				sqlite.And(
+					sqlite.Quote("blocked_by_user_id").EQ(sqlite.Arg(userIdB)),
+					sqlite.Quote("blocked_user_id").EQ(sqlite.Arg(userIdA)),
+				),
+			),
+		),
+	)
+}
+
+func (r *sqliteUserRepo) Mute(ctx context.Context, mutedByUserId uuid.UUID, mutedUserId uuid.UUID) error {
+	q := sqlite.Insert(
+		im.Into(mutesTableName, "muted_by_user_id", "muted_user_id"),
+		im.Values(sqlite.Arg(mutedByUserId), sqlite.Arg(mutedUserId)),
+		im.OnConflict("muted_by_user_id", "muted_user_id").DoNothing(),
+	)
+
+	_, err := bob.Exec(ctx, db_types.ExecutorFromCtx(ctx, r.db), q)
+	if err != nil {
+		return fmt.Errorf("error executing mute query: %w", err)
+	}
+
+	return nil
+}
+
+func (r *sqliteUserRepo) Unmute(ctx context.Context, mutedByUserId uuid.UUID, mutedUserId uuid.UUID) error {
+	q := sqlite.Delete(
+		dm.From(mutesTableName),
+		dm.Where(sqlite.Quote("muted_by_user_id").EQ(sqlite.Arg(mutedByUserId))),
+		dm.Where(sqlite.Quote("muted_user_id").EQ(sqlite.Arg(mutedUserId))),
+	)
+
+	_, err := bob.Exec(ctx, db_types.ExecutorFromCtx(ctx, r.db), q)
+	if err != nil {
+		return fmt.Errorf("error executing unmute query: %w", err)
+	}
+
+	return nil
+}
+
+func (r *sqliteUserRepo) IsMuting(ctx context.Context, mutedByUserId uuid.UUID, mutedUserId uuid.UUID) (bool, error) {
+	exists, err := r.exists(ctx, sqlite.Select(
+		sm.From(mutesTableName),
+		sm.Columns("muted_by_user_id"),
+		sm.Where(sqlite.Quote("muted_by_user_id").EQ(sqlite.Arg(mutedByUserId))),
+		sm.Where(sqlite.Quote("muted_user_id").EQ(sqlite.Arg(mutedUserId))),
+	))
+	if err != nil {
+		return false, fmt.Errorf("error with is muting query: %w", err)
+	}
+
+	return exists, nil
+}
+
+func (r *sqliteUserRepo) ListHiddenUserIds(ctx context.Context, viewerUserId uuid.UUID) ([]uuid.UUID, error) {
+	// bob parenthesizes the right side of a UNION, which sqlite rejects, so the two sets are matched against users
+	q := sqlite.Select(
+		sm.Columns(sqlite.Quote("id")),
+		sm.From(usersTableName),
+		sm.Where(sqlite.Or(
+			sqliteInSubquery(sqlite.Quote("id"), sqlite.Select(
+				sm.Columns(sqlite.Quote("blocked_user_id")),
+				sm.From(blocksTableName),
+				sm.Where(sqlite.Quote("blocked_by_user_id").EQ(sqlite.Arg(viewerUserId))),
+			)),
+			sqliteInSubquery(sqlite.Quote("id"), sqlite.Select(
+				sm.Columns(sqlite.Quote("muted_user_id")),
+				sm.From(mutesTableName),
+				sm.Where(sqlite.Quote("muted_by_user_id").EQ(sqlite.Arg(viewerUserId))),
+			)),
+		)),
+	)
+
+	result, err := bob.All(ctx, db_types.ReaderFromCtx(ctx, r.db), q, scan.SingleColumnMapper[uuid.UUID])
+	if err != nil {
+		return nil, fmt.Errorf("error with list hidden user ids query, viewer_user_id=%v: %w", viewerUserId.String(), err)
+	}
+
+	return result, nil
+}
+
+// getOne runs a query for at most one user, desc describes the query for the error message
+func (r *sqliteUserRepo) getOne(ctx context.Context, q bob.Query, desc string) (mo.Option[user_types.User], error) {
+	result, err := bob.One(ctx, db_types.ReaderFromCtx(ctx, r.db), q, scan.StructMapper[postgresUser]())
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return mo.None[user_types.User](), nil
+		}
+		return mo.None[user_types.User](), fmt.Errorf("error with %v: %w", desc, err)
+	}
+
+	return mo.Some(fromPostgresUser(result)), nil
+}
+
+// exists wraps the subquery in a SELECT EXISTS ... and returns the result
+func (r *sqliteUserRepo) exists(ctx context.Context, subquery bob.Query) (bool, error) {
+	// sqlite.F would wrap the subquery in a second set of parentheses, which sqlite does not accept after EXISTS
+	q := sqlite.Select(sm.Columns(sqlite.Raw("EXISTS ?", subquery)))
+
+	exists, err := bob.One(ctx, db_types.ReaderFromCtx(ctx, r.db), q, scan.SingleColumnMapper[bool])
+	if err != nil {
+		return false, fmt.Errorf("error executing exists query: %w", err)
+	}
+
+	return exists, nil
+}
+
+// asSqliteConflictError is asConflictError for sqlite, which names the violated index in the error message
+func asSqliteConflictError(err error) (user_types.ConflictError, bool) {
+	sqliteErr, ok := errors.AsType[*sqlite_driver.Error](err)
+	if !ok || sqliteErr.Code() != sqlite_lib.SQLITE_CONSTRAINT_UNIQUE {
+		return user_types.ConflictError{}, false
+	}
+
+	switch msg := sqliteErr.Error(); {
+	case strings.Contains(msg, usernameUniqueIndexName):
+		return user_types.ConflictError{Msg: "username already exists"}, true
+	case strings.Contains(msg, emailUniqueIndexName):
+		return user_types.ConflictError{Msg: "email already exists"}, true
+	default:
+		return user_types.ConflictError{}, false
+	}
+}
+
+func sqliteCaseInsensitiveEQ(column string, value string) bob.Expression {
+	return sqlite.F("LOWER", sqlite.Quote(column))().EQ(sqlite.F("LOWER", sqlite.Arg(value))())
+}
+
+// sqliteInSubquery is column IN (subquery). In() would wrap the subquery in a second set of parentheses, turning it
+// into a scalar subquery that only matches its first row
+func sqliteInSubquery(column bob.Expression, subquery bob.Query) bob.Expression {
+	return sqlite.Raw("? IN ?", column, subquery)
+}
+
+func sqliteUuidArgs(ids []uuid.UUID) bob.Expression {
+	args := make([]any, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+	return sqlite.Arg(args...)
+}
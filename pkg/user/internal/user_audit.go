@@ -0,0 +1,89 @@
+package internal
+
+import (
+	audit_types "github.com/nimaeskandary/go-realworld/pkg/audit/types"
+	user_types "github.com/nimaeskandary/go-realworld/pkg/user/types"
+
+	"github.com/google/uuid"
+	"github.com/samber/mo"
+)
+
+// auditedUser is the state of a user recorded in the audit log, the fields a user changes. Times and versions change
+// on every write, so they are left out, and an update that only touches them is not recorded
+type auditedUser struct {
+	Username string            `json:"username"`
+	Email    string            `json:"email"`
+	Bio      mo.Option[string] `json:"bio"`
+	Image    mo.Option[string] `json:"image"`
+}
+
+func toAuditedUser(user user_types.User) auditedUser {
+	return auditedUser{
+		Username: user.Username,
+		Email:    user.Email,
+		Bio:      user.Bio,
+		Image:    user.Image,
+	}
+}
+
+// auditedFollow is the state of a follow recorded in the audit log
+type auditedFollow struct {
+	FollowedByUserId uuid.UUID `json:"followed_by_user_id"`
+	FollowingUserId  uuid.UUID `json:"following_user_id"`
+}
+
+func userChange(action audit_types.AuditAction, id uuid.UUID, before any, after any) audit_types.AuditChange {
+	return audit_types.AuditChange{
+		Action:     action,
+		EntityType: audit_types.AuditEntityUser,
+		EntityId:   id.String(),
+		Before:     before,
+		After:      after,
+	}
+}
+
+// auditedBlock is the state of a block recorded in the audit log
+type auditedBlock struct {
+	BlockedByUserId uuid.UUID `json:"blocked_by_user_id"`
+	BlockedUserId   uuid.UUID `json:"blocked_user_id"`
+}
+
+// auditedMute is the state of a mute recorded in the audit log
+type auditedMute struct {
+	MutedByUserId uuid.UUID `json:"muted_by_user_id"`
+	MutedUserId   uuid.UUID `json:"muted_user_id"`
+}
+
+// followChange is the creation or deletion of a follow, the follow is the entity's state after or before the change
+func followChange(action audit_types.AuditAction, followedByUserId uuid.UUID, followingUserId uuid.UUID) audit_types.AuditChange {
+	follow := auditedFollow{FollowedByUserId: followedByUserId, FollowingUserId: followingUserId}
+	return relationChange(action, audit_types.AuditEntityFollow, followingUserId, follow)
+}
+
+// blockChange is the creation or deletion of a block, like a followChange
+func blockChange(action audit_types.AuditAction, blockedByUserId uuid.UUID, blockedUserId uuid.UUID) audit_types.AuditChange {
+	block := auditedBlock{BlockedByUserId: blockedByUserId, BlockedUserId: blockedUserId}
+	return relationChange(action, audit_types.AuditEntityBlock, blockedUserId, block)
+}
+
+// muteChange is the creation or deletion of a mute, like a followChange
+func muteChange(action audit_types.AuditAction, mutedByUserId uuid.UUID, mutedUserId uuid.UUID) audit_types.AuditChange {
+	mute := auditedMute{MutedByUserId: mutedByUserId, MutedUserId: mutedUserId}
+	return relationChange(action, audit_types.AuditEntityMute, mutedUserId, mute)
+}
+
+// relationChange is the creation or deletion of a relation from one user to another, identified by the other user.
+// The relation is the entity's state after or before the change
+func relationChange(action audit_types.AuditAction, entityType audit_types.AuditEntityType, toUserId uuid.UUID, relation any) audit_types.AuditChange {
+	change := audit_types.AuditChange{
+		Action:     action,
+		EntityType: entityType,
+		EntityId:   toUserId.String(),
+	}
+	if action == audit_types.AuditActionDelete {
+		change.Before = relation
+	} else {
+		change.After = relation
+	}
+	return change
+}
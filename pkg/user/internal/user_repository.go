@@ -0,0 +1,21 @@
+package internal
+
+import (
+	"fmt"
+
+	"github.com/nimaeskandary/go-realworld/pkg/database/types"
+	"github.com/nimaeskandary/go-realworld/pkg/observability/types"
+	"github.com/nimaeskandary/go-realworld/pkg/user/types"
+)
+
+// NewUserRepository returns the repository for the database's dialect
+func NewUserRepository(db db_types.RealWorldAppDb, txManager db_types.TxManager, logger obs_types.Logger) (user_types.UserRepository, error) {
+	switch db.GetDialect() {
+	case db_types.DialectPostgres:
+		return NewPostgresUserRepository(db, logger), nil
+	case db_types.DialectSqlite:
+		return NewSqliteUserRepository(db, txManager, logger), nil
+	default:
+		return nil, fmt.Errorf("no user repository for dialect: %v", db.GetDialect())
+	}
+}
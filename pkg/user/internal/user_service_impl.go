@@ -2,23 +2,40 @@ package internal
 
 import (
 	"context"
+	"strings"
 	"time"
 
+	audit_types "github.com/nimaeskandary/go-realworld/pkg/audit/types"
+	db_types "github.com/nimaeskandary/go-realworld/pkg/database/types"
+	soft_delete_types "github.com/nimaeskandary/go-realworld/pkg/soft_delete/types"
 	user_types "github.com/nimaeskandary/go-realworld/pkg/user/types"
 
 	"github.com/google/uuid"
+	"github.com/samber/mo"
 	"golang.org/x/sync/errgroup"
 )
 
 type userServiceImpl struct {
-	userRepo    user_types.UserRepository
-	validations user_types.UserValidations
+	userRepo      user_types.UserRepository
+	validations   user_types.UserValidations
+	softDeleteCfg soft_delete_types.SoftDeleteConfig
+	txManager     db_types.TxManager
+	auditLog      audit_types.AuditLog
 }
 
-func NewUserServiceImpl(userRepo user_types.UserRepository, validations user_types.UserValidations) user_types.UserService {
+func NewUserServiceImpl(
+	userRepo user_types.UserRepository,
+	validations user_types.UserValidations,
+	softDeleteCfg soft_delete_types.SoftDeleteConfig,
+	txManager db_types.TxManager,
+	auditLog audit_types.AuditLog,
+) user_types.UserService {
 	return &userServiceImpl{
-		userRepo:    userRepo,
-		validations: validations,
+		userRepo:      userRepo,
+		validations:   validations,
+		softDeleteCfg: softDeleteCfg,
+		txManager:     txManager,
+		auditLog:      auditLog,
 	}
 }
 
@@ -37,26 +54,39 @@ func (s *userServiceImpl) CreateUser(ctx context.Context, params user_types.Upse
 	eg, egCtx := errgroup.WithContext(ctx)
 	eg.Go(func() error { return s.validations.ValidateUser(newUser) })
 	eg.Go(func() error { return s.validations.ValidateUsernameDoesNotConflict(egCtx, newUser.Username) })
+	eg.Go(func() error { return s.validations.ValidateUsernameNotReserved(egCtx, newUser.Username, newUser.Id) })
 	eg.Go(func() error { return s.validations.ValidateEmailDoesNotConflict(egCtx, newUser.Email) })
 
 	if err := eg.Wait(); err != nil {
 		return user_types.User{}, user_types.AsDomainError(err)
 	}
 
-	created, err := s.userRepo.UpsertUser(ctx, newUser)
+	var created user_types.User
+	err := s.withAudit(ctx, func(ctx context.Context) (audit_types.AuditChange, error) {
+		var err error
+		created, err = s.userRepo.UpsertUser(ctx, newUser)
+		if err != nil {
+			return audit_types.AuditChange{}, err
+		}
+		return userChange(audit_types.AuditActionCreate, created.Id, nil, toAuditedUser(created)), nil
+	})
 	if err != nil {
 		return user_types.User{}, user_types.AsDomainError(err)
 	}
 	return created, nil
 }
 
-func (s *userServiceImpl) UpdateUser(ctx context.Context, id uuid.UUID, params user_types.UpsertUserParams) (user_types.User, user_types.DomainError) {
+func (s *userServiceImpl) UpdateUser(ctx context.Context, id uuid.UUID, params user_types.UpsertUserParams, expectedVersion mo.Option[int64]) (user_types.User, user_types.DomainError) {
 	var err error
+	ctx = db_types.CtxWithPrimaryReads(ctx)
 
 	existingUser, err := s.validations.ValidateUserIdExists(ctx, id)
 	if err != nil {
 		return user_types.User{}, user_types.AsDomainError(err)
 	}
+	if expectedVersion.IsPresent() && expectedVersion.MustGet() != existingUser.Version {
+		return user_types.User{}, user_types.VersionConflictError{Identifier: id.String()}
+	}
 
 	updatedUser := user_types.User{
 		Id:              existingUser.Id,
@@ -66,6 +96,7 @@ func (s *userServiceImpl) UpdateUser(ctx context.Context, id uuid.UUID, params u
 		Image:           params.Image,
 		CreatedAtMillis: existingUser.CreatedAtMillis,
 		UpdatedAtMillis: time.Now().UnixMilli(),
+		Version:         existingUser.Version,
 	}
 
 	err = s.validations.ValidateUser(updatedUser)
@@ -75,10 +106,14 @@ func (s *userServiceImpl) UpdateUser(ctx context.Context, id uuid.UUID, params u
 
 	eg, egCtx := errgroup.WithContext(ctx)
 
-	if updatedUser.Username != existingUser.Username {
+	// uniqueness ignores case, so a change in case only would otherwise conflict with the user itself
+	if !strings.EqualFold(updatedUser.Username, existingUser.Username) {
 		eg.Go(func() error { return s.validations.ValidateUsernameDoesNotConflict(egCtx, updatedUser.Username) })
+		eg.Go(func() error {
+			return s.validations.ValidateUsernameNotReserved(egCtx, updatedUser.Username, updatedUser.Id)
+		})
 	}
-	if updatedUser.Email != existingUser.Email {
+	if !strings.EqualFold(updatedUser.Email, existingUser.Email) {
 		eg.Go(func() error { return s.validations.ValidateEmailDoesNotConflict(egCtx, updatedUser.Email) })
 	}
 
@@ -86,7 +121,15 @@ func (s *userServiceImpl) UpdateUser(ctx context.Context, id uuid.UUID, params u
 		return user_types.User{}, user_types.AsDomainError(err)
 	}
 
-	updated, err := s.userRepo.UpsertUser(ctx, updatedUser)
+	var updated user_types.User
+	err = s.withAudit(ctx, func(ctx context.Context) (audit_types.AuditChange, error) {
+		var err error
+		updated, err = s.userRepo.UpsertUser(ctx, updatedUser)
+		if err != nil {
+			return audit_types.AuditChange{}, err
+		}
+		return userChange(audit_types.AuditActionUpdate, updated.Id, toAuditedUser(existingUser), toAuditedUser(updated)), nil
+	})
 	if err != nil {
 		return user_types.User{}, user_types.AsDomainError(err)
 	}
@@ -94,7 +137,19 @@ func (s *userServiceImpl) UpdateUser(ctx context.Context, id uuid.UUID, params u
 }
 
 func (s *userServiceImpl) DeleteUser(ctx context.Context, id uuid.UUID) user_types.DomainError {
-	err := s.userRepo.DeleteUser(ctx, id)
+	err := s.withAudit(ctx, func(ctx context.Context) (audit_types.AuditChange, error) {
+		existing, err := s.userRepo.GetUserById(ctx, id)
+		if err != nil {
+			return audit_types.AuditChange{}, err
+		}
+		if err := s.userRepo.DeleteUser(ctx, id); err != nil {
+			return audit_types.AuditChange{}, err
+		}
+		if user, ok := existing.Get(); ok {
+			return userChange(audit_types.AuditActionDelete, id, toAuditedUser(user), nil), nil
+		}
+		return audit_types.AuditChange{}, nil
+	})
 
 	if err != nil {
 		return user_types.AsDomainError(err)
@@ -103,6 +158,38 @@ func (s *userServiceImpl) DeleteUser(ctx context.Context, id uuid.UUID) user_typ
 	return nil
 }
 
+func (s *userServiceImpl) RestoreUser(ctx context.Context, id uuid.UUID) (user_types.User, user_types.DomainError) {
+	deletedOpt, err := s.userRepo.GetDeletedUserById(ctx, id, s.softDeleteCfg.GracePeriodCutoff(time.Now()))
+	if err != nil {
+		return user_types.User{}, user_types.AsDomainError(err)
+	}
+	deleted, ok := deletedOpt.Get()
+	if !ok {
+		return user_types.User{}, user_types.NotFoundError{Identifier: id.String()}
+	}
+
+	// the username and email are released on delete, so another user may have claimed them since
+	eg, egCtx := errgroup.WithContext(ctx)
+	eg.Go(func() error { return s.validations.ValidateUsernameDoesNotConflict(egCtx, deleted.Username) })
+	eg.Go(func() error { return s.validations.ValidateUsernameNotReserved(egCtx, deleted.Username, deleted.Id) })
+	eg.Go(func() error { return s.validations.ValidateEmailDoesNotConflict(egCtx, deleted.Email) })
+
+	if err := eg.Wait(); err != nil {
+		return user_types.User{}, user_types.AsDomainError(err)
+	}
+
+	err = s.withAudit(ctx, func(ctx context.Context) (audit_types.AuditChange, error) {
+		if err := s.userRepo.RestoreUser(ctx, id); err != nil {
+			return audit_types.AuditChange{}, err
+		}
+		return userChange(audit_types.AuditActionRestore, id, nil, toAuditedUser(deleted)), nil
+	})
+	if err != nil {
+		return user_types.User{}, user_types.AsDomainError(err)
+	}
+	return deleted, nil
+}
+
 func (s *userServiceImpl) GetUserByEmail(ctx context.Context, email string) (user_types.User, user_types.DomainError) {
 	result, err := s.userRepo.GetUserByEmail(ctx, email)
 	if err != nil {
@@ -125,6 +212,27 @@ func (s *userServiceImpl) GetUserByUsername(ctx context.Context, username string
 	return result.MustGet(), nil
 }
 
+func (s *userServiceImpl) ResolveUsername(ctx context.Context, username string) (user_types.User, user_types.DomainError) {
+	user, err := s.validations.ValidateUsernameExists(ctx, username)
+	if err != nil {
+		return user_types.User{}, user_types.AsDomainError(err)
+	}
+	return user, nil
+}
+
+func (s *userServiceImpl) GetUsersByIds(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]user_types.User, user_types.DomainError) {
+	result, err := s.userRepo.GetUsersByIds(ctx, ids)
+	if err != nil {
+		return nil, user_types.AsDomainError(err)
+	}
+
+	users := make(map[uuid.UUID]user_types.User, len(result))
+	for _, user := range result {
+		users[user.Id] = user
+	}
+	return users, nil
+}
+
 func (s *userServiceImpl) IsFollowing(ctx context.Context, authUser user_types.User, targetUsername string) (bool, user_types.DomainError) {
 	var err error
 	targetUser, err := s.validations.ValidateUsernameExists(ctx, targetUsername)
@@ -139,6 +247,14 @@ func (s *userServiceImpl) IsFollowing(ctx context.Context, authUser user_types.U
 	return isFollowing, nil
 }
 
+func (s *userServiceImpl) AreFollowing(ctx context.Context, authUser user_types.User, targetUserIds []uuid.UUID) (map[uuid.UUID]bool, user_types.DomainError) {
+	following, err := s.userRepo.AreFollowing(ctx, authUser.Id, targetUserIds)
+	if err != nil {
+		return nil, user_types.AsDomainError(err)
+	}
+	return following, nil
+}
+
 func (s *userServiceImpl) FollowProfile(ctx context.Context, authUser user_types.User, targetUsername string) (user_types.User, user_types.DomainError) {
 	var err error
 	targetUser, err := s.validations.ValidateUsernameExists(ctx, targetUsername)
@@ -149,8 +265,25 @@ func (s *userServiceImpl) FollowProfile(ctx context.Context, authUser user_types
 	if err != nil {
 		return user_types.User{}, user_types.AsDomainError(err)
 	}
+	err = s.validations.ValidateNotBlocked(ctx, authUser.Id, targetUser.Id)
+	if err != nil {
+		return user_types.User{}, user_types.AsDomainError(err)
+	}
 
-	err = s.userRepo.Follow(ctx, authUser.Id, targetUser.Id)
+	err = s.withAudit(ctx, func(ctx context.Context) (audit_types.AuditChange, error) {
+		wasFollowing, err := s.userRepo.IsFollowing(ctx, authUser.Id, targetUser.Id)
+		if err != nil {
+			return audit_types.AuditChange{}, err
+		}
+		if err := s.userRepo.Follow(ctx, authUser.Id, targetUser.Id); err != nil {
+			return audit_types.AuditChange{}, err
+		}
+		// following again changes nothing, so nothing is recorded
+		if wasFollowing {
+			return audit_types.AuditChange{}, nil
+		}
+		return followChange(audit_types.AuditActionCreate, authUser.Id, targetUser.Id), nil
+	})
 	if err != nil {
 		return user_types.User{}, user_types.AsDomainError(err)
 	}
@@ -164,9 +297,172 @@ func (s *userServiceImpl) UnfollowProfile(ctx context.Context, authUser user_typ
 		return user_types.User{}, user_types.AsDomainError(err)
 	}
 
-	err = s.userRepo.Unfollow(ctx, authUser.Id, targetUser.Id)
+	err = s.withAudit(ctx, func(ctx context.Context) (audit_types.AuditChange, error) {
+		wasFollowing, err := s.userRepo.IsFollowing(ctx, authUser.Id, targetUser.Id)
+		if err != nil {
+			return audit_types.AuditChange{}, err
+		}
+		if err := s.userRepo.Unfollow(ctx, authUser.Id, targetUser.Id); err != nil {
+			return audit_types.AuditChange{}, err
+		}
+		if !wasFollowing {
+			return audit_types.AuditChange{}, nil
+		}
+		return followChange(audit_types.AuditActionDelete, authUser.Id, targetUser.Id), nil
+	})
+	if err != nil {
+		return user_types.User{}, user_types.AsDomainError(err)
+	}
+	return targetUser, nil
+}
+
+func (s *userServiceImpl) BlockProfile(ctx context.Context, authUser user_types.User, targetUsername string) (user_types.User, user_types.DomainError) {
+	var err error
+	targetUser, err := s.validations.ValidateUsernameExists(ctx, targetUsername)
+	if err != nil {
+		return user_types.User{}, user_types.AsDomainError(err)
+	}
+	err = s.validations.ValidateCanBlockOrMute(authUser.Id, targetUser.Id)
+	if err != nil {
+		return user_types.User{}, user_types.AsDomainError(err)
+	}
+
+	// blocking removes the follows between the users, which are recorded as unfollows
+	err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		var changes []audit_types.AuditChange
+		wasBlocking, err := s.userRepo.IsBlocking(ctx, authUser.Id, targetUser.Id)
+		if err != nil {
+			return err
+		}
+		// blocking again changes nothing, so nothing is recorded
+		if !wasBlocking {
+			changes = append(changes, blockChange(audit_types.AuditActionCreate, authUser.Id, targetUser.Id))
+		}
+		follows := [][2]uuid.UUID{{authUser.Id, targetUser.Id}, {targetUser.Id, authUser.Id}}
+		for _, follow := range follows {
+			following, err := s.userRepo.IsFollowing(ctx, follow[0], follow[1])
+			if err != nil {
+				return err
+			}
+			if following {
+				changes = append(changes, followChange(audit_types.AuditActionDelete, follow[0], follow[1]))
+			}
+		}
+
+		if err := s.userRepo.Block(ctx, authUser.Id, targetUser.Id); err != nil {
+			return err
+		}
+		for _, change := range changes {
+			if err := s.auditLog.Record(ctx, change); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return user_types.User{}, user_types.AsDomainError(err)
+	}
+	return targetUser, nil
+}
+
+func (s *userServiceImpl) UnblockProfile(ctx context.Context, authUser user_types.User, targetUsername string) (user_types.User, user_types.DomainError) {
+	var err error
+	targetUser, err := s.validations.ValidateUsernameExists(ctx, targetUsername)
+	if err != nil {
+		return user_types.User{}, user_types.AsDomainError(err)
+	}
+
+	err = s.withAudit(ctx, func(ctx context.Context) (audit_types.AuditChange, error) {
+		wasBlocking, err := s.userRepo.IsBlocking(ctx, authUser.Id, targetUser.Id)
+		if err != nil {
+			return audit_types.AuditChange{}, err
+		}
+		if err := s.userRepo.Unblock(ctx, authUser.Id, targetUser.Id); err != nil {
+			return audit_types.AuditChange{}, err
+		}
+		if !wasBlocking {
+			return audit_types.AuditChange{}, nil
+		}
+		return blockChange(audit_types.AuditActionDelete, authUser.Id, targetUser.Id), nil
+	})
+	if err != nil {
+		return user_types.User{}, user_types.AsDomainError(err)
+	}
+	return targetUser, nil
+}
+
+func (s *userServiceImpl) MuteProfile(ctx context.Context, authUser user_types.User, targetUsername string) (user_types.User, user_types.DomainError) {
+	var err error
+	targetUser, err := s.validations.ValidateUsernameExists(ctx, targetUsername)
+	if err != nil {
+		return user_types.User{}, user_types.AsDomainError(err)
+	}
+	err = s.validations.ValidateCanBlockOrMute(authUser.Id, targetUser.Id)
+	if err != nil {
+		return user_types.User{}, user_types.AsDomainError(err)
+	}
+
+	err = s.withAudit(ctx, func(ctx context.Context) (audit_types.AuditChange, error) {
+		wasMuting, err := s.userRepo.IsMuting(ctx, authUser.Id, targetUser.Id)
+		if err != nil {
+			return audit_types.AuditChange{}, err
+		}
+		if err := s.userRepo.Mute(ctx, authUser.Id, targetUser.Id); err != nil {
+			return audit_types.AuditChange{}, err
+		}
+		if wasMuting {
+			return audit_types.AuditChange{}, nil
+		}
+		return muteChange(audit_types.AuditActionCreate, authUser.Id, targetUser.Id), nil
+	})
+	if err != nil {
+		return user_types.User{}, user_types.AsDomainError(err)
+	}
+	return targetUser, nil
+}
+
+func (s *userServiceImpl) UnmuteProfile(ctx context.Context, authUser user_types.User, targetUsername string) (user_types.User, user_types.DomainError) {
+	var err error
+	targetUser, err := s.validations.ValidateUsernameExists(ctx, targetUsername)
+	if err != nil {
+		return user_types.User{}, user_types.AsDomainError(err)
+	}
+
+	err = s.withAudit(ctx, func(ctx context.Context) (audit_types.AuditChange, error) {
+		wasMuting, err := s.userRepo.IsMuting(ctx, authUser.Id, targetUser.Id)
+		if err != nil {
+			return audit_types.AuditChange{}, err
+		}
+		if err := s.userRepo.Unmute(ctx, authUser.Id, targetUser.Id); err != nil {
+			return audit_types.AuditChange{}, err
+		}
+		if !wasMuting {
+			return audit_types.AuditChange{}, nil
+		}
+		return muteChange(audit_types.AuditActionDelete, authUser.Id, targetUser.Id), nil
+	})
 	if err != nil {
 		return user_types.User{}, user_types.AsDomainError(err)
 	}
 	return targetUser, nil
 }
+
+func (s *userServiceImpl) GetHiddenUserIds(ctx context.Context, viewer user_types.User) ([]uuid.UUID, user_types.DomainError) {
+	hidden, err := s.userRepo.ListHiddenUserIds(ctx, viewer.Id)
+	if err != nil {
+		return nil, user_types.AsDomainError(err)
+	}
+	return hidden, nil
+}
+
+// withAudit runs write in a transaction, recording the change it returns in the audit log. A zero change records
+// nothing, e.g. for a write that turned out to change nothing
+func (s *userServiceImpl) withAudit(ctx context.Context, write func(ctx context.Context) (audit_types.AuditChange, error)) error {
+	return s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		change, err := write(ctx)
+		if err != nil {
+			return err
+		}
+		return s.auditLog.Record(ctx, change)
+	})
+}
@@ -1,10 +1,16 @@
 package internal_test
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"testing"
 
+	audit_types "github.com/nimaeskandary/go-realworld/pkg/audit/types"
+	audit_types_mocks "github.com/nimaeskandary/go-realworld/pkg/audit/types/mocks"
+	db_types_mocks "github.com/nimaeskandary/go-realworld/pkg/database/types/mocks"
+	"github.com/nimaeskandary/go-realworld/pkg/test_utils/config"
 	"github.com/nimaeskandary/go-realworld/pkg/test_utils/helpers"
 	"github.com/nimaeskandary/go-realworld/pkg/user/internal"
 	user_types "github.com/nimaeskandary/go-realworld/pkg/user/types"
@@ -23,21 +29,35 @@ func Test_UserServiceImpl(t *testing.T) {
 	type testFixture struct {
 		userRepoMock    *user_types_mocks.MockUserRepository
 		validationsMock *user_types_mocks.MockUserValidations
+		auditLogMock    *audit_types_mocks.MockAuditLog
 		underTest       user_types.UserService
 	}
 
 	setup := func(t *testing.T) testFixture {
 		userRepoMock := user_types_mocks.NewMockUserRepository(t)
 		validationsMock := user_types_mocks.NewMockUserValidations(t)
-		underTest := internal.NewUserServiceImpl(userRepoMock, validationsMock)
+		auditLogMock := audit_types_mocks.NewMockAuditLog(t)
+		txManagerMock := db_types_mocks.NewMockTxManager(t)
+		txManagerMock.EXPECT().WithinTx(mock.Anything, mock.Anything).
+			RunAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error { return fn(ctx) }).
+			Maybe()
+		underTest := internal.NewUserServiceImpl(userRepoMock, validationsMock, config.NewTestConfig().SoftDelete, txManagerMock, auditLogMock)
 
 		return testFixture{
 			userRepoMock:    userRepoMock,
 			validationsMock: validationsMock,
+			auditLogMock:    auditLogMock,
 			underTest:       underTest,
 		}
 	}
 
+	// auditChange matches a change recorded in the audit log by its action and entity
+	auditChange := func(action audit_types.AuditAction, entityType audit_types.AuditEntityType, entityId uuid.UUID) any {
+		return mock.MatchedBy(func(change audit_types.AuditChange) bool {
+			return change.Action == action && change.EntityType == entityType && change.EntityId == entityId.String()
+		})
+	}
+
 	t.Run("CreateUser", func(t *testing.T) {
 		t.Parallel()
 
@@ -52,6 +72,7 @@ func Test_UserServiceImpl(t *testing.T) {
 
 			f.validationsMock.EXPECT().ValidateUser(mock.MatchedBy(helpers.UserMatchesUpsertParams(params))).Return(user_types.BadParamsError{})
 			f.validationsMock.EXPECT().ValidateUsernameDoesNotConflict(mock.Anything, params.Username).Return(nil)
+			f.validationsMock.EXPECT().ValidateUsernameNotReserved(mock.Anything, params.Username, mock.Anything).Return(nil)
 			f.validationsMock.EXPECT().ValidateEmailDoesNotConflict(mock.Anything, params.Email).Return(nil)
 
 			created, err := f.underTest.CreateUser(t.Context(), params)
@@ -66,6 +87,7 @@ func Test_UserServiceImpl(t *testing.T) {
 
 			f.validationsMock.EXPECT().ValidateUser(mock.MatchedBy(helpers.UserMatchesUpsertParams(params))).Return(nil)
 			f.validationsMock.EXPECT().ValidateUsernameDoesNotConflict(mock.Anything, params.Username).Return(user_types.ConflictError{})
+			f.validationsMock.EXPECT().ValidateUsernameNotReserved(mock.Anything, params.Username, mock.Anything).Return(nil)
 			f.validationsMock.EXPECT().ValidateEmailDoesNotConflict(mock.Anything, params.Email).Return(nil)
 
 			created, err := f.underTest.CreateUser(t.Context(), params)
@@ -80,6 +102,7 @@ func Test_UserServiceImpl(t *testing.T) {
 
 			f.validationsMock.EXPECT().ValidateUser(mock.MatchedBy(helpers.UserMatchesUpsertParams(params))).Return(nil)
 			f.validationsMock.EXPECT().ValidateUsernameDoesNotConflict(mock.Anything, params.Username).Return(nil)
+			f.validationsMock.EXPECT().ValidateUsernameNotReserved(mock.Anything, params.Username, mock.Anything).Return(nil)
 			f.validationsMock.EXPECT().ValidateEmailDoesNotConflict(mock.Anything, params.Email).Return(user_types.ConflictError{})
 
 			created, err := f.underTest.CreateUser(t.Context(), params)
@@ -96,6 +119,7 @@ func Test_UserServiceImpl(t *testing.T) {
 
 			f.validationsMock.EXPECT().ValidateUser(mock.MatchedBy(helpers.UserMatchesUpsertParams(params))).Return(nil)
 			f.validationsMock.EXPECT().ValidateUsernameDoesNotConflict(mock.Anything, params.Username).Return(nil)
+			f.validationsMock.EXPECT().ValidateUsernameNotReserved(mock.Anything, params.Username, mock.Anything).Return(nil)
 			f.validationsMock.EXPECT().ValidateEmailDoesNotConflict(mock.Anything, params.Email).Return(nil)
 
 			f.userRepoMock.EXPECT().UpsertUser(
@@ -123,6 +147,7 @@ func Test_UserServiceImpl(t *testing.T) {
 
 				f.validationsMock.EXPECT().ValidateUser(mock.MatchedBy(helpers.UserMatchesUpsertParams(upsertParams))).Return(nil)
 				f.validationsMock.EXPECT().ValidateUsernameDoesNotConflict(mock.Anything, upsertParams.Username).Return(nil)
+				f.validationsMock.EXPECT().ValidateUsernameNotReserved(mock.Anything, upsertParams.Username, mock.Anything).Return(nil)
 				f.validationsMock.EXPECT().ValidateEmailDoesNotConflict(mock.Anything, upsertParams.Email).Return(nil)
 
 				f.userRepoMock.EXPECT().
@@ -131,6 +156,9 @@ func Test_UserServiceImpl(t *testing.T) {
 						mock.MatchedBy(helpers.UserMatchesUpsertParams(upsertParams)),
 					).
 					Return(expectedUser, nil)
+				f.auditLogMock.EXPECT().
+					Record(mock.Anything, auditChange(audit_types.AuditActionCreate, audit_types.AuditEntityUser, expectedUser.Id)).
+					Return(nil)
 
 				created, err := f.underTest.CreateUser(t.Context(), upsertParams)
 
@@ -149,6 +177,7 @@ func Test_UserServiceImpl(t *testing.T) {
 
 				f.validationsMock.EXPECT().ValidateUser(mock.MatchedBy(helpers.UserMatchesUpsertParams(upsertParams))).Return(nil)
 				f.validationsMock.EXPECT().ValidateUsernameDoesNotConflict(mock.Anything, upsertParams.Username).Return(nil)
+				f.validationsMock.EXPECT().ValidateUsernameNotReserved(mock.Anything, upsertParams.Username, mock.Anything).Return(nil)
 				f.validationsMock.EXPECT().ValidateEmailDoesNotConflict(mock.Anything, upsertParams.Email).Return(nil)
 
 				f.userRepoMock.EXPECT().
@@ -157,6 +186,9 @@ func Test_UserServiceImpl(t *testing.T) {
 						mock.MatchedBy(helpers.UserMatchesUpsertParams(upsertParams)),
 					).
 					Return(expectedUser, nil)
+				f.auditLogMock.EXPECT().
+					Record(mock.Anything, auditChange(audit_types.AuditActionCreate, audit_types.AuditEntityUser, expectedUser.Id)).
+					Return(nil)
 
 				created, err := f.underTest.CreateUser(t.Context(), upsertParams)
 
@@ -182,12 +214,50 @@ func Test_UserServiceImpl(t *testing.T) {
 
 			f.validationsMock.EXPECT().ValidateUserIdExists(mock.Anything, badId).Return(user_types.User{}, user_types.NotFoundError{})
 
-			updated, err := f.underTest.UpdateUser(t.Context(), badId, params)
+			updated, err := f.underTest.UpdateUser(t.Context(), badId, params, mo.None[int64]())
 
 			assert.Empty(t, updated)
 			assert.IsType(t, user_types.NotFoundError{}, err)
 		})
 
+		t.Run("should fail with VersionConflictError if the expected version is not the current version", func(t *testing.T) {
+			t.Parallel()
+			f := setup(t)
+
+			f.validationsMock.EXPECT().ValidateUserIdExists(mock.Anything, existingUser.Id).Return(existingUser, nil)
+
+			updated, err := f.underTest.UpdateUser(t.Context(), existingUser.Id, params, mo.Some(existingUser.Version+1))
+
+			assert.Empty(t, updated)
+			assert.IsType(t, user_types.VersionConflictError{}, err)
+		})
+
+		t.Run("should upsert with the version that was read", func(t *testing.T) {
+			t.Parallel()
+			f := setup(t)
+
+			expectedUpdatedUser := helpers.GenUserWithUpsertParams(params)
+			expectedUpdatedUser.Id = existingUser.Id
+			expectedUpdatedUser.Version = existingUser.Version + 1
+
+			f.validationsMock.EXPECT().ValidateUserIdExists(mock.Anything, existingUser.Id).Return(existingUser, nil)
+			f.validationsMock.EXPECT().ValidateUser(mock.MatchedBy(helpers.UserMatchesUpsertParams(params))).Return(nil)
+			f.validationsMock.EXPECT().ValidateEmailDoesNotConflict(mock.Anything, params.Email).Return(nil)
+			f.validationsMock.EXPECT().ValidateUsernameDoesNotConflict(mock.Anything, params.Username).Return(nil)
+			f.validationsMock.EXPECT().ValidateUsernameNotReserved(mock.Anything, params.Username, mock.Anything).Return(nil)
+			f.userRepoMock.EXPECT().
+				UpsertUser(mock.Anything, mock.MatchedBy(func(u user_types.User) bool { return u.Version == existingUser.Version })).
+				Return(expectedUpdatedUser, nil)
+			f.auditLogMock.EXPECT().
+				Record(mock.Anything, auditChange(audit_types.AuditActionUpdate, audit_types.AuditEntityUser, existingUser.Id)).
+				Return(nil)
+
+			updated, err := f.underTest.UpdateUser(t.Context(), existingUser.Id, params, mo.Some(existingUser.Version))
+
+			assert.NoError(t, err)
+			assert.Equal(t, expectedUpdatedUser, updated)
+		})
+
 		t.Run("should fail if ValidateUser fails", func(t *testing.T) {
 			t.Parallel()
 			f := setup(t)
@@ -195,7 +265,7 @@ func Test_UserServiceImpl(t *testing.T) {
 			f.validationsMock.EXPECT().ValidateUserIdExists(mock.Anything, existingUser.Id).Return(existingUser, nil)
 			f.validationsMock.EXPECT().ValidateUser(mock.MatchedBy(helpers.UserMatchesUpsertParams(params))).Return(user_types.BadParamsError{})
 
-			updated, err := f.underTest.UpdateUser(t.Context(), existingUser.Id, params)
+			updated, err := f.underTest.UpdateUser(t.Context(), existingUser.Id, params, mo.None[int64]())
 
 			require.Empty(t, updated)
 			assert.IsType(t, user_types.BadParamsError{}, err)
@@ -209,8 +279,25 @@ func Test_UserServiceImpl(t *testing.T) {
 			f.validationsMock.EXPECT().ValidateUser(mock.MatchedBy(helpers.UserMatchesUpsertParams(params))).Return(nil)
 			f.validationsMock.EXPECT().ValidateEmailDoesNotConflict(mock.Anything, params.Email).Return(nil)
 			f.validationsMock.EXPECT().ValidateUsernameDoesNotConflict(mock.Anything, params.Username).Return(user_types.ConflictError{})
+			f.validationsMock.EXPECT().ValidateUsernameNotReserved(mock.Anything, params.Username, mock.Anything).Return(nil)
 
-			updated, err := f.underTest.UpdateUser(t.Context(), existingUser.Id, params)
+			updated, err := f.underTest.UpdateUser(t.Context(), existingUser.Id, params, mo.None[int64]())
+
+			assert.Empty(t, updated)
+			assert.IsType(t, user_types.ConflictError{}, err)
+		})
+
+		t.Run("should fail if ValidateUsernameNotReserved fails", func(t *testing.T) {
+			t.Parallel()
+			f := setup(t)
+
+			f.validationsMock.EXPECT().ValidateUserIdExists(mock.Anything, existingUser.Id).Return(existingUser, nil)
+			f.validationsMock.EXPECT().ValidateUser(mock.MatchedBy(helpers.UserMatchesUpsertParams(params))).Return(nil)
+			f.validationsMock.EXPECT().ValidateEmailDoesNotConflict(mock.Anything, params.Email).Return(nil)
+			f.validationsMock.EXPECT().ValidateUsernameDoesNotConflict(mock.Anything, params.Username).Return(nil)
+			f.validationsMock.EXPECT().ValidateUsernameNotReserved(mock.Anything, params.Username, existingUser.Id).Return(user_types.ConflictError{})
+
+			updated, err := f.underTest.UpdateUser(t.Context(), existingUser.Id, params, mo.None[int64]())
 
 			assert.Empty(t, updated)
 			assert.IsType(t, user_types.ConflictError{}, err)
@@ -224,8 +311,9 @@ func Test_UserServiceImpl(t *testing.T) {
 			f.validationsMock.EXPECT().ValidateUser(mock.MatchedBy(helpers.UserMatchesUpsertParams(params))).Return(nil)
 			f.validationsMock.EXPECT().ValidateEmailDoesNotConflict(mock.Anything, params.Email).Return(user_types.ConflictError{})
 			f.validationsMock.EXPECT().ValidateUsernameDoesNotConflict(mock.Anything, params.Username).Return(nil)
+			f.validationsMock.EXPECT().ValidateUsernameNotReserved(mock.Anything, params.Username, mock.Anything).Return(nil)
 
-			updated, err := f.underTest.UpdateUser(t.Context(), existingUser.Id, params)
+			updated, err := f.underTest.UpdateUser(t.Context(), existingUser.Id, params, mo.None[int64]())
 
 			assert.Empty(t, updated)
 			assert.IsType(t, user_types.ConflictError{}, err)
@@ -239,12 +327,13 @@ func Test_UserServiceImpl(t *testing.T) {
 			f.validationsMock.EXPECT().ValidateUser(mock.MatchedBy(helpers.UserMatchesUpsertParams(params))).Return(nil)
 			f.validationsMock.EXPECT().ValidateEmailDoesNotConflict(mock.Anything, params.Email).Return(nil)
 			f.validationsMock.EXPECT().ValidateUsernameDoesNotConflict(mock.Anything, params.Username).Return(nil)
+			f.validationsMock.EXPECT().ValidateUsernameNotReserved(mock.Anything, params.Username, mock.Anything).Return(nil)
 
 			f.userRepoMock.EXPECT().
 				UpsertUser(mock.Anything, mock.MatchedBy(helpers.UserMatchesUpsertParams(params))).
 				Return(user_types.User{}, errors.New("unknown error"))
 
-			updated, err := f.underTest.UpdateUser(t.Context(), existingUser.Id, params)
+			updated, err := f.underTest.UpdateUser(t.Context(), existingUser.Id, params, mo.None[int64]())
 
 			assert.Empty(t, updated)
 			assert.IsType(t, user_types.UnknownError{}, err)
@@ -264,12 +353,44 @@ func Test_UserServiceImpl(t *testing.T) {
 			f.validationsMock.EXPECT().ValidateUser(mock.MatchedBy(helpers.UserMatchesUpsertParams(upsertParams))).Return(nil)
 			f.validationsMock.EXPECT().ValidateEmailDoesNotConflict(mock.Anything, params.Email).Return(nil)
 			f.validationsMock.EXPECT().ValidateUsernameDoesNotConflict(mock.Anything, params.Username).Return(nil)
+			f.validationsMock.EXPECT().ValidateUsernameNotReserved(mock.Anything, params.Username, mock.Anything).Return(nil)
+
+			f.userRepoMock.EXPECT().
+				UpsertUser(mock.Anything, mock.MatchedBy(helpers.UserMatchesUpsertParams(upsertParams))).
+				Return(expectedUpdatedUser, nil)
+			f.auditLogMock.EXPECT().
+				Record(mock.Anything, auditChange(audit_types.AuditActionUpdate, audit_types.AuditEntityUser, existingUser.Id)).
+				Return(nil)
+
+			updated, err := f.underTest.UpdateUser(t.Context(), existingUser.Id, upsertParams, mo.None[int64]())
+
+			assert.NoError(t, err)
+			assert.Equal(t, expectedUpdatedUser, updated)
+		})
 
+		t.Run("should not validate conflicts when only the case of username and email changes", func(t *testing.T) {
+			t.Parallel()
+			f := setup(t)
+			upsertParams := user_types.UpsertUserParams{
+				Username: strings.ToUpper(existingUser.Username),
+				Email:    strings.ToUpper(existingUser.Email),
+				Bio:      existingUser.Bio,
+				Image:    existingUser.Image,
+			}
+
+			expectedUpdatedUser := helpers.GenUserWithUpsertParams(upsertParams)
+			expectedUpdatedUser.Id = existingUser.Id
+
+			f.validationsMock.EXPECT().ValidateUserIdExists(mock.Anything, existingUser.Id).Return(existingUser, nil)
+			f.validationsMock.EXPECT().ValidateUser(mock.MatchedBy(helpers.UserMatchesUpsertParams(upsertParams))).Return(nil)
 			f.userRepoMock.EXPECT().
 				UpsertUser(mock.Anything, mock.MatchedBy(helpers.UserMatchesUpsertParams(upsertParams))).
 				Return(expectedUpdatedUser, nil)
+			f.auditLogMock.EXPECT().
+				Record(mock.Anything, auditChange(audit_types.AuditActionUpdate, audit_types.AuditEntityUser, existingUser.Id)).
+				Return(nil)
 
-			updated, err := f.underTest.UpdateUser(t.Context(), existingUser.Id, upsertParams)
+			updated, err := f.underTest.UpdateUser(t.Context(), existingUser.Id, upsertParams, mo.None[int64]())
 
 			assert.NoError(t, err)
 			assert.Equal(t, expectedUpdatedUser, updated)
@@ -289,12 +410,16 @@ func Test_UserServiceImpl(t *testing.T) {
 			f.validationsMock.EXPECT().ValidateUser(mock.MatchedBy(helpers.UserMatchesUpsertParams(upsertParams))).Return(nil)
 			f.validationsMock.EXPECT().ValidateEmailDoesNotConflict(mock.Anything, params.Email).Return(nil)
 			f.validationsMock.EXPECT().ValidateUsernameDoesNotConflict(mock.Anything, params.Username).Return(nil)
+			f.validationsMock.EXPECT().ValidateUsernameNotReserved(mock.Anything, params.Username, mock.Anything).Return(nil)
 
 			f.userRepoMock.EXPECT().
 				UpsertUser(mock.Anything, mock.MatchedBy(helpers.UserMatchesUpsertParams(upsertParams))).
 				Return(expectedUpdatedUser, nil)
+			f.auditLogMock.EXPECT().
+				Record(mock.Anything, auditChange(audit_types.AuditActionUpdate, audit_types.AuditEntityUser, existingUser.Id)).
+				Return(nil)
 
-			updated, err := f.underTest.UpdateUser(t.Context(), existingUser.Id, upsertParams)
+			updated, err := f.underTest.UpdateUser(t.Context(), existingUser.Id, upsertParams, mo.None[int64]())
 
 			assert.NoError(t, err)
 			assert.Equal(t, expectedUpdatedUser, updated)
@@ -308,6 +433,7 @@ func Test_UserServiceImpl(t *testing.T) {
 			t.Parallel()
 			f := setup(t)
 			id := uuid.New()
+			f.userRepoMock.EXPECT().GetUserById(mock.Anything, id).Return(mo.Some(helpers.GenUser()), nil)
 			f.userRepoMock.EXPECT().DeleteUser(mock.Anything, id).Return(errors.New("query failure"))
 
 			err := f.underTest.DeleteUser(t.Context(), id)
@@ -317,14 +443,62 @@ func Test_UserServiceImpl(t *testing.T) {
 		t.Run("should delete user successfully", func(t *testing.T) {
 			t.Parallel()
 			f := setup(t)
-			id := uuid.New()
+			user := helpers.GenUser()
+			id := user.Id
+			f.userRepoMock.EXPECT().GetUserById(mock.Anything, id).Return(mo.Some(user), nil)
 			f.userRepoMock.EXPECT().DeleteUser(mock.Anything, id).Return(nil)
+			f.auditLogMock.EXPECT().Record(mock.Anything, auditChange(audit_types.AuditActionDelete, audit_types.AuditEntityUser, id)).Return(nil)
 
 			err := f.underTest.DeleteUser(t.Context(), id)
 			assert.NoError(t, err)
 		})
 	})
 
+	t.Run("RestoreUser", func(t *testing.T) {
+		t.Parallel()
+
+		t.Run("should return NotFoundError if user is not deleted or past the grace period", func(t *testing.T) {
+			t.Parallel()
+			f := setup(t)
+			id := uuid.New()
+			f.userRepoMock.EXPECT().GetDeletedUserById(mock.Anything, id, mock.Anything).Return(mo.None[user_types.User](), nil)
+
+			res, err := f.underTest.RestoreUser(t.Context(), id)
+			assert.IsType(t, user_types.NotFoundError{}, err)
+			assert.Empty(t, res)
+		})
+
+		t.Run("should fail if username was claimed while deleted", func(t *testing.T) {
+			t.Parallel()
+			f := setup(t)
+			deleted := helpers.GenUser()
+			f.userRepoMock.EXPECT().GetDeletedUserById(mock.Anything, deleted.Id, mock.Anything).Return(mo.Some(deleted), nil)
+			f.validationsMock.EXPECT().ValidateUsernameDoesNotConflict(mock.Anything, deleted.Username).Return(user_types.ConflictError{Msg: "username already exists"})
+			f.validationsMock.EXPECT().ValidateUsernameNotReserved(mock.Anything, deleted.Username, mock.Anything).Return(nil)
+			f.validationsMock.EXPECT().ValidateEmailDoesNotConflict(mock.Anything, deleted.Email).Return(nil).Maybe()
+
+			res, err := f.underTest.RestoreUser(t.Context(), deleted.Id)
+			assert.IsType(t, user_types.ConflictError{}, err)
+			assert.Empty(t, res)
+		})
+
+		t.Run("should restore user successfully", func(t *testing.T) {
+			t.Parallel()
+			f := setup(t)
+			deleted := helpers.GenUser()
+			f.userRepoMock.EXPECT().GetDeletedUserById(mock.Anything, deleted.Id, mock.Anything).Return(mo.Some(deleted), nil)
+			f.validationsMock.EXPECT().ValidateUsernameDoesNotConflict(mock.Anything, deleted.Username).Return(nil)
+			f.validationsMock.EXPECT().ValidateUsernameNotReserved(mock.Anything, deleted.Username, mock.Anything).Return(nil)
+			f.validationsMock.EXPECT().ValidateEmailDoesNotConflict(mock.Anything, deleted.Email).Return(nil)
+			f.userRepoMock.EXPECT().RestoreUser(mock.Anything, deleted.Id).Return(nil)
+			f.auditLogMock.EXPECT().Record(mock.Anything, auditChange(audit_types.AuditActionRestore, audit_types.AuditEntityUser, deleted.Id)).Return(nil)
+
+			res, err := f.underTest.RestoreUser(t.Context(), deleted.Id)
+			assert.NoError(t, err)
+			assert.Equal(t, deleted, res)
+		})
+	})
+
 	t.Run("GetUserByEmail", func(t *testing.T) {
 		t.Parallel()
 
@@ -401,6 +575,85 @@ func Test_UserServiceImpl(t *testing.T) {
 		})
 	})
 
+	t.Run("ResolveUsername", func(t *testing.T) {
+		t.Parallel()
+
+		t.Run("should return NotFoundError when no user has or had the username", func(t *testing.T) {
+			t.Parallel()
+			f := setup(t)
+			f.validationsMock.EXPECT().ValidateUsernameExists(mock.Anything, "no-existo").Return(user_types.User{}, user_types.NotFoundError{})
+
+			user, err := f.underTest.ResolveUsername(t.Context(), "no-existo")
+			assert.Empty(t, user)
+			assert.IsType(t, user_types.NotFoundError{}, err)
+		})
+
+		t.Run("should return the user the username resolves to", func(t *testing.T) {
+			t.Parallel()
+			f := setup(t)
+			renamed := helpers.GenUser()
+			f.validationsMock.EXPECT().ValidateUsernameExists(mock.Anything, "previous-username").Return(renamed, nil)
+
+			user, err := f.underTest.ResolveUsername(t.Context(), "previous-username")
+			assert.NoError(t, err)
+			assert.Equal(t, renamed, user)
+		})
+	})
+
+	t.Run("GetUsersByIds", func(t *testing.T) {
+		t.Parallel()
+
+		t.Run("should fail if get users by ids fails", func(t *testing.T) {
+			t.Parallel()
+			f := setup(t)
+			ids := []uuid.UUID{uuid.New()}
+			f.userRepoMock.EXPECT().GetUsersByIds(mock.Anything, ids).Return(nil, fmt.Errorf("get users failed"))
+
+			res, err := f.underTest.GetUsersByIds(t.Context(), ids)
+			assert.IsType(t, user_types.UnknownError{}, err)
+			assert.Nil(t, res)
+		})
+
+		t.Run("should return the found users keyed by id", func(t *testing.T) {
+			t.Parallel()
+			f := setup(t)
+			users := []user_types.User{helpers.GenUser(), helpers.GenUser()}
+			ids := []uuid.UUID{users[0].Id, users[1].Id, uuid.New()}
+			f.userRepoMock.EXPECT().GetUsersByIds(mock.Anything, ids).Return(users, nil)
+
+			res, err := f.underTest.GetUsersByIds(t.Context(), ids)
+			assert.NoError(t, err)
+			assert.Equal(t, map[uuid.UUID]user_types.User{users[0].Id: users[0], users[1].Id: users[1]}, res)
+		})
+	})
+
+	t.Run("AreFollowing", func(t *testing.T) {
+		t.Parallel()
+		authUser := helpers.GenUser()
+		ids := []uuid.UUID{uuid.New(), uuid.New()}
+
+		t.Run("should fail if are following fails", func(t *testing.T) {
+			t.Parallel()
+			f := setup(t)
+			f.userRepoMock.EXPECT().AreFollowing(mock.Anything, authUser.Id, ids).Return(nil, fmt.Errorf("are following failed"))
+
+			res, err := f.underTest.AreFollowing(t.Context(), authUser, ids)
+			assert.IsType(t, user_types.UnknownError{}, err)
+			assert.Nil(t, res)
+		})
+
+		t.Run("should return the following flags", func(t *testing.T) {
+			t.Parallel()
+			f := setup(t)
+			expected := map[uuid.UUID]bool{ids[0]: true, ids[1]: false}
+			f.userRepoMock.EXPECT().AreFollowing(mock.Anything, authUser.Id, ids).Return(expected, nil)
+
+			res, err := f.underTest.AreFollowing(t.Context(), authUser, ids)
+			assert.NoError(t, err)
+			assert.Equal(t, expected, res)
+		})
+	})
+
 	t.Run("IsFollowing", func(t *testing.T) {
 		t.Parallel()
 		authUser := helpers.GenUser()
@@ -487,6 +740,20 @@ func Test_UserServiceImpl(t *testing.T) {
 			assert.Empty(t, res)
 		})
 
+		t.Run("should fail if either user has blocked the other", func(t *testing.T) {
+			t.Parallel()
+
+			targetUser := helpers.GenUser()
+			f := setup(t)
+			f.validationsMock.EXPECT().ValidateUsernameExists(mock.Anything, targetUser.Username).Return(targetUser, nil)
+			f.validationsMock.EXPECT().ValidateCanFollow(authUser.Id, targetUser.Id).Return(nil)
+			f.validationsMock.EXPECT().ValidateNotBlocked(mock.Anything, authUser.Id, targetUser.Id).Return(user_types.CannotFollowBlockedUserError{})
+
+			res, err := f.underTest.FollowProfile(t.Context(), authUser, targetUser.Username)
+			assert.IsType(t, user_types.CannotFollowBlockedUserError{}, err)
+			assert.Empty(t, res)
+		})
+
 		t.Run("should fail if follow fails", func(t *testing.T) {
 			t.Parallel()
 
@@ -494,7 +761,9 @@ func Test_UserServiceImpl(t *testing.T) {
 			f := setup(t)
 			f.validationsMock.EXPECT().ValidateUsernameExists(mock.Anything, targetUser.Username).Return(targetUser, nil)
 			f.validationsMock.EXPECT().ValidateCanFollow(authUser.Id, targetUser.Id).Return(nil)
+			f.validationsMock.EXPECT().ValidateNotBlocked(mock.Anything, authUser.Id, targetUser.Id).Return(nil)
 
+			f.userRepoMock.EXPECT().IsFollowing(mock.Anything, authUser.Id, targetUser.Id).Return(false, nil)
 			f.userRepoMock.EXPECT().Follow(mock.Anything, authUser.Id, targetUser.Id).Return(fmt.Errorf("follow failed"))
 
 			res, err := f.underTest.FollowProfile(t.Context(), authUser, targetUser.Username)
@@ -509,7 +778,27 @@ func Test_UserServiceImpl(t *testing.T) {
 			f := setup(t)
 			f.validationsMock.EXPECT().ValidateUsernameExists(mock.Anything, targetUser.Username).Return(targetUser, nil)
 			f.validationsMock.EXPECT().ValidateCanFollow(authUser.Id, targetUser.Id).Return(nil)
+			f.validationsMock.EXPECT().ValidateNotBlocked(mock.Anything, authUser.Id, targetUser.Id).Return(nil)
+			f.userRepoMock.EXPECT().IsFollowing(mock.Anything, authUser.Id, targetUser.Id).Return(false, nil)
+			f.userRepoMock.EXPECT().Follow(mock.Anything, authUser.Id, targetUser.Id).Return(nil)
+			f.auditLogMock.EXPECT().Record(mock.Anything, auditChange(audit_types.AuditActionCreate, audit_types.AuditEntityFollow, targetUser.Id)).Return(nil)
+
+			res, err := f.underTest.FollowProfile(t.Context(), authUser, targetUser.Username)
+			assert.NoError(t, err)
+			assert.Equal(t, targetUser, res)
+		})
+
+		t.Run("should not record following a user that is already followed", func(t *testing.T) {
+			t.Parallel()
+
+			targetUser := helpers.GenUser()
+			f := setup(t)
+			f.validationsMock.EXPECT().ValidateUsernameExists(mock.Anything, targetUser.Username).Return(targetUser, nil)
+			f.validationsMock.EXPECT().ValidateCanFollow(authUser.Id, targetUser.Id).Return(nil)
+			f.validationsMock.EXPECT().ValidateNotBlocked(mock.Anything, authUser.Id, targetUser.Id).Return(nil)
+			f.userRepoMock.EXPECT().IsFollowing(mock.Anything, authUser.Id, targetUser.Id).Return(true, nil)
 			f.userRepoMock.EXPECT().Follow(mock.Anything, authUser.Id, targetUser.Id).Return(nil)
+			f.auditLogMock.EXPECT().Record(mock.Anything, audit_types.AuditChange{}).Return(nil)
 
 			res, err := f.underTest.FollowProfile(t.Context(), authUser, targetUser.Username)
 			assert.NoError(t, err)
@@ -539,6 +828,7 @@ func Test_UserServiceImpl(t *testing.T) {
 			targetUser := helpers.GenUser()
 			f := setup(t)
 			f.validationsMock.EXPECT().ValidateUsernameExists(mock.Anything, targetUser.Username).Return(targetUser, nil)
+			f.userRepoMock.EXPECT().IsFollowing(mock.Anything, authUser.Id, targetUser.Id).Return(true, nil)
 			f.userRepoMock.EXPECT().Unfollow(mock.Anything, authUser.Id, targetUser.Id).Return(fmt.Errorf("unfollow failed"))
 
 			res, err := f.underTest.UnfollowProfile(t.Context(), authUser, targetUser.Username)
@@ -552,11 +842,274 @@ func Test_UserServiceImpl(t *testing.T) {
 			targetUser := helpers.GenUser()
 			f := setup(t)
 			f.validationsMock.EXPECT().ValidateUsernameExists(mock.Anything, targetUser.Username).Return(targetUser, nil)
+			f.userRepoMock.EXPECT().IsFollowing(mock.Anything, authUser.Id, targetUser.Id).Return(true, nil)
 			f.userRepoMock.EXPECT().Unfollow(mock.Anything, authUser.Id, targetUser.Id).Return(nil)
+			f.auditLogMock.EXPECT().Record(mock.Anything, auditChange(audit_types.AuditActionDelete, audit_types.AuditEntityFollow, targetUser.Id)).Return(nil)
 
 			res, err := f.underTest.UnfollowProfile(t.Context(), authUser, targetUser.Username)
 			assert.NoError(t, err)
 			assert.Equal(t, targetUser, res)
 		})
 	})
+
+	t.Run("BlockProfile", func(t *testing.T) {
+		t.Parallel()
+		authUser := helpers.GenUser()
+
+		t.Run("should fail if validate profile fails", func(t *testing.T) {
+			t.Parallel()
+
+			targetUser := helpers.GenUser()
+			f := setup(t)
+			f.validationsMock.EXPECT().ValidateUsernameExists(mock.Anything, targetUser.Username).Return(user_types.User{}, user_types.NotFoundError{Identifier: targetUser.Username})
+
+			res, err := f.underTest.BlockProfile(t.Context(), authUser, targetUser.Username)
+			assert.IsType(t, user_types.NotFoundError{}, err)
+			assert.Empty(t, res)
+		})
+
+		t.Run("should fail if blocking self", func(t *testing.T) {
+			t.Parallel()
+
+			f := setup(t)
+			f.validationsMock.EXPECT().ValidateUsernameExists(mock.Anything, authUser.Username).Return(authUser, nil)
+			f.validationsMock.EXPECT().ValidateCanBlockOrMute(authUser.Id, authUser.Id).Return(user_types.CannotBlockOrMuteYourselfError{})
+
+			res, err := f.underTest.BlockProfile(t.Context(), authUser, authUser.Username)
+			assert.IsType(t, user_types.CannotBlockOrMuteYourselfError{}, err)
+			assert.Empty(t, res)
+		})
+
+		t.Run("should fail if block fails", func(t *testing.T) {
+			t.Parallel()
+
+			targetUser := helpers.GenUser()
+			f := setup(t)
+			f.validationsMock.EXPECT().ValidateUsernameExists(mock.Anything, targetUser.Username).Return(targetUser, nil)
+			f.validationsMock.EXPECT().ValidateCanBlockOrMute(authUser.Id, targetUser.Id).Return(nil)
+			f.userRepoMock.EXPECT().IsBlocking(mock.Anything, authUser.Id, targetUser.Id).Return(false, nil)
+			f.userRepoMock.EXPECT().IsFollowing(mock.Anything, authUser.Id, targetUser.Id).Return(false, nil)
+			f.userRepoMock.EXPECT().IsFollowing(mock.Anything, targetUser.Id, authUser.Id).Return(false, nil)
+			f.userRepoMock.EXPECT().Block(mock.Anything, authUser.Id, targetUser.Id).Return(fmt.Errorf("block failed"))
+
+			res, err := f.underTest.BlockProfile(t.Context(), authUser, targetUser.Username)
+			assert.IsType(t, user_types.UnknownError{}, err)
+			assert.Empty(t, res)
+		})
+
+		t.Run("should block successfully", func(t *testing.T) {
+			t.Parallel()
+
+			targetUser := helpers.GenUser()
+			f := setup(t)
+			f.validationsMock.EXPECT().ValidateUsernameExists(mock.Anything, targetUser.Username).Return(targetUser, nil)
+			f.validationsMock.EXPECT().ValidateCanBlockOrMute(authUser.Id, targetUser.Id).Return(nil)
+			f.userRepoMock.EXPECT().IsBlocking(mock.Anything, authUser.Id, targetUser.Id).Return(false, nil)
+			f.userRepoMock.EXPECT().IsFollowing(mock.Anything, authUser.Id, targetUser.Id).Return(false, nil)
+			f.userRepoMock.EXPECT().IsFollowing(mock.Anything, targetUser.Id, authUser.Id).Return(false, nil)
+			f.userRepoMock.EXPECT().Block(mock.Anything, authUser.Id, targetUser.Id).Return(nil)
+			f.auditLogMock.EXPECT().Record(mock.Anything, auditChange(audit_types.AuditActionCreate, audit_types.AuditEntityBlock, targetUser.Id)).Return(nil)
+
+			res, err := f.underTest.BlockProfile(t.Context(), authUser, targetUser.Username)
+			assert.NoError(t, err)
+			assert.Equal(t, targetUser, res)
+		})
+
+		t.Run("should not record blocking a user that is already blocked", func(t *testing.T) {
+			t.Parallel()
+
+			targetUser := helpers.GenUser()
+			f := setup(t)
+			f.validationsMock.EXPECT().ValidateUsernameExists(mock.Anything, targetUser.Username).Return(targetUser, nil)
+			f.validationsMock.EXPECT().ValidateCanBlockOrMute(authUser.Id, targetUser.Id).Return(nil)
+			f.userRepoMock.EXPECT().IsBlocking(mock.Anything, authUser.Id, targetUser.Id).Return(true, nil)
+			f.userRepoMock.EXPECT().IsFollowing(mock.Anything, authUser.Id, targetUser.Id).Return(false, nil)
+			f.userRepoMock.EXPECT().IsFollowing(mock.Anything, targetUser.Id, authUser.Id).Return(false, nil)
+			f.userRepoMock.EXPECT().Block(mock.Anything, authUser.Id, targetUser.Id).Return(nil)
+
+			res, err := f.underTest.BlockProfile(t.Context(), authUser, targetUser.Username)
+			assert.NoError(t, err)
+			assert.Equal(t, targetUser, res)
+		})
+
+		t.Run("should record the follows removed by the block as unfollows", func(t *testing.T) {
+			t.Parallel()
+
+			targetUser := helpers.GenUser()
+			f := setup(t)
+			f.validationsMock.EXPECT().ValidateUsernameExists(mock.Anything, targetUser.Username).Return(targetUser, nil)
+			f.validationsMock.EXPECT().ValidateCanBlockOrMute(authUser.Id, targetUser.Id).Return(nil)
+			f.userRepoMock.EXPECT().IsBlocking(mock.Anything, authUser.Id, targetUser.Id).Return(false, nil)
+			f.userRepoMock.EXPECT().IsFollowing(mock.Anything, authUser.Id, targetUser.Id).Return(false, nil)
+			f.userRepoMock.EXPECT().IsFollowing(mock.Anything, targetUser.Id, authUser.Id).Return(true, nil)
+			f.userRepoMock.EXPECT().Block(mock.Anything, authUser.Id, targetUser.Id).Return(nil)
+			f.auditLogMock.EXPECT().Record(mock.Anything, auditChange(audit_types.AuditActionCreate, audit_types.AuditEntityBlock, targetUser.Id)).Return(nil)
+			f.auditLogMock.EXPECT().Record(mock.Anything, auditChange(audit_types.AuditActionDelete, audit_types.AuditEntityFollow, authUser.Id)).Return(nil)
+
+			res, err := f.underTest.BlockProfile(t.Context(), authUser, targetUser.Username)
+			assert.NoError(t, err)
+			assert.Equal(t, targetUser, res)
+		})
+	})
+
+	t.Run("UnblockProfile", func(t *testing.T) {
+		t.Parallel()
+		authUser := helpers.GenUser()
+
+		t.Run("should fail if unblock fails", func(t *testing.T) {
+			t.Parallel()
+
+			targetUser := helpers.GenUser()
+			f := setup(t)
+			f.validationsMock.EXPECT().ValidateUsernameExists(mock.Anything, targetUser.Username).Return(targetUser, nil)
+			f.userRepoMock.EXPECT().IsBlocking(mock.Anything, authUser.Id, targetUser.Id).Return(true, nil)
+			f.userRepoMock.EXPECT().Unblock(mock.Anything, authUser.Id, targetUser.Id).Return(fmt.Errorf("unblock failed"))
+
+			res, err := f.underTest.UnblockProfile(t.Context(), authUser, targetUser.Username)
+			assert.IsType(t, user_types.UnknownError{}, err)
+			assert.Empty(t, res)
+		})
+
+		t.Run("should unblock successfully", func(t *testing.T) {
+			t.Parallel()
+
+			targetUser := helpers.GenUser()
+			f := setup(t)
+			f.validationsMock.EXPECT().ValidateUsernameExists(mock.Anything, targetUser.Username).Return(targetUser, nil)
+			f.userRepoMock.EXPECT().IsBlocking(mock.Anything, authUser.Id, targetUser.Id).Return(true, nil)
+			f.userRepoMock.EXPECT().Unblock(mock.Anything, authUser.Id, targetUser.Id).Return(nil)
+			f.auditLogMock.EXPECT().Record(mock.Anything, auditChange(audit_types.AuditActionDelete, audit_types.AuditEntityBlock, targetUser.Id)).Return(nil)
+
+			res, err := f.underTest.UnblockProfile(t.Context(), authUser, targetUser.Username)
+			assert.NoError(t, err)
+			assert.Equal(t, targetUser, res)
+		})
+
+		t.Run("should not record unblocking a user that is not blocked", func(t *testing.T) {
+			t.Parallel()
+
+			targetUser := helpers.GenUser()
+			f := setup(t)
+			f.validationsMock.EXPECT().ValidateUsernameExists(mock.Anything, targetUser.Username).Return(targetUser, nil)
+			f.userRepoMock.EXPECT().IsBlocking(mock.Anything, authUser.Id, targetUser.Id).Return(false, nil)
+			f.userRepoMock.EXPECT().Unblock(mock.Anything, authUser.Id, targetUser.Id).Return(nil)
+			f.auditLogMock.EXPECT().Record(mock.Anything, audit_types.AuditChange{}).Return(nil)
+
+			res, err := f.underTest.UnblockProfile(t.Context(), authUser, targetUser.Username)
+			assert.NoError(t, err)
+			assert.Equal(t, targetUser, res)
+		})
+	})
+
+	t.Run("MuteProfile", func(t *testing.T) {
+		t.Parallel()
+		authUser := helpers.GenUser()
+
+		t.Run("should fail if muting self", func(t *testing.T) {
+			t.Parallel()
+
+			f := setup(t)
+			f.validationsMock.EXPECT().ValidateUsernameExists(mock.Anything, authUser.Username).Return(authUser, nil)
+			f.validationsMock.EXPECT().ValidateCanBlockOrMute(authUser.Id, authUser.Id).Return(user_types.CannotBlockOrMuteYourselfError{})
+
+			res, err := f.underTest.MuteProfile(t.Context(), authUser, authUser.Username)
+			assert.IsType(t, user_types.CannotBlockOrMuteYourselfError{}, err)
+			assert.Empty(t, res)
+		})
+
+		t.Run("should mute successfully", func(t *testing.T) {
+			t.Parallel()
+
+			targetUser := helpers.GenUser()
+			f := setup(t)
+			f.validationsMock.EXPECT().ValidateUsernameExists(mock.Anything, targetUser.Username).Return(targetUser, nil)
+			f.validationsMock.EXPECT().ValidateCanBlockOrMute(authUser.Id, targetUser.Id).Return(nil)
+			f.userRepoMock.EXPECT().IsMuting(mock.Anything, authUser.Id, targetUser.Id).Return(false, nil)
+			f.userRepoMock.EXPECT().Mute(mock.Anything, authUser.Id, targetUser.Id).Return(nil)
+			f.auditLogMock.EXPECT().Record(mock.Anything, auditChange(audit_types.AuditActionCreate, audit_types.AuditEntityMute, targetUser.Id)).Return(nil)
+
+			res, err := f.underTest.MuteProfile(t.Context(), authUser, targetUser.Username)
+			assert.NoError(t, err)
+			assert.Equal(t, targetUser, res)
+		})
+
+		t.Run("should not record muting a user that is already muted", func(t *testing.T) {
+			t.Parallel()
+
+			targetUser := helpers.GenUser()
+			f := setup(t)
+			f.validationsMock.EXPECT().ValidateUsernameExists(mock.Anything, targetUser.Username).Return(targetUser, nil)
+			f.validationsMock.EXPECT().ValidateCanBlockOrMute(authUser.Id, targetUser.Id).Return(nil)
+			f.userRepoMock.EXPECT().IsMuting(mock.Anything, authUser.Id, targetUser.Id).Return(true, nil)
+			f.userRepoMock.EXPECT().Mute(mock.Anything, authUser.Id, targetUser.Id).Return(nil)
+			f.auditLogMock.EXPECT().Record(mock.Anything, audit_types.AuditChange{}).Return(nil)
+
+			res, err := f.underTest.MuteProfile(t.Context(), authUser, targetUser.Username)
+			assert.NoError(t, err)
+			assert.Equal(t, targetUser, res)
+		})
+	})
+
+	t.Run("UnmuteProfile", func(t *testing.T) {
+		t.Parallel()
+		authUser := helpers.GenUser()
+
+		t.Run("should unmute successfully", func(t *testing.T) {
+			t.Parallel()
+
+			targetUser := helpers.GenUser()
+			f := setup(t)
+			f.validationsMock.EXPECT().ValidateUsernameExists(mock.Anything, targetUser.Username).Return(targetUser, nil)
+			f.userRepoMock.EXPECT().IsMuting(mock.Anything, authUser.Id, targetUser.Id).Return(true, nil)
+			f.userRepoMock.EXPECT().Unmute(mock.Anything, authUser.Id, targetUser.Id).Return(nil)
+			f.auditLogMock.EXPECT().Record(mock.Anything, auditChange(audit_types.AuditActionDelete, audit_types.AuditEntityMute, targetUser.Id)).Return(nil)
+
+			res, err := f.underTest.UnmuteProfile(t.Context(), authUser, targetUser.Username)
+			assert.NoError(t, err)
+			assert.Equal(t, targetUser, res)
+		})
+
+		t.Run("should not record unmuting a user that is not muted", func(t *testing.T) {
+			t.Parallel()
+
+			targetUser := helpers.GenUser()
+			f := setup(t)
+			f.validationsMock.EXPECT().ValidateUsernameExists(mock.Anything, targetUser.Username).Return(targetUser, nil)
+			f.userRepoMock.EXPECT().IsMuting(mock.Anything, authUser.Id, targetUser.Id).Return(false, nil)
+			f.userRepoMock.EXPECT().Unmute(mock.Anything, authUser.Id, targetUser.Id).Return(nil)
+			f.auditLogMock.EXPECT().Record(mock.Anything, audit_types.AuditChange{}).Return(nil)
+
+			res, err := f.underTest.UnmuteProfile(t.Context(), authUser, targetUser.Username)
+			assert.NoError(t, err)
+			assert.Equal(t, targetUser, res)
+		})
+	})
+
+	t.Run("GetHiddenUserIds", func(t *testing.T) {
+		t.Parallel()
+		viewer := helpers.GenUser()
+
+		t.Run("should return UnknownError when repo fails", func(t *testing.T) {
+			t.Parallel()
+
+			f := setup(t)
+			f.userRepoMock.EXPECT().ListHiddenUserIds(mock.Anything, viewer.Id).Return(nil, fmt.Errorf("db error"))
+
+			res, err := f.underTest.GetHiddenUserIds(t.Context(), viewer)
+			assert.IsType(t, user_types.UnknownError{}, err)
+			assert.Empty(t, res)
+		})
+
+		t.Run("should return hidden user ids", func(t *testing.T) {
+			t.Parallel()
+
+			hidden := []uuid.UUID{uuid.New(), uuid.New()}
+			f := setup(t)
+			f.userRepoMock.EXPECT().ListHiddenUserIds(mock.Anything, viewer.Id).Return(hidden, nil)
+
+			res, err := f.underTest.GetHiddenUserIds(t.Context(), viewer)
+			assert.NoError(t, err)
+			assert.Equal(t, hidden, res)
+		})
+	})
 }
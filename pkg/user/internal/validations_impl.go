@@ -2,6 +2,7 @@ package internal
 
 import (
 	"context"
+	"time"
 
 	"github.com/nimaeskandary/go-realworld/pkg/user/types"
 	"github.com/nimaeskandary/go-realworld/pkg/util"
@@ -13,12 +14,14 @@ import (
 type userValidationsImpl struct {
 	validator *validator.Validate
 	userRepo  user_types.UserRepository
+	cfg       user_types.UserConfig
 }
 
-func NewUserValidationsImpl(userRepo user_types.UserRepository) user_types.UserValidations {
+func NewUserValidationsImpl(userRepo user_types.UserRepository, cfg user_types.UserConfig) user_types.UserValidations {
 	return &userValidationsImpl{
 		validator: util.NewValidator(),
 		userRepo:  userRepo,
+		cfg:       cfg,
 	}
 }
 
@@ -43,6 +46,19 @@ func (v *userValidationsImpl) ValidateUsernameDoesNotConflict(ctx context.Contex
 	return nil
 }
 
+func (v *userValidationsImpl) ValidateUsernameNotReserved(ctx context.Context, username string, claimantUserId uuid.UUID) user_types.DomainError {
+	reserved, err := v.userRepo.IsUsernameReserved(ctx, username, claimantUserId, v.cfg.UsernameReleaseCutoff(time.Now()))
+	if err != nil {
+		return user_types.AsDomainError(err)
+	}
+	if reserved {
+		return user_types.ConflictError{
+			Msg: "username was recently used by another user",
+		}
+	}
+	return nil
+}
+
 func (v *userValidationsImpl) ValidateEmailDoesNotConflict(ctx context.Context, email string) user_types.DomainError {
 	opt, err := v.userRepo.GetUserByEmail(ctx, email)
 	if err != nil {
@@ -75,13 +91,22 @@ func (v *userValidationsImpl) ValidateUsernameExists(ctx context.Context, userna
 	if err != nil {
 		return user_types.User{}, user_types.AsDomainError(err)
 	}
-	existingUser, ok := existingUserOpt.Get()
+	if existingUser, ok := existingUserOpt.Get(); ok {
+		return existingUser, nil
+	}
+
+	// the current owner of a username always wins, old names only resolve while nobody else holds them
+	renamedUserOpt, err := v.userRepo.GetUserByPreviousUsername(ctx, username)
+	if err != nil {
+		return user_types.User{}, user_types.AsDomainError(err)
+	}
+	renamedUser, ok := renamedUserOpt.Get()
 
 	if !ok {
 		return user_types.User{}, user_types.NotFoundError{Identifier: username}
 	}
 
-	return existingUser, nil
+	return renamedUser, nil
 }
 
 func (v *userValidationsImpl) ValidateCanFollow(followedByUserId, followingUserId uuid.UUID) user_types.DomainError {
@@ -90,3 +115,21 @@ func (v *userValidationsImpl) ValidateCanFollow(followedByUserId, followingUserI
 	}
 	return nil
 }
+
+func (v *userValidationsImpl) ValidateNotBlocked(ctx context.Context, userIdA, userIdB uuid.UUID) user_types.DomainError {
+	isBlocked, err := v.userRepo.IsBlockedEitherWay(ctx, userIdA, userIdB)
+	if err != nil {
+		return user_types.AsDomainError(err)
+	}
+	if isBlocked {
+		return user_types.CannotFollowBlockedUserError{}
+	}
+	return nil
+}
+
+func (v *userValidationsImpl) ValidateCanBlockOrMute(actingUserId, targetUserId uuid.UUID) user_types.DomainError {
+	if actingUserId.String() == targetUserId.String() {
+		return user_types.CannotBlockOrMuteYourselfError{}
+	}
+	return nil
+}
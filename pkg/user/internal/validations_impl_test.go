@@ -4,6 +4,7 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/nimaeskandary/go-realworld/pkg/test_utils/config"
 	"github.com/nimaeskandary/go-realworld/pkg/test_utils/helpers"
 	"github.com/nimaeskandary/go-realworld/pkg/user/internal"
 	user_types "github.com/nimaeskandary/go-realworld/pkg/user/types"
@@ -25,7 +26,7 @@ func Test_UserValidationsImpl(t *testing.T) {
 
 	setup := func(t *testing.T) testFixture {
 		userRepoMock := user_types_mocks.NewMockUserRepository(t)
-		underTest := internal.NewUserValidationsImpl(userRepoMock)
+		underTest := internal.NewUserValidationsImpl(userRepoMock, config.NewTestConfig().User)
 		return testFixture{
 			userRepoMock: userRepoMock,
 			underTest:    underTest,
@@ -109,6 +110,43 @@ func Test_UserValidationsImpl(t *testing.T) {
 		})
 	})
 
+	t.Run("ValidateUsernameNotReserved", func(t *testing.T) {
+		t.Parallel()
+
+		t.Run("should return UnknownError if repo fails", func(t *testing.T) {
+			t.Parallel()
+			f := setup(t)
+			f.userRepoMock.EXPECT().
+				IsUsernameReserved(mock.Anything, "error-case", user.Id, mock.Anything).
+				Return(false, errors.New("db down"))
+
+			err := f.underTest.ValidateUsernameNotReserved(t.Context(), "error-case", user.Id)
+			assert.IsType(t, user_types.UnknownError{}, err)
+		})
+
+		t.Run("should return ConflictError if another user renamed away from the username within the cooldown", func(t *testing.T) {
+			t.Parallel()
+			f := setup(t)
+			f.userRepoMock.EXPECT().
+				IsUsernameReserved(mock.Anything, "reserved", user.Id, mock.Anything).
+				Return(true, nil)
+
+			err := f.underTest.ValidateUsernameNotReserved(t.Context(), "reserved", user.Id)
+			assert.IsType(t, user_types.ConflictError{}, err)
+		})
+
+		t.Run("should return no error if the username is not reserved", func(t *testing.T) {
+			t.Parallel()
+			f := setup(t)
+			f.userRepoMock.EXPECT().
+				IsUsernameReserved(mock.Anything, "released", user.Id, mock.Anything).
+				Return(false, nil)
+
+			err := f.underTest.ValidateUsernameNotReserved(t.Context(), "released", user.Id)
+			assert.NoError(t, err)
+		})
+	})
+
 	t.Run("ValidateEmailDoesNotConflict", func(t *testing.T) {
 		t.Parallel()
 
@@ -201,12 +239,25 @@ func Test_UserValidationsImpl(t *testing.T) {
 			f := setup(t)
 			username := "test-username"
 			f.userRepoMock.EXPECT().GetUserByUsername(mock.Anything, username).Return(mo.None[user_types.User](), nil)
+			f.userRepoMock.EXPECT().GetUserByPreviousUsername(mock.Anything, username).Return(mo.None[user_types.User](), nil)
 
 			user, err := f.underTest.ValidateUsernameExists(t.Context(), username)
 			assert.Empty(t, user)
 			assert.IsType(t, user_types.NotFoundError{}, err)
 		})
 
+		t.Run("should return the renamed user for a previous username", func(t *testing.T) {
+			t.Parallel()
+			f := setup(t)
+			username := "previous-username"
+			f.userRepoMock.EXPECT().GetUserByUsername(mock.Anything, username).Return(mo.None[user_types.User](), nil)
+			f.userRepoMock.EXPECT().GetUserByPreviousUsername(mock.Anything, username).Return(mo.Some(user), nil)
+
+			renamed, err := f.underTest.ValidateUsernameExists(t.Context(), username)
+			assert.NoError(t, err)
+			assert.Equal(t, user, renamed)
+		})
+
 		t.Run("should return user if found", func(t *testing.T) {
 			t.Parallel()
 			f := setup(t)
@@ -239,4 +290,59 @@ func Test_UserValidationsImpl(t *testing.T) {
 		})
 
 	})
+
+	t.Run("ValidateNotBlocked", func(t *testing.T) {
+		t.Parallel()
+
+		t.Run("should return UnknownError if repo fails", func(t *testing.T) {
+			t.Parallel()
+			f := setup(t)
+			a, b := uuid.New(), uuid.New()
+			f.userRepoMock.EXPECT().IsBlockedEitherWay(mock.Anything, a, b).Return(false, errors.New("db error"))
+
+			err := f.underTest.ValidateNotBlocked(t.Context(), a, b)
+			assert.IsType(t, user_types.UnknownError{}, err)
+		})
+
+		t.Run("should return CannotFollowBlockedUserError if blocked", func(t *testing.T) {
+			t.Parallel()
+			f := setup(t)
+			a, b := uuid.New(), uuid.New()
+			f.userRepoMock.EXPECT().IsBlockedEitherWay(mock.Anything, a, b).Return(true, nil)
+
+			err := f.underTest.ValidateNotBlocked(t.Context(), a, b)
+			assert.IsType(t, user_types.CannotFollowBlockedUserError{}, err)
+		})
+
+		t.Run("should return no error if not blocked", func(t *testing.T) {
+			t.Parallel()
+			f := setup(t)
+			a, b := uuid.New(), uuid.New()
+			f.userRepoMock.EXPECT().IsBlockedEitherWay(mock.Anything, a, b).Return(false, nil)
+
+			err := f.underTest.ValidateNotBlocked(t.Context(), a, b)
+			assert.Empty(t, err)
+		})
+	})
+
+	t.Run("ValidateCanBlockOrMute", func(t *testing.T) {
+		t.Parallel()
+
+		t.Run("should return CannotBlockOrMuteYourselfError if user ids are equal", func(t *testing.T) {
+			t.Parallel()
+			f := setup(t)
+			id := uuid.New()
+
+			err := f.underTest.ValidateCanBlockOrMute(id, id)
+			assert.IsType(t, user_types.CannotBlockOrMuteYourselfError{}, err)
+		})
+
+		t.Run("should return no error if user ids are different", func(t *testing.T) {
+			t.Parallel()
+			f := setup(t)
+
+			err := f.underTest.ValidateCanBlockOrMute(uuid.New(), uuid.New())
+			assert.Empty(t, err)
+		})
+	})
 }
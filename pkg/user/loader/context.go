@@ -0,0 +1,21 @@
+package user_loader
+
+import (
+	"context"
+
+	"github.com/samber/mo"
+)
+
+type loaderKey struct{}
+
+func CtxWithLoader(ctx context.Context, loader *UserLoader) context.Context {
+	return context.WithValue(ctx, loaderKey{}, loader)
+}
+
+func LoaderFromCtx(ctx context.Context) mo.Option[*UserLoader] {
+	loader, ok := ctx.Value(loaderKey{}).(*UserLoader)
+	if !ok {
+		return mo.None[*UserLoader]()
+	}
+	return mo.Some(loader)
+}
@@ -0,0 +1,146 @@
+package user_loader
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"sync"
+
+	user_types "github.com/nimaeskandary/go-realworld/pkg/user/types"
+
+	"github.com/google/uuid"
+	"github.com/samber/mo"
+)
+
+// UserLoader batches and caches user and following lookups for the lifetime of a single request. Ids passed to Prime
+// are fetched together with the next lookup, so building a list of n rows costs one query per kind of lookup instead of n.
+// It is safe for concurrent use, concurrent lookups wait for an in flight batch rather than issuing their own
+type UserLoader struct {
+	userService user_types.UserService
+	viewer      mo.Option[user_types.User]
+
+	mu sync.Mutex
+	// users caches lookups including misses, so a missing id is not queried again
+	users            map[uuid.UUID]mo.Option[user_types.User]
+	following        map[uuid.UUID]bool
+	pendingUsers     map[uuid.UUID]struct{}
+	pendingFollowing map[uuid.UUID]struct{}
+}
+
+// NewUserLoader creates a loader, following lookups are relative to the viewer and are always false without one
+func NewUserLoader(userService user_types.UserService, viewer mo.Option[user_types.User]) *UserLoader {
+	return &UserLoader{
+		userService:      userService,
+		viewer:           viewer,
+		users:            map[uuid.UUID]mo.Option[user_types.User]{},
+		following:        map[uuid.UUID]bool{},
+		pendingUsers:     map[uuid.UUID]struct{}{},
+		pendingFollowing: map[uuid.UUID]struct{}{},
+	}
+}
+
+// Prime queues ids to be fetched with the next lookup, call it with every id of a list before looking any of them up
+func (l *UserLoader) Prime(ids ...uuid.UUID) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for _, id := range ids {
+		if _, ok := l.users[id]; !ok {
+			l.pendingUsers[id] = struct{}{}
+		}
+		if _, ok := l.following[id]; !ok && l.viewer.IsPresent() {
+			l.pendingFollowing[id] = struct{}{}
+		}
+	}
+}
+
+func (l *UserLoader) GetUser(ctx context.Context, id uuid.UUID) (mo.Option[user_types.User], user_types.DomainError) {
+	users, err := l.GetUsers(ctx, []uuid.UUID{id})
+	if err != nil {
+		return mo.None[user_types.User](), err
+	}
+	user, ok := users[id]
+	if !ok {
+		return mo.None[user_types.User](), nil
+	}
+	return mo.Some(user), nil
+}
+
+// GetUsers returns the users keyed by id, ids that are not found are omitted
+func (l *UserLoader) GetUsers(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]user_types.User, user_types.DomainError) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for _, id := range ids {
+		if _, ok := l.users[id]; !ok {
+			l.pendingUsers[id] = struct{}{}
+		}
+	}
+
+	if len(l.pendingUsers) > 0 {
+		batch := slices.Collect(maps.Keys(l.pendingUsers))
+		fetched, err := l.userService.GetUsersByIds(ctx, batch)
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range batch {
+			user, ok := fetched[id]
+			l.users[id] = mo.TupleToOption(user, ok)
+		}
+		clear(l.pendingUsers)
+	}
+
+	result := make(map[uuid.UUID]user_types.User, len(ids))
+	for _, id := range ids {
+		if user, ok := l.users[id].Get(); ok {
+			result[id] = user
+		}
+	}
+	return result, nil
+}
+
+func (l *UserLoader) IsFollowing(ctx context.Context, id uuid.UUID) (bool, user_types.DomainError) {
+	following, err := l.AreFollowing(ctx, []uuid.UUID{id})
+	if err != nil {
+		return false, err
+	}
+	return following[id], nil
+}
+
+// AreFollowing returns whether the viewer follows each of the users, every id is present in the result
+func (l *UserLoader) AreFollowing(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]bool, user_types.DomainError) {
+	result := make(map[uuid.UUID]bool, len(ids))
+	viewer, ok := l.viewer.Get()
+	if !ok {
+		for _, id := range ids {
+			result[id] = false
+		}
+		return result, nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for _, id := range ids {
+		if _, ok := l.following[id]; !ok {
+			l.pendingFollowing[id] = struct{}{}
+		}
+	}
+
+	if len(l.pendingFollowing) > 0 {
+		batch := slices.Collect(maps.Keys(l.pendingFollowing))
+		fetched, err := l.userService.AreFollowing(ctx, viewer, batch)
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range batch {
+			l.following[id] = fetched[id]
+		}
+		clear(l.pendingFollowing)
+	}
+
+	for _, id := range ids {
+		result[id] = l.following[id]
+	}
+	return result, nil
+}
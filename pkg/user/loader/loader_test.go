@@ -0,0 +1,133 @@
+package user_loader_test
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/nimaeskandary/go-realworld/pkg/test_utils/helpers"
+	"github.com/nimaeskandary/go-realworld/pkg/user/loader"
+	user_types "github.com/nimaeskandary/go-realworld/pkg/user/types"
+	user_types_mocks "github.com/nimaeskandary/go-realworld/pkg/user/types/mocks"
+
+	"github.com/google/uuid"
+	"github.com/samber/mo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_UserLoader(t *testing.T) {
+	t.Parallel()
+
+	viewer := helpers.GenUser()
+
+	// idsMatch matches a batch regardless of order, as it is built from a map
+	idsMatch := func(expected ...uuid.UUID) any {
+		return mock.MatchedBy(func(ids []uuid.UUID) bool {
+			return len(ids) == len(expected) && !slices.ContainsFunc(expected, func(id uuid.UUID) bool { return !slices.Contains(ids, id) })
+		})
+	}
+
+	t.Run("GetUsers", func(t *testing.T) {
+		t.Parallel()
+
+		t.Run("should fetch primed ids in the same batch as the lookup", func(t *testing.T) {
+			t.Parallel()
+			users := []user_types.User{helpers.GenUser(), helpers.GenUser(), helpers.GenUser()}
+			missingId := uuid.New()
+			userServiceMock := user_types_mocks.NewMockUserService(t)
+			userServiceMock.EXPECT().GetUsersByIds(mock.Anything, idsMatch(users[0].Id, users[1].Id, users[2].Id, missingId)).
+				Return(map[uuid.UUID]user_types.User{users[0].Id: users[0], users[1].Id: users[1], users[2].Id: users[2]}, nil).
+				Once()
+			underTest := user_loader.NewUserLoader(userServiceMock, mo.Some(viewer))
+
+			underTest.Prime(users[1].Id, users[2].Id, missingId)
+
+			first, err := underTest.GetUser(t.Context(), users[0].Id)
+			assert.NoError(t, err)
+			assert.Equal(t, mo.Some(users[0]), first)
+
+			// served from the cache, including the miss
+			rest, err := underTest.GetUsers(t.Context(), []uuid.UUID{users[1].Id, users[2].Id, missingId})
+			assert.NoError(t, err)
+			assert.Equal(t, map[uuid.UUID]user_types.User{users[1].Id: users[1], users[2].Id: users[2]}, rest)
+		})
+
+		t.Run("should coalesce concurrent lookups", func(t *testing.T) {
+			t.Parallel()
+			user := helpers.GenUser()
+			userServiceMock := user_types_mocks.NewMockUserService(t)
+			userServiceMock.EXPECT().GetUsersByIds(mock.Anything, []uuid.UUID{user.Id}).
+				Return(map[uuid.UUID]user_types.User{user.Id: user}, nil).
+				Once()
+			underTest := user_loader.NewUserLoader(userServiceMock, mo.Some(viewer))
+
+			var wg sync.WaitGroup
+			for range 10 {
+				wg.Go(func() {
+					result, err := underTest.GetUser(t.Context(), user.Id)
+					assert.NoError(t, err)
+					assert.Equal(t, mo.Some(user), result)
+				})
+			}
+			wg.Wait()
+		})
+
+		t.Run("should return the error and retry on the next lookup if the batch fails", func(t *testing.T) {
+			t.Parallel()
+			user := helpers.GenUser()
+			userServiceMock := user_types_mocks.NewMockUserService(t)
+			userServiceMock.EXPECT().GetUsersByIds(mock.Anything, []uuid.UUID{user.Id}).
+				Return(nil, user_types.UnknownError{Err: fmt.Errorf("get users failed")}).
+				Once()
+			userServiceMock.EXPECT().GetUsersByIds(mock.Anything, []uuid.UUID{user.Id}).
+				Return(map[uuid.UUID]user_types.User{user.Id: user}, nil).
+				Once()
+			underTest := user_loader.NewUserLoader(userServiceMock, mo.Some(viewer))
+
+			_, err := underTest.GetUser(t.Context(), user.Id)
+			assert.IsType(t, user_types.UnknownError{}, err)
+
+			result, err := underTest.GetUser(t.Context(), user.Id)
+			assert.NoError(t, err)
+			assert.Equal(t, mo.Some(user), result)
+		})
+	})
+
+	t.Run("AreFollowing", func(t *testing.T) {
+		t.Parallel()
+
+		t.Run("should return false for every id without querying when there is no viewer", func(t *testing.T) {
+			t.Parallel()
+			ids := []uuid.UUID{uuid.New(), uuid.New()}
+			userServiceMock := user_types_mocks.NewMockUserService(t)
+			underTest := user_loader.NewUserLoader(userServiceMock, mo.None[user_types.User]())
+
+			underTest.Prime(ids...)
+			result, err := underTest.AreFollowing(t.Context(), ids)
+			assert.NoError(t, err)
+			assert.Equal(t, map[uuid.UUID]bool{ids[0]: false, ids[1]: false}, result)
+		})
+
+		t.Run("should fetch primed ids in the same batch and cache the result", func(t *testing.T) {
+			t.Parallel()
+			ids := []uuid.UUID{uuid.New(), uuid.New()}
+			userServiceMock := user_types_mocks.NewMockUserService(t)
+			userServiceMock.EXPECT().AreFollowing(mock.Anything, viewer, idsMatch(ids...)).
+				Return(map[uuid.UUID]bool{ids[0]: true, ids[1]: false}, nil).
+				Once()
+			underTest := user_loader.NewUserLoader(userServiceMock, mo.Some(viewer))
+
+			underTest.Prime(ids...)
+
+			first, err := underTest.IsFollowing(t.Context(), ids[0])
+			assert.NoError(t, err)
+			assert.True(t, first)
+
+			second, err := underTest.IsFollowing(t.Context(), ids[1])
+			assert.NoError(t, err)
+			assert.False(t, second)
+		})
+	})
+}
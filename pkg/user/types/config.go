@@ -0,0 +1,13 @@
+package user_types
+
+import "time"
+
+type UserConfig struct {
+	// UsernameReleaseCooldownSeconds is how long a username stays reserved for its previous owner after a rename
+	UsernameReleaseCooldownSeconds int64 `json:"username_release_cooldown_seconds" validate:"required"`
+}
+
+// UsernameReleaseCutoff returns the point in time before which renamed away usernames can be claimed by another user
+func (c UserConfig) UsernameReleaseCutoff(now time.Time) time.Time {
+	return now.Add(-time.Duration(c.UsernameReleaseCooldownSeconds) * time.Second)
+}
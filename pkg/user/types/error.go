@@ -45,6 +45,15 @@ func (e ConflictError) Error() string {
 	return fmt.Sprintf("ConflictError: user conflict error: %v", e.Msg)
 }
 
+type VersionConflictError struct {
+	Identifier string
+}
+
+func (e VersionConflictError) sealed() {}
+func (e VersionConflictError) Error() string {
+	return fmt.Sprintf("VersionConflictError: user with identifier %v was modified by another request", e.Identifier)
+}
+
 type BadParamsError struct {
 	Err error
 }
@@ -60,3 +69,17 @@ func (e CannotFollowYourselfError) sealed() {}
 func (e CannotFollowYourselfError) Error() string {
 	return "CannotFollowYourselfError: cannot follow yourself"
 }
+
+type CannotFollowBlockedUserError struct{}
+
+func (e CannotFollowBlockedUserError) sealed() {}
+func (e CannotFollowBlockedUserError) Error() string {
+	return "CannotFollowBlockedUserError: cannot follow a user when either user has blocked the other"
+}
+
+type CannotBlockOrMuteYourselfError struct{}
+
+func (e CannotBlockOrMuteYourselfError) sealed() {}
+func (e CannotBlockOrMuteYourselfError) Error() string {
+	return "CannotBlockOrMuteYourselfError: cannot block or mute yourself"
+}
@@ -6,6 +6,7 @@ package user_types_mocks
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/nimaeskandary/go-realworld/pkg/user/types"
@@ -40,6 +41,143 @@ func (_m *MockUserRepository) EXPECT() *MockUserRepository_Expecter {
 	return &MockUserRepository_Expecter{mock: &_m.Mock}
 }
 
+// AreFollowing provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) AreFollowing(ctx context.Context, followedByUserId uuid.UUID, followingUserIds []uuid.UUID) (map[uuid.UUID]bool, error) {
+	ret := _mock.Called(ctx, followedByUserId, followingUserIds)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AreFollowing")
+	}
+
+	var r0 map[uuid.UUID]bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, []uuid.UUID) (map[uuid.UUID]bool, error)); ok {
+		return returnFunc(ctx, followedByUserId, followingUserIds)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, []uuid.UUID) map[uuid.UUID]bool); ok {
+		r0 = returnFunc(ctx, followedByUserId, followingUserIds)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[uuid.UUID]bool)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, []uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, followedByUserId, followingUserIds)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserRepository_AreFollowing_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AreFollowing'
+type MockUserRepository_AreFollowing_Call struct {
+	*mock.Call
+}
+
+// AreFollowing is a helper method to define mock.On call
+//   - ctx context.Context
+//   - followedByUserId uuid.UUID
+//   - followingUserIds []uuid.UUID
+func (_e *MockUserRepository_Expecter) AreFollowing(ctx interface{}, followedByUserId interface{}, followingUserIds interface{}) *MockUserRepository_AreFollowing_Call {
+	return &MockUserRepository_AreFollowing_Call{Call: _e.mock.On("AreFollowing", ctx, followedByUserId, followingUserIds)}
+}
+
+func (_c *MockUserRepository_AreFollowing_Call) Run(run func(ctx context.Context, followedByUserId uuid.UUID, followingUserIds []uuid.UUID)) *MockUserRepository_AreFollowing_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 []uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].([]uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_AreFollowing_Call) Return(uUIDToBool map[uuid.UUID]bool, err error) *MockUserRepository_AreFollowing_Call {
+	_c.Call.Return(uUIDToBool, err)
+	return _c
+}
+
+func (_c *MockUserRepository_AreFollowing_Call) RunAndReturn(run func(ctx context.Context, followedByUserId uuid.UUID, followingUserIds []uuid.UUID) (map[uuid.UUID]bool, error)) *MockUserRepository_AreFollowing_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Block provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) Block(ctx context.Context, blockedByUserId uuid.UUID, blockedUserId uuid.UUID) error {
+	ret := _mock.Called(ctx, blockedByUserId, blockedUserId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Block")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, blockedByUserId, blockedUserId)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockUserRepository_Block_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Block'
+type MockUserRepository_Block_Call struct {
+	*mock.Call
+}
+
+// Block is a helper method to define mock.On call
+//   - ctx context.Context
+//   - blockedByUserId uuid.UUID
+//   - blockedUserId uuid.UUID
+func (_e *MockUserRepository_Expecter) Block(ctx interface{}, blockedByUserId interface{}, blockedUserId interface{}) *MockUserRepository_Block_Call {
+	return &MockUserRepository_Block_Call{Call: _e.mock.On("Block", ctx, blockedByUserId, blockedUserId)}
+}
+
+func (_c *MockUserRepository_Block_Call) Run(run func(ctx context.Context, blockedByUserId uuid.UUID, blockedUserId uuid.UUID)) *MockUserRepository_Block_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_Block_Call) Return(err error) *MockUserRepository_Block_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockUserRepository_Block_Call) RunAndReturn(run func(ctx context.Context, blockedByUserId uuid.UUID, blockedUserId uuid.UUID) error) *MockUserRepository_Block_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // DeleteUser provides a mock function for the type MockUserRepository
 func (_mock *MockUserRepository) DeleteUser(ctx context.Context, id uuid.UUID) error {
 	ret := _mock.Called(ctx, id)
@@ -160,6 +298,78 @@ func (_c *MockUserRepository_Follow_Call) RunAndReturn(run func(ctx context.Cont
 	return _c
 }
 
+// GetDeletedUserById provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) GetDeletedUserById(ctx context.Context, id uuid.UUID, deletedAfter time.Time) (mo.Option[user_types.User], error) {
+	ret := _mock.Called(ctx, id, deletedAfter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDeletedUserById")
+	}
+
+	var r0 mo.Option[user_types.User]
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time) (mo.Option[user_types.User], error)); ok {
+		return returnFunc(ctx, id, deletedAfter)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time) mo.Option[user_types.User]); ok {
+		r0 = returnFunc(ctx, id, deletedAfter)
+	} else {
+		r0 = ret.Get(0).(mo.Option[user_types.User])
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, time.Time) error); ok {
+		r1 = returnFunc(ctx, id, deletedAfter)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserRepository_GetDeletedUserById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDeletedUserById'
+type MockUserRepository_GetDeletedUserById_Call struct {
+	*mock.Call
+}
+
+// GetDeletedUserById is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+//   - deletedAfter time.Time
+func (_e *MockUserRepository_Expecter) GetDeletedUserById(ctx interface{}, id interface{}, deletedAfter interface{}) *MockUserRepository_GetDeletedUserById_Call {
+	return &MockUserRepository_GetDeletedUserById_Call{Call: _e.mock.On("GetDeletedUserById", ctx, id, deletedAfter)}
+}
+
+func (_c *MockUserRepository_GetDeletedUserById_Call) Run(run func(ctx context.Context, id uuid.UUID, deletedAfter time.Time)) *MockUserRepository_GetDeletedUserById_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_GetDeletedUserById_Call) Return(option mo.Option[user_types.User], err error) *MockUserRepository_GetDeletedUserById_Call {
+	_c.Call.Return(option, err)
+	return _c
+}
+
+func (_c *MockUserRepository_GetDeletedUserById_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID, deletedAfter time.Time) (mo.Option[user_types.User], error)) *MockUserRepository_GetDeletedUserById_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetUserByEmail provides a mock function for the type MockUserRepository
 func (_mock *MockUserRepository) GetUserByEmail(ctx context.Context, email string) (mo.Option[user_types.User], error) {
 	ret := _mock.Called(ctx, email)
@@ -292,6 +502,72 @@ func (_c *MockUserRepository_GetUserById_Call) RunAndReturn(run func(ctx context
 	return _c
 }
 
+// GetUserByPreviousUsername provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) GetUserByPreviousUsername(ctx context.Context, username string) (mo.Option[user_types.User], error) {
+	ret := _mock.Called(ctx, username)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserByPreviousUsername")
+	}
+
+	var r0 mo.Option[user_types.User]
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (mo.Option[user_types.User], error)); ok {
+		return returnFunc(ctx, username)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) mo.Option[user_types.User]); ok {
+		r0 = returnFunc(ctx, username)
+	} else {
+		r0 = ret.Get(0).(mo.Option[user_types.User])
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, username)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserRepository_GetUserByPreviousUsername_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserByPreviousUsername'
+type MockUserRepository_GetUserByPreviousUsername_Call struct {
+	*mock.Call
+}
+
+// GetUserByPreviousUsername is a helper method to define mock.On call
+//   - ctx context.Context
+//   - username string
+func (_e *MockUserRepository_Expecter) GetUserByPreviousUsername(ctx interface{}, username interface{}) *MockUserRepository_GetUserByPreviousUsername_Call {
+	return &MockUserRepository_GetUserByPreviousUsername_Call{Call: _e.mock.On("GetUserByPreviousUsername", ctx, username)}
+}
+
+func (_c *MockUserRepository_GetUserByPreviousUsername_Call) Run(run func(ctx context.Context, username string)) *MockUserRepository_GetUserByPreviousUsername_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_GetUserByPreviousUsername_Call) Return(option mo.Option[user_types.User], err error) *MockUserRepository_GetUserByPreviousUsername_Call {
+	_c.Call.Return(option, err)
+	return _c
+}
+
+func (_c *MockUserRepository_GetUserByPreviousUsername_Call) RunAndReturn(run func(ctx context.Context, username string) (mo.Option[user_types.User], error)) *MockUserRepository_GetUserByPreviousUsername_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetUserByUsername provides a mock function for the type MockUserRepository
 func (_mock *MockUserRepository) GetUserByUsername(ctx context.Context, username string) (mo.Option[user_types.User], error) {
 	ret := _mock.Called(ctx, username)
@@ -358,58 +634,2015 @@ func (_c *MockUserRepository_GetUserByUsername_Call) RunAndReturn(run func(ctx c
 	return _c
 }
 
-// IsFollowing provides a mock function for the type MockUserRepository
-func (_mock *MockUserRepository) IsFollowing(ctx context.Context, followedByUserId uuid.UUID, followingUserId uuid.UUID) (bool, error) {
-	ret := _mock.Called(ctx, followedByUserId, followingUserId)
+// GetUsersByIds provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) GetUsersByIds(ctx context.Context, ids []uuid.UUID) ([]user_types.User, error) {
+	ret := _mock.Called(ctx, ids)
 
 	if len(ret) == 0 {
-		panic("no return value specified for IsFollowing")
+		panic("no return value specified for GetUsersByIds")
+	}
+
+	var r0 []user_types.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []uuid.UUID) ([]user_types.User, error)); ok {
+		return returnFunc(ctx, ids)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []uuid.UUID) []user_types.User); ok {
+		r0 = returnFunc(ctx, ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]user_types.User)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserRepository_GetUsersByIds_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUsersByIds'
+type MockUserRepository_GetUsersByIds_Call struct {
+	*mock.Call
+}
+
+// GetUsersByIds is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ids []uuid.UUID
+func (_e *MockUserRepository_Expecter) GetUsersByIds(ctx interface{}, ids interface{}) *MockUserRepository_GetUsersByIds_Call {
+	return &MockUserRepository_GetUsersByIds_Call{Call: _e.mock.On("GetUsersByIds", ctx, ids)}
+}
+
+func (_c *MockUserRepository_GetUsersByIds_Call) Run(run func(ctx context.Context, ids []uuid.UUID)) *MockUserRepository_GetUsersByIds_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].([]uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_GetUsersByIds_Call) Return(users []user_types.User, err error) *MockUserRepository_GetUsersByIds_Call {
+	_c.Call.Return(users, err)
+	return _c
+}
+
+func (_c *MockUserRepository_GetUsersByIds_Call) RunAndReturn(run func(ctx context.Context, ids []uuid.UUID) ([]user_types.User, error)) *MockUserRepository_GetUsersByIds_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IsBlockedEitherWay provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) IsBlockedEitherWay(ctx context.Context, userIdA uuid.UUID, userIdB uuid.UUID) (bool, error) {
+	ret := _mock.Called(ctx, userIdA, userIdB)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsBlockedEitherWay")
 	}
 
 	var r0 bool
 	var r1 error
 	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) (bool, error)); ok {
-		return returnFunc(ctx, followedByUserId, followingUserId)
+		return returnFunc(ctx, userIdA, userIdB)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) bool); ok {
+		r0 = returnFunc(ctx, userIdA, userIdB)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, userIdA, userIdB)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserRepository_IsBlockedEitherWay_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsBlockedEitherWay'
+type MockUserRepository_IsBlockedEitherWay_Call struct {
+	*mock.Call
+}
+
+// IsBlockedEitherWay is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userIdA uuid.UUID
+//   - userIdB uuid.UUID
+func (_e *MockUserRepository_Expecter) IsBlockedEitherWay(ctx interface{}, userIdA interface{}, userIdB interface{}) *MockUserRepository_IsBlockedEitherWay_Call {
+	return &MockUserRepository_IsBlockedEitherWay_Call{Call: _e.mock.On("IsBlockedEitherWay", ctx, userIdA, userIdB)}
+}
+
+func (_c *MockUserRepository_IsBlockedEitherWay_Call) Run(run func(ctx context.Context, userIdA uuid.UUID, userIdB uuid.UUID)) *MockUserRepository_IsBlockedEitherWay_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_IsBlockedEitherWay_Call) Return(b bool, err error) *MockUserRepository_IsBlockedEitherWay_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *MockUserRepository_IsBlockedEitherWay_Call) RunAndReturn(run func(ctx context.Context, userIdA uuid.UUID, userIdB uuid.UUID) (bool, error)) *MockUserRepository_IsBlockedEitherWay_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IsBlocking provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) IsBlocking(ctx context.Context, blockedByUserId uuid.UUID, blockedUserId uuid.UUID) (bool, error) {
+	ret := _mock.Called(ctx, blockedByUserId, blockedUserId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsBlocking")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) (bool, error)); ok {
+		return returnFunc(ctx, blockedByUserId, blockedUserId)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) bool); ok {
+		r0 = returnFunc(ctx, blockedByUserId, blockedUserId)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, blockedByUserId, blockedUserId)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserRepository_IsBlocking_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsBlocking'
+type MockUserRepository_IsBlocking_Call struct {
+	*mock.Call
+}
+
+// IsBlocking is a helper method to define mock.On call
+//   - ctx context.Context
+//   - blockedByUserId uuid.UUID
+//   - blockedUserId uuid.UUID
+func (_e *MockUserRepository_Expecter) IsBlocking(ctx interface{}, blockedByUserId interface{}, blockedUserId interface{}) *MockUserRepository_IsBlocking_Call {
+	return &MockUserRepository_IsBlocking_Call{Call: _e.mock.On("IsBlocking", ctx, blockedByUserId, blockedUserId)}
+}
+
+func (_c *MockUserRepository_IsBlocking_Call) Run(run func(ctx context.Context, blockedByUserId uuid.UUID, blockedUserId uuid.UUID)) *MockUserRepository_IsBlocking_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_IsBlocking_Call) Return(b bool, err error) *MockUserRepository_IsBlocking_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *MockUserRepository_IsBlocking_Call) RunAndReturn(run func(ctx context.Context, blockedByUserId uuid.UUID, blockedUserId uuid.UUID) (bool, error)) *MockUserRepository_IsBlocking_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IsFollowing provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) IsFollowing(ctx context.Context, followedByUserId uuid.UUID, followingUserId uuid.UUID) (bool, error) {
+	ret := _mock.Called(ctx, followedByUserId, followingUserId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsFollowing")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) (bool, error)); ok {
+		return returnFunc(ctx, followedByUserId, followingUserId)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) bool); ok {
+		r0 = returnFunc(ctx, followedByUserId, followingUserId)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, followedByUserId, followingUserId)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserRepository_IsFollowing_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsFollowing'
+type MockUserRepository_IsFollowing_Call struct {
+	*mock.Call
+}
+
+// IsFollowing is a helper method to define mock.On call
+//   - ctx context.Context
+//   - followedByUserId uuid.UUID
+//   - followingUserId uuid.UUID
+func (_e *MockUserRepository_Expecter) IsFollowing(ctx interface{}, followedByUserId interface{}, followingUserId interface{}) *MockUserRepository_IsFollowing_Call {
+	return &MockUserRepository_IsFollowing_Call{Call: _e.mock.On("IsFollowing", ctx, followedByUserId, followingUserId)}
+}
+
+func (_c *MockUserRepository_IsFollowing_Call) Run(run func(ctx context.Context, followedByUserId uuid.UUID, followingUserId uuid.UUID)) *MockUserRepository_IsFollowing_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_IsFollowing_Call) Return(b bool, err error) *MockUserRepository_IsFollowing_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *MockUserRepository_IsFollowing_Call) RunAndReturn(run func(ctx context.Context, followedByUserId uuid.UUID, followingUserId uuid.UUID) (bool, error)) *MockUserRepository_IsFollowing_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IsMuting provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) IsMuting(ctx context.Context, mutedByUserId uuid.UUID, mutedUserId uuid.UUID) (bool, error) {
+	ret := _mock.Called(ctx, mutedByUserId, mutedUserId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsMuting")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) (bool, error)); ok {
+		return returnFunc(ctx, mutedByUserId, mutedUserId)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) bool); ok {
+		r0 = returnFunc(ctx, mutedByUserId, mutedUserId)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, mutedByUserId, mutedUserId)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserRepository_IsMuting_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsMuting'
+type MockUserRepository_IsMuting_Call struct {
+	*mock.Call
+}
+
+// IsMuting is a helper method to define mock.On call
+//   - ctx context.Context
+//   - mutedByUserId uuid.UUID
+//   - mutedUserId uuid.UUID
+func (_e *MockUserRepository_Expecter) IsMuting(ctx interface{}, mutedByUserId interface{}, mutedUserId interface{}) *MockUserRepository_IsMuting_Call {
+	return &MockUserRepository_IsMuting_Call{Call: _e.mock.On("IsMuting", ctx, mutedByUserId, mutedUserId)}
+}
+
+func (_c *MockUserRepository_IsMuting_Call) Run(run func(ctx context.Context, mutedByUserId uuid.UUID, mutedUserId uuid.UUID)) *MockUserRepository_IsMuting_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_IsMuting_Call) Return(b bool, err error) *MockUserRepository_IsMuting_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *MockUserRepository_IsMuting_Call) RunAndReturn(run func(ctx context.Context, mutedByUserId uuid.UUID, mutedUserId uuid.UUID) (bool, error)) *MockUserRepository_IsMuting_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IsUsernameReserved provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) IsUsernameReserved(ctx context.Context, username string, claimantUserId uuid.UUID, changedAfter time.Time) (bool, error) {
+	ret := _mock.Called(ctx, username, claimantUserId, changedAfter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsUsernameReserved")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, uuid.UUID, time.Time) (bool, error)); ok {
+		return returnFunc(ctx, username, claimantUserId, changedAfter)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, uuid.UUID, time.Time) bool); ok {
+		r0 = returnFunc(ctx, username, claimantUserId, changedAfter)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, uuid.UUID, time.Time) error); ok {
+		r1 = returnFunc(ctx, username, claimantUserId, changedAfter)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserRepository_IsUsernameReserved_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsUsernameReserved'
+type MockUserRepository_IsUsernameReserved_Call struct {
+	*mock.Call
+}
+
+// IsUsernameReserved is a helper method to define mock.On call
+//   - ctx context.Context
+//   - username string
+//   - claimantUserId uuid.UUID
+//   - changedAfter time.Time
+func (_e *MockUserRepository_Expecter) IsUsernameReserved(ctx interface{}, username interface{}, claimantUserId interface{}, changedAfter interface{}) *MockUserRepository_IsUsernameReserved_Call {
+	return &MockUserRepository_IsUsernameReserved_Call{Call: _e.mock.On("IsUsernameReserved", ctx, username, claimantUserId, changedAfter)}
+}
+
+func (_c *MockUserRepository_IsUsernameReserved_Call) Run(run func(ctx context.Context, username string, claimantUserId uuid.UUID, changedAfter time.Time)) *MockUserRepository_IsUsernameReserved_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
+		}
+		var arg3 time.Time
+		if args[3] != nil {
+			arg3 = args[3].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_IsUsernameReserved_Call) Return(b bool, err error) *MockUserRepository_IsUsernameReserved_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *MockUserRepository_IsUsernameReserved_Call) RunAndReturn(run func(ctx context.Context, username string, claimantUserId uuid.UUID, changedAfter time.Time) (bool, error)) *MockUserRepository_IsUsernameReserved_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListHiddenUserIds provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) ListHiddenUserIds(ctx context.Context, viewerUserId uuid.UUID) ([]uuid.UUID, error) {
+	ret := _mock.Called(ctx, viewerUserId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListHiddenUserIds")
+	}
+
+	var r0 []uuid.UUID
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]uuid.UUID, error)); ok {
+		return returnFunc(ctx, viewerUserId)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) []uuid.UUID); ok {
+		r0 = returnFunc(ctx, viewerUserId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]uuid.UUID)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, viewerUserId)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserRepository_ListHiddenUserIds_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListHiddenUserIds'
+type MockUserRepository_ListHiddenUserIds_Call struct {
+	*mock.Call
+}
+
+// ListHiddenUserIds is a helper method to define mock.On call
+//   - ctx context.Context
+//   - viewerUserId uuid.UUID
+func (_e *MockUserRepository_Expecter) ListHiddenUserIds(ctx interface{}, viewerUserId interface{}) *MockUserRepository_ListHiddenUserIds_Call {
+	return &MockUserRepository_ListHiddenUserIds_Call{Call: _e.mock.On("ListHiddenUserIds", ctx, viewerUserId)}
+}
+
+func (_c *MockUserRepository_ListHiddenUserIds_Call) Run(run func(ctx context.Context, viewerUserId uuid.UUID)) *MockUserRepository_ListHiddenUserIds_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_ListHiddenUserIds_Call) Return(uUIDs []uuid.UUID, err error) *MockUserRepository_ListHiddenUserIds_Call {
+	_c.Call.Return(uUIDs, err)
+	return _c
+}
+
+func (_c *MockUserRepository_ListHiddenUserIds_Call) RunAndReturn(run func(ctx context.Context, viewerUserId uuid.UUID) ([]uuid.UUID, error)) *MockUserRepository_ListHiddenUserIds_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Mute provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) Mute(ctx context.Context, mutedByUserId uuid.UUID, mutedUserId uuid.UUID) error {
+	ret := _mock.Called(ctx, mutedByUserId, mutedUserId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Mute")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, mutedByUserId, mutedUserId)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockUserRepository_Mute_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Mute'
+type MockUserRepository_Mute_Call struct {
+	*mock.Call
+}
+
+// Mute is a helper method to define mock.On call
+//   - ctx context.Context
+//   - mutedByUserId uuid.UUID
+//   - mutedUserId uuid.UUID
+func (_e *MockUserRepository_Expecter) Mute(ctx interface{}, mutedByUserId interface{}, mutedUserId interface{}) *MockUserRepository_Mute_Call {
+	return &MockUserRepository_Mute_Call{Call: _e.mock.On("Mute", ctx, mutedByUserId, mutedUserId)}
+}
+
+func (_c *MockUserRepository_Mute_Call) Run(run func(ctx context.Context, mutedByUserId uuid.UUID, mutedUserId uuid.UUID)) *MockUserRepository_Mute_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_Mute_Call) Return(err error) *MockUserRepository_Mute_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockUserRepository_Mute_Call) RunAndReturn(run func(ctx context.Context, mutedByUserId uuid.UUID, mutedUserId uuid.UUID) error) *MockUserRepository_Mute_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PurgeDeletedUsers provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) PurgeDeletedUsers(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	ret := _mock.Called(ctx, deletedBefore)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeDeletedUsers")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) (int64, error)); ok {
+		return returnFunc(ctx, deletedBefore)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) int64); ok {
+		r0 = returnFunc(ctx, deletedBefore)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = returnFunc(ctx, deletedBefore)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserRepository_PurgeDeletedUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PurgeDeletedUsers'
+type MockUserRepository_PurgeDeletedUsers_Call struct {
+	*mock.Call
+}
+
+// PurgeDeletedUsers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - deletedBefore time.Time
+func (_e *MockUserRepository_Expecter) PurgeDeletedUsers(ctx interface{}, deletedBefore interface{}) *MockUserRepository_PurgeDeletedUsers_Call {
+	return &MockUserRepository_PurgeDeletedUsers_Call{Call: _e.mock.On("PurgeDeletedUsers", ctx, deletedBefore)}
+}
+
+func (_c *MockUserRepository_PurgeDeletedUsers_Call) Run(run func(ctx context.Context, deletedBefore time.Time)) *MockUserRepository_PurgeDeletedUsers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 time.Time
+		if args[1] != nil {
+			arg1 = args[1].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_PurgeDeletedUsers_Call) Return(n int64, err error) *MockUserRepository_PurgeDeletedUsers_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockUserRepository_PurgeDeletedUsers_Call) RunAndReturn(run func(ctx context.Context, deletedBefore time.Time) (int64, error)) *MockUserRepository_PurgeDeletedUsers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RestoreUser provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) RestoreUser(ctx context.Context, id uuid.UUID) error {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreUser")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockUserRepository_RestoreUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreUser'
+type MockUserRepository_RestoreUser_Call struct {
+	*mock.Call
+}
+
+// RestoreUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockUserRepository_Expecter) RestoreUser(ctx interface{}, id interface{}) *MockUserRepository_RestoreUser_Call {
+	return &MockUserRepository_RestoreUser_Call{Call: _e.mock.On("RestoreUser", ctx, id)}
+}
+
+func (_c *MockUserRepository_RestoreUser_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockUserRepository_RestoreUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_RestoreUser_Call) Return(err error) *MockUserRepository_RestoreUser_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockUserRepository_RestoreUser_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID) error) *MockUserRepository_RestoreUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Unblock provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) Unblock(ctx context.Context, blockedByUserId uuid.UUID, blockedUserId uuid.UUID) error {
+	ret := _mock.Called(ctx, blockedByUserId, blockedUserId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Unblock")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, blockedByUserId, blockedUserId)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockUserRepository_Unblock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Unblock'
+type MockUserRepository_Unblock_Call struct {
+	*mock.Call
+}
+
+// Unblock is a helper method to define mock.On call
+//   - ctx context.Context
+//   - blockedByUserId uuid.UUID
+//   - blockedUserId uuid.UUID
+func (_e *MockUserRepository_Expecter) Unblock(ctx interface{}, blockedByUserId interface{}, blockedUserId interface{}) *MockUserRepository_Unblock_Call {
+	return &MockUserRepository_Unblock_Call{Call: _e.mock.On("Unblock", ctx, blockedByUserId, blockedUserId)}
+}
+
+func (_c *MockUserRepository_Unblock_Call) Run(run func(ctx context.Context, blockedByUserId uuid.UUID, blockedUserId uuid.UUID)) *MockUserRepository_Unblock_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_Unblock_Call) Return(err error) *MockUserRepository_Unblock_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockUserRepository_Unblock_Call) RunAndReturn(run func(ctx context.Context, blockedByUserId uuid.UUID, blockedUserId uuid.UUID) error) *MockUserRepository_Unblock_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Unfollow provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) Unfollow(ctx context.Context, followedByUserId uuid.UUID, followingUserId uuid.UUID) error {
+	ret := _mock.Called(ctx, followedByUserId, followingUserId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Unfollow")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, followedByUserId, followingUserId)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockUserRepository_Unfollow_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Unfollow'
+type MockUserRepository_Unfollow_Call struct {
+	*mock.Call
+}
+
+// Unfollow is a helper method to define mock.On call
+//   - ctx context.Context
+//   - followedByUserId uuid.UUID
+//   - followingUserId uuid.UUID
+func (_e *MockUserRepository_Expecter) Unfollow(ctx interface{}, followedByUserId interface{}, followingUserId interface{}) *MockUserRepository_Unfollow_Call {
+	return &MockUserRepository_Unfollow_Call{Call: _e.mock.On("Unfollow", ctx, followedByUserId, followingUserId)}
+}
+
+func (_c *MockUserRepository_Unfollow_Call) Run(run func(ctx context.Context, followedByUserId uuid.UUID, followingUserId uuid.UUID)) *MockUserRepository_Unfollow_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_Unfollow_Call) Return(err error) *MockUserRepository_Unfollow_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockUserRepository_Unfollow_Call) RunAndReturn(run func(ctx context.Context, followedByUserId uuid.UUID, followingUserId uuid.UUID) error) *MockUserRepository_Unfollow_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Unmute provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) Unmute(ctx context.Context, mutedByUserId uuid.UUID, mutedUserId uuid.UUID) error {
+	ret := _mock.Called(ctx, mutedByUserId, mutedUserId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Unmute")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, mutedByUserId, mutedUserId)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockUserRepository_Unmute_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Unmute'
+type MockUserRepository_Unmute_Call struct {
+	*mock.Call
+}
+
+// Unmute is a helper method to define mock.On call
+//   - ctx context.Context
+//   - mutedByUserId uuid.UUID
+//   - mutedUserId uuid.UUID
+func (_e *MockUserRepository_Expecter) Unmute(ctx interface{}, mutedByUserId interface{}, mutedUserId interface{}) *MockUserRepository_Unmute_Call {
+	return &MockUserRepository_Unmute_Call{Call: _e.mock.On("Unmute", ctx, mutedByUserId, mutedUserId)}
+}
+
+func (_c *MockUserRepository_Unmute_Call) Run(run func(ctx context.Context, mutedByUserId uuid.UUID, mutedUserId uuid.UUID)) *MockUserRepository_Unmute_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_Unmute_Call) Return(err error) *MockUserRepository_Unmute_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockUserRepository_Unmute_Call) RunAndReturn(run func(ctx context.Context, mutedByUserId uuid.UUID, mutedUserId uuid.UUID) error) *MockUserRepository_Unmute_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpsertUser provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) UpsertUser(ctx context.Context, user user_types.User) (user_types.User, error) {
+	ret := _mock.Called(ctx, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertUser")
+	}
+
+	var r0 user_types.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, user_types.User) (user_types.User, error)); ok {
+		return returnFunc(ctx, user)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, user_types.User) user_types.User); ok {
+		r0 = returnFunc(ctx, user)
+	} else {
+		r0 = ret.Get(0).(user_types.User)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, user_types.User) error); ok {
+		r1 = returnFunc(ctx, user)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserRepository_UpsertUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpsertUser'
+type MockUserRepository_UpsertUser_Call struct {
+	*mock.Call
+}
+
+// UpsertUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - user user_types.User
+func (_e *MockUserRepository_Expecter) UpsertUser(ctx interface{}, user interface{}) *MockUserRepository_UpsertUser_Call {
+	return &MockUserRepository_UpsertUser_Call{Call: _e.mock.On("UpsertUser", ctx, user)}
+}
+
+func (_c *MockUserRepository_UpsertUser_Call) Run(run func(ctx context.Context, user user_types.User)) *MockUserRepository_UpsertUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 user_types.User
+		if args[1] != nil {
+			arg1 = args[1].(user_types.User)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_UpsertUser_Call) Return(user1 user_types.User, err error) *MockUserRepository_UpsertUser_Call {
+	_c.Call.Return(user1, err)
+	return _c
+}
+
+func (_c *MockUserRepository_UpsertUser_Call) RunAndReturn(run func(ctx context.Context, user user_types.User) (user_types.User, error)) *MockUserRepository_UpsertUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockUserService creates a new instance of MockUserService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockUserService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockUserService {
+	mock := &MockUserService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockUserService is an autogenerated mock type for the UserService type
+type MockUserService struct {
+	mock.Mock
+}
+
+type MockUserService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockUserService) EXPECT() *MockUserService_Expecter {
+	return &MockUserService_Expecter{mock: &_m.Mock}
+}
+
+// AreFollowing provides a mock function for the type MockUserService
+func (_mock *MockUserService) AreFollowing(ctx context.Context, authUser user_types.User, targetUserIds []uuid.UUID) (map[uuid.UUID]bool, user_types.DomainError) {
+	ret := _mock.Called(ctx, authUser, targetUserIds)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AreFollowing")
+	}
+
+	var r0 map[uuid.UUID]bool
+	var r1 user_types.DomainError
+	if returnFunc, ok := ret.Get(0).(func(context.Context, user_types.User, []uuid.UUID) (map[uuid.UUID]bool, user_types.DomainError)); ok {
+		return returnFunc(ctx, authUser, targetUserIds)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, user_types.User, []uuid.UUID) map[uuid.UUID]bool); ok {
+		r0 = returnFunc(ctx, authUser, targetUserIds)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[uuid.UUID]bool)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, user_types.User, []uuid.UUID) user_types.DomainError); ok {
+		r1 = returnFunc(ctx, authUser, targetUserIds)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(user_types.DomainError)
+		}
+	}
+	return r0, r1
+}
+
+// MockUserService_AreFollowing_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AreFollowing'
+type MockUserService_AreFollowing_Call struct {
+	*mock.Call
+}
+
+// AreFollowing is a helper method to define mock.On call
+//   - ctx context.Context
+//   - authUser user_types.User
+//   - targetUserIds []uuid.UUID
+func (_e *MockUserService_Expecter) AreFollowing(ctx interface{}, authUser interface{}, targetUserIds interface{}) *MockUserService_AreFollowing_Call {
+	return &MockUserService_AreFollowing_Call{Call: _e.mock.On("AreFollowing", ctx, authUser, targetUserIds)}
+}
+
+func (_c *MockUserService_AreFollowing_Call) Run(run func(ctx context.Context, authUser user_types.User, targetUserIds []uuid.UUID)) *MockUserService_AreFollowing_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 user_types.User
+		if args[1] != nil {
+			arg1 = args[1].(user_types.User)
+		}
+		var arg2 []uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].([]uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserService_AreFollowing_Call) Return(uUIDToBool map[uuid.UUID]bool, domainError user_types.DomainError) *MockUserService_AreFollowing_Call {
+	_c.Call.Return(uUIDToBool, domainError)
+	return _c
+}
+
+func (_c *MockUserService_AreFollowing_Call) RunAndReturn(run func(ctx context.Context, authUser user_types.User, targetUserIds []uuid.UUID) (map[uuid.UUID]bool, user_types.DomainError)) *MockUserService_AreFollowing_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// BlockProfile provides a mock function for the type MockUserService
+func (_mock *MockUserService) BlockProfile(ctx context.Context, authUser user_types.User, targetUsername string) (user_types.User, user_types.DomainError) {
+	ret := _mock.Called(ctx, authUser, targetUsername)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BlockProfile")
+	}
+
+	var r0 user_types.User
+	var r1 user_types.DomainError
+	if returnFunc, ok := ret.Get(0).(func(context.Context, user_types.User, string) (user_types.User, user_types.DomainError)); ok {
+		return returnFunc(ctx, authUser, targetUsername)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, user_types.User, string) user_types.User); ok {
+		r0 = returnFunc(ctx, authUser, targetUsername)
+	} else {
+		r0 = ret.Get(0).(user_types.User)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, user_types.User, string) user_types.DomainError); ok {
+		r1 = returnFunc(ctx, authUser, targetUsername)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(user_types.DomainError)
+		}
+	}
+	return r0, r1
+}
+
+// MockUserService_BlockProfile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BlockProfile'
+type MockUserService_BlockProfile_Call struct {
+	*mock.Call
+}
+
+// BlockProfile is a helper method to define mock.On call
+//   - ctx context.Context
+//   - authUser user_types.User
+//   - targetUsername string
+func (_e *MockUserService_Expecter) BlockProfile(ctx interface{}, authUser interface{}, targetUsername interface{}) *MockUserService_BlockProfile_Call {
+	return &MockUserService_BlockProfile_Call{Call: _e.mock.On("BlockProfile", ctx, authUser, targetUsername)}
+}
+
+func (_c *MockUserService_BlockProfile_Call) Run(run func(ctx context.Context, authUser user_types.User, targetUsername string)) *MockUserService_BlockProfile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 user_types.User
+		if args[1] != nil {
+			arg1 = args[1].(user_types.User)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserService_BlockProfile_Call) Return(user user_types.User, domainError user_types.DomainError) *MockUserService_BlockProfile_Call {
+	_c.Call.Return(user, domainError)
+	return _c
+}
+
+func (_c *MockUserService_BlockProfile_Call) RunAndReturn(run func(ctx context.Context, authUser user_types.User, targetUsername string) (user_types.User, user_types.DomainError)) *MockUserService_BlockProfile_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateUser provides a mock function for the type MockUserService
+func (_mock *MockUserService) CreateUser(ctx context.Context, user user_types.UpsertUserParams) (user_types.User, user_types.DomainError) {
+	ret := _mock.Called(ctx, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateUser")
+	}
+
+	var r0 user_types.User
+	var r1 user_types.DomainError
+	if returnFunc, ok := ret.Get(0).(func(context.Context, user_types.UpsertUserParams) (user_types.User, user_types.DomainError)); ok {
+		return returnFunc(ctx, user)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, user_types.UpsertUserParams) user_types.User); ok {
+		r0 = returnFunc(ctx, user)
+	} else {
+		r0 = ret.Get(0).(user_types.User)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, user_types.UpsertUserParams) user_types.DomainError); ok {
+		r1 = returnFunc(ctx, user)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(user_types.DomainError)
+		}
+	}
+	return r0, r1
+}
+
+// MockUserService_CreateUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateUser'
+type MockUserService_CreateUser_Call struct {
+	*mock.Call
+}
+
+// CreateUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - user user_types.UpsertUserParams
+func (_e *MockUserService_Expecter) CreateUser(ctx interface{}, user interface{}) *MockUserService_CreateUser_Call {
+	return &MockUserService_CreateUser_Call{Call: _e.mock.On("CreateUser", ctx, user)}
+}
+
+func (_c *MockUserService_CreateUser_Call) Run(run func(ctx context.Context, user user_types.UpsertUserParams)) *MockUserService_CreateUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 user_types.UpsertUserParams
+		if args[1] != nil {
+			arg1 = args[1].(user_types.UpsertUserParams)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserService_CreateUser_Call) Return(user1 user_types.User, domainError user_types.DomainError) *MockUserService_CreateUser_Call {
+	_c.Call.Return(user1, domainError)
+	return _c
+}
+
+func (_c *MockUserService_CreateUser_Call) RunAndReturn(run func(ctx context.Context, user user_types.UpsertUserParams) (user_types.User, user_types.DomainError)) *MockUserService_CreateUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteUser provides a mock function for the type MockUserService
+func (_mock *MockUserService) DeleteUser(ctx context.Context, id uuid.UUID) user_types.DomainError {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteUser")
+	}
+
+	var r0 user_types.DomainError
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) user_types.DomainError); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(user_types.DomainError)
+		}
+	}
+	return r0
+}
+
+// MockUserService_DeleteUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteUser'
+type MockUserService_DeleteUser_Call struct {
+	*mock.Call
+}
+
+// DeleteUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockUserService_Expecter) DeleteUser(ctx interface{}, id interface{}) *MockUserService_DeleteUser_Call {
+	return &MockUserService_DeleteUser_Call{Call: _e.mock.On("DeleteUser", ctx, id)}
+}
+
+func (_c *MockUserService_DeleteUser_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockUserService_DeleteUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserService_DeleteUser_Call) Return(domainError user_types.DomainError) *MockUserService_DeleteUser_Call {
+	_c.Call.Return(domainError)
+	return _c
+}
+
+func (_c *MockUserService_DeleteUser_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID) user_types.DomainError) *MockUserService_DeleteUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FollowProfile provides a mock function for the type MockUserService
+func (_mock *MockUserService) FollowProfile(ctx context.Context, authUser user_types.User, targetUsername string) (user_types.User, user_types.DomainError) {
+	ret := _mock.Called(ctx, authUser, targetUsername)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FollowProfile")
+	}
+
+	var r0 user_types.User
+	var r1 user_types.DomainError
+	if returnFunc, ok := ret.Get(0).(func(context.Context, user_types.User, string) (user_types.User, user_types.DomainError)); ok {
+		return returnFunc(ctx, authUser, targetUsername)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, user_types.User, string) user_types.User); ok {
+		r0 = returnFunc(ctx, authUser, targetUsername)
+	} else {
+		r0 = ret.Get(0).(user_types.User)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, user_types.User, string) user_types.DomainError); ok {
+		r1 = returnFunc(ctx, authUser, targetUsername)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(user_types.DomainError)
+		}
+	}
+	return r0, r1
+}
+
+// MockUserService_FollowProfile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FollowProfile'
+type MockUserService_FollowProfile_Call struct {
+	*mock.Call
+}
+
+// FollowProfile is a helper method to define mock.On call
+//   - ctx context.Context
+//   - authUser user_types.User
+//   - targetUsername string
+func (_e *MockUserService_Expecter) FollowProfile(ctx interface{}, authUser interface{}, targetUsername interface{}) *MockUserService_FollowProfile_Call {
+	return &MockUserService_FollowProfile_Call{Call: _e.mock.On("FollowProfile", ctx, authUser, targetUsername)}
+}
+
+func (_c *MockUserService_FollowProfile_Call) Run(run func(ctx context.Context, authUser user_types.User, targetUsername string)) *MockUserService_FollowProfile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 user_types.User
+		if args[1] != nil {
+			arg1 = args[1].(user_types.User)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserService_FollowProfile_Call) Return(user user_types.User, domainError user_types.DomainError) *MockUserService_FollowProfile_Call {
+	_c.Call.Return(user, domainError)
+	return _c
+}
+
+func (_c *MockUserService_FollowProfile_Call) RunAndReturn(run func(ctx context.Context, authUser user_types.User, targetUsername string) (user_types.User, user_types.DomainError)) *MockUserService_FollowProfile_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetHiddenUserIds provides a mock function for the type MockUserService
+func (_mock *MockUserService) GetHiddenUserIds(ctx context.Context, viewer user_types.User) ([]uuid.UUID, user_types.DomainError) {
+	ret := _mock.Called(ctx, viewer)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetHiddenUserIds")
+	}
+
+	var r0 []uuid.UUID
+	var r1 user_types.DomainError
+	if returnFunc, ok := ret.Get(0).(func(context.Context, user_types.User) ([]uuid.UUID, user_types.DomainError)); ok {
+		return returnFunc(ctx, viewer)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, user_types.User) []uuid.UUID); ok {
+		r0 = returnFunc(ctx, viewer)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]uuid.UUID)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, user_types.User) user_types.DomainError); ok {
+		r1 = returnFunc(ctx, viewer)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(user_types.DomainError)
+		}
+	}
+	return r0, r1
+}
+
+// MockUserService_GetHiddenUserIds_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetHiddenUserIds'
+type MockUserService_GetHiddenUserIds_Call struct {
+	*mock.Call
+}
+
+// GetHiddenUserIds is a helper method to define mock.On call
+//   - ctx context.Context
+//   - viewer user_types.User
+func (_e *MockUserService_Expecter) GetHiddenUserIds(ctx interface{}, viewer interface{}) *MockUserService_GetHiddenUserIds_Call {
+	return &MockUserService_GetHiddenUserIds_Call{Call: _e.mock.On("GetHiddenUserIds", ctx, viewer)}
+}
+
+func (_c *MockUserService_GetHiddenUserIds_Call) Run(run func(ctx context.Context, viewer user_types.User)) *MockUserService_GetHiddenUserIds_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 user_types.User
+		if args[1] != nil {
+			arg1 = args[1].(user_types.User)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserService_GetHiddenUserIds_Call) Return(uUIDs []uuid.UUID, domainError user_types.DomainError) *MockUserService_GetHiddenUserIds_Call {
+	_c.Call.Return(uUIDs, domainError)
+	return _c
+}
+
+func (_c *MockUserService_GetHiddenUserIds_Call) RunAndReturn(run func(ctx context.Context, viewer user_types.User) ([]uuid.UUID, user_types.DomainError)) *MockUserService_GetHiddenUserIds_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUserByEmail provides a mock function for the type MockUserService
+func (_mock *MockUserService) GetUserByEmail(ctx context.Context, email string) (user_types.User, user_types.DomainError) {
+	ret := _mock.Called(ctx, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserByEmail")
+	}
+
+	var r0 user_types.User
+	var r1 user_types.DomainError
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (user_types.User, user_types.DomainError)); ok {
+		return returnFunc(ctx, email)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) user_types.User); ok {
+		r0 = returnFunc(ctx, email)
+	} else {
+		r0 = ret.Get(0).(user_types.User)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) user_types.DomainError); ok {
+		r1 = returnFunc(ctx, email)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(user_types.DomainError)
+		}
+	}
+	return r0, r1
+}
+
+// MockUserService_GetUserByEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserByEmail'
+type MockUserService_GetUserByEmail_Call struct {
+	*mock.Call
+}
+
+// GetUserByEmail is a helper method to define mock.On call
+//   - ctx context.Context
+//   - email string
+func (_e *MockUserService_Expecter) GetUserByEmail(ctx interface{}, email interface{}) *MockUserService_GetUserByEmail_Call {
+	return &MockUserService_GetUserByEmail_Call{Call: _e.mock.On("GetUserByEmail", ctx, email)}
+}
+
+func (_c *MockUserService_GetUserByEmail_Call) Run(run func(ctx context.Context, email string)) *MockUserService_GetUserByEmail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserService_GetUserByEmail_Call) Return(user user_types.User, domainError user_types.DomainError) *MockUserService_GetUserByEmail_Call {
+	_c.Call.Return(user, domainError)
+	return _c
+}
+
+func (_c *MockUserService_GetUserByEmail_Call) RunAndReturn(run func(ctx context.Context, email string) (user_types.User, user_types.DomainError)) *MockUserService_GetUserByEmail_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUserByUsername provides a mock function for the type MockUserService
+func (_mock *MockUserService) GetUserByUsername(ctx context.Context, username string) (user_types.User, user_types.DomainError) {
+	ret := _mock.Called(ctx, username)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserByUsername")
+	}
+
+	var r0 user_types.User
+	var r1 user_types.DomainError
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (user_types.User, user_types.DomainError)); ok {
+		return returnFunc(ctx, username)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) user_types.User); ok {
+		r0 = returnFunc(ctx, username)
+	} else {
+		r0 = ret.Get(0).(user_types.User)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) user_types.DomainError); ok {
+		r1 = returnFunc(ctx, username)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(user_types.DomainError)
+		}
+	}
+	return r0, r1
+}
+
+// MockUserService_GetUserByUsername_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserByUsername'
+type MockUserService_GetUserByUsername_Call struct {
+	*mock.Call
+}
+
+// GetUserByUsername is a helper method to define mock.On call
+//   - ctx context.Context
+//   - username string
+func (_e *MockUserService_Expecter) GetUserByUsername(ctx interface{}, username interface{}) *MockUserService_GetUserByUsername_Call {
+	return &MockUserService_GetUserByUsername_Call{Call: _e.mock.On("GetUserByUsername", ctx, username)}
+}
+
+func (_c *MockUserService_GetUserByUsername_Call) Run(run func(ctx context.Context, username string)) *MockUserService_GetUserByUsername_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserService_GetUserByUsername_Call) Return(user user_types.User, domainError user_types.DomainError) *MockUserService_GetUserByUsername_Call {
+	_c.Call.Return(user, domainError)
+	return _c
+}
+
+func (_c *MockUserService_GetUserByUsername_Call) RunAndReturn(run func(ctx context.Context, username string) (user_types.User, user_types.DomainError)) *MockUserService_GetUserByUsername_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUsersByIds provides a mock function for the type MockUserService
+func (_mock *MockUserService) GetUsersByIds(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]user_types.User, user_types.DomainError) {
+	ret := _mock.Called(ctx, ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUsersByIds")
+	}
+
+	var r0 map[uuid.UUID]user_types.User
+	var r1 user_types.DomainError
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []uuid.UUID) (map[uuid.UUID]user_types.User, user_types.DomainError)); ok {
+		return returnFunc(ctx, ids)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []uuid.UUID) map[uuid.UUID]user_types.User); ok {
+		r0 = returnFunc(ctx, ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[uuid.UUID]user_types.User)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []uuid.UUID) user_types.DomainError); ok {
+		r1 = returnFunc(ctx, ids)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(user_types.DomainError)
+		}
+	}
+	return r0, r1
+}
+
+// MockUserService_GetUsersByIds_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUsersByIds'
+type MockUserService_GetUsersByIds_Call struct {
+	*mock.Call
+}
+
+// GetUsersByIds is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ids []uuid.UUID
+func (_e *MockUserService_Expecter) GetUsersByIds(ctx interface{}, ids interface{}) *MockUserService_GetUsersByIds_Call {
+	return &MockUserService_GetUsersByIds_Call{Call: _e.mock.On("GetUsersByIds", ctx, ids)}
+}
+
+func (_c *MockUserService_GetUsersByIds_Call) Run(run func(ctx context.Context, ids []uuid.UUID)) *MockUserService_GetUsersByIds_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].([]uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserService_GetUsersByIds_Call) Return(uUIDToUser map[uuid.UUID]user_types.User, domainError user_types.DomainError) *MockUserService_GetUsersByIds_Call {
+	_c.Call.Return(uUIDToUser, domainError)
+	return _c
+}
+
+func (_c *MockUserService_GetUsersByIds_Call) RunAndReturn(run func(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]user_types.User, user_types.DomainError)) *MockUserService_GetUsersByIds_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IsFollowing provides a mock function for the type MockUserService
+func (_mock *MockUserService) IsFollowing(ctx context.Context, authUser user_types.User, targetUsername string) (bool, user_types.DomainError) {
+	ret := _mock.Called(ctx, authUser, targetUsername)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsFollowing")
+	}
+
+	var r0 bool
+	var r1 user_types.DomainError
+	if returnFunc, ok := ret.Get(0).(func(context.Context, user_types.User, string) (bool, user_types.DomainError)); ok {
+		return returnFunc(ctx, authUser, targetUsername)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, user_types.User, string) bool); ok {
+		r0 = returnFunc(ctx, authUser, targetUsername)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, user_types.User, string) user_types.DomainError); ok {
+		r1 = returnFunc(ctx, authUser, targetUsername)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(user_types.DomainError)
+		}
+	}
+	return r0, r1
+}
+
+// MockUserService_IsFollowing_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsFollowing'
+type MockUserService_IsFollowing_Call struct {
+	*mock.Call
+}
+
+// IsFollowing is a helper method to define mock.On call
+//   - ctx context.Context
+//   - authUser user_types.User
+//   - targetUsername string
+func (_e *MockUserService_Expecter) IsFollowing(ctx interface{}, authUser interface{}, targetUsername interface{}) *MockUserService_IsFollowing_Call {
+	return &MockUserService_IsFollowing_Call{Call: _e.mock.On("IsFollowing", ctx, authUser, targetUsername)}
+}
+
+func (_c *MockUserService_IsFollowing_Call) Run(run func(ctx context.Context, authUser user_types.User, targetUsername string)) *MockUserService_IsFollowing_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 user_types.User
+		if args[1] != nil {
+			arg1 = args[1].(user_types.User)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserService_IsFollowing_Call) Return(b bool, domainError user_types.DomainError) *MockUserService_IsFollowing_Call {
+	_c.Call.Return(b, domainError)
+	return _c
+}
+
+func (_c *MockUserService_IsFollowing_Call) RunAndReturn(run func(ctx context.Context, authUser user_types.User, targetUsername string) (bool, user_types.DomainError)) *MockUserService_IsFollowing_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MuteProfile provides a mock function for the type MockUserService
+func (_mock *MockUserService) MuteProfile(ctx context.Context, authUser user_types.User, targetUsername string) (user_types.User, user_types.DomainError) {
+	ret := _mock.Called(ctx, authUser, targetUsername)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MuteProfile")
+	}
+
+	var r0 user_types.User
+	var r1 user_types.DomainError
+	if returnFunc, ok := ret.Get(0).(func(context.Context, user_types.User, string) (user_types.User, user_types.DomainError)); ok {
+		return returnFunc(ctx, authUser, targetUsername)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, user_types.User, string) user_types.User); ok {
+		r0 = returnFunc(ctx, authUser, targetUsername)
+	} else {
+		r0 = ret.Get(0).(user_types.User)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, user_types.User, string) user_types.DomainError); ok {
+		r1 = returnFunc(ctx, authUser, targetUsername)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(user_types.DomainError)
+		}
+	}
+	return r0, r1
+}
+
+// MockUserService_MuteProfile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MuteProfile'
+type MockUserService_MuteProfile_Call struct {
+	*mock.Call
+}
+
+// MuteProfile is a helper method to define mock.On call
+//   - ctx context.Context
+//   - authUser user_types.User
+//   - targetUsername string
+func (_e *MockUserService_Expecter) MuteProfile(ctx interface{}, authUser interface{}, targetUsername interface{}) *MockUserService_MuteProfile_Call {
+	return &MockUserService_MuteProfile_Call{Call: _e.mock.On("MuteProfile", ctx, authUser, targetUsername)}
+}
+
+func (_c *MockUserService_MuteProfile_Call) Run(run func(ctx context.Context, authUser user_types.User, targetUsername string)) *MockUserService_MuteProfile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 user_types.User
+		if args[1] != nil {
+			arg1 = args[1].(user_types.User)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserService_MuteProfile_Call) Return(user user_types.User, domainError user_types.DomainError) *MockUserService_MuteProfile_Call {
+	_c.Call.Return(user, domainError)
+	return _c
+}
+
+func (_c *MockUserService_MuteProfile_Call) RunAndReturn(run func(ctx context.Context, authUser user_types.User, targetUsername string) (user_types.User, user_types.DomainError)) *MockUserService_MuteProfile_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ResolveUsername provides a mock function for the type MockUserService
+func (_mock *MockUserService) ResolveUsername(ctx context.Context, username string) (user_types.User, user_types.DomainError) {
+	ret := _mock.Called(ctx, username)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResolveUsername")
+	}
+
+	var r0 user_types.User
+	var r1 user_types.DomainError
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (user_types.User, user_types.DomainError)); ok {
+		return returnFunc(ctx, username)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) user_types.User); ok {
+		r0 = returnFunc(ctx, username)
+	} else {
+		r0 = ret.Get(0).(user_types.User)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) user_types.DomainError); ok {
+		r1 = returnFunc(ctx, username)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(user_types.DomainError)
+		}
+	}
+	return r0, r1
+}
+
+// MockUserService_ResolveUsername_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResolveUsername'
+type MockUserService_ResolveUsername_Call struct {
+	*mock.Call
+}
+
+// ResolveUsername is a helper method to define mock.On call
+//   - ctx context.Context
+//   - username string
+func (_e *MockUserService_Expecter) ResolveUsername(ctx interface{}, username interface{}) *MockUserService_ResolveUsername_Call {
+	return &MockUserService_ResolveUsername_Call{Call: _e.mock.On("ResolveUsername", ctx, username)}
+}
+
+func (_c *MockUserService_ResolveUsername_Call) Run(run func(ctx context.Context, username string)) *MockUserService_ResolveUsername_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserService_ResolveUsername_Call) Return(user user_types.User, domainError user_types.DomainError) *MockUserService_ResolveUsername_Call {
+	_c.Call.Return(user, domainError)
+	return _c
+}
+
+func (_c *MockUserService_ResolveUsername_Call) RunAndReturn(run func(ctx context.Context, username string) (user_types.User, user_types.DomainError)) *MockUserService_ResolveUsername_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RestoreUser provides a mock function for the type MockUserService
+func (_mock *MockUserService) RestoreUser(ctx context.Context, id uuid.UUID) (user_types.User, user_types.DomainError) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreUser")
+	}
+
+	var r0 user_types.User
+	var r1 user_types.DomainError
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) (user_types.User, user_types.DomainError)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) user_types.User); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Get(0).(user_types.User)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) user_types.DomainError); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(user_types.DomainError)
+		}
+	}
+	return r0, r1
+}
+
+// MockUserService_RestoreUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreUser'
+type MockUserService_RestoreUser_Call struct {
+	*mock.Call
+}
+
+// RestoreUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockUserService_Expecter) RestoreUser(ctx interface{}, id interface{}) *MockUserService_RestoreUser_Call {
+	return &MockUserService_RestoreUser_Call{Call: _e.mock.On("RestoreUser", ctx, id)}
+}
+
+func (_c *MockUserService_RestoreUser_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockUserService_RestoreUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserService_RestoreUser_Call) Return(user user_types.User, domainError user_types.DomainError) *MockUserService_RestoreUser_Call {
+	_c.Call.Return(user, domainError)
+	return _c
+}
+
+func (_c *MockUserService_RestoreUser_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID) (user_types.User, user_types.DomainError)) *MockUserService_RestoreUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UnblockProfile provides a mock function for the type MockUserService
+func (_mock *MockUserService) UnblockProfile(ctx context.Context, authUser user_types.User, targetUsername string) (user_types.User, user_types.DomainError) {
+	ret := _mock.Called(ctx, authUser, targetUsername)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnblockProfile")
+	}
+
+	var r0 user_types.User
+	var r1 user_types.DomainError
+	if returnFunc, ok := ret.Get(0).(func(context.Context, user_types.User, string) (user_types.User, user_types.DomainError)); ok {
+		return returnFunc(ctx, authUser, targetUsername)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, user_types.User, string) user_types.User); ok {
+		r0 = returnFunc(ctx, authUser, targetUsername)
+	} else {
+		r0 = ret.Get(0).(user_types.User)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, user_types.User, string) user_types.DomainError); ok {
+		r1 = returnFunc(ctx, authUser, targetUsername)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(user_types.DomainError)
+		}
+	}
+	return r0, r1
+}
+
+// MockUserService_UnblockProfile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UnblockProfile'
+type MockUserService_UnblockProfile_Call struct {
+	*mock.Call
+}
+
+// UnblockProfile is a helper method to define mock.On call
+//   - ctx context.Context
+//   - authUser user_types.User
+//   - targetUsername string
+func (_e *MockUserService_Expecter) UnblockProfile(ctx interface{}, authUser interface{}, targetUsername interface{}) *MockUserService_UnblockProfile_Call {
+	return &MockUserService_UnblockProfile_Call{Call: _e.mock.On("UnblockProfile", ctx, authUser, targetUsername)}
+}
+
+func (_c *MockUserService_UnblockProfile_Call) Run(run func(ctx context.Context, authUser user_types.User, targetUsername string)) *MockUserService_UnblockProfile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 user_types.User
+		if args[1] != nil {
+			arg1 = args[1].(user_types.User)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserService_UnblockProfile_Call) Return(user user_types.User, domainError user_types.DomainError) *MockUserService_UnblockProfile_Call {
+	_c.Call.Return(user, domainError)
+	return _c
+}
+
+func (_c *MockUserService_UnblockProfile_Call) RunAndReturn(run func(ctx context.Context, authUser user_types.User, targetUsername string) (user_types.User, user_types.DomainError)) *MockUserService_UnblockProfile_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UnfollowProfile provides a mock function for the type MockUserService
+func (_mock *MockUserService) UnfollowProfile(ctx context.Context, authUser user_types.User, targetUsername string) (user_types.User, user_types.DomainError) {
+	ret := _mock.Called(ctx, authUser, targetUsername)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnfollowProfile")
+	}
+
+	var r0 user_types.User
+	var r1 user_types.DomainError
+	if returnFunc, ok := ret.Get(0).(func(context.Context, user_types.User, string) (user_types.User, user_types.DomainError)); ok {
+		return returnFunc(ctx, authUser, targetUsername)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) bool); ok {
-		r0 = returnFunc(ctx, followedByUserId, followingUserId)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, user_types.User, string) user_types.User); ok {
+		r0 = returnFunc(ctx, authUser, targetUsername)
 	} else {
-		r0 = ret.Get(0).(bool)
+		r0 = ret.Get(0).(user_types.User)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
-		r1 = returnFunc(ctx, followedByUserId, followingUserId)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, user_types.User, string) user_types.DomainError); ok {
+		r1 = returnFunc(ctx, authUser, targetUsername)
 	} else {
-		r1 = ret.Error(1)
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(user_types.DomainError)
+		}
 	}
 	return r0, r1
 }
 
-// MockUserRepository_IsFollowing_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsFollowing'
-type MockUserRepository_IsFollowing_Call struct {
+// MockUserService_UnfollowProfile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UnfollowProfile'
+type MockUserService_UnfollowProfile_Call struct {
 	*mock.Call
 }
 
-// IsFollowing is a helper method to define mock.On call
+// UnfollowProfile is a helper method to define mock.On call
 //   - ctx context.Context
-//   - followedByUserId uuid.UUID
-//   - followingUserId uuid.UUID
-func (_e *MockUserRepository_Expecter) IsFollowing(ctx interface{}, followedByUserId interface{}, followingUserId interface{}) *MockUserRepository_IsFollowing_Call {
-	return &MockUserRepository_IsFollowing_Call{Call: _e.mock.On("IsFollowing", ctx, followedByUserId, followingUserId)}
+//   - authUser user_types.User
+//   - targetUsername string
+func (_e *MockUserService_Expecter) UnfollowProfile(ctx interface{}, authUser interface{}, targetUsername interface{}) *MockUserService_UnfollowProfile_Call {
+	return &MockUserService_UnfollowProfile_Call{Call: _e.mock.On("UnfollowProfile", ctx, authUser, targetUsername)}
 }
 
-func (_c *MockUserRepository_IsFollowing_Call) Run(run func(ctx context.Context, followedByUserId uuid.UUID, followingUserId uuid.UUID)) *MockUserRepository_IsFollowing_Call {
+func (_c *MockUserService_UnfollowProfile_Call) Run(run func(ctx context.Context, authUser user_types.User, targetUsername string)) *MockUserService_UnfollowProfile_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 uuid.UUID
+		var arg1 user_types.User
 		if args[1] != nil {
-			arg1 = args[1].(uuid.UUID)
+			arg1 = args[1].(user_types.User)
 		}
-		var arg2 uuid.UUID
+		var arg2 string
 		if args[2] != nil {
-			arg2 = args[2].(uuid.UUID)
+			arg2 = args[2].(string)
 		}
 		run(
 			arg0,
@@ -420,59 +2653,70 @@ func (_c *MockUserRepository_IsFollowing_Call) Run(run func(ctx context.Context,
 	return _c
 }
 
-func (_c *MockUserRepository_IsFollowing_Call) Return(b bool, err error) *MockUserRepository_IsFollowing_Call {
-	_c.Call.Return(b, err)
+func (_c *MockUserService_UnfollowProfile_Call) Return(user user_types.User, domainError user_types.DomainError) *MockUserService_UnfollowProfile_Call {
+	_c.Call.Return(user, domainError)
 	return _c
 }
 
-func (_c *MockUserRepository_IsFollowing_Call) RunAndReturn(run func(ctx context.Context, followedByUserId uuid.UUID, followingUserId uuid.UUID) (bool, error)) *MockUserRepository_IsFollowing_Call {
+func (_c *MockUserService_UnfollowProfile_Call) RunAndReturn(run func(ctx context.Context, authUser user_types.User, targetUsername string) (user_types.User, user_types.DomainError)) *MockUserService_UnfollowProfile_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Unfollow provides a mock function for the type MockUserRepository
-func (_mock *MockUserRepository) Unfollow(ctx context.Context, followedByUserId uuid.UUID, followingUserId uuid.UUID) error {
-	ret := _mock.Called(ctx, followedByUserId, followingUserId)
+// UnmuteProfile provides a mock function for the type MockUserService
+func (_mock *MockUserService) UnmuteProfile(ctx context.Context, authUser user_types.User, targetUsername string) (user_types.User, user_types.DomainError) {
+	ret := _mock.Called(ctx, authUser, targetUsername)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Unfollow")
+		panic("no return value specified for UnmuteProfile")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
-		r0 = returnFunc(ctx, followedByUserId, followingUserId)
+	var r0 user_types.User
+	var r1 user_types.DomainError
+	if returnFunc, ok := ret.Get(0).(func(context.Context, user_types.User, string) (user_types.User, user_types.DomainError)); ok {
+		return returnFunc(ctx, authUser, targetUsername)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, user_types.User, string) user_types.User); ok {
+		r0 = returnFunc(ctx, authUser, targetUsername)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(user_types.User)
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, user_types.User, string) user_types.DomainError); ok {
+		r1 = returnFunc(ctx, authUser, targetUsername)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(user_types.DomainError)
+		}
+	}
+	return r0, r1
 }
 
-// MockUserRepository_Unfollow_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Unfollow'
-type MockUserRepository_Unfollow_Call struct {
+// MockUserService_UnmuteProfile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UnmuteProfile'
+type MockUserService_UnmuteProfile_Call struct {
 	*mock.Call
 }
 
-// Unfollow is a helper method to define mock.On call
+// UnmuteProfile is a helper method to define mock.On call
 //   - ctx context.Context
-//   - followedByUserId uuid.UUID
-//   - followingUserId uuid.UUID
-func (_e *MockUserRepository_Expecter) Unfollow(ctx interface{}, followedByUserId interface{}, followingUserId interface{}) *MockUserRepository_Unfollow_Call {
-	return &MockUserRepository_Unfollow_Call{Call: _e.mock.On("Unfollow", ctx, followedByUserId, followingUserId)}
+//   - authUser user_types.User
+//   - targetUsername string
+func (_e *MockUserService_Expecter) UnmuteProfile(ctx interface{}, authUser interface{}, targetUsername interface{}) *MockUserService_UnmuteProfile_Call {
+	return &MockUserService_UnmuteProfile_Call{Call: _e.mock.On("UnmuteProfile", ctx, authUser, targetUsername)}
 }
 
-func (_c *MockUserRepository_Unfollow_Call) Run(run func(ctx context.Context, followedByUserId uuid.UUID, followingUserId uuid.UUID)) *MockUserRepository_Unfollow_Call {
+func (_c *MockUserService_UnmuteProfile_Call) Run(run func(ctx context.Context, authUser user_types.User, targetUsername string)) *MockUserService_UnmuteProfile_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 uuid.UUID
+		var arg1 user_types.User
 		if args[1] != nil {
-			arg1 = args[1].(uuid.UUID)
+			arg1 = args[1].(user_types.User)
 		}
-		var arg2 uuid.UUID
+		var arg2 string
 		if args[2] != nil {
-			arg2 = args[2].(uuid.UUID)
+			arg2 = args[2].(string)
 		}
 		run(
 			arg0,
@@ -483,78 +2727,92 @@ func (_c *MockUserRepository_Unfollow_Call) Run(run func(ctx context.Context, fo
 	return _c
 }
 
-func (_c *MockUserRepository_Unfollow_Call) Return(err error) *MockUserRepository_Unfollow_Call {
-	_c.Call.Return(err)
+func (_c *MockUserService_UnmuteProfile_Call) Return(user user_types.User, domainError user_types.DomainError) *MockUserService_UnmuteProfile_Call {
+	_c.Call.Return(user, domainError)
 	return _c
 }
 
-func (_c *MockUserRepository_Unfollow_Call) RunAndReturn(run func(ctx context.Context, followedByUserId uuid.UUID, followingUserId uuid.UUID) error) *MockUserRepository_Unfollow_Call {
+func (_c *MockUserService_UnmuteProfile_Call) RunAndReturn(run func(ctx context.Context, authUser user_types.User, targetUsername string) (user_types.User, user_types.DomainError)) *MockUserService_UnmuteProfile_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UpsertUser provides a mock function for the type MockUserRepository
-func (_mock *MockUserRepository) UpsertUser(ctx context.Context, user user_types.User) (user_types.User, error) {
-	ret := _mock.Called(ctx, user)
+// UpdateUser provides a mock function for the type MockUserService
+func (_mock *MockUserService) UpdateUser(ctx context.Context, id uuid.UUID, updated user_types.UpsertUserParams, expectedVersion mo.Option[int64]) (user_types.User, user_types.DomainError) {
+	ret := _mock.Called(ctx, id, updated, expectedVersion)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpsertUser")
+		panic("no return value specified for UpdateUser")
 	}
 
 	var r0 user_types.User
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, user_types.User) (user_types.User, error)); ok {
-		return returnFunc(ctx, user)
+	var r1 user_types.DomainError
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, user_types.UpsertUserParams, mo.Option[int64]) (user_types.User, user_types.DomainError)); ok {
+		return returnFunc(ctx, id, updated, expectedVersion)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, user_types.User) user_types.User); ok {
-		r0 = returnFunc(ctx, user)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, user_types.UpsertUserParams, mo.Option[int64]) user_types.User); ok {
+		r0 = returnFunc(ctx, id, updated, expectedVersion)
 	} else {
 		r0 = ret.Get(0).(user_types.User)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, user_types.User) error); ok {
-		r1 = returnFunc(ctx, user)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, user_types.UpsertUserParams, mo.Option[int64]) user_types.DomainError); ok {
+		r1 = returnFunc(ctx, id, updated, expectedVersion)
 	} else {
-		r1 = ret.Error(1)
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(user_types.DomainError)
+		}
 	}
 	return r0, r1
 }
 
-// MockUserRepository_UpsertUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpsertUser'
-type MockUserRepository_UpsertUser_Call struct {
+// MockUserService_UpdateUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateUser'
+type MockUserService_UpdateUser_Call struct {
 	*mock.Call
 }
 
-// UpsertUser is a helper method to define mock.On call
+// UpdateUser is a helper method to define mock.On call
 //   - ctx context.Context
-//   - user user_types.User
-func (_e *MockUserRepository_Expecter) UpsertUser(ctx interface{}, user interface{}) *MockUserRepository_UpsertUser_Call {
-	return &MockUserRepository_UpsertUser_Call{Call: _e.mock.On("UpsertUser", ctx, user)}
+//   - id uuid.UUID
+//   - updated user_types.UpsertUserParams
+//   - expectedVersion mo.Option[int64]
+func (_e *MockUserService_Expecter) UpdateUser(ctx interface{}, id interface{}, updated interface{}, expectedVersion interface{}) *MockUserService_UpdateUser_Call {
+	return &MockUserService_UpdateUser_Call{Call: _e.mock.On("UpdateUser", ctx, id, updated, expectedVersion)}
 }
 
-func (_c *MockUserRepository_UpsertUser_Call) Run(run func(ctx context.Context, user user_types.User)) *MockUserRepository_UpsertUser_Call {
+func (_c *MockUserService_UpdateUser_Call) Run(run func(ctx context.Context, id uuid.UUID, updated user_types.UpsertUserParams, expectedVersion mo.Option[int64])) *MockUserService_UpdateUser_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 user_types.User
+		var arg1 uuid.UUID
 		if args[1] != nil {
-			arg1 = args[1].(user_types.User)
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 user_types.UpsertUserParams
+		if args[2] != nil {
+			arg2 = args[2].(user_types.UpsertUserParams)
+		}
+		var arg3 mo.Option[int64]
+		if args[3] != nil {
+			arg3 = args[3].(mo.Option[int64])
 		}
 		run(
 			arg0,
 			arg1,
+			arg2,
+			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *MockUserRepository_UpsertUser_Call) Return(user1 user_types.User, err error) *MockUserRepository_UpsertUser_Call {
-	_c.Call.Return(user1, err)
+func (_c *MockUserService_UpdateUser_Call) Return(user user_types.User, domainError user_types.DomainError) *MockUserService_UpdateUser_Call {
+	_c.Call.Return(user, domainError)
 	return _c
 }
 
-func (_c *MockUserRepository_UpsertUser_Call) RunAndReturn(run func(ctx context.Context, user user_types.User) (user_types.User, error)) *MockUserRepository_UpsertUser_Call {
+func (_c *MockUserService_UpdateUser_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID, updated user_types.UpsertUserParams, expectedVersion mo.Option[int64]) (user_types.User, user_types.DomainError)) *MockUserService_UpdateUser_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -586,6 +2844,65 @@ func (_m *MockUserValidations) EXPECT() *MockUserValidations_Expecter {
 	return &MockUserValidations_Expecter{mock: &_m.Mock}
 }
 
+// ValidateCanBlockOrMute provides a mock function for the type MockUserValidations
+func (_mock *MockUserValidations) ValidateCanBlockOrMute(actingUserId uuid.UUID, targetUserId uuid.UUID) user_types.DomainError {
+	ret := _mock.Called(actingUserId, targetUserId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ValidateCanBlockOrMute")
+	}
+
+	var r0 user_types.DomainError
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID, uuid.UUID) user_types.DomainError); ok {
+		r0 = returnFunc(actingUserId, targetUserId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(user_types.DomainError)
+		}
+	}
+	return r0
+}
+
+// MockUserValidations_ValidateCanBlockOrMute_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ValidateCanBlockOrMute'
+type MockUserValidations_ValidateCanBlockOrMute_Call struct {
+	*mock.Call
+}
+
+// ValidateCanBlockOrMute is a helper method to define mock.On call
+//   - actingUserId uuid.UUID
+//   - targetUserId uuid.UUID
+func (_e *MockUserValidations_Expecter) ValidateCanBlockOrMute(actingUserId interface{}, targetUserId interface{}) *MockUserValidations_ValidateCanBlockOrMute_Call {
+	return &MockUserValidations_ValidateCanBlockOrMute_Call{Call: _e.mock.On("ValidateCanBlockOrMute", actingUserId, targetUserId)}
+}
+
+func (_c *MockUserValidations_ValidateCanBlockOrMute_Call) Run(run func(actingUserId uuid.UUID, targetUserId uuid.UUID)) *MockUserValidations_ValidateCanBlockOrMute_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 uuid.UUID
+		if args[0] != nil {
+			arg0 = args[0].(uuid.UUID)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserValidations_ValidateCanBlockOrMute_Call) Return(domainError user_types.DomainError) *MockUserValidations_ValidateCanBlockOrMute_Call {
+	_c.Call.Return(domainError)
+	return _c
+}
+
+func (_c *MockUserValidations_ValidateCanBlockOrMute_Call) RunAndReturn(run func(actingUserId uuid.UUID, targetUserId uuid.UUID) user_types.DomainError) *MockUserValidations_ValidateCanBlockOrMute_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ValidateCanFollow provides a mock function for the type MockUserValidations
 func (_mock *MockUserValidations) ValidateCanFollow(followedByUserId uuid.UUID, followingUserId uuid.UUID) user_types.DomainError {
 	ret := _mock.Called(followedByUserId, followingUserId)
@@ -704,6 +3021,71 @@ func (_c *MockUserValidations_ValidateEmailDoesNotConflict_Call) RunAndReturn(ru
 	return _c
 }
 
+// ValidateNotBlocked provides a mock function for the type MockUserValidations
+func (_mock *MockUserValidations) ValidateNotBlocked(ctx context.Context, userIdA uuid.UUID, userIdB uuid.UUID) user_types.DomainError {
+	ret := _mock.Called(ctx, userIdA, userIdB)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ValidateNotBlocked")
+	}
+
+	var r0 user_types.DomainError
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) user_types.DomainError); ok {
+		r0 = returnFunc(ctx, userIdA, userIdB)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(user_types.DomainError)
+		}
+	}
+	return r0
+}
+
+// MockUserValidations_ValidateNotBlocked_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ValidateNotBlocked'
+type MockUserValidations_ValidateNotBlocked_Call struct {
+	*mock.Call
+}
+
+// ValidateNotBlocked is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userIdA uuid.UUID
+//   - userIdB uuid.UUID
+func (_e *MockUserValidations_Expecter) ValidateNotBlocked(ctx interface{}, userIdA interface{}, userIdB interface{}) *MockUserValidations_ValidateNotBlocked_Call {
+	return &MockUserValidations_ValidateNotBlocked_Call{Call: _e.mock.On("ValidateNotBlocked", ctx, userIdA, userIdB)}
+}
+
+func (_c *MockUserValidations_ValidateNotBlocked_Call) Run(run func(ctx context.Context, userIdA uuid.UUID, userIdB uuid.UUID)) *MockUserValidations_ValidateNotBlocked_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserValidations_ValidateNotBlocked_Call) Return(domainError user_types.DomainError) *MockUserValidations_ValidateNotBlocked_Call {
+	_c.Call.Return(domainError)
+	return _c
+}
+
+func (_c *MockUserValidations_ValidateNotBlocked_Call) RunAndReturn(run func(ctx context.Context, userIdA uuid.UUID, userIdB uuid.UUID) user_types.DomainError) *MockUserValidations_ValidateNotBlocked_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ValidateUser provides a mock function for the type MockUserValidations
 func (_mock *MockUserValidations) ValidateUser(user user_types.User) user_types.DomainError {
 	ret := _mock.Called(user)
@@ -885,8 +3267,8 @@ func (_c *MockUserValidations_ValidateUsernameDoesNotConflict_Call) RunAndReturn
 }
 
 // ValidateUsernameExists provides a mock function for the type MockUserValidations
-func (_mock *MockUserValidations) ValidateUsernameExists(ctx context.Context, email string) (user_types.User, user_types.DomainError) {
-	ret := _mock.Called(ctx, email)
+func (_mock *MockUserValidations) ValidateUsernameExists(ctx context.Context, username string) (user_types.User, user_types.DomainError) {
+	ret := _mock.Called(ctx, username)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ValidateUsernameExists")
@@ -895,15 +3277,15 @@ func (_mock *MockUserValidations) ValidateUsernameExists(ctx context.Context, em
 	var r0 user_types.User
 	var r1 user_types.DomainError
 	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (user_types.User, user_types.DomainError)); ok {
-		return returnFunc(ctx, email)
+		return returnFunc(ctx, username)
 	}
 	if returnFunc, ok := ret.Get(0).(func(context.Context, string) user_types.User); ok {
-		r0 = returnFunc(ctx, email)
+		r0 = returnFunc(ctx, username)
 	} else {
 		r0 = ret.Get(0).(user_types.User)
 	}
 	if returnFunc, ok := ret.Get(1).(func(context.Context, string) user_types.DomainError); ok {
-		r1 = returnFunc(ctx, email)
+		r1 = returnFunc(ctx, username)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(user_types.DomainError)
@@ -919,12 +3301,12 @@ type MockUserValidations_ValidateUsernameExists_Call struct {
 
 // ValidateUsernameExists is a helper method to define mock.On call
 //   - ctx context.Context
-//   - email string
-func (_e *MockUserValidations_Expecter) ValidateUsernameExists(ctx interface{}, email interface{}) *MockUserValidations_ValidateUsernameExists_Call {
-	return &MockUserValidations_ValidateUsernameExists_Call{Call: _e.mock.On("ValidateUsernameExists", ctx, email)}
+//   - username string
+func (_e *MockUserValidations_Expecter) ValidateUsernameExists(ctx interface{}, username interface{}) *MockUserValidations_ValidateUsernameExists_Call {
+	return &MockUserValidations_ValidateUsernameExists_Call{Call: _e.mock.On("ValidateUsernameExists", ctx, username)}
 }
 
-func (_c *MockUserValidations_ValidateUsernameExists_Call) Run(run func(ctx context.Context, email string)) *MockUserValidations_ValidateUsernameExists_Call {
+func (_c *MockUserValidations_ValidateUsernameExists_Call) Run(run func(ctx context.Context, username string)) *MockUserValidations_ValidateUsernameExists_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -947,7 +3329,72 @@ func (_c *MockUserValidations_ValidateUsernameExists_Call) Return(user user_type
 	return _c
 }
 
-func (_c *MockUserValidations_ValidateUsernameExists_Call) RunAndReturn(run func(ctx context.Context, email string) (user_types.User, user_types.DomainError)) *MockUserValidations_ValidateUsernameExists_Call {
+func (_c *MockUserValidations_ValidateUsernameExists_Call) RunAndReturn(run func(ctx context.Context, username string) (user_types.User, user_types.DomainError)) *MockUserValidations_ValidateUsernameExists_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ValidateUsernameNotReserved provides a mock function for the type MockUserValidations
+func (_mock *MockUserValidations) ValidateUsernameNotReserved(ctx context.Context, username string, claimantUserId uuid.UUID) user_types.DomainError {
+	ret := _mock.Called(ctx, username, claimantUserId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ValidateUsernameNotReserved")
+	}
+
+	var r0 user_types.DomainError
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, uuid.UUID) user_types.DomainError); ok {
+		r0 = returnFunc(ctx, username, claimantUserId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(user_types.DomainError)
+		}
+	}
+	return r0
+}
+
+// MockUserValidations_ValidateUsernameNotReserved_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ValidateUsernameNotReserved'
+type MockUserValidations_ValidateUsernameNotReserved_Call struct {
+	*mock.Call
+}
+
+// ValidateUsernameNotReserved is a helper method to define mock.On call
+//   - ctx context.Context
+//   - username string
+//   - claimantUserId uuid.UUID
+func (_e *MockUserValidations_Expecter) ValidateUsernameNotReserved(ctx interface{}, username interface{}, claimantUserId interface{}) *MockUserValidations_ValidateUsernameNotReserved_Call {
+	return &MockUserValidations_ValidateUsernameNotReserved_Call{Call: _e.mock.On("ValidateUsernameNotReserved", ctx, username, claimantUserId)}
+}
+
+func (_c *MockUserValidations_ValidateUsernameNotReserved_Call) Run(run func(ctx context.Context, username string, claimantUserId uuid.UUID)) *MockUserValidations_ValidateUsernameNotReserved_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserValidations_ValidateUsernameNotReserved_Call) Return(domainError user_types.DomainError) *MockUserValidations_ValidateUsernameNotReserved_Call {
+	_c.Call.Return(domainError)
+	return _c
+}
+
+func (_c *MockUserValidations_ValidateUsernameNotReserved_Call) RunAndReturn(run func(ctx context.Context, username string, claimantUserId uuid.UUID) user_types.DomainError) *MockUserValidations_ValidateUsernameNotReserved_Call {
 	_c.Call.Return(run)
 	return _c
 }
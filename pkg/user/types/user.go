@@ -13,4 +13,7 @@ type User struct {
 	Image           mo.Option[string]
 	CreatedAtMillis int64 `validate:"required"`
 	UpdatedAtMillis int64 `validate:"required"`
+	// Version is incremented on every update. When upserting an existing user it must be the version being
+	// updated, otherwise the update is rejected with a VersionConflictError
+	Version int64
 }
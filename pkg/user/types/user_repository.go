@@ -2,6 +2,7 @@ package user_types
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/samber/mo"
@@ -9,12 +10,43 @@ import (
 
 //mockery:generate: true
 type UserRepository interface {
+	// UpsertUser inserts the user, or updates it if user.Version matches the stored version,
+	// returning a VersionConflictError otherwise. A change of username records the previous one in the history
 	UpsertUser(ctx context.Context, user User) (User, error)
+	// DeleteUser soft deletes the user, it is excluded from all reads until restored or purged
 	DeleteUser(ctx context.Context, id uuid.UUID) error
+	// GetDeletedUserById returns the user if it was soft deleted after deletedAfter
+	GetDeletedUserById(ctx context.Context, id uuid.UUID, deletedAfter time.Time) (mo.Option[User], error)
+	RestoreUser(ctx context.Context, id uuid.UUID) error
+	// PurgeDeletedUsers hard deletes users soft deleted at or before deletedBefore, returning the number purged
+	PurgeDeletedUsers(ctx context.Context, deletedBefore time.Time) (int64, error)
 	GetUserByUsername(ctx context.Context, username string) (mo.Option[User], error)
+	// GetUserByPreviousUsername returns the active user that most recently renamed away from username
+	GetUserByPreviousUsername(ctx context.Context, username string) (mo.Option[User], error)
+	// IsUsernameReserved returns true if a user other than the claimant renamed away from username after changedAfter
+	IsUsernameReserved(ctx context.Context, username string, claimantUserId uuid.UUID, changedAfter time.Time) (bool, error)
 	GetUserById(ctx context.Context, id uuid.UUID) (mo.Option[User], error)
+	// GetUsersByIds returns the active users with the given ids in a single query, ids that are not found are omitted
+	GetUsersByIds(ctx context.Context, ids []uuid.UUID) ([]User, error)
 	GetUserByEmail(ctx context.Context, email string) (mo.Option[User], error)
 	IsFollowing(ctx context.Context, followedByUserId uuid.UUID, followingUserId uuid.UUID) (bool, error)
+	// AreFollowing returns, in a single query, whether followedByUserId follows each of followingUserIds. Every id is
+	// present in the result
+	AreFollowing(ctx context.Context, followedByUserId uuid.UUID, followingUserIds []uuid.UUID) (map[uuid.UUID]bool, error)
+	// Follow is a no-op if the follow already exists, and fails with a CannotFollowBlockedUserError if either user has
+	// blocked the other
 	Follow(ctx context.Context, followedByUserId uuid.UUID, followingUserId uuid.UUID) error
 	Unfollow(ctx context.Context, followedByUserId uuid.UUID, followingUserId uuid.UUID) error
+	// Block records the block and removes any follows between the two users, in both directions
+	Block(ctx context.Context, blockedByUserId uuid.UUID, blockedUserId uuid.UUID) error
+	Unblock(ctx context.Context, blockedByUserId uuid.UUID, blockedUserId uuid.UUID) error
+	IsBlocking(ctx context.Context, blockedByUserId uuid.UUID, blockedUserId uuid.UUID) (bool, error)
+	// IsBlockedEitherWay returns true if either user has blocked the other
+	IsBlockedEitherWay(ctx context.Context, userIdA uuid.UUID, userIdB uuid.UUID) (bool, error)
+	Mute(ctx context.Context, mutedByUserId uuid.UUID, mutedUserId uuid.UUID) error
+	Unmute(ctx context.Context, mutedByUserId uuid.UUID, mutedUserId uuid.UUID) error
+	IsMuting(ctx context.Context, mutedByUserId uuid.UUID, mutedUserId uuid.UUID) (bool, error)
+	// ListHiddenUserIds returns the ids of users whose content should be hidden from the viewer, i.e. users
+	// the viewer has blocked or muted
+	ListHiddenUserIds(ctx context.Context, viewerUserId uuid.UUID) ([]uuid.UUID, error)
 }
@@ -7,15 +7,35 @@ import (
 	"github.com/samber/mo"
 )
 
+//mockery:generate: true
 type UserService interface {
 	CreateUser(ctx context.Context, user UpsertUserParams) (User, DomainError)
-	UpdateUser(ctx context.Context, id uuid.UUID, updated UpsertUserParams) (User, DomainError)
+	// UpdateUser fails with a VersionConflictError if expectedVersion is set and the user has since been modified. When
+	// it is not set, the update is still rejected if the user is modified between being read and written
+	UpdateUser(ctx context.Context, id uuid.UUID, updated UpsertUserParams, expectedVersion mo.Option[int64]) (User, DomainError)
+	// DeleteUser soft deletes the user, it can be restored until the grace period expires and it is purged
 	DeleteUser(ctx context.Context, id uuid.UUID) DomainError
+	RestoreUser(ctx context.Context, id uuid.UUID) (User, DomainError)
 	GetUserByEmail(ctx context.Context, email string) (User, DomainError)
 	GetUserByUsername(ctx context.Context, username string) (User, DomainError)
+	// ResolveUsername returns the user with the username, or the user that has since renamed away from it. Callers can
+	// compare the returned user's Username to detect an old name
+	ResolveUsername(ctx context.Context, username string) (User, DomainError)
+	// GetUsersByIds returns the users keyed by id, ids that are not found are omitted rather than being an error
+	GetUsersByIds(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]User, DomainError)
 	IsFollowing(ctx context.Context, authUser User, targetUsername string) (bool, DomainError)
+	// AreFollowing returns whether the auth user follows each of the target users, every id is present in the result
+	AreFollowing(ctx context.Context, authUser User, targetUserIds []uuid.UUID) (map[uuid.UUID]bool, DomainError)
 	FollowProfile(ctx context.Context, authUser User, targetUsername string) (User, DomainError)
 	UnfollowProfile(ctx context.Context, authUser User, targetUsername string) (User, DomainError)
+	// BlockProfile blocks the target user, removing follows in both directions and preventing either user from following the other
+	BlockProfile(ctx context.Context, authUser User, targetUsername string) (User, DomainError)
+	UnblockProfile(ctx context.Context, authUser User, targetUsername string) (User, DomainError)
+	// MuteProfile hides the target user's content from the auth user, without affecting follows
+	MuteProfile(ctx context.Context, authUser User, targetUsername string) (User, DomainError)
+	UnmuteProfile(ctx context.Context, authUser User, targetUsername string) (User, DomainError)
+	// GetHiddenUserIds returns the ids of users whose content, e.g. feed articles and comments, must be filtered out for the viewer
+	GetHiddenUserIds(ctx context.Context, viewer User) ([]uuid.UUID, DomainError)
 }
 
 type UpsertUserParams struct {
@@ -10,8 +10,13 @@ import (
 type UserValidations interface {
 	ValidateUser(user User) DomainError
 	ValidateUsernameDoesNotConflict(ctx context.Context, username string) DomainError
+	// ValidateUsernameNotReserved fails if another user renamed away from username within the release cooldown
+	ValidateUsernameNotReserved(ctx context.Context, username string, claimantUserId uuid.UUID) DomainError
 	ValidateEmailDoesNotConflict(ctx context.Context, email string) DomainError
 	ValidateUserIdExists(ctx context.Context, id uuid.UUID) (User, DomainError)
-	ValidateUsernameExists(ctx context.Context, email string) (User, DomainError)
+	// ValidateUsernameExists returns the user with the username, or the user that most recently renamed away from it
+	ValidateUsernameExists(ctx context.Context, username string) (User, DomainError)
 	ValidateCanFollow(followedByUserId, followingUserId uuid.UUID) DomainError
+	ValidateNotBlocked(ctx context.Context, userIdA, userIdB uuid.UUID) DomainError
+	ValidateCanBlockOrMute(actingUserId, targetUserId uuid.UUID) DomainError
 }
@@ -9,11 +9,20 @@ import (
 )
 
 func NewUserModule() fx.Option {
+	return newUserModule(internal.NewUserRepository)
+}
+
+// NewInMemoryUserModule is NewUserModule with the repository kept in a RealWorldAppInMemoryDb
+func NewInMemoryUserModule() fx.Option {
+	return newUserModule(internal.NewInMemoryUserRepository)
+}
+
+func newUserModule(repositoryConstructor any) fx.Option {
 	return util.NewFxModule[user_types.UserService](
 		"user_service",
 		internal.NewUserServiceImpl,
 		fx.Provide(
-			internal.NewPostgresUserRepository,
+			repositoryConstructor,
 			internal.NewUserValidationsImpl,
 		),
 	)
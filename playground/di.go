@@ -8,14 +8,24 @@ import (
 	"github.com/nimaeskandary/go-realworld/cmd/http_server/app/http_handler"
 	"github.com/nimaeskandary/go-realworld/pkg/article"
 	article_types "github.com/nimaeskandary/go-realworld/pkg/article/types"
+	"github.com/nimaeskandary/go-realworld/pkg/audit"
+	audit_types "github.com/nimaeskandary/go-realworld/pkg/audit/types"
 	"github.com/nimaeskandary/go-realworld/pkg/auth"
 	auth_types "github.com/nimaeskandary/go-realworld/pkg/auth/types"
+	"github.com/nimaeskandary/go-realworld/pkg/blob_store"
+	blob_store_types "github.com/nimaeskandary/go-realworld/pkg/blob_store/types"
 	"github.com/nimaeskandary/go-realworld/pkg/config"
 	config_types "github.com/nimaeskandary/go-realworld/pkg/config/types"
+	"github.com/nimaeskandary/go-realworld/pkg/data_export"
 	"github.com/nimaeskandary/go-realworld/pkg/database"
 	db_types "github.com/nimaeskandary/go-realworld/pkg/database/types"
+	"github.com/nimaeskandary/go-realworld/pkg/media"
+	media_types "github.com/nimaeskandary/go-realworld/pkg/media/types"
 	obs "github.com/nimaeskandary/go-realworld/pkg/observability"
 	obs_types "github.com/nimaeskandary/go-realworld/pkg/observability/types"
+	"github.com/nimaeskandary/go-realworld/pkg/outbox"
+	outbox_types "github.com/nimaeskandary/go-realworld/pkg/outbox/types"
+	soft_delete_types "github.com/nimaeskandary/go-realworld/pkg/soft_delete/types"
 	"github.com/nimaeskandary/go-realworld/pkg/user"
 	user_types "github.com/nimaeskandary/go-realworld/pkg/user/types"
 	"github.com/nimaeskandary/go-realworld/pkg/util"
@@ -24,30 +34,50 @@ import (
 )
 
 type Config struct {
-	JwtAuthService auth_types.JwtAuthServiceConfig `json:"jwt_auth_service" validate:"required"`
-	Slog           obs_types.SlogLoggerConfig      `json:"slog" validate:"required"`
-	RealWorldAppDb db_types.RealWorldAppDbConfig   `json:"realworld_app_db" validate:"required"`
+	JwtAuthService auth_types.JwtAuthServiceConfig       `json:"jwt_auth_service" validate:"required"`
+	Slog           obs_types.SlogLoggerConfig            `json:"slog" validate:"required"`
+	RealWorldAppDb db_types.RealWorldAppDbConfig         `json:"realworld_app_db" validate:"required"`
+	SoftDelete     soft_delete_types.SoftDeleteConfig    `json:"soft_delete" validate:"required"`
+	User           user_types.UserConfig                 `json:"user" validate:"required"`
+	LocalBlobStore blob_store_types.LocalBlobStoreConfig `json:"local_blob_store" validate:"required"`
+	Media          media_types.MediaConfig               `json:"media" validate:"required"`
 }
 
 type StandardSystem struct {
 	ArticleRepo    article_types.ArticleRepository
 	ArticleService article_types.ArticleService
+	AuditLog       audit_types.AuditLog
 	AuthService    auth_types.AuthService
+	Outbox         outbox_types.Outbox
+	TxManager      db_types.TxManager
 	UserRepo       user_types.UserRepository
 	UserService    user_types.UserService
 }
 
 func SetupStandardSystem(ctx context.Context, cm util.CleanupManager) StandardSystem {
+	return setupSystem(ctx, cm, ModuleList)
+}
+
+// SetupInMemorySystem is SetupStandardSystem with repositories kept in memory, nothing is read from or written to the
+// local database, and everything is lost when the system stops
+func SetupInMemorySystem(ctx context.Context, cm util.CleanupManager) StandardSystem {
+	return setupSystem(ctx, cm, InMemoryModuleList)
+}
+
+func setupSystem(ctx context.Context, cm util.CleanupManager, moduleList func(configData []byte) []fx.Option) StandardSystem {
 	configData, err := os.ReadFile("config/local.yaml")
 	if err != nil {
 		log.Fatalf("failed to read config file %v", err)
 	}
 
 	s := StandardSystem{}
-	app := util.CreateFxAppAndExtract(ModuleList(configData),
+	app := util.CreateFxAppAndExtract(moduleList(configData),
 		&s.ArticleRepo,
 		&s.ArticleService,
+		&s.AuditLog,
 		&s.AuthService,
+		&s.Outbox,
+		&s.TxManager,
 		&s.UserRepo,
 		&s.UserService,
 	)
@@ -69,6 +99,33 @@ func SetupStandardSystem(ctx context.Context, cm util.CleanupManager) StandardSy
 }
 
 func ModuleList(configData []byte) []fx.Option {
+	return append(
+		commonModuleList(configData),
+		database.NewRealworldAppDbModule[db_types.RealWorldAppDb](),
+		database.NewRealworldAppTxManagerModule(),
+		user.NewUserModule(),
+		article.NewArticleModule(),
+		audit.NewAuditModule(),
+		data_export.NewDataExportModule(),
+		outbox.NewOutboxModule(),
+	)
+}
+
+func InMemoryModuleList(configData []byte) []fx.Option {
+	return append(
+		commonModuleList(configData),
+		database.NewRealworldAppInMemoryDbModule(),
+		database.NewRealworldAppInMemoryTxManagerModule(),
+		user.NewInMemoryUserModule(),
+		article.NewInMemoryArticleModule(),
+		audit.NewInMemoryAuditModule(),
+		data_export.NewInMemoryDataExportModule(),
+		outbox.NewInMemoryOutboxModule(),
+	)
+}
+
+// commonModuleList are the modules that do not depend on how repositories are stored
+func commonModuleList(configData []byte) []fx.Option {
 	return []fx.Option{
 		config.NewIdentitySecretParserModule(),
 		config.NewYamlConfigLoaderModule[Config](configData),
@@ -82,12 +139,23 @@ func ModuleList(configData []byte) []fx.Option {
 			func(cfg config_types.ConfigLoader[Config]) db_types.RealWorldAppDbConfig {
 				return cfg.GetConfig().RealWorldAppDb
 			},
+			func(cfg config_types.ConfigLoader[Config]) soft_delete_types.SoftDeleteConfig {
+				return cfg.GetConfig().SoftDelete
+			},
+			func(cfg config_types.ConfigLoader[Config]) user_types.UserConfig {
+				return cfg.GetConfig().User
+			},
+			func(cfg config_types.ConfigLoader[Config]) blob_store_types.LocalBlobStoreConfig {
+				return cfg.GetConfig().LocalBlobStore
+			},
+			func(cfg config_types.ConfigLoader[Config]) media_types.MediaConfig {
+				return cfg.GetConfig().Media
+			},
 		),
-		database.NewPostgresRealworldAppDbModule[db_types.PostgresRealWorldAppDb](),
 		http_handler.NewHttpHandlerModule(),
 		auth.NewAuthModule(),
-		user.NewUserModule(),
-		article.NewArticleModule(),
+		blob_store.NewLocalBlobStoreModule(),
+		media.NewMediaModule(),
 		obs.NewSlogLoggerModule(),
 	}
 }